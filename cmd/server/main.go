@@ -15,12 +15,17 @@ import (
 
 	"pay-your-dues/internal/config"
 	"pay-your-dues/internal/database"
+	"pay-your-dues/internal/domain/entities"
 	"pay-your-dues/internal/handlers"
 	"pay-your-dues/internal/middleware"
 	"pay-your-dues/internal/repository"
 	"pay-your-dues/internal/services"
+	"pay-your-dues/internal/workers"
 )
 
+// shutdownTimeout bounds how long in-flight requests and background workers get to finish
+const shutdownTimeout = 30 * time.Second
+
 func main() {
 	// Initialize logger with structured format
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
@@ -49,7 +54,7 @@ func main() {
 	logger.Info().Str("log_level", level.String()).Msg("Logger initialized")
 
 	// Initialize database with GORM
-	db, err := database.NewDatabase(cfg.GetDSN())
+	db, err := database.NewDatabase(cfg.GetDSN(), database.NewQueryLogger(logger, cfg.DBLogQueries, cfg.DBSlowQueryThreshold))
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to connect to database")
 	}
@@ -61,15 +66,41 @@ func main() {
 
 	logger.Info().Msg("Database connected successfully")
 
+	if cfg.NormalizeCurrencyCodes {
+		updated, err := database.NormalizeLegacyCurrency(db.DB)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to normalize currency codes")
+		}
+		logger.Info().Int64("rows_updated", updated).Msg("Currency codes normalized")
+	}
+
+	if cfg.CaseInsensitiveEmails {
+		updated, err := database.NormalizeEmails(db.DB)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to normalize emails")
+		}
+		logger.Info().Int64("rows_updated", updated).Msg("Emails normalized")
+	}
+
 	// Initialize repositories
-	userRepo := repository.NewUserRepositoryGORM(db.DB)
-	contactRepo := repository.NewContactRepositoryGORM(db.DB)
-	debtListRepo := repository.NewDebtListRepositoryGORM(db.DB, contactRepo)
+	userRepo := repository.NewUserRepositoryGORM(db.DB, repository.WithUserCaseInsensitiveEmails(cfg.CaseInsensitiveEmails))
+	contactRepo := repository.NewContactRepositoryGORM(db.DB, repository.WithContactCaseInsensitiveEmails(cfg.CaseInsensitiveEmails))
+	debtListRepo := repository.NewDebtListRepositoryGORM(db.DB, contactRepo, repository.WithDebtListContactNameSource(cfg.ContactNameSource))
 	debtItemRepo := repository.NewDebtItemRepositoryGORM(db.DB)
+	userSettingsRepo := repository.NewUserSettingsRepositoryGORM(db.DB)
+	debtProposalRepo := repository.NewDebtProposalRepositoryGORM(db.DB)
+	apiKeyRepo := repository.NewAPIKeyRepositoryGORM(db.DB)
+	pendingDeletionRepo := repository.NewPendingDeletionRepositoryGORM(db.DB)
 
 	// Initialize services with dependency injection
 	paymentScheduleService := services.NewPaymentScheduleService()
-	contactService := services.NewContactService(contactRepo, userRepo)
+	contactService := services.NewContactService(contactRepo, userRepo,
+		services.WithReciprocalContacts(cfg.EnableReciprocalContacts),
+		services.WithContactNameSource(cfg.ContactNameSource),
+		services.WithContactDeletionPolicy(cfg.ContactDeletionPolicy),
+		services.WithContactDebtListRepository(debtListRepo),
+	)
+	userSettingsService := services.NewUserSettingsService(userSettingsRepo)
 	
 	// Initialize S3 service for file storage
 	s3Service, err := services.NewS3Service(cfg, logger)
@@ -77,18 +108,49 @@ func main() {
 		logger.Fatal().Err(err).Msg("Failed to initialize S3 service")
 	}
 	
-	debtService := services.NewDebtService(debtListRepo, debtItemRepo, contactRepo, paymentScheduleService, s3Service)
+	receiptDeletionService := services.NewReceiptDeletionService(pendingDeletionRepo, s3Service, cfg.ReceiptDeletionBackoff, cfg.ReceiptDeletionMaxAttempts, logger)
+
+	debtServiceOptions := []services.DebtServiceOption{
+		services.WithUserSettingsRepository(userSettingsRepo),
+		services.WithFuturePaymentWindow(cfg.PaymentDateFutureWindow),
+		services.WithNudgeInterval(cfg.PaymentNudgeInterval),
+		services.WithDefaultDueDateOffset(cfg.DefaultDueDateOffset),
+		services.WithMaxNumberOfPayments(cfg.MaxNumberOfPayments),
+		services.WithMaxTotalAmount(cfg.MaxTotalAmount),
+		services.WithSoftDeletePayments(cfg.SoftDeletePayments),
+		services.WithStrictScheduleValidation(cfg.StrictScheduleValidation),
+		services.WithSettledTolerance(cfg.SettledTolerance),
+		services.WithAutoMatchTolerance(cfg.AutoMatchTolerance),
+		services.WithAutoVerifyMatches(cfg.AutoVerifyMatches),
+		services.WithDebtContactNameSource(cfg.ContactNameSource),
+		services.WithReceiptDeletionService(receiptDeletionService),
+		services.WithCurrencyService(services.NewStaticCurrencyService(cfg.ExchangeRates)),
+	}
+	if cfg.DebtEventsWebhookURL != "" {
+		debtServiceOptions = append(debtServiceOptions,
+			services.WithEventPublisher(services.NewWebhookEventPublisher(cfg.DebtEventsWebhookURL, cfg.DebtEventsWebhookTimeout)))
+	}
+	debtService := services.NewDebtService(debtListRepo, debtItemRepo, contactRepo, paymentScheduleService, s3Service, debtServiceOptions...)
+	debtProposalService := services.NewDebtProposalService(debtProposalRepo, contactRepo, debtService)
 
 	// Initialize auth service with all dependencies
-	authService, err := services.NewAuthService(userRepo, contactService, cfg.JWTSecret, cfg.JWTExpiry)
+	authService, err := services.NewAuthService(userRepo, contactService, cfg.JWTSecret, cfg.JWTExpiry,
+		services.WithAPIKeyRepository(apiKeyRepo),
+		services.WithJWTIssuerAndAudience(cfg.JWTIssuer, cfg.JWTAudience),
+	)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to initialize auth service")
 	}
 
 	// Initialize handlers
+	handlers.SetDefaultPageSize(cfg.DefaultPageSize)
+	handlers.SetDefaultDebtSort(cfg.DefaultDebtSort)
+	handlers.SetStrictJSONBinding(cfg.StrictJSONBinding)
 	authHandler := handlers.NewAuthHandler(authService, logger)
 	contactHandler := handlers.NewContactHandler(contactService, logger)
-	debtHandler := handlers.NewDebtHandler(debtService, s3Service, logger)
+	debtHandler := handlers.NewDebtHandler(debtService, s3Service, logger, handlers.WithMaxReceiptSize(cfg.MaxReceiptSize), handlers.WithReceiptTypes(cfg.ReceiptContentTypes, cfg.ReceiptExtensions))
+	debtProposalHandler := handlers.NewDebtProposalHandler(debtProposalService, logger)
+	userSettingsHandler := handlers.NewUserSettingsHandler(userSettingsService, logger)
 
 	// Initialize middleware
 	authMiddleware := middleware.NewAuthMiddleware(authService, logger)
@@ -107,6 +169,7 @@ func main() {
 	router.Use(loggingMiddleware.Recovery())
 	router.Use(loggingMiddleware.CORS())
 	router.Use(loggingMiddleware.LogRequests())
+	router.Use(middleware.Gzip())
 
 	// Health check endpoint (no auth required)
 	router.GET("/health", func(c *gin.Context) {
@@ -119,7 +182,8 @@ func main() {
 	})
 
 	// API routes
-	apiV1 := router.Group("/api/v1")
+	// Handlers get the request timeout unless their group sets a budget of its own
+	apiV1 := router.Group("/api/v1", middleware.Timeout(cfg.RequestTimeout))
 	{
 		// Authentication routes (no auth required)
 		auth := apiV1.Group("/auth")
@@ -131,6 +195,9 @@ func main() {
 		// Protected routes (auth required)
 		protected := apiV1.Group("")
 		protected.Use(authMiddleware.Authenticate())
+
+		// Routes that modify data are closed to read-only tokens and API keys
+		requireFull := authMiddleware.RequireScope(entities.ScopeFull)
 		{
 			// Health check for authenticated users
 		protected.GET("/health", func(c *gin.Context) {
@@ -142,59 +209,161 @@ func main() {
 				})
 		})
 
+			// Account settings routes
+			account := protected.Group("/auth")
+			{
+				account.GET("/settings", userSettingsHandler.GetUserSettings)
+				account.PUT("/settings", requireFull, userSettingsHandler.UpdateUserSettings)
+				account.GET("/notifications", userSettingsHandler.GetNotificationPreferences)
+				account.PUT("/notifications", requireFull, userSettingsHandler.UpdateNotificationPreferences)
+				account.POST("/api-keys", requireFull, authHandler.CreateAPIKey)
+				account.GET("/api-keys", authHandler.GetAPIKeys)
+				account.DELETE("/api-keys/:id", requireFull, authHandler.RevokeAPIKey)
+				account.POST("/tokens/read-only", requireFull, authHandler.CreateReadOnlyToken)
+			}
+
 					// Contact routes
 			contacts := protected.Group("/contacts")
 			{
-				contacts.POST("", contactHandler.CreateContact)
+				contacts.POST("", requireFull, contactHandler.CreateContact)
 				contacts.GET("", contactHandler.GetUserContacts)
+				contacts.GET("/lookup", contactHandler.LookupContact)
 				contacts.GET("/:id", contactHandler.GetContact)
-				contacts.PUT("/:id", contactHandler.UpdateContact)
-				contacts.DELETE("/:id", contactHandler.DeleteContact)
+				contacts.GET("/:id/summary", debtHandler.GetContactSummary)
+				contacts.GET("/:id/reconciliation", debtHandler.GetContactReconciliation)
+				contacts.PUT("/:id", requireFull, contactHandler.UpdateContact)
+				contacts.DELETE("/:id", requireFull, contactHandler.DeleteContact)
+				contacts.POST("/:id/favorite", requireFull, contactHandler.ToggleFavorite)
+				contacts.POST("/:id/block", requireFull, contactHandler.BlockContact)
+				contacts.DELETE("/:id/block", requireFull, contactHandler.UnblockContact)
 			}
 
 			// Debt management routes
 			debts := protected.Group("/debts")
+			uploads := debts.Group("", middleware.Timeout(cfg.UploadTimeout))
+			analytics := debts.Group("", middleware.Timeout(cfg.AnalyticsTimeout))
 			{
 				// Debt list operations
-				debts.POST("", debtHandler.CreateDebtList)
+				debts.POST("", requireFull, debtHandler.CreateDebtList)
+				debts.POST("/quick", requireFull, debtHandler.CreateQuickDebt)
+				debts.POST("/schedule-preview", debtHandler.PreviewPaymentSchedule)
 				debts.GET("", debtHandler.GetUserDebtLists)
+				debts.GET("/shared-with-me", debtHandler.GetSharedDebtLists)
+				debts.GET("/by-contact", debtHandler.GetDebtsByContact)
 				debts.GET("/:id", debtHandler.GetDebtList)
-				debts.PUT("/:id", debtHandler.UpdateDebtList)
-				debts.DELETE("/:id", debtHandler.DeleteDebtList)
+				debts.GET("/:id/perspective", debtHandler.GetDebtPerspective)
+				debts.GET("/:id/history", debtHandler.GetDebtListHistory)
+				debts.PUT("/:id", requireFull, debtHandler.UpdateDebtList)
+				debts.DELETE("/:id", requireFull, debtHandler.DeleteDebtList)
+				debts.POST("/:id/transfer-ownership", requireFull, debtHandler.TransferOwnership)
+				debts.POST("/:id/recompute", requireFull, debtHandler.RecomputeDebtList)
 
 			// Debt item (payment) operations
-			debts.POST("/payments", debtHandler.CreateDebtItem)
+			debts.POST("/payments", requireFull, debtHandler.CreateDebtItem)
 			debts.GET("/:id/payments", debtHandler.GetDebtListItems)
-			debts.DELETE("/payments/:id", debtHandler.DeleteDebtItem)
+			debts.DELETE("/payments/:id", requireFull, debtHandler.DeleteDebtItem)
+			debts.POST("/payments/:id/restore", requireFull, debtHandler.RestoreDebtItem)
+			debts.POST("/payments/:id/move", requireFull, debtHandler.MoveDebtItem)
+			uploads.POST("/:id/payments/import", requireFull, debtHandler.ImportPayments)
+			debts.DELETE("/:id/payments", requireFull, debtHandler.DeleteDebtItems)
 
 			// Payment verification operations
 				debts.GET("/verifications/pending", debtHandler.GetPendingVerifications)
-				debts.POST("/payments/:id/verify", debtHandler.VerifyDebtItem)
-				debts.POST("/payments/:id/reject", debtHandler.RejectDebtItem)
-				debts.POST("/payments/:id/receipt", debtHandler.UploadReceipt)
+				debts.GET("/payments/:id/verifier", debtHandler.GetPaymentVerifier)
+				debts.POST("/payments/:id/verify", requireFull, debtHandler.VerifyDebtItem)
+				debts.POST("/payments/:id/auto-match", requireFull, debtHandler.AutoMatchPayment)
+				debts.POST("/payments/:id/reverse", requireFull, debtHandler.ReversePayment)
+				debts.POST("/payments/:id/reject", requireFull, debtHandler.RejectDebtItem)
+				debts.POST("/payments/:id/dispute", requireFull, debtHandler.DisputeDebtItem)
+				debts.POST("/payments/:id/resubmit", requireFull, debtHandler.ResubmitDebtItem)
+				debts.POST("/payments/:id/nudge", requireFull, debtHandler.NudgeDebtItem)
+				uploads.POST("/payments/:id/receipt", requireFull, debtHandler.UploadReceipt)
 
 				// Receipt photo serving
 				debts.GET("/:id/receipts/:filename", debtHandler.GetReceiptPhoto)
 
+				// Debt list documents (agreements)
+				uploads.POST("/:id/documents", requireFull, debtHandler.UploadDebtDocument)
+				debts.GET("/:id/documents/:filename", debtHandler.GetDebtDocument)
+
 				// Analytics and reporting
-				debts.GET("/overdue", debtHandler.GetOverdueItems)
-				debts.GET("/due-soon", debtHandler.GetDueSoonItems)
-				debts.GET("/:id/schedule", debtHandler.GetPaymentSchedule)
-				debts.GET("/:id/summary", debtHandler.GetTotalPaymentsForDebtList)
+				analytics.GET("/overdue", debtHandler.GetOverdueItems)
+				analytics.GET("/overdue/total", debtHandler.GetOverdueTotals)
+				debts.POST("/overdue/acknowledge", requireFull, debtHandler.AcknowledgeOverdue)
+				analytics.GET("/due-soon", debtHandler.GetDueSoonItems)
+				analytics.GET("/attention", debtHandler.GetAttention)
+				analytics.GET("/counts", debtHandler.GetDebtCounts)
+				analytics.GET("/:id/schedule", debtHandler.GetPaymentSchedule)
+				analytics.GET("/:id/variance", debtHandler.GetScheduleVariance)
+				analytics.GET("/:id/next-payment", debtHandler.GetNextPayment)
+				analytics.GET("/:id/payoff-projection", debtHandler.GetPayoffProjection)
+				analytics.GET("/:id/summary", debtHandler.GetTotalPaymentsForDebtList)
+				analytics.GET("/:id/balance-history", debtHandler.GetBalanceHistory)
+			}
+
+			// Debt proposal routes
+			debtProposals := protected.Group("/debt-proposals")
+			{
+				debtProposals.POST("", requireFull, debtProposalHandler.ProposeDebt)
+				debtProposals.GET("", debtProposalHandler.GetUserDebtProposals)
+				debtProposals.POST("/:id/accept", requireFull, debtProposalHandler.AcceptDebtProposal)
+				debtProposals.POST("/:id/reject", requireFull, debtProposalHandler.RejectDebtProposal)
+				debtProposals.POST("/:id/cancel", requireFull, debtProposalHandler.CancelDebtProposal)
+			}
+
+			// Reports over a date range
+			reports := protected.Group("/reports", middleware.Timeout(cfg.AnalyticsTimeout))
+			{
+				reports.GET("/summary", debtHandler.GetActivitySummary)
+				reports.GET("/payment-methods", debtHandler.GetPaymentMethodStats)
 			}
 
 			// Additional analytics routes
-			protected.GET("/upcoming-payments", debtHandler.GetUpcomingPayments)
+			protected.GET("/upcoming-payments", middleware.Timeout(cfg.AnalyticsTimeout), debtHandler.GetUpcomingPayments)
+
+			// Currencies of the user's debts, for currency pickers
+			protected.GET("/currencies", debtHandler.GetCurrencies)
+
+			// Payments across all of the user's debts, with their debt context
+			protected.GET("/payments", debtHandler.GetUserPayments)
+
+			// Payments awaiting the user's verification, with their debt context
+			protected.GET("/verifications/pending", debtHandler.GetPendingVerificationQueue)
 		}
 	}
 
+	// Background workers share the shutdown deadline of the HTTP server
+	workerManager := workers.NewManager(logger, shutdownTimeout)
+	if cfg.ReceiptRetention > 0 {
+		receiptCleanupService := services.NewReceiptCleanupService(debtItemRepo, s3Service, cfg.ReceiptRetention, logger)
+		workerManager.Register(workers.NewPeriodicWorker("receipt-cleanup", cfg.ReceiptCleanupInterval, logger, func(ctx context.Context) error {
+			_, err := receiptCleanupService.CleanupReceipts(ctx, cfg.ReceiptCleanupDryRun)
+			return err
+		}))
+	}
+	workerManager.Register(workers.NewPeriodicWorker("receipt-deletion-retry", cfg.ReceiptDeletionRetryInterval, logger, func(ctx context.Context) error {
+		_, err := receiptDeletionService.RetryPendingDeletions(ctx)
+		return err
+	}))
+	if cfg.StatusRecomputeInterval > 0 {
+		workerManager.Register(workers.NewPeriodicWorker("status-recompute", cfg.StatusRecomputeInterval, logger, func(ctx context.Context) error {
+			recomputed, err := debtService.RecomputeOverdueStatuses(ctx)
+			if err != nil {
+				return err
+			}
+			logger.Info().Int("recomputed", recomputed).Msg("Recomputed past due debt statuses")
+			return nil
+		}))
+	}
+	workerManager.Start(context.Background())
+
 	// Start server with graceful shutdown
 	addr := fmt.Sprintf("%s:%s", cfg.ServerHost, cfg.ServerPort)
 	srv := &http.Server{
 		Addr:         addr,
 		Handler:      router,
-		ReadTimeout:  30 * time.Second,
-		WriteTimeout: 30 * time.Second,
+		ReadTimeout:  cfg.ServerTimeout(),
+		WriteTimeout: cfg.ServerTimeout(),
 		IdleTimeout:  120 * time.Second,
 	}
 
@@ -213,7 +382,7 @@ func main() {
 	logger.Info().Msg("Shutting down server...")
 
 	// Give outstanding requests a deadline for completion
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
 
 	if err := srv.Shutdown(ctx); err != nil {
@@ -221,4 +390,9 @@ func main() {
 	} else {
 		logger.Info().Msg("Server shutdown completed")
 	}
+
+	// Drain workers after the HTTP server so in-flight requests can still hand them work
+	if err := workerManager.Stop(); err != nil {
+		logger.Error().Err(err).Msg("Background workers forced to shutdown")
+	}
 } 
\ No newline at end of file
@@ -4,10 +4,27 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/shopspring/decimal"
+
+	"pay-your-dues/internal/domain/entities"
 )
 
+// DefaultMaxReceiptSize is the largest receipt upload accepted when
+// MAX_RECEIPT_SIZE is not set (10MB)
+const DefaultMaxReceiptSize int64 = 10 << 20
+
+// DefaultReceiptContentTypes are the receipt content types accepted when
+// RECEIPT_ALLOWED_CONTENT_TYPES is not set
+var DefaultReceiptContentTypes = []string{"image/jpeg", "image/jpg", "image/png", "image/gif", "image/webp"}
+
+// DefaultReceiptExtensions are the receipt file extensions accepted when
+// RECEIPT_ALLOWED_EXTENSIONS is not set
+var DefaultReceiptExtensions = []string{".jpg", ".jpeg", ".png", ".gif", ".webp"}
+
 type Config struct {
 	DBHost     string
 	DBPort     int
@@ -16,14 +33,149 @@ type Config struct {
 	DBName     string
 	DBSSLMode  string
 
+	// DBLogQueries logs every SQL query at debug level. Failed queries, and
+	// queries slower than DBSlowQueryThreshold, are logged either way. Zero
+	// disables the slow query warnings.
+	DBLogQueries         bool
+	DBSlowQueryThreshold time.Duration
+
 	ServerPort string
 	ServerHost string
 
 	JWTSecret  string
 	JWTExpiry  string
 
+	// JWTIssuer and JWTAudience are set as the iss and aud claims of issued tokens,
+	// and tokens with other values are rejected. Empty leaves the claim unchecked.
+	JWTIssuer   string
+	JWTAudience string
+
 	LogLevel string
 
+	// PaymentDateFutureWindow is how far ahead of now a payment may be dated
+	PaymentDateFutureWindow time.Duration
+
+	// PaymentNudgeInterval is how long the submitter of a pending payment waits
+	// between reminders to its verifier
+	PaymentNudgeInterval time.Duration
+
+	// DefaultDueDateOffset is how long after creation a one-time debt falls due
+	// when it is created without a due date or number of payments
+	DefaultDueDateOffset time.Duration
+
+	// MaxNumberOfPayments is the most payments a debt list may be split into
+	MaxNumberOfPayments int
+
+	// MaxTotalAmount is the largest total amount a debt may have
+	MaxTotalAmount decimal.Decimal
+
+	// StrictScheduleValidation rejects debts whose due date contradicts their
+	// number of payments instead of creating them with a warning
+	StrictScheduleValidation bool
+
+	// SettledTolerance is the remaining balance at or below which a debt counts
+	// as settled, absorbing rounding leftovers
+	SettledTolerance decimal.Decimal
+
+	// AutoMatchTolerance is how far a pending payment's amount may be from the
+	// expected installment and still match it
+	AutoMatchTolerance decimal.Decimal
+
+	// AutoVerifyMatches verifies pending payments that match the expected
+	// installment when they are checked, instead of only reporting the match
+	AutoVerifyMatches bool
+
+	// SoftDeletePayments keeps deleted payments, and their receipts, so they can
+	// be restored. When false, deleting a payment is permanent.
+	SoftDeletePayments bool
+
+	// MaxReceiptSize is the largest receipt upload accepted, in bytes
+	MaxReceiptSize int64
+
+	// ReceiptContentTypes are the content types accepted for receipt uploads,
+	// lowercased
+	ReceiptContentTypes []string
+
+	// ReceiptExtensions are the file extensions accepted for receipt uploads,
+	// lowercased and with their leading dot
+	ReceiptExtensions []string
+
+	// ReceiptRetention is how long after a debt is settled its receipts are kept.
+	// Zero disables the receipt cleanup worker.
+	ReceiptRetention time.Duration
+
+	// ReceiptCleanupInterval is how often the receipt cleanup worker runs
+	ReceiptCleanupInterval time.Duration
+
+	// ReceiptCleanupDryRun makes the cleanup worker only log the receipts it would delete
+	ReceiptCleanupDryRun bool
+
+	// ReceiptDeletionRetryInterval is how often failed receipt deletions are retried
+	ReceiptDeletionRetryInterval time.Duration
+
+	// ReceiptDeletionBackoff is how long after failing a receipt deletion is first
+	// retried. The wait doubles with every further failure.
+	ReceiptDeletionBackoff time.Duration
+
+	// ReceiptDeletionMaxAttempts is how many times a receipt deletion is attempted
+	// before it is dead-lettered and no longer retried
+	ReceiptDeletionMaxAttempts int
+
+	// StatusRecomputeInterval is how often active debts whose next payment date has
+	// passed are recomputed, turning them overdue. Zero disables the job.
+	StatusRecomputeInterval time.Duration
+
+	// DefaultPageSize is how many items list endpoints return when the client
+	// does not pass a limit
+	DefaultPageSize int
+
+	// DefaultDebtSort orders debt list listings when the client does not pass a
+	// sort, such as "next_payment_date asc"
+	DefaultDebtSort entities.DebtSort
+
+	// ExchangeRates are the rates amounts are converted with for display
+	ExchangeRates map[entities.CurrencyPair]decimal.Decimal
+
+	// StrictJSONBinding rejects request bodies with fields the endpoint does not
+	// know, instead of silently ignoring them
+	StrictJSONBinding bool
+
+	// RequestTimeout bounds how long handlers may work on a request. Uploads and
+	// analytics endpoints have budgets of their own in UploadTimeout and
+	// AnalyticsTimeout.
+	RequestTimeout   time.Duration
+	UploadTimeout    time.Duration
+	AnalyticsTimeout time.Duration
+
+	// DebtEventsWebhookURL receives debt events, such as a debt becoming overdue,
+	// as JSON POSTs. Empty disables event delivery.
+	DebtEventsWebhookURL string
+
+	// DebtEventsWebhookTimeout is how long a single event delivery may take
+	DebtEventsWebhookTimeout time.Duration
+
+	// EnableReciprocalContacts links contacts to the users who own their email and
+	// gives those users a contact back. Disable it to keep contact lists private.
+	EnableReciprocalContacts bool
+
+	// ContactNameSource chooses the name shown for contacts that are registered
+	// users: "contact" for the name the user saved, "verified" for the name the
+	// linked user registered with
+	ContactNameSource string
+
+	// CaseInsensitiveEmails stores user and contact emails lower-cased and looks
+	// them up ignoring case, whatever the database collation. Emails already
+	// stored are lower-cased on startup.
+	CaseInsensitiveEmails bool
+
+	// ContactDeletionPolicy decides what happens when a contact with unsettled
+	// debts is deleted: "block" refuses, "detach" keeps the debts with a
+	// placeholder contact
+	ContactDeletionPolicy string
+
+	// NormalizeCurrencyCodes rewrites legacy "Php" currency values to "PHP" on startup
+	NormalizeCurrencyCodes bool
+
 	// S3 Configuration
 	S3Region          string
 	S3BucketName      string
@@ -45,6 +197,196 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("invalid DB_PORT: %v", err)
 	}
 
+	paymentDateFutureWindow, err := time.ParseDuration(getEnv("PAYMENT_DATE_FUTURE_WINDOW", "24h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid PAYMENT_DATE_FUTURE_WINDOW: %v", err)
+	}
+
+	paymentNudgeInterval, err := time.ParseDuration(getEnv("PAYMENT_NUDGE_INTERVAL", "24h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid PAYMENT_NUDGE_INTERVAL: %v", err)
+	}
+	if paymentNudgeInterval < 0 {
+		return nil, fmt.Errorf("invalid PAYMENT_NUDGE_INTERVAL: must not be negative")
+	}
+
+	defaultDueDateOffset, err := time.ParseDuration(getEnv("DEFAULT_DUE_DATE_OFFSET", "720h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DEFAULT_DUE_DATE_OFFSET: %v", err)
+	}
+	if defaultDueDateOffset <= 0 {
+		return nil, fmt.Errorf("invalid DEFAULT_DUE_DATE_OFFSET: must be positive")
+	}
+
+	maxNumberOfPayments, err := strconv.Atoi(getEnv("MAX_NUMBER_OF_PAYMENTS", "600"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAX_NUMBER_OF_PAYMENTS: %v", err)
+	}
+	if maxNumberOfPayments <= 0 {
+		return nil, fmt.Errorf("invalid MAX_NUMBER_OF_PAYMENTS: must be positive")
+	}
+
+	maxTotalAmount, err := decimal.NewFromString(getEnv("MAX_TOTAL_AMOUNT", "1000000000000"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAX_TOTAL_AMOUNT: %v", err)
+	}
+	if !maxTotalAmount.IsPositive() {
+		return nil, fmt.Errorf("invalid MAX_TOTAL_AMOUNT: must be positive")
+	}
+
+	settledTolerance, err := decimal.NewFromString(getEnv("SETTLED_TOLERANCE", "0.01"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SETTLED_TOLERANCE: %v", err)
+	}
+	if settledTolerance.IsNegative() {
+		return nil, fmt.Errorf("invalid SETTLED_TOLERANCE: must not be negative")
+	}
+
+	autoMatchTolerance, err := decimal.NewFromString(getEnv("AUTO_MATCH_TOLERANCE", "0.01"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid AUTO_MATCH_TOLERANCE: %v", err)
+	}
+	if autoMatchTolerance.IsNegative() {
+		return nil, fmt.Errorf("invalid AUTO_MATCH_TOLERANCE: must not be negative")
+	}
+
+	maxReceiptSize, err := strconv.ParseInt(getEnv("MAX_RECEIPT_SIZE", strconv.FormatInt(DefaultMaxReceiptSize, 10)), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAX_RECEIPT_SIZE: %v", err)
+	}
+	if maxReceiptSize <= 0 {
+		return nil, fmt.Errorf("invalid MAX_RECEIPT_SIZE: must be positive")
+	}
+
+	receiptContentTypes := getEnvList("RECEIPT_ALLOWED_CONTENT_TYPES", DefaultReceiptContentTypes)
+	for _, contentType := range receiptContentTypes {
+		if !strings.Contains(contentType, "/") {
+			return nil, fmt.Errorf("invalid RECEIPT_ALLOWED_CONTENT_TYPES: %q is not a content type", contentType)
+		}
+	}
+	receiptExtensions := getEnvList("RECEIPT_ALLOWED_EXTENSIONS", DefaultReceiptExtensions)
+	for i, ext := range receiptExtensions {
+		if !strings.HasPrefix(ext, ".") {
+			receiptExtensions[i] = "." + ext
+		}
+	}
+
+	receiptRetention, err := time.ParseDuration(getEnv("RECEIPT_RETENTION", "0s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RECEIPT_RETENTION: %v", err)
+	}
+	if receiptRetention < 0 {
+		return nil, fmt.Errorf("invalid RECEIPT_RETENTION: must not be negative")
+	}
+
+	receiptCleanupInterval, err := time.ParseDuration(getEnv("RECEIPT_CLEANUP_INTERVAL", "24h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RECEIPT_CLEANUP_INTERVAL: %v", err)
+	}
+	if receiptCleanupInterval <= 0 {
+		return nil, fmt.Errorf("invalid RECEIPT_CLEANUP_INTERVAL: must be positive")
+	}
+
+	receiptDeletionRetryInterval, err := time.ParseDuration(getEnv("RECEIPT_DELETION_RETRY_INTERVAL", "5m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RECEIPT_DELETION_RETRY_INTERVAL: %v", err)
+	}
+	if receiptDeletionRetryInterval <= 0 {
+		return nil, fmt.Errorf("invalid RECEIPT_DELETION_RETRY_INTERVAL: must be positive")
+	}
+
+	receiptDeletionBackoff, err := time.ParseDuration(getEnv("RECEIPT_DELETION_BACKOFF", "1m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RECEIPT_DELETION_BACKOFF: %v", err)
+	}
+	if receiptDeletionBackoff <= 0 {
+		return nil, fmt.Errorf("invalid RECEIPT_DELETION_BACKOFF: must be positive")
+	}
+
+	receiptDeletionMaxAttempts, err := strconv.Atoi(getEnv("RECEIPT_DELETION_MAX_ATTEMPTS", "10"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RECEIPT_DELETION_MAX_ATTEMPTS: %v", err)
+	}
+	if receiptDeletionMaxAttempts <= 0 {
+		return nil, fmt.Errorf("invalid RECEIPT_DELETION_MAX_ATTEMPTS: must be positive")
+	}
+
+	statusRecomputeInterval, err := time.ParseDuration(getEnv("STATUS_RECOMPUTE_INTERVAL", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid STATUS_RECOMPUTE_INTERVAL: %v", err)
+	}
+	if statusRecomputeInterval < 0 {
+		return nil, fmt.Errorf("invalid STATUS_RECOMPUTE_INTERVAL: must not be negative")
+	}
+
+	dbSlowQueryThreshold, err := time.ParseDuration(getEnv("DB_SLOW_QUERY_THRESHOLD", "200ms"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DB_SLOW_QUERY_THRESHOLD: %v", err)
+	}
+	if dbSlowQueryThreshold < 0 {
+		return nil, fmt.Errorf("invalid DB_SLOW_QUERY_THRESHOLD: must not be negative")
+	}
+
+	defaultPageSize, err := strconv.Atoi(getEnv("DEFAULT_PAGE_SIZE", "50"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DEFAULT_PAGE_SIZE: %v", err)
+	}
+	if defaultPageSize <= 0 || defaultPageSize > 100 {
+		return nil, fmt.Errorf("invalid DEFAULT_PAGE_SIZE: must be between 1 and 100")
+	}
+
+	requestTimeout, err := time.ParseDuration(getEnv("REQUEST_TIMEOUT", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid REQUEST_TIMEOUT: %v", err)
+	}
+	if requestTimeout <= 0 {
+		return nil, fmt.Errorf("invalid REQUEST_TIMEOUT: must be positive")
+	}
+
+	uploadTimeout, err := time.ParseDuration(getEnv("UPLOAD_TIMEOUT", "60s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid UPLOAD_TIMEOUT: %v", err)
+	}
+	if uploadTimeout <= 0 {
+		return nil, fmt.Errorf("invalid UPLOAD_TIMEOUT: must be positive")
+	}
+
+	analyticsTimeout, err := time.ParseDuration(getEnv("ANALYTICS_TIMEOUT", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ANALYTICS_TIMEOUT: %v", err)
+	}
+	if analyticsTimeout <= 0 {
+		return nil, fmt.Errorf("invalid ANALYTICS_TIMEOUT: must be positive")
+	}
+
+	debtEventsWebhookTimeout, err := time.ParseDuration(getEnv("DEBT_EVENTS_WEBHOOK_TIMEOUT", "5s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DEBT_EVENTS_WEBHOOK_TIMEOUT: %v", err)
+	}
+	if debtEventsWebhookTimeout <= 0 {
+		return nil, fmt.Errorf("invalid DEBT_EVENTS_WEBHOOK_TIMEOUT: must be positive")
+	}
+
+	defaultDebtSort, err := entities.ParseDebtSort(getEnv("DEFAULT_DEBT_SORT", "created_at desc"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DEFAULT_DEBT_SORT: %v", err)
+	}
+
+	exchangeRates, err := entities.ParseExchangeRates(getEnv("EXCHANGE_RATES", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid EXCHANGE_RATES: %v", err)
+	}
+
+	contactNameSource := getEnv("CONTACT_NAME_SOURCE", entities.ContactNameSourceContact)
+	if !entities.IsValidContactNameSource(contactNameSource) {
+		return nil, fmt.Errorf("invalid CONTACT_NAME_SOURCE: must be %q or %q", entities.ContactNameSourceContact, entities.ContactNameSourceVerified)
+	}
+
+	contactDeletionPolicy := getEnv("CONTACT_DELETION_POLICY", entities.ContactDeletionPolicyBlock)
+	if !entities.IsValidContactDeletionPolicy(contactDeletionPolicy) {
+		return nil, fmt.Errorf("invalid CONTACT_DELETION_POLICY: must be %q or %q", entities.ContactDeletionPolicyBlock, entities.ContactDeletionPolicyDetach)
+	}
+
 	// Parse S3 force path style boolean
 	s3ForcePathStyle := false
 	if forcePathStyle := getEnv("S3_FORCE_PATH_STYLE", "false"); forcePathStyle == "true" {
@@ -65,8 +407,48 @@ func Load() (*Config, error) {
 		JWTSecret: getEnv("JWT_SECRET", "your-secret-key-here"),
 		JWTExpiry: getEnv("JWT_EXPIRY", "24h"),
 
+		JWTIssuer:   getEnv("JWT_ISSUER", ""),
+		JWTAudience: getEnv("JWT_AUDIENCE", ""),
+
 		LogLevel: getEnv("LOG_LEVEL", "debug"),
 
+		DBLogQueries:             getEnv("DB_LOG_QUERIES", "false") == "true",
+		DBSlowQueryThreshold:     dbSlowQueryThreshold,
+		PaymentDateFutureWindow:  paymentDateFutureWindow,
+		PaymentNudgeInterval:     paymentNudgeInterval,
+		DefaultDueDateOffset:     defaultDueDateOffset,
+		MaxNumberOfPayments:      maxNumberOfPayments,
+		MaxTotalAmount:           maxTotalAmount,
+		SoftDeletePayments:       getEnv("SOFT_DELETE_PAYMENTS", "true") == "true",
+		StrictScheduleValidation: getEnv("STRICT_SCHEDULE_VALIDATION", "false") == "true",
+		SettledTolerance:         settledTolerance,
+		AutoMatchTolerance:       autoMatchTolerance,
+		AutoVerifyMatches:        getEnv("AUTO_VERIFY_MATCHED_PAYMENTS", "false") == "true",
+		MaxReceiptSize:           maxReceiptSize,
+		ReceiptContentTypes:      receiptContentTypes,
+		ReceiptExtensions:        receiptExtensions,
+		NormalizeCurrencyCodes:   getEnv("NORMALIZE_CURRENCY_CODES", "false") == "true",
+		ReceiptRetention:         receiptRetention,
+		ReceiptCleanupInterval:   receiptCleanupInterval,
+		ReceiptCleanupDryRun:     getEnv("RECEIPT_CLEANUP_DRY_RUN", "false") == "true",
+		ReceiptDeletionRetryInterval: receiptDeletionRetryInterval,
+		ReceiptDeletionBackoff:       receiptDeletionBackoff,
+		ReceiptDeletionMaxAttempts:   receiptDeletionMaxAttempts,
+		StatusRecomputeInterval:  statusRecomputeInterval,
+		DefaultPageSize:          defaultPageSize,
+		DefaultDebtSort:          defaultDebtSort,
+		ExchangeRates:            exchangeRates,
+		StrictJSONBinding:        getEnv("STRICT_JSON_BINDING", "false") == "true",
+		RequestTimeout:           requestTimeout,
+		UploadTimeout:            uploadTimeout,
+		AnalyticsTimeout:         analyticsTimeout,
+		DebtEventsWebhookURL:     getEnv("DEBT_EVENTS_WEBHOOK_URL", ""),
+		DebtEventsWebhookTimeout: debtEventsWebhookTimeout,
+		EnableReciprocalContacts: getEnv("ENABLE_RECIPROCAL_CONTACTS", "true") == "true",
+		ContactNameSource:        contactNameSource,
+		ContactDeletionPolicy:    contactDeletionPolicy,
+		CaseInsensitiveEmails:    getEnv("CASE_INSENSITIVE_EMAILS", "true") == "true",
+
 		// S3 Configuration
 		S3Region:          getEnv("S3_REGION", "us-east-1"),
 		S3BucketName:      getEnv("S3_BUCKET_NAME", ""),
@@ -84,6 +466,38 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvList reads a comma-separated list, trimming and lowercasing each entry
+// and dropping empty ones. The default is used when the variable is unset or
+// lists nothing.
+func getEnvList(key string, defaultValue []string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.ToLower(strings.TrimSpace(value)); value != "" {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return append([]string(nil), defaultValue...)
+	}
+	return values
+}
+
+// serverTimeoutMargin is how much longer than the largest handler budget the
+// HTTP server keeps a connection, so handlers time out and respond first
+const serverTimeoutMargin = 10 * time.Second
+
+// ServerTimeout returns the HTTP server's read and write timeout, derived from
+// the largest of RequestTimeout, UploadTimeout and AnalyticsTimeout
+func (c *Config) ServerTimeout() time.Duration {
+	longest := c.RequestTimeout
+	for _, timeout := range []time.Duration{c.UploadTimeout, c.AnalyticsTimeout} {
+		if timeout > longest {
+			longest = timeout
+		}
+	}
+	return longest + serverTimeoutMargin
+}
+
 func (c *Config) GetDSN() string {
 	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		c.DBHost, c.DBPort, c.DBUser, c.DBPassword, c.DBName, c.DBSSLMode)
@@ -15,14 +15,20 @@ type Database struct {
 	DB *gorm.DB
 }
 
-func NewDatabase(dsn string) (*Database, error) {
+// NewDatabase connects to the database and migrates its schema. GORM logs
+// through queryLogger, see NewQueryLogger.
+func NewDatabase(dsn string, queryLogger logger.Interface) (*Database, error) {
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger: queryLogger,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %v", err)
 	}
 
+	if _, err := NormalizeLegacyDebtTypes(db); err != nil {
+		return nil, fmt.Errorf("failed to normalize debt types: %v", err)
+	}
+
 	// Auto migrate the schema
 	if err := db.AutoMigrate(
 		&models.User{},
@@ -31,11 +37,25 @@ func NewDatabase(dsn string) (*Database, error) {
 		&models.UserContact{},
 		&models.DebtList{},
 		&models.DebtItem{},
+		&models.DebtBalance{},
+		&models.DebtListHistoryEntry{},
+		&models.DebtProposal{},
 		&models.Notification{},
+		&models.APIKey{},
+		&models.PendingDeletion{},
+		&models.Deletion{},
 	); err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %v", err)
 	}
 
+	if err := RefreshCheckConstraints(db); err != nil {
+		return nil, fmt.Errorf("failed to refresh check constraints: %v", err)
+	}
+
+	if err := CreatePendingVerificationIndexes(db); err != nil {
+		return nil, fmt.Errorf("failed to create pending verification indexes: %v", err)
+	}
+
 	// Add unique constraint on user_contacts (user_id, email)
 	if err := db.Exec(`
 		CREATE UNIQUE INDEX IF NOT EXISTS idx_user_contacts_user_email 
@@ -0,0 +1,190 @@
+package database
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/models"
+)
+
+const (
+	legacyPesoCurrency = "Php"
+	isoPesoCurrency    = "PHP"
+)
+
+// NormalizeLegacyCurrency rewrites the legacy "Php" currency code to the ISO 4217
+// "PHP" on debt lists, debt items and user settings. It runs in a single
+// transaction, is safe to run repeatedly, and returns the number of rows changed.
+func NormalizeLegacyCurrency(db *gorm.DB) (int64, error) {
+	var updated int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		updates := []struct {
+			model  interface{}
+			column string
+		}{
+			{&models.DebtList{}, "currency"},
+			{&models.DebtItem{}, "currency"},
+			{&models.UserSettings{}, "default_currency"},
+		}
+
+		for _, u := range updates {
+			// Soft-deleted rows are included so restoring them cannot bring the legacy code back
+			result := tx.Unscoped().Model(u.model).
+				Where(u.column+" = ?", legacyPesoCurrency).
+				UpdateColumn(u.column, isoPesoCurrency)
+			if result.Error != nil {
+				return fmt.Errorf("failed to normalize %T currency: %w", u.model, result.Error)
+			}
+			updated += result.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return updated, nil
+}
+
+// emailColumns are the email columns stored normalized when emails are
+// case-insensitive, with the columns their emails must be unique within
+var emailColumns = []struct {
+	model   interface{}
+	table   string
+	scopeBy string
+}{
+	{&models.User{}, "users", ""},
+	{&models.UserContact{}, "user_contacts", "user_id"},
+}
+
+// NormalizeEmails trims and lower-cases the emails of users and user contacts
+// stored before case-insensitive emails, so lookups can compare the column as
+// stored and use its index. It refuses, changing nothing, when two emails would
+// become the same. It runs in a single transaction, is safe to run repeatedly,
+// and returns the number of rows changed.
+func NormalizeEmails(db *gorm.DB) (int64, error) {
+	var updated int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, c := range emailColumns {
+			group := "LOWER(TRIM(email))"
+			if c.scopeBy != "" {
+				group = c.scopeBy + ", " + group
+			}
+			var duplicates int64
+			if err := tx.Table("(?) AS duplicates",
+				tx.Unscoped().Model(c.model).Select(group).Where("email IS NOT NULL").Group(group).Having("COUNT(*) > 1"),
+			).Count(&duplicates).Error; err != nil {
+				return fmt.Errorf("failed to check %s emails: %w", c.table, err)
+			}
+			if duplicates > 0 {
+				return fmt.Errorf("%d %s emails differ only by case or whitespace and must be merged first", duplicates, c.table)
+			}
+
+			// Soft-deleted rows are included so restoring them cannot bring unnormalized emails back
+			result := tx.Unscoped().Model(c.model).
+				Where("email <> LOWER(TRIM(email))").
+				UpdateColumn("email", gorm.Expr("LOWER(TRIM(email))"))
+			if result.Error != nil {
+				return fmt.Errorf("failed to normalize %s emails: %w", c.table, result.Error)
+			}
+			updated += result.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return updated, nil
+}
+
+// legacyDebtTypes maps the debt type values older clients stored to the current ones
+var legacyDebtTypes = map[string]string{
+	entities.DebtDirectionOwedToMe: entities.DebtTypeToReceive,
+	entities.DebtDirectionIOwe:     entities.DebtTypeToPay,
+}
+
+// NormalizeLegacyDebtTypes rewrites legacy "owed_to_me" and "i_owe" debt types
+// on debt lists and debt proposals to "to_receive" and "to_pay". It runs before
+// AutoMigrate, which would otherwise fail to add the debt type check constraint
+// to tables holding legacy rows. It is safe to run repeatedly and returns the
+// number of rows changed.
+func NormalizeLegacyDebtTypes(db *gorm.DB) (int64, error) {
+	var updated int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&models.DebtList{}, &models.DebtProposal{}} {
+			if !tx.Migrator().HasTable(model) {
+				continue
+			}
+			for legacy, current := range legacyDebtTypes {
+				// Soft-deleted rows are included so restoring them cannot bring legacy values back
+				result := tx.Unscoped().Model(model).
+					Where("debt_type = ?", legacy).
+					UpdateColumn("debt_type", current)
+				if result.Error != nil {
+					return fmt.Errorf("failed to normalize %T debt type: %w", model, result.Error)
+				}
+				updated += result.RowsAffected
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return updated, nil
+}
+
+// valueListConstraints are the check constraints that list the allowed values of
+// a column, keyed by the GORM names AutoMigrate gives them
+var valueListConstraints = []struct {
+	model interface{}
+	name  string
+}{
+	{&models.DebtList{}, "chk_debt_lists_installment_plan"},
+	{&models.UserSettings{}, "chk_user_settings_default_installment_plan"},
+	{&models.DebtItem{}, "chk_debt_items_status"},
+	{&models.DebtProposal{}, "chk_debt_proposals_status"},
+}
+
+// RefreshCheckConstraints recreates the value list check constraints from the
+// models. AutoMigrate only creates missing constraints, so databases migrated
+// before a value was added, such as an installment plan or payment status,
+// would otherwise keep rejecting it.
+func RefreshCheckConstraints(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, c := range valueListConstraints {
+			if tx.Migrator().HasConstraint(c.model, c.name) {
+				if err := tx.Migrator().DropConstraint(c.model, c.name); err != nil {
+					return fmt.Errorf("failed to drop constraint %s: %w", c.name, err)
+				}
+			}
+			if err := tx.Migrator().CreateConstraint(c.model, c.name); err != nil {
+				return fmt.Errorf("failed to create constraint %s: %w", c.name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// pendingVerificationIndexes support the pending verification query: the partial
+// index keeps only pending payments, which are a small fraction of all payments,
+// and the debt list index resolves the lists a user verifies as the owner
+var pendingVerificationIndexes = []string{
+	`CREATE INDEX IF NOT EXISTS idx_debt_items_pending_debt_list
+		ON debt_items(debt_list_id, created_at)
+		WHERE status = 'pending'`,
+	`CREATE INDEX IF NOT EXISTS idx_debt_lists_user_debt_type
+		ON debt_lists(user_id, debt_type)`,
+}
+
+// CreatePendingVerificationIndexes creates the indexes used to look up the
+// payments a user has to verify. It is safe to run repeatedly.
+func CreatePendingVerificationIndexes(db *gorm.DB) error {
+	for _, statement := range pendingVerificationIndexes {
+		if err := db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to create pending verification index: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// queryLogger routes GORM's logging through zerolog. Queries are logged at debug
+// level, those slower than slowThreshold as warnings and failed ones as errors.
+// Queries are logged without their parameters, which may hold personal data.
+type queryLogger struct {
+	logger        zerolog.Logger
+	level         logger.LogLevel
+	slowThreshold time.Duration
+}
+
+// NewQueryLogger creates the GORM logger for the database connection. When
+// enabled is false only failed and slow queries are logged. A zero slowThreshold
+// logs no query as slow.
+func NewQueryLogger(zlog zerolog.Logger, enabled bool, slowThreshold time.Duration) logger.Interface {
+	level := logger.Warn
+	if enabled {
+		level = logger.Info
+	}
+	return &queryLogger{
+		logger:        zlog.With().Str("component", "database").Logger(),
+		level:         level,
+		slowThreshold: slowThreshold,
+	}
+}
+
+// LogMode returns a copy of the logger logging at the given level
+func (l *queryLogger) LogMode(level logger.LogLevel) logger.Interface {
+	copied := *l
+	copied.level = level
+	return &copied
+}
+
+func (l *queryLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Info {
+		l.logger.Info().Msg(fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *queryLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Warn {
+		l.logger.Warn().Msg(fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *queryLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Error {
+		l.logger.Error().Msg(fmt.Sprintf(msg, data...))
+	}
+}
+
+// ParamsFilter leaves the parameters out of logged queries
+func (l *queryLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
+
+// Trace logs a query once it has run
+func (l *queryLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	switch {
+	// Missing records are an expected outcome that callers handle
+	case err != nil && l.level >= logger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		sql, rows := fc()
+		l.logger.Error().Err(err).Dur("elapsed", elapsed).Int64("rows", rows).Str("sql", sql).Msg("Query failed")
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= logger.Warn:
+		sql, rows := fc()
+		l.logger.Warn().Dur("elapsed", elapsed).Dur("threshold", l.slowThreshold).Int64("rows", rows).Str("sql", sql).Msg("Slow query")
+	case l.level >= logger.Info:
+		sql, rows := fc()
+		l.logger.Debug().Dur("elapsed", elapsed).Int64("rows", rows).Str("sql", sql).Msg("Query")
+	}
+}
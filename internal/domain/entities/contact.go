@@ -6,6 +6,37 @@ import (
 	"github.com/google/uuid"
 )
 
+// UnknownContactName is shown in place of a contact's name when the viewing
+// user's UserContact relation no longer exists.
+const UnknownContactName = "Unknown"
+
+// Sources of the name shown for a contact that is also a registered user
+const (
+	// ContactNameSourceContact shows the name the viewing user saved for the contact
+	ContactNameSourceContact = "contact"
+	// ContactNameSourceVerified shows the name the linked user registered with
+	ContactNameSourceVerified = "verified"
+)
+
+// IsValidContactNameSource checks if source is a known contact name source
+func IsValidContactNameSource(source string) bool {
+	return source == ContactNameSourceContact || source == ContactNameSourceVerified
+}
+
+// Policies for deleting a contact the user still has unsettled debts with
+const (
+	// ContactDeletionPolicyBlock refuses to delete the contact until its debts are settled
+	ContactDeletionPolicyBlock = "block"
+	// ContactDeletionPolicyDetach deletes the contact and keeps its debts, which
+	// then show UnknownContactName in place of the contact
+	ContactDeletionPolicyDetach = "detach"
+)
+
+// IsValidContactDeletionPolicy checks if policy is a known contact deletion policy
+func IsValidContactDeletionPolicy(policy string) bool {
+	return policy == ContactDeletionPolicyBlock || policy == ContactDeletionPolicyDetach
+}
+
 // Contact represents the core contact entity (minimal identity)
 type Contact struct {
 	ID         uuid.UUID
@@ -15,18 +46,42 @@ type Contact struct {
 	UpdatedAt  time.Time
 }
 
+// ContactWithUser is a contact together with the registered user it links to.
+// User is nil for contacts that are not app users, or whose user no longer exists.
+type ContactWithUser struct {
+	Contact
+	User *User
+}
+
+// IsActiveUser reports whether the contact links to an existing app user
+func (c *ContactWithUser) IsActiveUser() bool {
+	return c.IsUser && c.User != nil
+}
+
+// VerifiedName returns the name the linked user registered with, or nil when
+// the contact is not an active app user
+func (c *ContactWithUser) VerifiedName() *string {
+	if !c.IsActiveUser() {
+		return nil
+	}
+	name := c.User.FullName()
+	return &name
+}
+
 // UserContact represents the many-to-many relationship between users and contacts
 // with user-specific contact information
 type UserContact struct {
-	ID        uuid.UUID
-	UserID    uuid.UUID
-	ContactID uuid.UUID
-	Name      string
-	Email     *string
-	Phone     *string
-	Notes     *string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	ContactID  uuid.UUID
+	Name       string
+	Email      *string
+	Phone      *string
+	Notes      *string
+	IsFavorite bool
+	IsBlocked  bool // Blocked contacts can't be used for new debts or linked back to the user
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
 
 // CreateContactRequest represents a request to create a new contact
@@ -48,15 +103,63 @@ type UpdateContactRequest struct {
 // ContactResponse represents a contact with user-specific information
 // This combines Contact entity (identity, IsUser) with UserContact (user-specific data)
 type ContactResponse struct {
-	ID        uuid.UUID  `json:"id"`
-	Name      string     `json:"name"`
-	Email     *string    `json:"email"`
-	Phone     *string    `json:"phone"`
-	Notes     *string    `json:"notes"`
-	IsUser    bool       `json:"is_user"`
-	UserIDRef *uuid.UUID `json:"user_id_ref,omitempty"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
+	ID           uuid.UUID  `json:"id"`
+	Name         string     `json:"name"`
+	Email        *string    `json:"email"`
+	Phone        *string    `json:"phone"`
+	Notes        *string    `json:"notes"`
+	IsUser       bool       `json:"is_user"`
+	UserIDRef    *uuid.UUID `json:"user_id_ref,omitempty"`
+	IsActiveUser bool       `json:"is_active_user"`          // Linked app user still exists
+	VerifiedName *string    `json:"verified_name,omitempty"` // Registered name of the linked user
+	IsFavorite   bool       `json:"is_favorite"`
+	IsBlocked    bool       `json:"is_blocked"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// ApplyNameSource shows the linked user's verified name as the contact's name when
+// source prefers it. Contacts that are not active app users keep their saved name.
+func (c *ContactResponse) ApplyNameSource(source string) {
+	if source == ContactNameSourceVerified && c.VerifiedName != nil {
+		c.Name = *c.VerifiedName
+	}
+}
+
+// NewContactResponse combines a contact, loaded with its linked user, with the
+// user's own details for it, named as nameSource says
+func NewContactResponse(contact *ContactWithUser, userContact *UserContact, nameSource string) ContactResponse {
+	response := ContactResponse{
+		ID:           contact.ID,
+		Name:         userContact.Name,
+		Email:        userContact.Email,
+		Phone:        userContact.Phone,
+		Notes:        userContact.Notes,
+		IsUser:       contact.IsUser,
+		UserIDRef:    contact.UserIDRef,
+		IsActiveUser: contact.IsActiveUser(),
+		VerifiedName: contact.VerifiedName(),
+		IsFavorite:   userContact.IsFavorite,
+		IsBlocked:    userContact.IsBlocked,
+		CreatedAt:    userContact.CreatedAt,
+		UpdatedAt:    userContact.UpdatedAt,
+	}
+	response.ApplyNameSource(nameSource)
+	return response
+}
+
+// ContactLookup tells a user whether an email they are about to add as a contact
+// belongs to a registered user. Only the user's display name is revealed.
+type ContactLookup struct {
+	Email       string  `json:"email"`
+	IsUser      bool    `json:"is_user"`
+	DisplayName *string `json:"display_name,omitempty"`
+	WillLink    bool    `json:"will_link"` // Adding the contact links it to the user and creates a reciprocal contact
+}
+
+// ContactConflict points a client at the existing contact a create request duplicates
+type ContactConflict struct {
+	ExistingContactID uuid.UUID `json:"existing_contact_id"`
 }
 
 // IsValid validates the user contact entity
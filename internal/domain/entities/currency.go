@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// CurrencyPair identifies the rate for converting amounts from one currency into another
+type CurrencyPair struct {
+	From string
+	To   string
+}
+
+// IsValidCurrencyCode reports whether code looks like an ISO 4217 code: three
+// uppercase letters
+func IsValidCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseExchangeRates parses a comma-separated list of FROM:TO=RATE entries, such
+// as "USD:PHP=56.25,EUR:USD=1.08", where one FROM is worth RATE of TO. Currency
+// codes are case-insensitive and an empty value has no rates.
+func ParseExchangeRates(value string) (map[CurrencyPair]decimal.Decimal, error) {
+	rates := make(map[CurrencyPair]decimal.Decimal)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		pair, rateValue, ok := strings.Cut(entry, "=")
+		from, to, okPair := strings.Cut(pair, ":")
+		if !ok || !okPair {
+			return nil, fmt.Errorf("%q must be FROM:TO=RATE", entry)
+		}
+		from = strings.ToUpper(strings.TrimSpace(from))
+		to = strings.ToUpper(strings.TrimSpace(to))
+		if !IsValidCurrencyCode(from) || !IsValidCurrencyCode(to) || from == to {
+			return nil, fmt.Errorf("%q must convert between two different currency codes", entry)
+		}
+		rate, err := decimal.NewFromString(strings.TrimSpace(rateValue))
+		if err != nil || !rate.IsPositive() {
+			return nil, fmt.Errorf("%q must have a positive rate", entry)
+		}
+
+		rates[CurrencyPair{From: from, To: to}] = rate
+	}
+	return rates, nil
+}
@@ -1,6 +1,9 @@
 package entities
 
 import (
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,6 +17,40 @@ const (
 	PaymentStatusFailed    = "failed"
 	PaymentStatusRefunded  = "refunded"
 	PaymentStatusRejected  = "rejected" // New status for rejected payments
+	PaymentStatusDisputed  = "disputed" // Flagged by the verifier for discussion, not yet rejected
+)
+
+// Payment type constants. Adjustments correct the amount paid, e.g. refunding an
+// overpayment, and are the only payments whose amount may be negative.
+const (
+	PaymentTypePayment    = "payment"
+	PaymentTypeAdjustment = "adjustment"
+)
+
+// MaxTextLength is the most characters a description or notes field may hold
+const MaxTextLength = 2000
+
+// MaxReferenceLength is the most characters a payment reference number may hold
+const MaxReferenceLength = 100
+
+// Debt types stored on debt lists and proposals, from the owner's perspective
+const (
+	DebtTypeToReceive = "to_receive"
+	DebtTypeToPay     = "to_pay"
+)
+
+// Rounding modes decide which installments absorb the cents left over when a debt's
+// total does not split evenly into installments
+const (
+	RoundingModeFirst  = "first"  // The first installment
+	RoundingModeLast   = "last"   // The last installment
+	RoundingModeSpread = "spread" // One cent each to the earliest installments
+)
+
+// Debt direction filters, from the viewing user's perspective
+const (
+	DebtDirectionOwedToMe = "owed_to_me"
+	DebtDirectionIOwe     = "i_owe"
 )
 
 // DebtList represents the core debt list entity
@@ -32,10 +69,28 @@ type DebtList struct {
 	NextPaymentDate     time.Time
 	InstallmentPlan     string
 	NumberOfPayments    *int
+	PaymentWeekday      *int // 0 (Sunday) to 6; weekly and biweekly due dates fall on it when set
+	RoundingMode        string
 	Description         *string
 	Notes               *string
 	CreatedAt           time.Time
 	UpdatedAt           time.Time
+	// Balances holds the amounts owed in currencies other than Currency
+	Balances []DebtBalance
+	// Warnings explains how the create request was adjusted; it is not stored
+	Warnings []string `json:",omitempty"`
+}
+
+// DebtBalance is the amount a debt list owes in one additional currency. The
+// debt list's own TotalAmount and Currency form its main balance, which drives
+// its totals, status and schedule.
+type DebtBalance struct {
+	ID          uuid.UUID
+	DebtListID  uuid.UUID
+	Currency    string
+	TotalAmount decimal.Decimal
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
 
 // DebtItem represents the core debt item (payment) entity
@@ -46,12 +101,19 @@ type DebtItem struct {
 	Currency          string
 	PaymentDate       time.Time
 	PaymentMethod     string
+	PaymentType       string
 	Description       *string
+	Reference         *string // Reference number of the transfer, e.g. from a bank statement
 	Status            string
 	ReceiptPhotoURL   *string
 	VerifiedBy        *uuid.UUID
 	VerifiedAt        *time.Time
 	VerificationNotes *string
+	ResubmissionCount int
+	ResubmittedAt     *time.Time
+	LastNudgedAt      *time.Time // When the submitter last reminded the verifier of the pending payment
+	CreatedBy         uuid.UUID // User who recorded the payment; uuid.Nil for payments recorded before it was tracked
+	ReversalOf        *uuid.UUID // Payment this adjustment partially reverses
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
 }
@@ -59,24 +121,43 @@ type DebtItem struct {
 // CreateDebtListRequest represents a request to create a new debt list
 type CreateDebtListRequest struct {
 	ContactID        uuid.UUID  `json:"contact_id" validate:"required"`
-	DebtType         string     `json:"debt_type" validate:"required,oneof=to_receive to_pay"`
+	DebtType         string     `json:"debt_type" validate:"required,debt_type"`
 	TotalAmount      string     `json:"total_amount" validate:"required"`
 	Currency         string     `json:"currency"`
 	DueDate          *time.Time `json:"due_date"`
-	InstallmentPlan  string     `json:"installment_plan" validate:"omitempty,oneof=onetime weekly biweekly monthly quarterly yearly"`
+	InstallmentPlan  string     `json:"installment_plan" validate:"omitempty,oneof=onetime daily weekly biweekly monthly quarterly yearly"`
 	NumberOfPayments *int       `json:"number_of_payments"`
+	PaymentWeekday   *int       `json:"payment_weekday" validate:"omitempty,min=0,max=6"`
+	RoundingMode     string     `json:"rounding_mode" validate:"omitempty,oneof=first last spread"`
 	Description      *string    `json:"description"`
 	Notes            *string    `json:"notes"`
+	// AdditionalBalances are amounts owed in other currencies under the same debt
+	AdditionalBalances []DebtBalanceRequest `json:"additional_balances" validate:"omitempty,dive"`
+}
+
+// DebtBalanceRequest represents an additional currency balance of a new debt list
+type DebtBalanceRequest struct {
+	Currency    string `json:"currency" validate:"required"`
+	TotalAmount string `json:"total_amount" validate:"required"`
+}
+
+// QuickDebtRequest represents a request to create a onetime debt with derived defaults
+type QuickDebtRequest struct {
+	ContactID uuid.UUID `json:"contact_id" validate:"required"`
+	Amount    string    `json:"amount" validate:"required"`
+	DebtType  string    `json:"debt_type" validate:"required,debt_type"`
 }
 
 // UpdateDebtListRequest represents a request to update a debt list
 type UpdateDebtListRequest struct {
 	TotalAmount      *string    `json:"total_amount"`
 	Currency         *string    `json:"currency"`
-	Status           *string    `json:"status" validate:"omitempty,oneof=active settled archived overdue"`
+	Status           *string    `json:"status" validate:"omitempty,oneof=active overdue settled"`
 	DueDate          *time.Time `json:"due_date"`
-	InstallmentPlan  *string    `json:"installment_plan" validate:"omitempty,oneof=onetime weekly biweekly monthly quarterly yearly"`
+	InstallmentPlan  *string    `json:"installment_plan" validate:"omitempty,oneof=onetime daily weekly biweekly monthly quarterly yearly"`
 	NumberOfPayments *int       `json:"number_of_payments"`
+	PaymentWeekday   *int       `json:"payment_weekday" validate:"omitempty,min=0,max=6"`
+	RoundingMode     *string    `json:"rounding_mode" validate:"omitempty,oneof=first last spread"`
 	Description      *string    `json:"description"`
 	Notes            *string    `json:"notes"`
 }
@@ -88,11 +169,18 @@ type CreateDebtItemRequest struct {
 	Currency          string    `json:"currency"`
 	PaymentDate       time.Time `json:"payment_date" validate:"required"`
 	PaymentMethod     string    `json:"payment_method" validate:"required,oneof=cash bank_transfer check digital_wallet other"`
+	PaymentType       string    `json:"payment_type" validate:"omitempty,oneof=payment adjustment"`
 	Description       *string   `json:"description"`
+	Reference         *string   `json:"reference"`
 	ReceiptPhotoURL   *string   `json:"receipt_photo_url"`
 	VerificationNotes *string   `json:"verification_notes"`
 }
 
+// TransferOwnershipRequest represents a request to hand a debt list over to its contact
+type TransferOwnershipRequest struct {
+	NewOwnerID uuid.UUID `json:"new_owner_id" validate:"required"`
+}
+
 // UpdateDebtItemRequest represents a request to update a debt item
 type UpdateDebtItemRequest struct {
 	Amount            *string    `json:"amount"`
@@ -105,12 +193,44 @@ type UpdateDebtItemRequest struct {
 	VerificationNotes *string    `json:"verification_notes"`
 }
 
+// ResubmitDebtItemRequest represents a request to resubmit a rejected debt item for verification
+type ResubmitDebtItemRequest struct {
+	ReceiptPhotoURL   *string `json:"receipt_photo_url"`
+	VerificationNotes *string `json:"verification_notes"`
+}
+
+// DisputeDebtItemRequest represents a request to flag a pending payment as disputed
+type DisputeDebtItemRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+// ReversePaymentRequest represents a request to partially reverse a completed payment
+type ReversePaymentRequest struct {
+	Amount string `json:"amount" validate:"required"`
+	Reason string `json:"reason" validate:"required,max=2000"`
+}
+
+// MoveDebtItemRequest represents a request to move a payment to another debt list
+type MoveDebtItemRequest struct {
+	TargetDebtListID uuid.UUID `json:"target_debt_list_id" validate:"required"`
+}
+
 // VerifyDebtItemRequest represents a request to verify a debt item
 type VerifyDebtItemRequest struct {
 	Status            string  `json:"status" validate:"required,oneof=completed rejected"`
 	VerificationNotes *string `json:"verification_notes"`
 }
 
+// PaymentMatch is the outcome of comparing a pending payment with the installment
+// it is expected to cover. AutoVerified is set when the match also verified it.
+type PaymentMatch struct {
+	Matched        bool            `json:"matched"`
+	AutoVerified   bool            `json:"auto_verified"`
+	ExpectedAmount decimal.Decimal `json:"expected_amount"`
+	PaymentNumber  int             `json:"payment_number,omitempty"`
+	Payment        *DebtItem       `json:"payment"`
+}
+
 // DebtItemVerificationResponse represents verification details for a debt item
 type DebtItemVerificationResponse struct {
 	ID                uuid.UUID  `json:"id"`
@@ -129,17 +249,415 @@ type PaymentScheduleItem struct {
 	ScheduledAmount  decimal.Decimal `json:"scheduled_amount"`  // Original scheduled amount for this payment
 	PaidAmount       decimal.Decimal `json:"paid_amount"`       // Amount already paid
 	Status           string          `json:"status"`            // pending, paid, overdue, missed
+	PaymentIDs       []uuid.UUID     `json:"payment_ids"`       // Payments that contributed to PaidAmount
+	Display          *ScheduleItemDisplay `json:"display,omitempty"` // Amounts converted for display, when requested
+}
+
+// ScheduleItemDisplay holds a schedule item's amounts converted into another
+// currency for display only; the stored amounts stay in the debt's currency
+type ScheduleItemDisplay struct {
+	Currency        string          `json:"currency"`
+	ExchangeRate    decimal.Decimal `json:"exchange_rate"`
+	Amount          decimal.Decimal `json:"amount"`
+	ScheduledAmount decimal.Decimal `json:"scheduled_amount"`
+	PaidAmount      decimal.Decimal `json:"paid_amount"`
+}
+
+// UserCurrencies lists the currencies a user works with: those of their debts plus
+// their default currency, which is always included
+type UserCurrencies struct {
+	DefaultCurrency string   `json:"default_currency"`
+	Currencies      []string `json:"currencies"`
+}
+
+// OverdueAcknowledgment records when a user last marked their overdue debts as
+// seen. Debts that became overdue after it are new to the user.
+type OverdueAcknowledgment struct {
+	AcknowledgedAt time.Time `json:"acknowledged_at"`
+}
+
+// Schedule variance statuses
+const (
+	VarianceEarly   = "early"
+	VarianceOnTime  = "on_time"
+	VarianceLate    = "late"
+	VariancePartial = "partial"
+	VarianceUnpaid  = "unpaid"
+)
+
+// ScheduleVariance compares one scheduled installment with what was actually paid
+// towards it. ActualDate is when the installment was completed, or when the last
+// payment towards it was made if it is only partially paid. DaysVariance is positive
+// when the payment was late and AmountVariance negative when it fell short.
+type ScheduleVariance struct {
+	PaymentNumber   int             `json:"payment_number"`
+	ScheduledDate   time.Time       `json:"scheduled_date"`
+	ScheduledAmount decimal.Decimal `json:"scheduled_amount"`
+	ActualDate      *time.Time      `json:"actual_date"`
+	ActualAmount    decimal.Decimal `json:"actual_amount"`
+	DaysVariance    *int            `json:"days_variance"`
+	AmountVariance  decimal.Decimal `json:"amount_variance"`
+	Status          string          `json:"status"`
+}
+
+// NextPayment represents the next unpaid installment of a debt list, with DebtType
+// expressed from the viewing user's perspective
+type NextPayment struct {
+	DebtListID    uuid.UUID       `json:"debt_list_id"`
+	DebtType      string          `json:"debt_type"`
+	PaymentNumber int             `json:"payment_number"`
+	DueDate       time.Time       `json:"due_date"`
+	Amount        decimal.Decimal `json:"amount"`
+	Currency      string          `json:"currency"`
+	Status        string          `json:"status"`
+}
+
+// PayoffProjection projects when a debt list will be paid off if each remaining
+// installment is paid in full on schedule from now on. Installments already overdue
+// are projected one per period starting today.
+type PayoffProjection struct {
+	DebtListID          uuid.UUID       `json:"debt_list_id"`
+	RemainingAmount     decimal.Decimal `json:"remaining_amount"`
+	Currency            string          `json:"currency"`
+	RemainingPayments   int             `json:"remaining_payments"`
+	ProjectedPayoffDate *time.Time      `json:"projected_payoff_date"` // Nil once every installment is paid
+	ScheduledPayoffDate time.Time       `json:"scheduled_payoff_date"` // Due date of the last scheduled installment
+	DaysBehind          int             `json:"days_behind"`
+	OnTrack             bool            `json:"on_track"`
+}
+
+// PaymentVerifier identifies the user allowed to verify a payment: the creditor of
+// its debt list. Only their ID and name are revealed.
+type PaymentVerifier struct {
+	DebtItemID  uuid.UUID  `json:"debt_item_id"`
+	Role        string     `json:"role"`    // The verifier's role on the debt list
+	UserID      *uuid.UUID `json:"user_id"` // Nil when the creditor is not a registered user
+	Name        *string    `json:"name"`
+	IsRequester bool       `json:"is_requester"` // The requesting user is the verifier
 }
 
-// PaymentSummary represents a summary of payments for a debt list
+// Roles a user can have on a debt list
+const (
+	DebtRoleOwner   = "owner"
+	DebtRoleContact = "contact"
+)
+
+// DebtView is a debt's type and direction as seen by one of its parties
+type DebtView struct {
+	DebtType  string `json:"debt_type"`
+	Direction string `json:"direction"`
+}
+
+// DebtPerspective contrasts the debt as stored for its owner with the view of the
+// requesting user, which is flipped when they are the debt's contact
+type DebtPerspective struct {
+	DebtListID    uuid.UUID `json:"debt_list_id"`
+	Role          string    `json:"role"`
+	Flipped       bool      `json:"flipped"`
+	OwnerView     DebtView  `json:"owner_view"`
+	EffectiveView DebtView  `json:"effective_view"`
+}
+
+// PaymentSummary represents a summary of payments for a debt list. The top-level
+// totals describe its main currency; Balances breaks them down per currency.
 type PaymentSummary struct {
-	DebtListID       uuid.UUID       `json:"debt_list_id"`
+	DebtListID       uuid.UUID         `json:"debt_list_id"`
+	Currency         string            `json:"currency"`
+	TotalAmount      decimal.Decimal   `json:"total_amount"`
+	TotalPaid        decimal.Decimal   `json:"total_paid"`
+	RemainingDebt    decimal.Decimal   `json:"remaining_debt"`
+	PercentagePaid   decimal.Decimal   `json:"percentage_paid"`
+	NumberOfPayments int               `json:"number_of_payments"`
+	Balances         []CurrencySummary `json:"balances"`
+	Payments         []DebtItem        `json:"payments"`
+}
+
+// CurrencySummary summarizes the payments made against one currency balance of a debt list
+type CurrencySummary struct {
+	Currency         string          `json:"currency"`
 	TotalAmount      decimal.Decimal `json:"total_amount"`
 	TotalPaid        decimal.Decimal `json:"total_paid"`
 	RemainingDebt    decimal.Decimal `json:"remaining_debt"`
 	PercentagePaid   decimal.Decimal `json:"percentage_paid"`
 	NumberOfPayments int             `json:"number_of_payments"`
-	Payments         []DebtItem      `json:"payments"`
+}
+
+// MaxImportRows is the most data rows a payment import file may contain
+const MaxImportRows = 1000
+
+// Payment import row statuses
+const (
+	ImportRowImported = "imported"
+	ImportRowSkipped  = "skipped"
+)
+
+// PaymentImportMapping names the CSV columns, by header, that hold each payment
+// field, and how their values are read
+type PaymentImportMapping struct {
+	DateColumn        string
+	AmountColumn      string
+	ReferenceColumn   string
+	DescriptionColumn string
+	DateFormat        string
+	PaymentMethod     string
+	Currency          string
+}
+
+// PaymentImportResult reports the outcome of every data row of a payment import
+type PaymentImportResult struct {
+	Imported int                      `json:"imported"`
+	Skipped  int                      `json:"skipped"`
+	Rows     []PaymentImportRowResult `json:"rows"`
+}
+
+// PaymentImportRowResult is the outcome of one CSV row, identified by its line number
+type PaymentImportRowResult struct {
+	Line      int        `json:"line"`
+	Status    string     `json:"status"`
+	PaymentID *uuid.UUID `json:"payment_id,omitempty"`
+	Error     string     `json:"error,omitempty"`
+}
+
+// ContactSummary gathers everything about one contact as seen by the user,
+// across the debts they own and the debts the contact recorded with them
+type ContactSummary struct {
+	Contact       ContactResponse     `json:"contact"`
+	IsAppUser     bool                `json:"is_app_user"`
+	OwedToMeCount int                 `json:"owed_to_me_count"`
+	IOweCount     int                 `json:"i_owe_count"`
+	NetBalances   []ContactNetBalance `json:"net_balances"`
+	NextPayment   *NextPayment        `json:"next_payment"`
+}
+
+// ContactNetBalance is what is still owed between the user and a contact in one
+// currency. Net is positive when the contact owes the user on balance.
+type ContactNetBalance struct {
+	Currency string          `json:"currency"`
+	OwedToMe decimal.Decimal `json:"owed_to_me"`
+	IOwe     decimal.Decimal `json:"i_owe"`
+	Net      decimal.Decimal `json:"net"`
+}
+
+// ContactDebts groups the debts between the user and one contact, both the ones
+// the user recorded and the ones the contact recorded with them
+type ContactDebts struct {
+	Contact     ContactResponse     `json:"contact"`
+	NetBalances []ContactNetBalance `json:"net_balances"`
+	Debts       []DebtListResponse  `json:"debts"`
+}
+
+// ContactReconciliation sets the debts the user recorded with a contact who is a
+// registered user against the debts that user recorded with them, currency by
+// currency and from the requesting user's perspective
+type ContactReconciliation struct {
+	ContactID     uuid.UUID                   `json:"contact_id"`
+	Currencies    []CurrencyReconciliation    `json:"currencies"`
+	Discrepancies []ReconciliationDiscrepancy `json:"discrepancies"`
+	Reconciled    bool                        `json:"reconciled"` // Both sides' records agree
+}
+
+// CurrencyReconciliation compares both sides' records in one currency. Theirs are
+// the contact's records, expressed from the user's perspective.
+type CurrencyReconciliation struct {
+	Currency string               `json:"currency"`
+	Mine     ReconciliationTotals `json:"mine"`
+	Theirs   ReconciliationTotals `json:"theirs"`
+}
+
+// ReconciliationTotals totals one side's debt lists in one currency
+type ReconciliationTotals struct {
+	DebtCount int             `json:"debt_count"`
+	OwedToMe  decimal.Decimal `json:"owed_to_me"` // Total amount of the debts owed to the user
+	IOwe      decimal.Decimal `json:"i_owe"`      // Total amount of the debts the user owes
+	PaidToMe  decimal.Decimal `json:"paid_to_me"` // Payments made on the debts owed to the user
+	PaidByMe  decimal.Decimal `json:"paid_by_me"` // Payments made on the debts the user owes
+}
+
+// Totals a reconciliation compares
+const (
+	ReconciliationOwedToMe = "owed_to_me"
+	ReconciliationIOwe     = "i_owe"
+	ReconciliationPaidToMe = "paid_to_me"
+	ReconciliationPaidByMe = "paid_by_me"
+)
+
+// ReconciliationDiscrepancy is a total the two sides' records disagree on.
+// Difference is the user's total minus the contact's.
+type ReconciliationDiscrepancy struct {
+	Currency   string          `json:"currency"`
+	Field      string          `json:"field"`
+	Mine       decimal.Decimal `json:"mine"`
+	Theirs     decimal.Decimal `json:"theirs"`
+	Difference decimal.Decimal `json:"difference"`
+}
+
+// PendingVerification is a payment awaiting the user's verification together with
+// the debt it was recorded against, as seen by the verifying user
+type PendingVerification struct {
+	Payment       DebtItem                `json:"payment"`
+	ClaimedAmount decimal.Decimal         `json:"claimed_amount"`
+	Currency      string                  `json:"currency"`
+	SubmittedAt   time.Time               `json:"submitted_at"`
+	DebtList      PendingVerificationDebt `json:"debt_list"`
+	Contact       ContactResponse         `json:"contact"`
+}
+
+// PendingVerificationDebt is the debt list context of a pending verification
+type PendingVerificationDebt struct {
+	ID                 uuid.UUID       `json:"id"`
+	DebtType           string          `json:"debt_type"`
+	TotalAmount        decimal.Decimal `json:"total_amount"`
+	TotalRemainingDebt decimal.Decimal `json:"total_remaining_debt"`
+	Currency           string          `json:"currency"`
+	Status             string          `json:"status"`
+	Description        *string         `json:"description"`
+}
+
+// PaymentFilter narrows a user's payments. An empty Status matches every status,
+// and a nil bound leaves that side of the payment date range open; From is
+// inclusive and To exclusive. Limit and Offset select a page of the matching
+// payments, with a zero Limit selecting all of them.
+type PaymentFilter struct {
+	Status string
+	From   *time.Time
+	To     *time.Time
+	Limit  int
+	Offset int
+}
+
+// UserPayment is a payment on one of the user's debts together with a summary of
+// its debt list, as seen by the user
+type UserPayment struct {
+	Payment  DebtItem        `json:"payment"`
+	DebtList PaymentDebtList `json:"debt_list"`
+}
+
+// PaymentDebtList is the debt list context of a user payment. IsOwner tells
+// whether the user recorded the debt or is its contact.
+type PaymentDebtList struct {
+	ID                 uuid.UUID       `json:"id"`
+	DebtType           string          `json:"debt_type"`
+	TotalAmount        decimal.Decimal `json:"total_amount"`
+	TotalRemainingDebt decimal.Decimal `json:"total_remaining_debt"`
+	Currency           string          `json:"currency"`
+	Status             string          `json:"status"`
+	Description        *string         `json:"description"`
+	IsOwner            bool            `json:"is_owner"`
+}
+
+// BalancePoint is the remaining balance of a debt list as of a given date
+type BalancePoint struct {
+	Date    time.Time       `json:"date"`
+	Balance decimal.Decimal `json:"balance"`
+}
+
+// DebtListHistoryEntry records one field of a debt list changed by an update, with
+// its values before and after as text. A nil value means the field was unset.
+type DebtListHistoryEntry struct {
+	ID         uuid.UUID `json:"id"`
+	DebtListID uuid.UUID `json:"debt_list_id"`
+	ChangedBy  uuid.UUID `json:"changed_by"`
+	Field      string    `json:"field"`
+	OldValue   *string   `json:"old_value"`
+	NewValue   *string   `json:"new_value"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
+
+// DebtStatusCounts represents how many debts a user is party to in each status,
+// counting both debts they own and debts where they are the contact
+type DebtStatusCounts struct {
+	Active              int64 `json:"active"`
+	Overdue             int64 `json:"overdue"`
+	Settled             int64 `json:"settled"`
+	PendingVerification int64 `json:"pending_verification"`
+}
+
+// ActivitySummary aggregates the debt activity a user took part in between From
+// (inclusive) and To (exclusive), with one entry per currency
+type ActivitySummary struct {
+	From       time.Time          `json:"from"`
+	To         time.Time          `json:"to"`
+	Currencies []CurrencyActivity `json:"currencies"`
+}
+
+// CurrencyActivity is the activity in one currency, from the user's perspective:
+// lent and received cover debts owed to the user, borrowed and paid debts the
+// user owes. Payments count completed payments by payment date, leaving
+// adjustments out; verification outcomes count decisions made in the range.
+type CurrencyActivity struct {
+	Currency         string          `json:"currency"`
+	DebtsCreated     int64           `json:"debts_created"`
+	AmountLent       decimal.Decimal `json:"amount_lent"`
+	AmountBorrowed   decimal.Decimal `json:"amount_borrowed"`
+	PaymentsMade     int64           `json:"payments_made"`
+	AmountPaid       decimal.Decimal `json:"amount_paid"`
+	PaymentsReceived int64           `json:"payments_received"`
+	AmountReceived   decimal.Decimal `json:"amount_received"`
+	PaymentsVerified int64           `json:"payments_verified"`
+	PaymentsRejected int64           `json:"payments_rejected"`
+	PaymentsDisputed int64           `json:"payments_disputed"`
+}
+
+// PaymentMethodStats counts the completed payments made with one payment method in
+// one currency, on the debts the user owns or is the contact of. Adjustments and
+// reversals are left out.
+type PaymentMethodStats struct {
+	PaymentMethod string          `json:"payment_method"`
+	Currency      string          `json:"currency"`
+	Count         int64           `json:"count"`
+	TotalAmount   decimal.Decimal `json:"total_amount"`
+}
+
+// PaymentAggregate summarizes a debt list's completed payments. TotalPaid sums the
+// payments and adjustments in the list's currency; PaymentCount and
+// LastPaymentDate cover payments in any currency and leave adjustments out.
+type PaymentAggregate struct {
+	TotalPaid       decimal.Decimal
+	PaymentCount    int
+	LastPaymentDate *time.Time
+}
+
+// PaymentTotals are the totals, status and next payment date of a debt list
+// derived from its payments
+type PaymentTotals struct {
+	TotalPaid       decimal.Decimal
+	RemainingDebt   decimal.Decimal
+	Status          string
+	NextPaymentDate time.Time
+}
+
+// OverdueTotal is the remaining balance of a user's overdue debts in one currency,
+// split by direction from the user's perspective
+type OverdueTotal struct {
+	Currency string          `json:"currency"`
+	OwedToMe decimal.Decimal `json:"owed_to_me"`
+	IOwe     decimal.Decimal `json:"i_owe"`
+	Count    int             `json:"count"`
+}
+
+// DebtAttention is what needs a user's attention across their debts: the remaining
+// balances of overdue debts and of those with a payment due within Days, each in
+// the shape of OverdueTotal
+type DebtAttention struct {
+	Days    int            `json:"days"`
+	Overdue []OverdueTotal `json:"overdue"`
+	DueSoon []OverdueTotal `json:"due_soon"`
+}
+
+// DebtDocument represents a file attached to a debt list, such as a signed agreement
+type DebtDocument struct {
+	DebtListID uuid.UUID `json:"debt_list_id"`
+	Filename   string    `json:"filename"`
+	URL        string    `json:"url"`
+}
+
+// ReceiptCleanupResult summarizes a receipt retention cleanup run. In a dry run
+// the targeted receipts are reported but nothing is deleted.
+type ReceiptCleanupResult struct {
+	DryRun      bool     `json:"dry_run"`
+	ReceiptURLs []string `json:"receipt_urls"`
+	Deleted     int      `json:"deleted"`
+	Failed      int      `json:"failed"`
 }
 
 // UpcomingPayment represents an upcoming payment
@@ -170,6 +688,8 @@ type DebtListResponse struct {
 	NextPaymentDate     time.Time       `json:"next_payment_date"`
 	InstallmentPlan     string          `json:"installment_plan"`
 	NumberOfPayments    *int            `json:"number_of_payments"`
+	PaymentWeekday      *int            `json:"payment_weekday"`
+	RoundingMode        string          `json:"rounding_mode"`
 	Description         *string         `json:"description"`
 	Notes               *string         `json:"notes"`
 	CreatedAt           time.Time       `json:"created_at"`
@@ -178,6 +698,16 @@ type DebtListResponse struct {
 	Payments            []DebtItem      `json:"payments,omitempty"`
 }
 
+// DebtListChange is a debt list that changed since a client's last sync. Its
+// Payments hold only the payments that changed, and DeletedPaymentIDs the ones
+// deleted since, when the client asked for payments. A debt list deleted since
+// is reported with only its ID and Deleted set.
+type DebtListChange struct {
+	DebtListResponse
+	Deleted           bool        `json:"deleted"`
+	DeletedPaymentIDs []uuid.UUID `json:"deleted_payment_ids,omitempty"`
+}
+
 // IsValid validates the debt list entity
 func (d *DebtList) IsValid() error {
 	if d.UserID == uuid.Nil {
@@ -186,7 +716,7 @@ func (d *DebtList) IsValid() error {
 	if d.ContactID == uuid.Nil {
 		return ErrInvalidInput
 	}
-	if d.DebtType != "to_receive" && d.DebtType != "to_pay" {
+	if !IsValidDebtType(d.DebtType) {
 		return ErrInvalidDebtType
 	}
 	if d.TotalAmount.LessThanOrEqual(decimal.Zero) {
@@ -195,6 +725,17 @@ func (d *DebtList) IsValid() error {
 	if d.Currency == "" {
 		return ErrInvalidCurrency
 	}
+	if d.PaymentWeekday != nil {
+		if *d.PaymentWeekday < 0 || *d.PaymentWeekday > 6 {
+			return ErrInvalidPaymentWeekday
+		}
+		if d.InstallmentPlan != "weekly" && d.InstallmentPlan != "biweekly" {
+			return ErrInvalidPaymentWeekday
+		}
+	}
+	if d.RoundingMode != "" && !IsValidRoundingMode(d.RoundingMode) {
+		return ErrInvalidRoundingMode
+	}
 	return nil
 }
 
@@ -203,21 +744,30 @@ func (d *DebtItem) IsValid() error {
 	if d.DebtListID == uuid.Nil {
 		return ErrInvalidInput
 	}
-	if d.Amount.LessThanOrEqual(decimal.Zero) {
+	if d.Amount.IsZero() || (d.Amount.IsNegative() && !d.IsAdjustment()) {
 		return ErrInvalidAmount
 	}
+	if d.PaymentType != "" && d.PaymentType != PaymentTypePayment && d.PaymentType != PaymentTypeAdjustment {
+		return ErrInvalidPaymentType
+	}
 	if d.Currency == "" {
 		return ErrInvalidCurrency
 	}
 	if d.PaymentMethod == "" {
 		return ErrInvalidPaymentMethod
 	}
-	if d.Status != "" && d.Status != PaymentStatusCompleted && d.Status != PaymentStatusPending && d.Status != PaymentStatusFailed && d.Status != PaymentStatusRefunded && d.Status != PaymentStatusRejected {
+	if d.Status != "" && d.Status != PaymentStatusCompleted && d.Status != PaymentStatusPending && d.Status != PaymentStatusFailed && d.Status != PaymentStatusRefunded && d.Status != PaymentStatusRejected && d.Status != PaymentStatusDisputed {
 		return ErrInvalidPaymentStatus
 	}
 	return nil
 }
 
+// IsAdjustment reports whether the item corrects the amount paid rather than
+// being a payment towards the debt
+func (d *DebtItem) IsAdjustment() bool {
+	return d.PaymentType == PaymentTypeAdjustment
+}
+
 // IsSettled checks if the debt is fully settled
 func (d *DebtList) IsSettled() bool {
 	return d.TotalRemainingDebt.LessThanOrEqual(decimal.Zero) || d.Status == "settled"
@@ -228,12 +778,136 @@ func (d *DebtList) IsOverdue() bool {
 	return time.Now().After(d.NextPaymentDate) && !d.IsSettled()
 }
 
+// MatchesDirection reports whether the debt, with DebtType already expressed from
+// the viewing user's perspective, falls under direction. An empty direction matches all.
+func (d *DebtList) MatchesDirection(direction string) bool {
+	switch direction {
+	case "":
+		return true
+	case DebtDirectionOwedToMe:
+		return d.DebtType == DebtTypeToReceive
+	case DebtDirectionIOwe:
+		return d.DebtType == DebtTypeToPay
+	default:
+		return false
+	}
+}
+
+// DebtDirectionFor returns the direction filter a debt type falls under
+func DebtDirectionFor(debtType string) string {
+	if debtType == DebtTypeToPay {
+		return DebtDirectionIOwe
+	}
+	return DebtDirectionOwedToMe
+}
+
+// DebtSortFields are the fields debt list listings can be sorted by
+var DebtSortFields = []string{"created_at", "updated_at", "due_date", "next_payment_date", "total_amount", "total_remaining_debt"}
+
+// DebtSort orders debt list listings by one of DebtSortFields
+type DebtSort struct {
+	Field      string
+	Descending bool
+}
+
+// ParseDebtSort parses a sort such as "next_payment_date asc". The direction is
+// optional and defaults to ascending.
+func ParseDebtSort(value string) (DebtSort, error) {
+	parts := strings.Fields(strings.ToLower(value))
+	if len(parts) == 0 || len(parts) > 2 {
+		return DebtSort{}, ErrInvalidDebtSort
+	}
+
+	sort := DebtSort{Field: parts[0]}
+	if !slices.Contains(DebtSortFields, sort.Field) {
+		return DebtSort{}, fmt.Errorf("%w: %s is not a sortable field", ErrInvalidDebtSort, sort.Field)
+	}
+	if len(parts) == 2 {
+		switch parts[1] {
+		case "asc":
+		case "desc":
+			sort.Descending = true
+		default:
+			return DebtSort{}, fmt.Errorf("%w: direction must be asc or desc", ErrInvalidDebtSort)
+		}
+	}
+	return sort, nil
+}
+
+// IsValidRoundingMode checks if mode is a known rounding mode
+func IsValidRoundingMode(mode string) bool {
+	return mode == RoundingModeFirst || mode == RoundingModeLast || mode == RoundingModeSpread
+}
+
+// IsValidDebtType checks if debtType is one of the stored debt types
+func IsValidDebtType(debtType string) bool {
+	return debtType == DebtTypeToReceive || debtType == DebtTypeToPay
+}
+
+// IsValidPaymentStatus checks if status is one of the stored payment statuses
+func IsValidPaymentStatus(status string) bool {
+	switch status {
+	case PaymentStatusCompleted, PaymentStatusPending, PaymentStatusFailed,
+		PaymentStatusRefunded, PaymentStatusRejected, PaymentStatusDisputed:
+		return true
+	default:
+		return false
+	}
+}
+
+// NormalizeDebtType returns the stored form of debtType. Case and surrounding
+// whitespace are ignored, and the legacy "owed_to_me" and "i_owe" values older
+// clients send map to "to_receive" and "to_pay".
+func NormalizeDebtType(debtType string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(debtType))
+	switch normalized {
+	case DebtTypeToReceive, DebtDirectionOwedToMe:
+		return DebtTypeToReceive, nil
+	case DebtTypeToPay, DebtDirectionIOwe:
+		return DebtTypeToPay, nil
+	default:
+		return "", ErrInvalidDebtType
+	}
+}
+
+// OppositeDebtType returns the debt type as seen from the other party's side
+func OppositeDebtType(debtType string) string {
+	switch debtType {
+	case DebtTypeToReceive:
+		return DebtTypeToPay
+	case DebtTypeToPay:
+		return DebtTypeToReceive
+	default:
+		return debtType
+	}
+}
+
+// IsValidDebtDirection checks if direction is empty or a known direction filter
+func IsValidDebtDirection(direction string) bool {
+	return direction == "" || direction == DebtDirectionOwedToMe || direction == DebtDirectionIOwe
+}
+
 // CalculateProgress returns the payment progress as a percentage
 func (d *DebtList) CalculateProgress() decimal.Decimal {
-	if d.TotalAmount.LessThanOrEqual(decimal.Zero) {
+	return PercentagePaid(d.TotalPaymentsMade, d.TotalAmount)
+}
+
+// PercentagePaid returns paid as a percentage of total, rounded half-up to 2
+// decimals. A fully paid total is always 100, and rounding never reports an
+// unpaid balance as 100.
+func PercentagePaid(paid, total decimal.Decimal) decimal.Decimal {
+	if total.LessThanOrEqual(decimal.Zero) {
 		return decimal.Zero
 	}
-	return d.TotalPaymentsMade.Div(d.TotalAmount).Mul(decimal.NewFromInt(100))
+	hundred := decimal.NewFromInt(100)
+	if paid.GreaterThanOrEqual(total) {
+		return hundred
+	}
+	percentage := paid.Div(total).Mul(hundred).Round(2)
+	if percentage.GreaterThanOrEqual(hundred) {
+		return decimal.RequireFromString("99.99")
+	}
+	return percentage
 }
 
 
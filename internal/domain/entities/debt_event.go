@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// DebtEventOverdue is emitted when a debt list becomes overdue
+const DebtEventOverdue = "debt_list.overdue"
+
+// DebtEventPaymentNudged is emitted when the submitter of a pending payment
+// reminds its verifier to verify it
+const DebtEventPaymentNudged = "payment.nudged"
+
+// DebtEvent describes a change in a debt list that notifications or reminders can
+// react to. Payment events also name the payment and the user to notify.
+type DebtEvent struct {
+	Type          string          `json:"type"`
+	DebtListID    uuid.UUID       `json:"debt_list_id"`
+	UserID        uuid.UUID       `json:"user_id"`
+	ContactID     uuid.UUID       `json:"contact_id"`
+	DebtType      string          `json:"debt_type"`
+	Status        string          `json:"status"`
+	RemainingDebt decimal.Decimal `json:"remaining_debt"`
+	Currency      string          `json:"currency"`
+	DueDate       time.Time       `json:"due_date"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	PaymentID     *uuid.UUID      `json:"payment_id,omitempty"`
+	RecipientID   *uuid.UUID      `json:"recipient_id,omitempty"`
+}
@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Debt proposal status constants
+const (
+	DebtProposalStatusPending   = "pending"
+	DebtProposalStatusAccepted  = "accepted"
+	DebtProposalStatusRejected  = "rejected"
+	DebtProposalStatusCancelled = "cancelled"
+)
+
+// DebtProposal represents proposed debt terms awaiting the contact's acceptance.
+// Terms are stored from the proposer's perspective; on acceptance they become a
+// DebtList owned by the proposer.
+type DebtProposal struct {
+	ID               uuid.UUID
+	ProposerID       uuid.UUID
+	RecipientID      uuid.UUID
+	ContactID        uuid.UUID
+	DebtType         string
+	TotalAmount      decimal.Decimal
+	Currency         string
+	DueDate          *time.Time
+	InstallmentPlan  string
+	NumberOfPayments *int
+	Description      *string
+	Notes            *string
+	Status           string
+	DebtListID       *uuid.UUID
+	RespondedAt      *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// IsValid validates the debt proposal entity
+func (p *DebtProposal) IsValid() error {
+	if p.ProposerID == uuid.Nil || p.RecipientID == uuid.Nil || p.ContactID == uuid.Nil {
+		return ErrInvalidInput
+	}
+	if !IsValidDebtType(p.DebtType) {
+		return ErrInvalidDebtType
+	}
+	if p.TotalAmount.LessThanOrEqual(decimal.Zero) {
+		return ErrInvalidAmount
+	}
+	return nil
+}
+
+// IsPending reports whether the proposal is still awaiting a response
+func (p *DebtProposal) IsPending() bool {
+	return p.Status == DebtProposalStatusPending
+}
+
+// ToCreateDebtListRequest converts the proposed terms into a debt list creation request
+func (p *DebtProposal) ToCreateDebtListRequest() *CreateDebtListRequest {
+	return &CreateDebtListRequest{
+		ContactID:        p.ContactID,
+		DebtType:         p.DebtType,
+		TotalAmount:      p.TotalAmount.String(),
+		Currency:         p.Currency,
+		DueDate:          p.DueDate,
+		InstallmentPlan:  p.InstallmentPlan,
+		NumberOfPayments: p.NumberOfPayments,
+		Description:      p.Description,
+		Notes:            p.Notes,
+	}
+}
@@ -1,6 +1,10 @@
 package entities
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
 
 // Domain errors
 var (
@@ -13,12 +17,16 @@ var (
 	ErrInvalidFirstName  = errors.New("first name is required")
 	ErrInvalidLastName   = errors.New("last name is required")
 	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrUserSettingsNotFound = errors.New("user settings not found")
+	ErrInvalidReminderLeadDays = errors.New("reminder lead days must be between 0 and 30")
 
 	// Contact errors
 	ErrContactNotFound     = errors.New("contact not found")
 	ErrContactAlreadyExists = errors.New("contact already exists")
 	ErrContactPhoneExists   = errors.New("contact with this phone number already exists")
 	ErrInvalidContactName  = errors.New("contact name is required")
+	ErrContactHasActiveDebts = errors.New("contact has unsettled debts")
+	ErrContactBlocked = errors.New("contact is blocked")
 
 	// Debt errors
 	ErrDebtListNotFound     = errors.New("debt list not found")
@@ -28,7 +36,46 @@ var (
 	ErrInvalidCurrency      = errors.New("invalid currency")
 	ErrInvalidPaymentMethod = errors.New("invalid payment method")
 	ErrInvalidDueDate       = errors.New("due date must be in the future")
+	ErrConflictingSchedule  = errors.New("due date conflicts with the one implied by the number of payments")
+	ErrTooManyPayments      = errors.New("number of payments exceeds the maximum allowed")
+	ErrTotalAmountTooLarge  = errors.New("total amount exceeds the maximum allowed")
 	ErrInvalidPaymentStatus = errors.New("invalid payment status")
+	ErrInvalidPaymentType   = errors.New("invalid payment type")
+	ErrInvalidInstallmentPlan = errors.New("invalid installment plan")
+	ErrInvalidRoundingMode   = errors.New("rounding mode must be first, last or spread")
+	ErrInvalidDebtSort       = errors.New("invalid debt sort")
+	ErrExchangeRateUnavailable = errors.New("no exchange rate between the currencies")
+	ErrInvalidPaymentWeekday = errors.New("payment weekday must be 0 (Sunday) to 6 and is only allowed for weekly or biweekly plans")
+	ErrPaymentDateTooFarInFuture = errors.New("payment date is too far in the future")
+	ErrTextTooLong = errors.New("description and notes must be at most 2000 characters")
+	ErrReferenceTooLong = errors.New("reference must be at most 100 characters")
+	ErrInvalidDebtDirection = errors.New("invalid debt direction")
+	ErrPaymentAlreadyProcessed = errors.New("payment has already been processed")
+	ErrPaymentNotRejected = errors.New("only rejected payments can be resubmitted")
+	ErrPaymentNotPending = errors.New("only pending payments can be nudged")
+	ErrNudgeTooSoon = errors.New("payment was nudged too recently")
+	ErrNotificationsUnavailable = errors.New("notifications are unavailable")
+	ErrInvalidDebtStatus = errors.New("invalid debt status")
+	ErrConflictingDebtStatus = errors.New("status conflicts with the debt's remaining balance or schedule")
+	ErrDebtDocumentNotFound = errors.New("debt document not found")
+	ErrInvalidNewOwner = errors.New("new owner must be the registered user the debt list is shared with")
+	ErrReciprocalContactNotFound = errors.New("new owner has no contact for the current owner")
+	ErrInvalidImportFile = errors.New("invalid payment import file")
+	ErrPaymentNotReversible = errors.New("only completed payments can be reversed")
+	ErrReversalExceedsPayment = errors.New("reversal exceeds the payment's unreversed amount")
+	ErrPaymentNotMovable = errors.New("payments linked by a reversal cannot be moved")
+	ErrPaymentAlreadyOnDebtList = errors.New("payment is already on the target debt list")
+	ErrPaymentHasReversals = errors.New("payment has reversals, which must be deleted first")
+
+	// Debt proposal errors
+	ErrDebtProposalNotFound   = errors.New("debt proposal not found")
+	ErrDebtProposalNotPending = errors.New("debt proposal has already been answered")
+	ErrContactNotAppUser      = errors.New("contact is not a registered user")
+	ErrInvalidDateRange = errors.New("date range must end after it starts")
+
+	// API key errors
+	ErrAPIKeyNotFound = errors.New("API key not found")
+	ErrInvalidAPIKey  = errors.New("invalid API key")
 
 	// Generic errors
 	ErrInvalidInput       = errors.New("invalid input")
@@ -38,3 +85,19 @@ var (
 	ErrInvalidToken       = errors.New("invalid token")
 	ErrTokenExpired       = errors.New("token expired")
 )
+
+// DuplicateContactError reports that the user already has a contact with the
+// same email, pointing at it so clients can offer to open it instead. It
+// matches ErrContactAlreadyExists with errors.Is.
+type DuplicateContactError struct {
+	ExistingContactID uuid.UUID
+}
+
+func (e *DuplicateContactError) Error() string {
+	return ErrContactAlreadyExists.Error()
+}
+
+// Is lets errors.Is(err, ErrContactAlreadyExists) match duplicate contact errors
+func (e *DuplicateContactError) Is(target error) bool {
+	return target == ErrContactAlreadyExists
+}
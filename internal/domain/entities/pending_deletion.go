@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PendingDeletion is a stored file whose deletion failed and is queued to be
+// retried. Once it has failed MaxAttempts times it is dead-lettered: kept for
+// inspection but no longer retried.
+type PendingDeletion struct {
+	ID             uuid.UUID
+	FileURL        string
+	Attempts       int
+	LastError      string
+	NextAttemptAt  time.Time
+	DeadLetteredAt *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// PendingDeletionRetryResult summarizes a run of the deletion retry worker
+type PendingDeletionRetryResult struct {
+	Deleted      int `json:"deleted"`
+	Failed       int `json:"failed"`
+	DeadLettered int `json:"dead_lettered"`
+}
@@ -39,6 +39,19 @@ type LoginResponse struct {
 	User  User   `json:"user"`
 }
 
+// TokenClaims represents the identity and access scope carried by a JWT
+type TokenClaims struct {
+	UserID uuid.UUID
+	Scope  string
+}
+
+// ScopedTokenResponse represents a newly minted token limited to a scope
+type ScopedTokenResponse struct {
+	Token     string    `json:"token"`
+	Scope     string    `json:"scope"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
 // RegisterResponse represents a registration response
 type RegisterResponse struct {
 	User User `json:"user"`
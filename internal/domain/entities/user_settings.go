@@ -0,0 +1,130 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Default user settings applied when a user has not saved any preferences
+const (
+	DefaultUserCurrency        = "PHP"
+	DefaultUserInstallmentPlan = "onetime"
+	DefaultUserTimezone        = "UTC"
+)
+
+// Bounds and default for how many days before a payment is due a reminder is sent
+const (
+	DefaultReminderLeadDays = 3
+	MaxReminderLeadDays     = 30
+)
+
+// UserSettings represents per-user preferences used to fill in request defaults
+type UserSettings struct {
+	ID                     uuid.UUID
+	UserID                 uuid.UUID
+	NotificationEmail      bool
+	NotificationSMS        bool
+	NotificationFacebook   bool
+	DefaultCurrency        string
+	DefaultInstallmentPlan string
+	Timezone               string
+	EmailReminders         bool
+	ReminderLeadDays       int
+	OverdueAlerts          bool
+	VerificationAlerts     bool
+	OverdueAcknowledgedAt  *time.Time
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+}
+
+// UpdateUserSettingsRequest represents a request to update a user's settings
+type UpdateUserSettingsRequest struct {
+	DefaultCurrency        *string `json:"default_currency"`
+	DefaultInstallmentPlan *string `json:"default_installment_plan" validate:"omitempty,oneof=onetime daily weekly biweekly monthly quarterly yearly"`
+	Timezone               *string `json:"timezone"`
+}
+
+// UserSettingsResponse represents a user's settings
+type UserSettingsResponse struct {
+	DefaultCurrency        string `json:"default_currency"`
+	DefaultInstallmentPlan string `json:"default_installment_plan"`
+	Timezone               string `json:"timezone"`
+}
+
+// NotificationPreferences represents which notifications a user wants to receive
+type NotificationPreferences struct {
+	EmailReminders     bool `json:"email_reminders"`
+	ReminderLeadDays   int  `json:"reminder_lead_days"`
+	OverdueAlerts      bool `json:"overdue_alerts"`
+	VerificationAlerts bool `json:"verification_alerts"`
+}
+
+// UpdateNotificationPreferencesRequest represents a request to update a user's
+// notification preferences. Omitted fields are left unchanged.
+type UpdateNotificationPreferencesRequest struct {
+	EmailReminders     *bool `json:"email_reminders"`
+	ReminderLeadDays   *int  `json:"reminder_lead_days" validate:"omitempty,min=0,max=30"`
+	OverdueAlerts      *bool `json:"overdue_alerts"`
+	VerificationAlerts *bool `json:"verification_alerts"`
+}
+
+// NewDefaultUserSettings returns the settings used for a user with no saved preferences
+func NewDefaultUserSettings(userID uuid.UUID) *UserSettings {
+	return &UserSettings{
+		UserID:                 userID,
+		NotificationEmail:      true,
+		DefaultCurrency:        DefaultUserCurrency,
+		DefaultInstallmentPlan: DefaultUserInstallmentPlan,
+		Timezone:               DefaultUserTimezone,
+		EmailReminders:         true,
+		ReminderLeadDays:       DefaultReminderLeadDays,
+		OverdueAlerts:          true,
+		VerificationAlerts:     true,
+	}
+}
+
+// IsValid validates the user settings entity
+func (s *UserSettings) IsValid() error {
+	if s.UserID == uuid.Nil {
+		return ErrInvalidInput
+	}
+	if s.DefaultCurrency == "" {
+		return ErrInvalidCurrency
+	}
+	if !IsValidInstallmentPlan(s.DefaultInstallmentPlan) {
+		return ErrInvalidInstallmentPlan
+	}
+	if s.ReminderLeadDays < 0 || s.ReminderLeadDays > MaxReminderLeadDays {
+		return ErrInvalidReminderLeadDays
+	}
+	return nil
+}
+
+// ToResponse converts user settings to their API representation
+func (s *UserSettings) ToResponse() UserSettingsResponse {
+	return UserSettingsResponse{
+		DefaultCurrency:        s.DefaultCurrency,
+		DefaultInstallmentPlan: s.DefaultInstallmentPlan,
+		Timezone:               s.Timezone,
+	}
+}
+
+// ToNotificationPreferences returns the notification preferences held in the settings
+func (s *UserSettings) ToNotificationPreferences() NotificationPreferences {
+	return NotificationPreferences{
+		EmailReminders:     s.EmailReminders,
+		ReminderLeadDays:   s.ReminderLeadDays,
+		OverdueAlerts:      s.OverdueAlerts,
+		VerificationAlerts: s.VerificationAlerts,
+	}
+}
+
+// IsValidInstallmentPlan reports whether plan is one of the supported installment plans
+func IsValidInstallmentPlan(plan string) bool {
+	switch plan {
+	case "onetime", "daily", "weekly", "biweekly", "monthly", "quarterly", "yearly":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"pay-your-dues/internal/domain/entities"
+)
+
+// APIKeyRepository defines the interface for API key data access operations
+type APIKeyRepository interface {
+	Create(ctx context.Context, apiKey *entities.APIKey) error
+	GetByHash(ctx context.Context, keyHash string) (*entities.APIKey, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]entities.APIKey, error)
+	// Revoke marks one of the user's keys as revoked, failing with ErrAPIKeyNotFound
+	// if the user has no such active key
+	Revoke(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	UpdateLastUsed(ctx context.Context, id uuid.UUID) error
+}
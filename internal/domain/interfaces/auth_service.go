@@ -13,5 +13,13 @@ type AuthService interface {
 	Register(ctx context.Context, req *entities.CreateUserRequest) (*entities.RegisterResponse, error)
 	Login(ctx context.Context, req *entities.LoginRequest) (*entities.LoginResponse, error)
 	ValidateToken(ctx context.Context, tokenString string) (uuid.UUID, error)
+	ValidateTokenClaims(ctx context.Context, tokenString string) (*entities.TokenClaims, error)
 	GenerateJWT(ctx context.Context, userID uuid.UUID) (string, error)
+	CreateReadOnlyToken(ctx context.Context, userID uuid.UUID) (*entities.ScopedTokenResponse, error)
+
+	// API key operations
+	CreateAPIKey(ctx context.Context, userID uuid.UUID, req *entities.CreateAPIKeyRequest) (*entities.CreateAPIKeyResponse, error)
+	GetAPIKeys(ctx context.Context, userID uuid.UUID) ([]entities.APIKeyResponse, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	ValidateAPIKey(ctx context.Context, key string) (*entities.APIKey, error)
 }
@@ -12,6 +12,7 @@ import (
 type ContactRepository interface {
 	Create(ctx context.Context, contact *entities.Contact) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.Contact, error)
+	GetContactWithUser(ctx context.Context, id uuid.UUID) (*entities.ContactWithUser, error)
 	Update(ctx context.Context, contact *entities.Contact) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	
@@ -24,4 +25,8 @@ type ContactRepository interface {
 	GetUserContactRelationsByContactID(ctx context.Context, contactID uuid.UUID) ([]entities.UserContact, error)
 	GetUserContactsByEmail(ctx context.Context, email string) ([]entities.UserContact, error)
 	ExistsByEmailForUser(ctx context.Context, userID uuid.UUID, email string) (bool, error)
+	GetUserContactByEmail(ctx context.Context, userID uuid.UUID, email string) (*entities.UserContact, error)
+	// IsBlockedBy reports whether blockerID has blocked any of their contacts that
+	// links to the registered user userID
+	IsBlockedBy(ctx context.Context, blockerID, userID uuid.UUID) (bool, error)
 }
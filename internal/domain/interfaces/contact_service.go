@@ -15,6 +15,9 @@ type ContactService interface {
 	GetUserContacts(ctx context.Context, userID uuid.UUID) ([]entities.ContactResponse, error)
 	UpdateContact(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *entities.UpdateContactRequest) (*entities.ContactResponse, error)
 	DeleteContact(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	ToggleFavorite(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.ContactResponse, error)
+	SetBlocked(ctx context.Context, id uuid.UUID, userID uuid.UUID, blocked bool) (*entities.ContactResponse, error)
+	LookupContact(ctx context.Context, userID uuid.UUID, email string) (*entities.ContactLookup, error)
 	CreateContactsForNewUser(ctx context.Context, userID uuid.UUID, userEmail string) error
 	CreateReciprocalContact(ctx context.Context, contactEmail string, contactOwnerID uuid.UUID) error
 }
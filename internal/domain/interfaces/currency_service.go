@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+)
+
+// CurrencyService defines the interface for looking up exchange rates
+type CurrencyService interface {
+	// GetExchangeRate returns how much one unit of from is worth in to, failing
+	// with ErrExchangeRateUnavailable when there is no rate between them
+	GetExchangeRate(ctx context.Context, from, to string) (decimal.Decimal, error)
+}
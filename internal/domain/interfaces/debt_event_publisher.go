@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"pay-your-dues/internal/domain/entities"
+)
+
+// DebtEventPublisher delivers debt events, such as a debt becoming overdue, to
+// whatever sends reminders and notifications
+type DebtEventPublisher interface {
+	Publish(ctx context.Context, event entities.DebtEvent) error
+}
@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"pay-your-dues/internal/domain/entities"
+)
+
+// DebtProposalRepository defines the interface for debt proposal data access operations
+type DebtProposalRepository interface {
+	Create(ctx context.Context, proposal *entities.DebtProposal) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.DebtProposal, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]entities.DebtProposal, error)
+	// Respond records the answer to a pending proposal, failing with
+	// ErrDebtProposalNotPending if the proposal was already answered
+	Respond(ctx context.Context, id uuid.UUID, status string, debtListID *uuid.UUID) error
+	// SetDebtList links an accepted proposal to the debt list created from it
+	SetDebtList(ctx context.Context, id uuid.UUID, debtListID uuid.UUID) error
+	// Reopen puts an accepted proposal without a debt list back to pending
+	Reopen(ctx context.Context, id uuid.UUID) error
+}
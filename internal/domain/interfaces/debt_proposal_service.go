@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"pay-your-dues/internal/domain/entities"
+)
+
+// DebtProposalService defines the interface for debt proposal operations
+type DebtProposalService interface {
+	ProposeDebt(ctx context.Context, userID uuid.UUID, req *entities.CreateDebtListRequest) (*entities.DebtProposal, error)
+	GetUserDebtProposals(ctx context.Context, userID uuid.UUID) ([]entities.DebtProposal, error)
+	AcceptDebtProposal(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtList, error)
+	RejectDebtProposal(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtProposal, error)
+	CancelDebtProposal(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtProposal, error)
+}
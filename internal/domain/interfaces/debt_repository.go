@@ -17,32 +17,88 @@ type DebtListRepository interface {
 	GetByIDWithRelations(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtListResponse, error)
 	GetUserDebtLists(ctx context.Context, userID uuid.UUID) ([]entities.DebtListResponse, error)
 	GetDebtListsWhereUserIsContact(ctx context.Context, userID uuid.UUID) ([]entities.DebtListResponse, error)
+	// GetUserDebtListsModifiedSince and GetDebtListsWhereUserIsContactModifiedSince
+	// narrow the lists above to those that changed, or whose payments changed,
+	// after since
+	GetUserDebtListsModifiedSince(ctx context.Context, userID uuid.UUID, since time.Time) ([]entities.DebtListResponse, error)
+	GetDebtListsWhereUserIsContactModifiedSince(ctx context.Context, userID uuid.UUID, since time.Time) ([]entities.DebtListResponse, error)
 	Update(ctx context.Context, debtList *entities.DebtList) error
+	// Delete deletes the debt list, leaving a tombstone GetDeletedSince reports
 	Delete(ctx context.Context, id uuid.UUID) error
+	// GetDeletedSince gets the IDs of the debt lists the user owned or was the
+	// contact of that were deleted after since
+	GetDeletedSince(ctx context.Context, userID uuid.UUID, since time.Time) ([]uuid.UUID, error)
 	GetOverdueForUser(ctx context.Context, userID uuid.UUID) ([]entities.DebtList, error)
 	GetDueSoonForUser(ctx context.Context, userID uuid.UUID, dueDate time.Time) ([]entities.DebtList, error)
+	GetOverdueWhereUserIsContact(ctx context.Context, userID uuid.UUID) ([]entities.DebtList, error)
+	GetDueSoonWhereUserIsContact(ctx context.Context, userID uuid.UUID, dueDate time.Time) ([]entities.DebtList, error)
 	BelongsToUser(ctx context.Context, debtListID, userID uuid.UUID) (bool, error)
 	IsContactOfDebtList(ctx context.Context, debtListID, userID uuid.UUID) (bool, error)
-	UpdatePaymentTotals(ctx context.Context, debtListID uuid.UUID, totalPaid, remaining decimal.Decimal) error
 	UpdateStatus(ctx context.Context, debtListID uuid.UUID, status string) error
-	UpdateNextPaymentDate(ctx context.Context, debtListID uuid.UUID, nextPaymentDate time.Time) error
+	// RecalculatePaymentTotals locks the debt list, aggregates its payments and saves
+	// the totals compute derives from them, all in one transaction, so concurrent
+	// payment changes cannot interleave with the recalculation. Only completed
+	// payments are aggregated; pending payments never count until verified.
+	RecalculatePaymentTotals(ctx context.Context, debtListID uuid.UUID, compute func(debtList *entities.DebtList, payments entities.PaymentAggregate) entities.PaymentTotals) error
+	GetStatusCounts(ctx context.Context, userID uuid.UUID) (*entities.DebtStatusCounts, error)
+	GetActivitySummary(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]entities.CurrencyActivity, error)
+	GetPaymentMethodStats(ctx context.Context, userID uuid.UUID) ([]entities.PaymentMethodStats, error)
+	TransferOwnership(ctx context.Context, debtListID, fromUserID, toUserID, contactID uuid.UUID, debtType string) error
+	GetBalances(ctx context.Context, debtListID uuid.UUID) ([]entities.DebtBalance, error)
+	GetCurrenciesForUser(ctx context.Context, userID uuid.UUID) ([]string, error)
+	GetActivePastDueIDs(ctx context.Context, before time.Time) ([]uuid.UUID, error)
+	// CountUnsettledForContact counts the user's own debt lists with the contact
+	// that are not yet settled
+	CountUnsettledForContact(ctx context.Context, userID, contactID uuid.UUID) (int64, error)
+	CreateHistoryEntries(ctx context.Context, entries []entities.DebtListHistoryEntry) error
+	// GetHistory returns the recorded field changes of a debt list, newest first
+	GetHistory(ctx context.Context, debtListID uuid.UUID) ([]entities.DebtListHistoryEntry, error)
 }
 
 // DebtItemRepository defines the interface for debt item data access operations
 type DebtItemRepository interface {
 	Create(ctx context.Context, debtItem *entities.DebtItem) error
+	// CreateReversal creates a reversal of a completed payment unless, together
+	// with the payment's other completed reversals, it exceeds the payment
+	CreateReversal(ctx context.Context, reversal *entities.DebtItem) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.DebtItem, error)
 	GetByDebtListID(ctx context.Context, debtListID uuid.UUID) ([]entities.DebtItem, error)
 	Update(ctx context.Context, debtItem *entities.DebtItem) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	DeleteByIDs(ctx context.Context, debtListID uuid.UUID, ids []uuid.UUID) error
+	Purge(ctx context.Context, id uuid.UUID) error
+	PurgeByIDs(ctx context.Context, debtListID uuid.UUID, ids []uuid.UUID) error
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*entities.DebtItem, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+	// RestoreReversal restores a soft-deleted reversal unless its payment is gone
+	// or the reversal no longer fits in what the payment's other reversals left
+	RestoreReversal(ctx context.Context, reversal *entities.DebtItem) error
+	// GetDeletedSince gets the debt items of the given debt lists that were
+	// soft-deleted or purged after since
+	GetDeletedSince(ctx context.Context, debtListIDs []uuid.UUID, since time.Time) ([]entities.DebtItem, error)
+	// GetTotalPaidForDebtList and GetCompletedPaymentsForDebtList only cover payments
+	// in the debt list's main currency
 	GetTotalPaidForDebtList(ctx context.Context, debtListID uuid.UUID) (decimal.Decimal, error)
 	GetCompletedPaymentsForDebtList(ctx context.Context, debtListID uuid.UUID) ([]entities.DebtItem, error)
-	GetLastPaymentDate(ctx context.Context, debtListID uuid.UUID) (*time.Time, error)
+	GetCompletedPaymentsForBalance(ctx context.Context, debtListID uuid.UUID, currency string) ([]entities.DebtItem, error)
+	GetReversedAmount(ctx context.Context, paymentID uuid.UUID) (decimal.Decimal, error)
 	BelongsToUserDebtList(ctx context.Context, debtItemID, userID uuid.UUID) (bool, error)
 	CanUserVerifyDebtItem(ctx context.Context, debtItemID, userID uuid.UUID) (bool, error)
+	// GetVerifier returns the user CanUserVerifyDebtItem allows to verify the debt
+	// item, or nil when its creditor is not a registered user
+	GetVerifier(ctx context.Context, debtItemID uuid.UUID) (*entities.User, error)
+	// GetForUser returns a page of the payments on every debt list the user owns
+	// or is the contact of, newest payment first, each with its debt list as seen
+	// by the user, along with how many payments match the filter in total
+	GetForUser(ctx context.Context, userID uuid.UUID, filter entities.PaymentFilter) ([]entities.UserPayment, int, error)
 	
 	// Verification methods
 	GetPendingVerifications(ctx context.Context, userID uuid.UUID) ([]entities.DebtItem, error)
 	UpdatePaymentStatus(ctx context.Context, debtItemID uuid.UUID, status string, verifiedBy uuid.UUID, notes *string) error
+	ResubmitPayment(ctx context.Context, debtItemID uuid.UUID, photoURL *string, notes *string) error
+	// MarkNudged records a reminder to verify a pending payment unless one was
+	// already recorded after since
+	MarkNudged(ctx context.Context, debtItemID uuid.UUID, nudgedAt, since time.Time) error
 	UpdateReceiptPhoto(ctx context.Context, debtItemID uuid.UUID, photoURL *string) error
+	GetReceiptsForSettledDebts(ctx context.Context, settledBefore time.Time) ([]entities.DebtItem, error)
 }
@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,10 +15,22 @@ import (
 type DebtService interface {
 	// Debt List operations
 	CreateDebtList(ctx context.Context, userID uuid.UUID, req *entities.CreateDebtListRequest) (*entities.DebtList, error)
+	CreateQuickDebt(ctx context.Context, userID uuid.UUID, req *entities.QuickDebtRequest) (*entities.DebtList, error)
 	GetDebtList(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtListResponse, error)
 	GetUserDebtLists(ctx context.Context, userID uuid.UUID) ([]entities.DebtListResponse, error)
+	GetSharedDebtLists(ctx context.Context, userID uuid.UUID) ([]entities.DebtListResponse, error)
+	GetDebtListsModifiedSince(ctx context.Context, userID uuid.UUID, since time.Time, includePayments bool) ([]entities.DebtListChange, error)
 	UpdateDebtList(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *entities.UpdateDebtListRequest) (*entities.DebtList, error)
 	DeleteDebtList(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	TransferOwnership(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *entities.TransferOwnershipRequest) (*entities.DebtList, error)
+	RecomputeOverdueStatuses(ctx context.Context) (int, error)
+	RecomputeDebtList(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtListResponse, error)
+	GetDebtPerspective(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtPerspective, error)
+
+	// Debt list document operations
+	UploadDebtDocument(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID, file io.Reader, filename string, contentType string) (*entities.DebtDocument, error)
+	ImportPayments(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID, file io.Reader, mapping entities.PaymentImportMapping) (*entities.PaymentImportResult, error)
+	GetDebtDocument(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID, filename string) ([]byte, string, error)
 
 	// Debt Item (Payment) operations
 	CreateDebtItem(ctx context.Context, userID uuid.UUID, req *entities.CreateDebtItemRequest) (*entities.DebtItem, error)
@@ -25,18 +38,47 @@ type DebtService interface {
 	GetDebtListItems(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) ([]entities.DebtItem, error)
 	UpdateDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *entities.UpdateDebtItemRequest) (*entities.DebtItem, error)
 	DeleteDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	DeleteDebtItems(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID, ids []uuid.UUID) error
+	MoveDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID, targetDebtListID uuid.UUID) (*entities.DebtItem, error)
+	RestoreDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtItem, error)
 
 	// Payment verification operations
+	ReversePayment(ctx context.Context, id uuid.UUID, userID uuid.UUID, amount decimal.Decimal, reason string) (*entities.DebtItem, error)
 	VerifyDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *entities.VerifyDebtItemRequest) (*entities.DebtItem, error)
+	AutoMatchPayment(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.PaymentMatch, error)
 	GetPendingVerifications(ctx context.Context, userID uuid.UUID) ([]entities.DebtItem, error)
+	GetPaymentVerifier(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.PaymentVerifier, error)
+	GetPendingVerificationQueue(ctx context.Context, userID uuid.UUID, oldestFirst bool) ([]entities.PendingVerification, error)
+	GetUserPayments(ctx context.Context, userID uuid.UUID, filter entities.PaymentFilter) ([]entities.UserPayment, int, error)
 	RejectDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID, notes *string) (*entities.DebtItem, error)
+	DisputeDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *entities.DisputeDebtItemRequest) (*entities.DebtItem, error)
+	ResubmitDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *entities.ResubmitDebtItemRequest) (*entities.DebtItem, error)
+	NudgeDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtItem, error)
 
 	// Debt analytics and reporting
-	GetOverdueItems(ctx context.Context, userID uuid.UUID) ([]entities.DebtList, error)
-	GetDueSoonItems(ctx context.Context, userID uuid.UUID, days int) ([]entities.DebtList, error)
+	GetOverdueItems(ctx context.Context, userID uuid.UUID, direction string) ([]entities.DebtList, error)
+	GetOverdueTotals(ctx context.Context, userID uuid.UUID) ([]entities.OverdueTotal, error)
+	AcknowledgeOverdue(ctx context.Context, userID uuid.UUID) (*entities.OverdueAcknowledgment, error)
+	GetOverdueAcknowledgedAt(ctx context.Context, userID uuid.UUID) (*time.Time, error)
+	GetContactSummary(ctx context.Context, contactID uuid.UUID, userID uuid.UUID) (*entities.ContactSummary, error)
+	GetContactReconciliation(ctx context.Context, contactID uuid.UUID, userID uuid.UUID) (*entities.ContactReconciliation, error)
+	GetDebtsByContact(ctx context.Context, userID uuid.UUID) ([]entities.ContactDebts, error)
+	GetDueSoonItems(ctx context.Context, userID uuid.UUID, days int, direction string) ([]entities.DebtList, error)
+	GetAttention(ctx context.Context, userID uuid.UUID, days int) (*entities.DebtAttention, error)
 	GetPaymentSchedule(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) ([]entities.PaymentScheduleItem, error)
+	GetPaymentScheduleInCurrency(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID, displayCurrency string) ([]entities.PaymentScheduleItem, error)
+	GetScheduleVariance(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) ([]entities.ScheduleVariance, error)
+	GetNextPayment(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) (*entities.NextPayment, error)
+	GetPayoffProjection(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) (*entities.PayoffProjection, error)
+	PreviewPaymentSchedule(ctx context.Context, userID uuid.UUID, req *entities.CreateDebtListRequest) ([]entities.PaymentScheduleItem, error)
 	GetUpcomingPayments(ctx context.Context, userID uuid.UUID, days int) ([]entities.UpcomingPayment, error)
 	GetTotalPaymentsForDebtList(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) (*entities.PaymentSummary, error)
+	GetBalanceHistory(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) ([]entities.BalancePoint, error)
+	GetDebtListHistory(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) ([]entities.DebtListHistoryEntry, error)
+	GetDebtCounts(ctx context.Context, userID uuid.UUID) (*entities.DebtStatusCounts, error)
+	GetActivitySummary(ctx context.Context, userID uuid.UUID, from, to time.Time) (*entities.ActivitySummary, error)
+	GetPaymentMethodStats(ctx context.Context, userID uuid.UUID) ([]entities.PaymentMethodStats, error)
+	GetCurrencies(ctx context.Context, userID uuid.UUID) (*entities.UserCurrencies, error)
 }
 
 // PaymentScheduleService defines the interface for payment schedule calculations
@@ -44,6 +86,8 @@ type PaymentScheduleService interface {
 	CalculateNextPaymentDate(debtList *entities.DebtList, lastPaymentDate *time.Time) time.Time
 	CalculatePaymentSchedule(debtList *entities.DebtList, payments []entities.DebtItem) []entities.PaymentScheduleItem
 	CalculateDueDateFromNumberOfPayments(createdAt time.Time, numberOfPayments int, installmentPlan string) time.Time
-	CalculateInstallmentAmountFromNumberOfPayments(totalAmount decimal.Decimal, numberOfPayments int) decimal.Decimal
-	CalculateInstallmentAmount(totalAmount decimal.Decimal, installmentPlan string, createdAt time.Time, dueDate time.Time) decimal.Decimal
+	// CalculateInstallmentAmountFromNumberOfPayments and CalculateInstallmentAmount
+	// return the regular installment amount for the debt's rounding mode
+	CalculateInstallmentAmountFromNumberOfPayments(totalAmount decimal.Decimal, numberOfPayments int, roundingMode string) decimal.Decimal
+	CalculateInstallmentAmount(totalAmount decimal.Decimal, installmentPlan string, createdAt time.Time, dueDate time.Time, roundingMode string) decimal.Decimal
 }
@@ -20,4 +20,13 @@ type FileStorageService interface {
 	
 	// GetReceiptFile retrieves a receipt file and returns the file content and metadata
 	GetReceiptFile(ctx context.Context, fileURL string) ([]byte, string, error)
+
+	// GetReceiptFilename returns the name a receipt file was uploaded with
+	GetReceiptFilename(ctx context.Context, fileURL string) (string, error)
+
+	// UploadDocument uploads a document attached to a debt list, such as a signed agreement, and returns the relative path
+	UploadDocument(ctx context.Context, file io.Reader, filename string, contentType string, debtID uuid.UUID) (string, error)
+
+	// GetDocumentFile retrieves a debt list document and returns the file content and metadata
+	GetDocumentFile(ctx context.Context, fileURL string) ([]byte, string, error)
 }
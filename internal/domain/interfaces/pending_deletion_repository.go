@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"pay-your-dues/internal/domain/entities"
+)
+
+// PendingDeletionRepository defines the interface for the queue of file deletions awaiting retry
+type PendingDeletionRepository interface {
+	Create(ctx context.Context, pendingDeletion *entities.PendingDeletion) error
+	// GetDue returns up to limit deletions that are not dead-lettered and whose
+	// next attempt is at or before now, earliest first
+	GetDue(ctx context.Context, now time.Time, limit int) ([]entities.PendingDeletion, error)
+	Update(ctx context.Context, pendingDeletion *entities.PendingDeletion) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
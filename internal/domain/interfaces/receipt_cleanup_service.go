@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"pay-your-dues/internal/domain/entities"
+)
+
+// ReceiptCleanupService defines the interface for removing receipts of debts
+// settled longer ago than the retention period
+type ReceiptCleanupService interface {
+	CleanupReceipts(ctx context.Context, dryRun bool) (*entities.ReceiptCleanupResult, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"pay-your-dues/internal/domain/entities"
+)
+
+// ReceiptDeletionService defines the interface for deleting receipts from storage
+// with failed deletions queued and retried in the background
+type ReceiptDeletionService interface {
+	// DeleteReceipt deletes a receipt, queueing it for retry if storage fails. It
+	// only returns an error when the deletion could not be queued either.
+	DeleteReceipt(ctx context.Context, fileURL string) error
+	RetryPendingDeletions(ctx context.Context) (*entities.PendingDeletionRetryResult, error)
+}
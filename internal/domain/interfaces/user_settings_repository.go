@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"pay-your-dues/internal/domain/entities"
+)
+
+// UserSettingsRepository defines the interface for user settings data access operations
+type UserSettingsRepository interface {
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*entities.UserSettings, error)
+	Upsert(ctx context.Context, settings *entities.UserSettings) error
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"pay-your-dues/internal/domain/entities"
+)
+
+// UserSettingsService defines the interface for managing per-user preferences
+type UserSettingsService interface {
+	GetUserSettings(ctx context.Context, userID uuid.UUID) (*entities.UserSettings, error)
+	UpdateUserSettings(ctx context.Context, userID uuid.UUID, req *entities.UpdateUserSettingsRequest) (*entities.UserSettings, error)
+	UpdateNotificationPreferences(ctx context.Context, userID uuid.UUID, req *entities.UpdateNotificationPreferencesRequest) (*entities.UserSettings, error)
+}
@@ -2,10 +2,11 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 
 	"pay-your-dues/internal/domain/entities"
@@ -28,7 +29,7 @@ func NewAuthHandler(authService interfaces.AuthService, logger zerolog.Logger) *
 
 // Register handles user registration
 func (h *AuthHandler) Register(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
 	defer cancel()
 
 	// Extract request ID for logging
@@ -36,13 +37,9 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	logger := h.logger.With().Str("request_id", requestID).Str("method", "Register").Logger()
 
 	var req entities.CreateUserRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		logger.Warn().Err(err).Msg("Invalid request body")
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:     "Invalid request body",
-			Details:   err.Error(),
-			RequestID: requestID,
-		})
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(c, err, requestID))
 		return
 	}
 
@@ -64,37 +61,23 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		// Handle specific error types
 		switch err {
 		case entities.ErrUserAlreadyExists:
-			c.JSON(http.StatusConflict, ErrorResponse{
-				Error:     "User already exists",
-				RequestID: requestID,
-			})
+			c.JSON(http.StatusConflict, NewErrorResponse(c, "User already exists", "", requestID))
 		case entities.ErrInvalidEmail, entities.ErrInvalidPassword, entities.ErrInvalidFirstName, entities.ErrInvalidLastName:
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:     "Invalid input",
-				Details:   err.Error(),
-				RequestID: requestID,
-			})
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid input", err.Error(), requestID))
 		default:
-			c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:     "Internal server error",
-				RequestID: requestID,
-			})
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
 		}
 		return
 	}
 
 	logger.Info().Str("email", req.Email).Str("user_id", response.User.ID.String()).Msg("User registered successfully")
 
-	c.JSON(http.StatusCreated, SuccessResponse{
-		Message:   "User registered successfully",
-		Data:      response,
-		RequestID: requestID,
-	})
+	c.JSON(http.StatusCreated, NewSuccessResponse(c, "User registered successfully", response, requestID))
 }
 
 // Login handles user login
 func (h *AuthHandler) Login(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
 	defer cancel()
 
 	// Extract request ID for logging
@@ -102,13 +85,9 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	logger := h.logger.With().Str("request_id", requestID).Str("method", "Login").Logger()
 
 	var req entities.LoginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		logger.Warn().Err(err).Msg("Invalid request body")
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:     "Invalid request body",
-			Details:   err.Error(),
-			RequestID: requestID,
-		})
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(c, err, requestID))
 		return
 	}
 
@@ -124,32 +103,18 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		// Handle specific error types
 		switch err {
 		case entities.ErrInvalidCredentials:
-			c.JSON(http.StatusUnauthorized, ErrorResponse{
-				Error:     "Invalid credentials",
-				RequestID: requestID,
-			})
+			c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Invalid credentials", "", requestID))
 		case entities.ErrInvalidEmail, entities.ErrInvalidPassword:
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:     "Invalid input",
-				Details:   err.Error(),
-				RequestID: requestID,
-			})
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid input", err.Error(), requestID))
 		default:
-			c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:     "Internal server error",
-				RequestID: requestID,
-			})
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
 		}
 		return
 	}
 
 	logger.Info().Str("email", req.Email).Str("user_id", response.User.ID.String()).Msg("User logged in successfully")
 
-	c.JSON(http.StatusOK, SuccessResponse{
-		Message:   "Login successful",
-		Data:      response,
-		RequestID: requestID,
-	})
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Login successful", response, requestID))
 }
 
 // ValidateToken validates a JWT token (used by middleware)
@@ -160,3 +125,174 @@ func (h *AuthHandler) ValidateToken(ctx context.Context, tokenString string) (st
 	}
 	return userID.String(), nil
 }
+
+// CreateAPIKey handles issuing a new API key for the authenticated user
+func (h *AuthHandler) CreateAPIKey(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userUUID, ok := h.getUserID(c, requestID)
+	if !ok {
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "CreateAPIKey").Logger()
+
+	var req entities.CreateAPIKeyRequest
+	if err := bindJSON(c, &req); err != nil {
+		logger.Warn().Err(err).Msg("Invalid request body")
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(c, err, requestID))
+		return
+	}
+
+	// Sanitize input
+	req.Name = sanitizeString(req.Name)
+
+	logger.Info().Strs("scopes", req.Scopes).Msg("API key creation attempt")
+
+	response, err := h.authService.CreateAPIKey(ctx, userUUID, &req)
+	if err != nil {
+		logger.Error().Err(err).Msg("API key creation failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, entities.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid input", err.Error(), requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	logger.Info().Str("api_key_id", response.ID.String()).Msg("API key created successfully")
+
+	c.JSON(http.StatusCreated, NewSuccessResponse(c, "API key created successfully", response, requestID))
+}
+
+// GetAPIKeys handles listing the authenticated user's API keys
+func (h *AuthHandler) GetAPIKeys(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userUUID, ok := h.getUserID(c, requestID)
+	if !ok {
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "GetAPIKeys").Logger()
+
+	apiKeys, err := h.authService.GetAPIKeys(ctx, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve API keys")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "API keys retrieved successfully", apiKeys, requestID))
+}
+
+// RevokeAPIKey handles revoking one of the authenticated user's API keys
+func (h *AuthHandler) RevokeAPIKey(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userUUID, ok := h.getUserID(c, requestID)
+	if !ok {
+		return
+	}
+
+	// Parse API key ID from URL parameter
+	apiKeyIDStr := c.Param("id")
+	apiKeyID, err := uuid.Parse(apiKeyIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("api_key_id", apiKeyIDStr).Msg("Invalid API key ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid API key ID", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("api_key_id", apiKeyID.String()).Str("method", "RevokeAPIKey").Logger()
+
+	if err := h.authService.RevokeAPIKey(ctx, apiKeyID, userUUID); err != nil {
+		logger.Error().Err(err).Msg("API key revocation failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, entities.ErrAPIKeyNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "API key not found", "", requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	logger.Info().Msg("API key revoked successfully")
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "API key revoked successfully", nil, requestID))
+}
+
+// CreateReadOnlyToken handles minting a token that can read but not modify the user's data
+func (h *AuthHandler) CreateReadOnlyToken(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userUUID, ok := h.getUserID(c, requestID)
+	if !ok {
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "CreateReadOnlyToken").Logger()
+
+	response, err := h.authService.CreateReadOnlyToken(ctx, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Read-only token creation failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	logger.Info().Msg("Read-only token created successfully")
+
+	c.JSON(http.StatusCreated, NewSuccessResponse(c, "Read-only token created successfully", response, requestID))
+}
+
+// getUserID extracts the authenticated user ID, writing a 401 response if missing
+func (h *AuthHandler) getUserID(c *gin.Context, requestID string) (uuid.UUID, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return uuid.Nil, false
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return uuid.Nil, false
+	}
+
+	return userUUID, true
+}
@@ -2,8 +2,9 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"net/http"
-	"time"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -29,7 +30,7 @@ func NewContactHandler(contactService interfaces.ContactService, logger zerolog.
 
 // CreateContact handles contact creation
 func (h *ContactHandler) CreateContact(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
 	defer cancel()
 
 	// Extract request ID and user ID for logging
@@ -37,23 +38,35 @@ func (h *ContactHandler) CreateContact(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
 		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "CreateContact").Logger()
 
+	// In upsert mode a duplicate email returns the existing contact instead of a conflict
+	upsert := false
+	if value := c.Query("upsert"); value != "" {
+		var err error
+		upsert, err = strconv.ParseBool(value)
+		if err != nil {
+			logger.Warn().Str("upsert", value).Msg("Invalid upsert flag")
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid upsert flag", "", requestID))
+			return
+		}
+	}
+
 	var req entities.CreateContactRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		logger.Warn().Err(err).Msg("Invalid request body")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid request body", err.Error(), requestID))
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(c, err, requestID))
 		return
 	}
 
@@ -68,38 +81,72 @@ func (h *ContactHandler) CreateContact(c *gin.Context) {
 		req.Phone = &sanitized
 	}
 	if req.Notes != nil {
-		sanitized := sanitizeString(*req.Notes)
+		sanitized := sanitizeRichText(*req.Notes)
 		req.Notes = &sanitized
 	}
 
 	logger.Info().Str("contact_name", req.Name).Msg("Contact creation attempt")
 
 	contact, err := h.contactService.CreateContact(ctx, userUUID, &req)
+	var duplicate *entities.DuplicateContactError
+	if errors.As(err, &duplicate) && upsert {
+		h.respondWithExistingContact(ctx, c, logger, duplicate.ExistingContactID, userUUID, requestID)
+		return
+	}
 	if err != nil {
 		logger.Error().Err(err).Str("contact_name", req.Name).Msg("Contact creation failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
 		
 		// Handle specific error types
+		if duplicate != nil {
+			response := NewErrorResponse(c, "Contact already exists", "", requestID)
+			response.Data = entities.ContactConflict{ExistingContactID: duplicate.ExistingContactID}
+			c.JSON(http.StatusConflict, response)
+			return
+		}
 		switch err {
 		case entities.ErrContactAlreadyExists:
-			c.JSON(http.StatusConflict, NewErrorResponse("Contact already exists", "", requestID))
+			c.JSON(http.StatusConflict, NewErrorResponse(c, "Contact already exists", "", requestID))
 		case entities.ErrContactPhoneExists:
-			c.JSON(http.StatusConflict, NewErrorResponse("Contact with this phone number already exists", "", requestID))
+			c.JSON(http.StatusConflict, NewErrorResponse(c, "Contact with this phone number already exists", "", requestID))
 		case entities.ErrInvalidContactName:
-			c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid input", err.Error(), requestID))
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid input", err.Error(), requestID))
 		default:
-			c.JSON(http.StatusInternalServerError, NewErrorResponse("Internal server error", "", requestID))
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
 		}
 		return
 	}
 
 	logger.Info().Str("contact_name", req.Name).Str("contact_id", contact.ID.String()).Msg("Contact created successfully")
 
-	c.JSON(http.StatusCreated, NewSuccessResponse("Contact created successfully", contact, requestID))
+	c.JSON(http.StatusCreated, NewSuccessResponse(c, "Contact created successfully", contact, requestID))
+}
+
+// respondWithExistingContact answers an upsert that matched one of the user's
+// contacts with that contact instead of a conflict
+func (h *ContactHandler) respondWithExistingContact(ctx context.Context, c *gin.Context, logger zerolog.Logger, contactID uuid.UUID, userID uuid.UUID, requestID string) {
+	contact, err := h.contactService.GetContact(ctx, contactID, userID)
+	if err != nil {
+		logger.Error().Err(err).Str("contact_id", contactID.String()).Msg("Failed to retrieve existing contact")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	logger.Info().Str("contact_id", contact.ID.String()).Msg("Existing contact returned")
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Existing contact returned", contact, requestID))
 }
 
 // GetUserContacts handles retrieving all contacts for a user
 func (h *ContactHandler) GetUserContacts(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
 	defer cancel()
 
 	// Extract request ID and user ID for logging
@@ -107,36 +154,55 @@ func (h *ContactHandler) GetUserContacts(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
 		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "GetUserContacts").Logger()
 
-	logger.Info().Msg("Retrieving user contacts")
+	favoritesFirst := false
+	if value := c.Query("favorites_first"); value != "" {
+		var err error
+		favoritesFirst, err = strconv.ParseBool(value)
+		if err != nil {
+			logger.Warn().Str("favorites_first", value).Msg("Invalid favorites_first flag")
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid favorites_first flag", "", requestID))
+			return
+		}
+	}
+
+	logger.Info().Bool("favorites_first", favoritesFirst).Msg("Retrieving user contacts")
 
 	contacts, err := h.contactService.GetUserContacts(ctx, userUUID)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to retrieve user contacts")
-		c.JSON(http.StatusInternalServerError, NewErrorResponse("Internal server error", "", requestID))
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
 		return
 	}
 
 	logger.Info().Int("count", len(contacts)).Msg("User contacts retrieved successfully")
 
-	c.JSON(http.StatusOK, NewSuccessResponse("Contacts retrieved successfully", contacts, requestID))
+	if favoritesFirst {
+		sortContactsFavoritesFirst(contacts)
+	}
+	page, meta := paginate(contacts, getPagination(c))
+	c.JSON(http.StatusOK, NewPaginatedResponse(c, "Contacts retrieved successfully", page, meta, requestID))
 }
 
 // GetContact handles retrieving a specific contact
 func (h *ContactHandler) GetContact(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
 	defer cancel()
 
 	// Extract request ID and user ID for logging
@@ -144,14 +210,14 @@ func (h *ContactHandler) GetContact(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
 		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
@@ -160,7 +226,7 @@ func (h *ContactHandler) GetContact(c *gin.Context) {
 	contactID, err := uuid.Parse(contactIDStr)
 	if err != nil {
 		h.logger.Warn().Str("request_id", requestID).Str("contact_id", contactIDStr).Msg("Invalid contact ID format")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid contact ID", "", requestID))
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid contact ID", "", requestID))
 		return
 	}
 
@@ -171,25 +237,29 @@ func (h *ContactHandler) GetContact(c *gin.Context) {
 	contact, err := h.contactService.GetContact(ctx, contactID, userUUID)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to retrieve contact")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
 		
 		// Handle specific error types
 		switch err {
 		case entities.ErrContactNotFound:
-			c.JSON(http.StatusNotFound, NewErrorResponse("Contact not found", "", requestID))
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Contact not found", "", requestID))
 		default:
-			c.JSON(http.StatusInternalServerError, NewErrorResponse("Internal server error", "", requestID))
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
 		}
 		return
 	}
 
 	logger.Info().Msg("Contact retrieved successfully")
 
-	c.JSON(http.StatusOK, NewSuccessResponse("Contact retrieved successfully", contact, requestID))
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Contact retrieved successfully", contact, requestID))
 }
 
 // UpdateContact handles contact updates
 func (h *ContactHandler) UpdateContact(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
 	defer cancel()
 
 	// Extract request ID and user ID for logging
@@ -197,14 +267,14 @@ func (h *ContactHandler) UpdateContact(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
 		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
@@ -213,16 +283,16 @@ func (h *ContactHandler) UpdateContact(c *gin.Context) {
 	contactID, err := uuid.Parse(contactIDStr)
 	if err != nil {
 		h.logger.Warn().Str("request_id", requestID).Str("contact_id", contactIDStr).Msg("Invalid contact ID format")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid contact ID", "", requestID))
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid contact ID", "", requestID))
 		return
 	}
 
 	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("contact_id", contactID.String()).Str("method", "UpdateContact").Logger()
 
 	var req entities.UpdateContactRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		logger.Warn().Err(err).Msg("Invalid request body")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid request body", err.Error(), requestID))
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(c, err, requestID))
 		return
 	}
 
@@ -240,7 +310,7 @@ func (h *ContactHandler) UpdateContact(c *gin.Context) {
 		req.Phone = &sanitized
 	}
 	if req.Notes != nil {
-		sanitized := sanitizeString(*req.Notes)
+		sanitized := sanitizeRichText(*req.Notes)
 		req.Notes = &sanitized
 	}
 
@@ -249,27 +319,31 @@ func (h *ContactHandler) UpdateContact(c *gin.Context) {
 	contact, err := h.contactService.UpdateContact(ctx, contactID, userUUID, &req)
 	if err != nil {
 		logger.Error().Err(err).Msg("Contact update failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
 		
 		// Handle specific error types
 		switch err {
 		case entities.ErrContactNotFound:
-			c.JSON(http.StatusNotFound, NewErrorResponse("Contact not found", "", requestID))
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Contact not found", "", requestID))
 		case entities.ErrInvalidContactName:
-			c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid input", err.Error(), requestID))
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid input", err.Error(), requestID))
 		default:
-			c.JSON(http.StatusInternalServerError, NewErrorResponse("Internal server error", "", requestID))
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
 		}
 		return
 	}
 
 	logger.Info().Msg("Contact updated successfully")
 
-	c.JSON(http.StatusOK, NewSuccessResponse("Contact updated successfully", contact, requestID))
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Contact updated successfully", contact, requestID))
 }
 
 // DeleteContact handles contact deletion
 func (h *ContactHandler) DeleteContact(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
 	defer cancel()
 
 	// Extract request ID and user ID for logging
@@ -277,14 +351,14 @@ func (h *ContactHandler) DeleteContact(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
 		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
@@ -293,7 +367,7 @@ func (h *ContactHandler) DeleteContact(c *gin.Context) {
 	contactID, err := uuid.Parse(contactIDStr)
 	if err != nil {
 		h.logger.Warn().Str("request_id", requestID).Str("contact_id", contactIDStr).Msg("Invalid contact ID format")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid contact ID", "", requestID))
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid contact ID", "", requestID))
 		return
 	}
 
@@ -304,18 +378,196 @@ func (h *ContactHandler) DeleteContact(c *gin.Context) {
 	err = h.contactService.DeleteContact(ctx, contactID, userUUID)
 	if err != nil {
 		logger.Error().Err(err).Msg("Contact deletion failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
 		
 		// Handle specific error types
 		switch err {
 		case entities.ErrContactNotFound:
-			c.JSON(http.StatusNotFound, NewErrorResponse("Contact not found", "", requestID))
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Contact not found", "", requestID))
+		case entities.ErrContactHasActiveDebts:
+			c.JSON(http.StatusConflict, NewErrorResponse(c, "Contact has unsettled debts", "settle or delete the debts with this contact first", requestID))
 		default:
-			c.JSON(http.StatusInternalServerError, NewErrorResponse("Internal server error", "", requestID))
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
 		}
 		return
 	}
 
 	logger.Info().Msg("Contact deleted successfully")
 
-	c.JSON(http.StatusOK, NewSuccessResponse("Contact deleted successfully", nil, requestID))
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Contact deleted successfully", nil, requestID))
+}
+
+// ToggleFavorite handles marking a contact as a favorite, or unmarking it
+func (h *ContactHandler) ToggleFavorite(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	// Parse contact ID from URL parameter
+	contactIDStr := c.Param("id")
+	contactID, err := uuid.Parse(contactIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("contact_id", contactIDStr).Msg("Invalid contact ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid contact ID", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("contact_id", contactID.String()).Str("method", "ToggleFavorite").Logger()
+
+	logger.Info().Msg("Toggling contact favorite")
+
+	contact, err := h.contactService.ToggleFavorite(ctx, contactID, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to toggle contact favorite")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		if errors.Is(err, entities.ErrContactNotFound) {
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Contact not found", "", requestID))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	logger.Info().Bool("is_favorite", contact.IsFavorite).Msg("Contact favorite toggled successfully")
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Contact favorite updated successfully", contact, requestID))
+}
+
+// BlockContact handles blocking a contact so it can't be used for new debts
+func (h *ContactHandler) BlockContact(c *gin.Context) {
+	h.setContactBlocked(c, true)
+}
+
+// UnblockContact handles lifting a block on a contact
+func (h *ContactHandler) UnblockContact(c *gin.Context) {
+	h.setContactBlocked(c, false)
+}
+
+// setContactBlocked blocks or unblocks the contact in the URL
+func (h *ContactHandler) setContactBlocked(c *gin.Context, blocked bool) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	method, message := "UnblockContact", "Contact unblocked successfully"
+	if blocked {
+		method, message = "BlockContact", "Contact blocked successfully"
+	}
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	// Parse contact ID from URL parameter
+	contactIDStr := c.Param("id")
+	contactID, err := uuid.Parse(contactIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("contact_id", contactIDStr).Msg("Invalid contact ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid contact ID", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("contact_id", contactID.String()).Str("method", method).Logger()
+
+	logger.Info().Bool("blocked", blocked).Msg("Updating contact block")
+
+	contact, err := h.contactService.SetBlocked(ctx, contactID, userUUID, blocked)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to update contact block")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		if errors.Is(err, entities.ErrContactNotFound) {
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Contact not found", "", requestID))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	logger.Info().Bool("is_blocked", contact.IsBlocked).Msg(message)
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, message, contact, requestID))
+}
+
+// LookupContact handles checking whether an email belongs to a registered user
+// before it is added as a contact
+func (h *ContactHandler) LookupContact(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "LookupContact").Logger()
+
+	email := sanitizeEmail(c.Query("email"))
+	if err := requestValidator.Var(email, "required,email"); err != nil {
+		logger.Warn().Msg("Invalid lookup email")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid input", "email must be a valid email address", requestID))
+		return
+	}
+
+	logger.Info().Msg("Contact lookup attempt")
+
+	lookup, err := h.contactService.LookupContact(ctx, userUUID, email)
+	if err != nil {
+		logger.Error().Err(err).Msg("Contact lookup failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	logger.Info().Bool("is_user", lookup.IsUser).Msg("Contact lookup completed")
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Contact lookup completed", lookup, requestID))
 }
@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"mime"
 	"mime/multipart"
 	"net/http"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -13,30 +16,67 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
 
+	"pay-your-dues/internal/config"
 	"pay-your-dues/internal/domain/entities"
 	"pay-your-dues/internal/domain/interfaces"
 )
 
 // DebtHandler handles debt-related HTTP requests
 type DebtHandler struct {
-	debtService        interfaces.DebtService
-	fileStorageService interfaces.FileStorageService
-	logger             zerolog.Logger
+	debtService         interfaces.DebtService
+	fileStorageService  interfaces.FileStorageService
+	maxReceiptSize      int64
+	receiptContentTypes []string
+	receiptExtensions   []string
+	logger              zerolog.Logger
 }
 
-// NewDebtHandler creates a new debt handler
-func NewDebtHandler(debtService interfaces.DebtService, fileStorageService interfaces.FileStorageService, logger zerolog.Logger) *DebtHandler {
-	return &DebtHandler{
-		debtService:        debtService,
-		fileStorageService: fileStorageService,
-		logger:             logger.With().Str("handler", "debt").Logger(),
+// DebtHandlerOption configures optional behaviour of the debt handler
+type DebtHandlerOption func(*DebtHandler)
+
+// WithMaxReceiptSize sets the largest receipt upload accepted, in bytes
+func WithMaxReceiptSize(size int64) DebtHandlerOption {
+	return func(h *DebtHandler) {
+		if size > 0 {
+			h.maxReceiptSize = size
+		}
+	}
+}
+
+// WithReceiptTypes sets the content types and file extensions accepted for
+// receipt uploads. An empty list keeps the default for that check.
+func WithReceiptTypes(contentTypes, extensions []string) DebtHandlerOption {
+	return func(h *DebtHandler) {
+		if len(contentTypes) > 0 {
+			h.receiptContentTypes = contentTypes
+		}
+		if len(extensions) > 0 {
+			h.receiptExtensions = extensions
+		}
 	}
 }
 
+// NewDebtHandler creates a new debt handler
+func NewDebtHandler(debtService interfaces.DebtService, fileStorageService interfaces.FileStorageService, logger zerolog.Logger, opts ...DebtHandlerOption) *DebtHandler {
+	h := &DebtHandler{
+		debtService:         debtService,
+		fileStorageService:  fileStorageService,
+		maxReceiptSize:      config.DefaultMaxReceiptSize,
+		receiptContentTypes: config.DefaultReceiptContentTypes,
+		receiptExtensions:   config.DefaultReceiptExtensions,
+		logger:              logger.With().Str("handler", "debt").Logger(),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
 // CreateDebtList handles debt list creation
 func (h *DebtHandler) CreateDebtList(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
 	defer cancel()
 
 	// Extract request ID and user ID for logging
@@ -44,23 +84,23 @@ func (h *DebtHandler) CreateDebtList(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
 		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "CreateDebtList").Logger()
 
 	var req entities.CreateDebtListRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		logger.Warn().Err(err).Msg("Invalid request body")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid request body", err.Error(), requestID))
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(c, err, requestID))
 		return
 	}
 
@@ -70,11 +110,11 @@ func (h *DebtHandler) CreateDebtList(c *gin.Context) {
 	req.InstallmentPlan = sanitizeString(req.InstallmentPlan)
 	req.DebtType = sanitizeString(req.DebtType)
 	if req.Description != nil {
-		sanitized := sanitizeString(*req.Description)
+		sanitized := sanitizeRichText(*req.Description)
 		req.Description = &sanitized
 	}
 	if req.Notes != nil {
-		sanitized := sanitizeString(*req.Notes)
+		sanitized := sanitizeRichText(*req.Notes)
 		req.Notes = &sanitized
 	}
 
@@ -84,26 +124,114 @@ func (h *DebtHandler) CreateDebtList(c *gin.Context) {
 	if err != nil {
 		logger.Error().Err(err).Str("debt_type", req.DebtType).Msg("Debt list creation failed")
 
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		if errors.Is(err, entities.ErrTooManyPayments) {
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Too many payments", err.Error(), requestID))
+			return
+		}
+		if errors.Is(err, entities.ErrTotalAmountTooLarge) {
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Total amount too large", err.Error(), requestID))
+			return
+		}
+		if errors.Is(err, entities.ErrTextTooLong) {
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid input", err.Error(), requestID))
+			return
+		}
+		if errors.Is(err, entities.ErrConflictingSchedule) {
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Conflicting schedule", err.Error(), requestID))
+			return
+		}
+
 		// Handle specific error types
 		switch err {
-		case entities.ErrInvalidDebtType, entities.ErrInvalidAmount, entities.ErrInvalidCurrency, entities.ErrInvalidDueDate:
-			c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid input", err.Error(), requestID))
+		case entities.ErrInvalidDebtType, entities.ErrInvalidAmount, entities.ErrInvalidCurrency, entities.ErrInvalidDueDate, entities.ErrInvalidPaymentWeekday, entities.ErrInvalidRoundingMode:
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid input", err.Error(), requestID))
 		case entities.ErrContactNotFound:
-			c.JSON(http.StatusNotFound, NewErrorResponse("Contact not found", "", requestID))
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Contact not found", "", requestID))
+		case entities.ErrContactBlocked:
+			c.JSON(http.StatusForbidden, NewErrorResponse(c, "Contact is blocked", "", requestID))
 		default:
-			c.JSON(http.StatusInternalServerError, NewErrorResponse("Internal server error", "", requestID))
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
 		}
 		return
 	}
 
 	logger.Info().Str("debt_list_id", debtList.ID.String()).Str("debt_type", req.DebtType).Msg("Debt list created successfully")
 
-	c.JSON(http.StatusCreated, NewSuccessResponse("Debt list created successfully", debtList, requestID))
+	c.JSON(http.StatusCreated, NewSuccessResponse(c, "Debt list created successfully", debtList, requestID))
+}
+
+// CreateQuickDebt handles onetime debt creation from a minimal request
+func (h *DebtHandler) CreateQuickDebt(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "CreateQuickDebt").Logger()
+
+	var req entities.QuickDebtRequest
+	if err := bindJSON(c, &req); err != nil {
+		logger.Warn().Err(err).Msg("Invalid request body")
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(c, err, requestID))
+		return
+	}
+
+	// Sanitize input
+	req.Amount = sanitizeString(req.Amount)
+	req.DebtType = sanitizeString(req.DebtType)
+
+	logger.Info().Str("debt_type", req.DebtType).Str("contact_id", req.ContactID.String()).Msg("Quick debt creation attempt")
+
+	debtList, err := h.debtService.CreateQuickDebt(ctx, userUUID, &req)
+	if err != nil {
+		logger.Error().Err(err).Str("debt_type", req.DebtType).Msg("Quick debt creation failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		// Handle specific error types
+		switch {
+		case errors.Is(err, entities.ErrInvalidDebtType), errors.Is(err, entities.ErrInvalidAmount), errors.Is(err, entities.ErrInvalidCurrency), errors.Is(err, entities.ErrInvalidDueDate), errors.Is(err, entities.ErrInvalidPaymentWeekday), errors.Is(err, entities.ErrInvalidRoundingMode):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid input", err.Error(), requestID))
+		case errors.Is(err, entities.ErrTotalAmountTooLarge):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Total amount too large", err.Error(), requestID))
+		case errors.Is(err, entities.ErrContactNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Contact not found", "", requestID))
+		case errors.Is(err, entities.ErrContactBlocked):
+			c.JSON(http.StatusForbidden, NewErrorResponse(c, "Contact is blocked", "", requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	logger.Info().Str("debt_list_id", debtList.ID.String()).Str("currency", debtList.Currency).Msg("Quick debt created successfully")
+
+	c.JSON(http.StatusCreated, NewSuccessResponse(c, "Debt list created successfully", debtList, requestID))
 }
 
 // GetUserDebtLists handles retrieving all debt lists for a user
 func (h *DebtHandler) GetUserDebtLists(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
 	defer cancel()
 
 	// Extract request ID and user ID for logging
@@ -111,36 +239,193 @@ func (h *DebtHandler) GetUserDebtLists(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
 		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "GetUserDebtLists").Logger()
 
-	logger.Info().Msg("Retrieving user debt lists")
+	debtSort, err := getDebtSort(c)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Invalid debt sort")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid sort", invalidDebtSortDetail, requestID))
+		return
+	}
+
+	// Clients syncing incrementally only ask for what changed since their last sync
+	if value := c.Query("modified_since"); value != "" {
+		since, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			logger.Warn().Str("modified_since", value).Msg("Invalid modified_since timestamp")
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid modified_since timestamp", "modified_since must be an RFC 3339 timestamp", requestID))
+			return
+		}
+		includePayments := false
+		if value := c.Query("include_payments"); value != "" {
+			includePayments, err = strconv.ParseBool(value)
+			if err != nil {
+				logger.Warn().Str("include_payments", value).Msg("Invalid include_payments flag")
+				c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid include_payments flag", "", requestID))
+				return
+			}
+		}
+
+		logger.Info().Time("modified_since", since).Bool("include_payments", includePayments).Str("sort", debtSort.Field).Bool("descending", debtSort.Descending).Msg("Retrieving user debt lists modified since last sync")
+
+		// Taken before reading so changes made during the read are picked up next time
+		syncedAt := time.Now()
+		changes, err := h.debtService.GetDebtListsModifiedSince(ctx, userUUID, since, includePayments)
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to retrieve modified debt lists")
+
+			if handleContextError(c, err, requestID) {
+				return
+			}
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+			return
+		}
+
+		logger.Info().Int("count", len(changes)).Msg("Modified debt lists retrieved successfully")
+
+		sortDebtListChanges(changes, debtSort)
+		page, meta := paginate(changes, getPagination(c))
+		meta.SyncedAt = &syncedAt
+		c.JSON(http.StatusOK, NewPaginatedResponse(c, "Debt lists retrieved successfully", page, meta, requestID))
+		return
+	}
+
+	logger.Info().Str("sort", debtSort.Field).Bool("descending", debtSort.Descending).Msg("Retrieving user debt lists")
 
 	debtLists, err := h.debtService.GetUserDebtLists(ctx, userUUID)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to retrieve user debt lists")
-		c.JSON(http.StatusInternalServerError, NewErrorResponse("Internal server error", "", requestID))
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
 		return
 	}
 
 	logger.Info().Int("count", len(debtLists)).Msg("User debt lists retrieved successfully")
 
-	c.JSON(http.StatusOK, NewSuccessResponse("Debt lists retrieved successfully", debtLists, requestID))
+	sortDebtLists(debtLists, debtSort)
+	page, meta := paginate(debtLists, getPagination(c))
+	c.JSON(http.StatusOK, NewPaginatedResponse(c, "Debt lists retrieved successfully", page, meta, requestID))
+}
+
+// GetSharedDebtLists handles retrieving the debt lists other users track with the user as their contact
+func (h *DebtHandler) GetSharedDebtLists(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "GetSharedDebtLists").Logger()
+
+	debtSort, err := getDebtSort(c)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Invalid debt sort")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid sort", invalidDebtSortDetail, requestID))
+		return
+	}
+
+	logger.Info().Str("sort", debtSort.Field).Bool("descending", debtSort.Descending).Msg("Retrieving debt lists shared with user")
+
+	debtLists, err := h.debtService.GetSharedDebtLists(ctx, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve debt lists shared with user")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	logger.Info().Int("count", len(debtLists)).Msg("Debt lists shared with user retrieved successfully")
+
+	sortDebtLists(debtLists, debtSort)
+	page, meta := paginate(debtLists, getPagination(c))
+	c.JSON(http.StatusOK, NewPaginatedResponse(c, "Shared debt lists retrieved successfully", page, meta, requestID))
+}
+
+// GetDebtsByContact handles retrieving the user's debts grouped by contact
+func (h *DebtHandler) GetDebtsByContact(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "GetDebtsByContact").Logger()
+
+	debtSort, err := getDebtSort(c)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Invalid debt sort")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid sort", invalidDebtSortDetail, requestID))
+		return
+	}
+
+	logger.Info().Str("sort", debtSort.Field).Bool("descending", debtSort.Descending).Msg("Retrieving debts grouped by contact")
+
+	groups, err := h.debtService.GetDebtsByContact(ctx, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve debts grouped by contact")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	logger.Info().Int("count", len(groups)).Msg("Debts grouped by contact retrieved successfully")
+
+	for i := range groups {
+		sortDebtLists(groups[i].Debts, debtSort)
+	}
+	page, meta := paginate(groups, getPagination(c))
+	c.JSON(http.StatusOK, NewPaginatedResponse(c, "Debts by contact retrieved successfully", page, meta, requestID))
 }
 
 // GetDebtList handles retrieving a specific debt list
 func (h *DebtHandler) GetDebtList(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
 	defer cancel()
 
 	// Extract request ID and user ID for logging
@@ -148,14 +433,14 @@ func (h *DebtHandler) GetDebtList(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
 		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
@@ -164,7 +449,7 @@ func (h *DebtHandler) GetDebtList(c *gin.Context) {
 	debtListID, err := uuid.Parse(debtListIDStr)
 	if err != nil {
 		h.logger.Warn().Str("request_id", requestID).Str("debt_list_id", debtListIDStr).Msg("Invalid debt list ID format")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid debt list ID", "", requestID))
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt list ID", "", requestID))
 		return
 	}
 
@@ -176,24 +461,28 @@ func (h *DebtHandler) GetDebtList(c *gin.Context) {
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to retrieve debt list")
 
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
 		// Handle specific error types
 		switch err {
 		case entities.ErrDebtListNotFound:
-			c.JSON(http.StatusNotFound, NewErrorResponse("Debt list not found", "", requestID))
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt list not found", "", requestID))
 		default:
-			c.JSON(http.StatusInternalServerError, NewErrorResponse("Internal server error", "", requestID))
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
 		}
 		return
 	}
 
 	logger.Info().Msg("Debt list retrieved successfully")
 
-	c.JSON(http.StatusOK, NewSuccessResponse("Debt list retrieved successfully", debtList, requestID))
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Debt list retrieved successfully", debtList, requestID))
 }
 
 // UpdateDebtList handles debt list updates
 func (h *DebtHandler) UpdateDebtList(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
 	defer cancel()
 
 	// Extract request ID and user ID for logging
@@ -201,14 +490,14 @@ func (h *DebtHandler) UpdateDebtList(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
 		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
@@ -217,16 +506,16 @@ func (h *DebtHandler) UpdateDebtList(c *gin.Context) {
 	debtListID, err := uuid.Parse(debtListIDStr)
 	if err != nil {
 		h.logger.Warn().Str("request_id", requestID).Str("debt_list_id", debtListIDStr).Msg("Invalid debt list ID format")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid debt list ID", "", requestID))
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt list ID", "", requestID))
 		return
 	}
 
 	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_list_id", debtListID.String()).Str("method", "UpdateDebtList").Logger()
 
 	var req entities.UpdateDebtListRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		logger.Warn().Err(err).Msg("Invalid request body")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid request body", err.Error(), requestID))
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(c, err, requestID))
 		return
 	}
 
@@ -248,11 +537,11 @@ func (h *DebtHandler) UpdateDebtList(c *gin.Context) {
 		req.InstallmentPlan = &sanitized
 	}
 	if req.Description != nil {
-		sanitized := sanitizeString(*req.Description)
+		sanitized := sanitizeRichText(*req.Description)
 		req.Description = &sanitized
 	}
 	if req.Notes != nil {
-		sanitized := sanitizeString(*req.Notes)
+		sanitized := sanitizeRichText(*req.Notes)
 		req.Notes = &sanitized
 	}
 
@@ -262,26 +551,50 @@ func (h *DebtHandler) UpdateDebtList(c *gin.Context) {
 	if err != nil {
 		logger.Error().Err(err).Msg("Debt list update failed")
 
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		if errors.Is(err, entities.ErrInvalidDebtStatus) {
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid input", err.Error(), requestID))
+			return
+		}
+		if errors.Is(err, entities.ErrConflictingDebtStatus) {
+			c.JSON(http.StatusConflict, NewErrorResponse(c, "Status conflicts with debt state", err.Error(), requestID))
+			return
+		}
+		if errors.Is(err, entities.ErrTooManyPayments) {
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Too many payments", err.Error(), requestID))
+			return
+		}
+		if errors.Is(err, entities.ErrTotalAmountTooLarge) {
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Total amount too large", err.Error(), requestID))
+			return
+		}
+		if errors.Is(err, entities.ErrTextTooLong) {
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid input", err.Error(), requestID))
+			return
+		}
+
 		// Handle specific error types
 		switch err {
 		case entities.ErrDebtListNotFound:
-			c.JSON(http.StatusNotFound, NewErrorResponse("Debt list not found", "", requestID))
-		case entities.ErrInvalidAmount, entities.ErrInvalidCurrency, entities.ErrInvalidDueDate:
-			c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid input", err.Error(), requestID))
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt list not found", "", requestID))
+		case entities.ErrInvalidAmount, entities.ErrInvalidCurrency, entities.ErrInvalidDueDate, entities.ErrInvalidPaymentWeekday, entities.ErrInvalidRoundingMode:
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid input", err.Error(), requestID))
 		default:
-			c.JSON(http.StatusInternalServerError, NewErrorResponse("Internal server error", "", requestID))
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
 		}
 		return
 	}
 
 	logger.Info().Msg("Debt list updated successfully")
 
-	c.JSON(http.StatusOK, NewSuccessResponse("Debt list updated successfully", debtList, requestID))
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Debt list updated successfully", debtList, requestID))
 }
 
 // DeleteDebtList handles debt list deletion
 func (h *DebtHandler) DeleteDebtList(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
 	defer cancel()
 
 	// Extract request ID and user ID for logging
@@ -289,14 +602,14 @@ func (h *DebtHandler) DeleteDebtList(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
 		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
@@ -305,7 +618,7 @@ func (h *DebtHandler) DeleteDebtList(c *gin.Context) {
 	debtListID, err := uuid.Parse(debtListIDStr)
 	if err != nil {
 		h.logger.Warn().Str("request_id", requestID).Str("debt_list_id", debtListIDStr).Msg("Invalid debt list ID format")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid debt list ID", "", requestID))
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt list ID", "", requestID))
 		return
 	}
 
@@ -317,24 +630,28 @@ func (h *DebtHandler) DeleteDebtList(c *gin.Context) {
 	if err != nil {
 		logger.Error().Err(err).Msg("Debt list deletion failed")
 
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
 		// Handle specific error types
 		switch err {
 		case entities.ErrDebtListNotFound:
-			c.JSON(http.StatusNotFound, NewErrorResponse("Debt list not found", "", requestID))
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt list not found", "", requestID))
 		default:
-			c.JSON(http.StatusInternalServerError, NewErrorResponse("Internal server error", "", requestID))
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
 		}
 		return
 	}
 
 	logger.Info().Msg("Debt list deleted successfully")
 
-	c.JSON(http.StatusOK, NewSuccessResponse("Debt list deleted successfully", nil, requestID))
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Debt list deleted successfully", nil, requestID))
 }
 
-// CreateDebtItem handles debt item (payment) creation
-func (h *DebtHandler) CreateDebtItem(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+// TransferOwnership handles handing a debt list over to the user it is shared with
+func (h *DebtHandler) TransferOwnership(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
 	defer cancel()
 
 	// Extract request ID and user ID for logging
@@ -342,61 +659,68 @@ func (h *DebtHandler) CreateDebtItem(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
 		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
-	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "CreateDebtItem").Logger()
-
-	var req entities.CreateDebtItemRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Warn().Err(err).Msg("Invalid request body")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid request body", err.Error(), requestID))
+	// Parse debt list ID from URL parameter
+	debtListIDStr := c.Param("id")
+	debtListID, err := uuid.Parse(debtListIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("debt_list_id", debtListIDStr).Msg("Invalid debt list ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt list ID", "", requestID))
 		return
 	}
 
-	// Sanitize input
-	req.Amount = sanitizeString(req.Amount)
-	req.Currency = sanitizeString(req.Currency)
-	req.PaymentMethod = sanitizeString(req.PaymentMethod)
-	if req.Description != nil {
-		sanitized := sanitizeString(*req.Description)
-		req.Description = &sanitized
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_list_id", debtListID.String()).Str("method", "TransferOwnership").Logger()
+
+	var req entities.TransferOwnershipRequest
+	if err := bindJSON(c, &req); err != nil {
+		logger.Warn().Err(err).Msg("Invalid request body")
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(c, err, requestID))
+		return
 	}
 
-	logger.Info().Str("debt_list_id", req.DebtListID.String()).Str("amount", req.Amount).Msg("Debt item creation attempt")
+	logger.Info().Str("new_owner_id", req.NewOwnerID.String()).Msg("Debt list ownership transfer attempt")
 
-	debtItem, err := h.debtService.CreateDebtItem(ctx, userUUID, &req)
+	debtList, err := h.debtService.TransferOwnership(ctx, debtListID, userUUID, &req)
 	if err != nil {
-		logger.Error().Err(err).Str("debt_list_id", req.DebtListID.String()).Msg("Debt item creation failed")
+		logger.Error().Err(err).Msg("Debt list ownership transfer failed")
 
-		// Handle specific error types
-		switch err {
-		case entities.ErrInvalidAmount, entities.ErrInvalidCurrency, entities.ErrInvalidPaymentMethod:
-			c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid input", err.Error(), requestID))
-		case entities.ErrDebtListNotFound:
-			c.JSON(http.StatusNotFound, NewErrorResponse("Debt list not found", "", requestID))
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, entities.ErrDebtListNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt list not found", "", requestID))
+		case errors.Is(err, entities.ErrForbidden):
+			c.JSON(http.StatusForbidden, NewErrorResponse(c, "Only the debt owner can transfer ownership", "", requestID))
+		case errors.Is(err, entities.ErrInvalidNewOwner):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid new owner", err.Error(), requestID))
+		case errors.Is(err, entities.ErrReciprocalContactNotFound):
+			c.JSON(http.StatusConflict, NewErrorResponse(c, "New owner has no contact for you", err.Error(), requestID))
 		default:
-			c.JSON(http.StatusInternalServerError, NewErrorResponse("Internal server error", "", requestID))
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
 		}
 		return
 	}
 
-	logger.Info().Str("debt_item_id", debtItem.ID.String()).Str("amount", req.Amount).Msg("Debt item created successfully")
+	logger.Info().Msg("Debt list ownership transferred successfully")
 
-	c.JSON(http.StatusCreated, NewSuccessResponse("Payment recorded successfully", debtItem, requestID))
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Debt list ownership transferred successfully", debtList, requestID))
 }
 
-// GetDebtListItems handles retrieving all debt items for a debt list
-func (h *DebtHandler) GetDebtListItems(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+// RecomputeDebtList handles recalculating a debt list's totals from its payments
+func (h *DebtHandler) RecomputeDebtList(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
 	defer cancel()
 
 	// Extract request ID and user ID for logging
@@ -404,14 +728,14 @@ func (h *DebtHandler) GetDebtListItems(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
 		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
@@ -420,36 +744,42 @@ func (h *DebtHandler) GetDebtListItems(c *gin.Context) {
 	debtListID, err := uuid.Parse(debtListIDStr)
 	if err != nil {
 		h.logger.Warn().Str("request_id", requestID).Str("debt_list_id", debtListIDStr).Msg("Invalid debt list ID format")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid debt list ID", "", requestID))
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt list ID", "", requestID))
 		return
 	}
 
-	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_list_id", debtListID.String()).Str("method", "GetDebtListItems").Logger()
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_list_id", debtListID.String()).Str("method", "RecomputeDebtList").Logger()
 
-	logger.Info().Msg("Retrieving debt list items")
+	logger.Info().Msg("Debt list recompute attempt")
 
-	debtItems, err := h.debtService.GetDebtListItems(ctx, debtListID, userUUID)
+	debtList, err := h.debtService.RecomputeDebtList(ctx, debtListID, userUUID)
 	if err != nil {
-		logger.Error().Err(err).Msg("Failed to retrieve debt list items")
+		logger.Error().Err(err).Msg("Debt list recompute failed")
 
-		// Handle specific error types
-		switch err {
-		case entities.ErrDebtListNotFound:
-			c.JSON(http.StatusNotFound, NewErrorResponse("Debt list not found", "", requestID))
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, entities.ErrDebtListNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt list not found", "", requestID))
+		case errors.Is(err, entities.ErrForbidden):
+			c.JSON(http.StatusForbidden, NewErrorResponse(c, "Only the debt owner can recompute totals", "", requestID))
 		default:
-			c.JSON(http.StatusInternalServerError, NewErrorResponse("Internal server error", "", requestID))
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
 		}
 		return
 	}
 
-	logger.Info().Int("count", len(debtItems)).Msg("Debt list items retrieved successfully")
+	logger.Info().Msg("Debt list recomputed successfully")
 
-	c.JSON(http.StatusOK, NewSuccessResponse("Payments retrieved successfully", debtItems, requestID))
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Debt list recomputed successfully", debtList, requestID))
 }
 
-// DeleteDebtItem handles debt item (payment) deletion
-func (h *DebtHandler) DeleteDebtItem(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+// GetDebtPerspective handles showing a debt as stored for its owner next to the
+// requesting user's view of it
+func (h *DebtHandler) GetDebtPerspective(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
 	defer cancel()
 
 	// Extract request ID and user ID for logging
@@ -457,52 +787,55 @@ func (h *DebtHandler) DeleteDebtItem(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
 		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
-	// Parse debt item ID from URL parameter
-	debtItemIDStr := c.Param("id")
-	debtItemID, err := uuid.Parse(debtItemIDStr)
+	// Parse debt list ID from URL parameter
+	debtListIDStr := c.Param("id")
+	debtListID, err := uuid.Parse(debtListIDStr)
 	if err != nil {
-		h.logger.Warn().Str("request_id", requestID).Str("debt_item_id", debtItemIDStr).Msg("Invalid debt item ID format")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid debt item ID", "", requestID))
+		h.logger.Warn().Str("request_id", requestID).Str("debt_list_id", debtListIDStr).Msg("Invalid debt list ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt list ID", "", requestID))
 		return
 	}
 
-	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_item_id", debtItemID.String()).Str("method", "DeleteDebtItem").Logger()
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_list_id", debtListID.String()).Str("method", "GetDebtPerspective").Logger()
 
-	logger.Info().Msg("Debt item deletion attempt")
+	logger.Info().Msg("Retrieving debt perspective")
 
-	err = h.debtService.DeleteDebtItem(ctx, debtItemID, userUUID)
+	perspective, err := h.debtService.GetDebtPerspective(ctx, debtListID, userUUID)
 	if err != nil {
-		logger.Error().Err(err).Msg("Debt item deletion failed")
+		logger.Error().Err(err).Msg("Failed to retrieve debt perspective")
 
-		// Handle specific error types
-		switch err {
-		case entities.ErrDebtItemNotFound:
-			c.JSON(http.StatusNotFound, NewErrorResponse("Debt item not found", "", requestID))
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, entities.ErrDebtListNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt list not found", "", requestID))
 		default:
-			c.JSON(http.StatusInternalServerError, NewErrorResponse("Internal server error", "", requestID))
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
 		}
 		return
 	}
 
-	logger.Info().Msg("Debt item deleted successfully")
+	logger.Info().Str("role", perspective.Role).Msg("Debt perspective retrieved successfully")
 
-	c.JSON(http.StatusOK, NewSuccessResponse("Payment deleted successfully", nil, requestID))
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Debt perspective retrieved successfully", perspective, requestID))
 }
 
-// GetOverdueItems handles retrieving overdue debt lists
-func (h *DebtHandler) GetOverdueItems(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+// CreateDebtItem handles debt item (payment) creation
+func (h *DebtHandler) CreateDebtItem(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
 	defer cancel()
 
 	// Extract request ID and user ID for logging
@@ -510,36 +843,78 @@ func (h *DebtHandler) GetOverdueItems(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
 		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
-	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "GetOverdueItems").Logger()
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "CreateDebtItem").Logger()
 
-	logger.Info().Msg("Retrieving overdue items")
+	var req entities.CreateDebtItemRequest
+	if err := bindJSON(c, &req); err != nil {
+		logger.Warn().Err(err).Msg("Invalid request body")
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(c, err, requestID))
+		return
+	}
+
+	// Sanitize input
+	req.Amount = sanitizeString(req.Amount)
+	req.Currency = sanitizeString(req.Currency)
+	req.PaymentMethod = sanitizeString(req.PaymentMethod)
+	if req.Description != nil {
+		sanitized := sanitizeRichText(*req.Description)
+		req.Description = &sanitized
+	}
+	if req.Reference != nil {
+		// A blank reference is the same as none
+		if sanitized := sanitizeString(*req.Reference); sanitized != "" {
+			req.Reference = &sanitized
+		} else {
+			req.Reference = nil
+		}
+	}
+
+	logger.Info().Str("debt_list_id", req.DebtListID.String()).Str("amount", req.Amount).Msg("Debt item creation attempt")
 
-	overdueItems, err := h.debtService.GetOverdueItems(ctx, userUUID)
+	debtItem, err := h.debtService.CreateDebtItem(ctx, userUUID, &req)
 	if err != nil {
-		logger.Error().Err(err).Msg("Failed to retrieve overdue items")
-		c.JSON(http.StatusInternalServerError, NewErrorResponse("Internal server error", "", requestID))
+		logger.Error().Err(err).Str("debt_list_id", req.DebtListID.String()).Msg("Debt item creation failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		if errors.Is(err, entities.ErrTextTooLong) || errors.Is(err, entities.ErrReferenceTooLong) || errors.Is(err, entities.ErrInvalidCurrency) {
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid input", err.Error(), requestID))
+			return
+		}
+
+		// Handle specific error types
+		switch err {
+		case entities.ErrInvalidAmount, entities.ErrInvalidPaymentMethod, entities.ErrPaymentDateTooFarInFuture:
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid input", err.Error(), requestID))
+		case entities.ErrDebtListNotFound:
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt list not found", "", requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
 		return
 	}
 
-	logger.Info().Int("count", len(overdueItems)).Msg("Overdue items retrieved successfully")
+	logger.Info().Str("debt_item_id", debtItem.ID.String()).Str("amount", req.Amount).Msg("Debt item created successfully")
 
-	c.JSON(http.StatusOK, NewSuccessResponse("Overdue items retrieved successfully", overdueItems, requestID))
+	c.JSON(http.StatusCreated, NewSuccessResponse(c, "Payment recorded successfully", debtItem, requestID))
 }
 
-// GetDueSoonItems handles retrieving debt lists due soon
-func (h *DebtHandler) GetDueSoonItems(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+// GetDebtListItems handles retrieving all debt items for a debt list
+func (h *DebtHandler) GetDebtListItems(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
 	defer cancel()
 
 	// Extract request ID and user ID for logging
@@ -547,43 +922,57 @@ func (h *DebtHandler) GetDueSoonItems(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
 		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
-	// Get days parameter from query (default to 7 days)
-	daysStr := c.DefaultQuery("days", "7")
-	days, err := strconv.Atoi(daysStr)
-	if err != nil || days < 1 {
-		days = 7
-	}
+	// Parse debt list ID from URL parameter
+	debtListIDStr := c.Param("id")
+	debtListID, err := uuid.Parse(debtListIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("debt_list_id", debtListIDStr).Msg("Invalid debt list ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt list ID", "", requestID))
+		return
+	}
 
-	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Int("days", days).Str("method", "GetDueSoonItems").Logger()
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_list_id", debtListID.String()).Str("method", "GetDebtListItems").Logger()
 
-	logger.Info().Msg("Retrieving due soon items")
+	logger.Info().Msg("Retrieving debt list items")
 
-	dueSoonItems, err := h.debtService.GetDueSoonItems(ctx, userUUID, days)
+	debtItems, err := h.debtService.GetDebtListItems(ctx, debtListID, userUUID)
 	if err != nil {
-		logger.Error().Err(err).Msg("Failed to retrieve due soon items")
-		c.JSON(http.StatusInternalServerError, NewErrorResponse("Internal server error", "", requestID))
+		logger.Error().Err(err).Msg("Failed to retrieve debt list items")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		// Handle specific error types
+		switch err {
+		case entities.ErrDebtListNotFound:
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt list not found", "", requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
 		return
 	}
 
-	logger.Info().Int("count", len(dueSoonItems)).Msg("Due soon items retrieved successfully")
+	logger.Info().Int("count", len(debtItems)).Msg("Debt list items retrieved successfully")
 
-	c.JSON(http.StatusOK, NewSuccessResponse("Due soon items retrieved successfully", dueSoonItems, requestID))
+	page, meta := paginate(debtItems, getPagination(c))
+	c.JSON(http.StatusOK, NewPaginatedResponse(c, "Payments retrieved successfully", page, meta, requestID))
 }
 
-// GetPaymentSchedule handles retrieving the payment schedule for a debt list
-func (h *DebtHandler) GetPaymentSchedule(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+// DeleteDebtItem handles debt item (payment) deletion
+func (h *DebtHandler) DeleteDebtItem(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
 	defer cancel()
 
 	// Extract request ID and user ID for logging
@@ -591,52 +980,58 @@ func (h *DebtHandler) GetPaymentSchedule(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
 		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
-	// Parse debt list ID from URL parameter
-	debtListIDStr := c.Param("id")
-	debtListID, err := uuid.Parse(debtListIDStr)
+	// Parse debt item ID from URL parameter
+	debtItemIDStr := c.Param("id")
+	debtItemID, err := uuid.Parse(debtItemIDStr)
 	if err != nil {
-		h.logger.Warn().Str("request_id", requestID).Str("debt_list_id", debtListIDStr).Msg("Invalid debt list ID format")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid debt list ID", "", requestID))
+		h.logger.Warn().Str("request_id", requestID).Str("debt_item_id", debtItemIDStr).Msg("Invalid debt item ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt item ID", "", requestID))
 		return
 	}
 
-	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_list_id", debtListID.String()).Str("method", "GetPaymentSchedule").Logger()
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_item_id", debtItemID.String()).Str("method", "DeleteDebtItem").Logger()
 
-	logger.Info().Msg("Retrieving payment schedule")
+	logger.Info().Msg("Debt item deletion attempt")
 
-	schedule, err := h.debtService.GetPaymentSchedule(ctx, debtListID, userUUID)
+	err = h.debtService.DeleteDebtItem(ctx, debtItemID, userUUID)
 	if err != nil {
-		logger.Error().Err(err).Msg("Failed to retrieve payment schedule")
+		logger.Error().Err(err).Msg("Debt item deletion failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
 
 		// Handle specific error types
 		switch err {
-		case entities.ErrDebtListNotFound:
-			c.JSON(http.StatusNotFound, NewErrorResponse("Debt list not found", "", requestID))
+		case entities.ErrDebtItemNotFound:
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt item not found", "", requestID))
+		case entities.ErrPaymentHasReversals:
+			c.JSON(http.StatusConflict, NewErrorResponse(c, "Payment has reversals", err.Error(), requestID))
 		default:
-			c.JSON(http.StatusInternalServerError, NewErrorResponse("Internal server error", "", requestID))
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
 		}
 		return
 	}
 
-	logger.Info().Int("schedule_items", len(schedule)).Msg("Payment schedule retrieved successfully")
+	logger.Info().Msg("Debt item deleted successfully")
 
-	c.JSON(http.StatusOK, NewSuccessResponse("Payment schedule retrieved successfully", schedule, requestID))
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Payment deleted successfully", nil, requestID))
 }
 
-// GetUpcomingPayments handles retrieving upcoming payments
-func (h *DebtHandler) GetUpcomingPayments(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+// RestoreDebtItem handles restoring a soft-deleted payment
+func (h *DebtHandler) RestoreDebtItem(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
 	defer cancel()
 
 	// Extract request ID and user ID for logging
@@ -644,43 +1039,57 @@ func (h *DebtHandler) GetUpcomingPayments(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
 		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
-	// Get days parameter from query (default to 30 days)
-	daysStr := c.DefaultQuery("days", "30")
-	days, err := strconv.Atoi(daysStr)
-	if err != nil || days < 1 {
-		days = 30
+	// Parse debt item ID from URL parameter
+	debtItemIDStr := c.Param("id")
+	debtItemID, err := uuid.Parse(debtItemIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("debt_item_id", debtItemIDStr).Msg("Invalid debt item ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt item ID", "", requestID))
+		return
 	}
 
-	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Int("days", days).Str("method", "GetUpcomingPayments").Logger()
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_item_id", debtItemID.String()).Str("method", "RestoreDebtItem").Logger()
 
-	logger.Info().Msg("Retrieving upcoming payments")
+	logger.Info().Msg("Debt item restore attempt")
 
-	upcomingPayments, err := h.debtService.GetUpcomingPayments(ctx, userUUID, days)
+	debtItem, err := h.debtService.RestoreDebtItem(ctx, debtItemID, userUUID)
 	if err != nil {
-		logger.Error().Err(err).Msg("Failed to retrieve upcoming payments")
-		c.JSON(http.StatusInternalServerError, NewErrorResponse("Internal server error", "", requestID))
+		logger.Error().Err(err).Msg("Debt item restore failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, entities.ErrDebtItemNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt item not found", "", requestID))
+		case errors.Is(err, entities.ErrReversalExceedsPayment):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Reversal exceeds payment", err.Error(), requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
 		return
 	}
 
-	logger.Info().Int("count", len(upcomingPayments)).Msg("Upcoming payments retrieved successfully")
+	logger.Info().Msg("Debt item restored successfully")
 
-	c.JSON(http.StatusOK, NewSuccessResponse("Upcoming payments retrieved successfully", upcomingPayments, requestID))
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Payment restored successfully", debtItem, requestID))
 }
 
-// GetTotalPaymentsForDebtList handles retrieving payment summary for a debt list
-func (h *DebtHandler) GetTotalPaymentsForDebtList(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+// DeleteDebtItems handles bulk deletion of payments for a debt list
+func (h *DebtHandler) DeleteDebtItems(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
 	defer cancel()
 
 	// Extract request ID and user ID for logging
@@ -688,14 +1097,14 @@ func (h *DebtHandler) GetTotalPaymentsForDebtList(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
 		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
@@ -704,36 +1113,1749 @@ func (h *DebtHandler) GetTotalPaymentsForDebtList(c *gin.Context) {
 	debtListID, err := uuid.Parse(debtListIDStr)
 	if err != nil {
 		h.logger.Warn().Str("request_id", requestID).Str("debt_list_id", debtListIDStr).Msg("Invalid debt list ID format")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid debt list ID", "", requestID))
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt list ID", "", requestID))
+		return
+	}
+
+	// Parse payment IDs, accepting both ?ids=a,b and ?ids=a&ids=b
+	var debtItemIDs []uuid.UUID
+	for _, value := range c.QueryArray("ids") {
+		for _, idStr := range strings.Split(value, ",") {
+			idStr = sanitizeString(idStr)
+			if idStr == "" {
+				continue
+			}
+			id, err := uuid.Parse(idStr)
+			if err != nil {
+				h.logger.Warn().Str("request_id", requestID).Str("debt_item_id", idStr).Msg("Invalid debt item ID format")
+				c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt item ID", idStr, requestID))
+				return
+			}
+			debtItemIDs = append(debtItemIDs, id)
+		}
+	}
+	if len(debtItemIDs) == 0 {
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid input", "ids query parameter is required", requestID))
 		return
 	}
 
-	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_list_id", debtListID.String()).Str("method", "GetTotalPaymentsForDebtList").Logger()
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_list_id", debtListID.String()).Str("method", "DeleteDebtItems").Logger()
 
-	logger.Info().Msg("Retrieving payment summary")
+	logger.Info().Int("count", len(debtItemIDs)).Msg("Bulk debt item deletion attempt")
 
-	summary, err := h.debtService.GetTotalPaymentsForDebtList(ctx, debtListID, userUUID)
+	err = h.debtService.DeleteDebtItems(ctx, debtListID, userUUID, debtItemIDs)
 	if err != nil {
-		logger.Error().Err(err).Msg("Failed to retrieve payment summary")
+		logger.Error().Err(err).Msg("Bulk debt item deletion failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		// Handle specific error types
+		switch {
+		case errors.Is(err, entities.ErrDebtListNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt list not found", "", requestID))
+		case errors.Is(err, entities.ErrDebtItemNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt item not found", "", requestID))
+		case errors.Is(err, entities.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid input", err.Error(), requestID))
+		case errors.Is(err, entities.ErrPaymentHasReversals):
+			c.JSON(http.StatusConflict, NewErrorResponse(c, "Payment has reversals", err.Error(), requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	logger.Info().Int("count", len(debtItemIDs)).Msg("Debt items deleted successfully")
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Payments deleted successfully", nil, requestID))
+}
+
+// GetOverdueItems handles retrieving overdue debt lists
+func (h *DebtHandler) GetOverdueItems(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	direction := c.Query("direction")
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("direction", direction).Str("method", "GetOverdueItems").Logger()
+
+	logger.Info().Msg("Retrieving overdue items")
+
+	overdueItems, err := h.debtService.GetOverdueItems(ctx, userUUID, direction)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve overdue items")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		if errors.Is(err, entities.ErrInvalidDebtDirection) {
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid direction", "direction must be owed_to_me or i_owe", requestID))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	// Clients compare the acknowledgment with each debt to tell new overdue items from seen ones
+	acknowledgedAt, err := h.debtService.GetOverdueAcknowledgedAt(ctx, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve overdue acknowledgment")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	logger.Info().Int("count", len(overdueItems)).Msg("Overdue items retrieved successfully")
+
+	page, meta := paginate(overdueItems, getPagination(c))
+	meta.AcknowledgedAt = acknowledgedAt
+	c.JSON(http.StatusOK, NewPaginatedResponse(c, "Overdue items retrieved successfully", page, meta, requestID))
+}
+
+// AcknowledgeOverdue handles marking all of the user's overdue debts as seen
+func (h *DebtHandler) AcknowledgeOverdue(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "AcknowledgeOverdue").Logger()
+
+	logger.Info().Msg("Acknowledging overdue items")
+
+	acknowledgment, err := h.debtService.AcknowledgeOverdue(ctx, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to acknowledge overdue items")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	logger.Info().Time("acknowledged_at", acknowledgment.AcknowledgedAt).Msg("Overdue items acknowledged successfully")
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Overdue items acknowledged successfully", acknowledgment, requestID))
+}
+
+// GetOverdueTotals handles retrieving the user's total overdue amount per currency and direction
+func (h *DebtHandler) GetOverdueTotals(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "GetOverdueTotals").Logger()
+
+	totals, err := h.debtService.GetOverdueTotals(ctx, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve overdue totals")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Overdue totals retrieved successfully", totals, requestID))
+}
+
+// GetDueSoonItems handles retrieving debt lists due soon
+func (h *DebtHandler) GetDueSoonItems(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	// Get days parameter from query (default to 7 days)
+	daysStr := c.DefaultQuery("days", "7")
+	days, err := strconv.Atoi(daysStr)
+	if err != nil || days < 1 {
+		days = 7
+	}
+
+	direction := c.Query("direction")
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Int("days", days).Str("direction", direction).Str("method", "GetDueSoonItems").Logger()
+
+	logger.Info().Msg("Retrieving due soon items")
+
+	dueSoonItems, err := h.debtService.GetDueSoonItems(ctx, userUUID, days, direction)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve due soon items")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		if errors.Is(err, entities.ErrInvalidDebtDirection) {
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid direction", "direction must be owed_to_me or i_owe", requestID))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	logger.Info().Int("count", len(dueSoonItems)).Msg("Due soon items retrieved successfully")
+
+	page, meta := paginate(dueSoonItems, getPagination(c))
+	c.JSON(http.StatusOK, NewPaginatedResponse(c, "Due soon items retrieved successfully", page, meta, requestID))
+}
+
+// GetAttention handles retrieving the per-currency totals of the user's overdue and
+// due-soon debts in one response
+func (h *DebtHandler) GetAttention(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	// Get days parameter from query (default to 7 days), as for due soon items
+	daysStr := c.DefaultQuery("days", "7")
+	days, err := strconv.Atoi(daysStr)
+	if err != nil || days < 1 {
+		days = 7
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Int("days", days).Str("method", "GetAttention").Logger()
+
+	logger.Info().Msg("Retrieving debts needing attention")
+
+	attention, err := h.debtService.GetAttention(ctx, userUUID, days)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve debts needing attention")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	logger.Info().Int("overdue_currencies", len(attention.Overdue)).Int("due_soon_currencies", len(attention.DueSoon)).Msg("Debts needing attention retrieved successfully")
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Debts needing attention retrieved successfully", attention, requestID))
+}
+
+// GetPaymentSchedule handles retrieving the payment schedule for a debt list
+func (h *DebtHandler) GetPaymentSchedule(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	// Parse debt list ID from URL parameter
+	debtListIDStr := c.Param("id")
+	debtListID, err := uuid.Parse(debtListIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("debt_list_id", debtListIDStr).Msg("Invalid debt list ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt list ID", "", requestID))
+		return
+	}
+
+	// Amounts can also be shown converted into another currency
+	displayCurrency := strings.ToUpper(strings.TrimSpace(c.Query("display_currency")))
+	if displayCurrency != "" && !entities.IsValidCurrencyCode(displayCurrency) {
+		h.logger.Warn().Str("request_id", requestID).Str("display_currency", displayCurrency).Msg("Invalid display currency")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid currency", "display_currency must be a three-letter currency code", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_list_id", debtListID.String()).Str("method", "GetPaymentSchedule").Logger()
+
+	logger.Info().Str("display_currency", displayCurrency).Msg("Retrieving payment schedule")
+
+	var schedule []entities.PaymentScheduleItem
+	if displayCurrency != "" {
+		schedule, err = h.debtService.GetPaymentScheduleInCurrency(ctx, debtListID, userUUID, displayCurrency)
+	} else {
+		schedule, err = h.debtService.GetPaymentSchedule(ctx, debtListID, userUUID)
+	}
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve payment schedule")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		// Handle specific error types
+		switch err {
+		case entities.ErrDebtListNotFound:
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt list not found", "", requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	logger.Info().Int("schedule_items", len(schedule)).Msg("Payment schedule retrieved successfully")
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Payment schedule retrieved successfully", schedule, requestID))
+}
+
+// GetScheduleVariance handles comparing each scheduled installment of a debt list
+// with what was actually paid towards it
+func (h *DebtHandler) GetScheduleVariance(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	// Parse debt list ID from URL parameter
+	debtListIDStr := c.Param("id")
+	debtListID, err := uuid.Parse(debtListIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("debt_list_id", debtListIDStr).Msg("Invalid debt list ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt list ID", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_list_id", debtListID.String()).Str("method", "GetScheduleVariance").Logger()
+
+	logger.Info().Msg("Retrieving schedule variance")
+
+	variances, err := h.debtService.GetScheduleVariance(ctx, debtListID, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve schedule variance")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, entities.ErrDebtListNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt list not found", "", requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	logger.Info().Int("installments", len(variances)).Msg("Schedule variance retrieved successfully")
+
+	page, meta := paginate(variances, getPagination(c))
+	c.JSON(http.StatusOK, NewPaginatedResponse(c, "Schedule variance retrieved successfully", page, meta, requestID))
+}
+
+// GetNextPayment handles retrieving the next unpaid installment of a debt list
+func (h *DebtHandler) GetNextPayment(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	// Parse debt list ID from URL parameter
+	debtListIDStr := c.Param("id")
+	debtListID, err := uuid.Parse(debtListIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("debt_list_id", debtListIDStr).Msg("Invalid debt list ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt list ID", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_list_id", debtListID.String()).Str("method", "GetNextPayment").Logger()
+
+	logger.Info().Msg("Retrieving next payment")
+
+	nextPayment, err := h.debtService.GetNextPayment(ctx, debtListID, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve next payment")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, entities.ErrDebtListNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt list not found", "", requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	// A fully paid debt has no next payment, which is reported as null data
+	if nextPayment == nil {
+		logger.Info().Msg("Debt list has no unpaid installments")
+		c.JSON(http.StatusOK, NewSuccessResponse(c, "Debt list has no upcoming payment", nil, requestID))
+		return
+	}
+
+	logger.Info().Int("payment_number", nextPayment.PaymentNumber).Msg("Next payment retrieved successfully")
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Next payment retrieved successfully", nextPayment, requestID))
+}
+
+// GetPayoffProjection handles projecting when a debt list will be paid off
+func (h *DebtHandler) GetPayoffProjection(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	// Parse debt list ID from URL parameter
+	debtListIDStr := c.Param("id")
+	debtListID, err := uuid.Parse(debtListIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("debt_list_id", debtListIDStr).Msg("Invalid debt list ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt list ID", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_list_id", debtListID.String()).Str("method", "GetPayoffProjection").Logger()
+
+	logger.Info().Msg("Projecting debt payoff")
+
+	projection, err := h.debtService.GetPayoffProjection(ctx, debtListID, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to project debt payoff")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, entities.ErrDebtListNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt list not found", "", requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	logger.Info().Int("remaining_payments", projection.RemainingPayments).Bool("on_track", projection.OnTrack).Msg("Payoff projection retrieved successfully")
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Payoff projection retrieved successfully", projection, requestID))
+}
+
+// GetContactSummary handles summarizing the user's debts with one contact
+func (h *DebtHandler) GetContactSummary(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	// Parse contact ID from URL parameter
+	contactIDStr := c.Param("id")
+	contactID, err := uuid.Parse(contactIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("contact_id", contactIDStr).Msg("Invalid contact ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid contact ID", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("contact_id", contactID.String()).Str("method", "GetContactSummary").Logger()
+
+	logger.Info().Msg("Retrieving contact summary")
+
+	summary, err := h.debtService.GetContactSummary(ctx, contactID, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve contact summary")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, entities.ErrContactNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Contact not found", "", requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	logger.Info().Int("owed_to_me", summary.OwedToMeCount).Int("i_owe", summary.IOweCount).Msg("Contact summary retrieved successfully")
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Contact summary retrieved successfully", summary, requestID))
+}
+
+// GetContactReconciliation handles comparing the user's debt records with a contact
+// against the records that contact keeps with the user
+func (h *DebtHandler) GetContactReconciliation(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	// Parse contact ID from URL parameter
+	contactIDStr := c.Param("id")
+	contactID, err := uuid.Parse(contactIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("contact_id", contactIDStr).Msg("Invalid contact ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid contact ID", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("contact_id", contactID.String()).Str("method", "GetContactReconciliation").Logger()
+
+	logger.Info().Msg("Reconciling debts with contact")
+
+	reconciliation, err := h.debtService.GetContactReconciliation(ctx, contactID, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to reconcile debts with contact")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, entities.ErrContactNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Contact not found", "", requestID))
+		case errors.Is(err, entities.ErrContactNotAppUser):
+			c.JSON(http.StatusUnprocessableEntity, NewErrorResponse(c, "Contact must be a registered user", "", requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	logger.Info().Int("discrepancies", len(reconciliation.Discrepancies)).Msg("Contact reconciliation retrieved successfully")
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Contact reconciliation retrieved successfully", reconciliation, requestID))
+}
+
+// PreviewPaymentSchedule handles previewing the schedule for proposed debt terms without creating a debt
+func (h *DebtHandler) PreviewPaymentSchedule(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "PreviewPaymentSchedule").Logger()
+
+	var req entities.CreateDebtListRequest
+	if err := bindJSON(c, &req); err != nil {
+		logger.Warn().Err(err).Msg("Invalid request body")
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(c, err, requestID))
+		return
+	}
+
+	// Sanitize input
+	req.TotalAmount = sanitizeString(req.TotalAmount)
+	req.Currency = sanitizeString(req.Currency)
+	req.InstallmentPlan = sanitizeString(req.InstallmentPlan)
+	req.DebtType = sanitizeString(req.DebtType)
+
+	schedule, err := h.debtService.PreviewPaymentSchedule(ctx, userUUID, &req)
+	if err != nil {
+		logger.Error().Err(err).Msg("Payment schedule preview failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		// Handle specific error types
+		switch {
+		case errors.Is(err, entities.ErrInvalidDebtType), errors.Is(err, entities.ErrInvalidAmount),
+			errors.Is(err, entities.ErrInvalidCurrency), errors.Is(err, entities.ErrInvalidDueDate),
+			errors.Is(err, entities.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid input", err.Error(), requestID))
+		case errors.Is(err, entities.ErrTooManyPayments):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Too many payments", err.Error(), requestID))
+		case errors.Is(err, entities.ErrTotalAmountTooLarge):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Total amount too large", err.Error(), requestID))
+		case errors.Is(err, entities.ErrConflictingSchedule):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Conflicting schedule", err.Error(), requestID))
+		case errors.Is(err, entities.ErrContactNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Contact not found", "", requestID))
+		case errors.Is(err, entities.ErrContactBlocked):
+			c.JSON(http.StatusForbidden, NewErrorResponse(c, "Contact is blocked", "", requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	logger.Info().Int("schedule_items", len(schedule)).Msg("Payment schedule preview generated successfully")
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Payment schedule preview generated successfully", schedule, requestID))
+}
+
+// GetUpcomingPayments handles retrieving upcoming payments
+func (h *DebtHandler) GetUpcomingPayments(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	// Get days parameter from query (default to 30 days)
+	daysStr := c.DefaultQuery("days", "30")
+	days, err := strconv.Atoi(daysStr)
+	if err != nil || days < 1 {
+		days = 30
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Int("days", days).Str("method", "GetUpcomingPayments").Logger()
+
+	logger.Info().Msg("Retrieving upcoming payments")
+
+	upcomingPayments, err := h.debtService.GetUpcomingPayments(ctx, userUUID, days)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve upcoming payments")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	logger.Info().Int("count", len(upcomingPayments)).Msg("Upcoming payments retrieved successfully")
+
+	page, meta := paginate(upcomingPayments, getPagination(c))
+	c.JSON(http.StatusOK, NewPaginatedResponse(c, "Upcoming payments retrieved successfully", page, meta, requestID))
+}
+
+// GetTotalPaymentsForDebtList handles retrieving payment summary for a debt list
+func (h *DebtHandler) GetTotalPaymentsForDebtList(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	// Parse debt list ID from URL parameter
+	debtListIDStr := c.Param("id")
+	debtListID, err := uuid.Parse(debtListIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("debt_list_id", debtListIDStr).Msg("Invalid debt list ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt list ID", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_list_id", debtListID.String()).Str("method", "GetTotalPaymentsForDebtList").Logger()
+
+	logger.Info().Msg("Retrieving payment summary")
+
+	summary, err := h.debtService.GetTotalPaymentsForDebtList(ctx, debtListID, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve payment summary")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		// Handle specific error types
+		switch err {
+		case entities.ErrDebtListNotFound:
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt list not found", "", requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	logger.Info().Msg("Payment summary retrieved successfully")
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Payment summary retrieved successfully", summary, requestID))
+}
+
+// GetBalanceHistory handles retrieving the remaining balance over time for a debt list
+func (h *DebtHandler) GetBalanceHistory(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	// Parse debt list ID from URL parameter
+	debtListIDStr := c.Param("id")
+	debtListID, err := uuid.Parse(debtListIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("debt_list_id", debtListIDStr).Msg("Invalid debt list ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt list ID", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_list_id", debtListID.String()).Str("method", "GetBalanceHistory").Logger()
+
+	logger.Info().Msg("Retrieving balance history")
+
+	history, err := h.debtService.GetBalanceHistory(ctx, debtListID, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve balance history")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		if errors.Is(err, entities.ErrDebtListNotFound) {
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt list not found", "", requestID))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	logger.Info().Int("points", len(history)).Msg("Balance history retrieved successfully")
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Balance history retrieved successfully", history, requestID))
+}
+
+// GetDebtListHistory handles retrieving the recorded field changes of a debt list
+func (h *DebtHandler) GetDebtListHistory(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	// Parse debt list ID from URL parameter
+	debtListIDStr := c.Param("id")
+	debtListID, err := uuid.Parse(debtListIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("debt_list_id", debtListIDStr).Msg("Invalid debt list ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt list ID", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_list_id", debtListID.String()).Str("method", "GetDebtListHistory").Logger()
+
+	logger.Info().Msg("Retrieving debt list history")
+
+	history, err := h.debtService.GetDebtListHistory(ctx, debtListID, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve debt list history")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		if errors.Is(err, entities.ErrDebtListNotFound) {
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt list not found", "", requestID))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	logger.Info().Int("entries", len(history)).Msg("Debt list history retrieved successfully")
+
+	page, meta := paginate(history, getPagination(c))
+	c.JSON(http.StatusOK, NewPaginatedResponse(c, "Debt list history retrieved successfully", page, meta, requestID))
+}
+
+// GetDebtCounts handles retrieving the number of the user's debts in each status
+func (h *DebtHandler) GetDebtCounts(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "GetDebtCounts").Logger()
+
+	counts, err := h.debtService.GetDebtCounts(ctx, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve debt counts")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Debt counts retrieved successfully", counts, requestID))
+}
+
+// defaultReportPeriod is how far back reports look when no start date is given
+const defaultReportPeriod = 30 * 24 * time.Hour
+
+// parseReportDate parses a report range bound given as an RFC 3339 timestamp or
+// a date. A date that ends the range covers that whole day.
+func parseReportDate(value string, end bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// GetActivitySummary handles retrieving aggregate statistics of the user's debt
+// activity between the from and to query parameters, which default to the last 30 days
+func (h *DebtHandler) GetActivitySummary(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "GetActivitySummary").Logger()
+
+	to := time.Now()
+	if value := c.Query("to"); value != "" {
+		parsed, err := parseReportDate(value, true)
+		if err != nil {
+			logger.Warn().Str("to", value).Msg("Invalid to date")
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid date range", "to must be a date or an RFC 3339 timestamp", requestID))
+			return
+		}
+		to = parsed
+	}
+	from := to.Add(-defaultReportPeriod)
+	if value := c.Query("from"); value != "" {
+		parsed, err := parseReportDate(value, false)
+		if err != nil {
+			logger.Warn().Str("from", value).Msg("Invalid from date")
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid date range", "from must be a date or an RFC 3339 timestamp", requestID))
+			return
+		}
+		from = parsed
+	}
+
+	logger.Info().Time("from", from).Time("to", to).Msg("Retrieving activity summary")
+
+	summary, err := h.debtService.GetActivitySummary(ctx, userUUID, from, to)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve activity summary")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		switch {
+		case errors.Is(err, entities.ErrInvalidDateRange):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid date range", err.Error(), requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Activity summary retrieved successfully", summary, requestID))
+}
+
+// GetPaymentMethodStats handles retrieving payment counts and totals per payment method
+func (h *DebtHandler) GetPaymentMethodStats(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "GetPaymentMethodStats").Logger()
+	logger.Info().Msg("Retrieving payment method statistics")
+
+	stats, err := h.debtService.GetPaymentMethodStats(ctx, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve payment method statistics")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Payment method statistics retrieved successfully", stats, requestID))
+}
+
+// GetCurrencies handles retrieving the currencies in use by the user
+func (h *DebtHandler) GetCurrencies(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "GetCurrencies").Logger()
+
+	currencies, err := h.debtService.GetCurrencies(ctx, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve currencies")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Currencies retrieved successfully", currencies, requestID))
+}
+
+// VerifyDebtItem handles debt item verification
+func (h *DebtHandler) VerifyDebtItem(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	// Parse debt item ID from URL parameter
+	debtItemIDStr := c.Param("id")
+	debtItemID, err := uuid.Parse(debtItemIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("debt_item_id", debtItemIDStr).Msg("Invalid debt item ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt item ID", "", requestID))
+		return
+	}
+
+	var req entities.VerifyDebtItemRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.Warn().Str("request_id", requestID).Err(err).Msg("Invalid request body")
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(c, err, requestID))
+		return
+	}
+
+	// Sanitize input
+	if req.VerificationNotes != nil {
+		sanitized := sanitizeRichText(*req.VerificationNotes)
+		req.VerificationNotes = &sanitized
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_item_id", debtItemID.String()).Str("method", "VerifyDebtItem").Logger()
+
+	logger.Info().Str("status", req.Status).Msg("Debt item verification attempt")
+
+	debtItem, err := h.debtService.VerifyDebtItem(ctx, debtItemID, userUUID, &req)
+	if err != nil {
+		logger.Error().Err(err).Str("status", req.Status).Msg("Debt item verification failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		if errors.Is(err, entities.ErrPaymentAlreadyProcessed) {
+			c.JSON(http.StatusConflict, NewErrorResponse(c, "Payment already processed", err.Error(), requestID))
+			return
+		}
+
+		// Handle specific error types
+		switch err {
+		case entities.ErrDebtItemNotFound:
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt item not found", "", requestID))
+		case entities.ErrInvalidPaymentStatus:
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid payment status", err.Error(), requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	logger.Info().Str("status", req.Status).Msg("Debt item verified successfully")
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Debt item verified successfully", debtItem, requestID))
+}
+
+// AutoMatchPayment handles checking a pending payment against the expected installment
+func (h *DebtHandler) AutoMatchPayment(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	// Parse debt item ID from URL parameter
+	debtItemIDStr := c.Param("id")
+	debtItemID, err := uuid.Parse(debtItemIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("debt_item_id", debtItemIDStr).Msg("Invalid debt item ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt item ID", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_item_id", debtItemID.String()).Str("method", "AutoMatchPayment").Logger()
+
+	logger.Info().Msg("Payment auto-match attempt")
+
+	match, err := h.debtService.AutoMatchPayment(ctx, debtItemID, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Payment auto-match failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, entities.ErrPaymentAlreadyProcessed):
+			c.JSON(http.StatusConflict, NewErrorResponse(c, "Payment already processed", err.Error(), requestID))
+		case errors.Is(err, entities.ErrDebtItemNotFound), errors.Is(err, entities.ErrDebtListNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt item not found", "", requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	logger.Info().Bool("matched", match.Matched).Bool("auto_verified", match.AutoVerified).Msg("Payment auto-match completed")
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Payment checked against expected installment", match, requestID))
+}
+
+// ReversePayment handles partially reversing a completed payment
+func (h *DebtHandler) ReversePayment(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	// Parse debt item ID from URL parameter
+	debtItemIDStr := c.Param("id")
+	debtItemID, err := uuid.Parse(debtItemIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("debt_item_id", debtItemIDStr).Msg("Invalid debt item ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt item ID", "", requestID))
+		return
+	}
+
+	var req entities.ReversePaymentRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.Warn().Str("request_id", requestID).Err(err).Msg("Invalid request body")
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(c, err, requestID))
+		return
+	}
+
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("amount", req.Amount).Msg("Invalid reversal amount")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid amount", "", requestID))
+		return
+	}
+
+	// Sanitize input
+	req.Reason = sanitizeRichText(req.Reason)
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_item_id", debtItemID.String()).Str("method", "ReversePayment").Logger()
+
+	logger.Info().Str("amount", amount.String()).Msg("Payment reversal attempt")
+
+	reversal, err := h.debtService.ReversePayment(ctx, debtItemID, userUUID, amount, req.Reason)
+	if err != nil {
+		logger.Error().Err(err).Str("amount", amount.String()).Msg("Payment reversal failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, entities.ErrDebtItemNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt item not found", "", requestID))
+		case errors.Is(err, entities.ErrPaymentNotReversible):
+			c.JSON(http.StatusConflict, NewErrorResponse(c, "Payment cannot be reversed", err.Error(), requestID))
+		case errors.Is(err, entities.ErrReversalExceedsPayment):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Reversal exceeds payment", err.Error(), requestID))
+		case errors.Is(err, entities.ErrInvalidAmount):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid amount", "", requestID))
+		case errors.Is(err, entities.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid input", "reason is required", requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	logger.Info().Str("reversal_id", reversal.ID.String()).Msg("Payment reversed successfully")
+
+	c.JSON(http.StatusCreated, NewSuccessResponse(c, "Payment reversed successfully", reversal, requestID))
+}
+
+// MoveDebtItem handles moving a payment recorded on the wrong debt list to another one
+func (h *DebtHandler) MoveDebtItem(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	// Parse debt item ID from URL parameter
+	debtItemIDStr := c.Param("id")
+	debtItemID, err := uuid.Parse(debtItemIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("debt_item_id", debtItemIDStr).Msg("Invalid debt item ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt item ID", "", requestID))
+		return
+	}
+
+	var req entities.MoveDebtItemRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.Warn().Str("request_id", requestID).Err(err).Msg("Invalid request body")
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(c, err, requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_item_id", debtItemID.String()).Str("method", "MoveDebtItem").Logger()
+
+	logger.Info().Str("target_debt_list_id", req.TargetDebtListID.String()).Msg("Payment move attempt")
+
+	debtItem, err := h.debtService.MoveDebtItem(ctx, debtItemID, userUUID, req.TargetDebtListID)
+	if err != nil {
+		logger.Error().Err(err).Str("target_debt_list_id", req.TargetDebtListID.String()).Msg("Payment move failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, entities.ErrDebtItemNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt item not found", "", requestID))
+		case errors.Is(err, entities.ErrDebtListNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt list not found", "", requestID))
+		case errors.Is(err, entities.ErrPaymentAlreadyOnDebtList):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Payment is already on this debt list", "", requestID))
+		case errors.Is(err, entities.ErrPaymentNotMovable):
+			c.JSON(http.StatusConflict, NewErrorResponse(c, "Payment cannot be moved", err.Error(), requestID))
+		case errors.Is(err, entities.ErrInvalidCurrency):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid input", err.Error(), requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	logger.Info().Str("target_debt_list_id", req.TargetDebtListID.String()).Msg("Payment moved successfully")
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Payment moved successfully", debtItem, requestID))
+}
+
+// GetPendingVerifications handles retrieving pending verifications for a user
+func (h *DebtHandler) GetPendingVerifications(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "GetPendingVerifications").Logger()
+
+	logger.Info().Msg("Retrieving pending verifications")
+
+	pendingVerifications, err := h.debtService.GetPendingVerifications(ctx, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve pending verifications")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	logger.Info().Int("count", len(pendingVerifications)).Msg("Pending verifications retrieved successfully")
+
+	page, meta := paginate(pendingVerifications, getPagination(c))
+	c.JSON(http.StatusOK, NewPaginatedResponse(c, "Pending verifications retrieved successfully", page, meta, requestID))
+}
+
+// GetPendingVerificationQueue handles retrieving the payments awaiting the user's
+// verification with their debt list and contact, sorted by submission date
+func (h *DebtHandler) GetPendingVerificationQueue(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	order := c.DefaultQuery("order", "desc")
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("order", order).Str("method", "GetPendingVerificationQueue").Logger()
+
+	if order != "asc" && order != "desc" {
+		logger.Warn().Msg("Invalid sort order")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid sort order", "order must be asc or desc", requestID))
+		return
+	}
+
+	logger.Info().Msg("Retrieving pending verification queue")
+
+	queue, err := h.debtService.GetPendingVerificationQueue(ctx, userUUID, order == "asc")
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve pending verification queue")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	logger.Info().Int("count", len(queue)).Msg("Pending verification queue retrieved successfully")
+
+	page, meta := paginate(queue, getPagination(c))
+	c.JSON(http.StatusOK, NewPaginatedResponse(c, "Pending verifications retrieved successfully", page, meta, requestID))
+}
+
+// GetUserPayments handles listing the payments on all of the user's debts, owned
+// and shared, optionally filtered by status and a from/to payment date range
+func (h *DebtHandler) GetUserPayments(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "GetUserPayments").Logger()
+
+	p := getPagination(c)
+	filter := entities.PaymentFilter{Status: c.Query("status"), Limit: p.Limit, Offset: p.Offset}
+	if value := c.Query("from"); value != "" {
+		from, err := parseReportDate(value, false)
+		if err != nil {
+			logger.Warn().Str("from", value).Msg("Invalid from date")
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid date range", "from must be a date or an RFC 3339 timestamp", requestID))
+			return
+		}
+		filter.From = &from
+	}
+	if value := c.Query("to"); value != "" {
+		to, err := parseReportDate(value, true)
+		if err != nil {
+			logger.Warn().Str("to", value).Msg("Invalid to date")
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid date range", "to must be a date or an RFC 3339 timestamp", requestID))
+			return
+		}
+		filter.To = &to
+	}
+
+	logger.Info().Str("status", filter.Status).Msg("Retrieving user payments")
+
+	payments, total, err := h.debtService.GetUserPayments(ctx, userUUID, filter)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve user payments")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		switch {
+		case errors.Is(err, entities.ErrInvalidPaymentStatus):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid payment status", err.Error(), requestID))
+		case errors.Is(err, entities.ErrInvalidDateRange):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid date range", err.Error(), requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	logger.Info().Int("count", len(payments)).Int("total", total).Msg("User payments retrieved successfully")
+
+	c.JSON(http.StatusOK, NewPaginatedResponse(c, "Payments retrieved successfully", payments, pageMeta(total, p), requestID))
+}
+
+// RejectDebtItem handles debt item rejection
+func (h *DebtHandler) RejectDebtItem(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	// Parse debt item ID from URL parameter
+	debtItemIDStr := c.Param("id")
+	debtItemID, err := uuid.Parse(debtItemIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("debt_item_id", debtItemIDStr).Msg("Invalid debt item ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt item ID", "", requestID))
+		return
+	}
+
+	var req struct {
+		Notes *string `json:"notes"`
+	}
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.Warn().Str("request_id", requestID).Err(err).Msg("Invalid request body")
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(c, err, requestID))
+		return
+	}
+
+	// Sanitize input
+	if req.Notes != nil {
+		sanitized := sanitizeRichText(*req.Notes)
+		req.Notes = &sanitized
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_item_id", debtItemID.String()).Str("method", "RejectDebtItem").Logger()
+
+	logger.Info().Msg("Debt item rejection attempt")
+
+	debtItem, err := h.debtService.RejectDebtItem(ctx, debtItemID, userUUID, req.Notes)
+	if err != nil {
+		logger.Error().Err(err).Msg("Debt item rejection failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		if errors.Is(err, entities.ErrPaymentAlreadyProcessed) {
+			c.JSON(http.StatusConflict, NewErrorResponse(c, "Payment already processed", err.Error(), requestID))
+			return
+		}
+
+		// Handle specific error types
+		switch err {
+		case entities.ErrDebtItemNotFound:
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt item not found", "", requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	logger.Info().Msg("Debt item rejected successfully")
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Debt item rejected successfully", debtItem, requestID))
+}
+
+// DisputeDebtItem handles flagging a pending debt item as disputed
+func (h *DebtHandler) DisputeDebtItem(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	// Parse debt item ID from URL parameter
+	debtItemIDStr := c.Param("id")
+	debtItemID, err := uuid.Parse(debtItemIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("debt_item_id", debtItemIDStr).Msg("Invalid debt item ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt item ID", "", requestID))
+		return
+	}
+
+	var req entities.DisputeDebtItemRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.Warn().Str("request_id", requestID).Err(err).Msg("Invalid request body")
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(c, err, requestID))
+		return
+	}
+
+	// Sanitize input
+	req.Reason = sanitizeRichText(req.Reason)
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_item_id", debtItemID.String()).Str("method", "DisputeDebtItem").Logger()
+
+	logger.Info().Msg("Debt item dispute attempt")
+
+	debtItem, err := h.debtService.DisputeDebtItem(ctx, debtItemID, userUUID, &req)
+	if err != nil {
+		logger.Error().Err(err).Msg("Debt item dispute failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
 
-		// Handle specific error types
-		switch err {
-		case entities.ErrDebtListNotFound:
-			c.JSON(http.StatusNotFound, NewErrorResponse("Debt list not found", "", requestID))
+		switch {
+		case errors.Is(err, entities.ErrDebtItemNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt item not found", "", requestID))
+		case errors.Is(err, entities.ErrPaymentAlreadyProcessed):
+			c.JSON(http.StatusConflict, NewErrorResponse(c, "Payment already processed", err.Error(), requestID))
 		default:
-			c.JSON(http.StatusInternalServerError, NewErrorResponse("Internal server error", "", requestID))
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
 		}
 		return
 	}
 
-	logger.Info().Msg("Payment summary retrieved successfully")
+	logger.Info().Msg("Debt item disputed successfully")
 
-	c.JSON(http.StatusOK, NewSuccessResponse("Payment summary retrieved successfully", summary, requestID))
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Debt item disputed successfully", debtItem, requestID))
 }
 
-// VerifyDebtItem handles debt item verification
-func (h *DebtHandler) VerifyDebtItem(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+// GetPaymentVerifier handles identifying the user who can verify a payment
+func (h *DebtHandler) GetPaymentVerifier(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
 	defer cancel()
 
 	// Extract request ID and user ID for logging
@@ -741,14 +2863,14 @@ func (h *DebtHandler) VerifyDebtItem(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
 		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
@@ -757,51 +2879,39 @@ func (h *DebtHandler) VerifyDebtItem(c *gin.Context) {
 	debtItemID, err := uuid.Parse(debtItemIDStr)
 	if err != nil {
 		h.logger.Warn().Str("request_id", requestID).Str("debt_item_id", debtItemIDStr).Msg("Invalid debt item ID format")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid debt item ID", "", requestID))
-		return
-	}
-
-	var req entities.VerifyDebtItemRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Warn().Str("request_id", requestID).Err(err).Msg("Invalid request body")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid request body", err.Error(), requestID))
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt item ID", "", requestID))
 		return
 	}
 
-	// Sanitize input
-	if req.VerificationNotes != nil {
-		sanitized := sanitizeString(*req.VerificationNotes)
-		req.VerificationNotes = &sanitized
-	}
-
-	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_item_id", debtItemID.String()).Str("method", "VerifyDebtItem").Logger()
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_item_id", debtItemID.String()).Str("method", "GetPaymentVerifier").Logger()
 
-	logger.Info().Str("status", req.Status).Msg("Debt item verification attempt")
+	logger.Info().Msg("Retrieving payment verifier")
 
-	debtItem, err := h.debtService.VerifyDebtItem(ctx, debtItemID, userUUID, &req)
+	verifier, err := h.debtService.GetPaymentVerifier(ctx, debtItemID, userUUID)
 	if err != nil {
-		logger.Error().Err(err).Str("status", req.Status).Msg("Debt item verification failed")
+		logger.Error().Err(err).Msg("Failed to retrieve payment verifier")
 
-		// Handle specific error types
-		switch err {
-		case entities.ErrDebtItemNotFound:
-			c.JSON(http.StatusNotFound, NewErrorResponse("Debt item not found", "", requestID))
-		case entities.ErrInvalidPaymentStatus:
-			c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid payment status", err.Error(), requestID))
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, entities.ErrDebtItemNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt item not found", "", requestID))
 		default:
-			c.JSON(http.StatusInternalServerError, NewErrorResponse("Internal server error", "", requestID))
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
 		}
 		return
 	}
 
-	logger.Info().Str("status", req.Status).Msg("Debt item verified successfully")
+	logger.Info().Str("role", verifier.Role).Bool("is_requester", verifier.IsRequester).Msg("Payment verifier retrieved successfully")
 
-	c.JSON(http.StatusOK, NewSuccessResponse("Debt item verified successfully", debtItem, requestID))
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Payment verifier retrieved successfully", verifier, requestID))
 }
 
-// GetPendingVerifications handles retrieving pending verifications for a user
-func (h *DebtHandler) GetPendingVerifications(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+// ResubmitDebtItem handles resubmitting a rejected debt item for verification
+func (h *DebtHandler) ResubmitDebtItem(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
 	defer cancel()
 
 	// Extract request ID and user ID for logging
@@ -809,36 +2919,76 @@ func (h *DebtHandler) GetPendingVerifications(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
 		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
-	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "GetPendingVerifications").Logger()
+	// Parse debt item ID from URL parameter
+	debtItemIDStr := c.Param("id")
+	debtItemID, err := uuid.Parse(debtItemIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("debt_item_id", debtItemIDStr).Msg("Invalid debt item ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt item ID", "", requestID))
+		return
+	}
 
-	logger.Info().Msg("Retrieving pending verifications")
+	var req entities.ResubmitDebtItemRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.Warn().Str("request_id", requestID).Err(err).Msg("Invalid request body")
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(c, err, requestID))
+		return
+	}
 
-	pendingVerifications, err := h.debtService.GetPendingVerifications(ctx, userUUID)
+	// Sanitize input
+	if req.ReceiptPhotoURL != nil {
+		sanitized := sanitizeString(*req.ReceiptPhotoURL)
+		req.ReceiptPhotoURL = &sanitized
+	}
+	if req.VerificationNotes != nil {
+		sanitized := sanitizeRichText(*req.VerificationNotes)
+		req.VerificationNotes = &sanitized
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_item_id", debtItemID.String()).Str("method", "ResubmitDebtItem").Logger()
+
+	logger.Info().Msg("Debt item resubmission attempt")
+
+	debtItem, err := h.debtService.ResubmitDebtItem(ctx, debtItemID, userUUID, &req)
 	if err != nil {
-		logger.Error().Err(err).Msg("Failed to retrieve pending verifications")
-		c.JSON(http.StatusInternalServerError, NewErrorResponse("Internal server error", "", requestID))
+		logger.Error().Err(err).Msg("Debt item resubmission failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, entities.ErrDebtItemNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt item not found", "", requestID))
+		case errors.Is(err, entities.ErrForbidden):
+			c.JSON(http.StatusForbidden, NewErrorResponse(c, "Only the payment submitter can resubmit it", "", requestID))
+		case errors.Is(err, entities.ErrPaymentNotRejected):
+			c.JSON(http.StatusConflict, NewErrorResponse(c, "Payment not rejected", err.Error(), requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
 		return
 	}
 
-	logger.Info().Int("count", len(pendingVerifications)).Msg("Pending verifications retrieved successfully")
+	logger.Info().Msg("Debt item resubmitted successfully")
 
-	c.JSON(http.StatusOK, NewSuccessResponse("Pending verifications retrieved successfully", pendingVerifications, requestID))
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Debt item resubmitted successfully", debtItem, requestID))
 }
 
-// RejectDebtItem handles debt item rejection
-func (h *DebtHandler) RejectDebtItem(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+// NudgeDebtItem handles reminding the verifier of a pending payment to verify it
+func (h *DebtHandler) NudgeDebtItem(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
 	defer cancel()
 
 	// Extract request ID and user ID for logging
@@ -846,14 +2996,14 @@ func (h *DebtHandler) RejectDebtItem(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
 		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
@@ -862,51 +3012,49 @@ func (h *DebtHandler) RejectDebtItem(c *gin.Context) {
 	debtItemID, err := uuid.Parse(debtItemIDStr)
 	if err != nil {
 		h.logger.Warn().Str("request_id", requestID).Str("debt_item_id", debtItemIDStr).Msg("Invalid debt item ID format")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid debt item ID", "", requestID))
-		return
-	}
-
-	var req struct {
-		Notes *string `json:"notes"`
-	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Warn().Str("request_id", requestID).Err(err).Msg("Invalid request body")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid request body", err.Error(), requestID))
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt item ID", "", requestID))
 		return
 	}
 
-	// Sanitize input
-	if req.Notes != nil {
-		sanitized := sanitizeString(*req.Notes)
-		req.Notes = &sanitized
-	}
-
-	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_item_id", debtItemID.String()).Str("method", "RejectDebtItem").Logger()
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_item_id", debtItemID.String()).Str("method", "NudgeDebtItem").Logger()
 
-	logger.Info().Msg("Debt item rejection attempt")
+	logger.Info().Msg("Debt item nudge attempt")
 
-	debtItem, err := h.debtService.RejectDebtItem(ctx, debtItemID, userUUID, req.Notes)
+	debtItem, err := h.debtService.NudgeDebtItem(ctx, debtItemID, userUUID)
 	if err != nil {
-		logger.Error().Err(err).Msg("Debt item rejection failed")
+		logger.Error().Err(err).Msg("Debt item nudge failed")
 
-		// Handle specific error types
-		switch err {
-		case entities.ErrDebtItemNotFound:
-			c.JSON(http.StatusNotFound, NewErrorResponse("Debt item not found", "", requestID))
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, entities.ErrDebtItemNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt item not found", "", requestID))
+		case errors.Is(err, entities.ErrForbidden):
+			c.JSON(http.StatusForbidden, NewErrorResponse(c, "Only the payment submitter can nudge it", "", requestID))
+		case errors.Is(err, entities.ErrPaymentNotPending):
+			c.JSON(http.StatusConflict, NewErrorResponse(c, "Payment not pending", err.Error(), requestID))
+		case errors.Is(err, entities.ErrNudgeTooSoon):
+			c.JSON(http.StatusTooManyRequests, NewErrorResponse(c, "Payment was nudged too recently", err.Error(), requestID))
+		case errors.Is(err, entities.ErrContactNotAppUser):
+			c.JSON(http.StatusUnprocessableEntity, NewErrorResponse(c, "Verifier must be a registered user", "", requestID))
+		case errors.Is(err, entities.ErrNotificationsUnavailable):
+			c.JSON(http.StatusServiceUnavailable, NewErrorResponse(c, "Notifications unavailable", "", requestID))
 		default:
-			c.JSON(http.StatusInternalServerError, NewErrorResponse("Internal server error", "", requestID))
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
 		}
 		return
 	}
 
-	logger.Info().Msg("Debt item rejected successfully")
+	logger.Info().Msg("Debt item nudged successfully")
 
-	c.JSON(http.StatusOK, NewSuccessResponse("Debt item rejected successfully", debtItem, requestID))
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Verifier nudged successfully", debtItem, requestID))
 }
 
 // UploadReceipt handles receipt photo upload for a debt item
 func (h *DebtHandler) UploadReceipt(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 60*time.Second) // Longer timeout for file uploads
+	ctx, cancel := requestContext(c, DefaultUploadTimeout)
 	defer cancel()
 
 	// Extract request ID and user ID for logging
@@ -914,14 +3062,14 @@ func (h *DebtHandler) UploadReceipt(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
 	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
 		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
@@ -930,14 +3078,20 @@ func (h *DebtHandler) UploadReceipt(c *gin.Context) {
 	debtItemID, err := uuid.Parse(debtItemIDStr)
 	if err != nil {
 		h.logger.Warn().Str("request_id", requestID).Str("debt_item_id", debtItemIDStr).Msg("Invalid debt item ID format")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid debt item ID", "", requestID))
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt item ID", "", requestID))
 		return
 	}
 
-	// Parse multipart form
-	if err := c.Request.ParseMultipartForm(32 << 20); err != nil { // 32MB max
+	// Parse multipart form, bounding the body by the same limit the file validator uses
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxReceiptSize+multipartFormOverhead)
+	if err := c.Request.ParseMultipartForm(h.maxReceiptSize); err != nil {
 		h.logger.Warn().Str("request_id", requestID).Err(err).Msg("Failed to parse multipart form")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Failed to parse form data", "", requestID))
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, NewErrorResponse(c, "Receipt file too large", fmt.Sprintf("maximum allowed size is %d bytes", h.maxReceiptSize), requestID))
+			return
+		}
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Failed to parse form data", "", requestID))
 		return
 	}
 
@@ -945,7 +3099,7 @@ func (h *DebtHandler) UploadReceipt(c *gin.Context) {
 	file, header, err := c.Request.FormFile("receipt")
 	if err != nil {
 		h.logger.Warn().Str("request_id", requestID).Err(err).Msg("No receipt file provided")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Receipt file is required", "", requestID))
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Receipt file is required", "", requestID))
 		return
 	}
 	defer file.Close()
@@ -955,7 +3109,7 @@ func (h *DebtHandler) UploadReceipt(c *gin.Context) {
 	// Validate file
 	if err := h.validateReceiptFile(header); err != nil {
 		logger.Warn().Err(err).Str("filename", header.Filename).Msg("Invalid receipt file")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid receipt file", err.Error(), requestID))
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid receipt file", err.Error(), requestID))
 		return
 	}
 
@@ -963,7 +3117,7 @@ func (h *DebtHandler) UploadReceipt(c *gin.Context) {
 	photoURL, err := h.fileStorageService.UploadReceipt(ctx, file, header.Filename, header.Header.Get("Content-Type"), debtItemID)
 	if err != nil {
 		logger.Error().Err(err).Str("filename", header.Filename).Msg("Failed to upload receipt")
-		c.JSON(http.StatusInternalServerError, NewErrorResponse("Failed to upload receipt", "", requestID))
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Failed to upload receipt", "", requestID))
 		return
 	}
 
@@ -975,13 +3129,17 @@ func (h *DebtHandler) UploadReceipt(c *gin.Context) {
 	debtItem, err := h.debtService.UpdateDebtItem(ctx, debtItemID, userUUID, updateReq)
 	if err != nil {
 		logger.Error().Err(err).Str("photo_url", photoURL).Msg("Failed to update debt item with receipt photo")
-		c.JSON(http.StatusInternalServerError, NewErrorResponse("Failed to update debt item", "", requestID))
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Failed to update debt item", "", requestID))
 		return
 	}
 
 	logger.Info().Str("filename", header.Filename).Str("photo_url", photoURL).Msg("Receipt uploaded successfully")
 
-	c.JSON(http.StatusOK, NewSuccessResponse("Receipt uploaded successfully", debtItem, requestID))
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Receipt uploaded successfully", debtItem, requestID))
 }
 
 // GetReceiptPhoto serves a receipt photo from S3
@@ -995,13 +3153,13 @@ func (h *DebtHandler) GetReceiptPhoto(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		h.logger.Warn().Str("request_id", requestID).Msg("User ID not found in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 	userUUID, ok := userID.(uuid.UUID)
 	if !ok {
 		h.logger.Warn().Str("request_id", requestID).Msg("Invalid user ID type in context")
-		c.JSON(http.StatusUnauthorized, NewErrorResponse("Unauthorized", "", requestID))
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
 		return
 	}
 
@@ -1010,17 +3168,28 @@ func (h *DebtHandler) GetReceiptPhoto(c *gin.Context) {
 	debtID, err := uuid.Parse(debtIDStr)
 	if err != nil {
 		h.logger.Warn().Str("request_id", requestID).Str("debt_id", debtIDStr).Msg("Invalid debt ID format")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid debt ID", "", requestID))
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt ID", "", requestID))
 		return
 	}
 
 	filename := c.Param("filename")
 	if filename == "" {
 		h.logger.Warn().Str("request_id", requestID).Msg("Filename not provided")
-		c.JSON(http.StatusBadRequest, NewErrorResponse("Filename is required", "", requestID))
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Filename is required", "", requestID))
 		return
 	}
 
+	// Receipts are shown inline unless the client asks to save them
+	download := false
+	if value := c.Query("download"); value != "" {
+		download, err = strconv.ParseBool(value)
+		if err != nil {
+			h.logger.Warn().Str("request_id", requestID).Str("download", value).Msg("Invalid download flag")
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid download flag", "", requestID))
+			return
+		}
+	}
+
 	// Construct the full API path
 	fullPath := fmt.Sprintf("/api/v1/debts/%s/receipts/%s", debtID.String(), filename)
 
@@ -1030,7 +3199,7 @@ func (h *DebtHandler) GetReceiptPhoto(c *gin.Context) {
 	fileContent, contentType, err := h.fileStorageService.GetReceiptFile(c.Request.Context(), fullPath)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to retrieve receipt photo")
-		c.JSON(http.StatusNotFound, NewErrorResponse("Receipt photo not found", "", requestID))
+		c.JSON(http.StatusNotFound, NewErrorResponse(c, "Receipt photo not found", "", requestID))
 		return
 	}
 
@@ -1038,7 +3207,11 @@ func (h *DebtHandler) GetReceiptPhoto(c *gin.Context) {
 	c.Header("Content-Type", contentType)
 	c.Header("Content-Length", fmt.Sprintf("%d", len(fileContent)))
 	c.Header("Cache-Control", "public, max-age=3600") // Cache for 1 hour
-	c.Header("Content-Disposition", "inline")
+	if download {
+		c.Header("Content-Disposition", h.receiptAttachmentDisposition(c.Request.Context(), fullPath, filename, logger))
+	} else {
+		c.Header("Content-Disposition", "inline")
+	}
 
 	// Serve the file
 	c.Data(http.StatusOK, contentType, fileContent)
@@ -1046,49 +3219,322 @@ func (h *DebtHandler) GetReceiptPhoto(c *gin.Context) {
 	logger.Info().Str("content_type", contentType).Int("size", len(fileContent)).Msg("Receipt photo served successfully")
 }
 
+// receiptAttachmentDisposition builds the Content-Disposition for saving a receipt
+// under the name it was uploaded with, falling back to the stored filename when
+// the original is unknown
+func (h *DebtHandler) receiptAttachmentDisposition(ctx context.Context, fullPath, storedFilename string, logger zerolog.Logger) string {
+	name, err := h.fileStorageService.GetReceiptFilename(ctx, fullPath)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to retrieve original receipt filename")
+	}
+	name = filepath.Base(name)
+	if name == "." || name == string(filepath.Separator) {
+		name = storedFilename
+	}
+
+	if disposition := mime.FormatMediaType("attachment", map[string]string{"filename": name}); disposition != "" {
+		return disposition
+	}
+	return fmt.Sprintf("attachment; filename=%q", storedFilename)
+}
+
+// UploadDebtDocument handles attaching a document, such as a signed agreement, to a debt list
+func (h *DebtHandler) UploadDebtDocument(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultUploadTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	// Parse debt list ID from URL parameter
+	debtListIDStr := c.Param("id")
+	debtListID, err := uuid.Parse(debtListIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("debt_list_id", debtListIDStr).Msg("Invalid debt list ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt list ID", "", requestID))
+		return
+	}
+
+	// Parse multipart form, bounding the body by the same limit the file validator uses
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxReceiptSize+multipartFormOverhead)
+	if err := c.Request.ParseMultipartForm(h.maxReceiptSize); err != nil {
+		h.logger.Warn().Str("request_id", requestID).Err(err).Msg("Failed to parse multipart form")
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, NewErrorResponse(c, "Document file too large", fmt.Sprintf("maximum allowed size is %d bytes", h.maxReceiptSize), requestID))
+			return
+		}
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Failed to parse form data", "", requestID))
+		return
+	}
+
+	// Get the uploaded file
+	file, header, err := c.Request.FormFile("document")
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Err(err).Msg("No document file provided")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Document file is required", "", requestID))
+		return
+	}
+	defer file.Close()
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_list_id", debtListID.String()).Str("method", "UploadDebtDocument").Logger()
+
+	// Validate file
+	if err := h.validateDocumentFile(header); err != nil {
+		logger.Warn().Err(err).Str("filename", header.Filename).Msg("Invalid document file")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid document file", err.Error(), requestID))
+		return
+	}
+
+	document, err := h.debtService.UploadDebtDocument(ctx, debtListID, userUUID, file, header.Filename, header.Header.Get("Content-Type"))
+	if err != nil {
+		logger.Error().Err(err).Str("filename", header.Filename).Msg("Failed to upload document")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		switch {
+		case errors.Is(err, entities.ErrDebtListNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt list not found", "", requestID))
+		case errors.Is(err, entities.ErrForbidden):
+			c.JSON(http.StatusForbidden, NewErrorResponse(c, "Only the debt owner can upload documents", "", requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Failed to upload document", "", requestID))
+		}
+		return
+	}
+
+	logger.Info().Str("filename", header.Filename).Str("document_url", document.URL).Msg("Document uploaded successfully")
+
+	c.JSON(http.StatusCreated, NewSuccessResponse(c, "Document uploaded successfully", document, requestID))
+}
+
+// ImportPayments handles importing payments into a debt list from a bank statement
+// CSV. Form fields name the CSV columns to read; each row is reported as imported or skipped.
+func (h *DebtHandler) ImportPayments(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultUploadTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	// Parse debt list ID from URL parameter
+	debtListIDStr := c.Param("id")
+	debtListID, err := uuid.Parse(debtListIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("debt_list_id", debtListIDStr).Msg("Invalid debt list ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt list ID", "", requestID))
+		return
+	}
+
+	// Parse multipart form, bounding the body by the import size limit
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize+multipartFormOverhead)
+	if err := c.Request.ParseMultipartForm(maxImportFileSize); err != nil {
+		h.logger.Warn().Str("request_id", requestID).Err(err).Msg("Failed to parse multipart form")
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, NewErrorResponse(c, "Import file too large", fmt.Sprintf("maximum allowed size is %d bytes", maxImportFileSize), requestID))
+			return
+		}
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Failed to parse form data", "", requestID))
+		return
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Err(err).Msg("No import file provided")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Import file is required", "", requestID))
+		return
+	}
+	defer file.Close()
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_list_id", debtListID.String()).Str("method", "ImportPayments").Logger()
+
+	if header.Size > maxImportFileSize {
+		logger.Warn().Int64("size", header.Size).Msg("Import file too large")
+		c.JSON(http.StatusRequestEntityTooLarge, NewErrorResponse(c, "Import file too large", fmt.Sprintf("maximum allowed size is %d bytes", maxImportFileSize), requestID))
+		return
+	}
+
+	mapping := entities.PaymentImportMapping{
+		DateColumn:        c.PostForm("date_column"),
+		AmountColumn:      c.PostForm("amount_column"),
+		ReferenceColumn:   c.PostForm("reference_column"),
+		DescriptionColumn: c.PostForm("description_column"),
+		DateFormat:        c.PostForm("date_format"),
+		PaymentMethod:     c.PostForm("payment_method"),
+		Currency:          c.PostForm("currency"),
+	}
+
+	logger.Info().Str("filename", header.Filename).Msg("Importing payments")
+
+	result, err := h.debtService.ImportPayments(ctx, debtListID, userUUID, file, mapping)
+	if err != nil {
+		logger.Error().Err(err).Str("filename", header.Filename).Msg("Failed to import payments")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		switch {
+		case errors.Is(err, entities.ErrInvalidImportFile):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid import file", err.Error(), requestID))
+		case errors.Is(err, entities.ErrDebtListNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt list not found", "", requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	logger.Info().Int("imported", result.Imported).Int("skipped", result.Skipped).Msg("Payments imported successfully")
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Payments imported successfully", result, requestID))
+}
+
+// GetDebtDocument serves a document attached to a debt list to either party of the debt
+func (h *DebtHandler) GetDebtDocument(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Warn().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Warn().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	// Get debt ID and filename from URL parameters
+	debtIDStr := c.Param("id")
+	debtID, err := uuid.Parse(debtIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("debt_id", debtIDStr).Msg("Invalid debt ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid debt ID", "", requestID))
+		return
+	}
+
+	filename := c.Param("filename")
+	if filename == "" {
+		h.logger.Warn().Str("request_id", requestID).Msg("Filename not provided")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Filename is required", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("debt_id", debtID.String()).Str("filename", filename).Str("method", "GetDebtDocument").Logger()
+
+	fileContent, contentType, err := h.debtService.GetDebtDocument(ctx, debtID, userUUID, filename)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve document")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+		switch {
+		case errors.Is(err, entities.ErrDebtListNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt list not found", "", requestID))
+		case errors.Is(err, entities.ErrDebtDocumentNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Document not found", "", requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	// Documents are private to the parties of the debt, so they must not be cached by shared caches
+	c.Header("Content-Length", fmt.Sprintf("%d", len(fileContent)))
+	c.Header("Cache-Control", "private, max-age=3600")
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+
+	c.Data(http.StatusOK, contentType, fileContent)
+
+	logger.Info().Str("content_type", contentType).Int("size", len(fileContent)).Msg("Document served successfully")
+}
+
+// multipartFormOverhead allows for boundaries and part headers on top of the
+// receipt itself when bounding the upload request body
+const multipartFormOverhead = 1 << 20
+
+// maxImportFileSize is the largest payment import CSV accepted (2MB)
+const maxImportFileSize = 2 << 20
+
 // validateReceiptFile validates the uploaded receipt file
 func (h *DebtHandler) validateReceiptFile(header *multipart.FileHeader) error {
-	// Check file size (max 10MB)
-	if header.Size > 10*1024*1024 {
-		return fmt.Errorf("file size %d bytes exceeds maximum allowed size of 10MB", header.Size)
+	// Check file size against the configured limit
+	if header.Size > h.maxReceiptSize {
+		return fmt.Errorf("file size %d bytes exceeds maximum allowed size of %d bytes", header.Size, h.maxReceiptSize)
 	}
 
 	// Check file type
 	contentType := header.Header.Get("Content-Type")
-	if !h.isValidImageType(contentType) {
-		return fmt.Errorf("invalid file type: %s. Only images (JPEG, PNG, GIF, WebP) are allowed", contentType)
+	if !h.isAllowedReceiptType(contentType) {
+		return fmt.Errorf("invalid file type: %s. Only %s are allowed", contentType, strings.Join(h.receiptContentTypes, ", "))
 	}
 
 	// Check file extension
 	ext := strings.ToLower(filepath.Ext(header.Filename))
-	validExtensions := map[string]bool{
-		".jpg":  true,
-		".jpeg": true,
-		".png":  true,
-		".gif":  true,
-		".webp": true,
-	}
-	if !validExtensions[ext] {
-		return fmt.Errorf("invalid file extension: %s. Only .jpg, .jpeg, .png, .gif, .webp are allowed", ext)
+	if !slices.Contains(h.receiptExtensions, ext) {
+		return fmt.Errorf("invalid file extension: %s. Only %s are allowed", ext, strings.Join(h.receiptExtensions, ", "))
 	}
 
 	return nil
 }
 
-// isValidImageType checks if the content type is a valid image type
-func (h *DebtHandler) isValidImageType(contentType string) bool {
-	validTypes := map[string]bool{
-		"image/jpeg": true,
-		"image/jpg":  true,
-		"image/png":  true,
-		"image/gif":  true,
-		"image/webp": true,
-	}
-	return validTypes[contentType]
+// isAllowedReceiptType checks if the content type is configured as accepted for receipts
+func (h *DebtHandler) isAllowedReceiptType(contentType string) bool {
+	return slices.Contains(h.receiptContentTypes, strings.ToLower(contentType))
 }
 
+// validateDocumentFile validates an uploaded debt list document. Documents
+// accept PDF in addition to the types allowed for receipts.
+func (h *DebtHandler) validateDocumentFile(header *multipart.FileHeader) error {
+	// Check file size against the configured limit
+	if header.Size > h.maxReceiptSize {
+		return fmt.Errorf("file size %d bytes exceeds maximum allowed size of %d bytes", header.Size, h.maxReceiptSize)
+	}
 
+	// Check file type
+	contentType := header.Header.Get("Content-Type")
+	if contentType != "application/pdf" && !h.isAllowedReceiptType(contentType) {
+		return fmt.Errorf("invalid file type: %s. Only application/pdf and %s are allowed", contentType, strings.Join(h.receiptContentTypes, ", "))
+	}
 
+	// Check file extension
+	ext := strings.ToLower(filepath.Ext(header.Filename))
+	if ext != ".pdf" && !slices.Contains(h.receiptExtensions, ext) {
+		return fmt.Errorf("invalid file extension: %s. Only .pdf and %s are allowed", ext, strings.Join(h.receiptExtensions, ", "))
+	}
 
-
-
+	return nil
+}
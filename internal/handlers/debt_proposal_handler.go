@@ -0,0 +1,299 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/domain/interfaces"
+)
+
+// DebtProposalHandler handles debt proposal HTTP requests
+type DebtProposalHandler struct {
+	proposalService interfaces.DebtProposalService
+	logger          zerolog.Logger
+}
+
+// NewDebtProposalHandler creates a new debt proposal handler
+func NewDebtProposalHandler(proposalService interfaces.DebtProposalService, logger zerolog.Logger) *DebtProposalHandler {
+	return &DebtProposalHandler{
+		proposalService: proposalService,
+		logger:          logger.With().Str("handler", "debt_proposal").Logger(),
+	}
+}
+
+// ProposeDebt handles proposing debt terms to a contact
+func (h *DebtProposalHandler) ProposeDebt(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userUUID, ok := h.getUserID(c, requestID)
+	if !ok {
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "ProposeDebt").Logger()
+
+	var req entities.CreateDebtListRequest
+	if err := bindJSON(c, &req); err != nil {
+		logger.Warn().Err(err).Msg("Invalid request body")
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(c, err, requestID))
+		return
+	}
+
+	// Sanitize input
+	req.TotalAmount = sanitizeString(req.TotalAmount)
+	req.Currency = sanitizeString(req.Currency)
+	req.InstallmentPlan = sanitizeString(req.InstallmentPlan)
+	req.DebtType = sanitizeString(req.DebtType)
+	if req.Description != nil {
+		sanitized := sanitizeRichText(*req.Description)
+		req.Description = &sanitized
+	}
+	if req.Notes != nil {
+		sanitized := sanitizeRichText(*req.Notes)
+		req.Notes = &sanitized
+	}
+
+	logger.Info().Str("debt_type", req.DebtType).Str("contact_id", req.ContactID.String()).Msg("Debt proposal attempt")
+
+	proposal, err := h.proposalService.ProposeDebt(ctx, userUUID, &req)
+	if err != nil {
+		logger.Error().Err(err).Msg("Debt proposal failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		// Handle specific error types
+		switch {
+		case errors.Is(err, entities.ErrTooManyPayments):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Too many payments", err.Error(), requestID))
+		case errors.Is(err, entities.ErrTotalAmountTooLarge):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Total amount too large", err.Error(), requestID))
+		case errors.Is(err, entities.ErrConflictingSchedule):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Conflicting schedule", err.Error(), requestID))
+		case errors.Is(err, entities.ErrInvalidDebtType), errors.Is(err, entities.ErrInvalidAmount),
+			errors.Is(err, entities.ErrInvalidCurrency), errors.Is(err, entities.ErrInvalidDueDate),
+			errors.Is(err, entities.ErrInvalidPaymentWeekday), errors.Is(err, entities.ErrInvalidRoundingMode),
+			errors.Is(err, entities.ErrTextTooLong), errors.Is(err, entities.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid input", err.Error(), requestID))
+		case errors.Is(err, entities.ErrContactNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse(c, "Contact not found", "", requestID))
+		case errors.Is(err, entities.ErrContactBlocked):
+			c.JSON(http.StatusForbidden, NewErrorResponse(c, "Contact is blocked", "", requestID))
+		case errors.Is(err, entities.ErrContactNotAppUser):
+			c.JSON(http.StatusUnprocessableEntity, NewErrorResponse(c, "Contact must be a registered user", "", requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	logger.Info().Str("proposal_id", proposal.ID.String()).Msg("Debt proposal created successfully")
+
+	c.JSON(http.StatusCreated, NewSuccessResponse(c, "Debt proposal sent successfully", proposal, requestID))
+}
+
+// GetUserDebtProposals handles retrieving proposals sent or received by the user
+func (h *DebtProposalHandler) GetUserDebtProposals(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userUUID, ok := h.getUserID(c, requestID)
+	if !ok {
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "GetUserDebtProposals").Logger()
+
+	logger.Info().Msg("Retrieving debt proposals")
+
+	proposals, err := h.proposalService.GetUserDebtProposals(ctx, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve debt proposals")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	logger.Info().Int("count", len(proposals)).Msg("Debt proposals retrieved successfully")
+
+	page, meta := paginate(proposals, getPagination(c))
+	c.JSON(http.StatusOK, NewPaginatedResponse(c, "Debt proposals retrieved successfully", page, meta, requestID))
+}
+
+// AcceptDebtProposal handles accepting a proposal, which creates the debt list
+func (h *DebtProposalHandler) AcceptDebtProposal(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userUUID, ok := h.getUserID(c, requestID)
+	if !ok {
+		return
+	}
+
+	proposalID, ok := h.getProposalID(c, requestID)
+	if !ok {
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("proposal_id", proposalID.String()).Str("method", "AcceptDebtProposal").Logger()
+
+	logger.Info().Msg("Debt proposal acceptance attempt")
+
+	debtList, err := h.proposalService.AcceptDebtProposal(ctx, proposalID, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Debt proposal acceptance failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		h.writeResponseError(c, err, requestID)
+		return
+	}
+
+	logger.Info().Str("debt_list_id", debtList.ID.String()).Msg("Debt proposal accepted successfully")
+
+	c.JSON(http.StatusCreated, NewSuccessResponse(c, "Debt proposal accepted successfully", debtList, requestID))
+}
+
+// RejectDebtProposal handles declining a proposal
+func (h *DebtProposalHandler) RejectDebtProposal(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userUUID, ok := h.getUserID(c, requestID)
+	if !ok {
+		return
+	}
+
+	proposalID, ok := h.getProposalID(c, requestID)
+	if !ok {
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("proposal_id", proposalID.String()).Str("method", "RejectDebtProposal").Logger()
+
+	logger.Info().Msg("Debt proposal rejection attempt")
+
+	proposal, err := h.proposalService.RejectDebtProposal(ctx, proposalID, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Debt proposal rejection failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		h.writeResponseError(c, err, requestID)
+		return
+	}
+
+	logger.Info().Msg("Debt proposal rejected successfully")
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Debt proposal rejected successfully", proposal, requestID))
+}
+
+// CancelDebtProposal handles the proposer withdrawing a proposal
+func (h *DebtProposalHandler) CancelDebtProposal(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userUUID, ok := h.getUserID(c, requestID)
+	if !ok {
+		return
+	}
+
+	proposalID, ok := h.getProposalID(c, requestID)
+	if !ok {
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("proposal_id", proposalID.String()).Str("method", "CancelDebtProposal").Logger()
+
+	logger.Info().Msg("Debt proposal cancellation attempt")
+
+	proposal, err := h.proposalService.CancelDebtProposal(ctx, proposalID, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Debt proposal cancellation failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		h.writeResponseError(c, err, requestID)
+		return
+	}
+
+	logger.Info().Msg("Debt proposal cancelled successfully")
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Debt proposal cancelled successfully", proposal, requestID))
+}
+
+// getUserID extracts the authenticated user ID, writing a 401 response if missing
+func (h *DebtProposalHandler) getUserID(c *gin.Context, requestID string) (uuid.UUID, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return uuid.Nil, false
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return uuid.Nil, false
+	}
+
+	return userUUID, true
+}
+
+// getProposalID parses the proposal ID URL parameter, writing a 400 response if invalid
+func (h *DebtProposalHandler) getProposalID(c *gin.Context, requestID string) (uuid.UUID, bool) {
+	proposalIDStr := c.Param("id")
+	proposalID, err := uuid.Parse(proposalIDStr)
+	if err != nil {
+		h.logger.Warn().Str("request_id", requestID).Str("proposal_id", proposalIDStr).Msg("Invalid proposal ID format")
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid proposal ID", "", requestID))
+		return uuid.Nil, false
+	}
+	return proposalID, true
+}
+
+// writeResponseError maps accept, reject and cancel errors to HTTP responses
+func (h *DebtProposalHandler) writeResponseError(c *gin.Context, err error, requestID string) {
+	switch {
+	case errors.Is(err, entities.ErrDebtProposalNotFound):
+		c.JSON(http.StatusNotFound, NewErrorResponse(c, "Debt proposal not found", "", requestID))
+	case errors.Is(err, entities.ErrDebtProposalNotPending):
+		c.JSON(http.StatusConflict, NewErrorResponse(c, "Debt proposal already answered", "", requestID))
+	case errors.Is(err, entities.ErrInvalidDueDate), errors.Is(err, entities.ErrInvalidAmount), errors.Is(err, entities.ErrInvalidDebtType):
+		c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid input", err.Error(), requestID))
+	case errors.Is(err, entities.ErrContactNotFound):
+		c.JSON(http.StatusNotFound, NewErrorResponse(c, "Contact not found", "", requestID))
+	case errors.Is(err, entities.ErrContactBlocked):
+		c.JSON(http.StatusForbidden, NewErrorResponse(c, "Contact is blocked", "", requestID))
+	default:
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+	}
+}
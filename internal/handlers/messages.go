@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultLocale is the language handlers write their messages in and the one
+// used when the client does not ask for a supported locale
+const defaultLocale = "en"
+
+// messageCatalogs holds translations of response messages keyed by locale and
+// then by message code. English needs no catalog because handlers already
+// write their messages in it.
+var messageCatalogs = map[string]map[string]string{
+	"es": {
+		"activity_summary_retrieved_successfully":          "Resumen de actividad obtenido correctamente",
+		"api_key_created_successfully":                     "Clave de API creada correctamente",
+		"api_key_not_found":                                "Clave de API no encontrada",
+		"api_key_revoked_successfully":                     "Clave de API revocada correctamente",
+		"api_keys_retrieved_successfully":                  "Claves de API obtenidas correctamente",
+		"balance_history_retrieved_successfully":           "Historial de saldo obtenido correctamente",
+		"conflicting_schedule":                             "El calendario es contradictorio",
+		"contact_already_exists":                           "El contacto ya existe",
+		"contact_blocked_successfully":                     "Contacto bloqueado correctamente",
+		"contact_created_successfully":                     "Contacto creado correctamente",
+		"contact_deleted_successfully":                     "Contacto eliminado correctamente",
+		"contact_favorite_updated_successfully":            "Favorito del contacto actualizado correctamente",
+		"contact_has_unsettled_debts":                      "El contacto tiene deudas sin saldar",
+		"contact_is_blocked":                               "El contacto está bloqueado",
+		"contact_lookup_completed":                         "Búsqueda de contacto completada",
+		"contact_must_be_a_registered_user":                "El contacto debe ser un usuario registrado",
+		"contact_not_found":                                "Contacto no encontrado",
+		"contact_reconciliation_retrieved_successfully":    "Conciliación con el contacto obtenida correctamente",
+		"contact_retrieved_successfully":                   "Contacto obtenido correctamente",
+		"contact_summary_retrieved_successfully":           "Resumen del contacto obtenido correctamente",
+		"contact_unblocked_successfully":                   "Contacto desbloqueado correctamente",
+		"contact_updated_successfully":                     "Contacto actualizado correctamente",
+		"contact_with_this_phone_number_already_exists":    "Ya existe un contacto con este número de teléfono",
+		"contacts_retrieved_successfully":                  "Contactos obtenidos correctamente",
+		"currencies_retrieved_successfully":                "Monedas obtenidas correctamente",
+		"debt_counts_retrieved_successfully":               "Conteo de deudas obtenido correctamente",
+		"debt_item_disputed_successfully":                  "Pago marcado como disputado correctamente",
+		"debt_item_not_found":                              "Pago no encontrado",
+		"debt_item_rejected_successfully":                  "Pago rechazado correctamente",
+		"debt_item_resubmitted_successfully":               "Pago reenviado correctamente",
+		"debt_item_verified_successfully":                  "Pago verificado correctamente",
+		"debt_list_created_successfully":                   "Deuda creada correctamente",
+		"debt_list_deleted_successfully":                   "Deuda eliminada correctamente",
+		"debt_list_has_no_upcoming_payment":                "La deuda no tiene próximos pagos",
+		"debt_list_history_retrieved_successfully":         "Historial de la deuda obtenido correctamente",
+		"debt_list_not_found":                              "Deuda no encontrada",
+		"debt_list_ownership_transferred_successfully":     "Propiedad de la deuda transferida correctamente",
+		"debt_list_recomputed_successfully":                "Deuda recalculada correctamente",
+		"debt_list_retrieved_successfully":                 "Deuda obtenida correctamente",
+		"debt_list_updated_successfully":                   "Deuda actualizada correctamente",
+		"debt_lists_retrieved_successfully":                "Deudas obtenidas correctamente",
+		"debt_perspective_retrieved_successfully":          "Perspectiva de la deuda obtenida correctamente",
+		"debt_proposal_accepted_successfully":              "Propuesta de deuda aceptada correctamente",
+		"debt_proposal_already_answered":                   "La propuesta de deuda ya fue respondida",
+		"debt_proposal_cancelled_successfully":             "Propuesta de deuda cancelada correctamente",
+		"debt_proposal_not_found":                          "Propuesta de deuda no encontrada",
+		"debt_proposal_rejected_successfully":              "Propuesta de deuda rechazada correctamente",
+		"debt_proposal_sent_successfully":                  "Propuesta de deuda enviada correctamente",
+		"debt_proposals_retrieved_successfully":            "Propuestas de deuda obtenidas correctamente",
+		"debts_by_contact_retrieved_successfully":          "Deudas por contacto obtenidas correctamente",
+		"debts_needing_attention_retrieved_successfully":   "Deudas que requieren atención obtenidas correctamente",
+		"document_file_is_required":                        "El archivo del documento es obligatorio",
+		"document_file_too_large":                          "El archivo del documento es demasiado grande",
+		"document_not_found":                               "Documento no encontrado",
+		"document_uploaded_successfully":                   "Documento subido correctamente",
+		"due_soon_items_retrieved_successfully":            "Pagos próximos a vencer obtenidos correctamente",
+		"existing_contact_returned":                        "Se devolvió el contacto existente",
+		"failed_to_parse_form_data":                        "No se pudieron procesar los datos del formulario",
+		"failed_to_update_debt_item":                       "No se pudo actualizar el pago",
+		"failed_to_upload_document":                        "No se pudo subir el documento",
+		"failed_to_upload_receipt":                         "No se pudo subir el recibo",
+		"filename_is_required":                             "El nombre del archivo es obligatorio",
+		"import_file_is_required":                          "El archivo de importación es obligatorio",
+		"import_file_too_large":                            "El archivo de importación es demasiado grande",
+		"internal_server_error":                            "Error interno del servidor",
+		"invalid_amount":                                   "Monto no válido",
+		"invalid_api_key_id":                               "ID de clave de API no válido",
+		"invalid_contact_id":                               "ID de contacto no válido",
+		"invalid_credentials":                              "Credenciales no válidas",
+		"invalid_currency":                                 "Moneda no válida",
+		"invalid_date_range":                               "Rango de fechas no válido",
+		"invalid_debt_id":                                  "ID de deuda no válido",
+		"invalid_debt_item_id":                             "ID de pago no válido",
+		"invalid_debt_list_id":                             "ID de deuda no válido",
+		"invalid_direction":                                "Dirección no válida",
+		"invalid_document_file":                            "Archivo de documento no válido",
+		"invalid_download_flag":                            "Indicador de descarga no válido",
+		"invalid_favorites_first_flag":                     "Indicador favorites_first no válido",
+		"invalid_import_file":                              "Archivo de importación no válido",
+		"invalid_include_payments_flag":                    "Indicador include_payments no válido",
+		"invalid_input":                                    "Datos no válidos",
+		"invalid_modified_since_timestamp":                 "Marca de tiempo modified_since no válida",
+		"invalid_new_owner":                                "Nuevo propietario no válido",
+		"invalid_payment_status":                           "Estado de pago no válido",
+		"invalid_proposal_id":                              "ID de propuesta no válido",
+		"invalid_receipt_file":                             "Archivo de recibo no válido",
+		"invalid_request_body":                             "Cuerpo de la solicitud no válido",
+		"invalid_sort":                                     "Orden no válido",
+		"invalid_sort_order":                               "Orden no válido",
+		"invalid_upsert_flag":                              "Indicador upsert no válido",
+		"login_successful":                                 "Inicio de sesión correcto",
+		"new_owner_has_no_contact_for_you":                 "El nuevo propietario no te tiene como contacto",
+		"next_payment_retrieved_successfully":              "Próximo pago obtenido correctamente",
+		"notification_preferences_retrieved_successfully":  "Preferencias de notificación obtenidas correctamente",
+		"notification_preferences_updated_successfully":    "Preferencias de notificación actualizadas correctamente",
+		"notifications_unavailable":                        "Notificaciones no disponibles",
+		"only_the_debt_owner_can_recompute_totals":         "Solo el propietario de la deuda puede recalcular los totales",
+		"only_the_debt_owner_can_transfer_ownership":       "Solo el propietario de la deuda puede transferirla",
+		"only_the_debt_owner_can_upload_documents":         "Solo el propietario de la deuda puede subir documentos",
+		"only_the_payment_submitter_can_nudge_it":          "Solo quien registró el pago puede enviar un recordatorio",
+		"only_the_payment_submitter_can_resubmit_it":       "Solo quien registró el pago puede reenviarlo",
+		"overdue_items_acknowledged_successfully":          "Deudas vencidas marcadas como vistas correctamente",
+		"overdue_items_retrieved_successfully":             "Pagos vencidos obtenidos correctamente",
+		"overdue_totals_retrieved_successfully":            "Totales vencidos obtenidos correctamente",
+		"payment_already_processed":                        "El pago ya fue procesado",
+		"payment_cannot_be_moved":                          "El pago no se puede mover",
+		"payment_cannot_be_reversed":                       "El pago no se puede revertir",
+		"payment_checked_against_expected_installment":     "Pago comparado con la cuota esperada",
+		"payment_deleted_successfully":                     "Pago eliminado correctamente",
+		"payment_has_reversals":                            "El pago tiene reversiones",
+		"payment_is_already_on_this_debt_list":             "El pago ya está en esta lista de deudas",
+		"payment_method_statistics_retrieved_successfully": "Estadísticas de métodos de pago obtenidas correctamente",
+		"payment_moved_successfully":                       "Pago movido correctamente",
+		"payment_not_pending":                              "El pago no está pendiente",
+		"payment_not_rejected":                             "El pago no está rechazado",
+		"payment_recorded_successfully":                    "Pago registrado correctamente",
+		"payment_restored_successfully":                    "Pago restaurado correctamente",
+		"payment_reversed_successfully":                    "Pago revertido correctamente",
+		"payment_schedule_preview_generated_successfully":  "Vista previa del calendario de pagos generada correctamente",
+		"payment_schedule_retrieved_successfully":          "Calendario de pagos obtenido correctamente",
+		"payment_summary_retrieved_successfully":           "Resumen de pagos obtenido correctamente",
+		"payment_verifier_retrieved_successfully":          "Verificador del pago obtenido correctamente",
+		"payment_was_nudged_too_recently":                  "Ya se envió un recordatorio de este pago recientemente",
+		"payments_deleted_successfully":                    "Pagos eliminados correctamente",
+		"payments_imported_successfully":                   "Pagos importados correctamente",
+		"payments_retrieved_successfully":                  "Pagos obtenidos correctamente",
+		"payoff_projection_retrieved_successfully":         "Proyección de liquidación obtenida correctamente",
+		"pending_verifications_retrieved_successfully":     "Verificaciones pendientes obtenidas correctamente",
+		"read_only_token_created_successfully":             "Token de solo lectura creado correctamente",
+		"receipt_file_is_required":                         "El archivo del recibo es obligatorio",
+		"receipt_file_too_large":                           "El archivo del recibo es demasiado grande",
+		"receipt_photo_not_found":                          "Foto del recibo no encontrada",
+		"receipt_uploaded_successfully":                    "Recibo subido correctamente",
+		"request_cancelled":                                "Solicitud cancelada",
+		"request_timeout":                                  "Tiempo de espera de la solicitud agotado",
+		"reversal_exceeds_payment":                         "La reversión supera el monto del pago",
+		"schedule_variance_retrieved_successfully":         "Variación del calendario obtenida correctamente",
+		"settings_retrieved_successfully":                  "Configuración obtenida correctamente",
+		"settings_updated_successfully":                    "Configuración actualizada correctamente",
+		"shared_debt_lists_retrieved_successfully":         "Deudas compartidas contigo obtenidas correctamente",
+		"status_conflicts_with_debt_state":                 "El estado no coincide con la situación de la deuda",
+		"too_many_payments":                                "Demasiados pagos",
+		"total_amount_too_large":                           "Monto total demasiado grande",
+		"unauthorized":                                     "No autorizado",
+		"upcoming_payments_retrieved_successfully":         "Próximos pagos obtenidos correctamente",
+		"user_already_exists":                              "El usuario ya existe",
+		"user_registered_successfully":                     "Usuario registrado correctamente",
+		"verifier_must_be_a_registered_user":               "El verificador debe ser un usuario registrado",
+		"verifier_nudged_successfully":                     "Recordatorio enviado al verificador correctamente",
+	},
+}
+
+// messageCode derives the stable code of an English response message, e.g.
+// "Debt list not found" becomes "debt_list_not_found"
+func messageCode(message string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(message) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			underscore = false
+			continue
+		}
+		if !underscore && b.Len() > 0 {
+			b.WriteByte('_')
+			underscore = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
+
+// localizeMessage returns the code of an English response message together with
+// its translation for the request's locale, falling back to the English text
+func localizeMessage(c *gin.Context, message string) (string, string) {
+	if message == "" {
+		return "", ""
+	}
+	code := messageCode(message)
+	if c == nil {
+		return code, message
+	}
+	if catalog, ok := messageCatalogs[requestLocale(c)]; ok {
+		if translated, ok := catalog[code]; ok {
+			return code, translated
+		}
+	}
+	return code, message
+}
+
+// requestLocale picks the supported locale the client prefers most according to
+// its Accept-Language header, matching on the primary language subtag
+func requestLocale(c *gin.Context) string {
+	header := c.GetHeader("Accept-Language")
+	if header == "" {
+		return defaultLocale
+	}
+
+	type preference struct {
+		locale string
+		weight float64
+	}
+	var preferences []preference
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" {
+			continue
+		}
+		weight := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					weight = q
+				}
+			}
+		}
+		if weight <= 0 {
+			continue
+		}
+		preferences = append(preferences, preference{locale: strings.SplitN(tag, "-", 2)[0], weight: weight})
+	}
+
+	sort.SliceStable(preferences, func(i, j int) bool {
+		return preferences[i].weight > preferences[j].weight
+	})
+	for _, p := range preferences {
+		if p.locale == defaultLocale {
+			return defaultLocale
+		}
+		if _, ok := messageCatalogs[p.locale]; ok {
+			return p.locale
+		}
+	}
+	return defaultLocale
+}
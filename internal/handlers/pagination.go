@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxPageLimit = 100
+
+// defaultPageLimit is the page size used when the client does not pass a limit
+var defaultPageLimit = 50
+
+// SetDefaultPageSize sets the page size list endpoints use when the client does
+// not pass a limit, clamped to between 1 and the maximum page size
+func SetDefaultPageSize(size int) {
+	if size < 1 {
+		size = 1
+	}
+	if size > maxPageLimit {
+		size = maxPageLimit
+	}
+	defaultPageLimit = size
+}
+
+// pagination holds the page window requested by the client
+type pagination struct {
+	Limit  int
+	Offset int
+}
+
+// getPagination reads limit and offset (or cursor) from the query string,
+// falling back to defaults for missing or invalid values
+func getPagination(c *gin.Context) pagination {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultPageLimit)))
+	if err != nil || limit < 1 {
+		limit = defaultPageLimit
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+
+	// The cursor returned in meta.next_cursor takes precedence over offset
+	offsetStr := c.Query("cursor")
+	if offsetStr == "" {
+		offsetStr = c.DefaultQuery("offset", "0")
+	}
+	offset, err := strconv.Atoi(offsetStr)
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	return pagination{Limit: limit, Offset: offset}
+}
+
+// paginate slices items to the requested page and builds its metadata
+func paginate[T any](items []T, p pagination) ([]T, PaginationMeta) {
+	total := len(items)
+	meta := pageMeta(total, p)
+
+	start := p.Offset
+	if start > total {
+		start = total
+	}
+	end := start + p.Limit
+	if end > total {
+		end = total
+	}
+
+	page := items[start:end]
+	if page == nil {
+		page = []T{}
+	}
+	return page, meta
+}
+
+// pageMeta builds the metadata of a page selected by p out of total items, for
+// results paginated before they reach the handler
+func pageMeta(total int, p pagination) PaginationMeta {
+	meta := PaginationMeta{
+		Total:  total,
+		Limit:  p.Limit,
+		Offset: p.Offset,
+	}
+	if p.Offset+p.Limit < total {
+		meta.NextCursor = strconv.Itoa(p.Offset + p.Limit)
+	}
+	return meta
+}
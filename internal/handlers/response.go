@@ -1,6 +1,11 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"html"
+	"net/http"
+	"regexp"
 	"strings"
 	"time"
 
@@ -8,8 +13,20 @@ import (
 	"github.com/google/uuid"
 )
 
+// StatusClientClosedRequest is the non-standard status reported when the client
+// cancels a request before it completes
+const StatusClientClosedRequest = 499
+
+// DefaultRequestTimeout bounds a handler's work when its route group sets no timeout
+const DefaultRequestTimeout = 30 * time.Second
+
+// DefaultUploadTimeout is the longer bound for handlers receiving file uploads
+// when their route group sets no timeout
+const DefaultUploadTimeout = 60 * time.Second
+
 // SuccessResponse represents a successful API response
 type SuccessResponse struct {
+	Code      string      `json:"code,omitempty"`
 	Message   string      `json:"message"`
 	Data      interface{} `json:"data,omitempty"`
 	RequestID string      `json:"request_id"`
@@ -18,15 +35,47 @@ type SuccessResponse struct {
 
 // ErrorResponse represents an error API response
 type ErrorResponse struct {
+	Code      string `json:"code,omitempty"`
 	Error     string `json:"error"`
 	Details   string `json:"details,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	Data      interface{} `json:"data,omitempty"`
 	RequestID string `json:"request_id"`
 	Timestamp time.Time `json:"timestamp"`
 }
 
-// NewSuccessResponse creates a new success response
-func NewSuccessResponse(message string, data interface{}, requestID string) SuccessResponse {
+// PaginationMeta describes the page returned by a list endpoint
+type PaginationMeta struct {
+	Total      int    `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	NextCursor string `json:"next_cursor,omitempty"`
+
+	// AcknowledgedAt is when the user last marked the listed items as seen, for
+	// listings that support it such as overdue debts
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+
+	// SyncedAt is the time to pass as modified_since on a client's next sync,
+	// for listings filtered by it
+	SyncedAt *time.Time `json:"synced_at,omitempty"`
+}
+
+// PaginatedResponse represents a successful API response for a list endpoint
+type PaginatedResponse struct {
+	Code      string         `json:"code,omitempty"`
+	Message   string         `json:"message"`
+	Data      interface{}    `json:"data"`
+	Meta      PaginationMeta `json:"meta"`
+	RequestID string         `json:"request_id"`
+	Timestamp time.Time      `json:"timestamp"`
+}
+
+// NewSuccessResponse creates a new success response with the message localized
+// for the request's Accept-Language
+func NewSuccessResponse(c *gin.Context, message string, data interface{}, requestID string) SuccessResponse {
+	code, message := localizeMessage(c, message)
 	return SuccessResponse{
+		Code:      code,
 		Message:   message,
 		Data:      data,
 		RequestID: requestID,
@@ -34,9 +83,25 @@ func NewSuccessResponse(message string, data interface{}, requestID string) Succ
 	}
 }
 
-// NewErrorResponse creates a new error response
-func NewErrorResponse(error, details, requestID string) ErrorResponse {
+// NewPaginatedResponse creates a new success response carrying pagination metadata
+func NewPaginatedResponse(c *gin.Context, message string, data interface{}, meta PaginationMeta, requestID string) PaginatedResponse {
+	code, message := localizeMessage(c, message)
+	return PaginatedResponse{
+		Code:      code,
+		Message:   message,
+		Data:      data,
+		Meta:      meta,
+		RequestID: requestID,
+		Timestamp: time.Now(),
+	}
+}
+
+// NewErrorResponse creates a new error response with the error localized for
+// the request's Accept-Language
+func NewErrorResponse(c *gin.Context, error, details, requestID string) ErrorResponse {
+	code, error := localizeMessage(c, error)
 	return ErrorResponse{
+		Code:      code,
 		Error:     error,
 		Details:   details,
 		RequestID: requestID,
@@ -44,6 +109,31 @@ func NewErrorResponse(error, details, requestID string) ErrorResponse {
 	}
 }
 
+// requestContext derives the context a handler works under from the request. It
+// is bounded by the timeout set by middleware.Timeout on the route's group, or by
+// fallback when there is none.
+func requestContext(c *gin.Context, fallback time.Duration) (context.Context, context.CancelFunc) {
+	timeout := fallback
+	if configured := c.GetDuration("request_timeout"); configured > 0 {
+		timeout = configured
+	}
+	return context.WithTimeout(c.Request.Context(), timeout)
+}
+
+// handleContextError writes a 499 or 408 response when err was caused by the
+// request context being cancelled or timing out, and reports whether it did so
+func handleContextError(c *gin.Context, err error, requestID string) bool {
+	switch {
+	case errors.Is(err, context.Canceled):
+		c.JSON(StatusClientClosedRequest, NewErrorResponse(c, "Request cancelled", "", requestID))
+		return true
+	case errors.Is(err, context.DeadlineExceeded):
+		c.JSON(http.StatusRequestTimeout, NewErrorResponse(c, "Request timeout", "", requestID))
+		return true
+	}
+	return false
+}
+
 // getRequestID extracts or generates a request ID for tracing
 func getRequestID(c *gin.Context) string {
 	requestID := c.GetHeader("X-Request-ID")
@@ -63,6 +153,40 @@ func sanitizeString(input string) string {
 	return cleaned
 }
 
+var (
+	// richTextEscaper escapes the characters that would let text become HTML markup
+	richTextEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	// linkSchemePattern matches the scheme of a markdown link target, inline or in
+	// a reference definition, including one split by the control characters
+	// browsers drop from URLs
+	linkSchemePattern = regexp.MustCompile(`(?m)(\]\(|^[ \t]{0,3}\[[^\]\n]*\]:)(\s*)([a-zA-Z][a-zA-Z0-9+.\-\x00-\x1f]*):`)
+	// allowedLinkSchemes are the link schemes kept in rich text
+	allowedLinkSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+)
+
+// sanitizeRichText sanitizes free text that clients may render as markdown, such
+// as notes and descriptions. On top of sanitizeString it HTML-escapes the text,
+// so stored text cannot inject markup into a page, and defuses links whose scheme
+// is not allowed. Entities are decoded before escaping, so sanitizing text twice
+// leaves it unchanged.
+func sanitizeRichText(input string) string {
+	cleaned := sanitizeString(html.UnescapeString(sanitizeString(input)))
+	cleaned = linkSchemePattern.ReplaceAllStringFunc(cleaned, func(match string) string {
+		groups := linkSchemePattern.FindStringSubmatch(match)
+		scheme := strings.Map(func(r rune) rune {
+			if r < 0x20 {
+				return -1
+			}
+			return r
+		}, groups[3])
+		if allowedLinkSchemes[strings.ToLower(scheme)] {
+			return match
+		}
+		return groups[1] + groups[2] + "#"
+	})
+	return richTextEscaper.Replace(cleaned)
+}
+
 // sanitizeEmail sanitizes an email address
 func sanitizeEmail(email string) string {
 	return strings.ToLower(sanitizeString(email))
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"pay-your-dues/internal/domain/entities"
+)
+
+// defaultDebtSort orders debt list listings when the client does not pass a sort
+var defaultDebtSort = entities.DebtSort{Field: "created_at", Descending: true}
+
+// SetDefaultDebtSort sets the order debt list listings use when the client does
+// not pass a sort
+func SetDefaultDebtSort(debtSort entities.DebtSort) {
+	defaultDebtSort = debtSort
+}
+
+// invalidDebtSortDetail explains the sorts debt list listings accept
+var invalidDebtSortDetail = fmt.Sprintf("sort must be one of %s, optionally followed by asc or desc", strings.Join(entities.DebtSortFields, ", "))
+
+// getDebtSort reads the sort query parameter, falling back to the default sort
+func getDebtSort(c *gin.Context) (entities.DebtSort, error) {
+	value := c.Query("sort")
+	if value == "" {
+		return defaultDebtSort, nil
+	}
+	return entities.ParseDebtSort(value)
+}
+
+// sortDebtLists orders debt lists in place. Debt lists that compare equal keep
+// their relative order.
+func sortDebtLists(debtLists []entities.DebtListResponse, debtSort entities.DebtSort) {
+	sort.SliceStable(debtLists, func(i, j int) bool {
+		return debtListLess(&debtLists[i], &debtLists[j], debtSort)
+	})
+}
+
+// sortDebtListChanges orders synced debt list changes in place the same way
+// sortDebtLists orders debt lists
+func sortDebtListChanges(changes []entities.DebtListChange, debtSort entities.DebtSort) {
+	sort.SliceStable(changes, func(i, j int) bool {
+		return debtListLess(&changes[i].DebtListResponse, &changes[j].DebtListResponse, debtSort)
+	})
+}
+
+// debtListLess reports whether a sorts before b
+func debtListLess(a, b *entities.DebtListResponse, debtSort entities.DebtSort) bool {
+	if debtSort.Descending {
+		a, b = b, a
+	}
+	switch debtSort.Field {
+	case "updated_at":
+		return a.UpdatedAt.Before(b.UpdatedAt)
+	case "due_date":
+		return a.DueDate.Before(b.DueDate)
+	case "next_payment_date":
+		return a.NextPaymentDate.Before(b.NextPaymentDate)
+	case "total_amount":
+		return a.TotalAmount.LessThan(b.TotalAmount)
+	case "total_remaining_debt":
+		return a.TotalRemainingDebt.LessThan(b.TotalRemainingDebt)
+	default:
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+}
+
+// sortContactsFavoritesFirst moves favorite contacts ahead of the rest in place,
+// keeping the existing order within each group
+func sortContactsFavoritesFirst(contacts []entities.ContactResponse) {
+	sort.SliceStable(contacts, func(i, j int) bool {
+		return contacts[i].IsFavorite && !contacts[j].IsFavorite
+	})
+}
@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/domain/interfaces"
+)
+
+// UserSettingsHandler handles user settings HTTP requests
+type UserSettingsHandler struct {
+	userSettingsService interfaces.UserSettingsService
+	logger              zerolog.Logger
+}
+
+// NewUserSettingsHandler creates a new user settings handler
+func NewUserSettingsHandler(userSettingsService interfaces.UserSettingsService, logger zerolog.Logger) *UserSettingsHandler {
+	return &UserSettingsHandler{
+		userSettingsService: userSettingsService,
+		logger:              logger.With().Str("handler", "user_settings").Logger(),
+	}
+}
+
+// GetUserSettings handles retrieving the authenticated user's settings
+func (h *UserSettingsHandler) GetUserSettings(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "GetUserSettings").Logger()
+
+	settings, err := h.userSettingsService.GetUserSettings(ctx, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve user settings")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Settings retrieved successfully", settings.ToResponse(), requestID))
+}
+
+// UpdateUserSettings handles updating the authenticated user's settings
+func (h *UserSettingsHandler) UpdateUserSettings(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "UpdateUserSettings").Logger()
+
+	var req entities.UpdateUserSettingsRequest
+	if err := bindJSON(c, &req); err != nil {
+		logger.Warn().Err(err).Msg("Invalid request body")
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(c, err, requestID))
+		return
+	}
+
+	// Sanitize input
+	if req.DefaultCurrency != nil {
+		sanitized := sanitizeString(*req.DefaultCurrency)
+		req.DefaultCurrency = &sanitized
+	}
+	if req.DefaultInstallmentPlan != nil {
+		sanitized := sanitizeString(*req.DefaultInstallmentPlan)
+		req.DefaultInstallmentPlan = &sanitized
+	}
+	if req.Timezone != nil {
+		sanitized := sanitizeString(*req.Timezone)
+		req.Timezone = &sanitized
+	}
+
+	settings, err := h.userSettingsService.UpdateUserSettings(ctx, userUUID, &req)
+	if err != nil {
+		logger.Error().Err(err).Msg("User settings update failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		// Handle specific error types
+		switch {
+		case errors.Is(err, entities.ErrInvalidInstallmentPlan), errors.Is(err, entities.ErrInvalidCurrency):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid input", err.Error(), requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	logger.Info().Msg("User settings updated successfully")
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Settings updated successfully", settings.ToResponse(), requestID))
+}
+
+// GetNotificationPreferences handles retrieving the authenticated user's notification preferences
+func (h *UserSettingsHandler) GetNotificationPreferences(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "GetNotificationPreferences").Logger()
+
+	settings, err := h.userSettingsService.GetUserSettings(ctx, userUUID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to retrieve notification preferences")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		return
+	}
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Notification preferences retrieved successfully", settings.ToNotificationPreferences(), requestID))
+}
+
+// UpdateNotificationPreferences handles updating the authenticated user's notification preferences
+func (h *UserSettingsHandler) UpdateNotificationPreferences(c *gin.Context) {
+	ctx, cancel := requestContext(c, DefaultRequestTimeout)
+	defer cancel()
+
+	// Extract request ID and user ID for logging
+	requestID := getRequestID(c)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.logger.Error().Str("request_id", requestID).Msg("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	userUUID, ok := userID.(uuid.UUID)
+	if !ok {
+		h.logger.Error().Str("request_id", requestID).Msg("Invalid user ID type in context")
+		c.JSON(http.StatusUnauthorized, NewErrorResponse(c, "Unauthorized", "", requestID))
+		return
+	}
+
+	logger := h.logger.With().Str("request_id", requestID).Str("user_id", userUUID.String()).Str("method", "UpdateNotificationPreferences").Logger()
+
+	var req entities.UpdateNotificationPreferencesRequest
+	if err := bindJSON(c, &req); err != nil {
+		logger.Warn().Err(err).Msg("Invalid request body")
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(c, err, requestID))
+		return
+	}
+
+	settings, err := h.userSettingsService.UpdateNotificationPreferences(ctx, userUUID, &req)
+	if err != nil {
+		logger.Error().Err(err).Msg("Notification preferences update failed")
+
+		if handleContextError(c, err, requestID) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, entities.ErrInvalidReminderLeadDays):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(c, "Invalid input", err.Error(), requestID))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse(c, "Internal server error", "", requestID))
+		}
+		return
+	}
+
+	logger.Info().Msg("Notification preferences updated successfully")
+
+	c.JSON(http.StatusOK, NewSuccessResponse(c, "Notification preferences updated successfully", settings.ToNotificationPreferences(), requestID))
+}
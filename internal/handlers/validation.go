@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"pay-your-dues/internal/domain/entities"
+)
+
+// FieldError describes a single invalid field in a request body, keyed by its JSON name
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// requestValidator enforces the `validate` tags declared on request entities and
+// reports fields by their JSON names
+var requestValidator = newRequestValidator()
+
+func newRequestValidator() *validator.Validate {
+	v := validator.New()
+
+	// Optional email fields arrive as pointers, which omitempty does not skip when
+	// they point at an empty string. Leave emptiness to the required tag.
+	builtin := validator.New()
+	_ = v.RegisterValidation("email", func(fl validator.FieldLevel) bool {
+		email := fl.Field().String()
+		return email == "" || builtin.Var(email, "email") == nil
+	})
+
+	// Debt types are normalized by the service, which also accepts legacy values
+	_ = v.RegisterValidation("debt_type", func(fl validator.FieldLevel) bool {
+		_, err := entities.NormalizeDebtType(fl.Field().String())
+		return err == nil
+	})
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+	return v
+}
+
+// strictJSONBinding makes bindJSON reject bodies with unknown fields
+var strictJSONBinding = false
+
+// SetStrictJSONBinding sets whether request bodies with fields the endpoint does
+// not know are rejected. By default unknown fields are ignored.
+func SetStrictJSONBinding(enabled bool) {
+	strictJSONBinding = enabled
+}
+
+// bindJSON decodes the request body into obj and validates it
+func bindJSON(c *gin.Context, obj interface{}) error {
+	if strictJSONBinding {
+		if err := decodeStrictJSON(c, obj); err != nil {
+			return err
+		}
+	} else if err := c.ShouldBindJSON(obj); err != nil {
+		return err
+	}
+	return requestValidator.Struct(obj)
+}
+
+// decodeStrictJSON decodes the request body into obj, failing on unknown fields
+func decodeStrictJSON(c *gin.Context, obj interface{}) error {
+	if c.Request.Body == nil {
+		return errors.New("invalid request")
+	}
+	decoder := json.NewDecoder(c.Request.Body)
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(obj)
+}
+
+// unknownFieldPrefix starts the error encoding/json returns for a field the
+// target struct does not have
+const unknownFieldPrefix = "json: unknown field "
+
+// NewValidationErrorResponse creates an error response listing the invalid fields
+// of a request body that failed to bind or validate
+func NewValidationErrorResponse(c *gin.Context, err error, requestID string) ErrorResponse {
+	message := "Invalid request body"
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		message = "Invalid input"
+	}
+	response := NewErrorResponse(c, message, "", requestID)
+	response.Errors = translateBindingError(err)
+	return response
+}
+
+// translateBindingError maps decoding and validator errors to field-level messages
+func translateBindingError(err error) []FieldError {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fieldErrors := make([]FieldError, len(validationErrs))
+		for i, fe := range validationErrs {
+			fieldErrors[i] = FieldError{
+				Field:   fieldPath(fe),
+				Message: validationMessage(fe),
+			}
+		}
+		return fieldErrors
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return []FieldError{{
+			Field:   typeErr.Field,
+			Message: fmt.Sprintf("%s must be of type %s", typeErr.Field, jsonTypeName(typeErr.Type)),
+		}}
+	}
+
+	if field, ok := strings.CutPrefix(err.Error(), unknownFieldPrefix); ok {
+		field = strings.Trim(field, `"`)
+		return []FieldError{{
+			Field:   field,
+			Message: fmt.Sprintf("%s is not a known field", field),
+		}}
+	}
+
+	return []FieldError{{
+		Field:   "body",
+		Message: "request body could not be parsed",
+	}}
+}
+
+// fieldPath returns the JSON path of the field without the top-level struct name
+func fieldPath(fe validator.FieldError) string {
+	namespace := fe.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return fe.Field()
+}
+
+func validationMessage(fe validator.FieldError) string {
+	field := fieldPath(fe)
+	switch fe.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", field)
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", field, strings.Join(strings.Fields(fe.Param()), ", "))
+	case "email":
+		return fmt.Sprintf("%s must be a valid email address", field)
+	case "debt_type":
+		return fmt.Sprintf("%s must be one of: %s, %s", field, entities.DebtTypeToReceive, entities.DebtTypeToPay)
+	case "min":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("%s must be at least %s characters", field, fe.Param())
+		}
+		return fmt.Sprintf("%s must be at least %s", field, fe.Param())
+	case "max":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("%s must be at most %s characters", field, fe.Param())
+		}
+		return fmt.Sprintf("%s must be at most %s", field, fe.Param())
+	default:
+		return fmt.Sprintf("%s is invalid", field)
+	}
+}
+
+// jsonTypeName describes a Go type in JSON terms
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 	"time"
@@ -28,7 +29,8 @@ func NewAuthMiddleware(authService interfaces.AuthService, logger zerolog.Logger
 	}
 }
 
-// Authenticate returns a Gin middleware function for JWT authentication
+// Authenticate returns a Gin middleware function for JWT or API key authentication.
+// A request carrying an X-API-Key header is authenticated by that key alone.
 func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
@@ -43,6 +45,38 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 
 		logger := m.logger.With().Str("request_id", requestID).Logger()
 
+		// Scripts may authenticate with an API key instead of a JWT
+		if key := c.GetHeader("X-API-Key"); key != "" {
+			apiKey, err := m.authService.ValidateAPIKey(ctx, key)
+			if err != nil {
+				logger.Warn().Err(err).Str("path", c.Request.URL.Path).Msg("API key validation failed")
+
+				errorMessage := "API key validation failed"
+				if errors.Is(err, entities.ErrInvalidAPIKey) {
+					errorMessage = "Invalid API key"
+				}
+
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error":      errorMessage,
+					"request_id": requestID,
+					"timestamp":  time.Now(),
+				})
+				c.Abort()
+				return
+			}
+
+			// Set user ID and the key's scopes in context for downstream handlers
+			c.Set("user_id", apiKey.UserID)
+			c.Set("api_key_id", apiKey.ID)
+			c.Set("scopes", apiKey.Scopes)
+			c.Set("request_id", requestID)
+
+			logger.Debug().Str("user_id", apiKey.UserID.String()).Str("api_key_id", apiKey.ID.String()).Str("path", c.Request.URL.Path).Msg("API key authentication successful")
+
+			c.Next()
+			return
+		}
+
 		// Get authorization header
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -82,7 +116,7 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 		}
 
 		// Validate the token
-		userID, err := m.authService.ValidateToken(ctx, token)
+		claims, err := m.authService.ValidateTokenClaims(ctx, token)
 		if err != nil {
 			logger.Warn().Err(err).Str("path", c.Request.URL.Path).Msg("Token validation failed")
 			
@@ -106,11 +140,12 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 			return
 		}
 
-		// Set user ID in context for downstream handlers
-		c.Set("user_id", userID)
+		// Set user ID and token scope in context for downstream handlers
+		c.Set("user_id", claims.UserID)
+		c.Set("scopes", []string{claims.Scope})
 		c.Set("request_id", requestID)
 
-		logger.Debug().Str("user_id", userID.String()).Str("path", c.Request.URL.Path).Msg("Authentication successful")
+		logger.Debug().Str("user_id", claims.UserID.String()).Str("path", c.Request.URL.Path).Msg("Authentication successful")
 
 		// Continue to the next handler
 		c.Next()
@@ -134,6 +169,23 @@ func (m *AuthMiddleware) OptionalAuthenticate() gin.HandlerFunc {
 
 		logger := m.logger.With().Str("request_id", requestID).Logger()
 
+		if key := c.GetHeader("X-API-Key"); key != "" {
+			apiKey, err := m.authService.ValidateAPIKey(ctx, key)
+			if err != nil {
+				logger.Debug().Err(err).Str("path", c.Request.URL.Path).Msg("API key validation failed for optional auth")
+				c.Next()
+				return
+			}
+
+			c.Set("user_id", apiKey.UserID)
+			c.Set("api_key_id", apiKey.ID)
+			c.Set("scopes", apiKey.Scopes)
+			logger.Debug().Str("user_id", apiKey.UserID.String()).Str("path", c.Request.URL.Path).Msg("Optional API key authentication successful")
+
+			c.Next()
+			return
+		}
+
 		// Get authorization header
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -159,7 +211,7 @@ func (m *AuthMiddleware) OptionalAuthenticate() gin.HandlerFunc {
 		}
 
 		// Validate the token
-		userID, err := m.authService.ValidateToken(ctx, token)
+		claims, err := m.authService.ValidateTokenClaims(ctx, token)
 		if err != nil {
 			logger.Debug().Err(err).Str("path", c.Request.URL.Path).Msg("Token validation failed for optional auth")
 			// Don't abort, just continue without setting user_id
@@ -167,11 +219,36 @@ func (m *AuthMiddleware) OptionalAuthenticate() gin.HandlerFunc {
 			return
 		}
 
-		// Set user ID in context for downstream handlers
-		c.Set("user_id", userID)
-		logger.Debug().Str("user_id", userID.String()).Str("path", c.Request.URL.Path).Msg("Optional authentication successful")
+		// Set user ID and token scope in context for downstream handlers
+		c.Set("user_id", claims.UserID)
+		c.Set("scopes", []string{claims.Scope})
+		logger.Debug().Str("user_id", claims.UserID.String()).Str("path", c.Request.URL.Path).Msg("Optional authentication successful")
 
 		// Continue to the next handler
 		c.Next()
 	}
 }
+
+// RequireScope returns a Gin middleware function that rejects requests whose
+// credentials were not granted the given scope. A full scope satisfies any
+// requirement. It must run after Authenticate.
+func (m *AuthMiddleware) RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scopes := c.GetStringSlice("scopes")
+		for _, granted := range scopes {
+			if granted == scope || granted == entities.ScopeFull {
+				c.Next()
+				return
+			}
+		}
+
+		requestID := c.GetString("request_id")
+		m.logger.Warn().Str("request_id", requestID).Str("path", c.Request.URL.Path).Str("required_scope", scope).Strs("scopes", scopes).Msg("Insufficient scope")
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":      "Insufficient scope",
+			"request_id": requestID,
+			"timestamp":  time.Now(),
+		})
+		c.Abort()
+	}
+}
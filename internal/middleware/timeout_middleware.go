@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Timeout returns a Gin middleware function that sets how long handlers of the
+// routes it applies to may work on a request. When route groups are nested, the
+// innermost group's timeout wins.
+func Timeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("request_timeout", timeout)
+		c.Next()
+	}
+}
@@ -35,7 +35,52 @@ func (m *MockAuthService) ValidateToken(ctx context.Context, token string) (uuid
 	return args.Get(0).(uuid.UUID), args.Error(1)
 }
 
+func (m *MockAuthService) ValidateTokenClaims(ctx context.Context, token string) (*entities.TokenClaims, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.TokenClaims), args.Error(1)
+}
+
+func (m *MockAuthService) CreateReadOnlyToken(ctx context.Context, userID uuid.UUID) (*entities.ScopedTokenResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ScopedTokenResponse), args.Error(1)
+}
+
 func (m *MockAuthService) GenerateJWT(ctx context.Context, userID uuid.UUID) (string, error) {
 	args := m.Called(ctx, userID)
 	return args.String(0), args.Error(1)
 }
+
+func (m *MockAuthService) CreateAPIKey(ctx context.Context, userID uuid.UUID, req *entities.CreateAPIKeyRequest) (*entities.CreateAPIKeyResponse, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.CreateAPIKeyResponse), args.Error(1)
+}
+
+func (m *MockAuthService) GetAPIKeys(ctx context.Context, userID uuid.UUID) ([]entities.APIKeyResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.APIKeyResponse), args.Error(1)
+}
+
+func (m *MockAuthService) RevokeAPIKey(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) ValidateAPIKey(ctx context.Context, key string) (*entities.APIKey, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.APIKey), args.Error(1)
+}
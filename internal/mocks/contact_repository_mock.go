@@ -27,6 +27,14 @@ func (m *MockContactRepository) GetByID(ctx context.Context, id uuid.UUID) (*ent
 	return args.Get(0).(*entities.Contact), args.Error(1)
 }
 
+func (m *MockContactRepository) GetContactWithUser(ctx context.Context, id uuid.UUID) (*entities.ContactWithUser, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ContactWithUser), args.Error(1)
+}
+
 func (m *MockContactRepository) GetUserContacts(ctx context.Context, userID uuid.UUID) ([]entities.UserContact, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
@@ -50,6 +58,19 @@ func (m *MockContactRepository) ExistsByEmailForUser(ctx context.Context, userID
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockContactRepository) GetUserContactByEmail(ctx context.Context, userID uuid.UUID, email string) (*entities.UserContact, error) {
+	args := m.Called(ctx, userID, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.UserContact), args.Error(1)
+}
+
+func (m *MockContactRepository) IsBlockedBy(ctx context.Context, blockerID, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, blockerID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockContactRepository) GetUserContactsByEmail(ctx context.Context, email string) ([]entities.UserContact, error) {
 	args := m.Called(ctx, email)
 	if args.Get(0) == nil {
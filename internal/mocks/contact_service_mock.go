@@ -51,6 +51,30 @@ func (m *MockContactService) DeleteContact(ctx context.Context, id uuid.UUID, us
 	return args.Error(0)
 }
 
+func (m *MockContactService) SetBlocked(ctx context.Context, id uuid.UUID, userID uuid.UUID, blocked bool) (*entities.ContactResponse, error) {
+	args := m.Called(ctx, id, userID, blocked)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ContactResponse), args.Error(1)
+}
+
+func (m *MockContactService) ToggleFavorite(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.ContactResponse, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ContactResponse), args.Error(1)
+}
+
+func (m *MockContactService) LookupContact(ctx context.Context, userID uuid.UUID, email string) (*entities.ContactLookup, error) {
+	args := m.Called(ctx, userID, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ContactLookup), args.Error(1)
+}
+
 func (m *MockContactService) CreateContactsForNewUser(ctx context.Context, userID uuid.UUID, userEmail string) error {
 	args := m.Called(ctx, userID, userEmail)
 	return args.Error(0)
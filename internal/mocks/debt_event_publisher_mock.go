@@ -0,0 +1,19 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"pay-your-dues/internal/domain/entities"
+)
+
+// MockDebtEventPublisher is a mock implementation of DebtEventPublisher
+type MockDebtEventPublisher struct {
+	mock.Mock
+}
+
+func (m *MockDebtEventPublisher) Publish(ctx context.Context, event entities.DebtEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
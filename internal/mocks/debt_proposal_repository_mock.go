@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"pay-your-dues/internal/domain/entities"
+)
+
+// MockDebtProposalRepository is a mock implementation of DebtProposalRepository
+type MockDebtProposalRepository struct {
+	mock.Mock
+}
+
+func (m *MockDebtProposalRepository) Create(ctx context.Context, proposal *entities.DebtProposal) error {
+	args := m.Called(ctx, proposal)
+	return args.Error(0)
+}
+
+func (m *MockDebtProposalRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.DebtProposal, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DebtProposal), args.Error(1)
+}
+
+func (m *MockDebtProposalRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]entities.DebtProposal, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.DebtProposal), args.Error(1)
+}
+
+func (m *MockDebtProposalRepository) Respond(ctx context.Context, id uuid.UUID, status string, debtListID *uuid.UUID) error {
+	args := m.Called(ctx, id, status, debtListID)
+	return args.Error(0)
+}
+
+func (m *MockDebtProposalRepository) SetDebtList(ctx context.Context, id uuid.UUID, debtListID uuid.UUID) error {
+	args := m.Called(ctx, id, debtListID)
+	return args.Error(0)
+}
+
+func (m *MockDebtProposalRepository) Reopen(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
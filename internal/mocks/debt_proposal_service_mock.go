@@ -0,0 +1,55 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"pay-your-dues/internal/domain/entities"
+)
+
+// MockDebtProposalService is a mock implementation of DebtProposalService
+type MockDebtProposalService struct {
+	mock.Mock
+}
+
+func (m *MockDebtProposalService) ProposeDebt(ctx context.Context, userID uuid.UUID, req *entities.CreateDebtListRequest) (*entities.DebtProposal, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DebtProposal), args.Error(1)
+}
+
+func (m *MockDebtProposalService) GetUserDebtProposals(ctx context.Context, userID uuid.UUID) ([]entities.DebtProposal, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.DebtProposal), args.Error(1)
+}
+
+func (m *MockDebtProposalService) AcceptDebtProposal(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtList, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DebtList), args.Error(1)
+}
+
+func (m *MockDebtProposalService) RejectDebtProposal(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtProposal, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DebtProposal), args.Error(1)
+}
+
+func (m *MockDebtProposalService) CancelDebtProposal(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtProposal, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DebtProposal), args.Error(1)
+}
@@ -29,6 +29,48 @@ func (m *MockDebtListRepository) GetByID(ctx context.Context, id uuid.UUID) (*en
 	return args.Get(0).(*entities.DebtList), args.Error(1)
 }
 
+func (m *MockDebtListRepository) GetBalances(ctx context.Context, debtListID uuid.UUID) ([]entities.DebtBalance, error) {
+	args := m.Called(ctx, debtListID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.DebtBalance), args.Error(1)
+}
+
+func (m *MockDebtListRepository) CreateHistoryEntries(ctx context.Context, entries []entities.DebtListHistoryEntry) error {
+	args := m.Called(ctx, entries)
+	return args.Error(0)
+}
+
+func (m *MockDebtListRepository) GetHistory(ctx context.Context, debtListID uuid.UUID) ([]entities.DebtListHistoryEntry, error) {
+	args := m.Called(ctx, debtListID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.DebtListHistoryEntry), args.Error(1)
+}
+
+func (m *MockDebtListRepository) GetCurrenciesForUser(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockDebtListRepository) GetActivePastDueIDs(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	args := m.Called(ctx, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockDebtListRepository) CountUnsettledForContact(ctx context.Context, userID, contactID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, userID, contactID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockDebtListRepository) GetByIDWithRelations(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtListResponse, error) {
 	args := m.Called(ctx, id, userID)
 	if args.Get(0) == nil {
@@ -81,9 +123,20 @@ func (m *MockDebtListRepository) GetDueSoonForUser(ctx context.Context, userID u
 	return args.Get(0).([]entities.DebtList), args.Error(1)
 }
 
-func (m *MockDebtListRepository) UpdatePaymentTotals(ctx context.Context, debtListID uuid.UUID, totalPaid, remainingDebt decimal.Decimal) error {
-	args := m.Called(ctx, debtListID, totalPaid, remainingDebt)
-	return args.Error(0)
+func (m *MockDebtListRepository) GetOverdueWhereUserIsContact(ctx context.Context, userID uuid.UUID) ([]entities.DebtList, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.DebtList), args.Error(1)
+}
+
+func (m *MockDebtListRepository) GetDueSoonWhereUserIsContact(ctx context.Context, userID uuid.UUID, dueDate time.Time) ([]entities.DebtList, error) {
+	args := m.Called(ctx, userID, dueDate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.DebtList), args.Error(1)
 }
 
 func (m *MockDebtListRepository) UpdateStatus(ctx context.Context, debtListID uuid.UUID, status string) error {
@@ -91,8 +144,10 @@ func (m *MockDebtListRepository) UpdateStatus(ctx context.Context, debtListID uu
 	return args.Error(0)
 }
 
-func (m *MockDebtListRepository) UpdateNextPaymentDate(ctx context.Context, debtListID uuid.UUID, nextPaymentDate time.Time) error {
-	args := m.Called(ctx, debtListID, nextPaymentDate)
+// RecalculatePaymentTotals passes compute to the expectation, whose Run can call it
+// with the debt list and payments the repository would lock and aggregate
+func (m *MockDebtListRepository) RecalculatePaymentTotals(ctx context.Context, debtListID uuid.UUID, compute func(debtList *entities.DebtList, payments entities.PaymentAggregate) entities.PaymentTotals) error {
+	args := m.Called(ctx, debtListID, compute)
 	return args.Error(0)
 }
 
@@ -104,6 +159,59 @@ func (m *MockDebtListRepository) GetDebtListsWhereUserIsContact(ctx context.Cont
 	return args.Get(0).([]entities.DebtListResponse), args.Error(1)
 }
 
+func (m *MockDebtListRepository) GetUserDebtListsModifiedSince(ctx context.Context, userID uuid.UUID, since time.Time) ([]entities.DebtListResponse, error) {
+	args := m.Called(ctx, userID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.DebtListResponse), args.Error(1)
+}
+
+func (m *MockDebtListRepository) GetDebtListsWhereUserIsContactModifiedSince(ctx context.Context, userID uuid.UUID, since time.Time) ([]entities.DebtListResponse, error) {
+	args := m.Called(ctx, userID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.DebtListResponse), args.Error(1)
+}
+
+func (m *MockDebtListRepository) GetDeletedSince(ctx context.Context, userID uuid.UUID, since time.Time) ([]uuid.UUID, error) {
+	args := m.Called(ctx, userID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockDebtListRepository) GetStatusCounts(ctx context.Context, userID uuid.UUID) (*entities.DebtStatusCounts, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DebtStatusCounts), args.Error(1)
+}
+
+func (m *MockDebtListRepository) GetActivitySummary(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]entities.CurrencyActivity, error) {
+	args := m.Called(ctx, userID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.CurrencyActivity), args.Error(1)
+}
+
+func (m *MockDebtListRepository) GetPaymentMethodStats(ctx context.Context, userID uuid.UUID) ([]entities.PaymentMethodStats, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.PaymentMethodStats), args.Error(1)
+}
+
+func (m *MockDebtListRepository) TransferOwnership(ctx context.Context, debtListID, fromUserID, toUserID, contactID uuid.UUID, debtType string) error {
+	args := m.Called(ctx, debtListID, fromUserID, toUserID, contactID, debtType)
+	return args.Error(0)
+}
+
 // MockDebtItemRepository is a mock implementation of DebtItemRepository
 type MockDebtItemRepository struct {
 	mock.Mock
@@ -114,6 +222,11 @@ func (m *MockDebtItemRepository) Create(ctx context.Context, debtItem *entities.
 	return args.Error(0)
 }
 
+func (m *MockDebtItemRepository) CreateReversal(ctx context.Context, reversal *entities.DebtItem) error {
+	args := m.Called(ctx, reversal)
+	return args.Error(0)
+}
+
 func (m *MockDebtItemRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.DebtItem, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -140,6 +253,47 @@ func (m *MockDebtItemRepository) Delete(ctx context.Context, id uuid.UUID) error
 	return args.Error(0)
 }
 
+func (m *MockDebtItemRepository) DeleteByIDs(ctx context.Context, debtListID uuid.UUID, ids []uuid.UUID) error {
+	args := m.Called(ctx, debtListID, ids)
+	return args.Error(0)
+}
+
+func (m *MockDebtItemRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockDebtItemRepository) PurgeByIDs(ctx context.Context, debtListID uuid.UUID, ids []uuid.UUID) error {
+	args := m.Called(ctx, debtListID, ids)
+	return args.Error(0)
+}
+
+func (m *MockDebtItemRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*entities.DebtItem, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DebtItem), args.Error(1)
+}
+
+func (m *MockDebtItemRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockDebtItemRepository) RestoreReversal(ctx context.Context, reversal *entities.DebtItem) error {
+	args := m.Called(ctx, reversal)
+	return args.Error(0)
+}
+
+func (m *MockDebtItemRepository) GetDeletedSince(ctx context.Context, debtListIDs []uuid.UUID, since time.Time) ([]entities.DebtItem, error) {
+	args := m.Called(ctx, debtListIDs, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.DebtItem), args.Error(1)
+}
+
 func (m *MockDebtItemRepository) BelongsToUserDebtList(ctx context.Context, debtItemID uuid.UUID, userID uuid.UUID) (bool, error) {
 	args := m.Called(ctx, debtItemID, userID)
 	return args.Bool(0), args.Error(1)
@@ -150,6 +304,14 @@ func (m *MockDebtItemRepository) CanUserVerifyDebtItem(ctx context.Context, debt
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockDebtItemRepository) GetVerifier(ctx context.Context, debtItemID uuid.UUID) (*entities.User, error) {
+	args := m.Called(ctx, debtItemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.User), args.Error(1)
+}
+
 func (m *MockDebtItemRepository) GetCompletedPaymentsForDebtList(ctx context.Context, debtListID uuid.UUID) ([]entities.DebtItem, error) {
 	args := m.Called(ctx, debtListID)
 	if args.Get(0) == nil {
@@ -158,17 +320,30 @@ func (m *MockDebtItemRepository) GetCompletedPaymentsForDebtList(ctx context.Con
 	return args.Get(0).([]entities.DebtItem), args.Error(1)
 }
 
+func (m *MockDebtItemRepository) GetCompletedPaymentsForBalance(ctx context.Context, debtListID uuid.UUID, currency string) ([]entities.DebtItem, error) {
+	args := m.Called(ctx, debtListID, currency)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.DebtItem), args.Error(1)
+}
+
 func (m *MockDebtItemRepository) GetTotalPaidForDebtList(ctx context.Context, debtListID uuid.UUID) (decimal.Decimal, error) {
 	args := m.Called(ctx, debtListID)
 	return args.Get(0).(decimal.Decimal), args.Error(1)
 }
 
-func (m *MockDebtItemRepository) GetLastPaymentDate(ctx context.Context, debtListID uuid.UUID) (*time.Time, error) {
-	args := m.Called(ctx, debtListID)
+func (m *MockDebtItemRepository) GetReversedAmount(ctx context.Context, paymentID uuid.UUID) (decimal.Decimal, error) {
+	args := m.Called(ctx, paymentID)
+	return args.Get(0).(decimal.Decimal), args.Error(1)
+}
+
+func (m *MockDebtItemRepository) GetForUser(ctx context.Context, userID uuid.UUID, filter entities.PaymentFilter) ([]entities.UserPayment, int, error) {
+	args := m.Called(ctx, userID, filter)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, args.Int(1), args.Error(2)
 	}
-	return args.Get(0).(*time.Time), args.Error(1)
+	return args.Get(0).([]entities.UserPayment), args.Int(1), args.Error(2)
 }
 
 // Verification methods
@@ -185,11 +360,29 @@ func (m *MockDebtItemRepository) UpdatePaymentStatus(ctx context.Context, debtIt
 	return args.Error(0)
 }
 
+func (m *MockDebtItemRepository) ResubmitPayment(ctx context.Context, debtItemID uuid.UUID, photoURL *string, notes *string) error {
+	args := m.Called(ctx, debtItemID, photoURL, notes)
+	return args.Error(0)
+}
+
+func (m *MockDebtItemRepository) MarkNudged(ctx context.Context, debtItemID uuid.UUID, nudgedAt, since time.Time) error {
+	args := m.Called(ctx, debtItemID, nudgedAt, since)
+	return args.Error(0)
+}
+
 func (m *MockDebtItemRepository) UpdateReceiptPhoto(ctx context.Context, debtItemID uuid.UUID, photoURL *string) error {
 	args := m.Called(ctx, debtItemID, photoURL)
 	return args.Error(0)
 }
 
+func (m *MockDebtItemRepository) GetReceiptsForSettledDebts(ctx context.Context, settledBefore time.Time) ([]entities.DebtItem, error) {
+	args := m.Called(ctx, settledBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.DebtItem), args.Error(1)
+}
+
 // MockPaymentScheduleService is a mock implementation of PaymentScheduleService
 type MockPaymentScheduleService struct {
 	mock.Mock
@@ -210,12 +403,12 @@ func (m *MockPaymentScheduleService) CalculateDueDateFromNumberOfPayments(create
 	return args.Get(0).(time.Time)
 }
 
-func (m *MockPaymentScheduleService) CalculateInstallmentAmountFromNumberOfPayments(totalAmount decimal.Decimal, numberOfPayments int) decimal.Decimal {
-	args := m.Called(totalAmount, numberOfPayments)
+func (m *MockPaymentScheduleService) CalculateInstallmentAmountFromNumberOfPayments(totalAmount decimal.Decimal, numberOfPayments int, roundingMode string) decimal.Decimal {
+	args := m.Called(totalAmount, numberOfPayments, roundingMode)
 	return args.Get(0).(decimal.Decimal)
 }
 
-func (m *MockPaymentScheduleService) CalculateInstallmentAmount(totalAmount decimal.Decimal, installmentPlan string, createdAt time.Time, dueDate time.Time) decimal.Decimal {
-	args := m.Called(totalAmount, installmentPlan, createdAt, dueDate)
+func (m *MockPaymentScheduleService) CalculateInstallmentAmount(totalAmount decimal.Decimal, installmentPlan string, createdAt time.Time, dueDate time.Time, roundingMode string) decimal.Decimal {
+	args := m.Called(totalAmount, installmentPlan, createdAt, dueDate, roundingMode)
 	return args.Get(0).(decimal.Decimal)
 }
@@ -2,8 +2,11 @@ package mocks
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/mock"
 
 	"pay-your-dues/internal/domain/entities"
@@ -22,6 +25,14 @@ func (m *MockDebtService) CreateDebtList(ctx context.Context, userID uuid.UUID,
 	return args.Get(0).(*entities.DebtList), args.Error(1)
 }
 
+func (m *MockDebtService) CreateQuickDebt(ctx context.Context, userID uuid.UUID, req *entities.QuickDebtRequest) (*entities.DebtList, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DebtList), args.Error(1)
+}
+
 func (m *MockDebtService) GetDebtList(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtListResponse, error) {
 	args := m.Called(ctx, id, userID)
 	if args.Get(0) == nil {
@@ -38,6 +49,22 @@ func (m *MockDebtService) GetUserDebtLists(ctx context.Context, userID uuid.UUID
 	return args.Get(0).([]entities.DebtListResponse), args.Error(1)
 }
 
+func (m *MockDebtService) GetSharedDebtLists(ctx context.Context, userID uuid.UUID) ([]entities.DebtListResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.DebtListResponse), args.Error(1)
+}
+
+func (m *MockDebtService) GetDebtListsModifiedSince(ctx context.Context, userID uuid.UUID, since time.Time, includePayments bool) ([]entities.DebtListChange, error) {
+	args := m.Called(ctx, userID, since, includePayments)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.DebtListChange), args.Error(1)
+}
+
 func (m *MockDebtService) UpdateDebtList(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *entities.UpdateDebtListRequest) (*entities.DebtList, error) {
 	args := m.Called(ctx, id, userID, req)
 	if args.Get(0) == nil {
@@ -51,6 +78,67 @@ func (m *MockDebtService) DeleteDebtList(ctx context.Context, id uuid.UUID, user
 	return args.Error(0)
 }
 
+func (m *MockDebtService) TransferOwnership(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *entities.TransferOwnershipRequest) (*entities.DebtList, error) {
+	args := m.Called(ctx, id, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DebtList), args.Error(1)
+}
+
+func (m *MockDebtService) RecomputeOverdueStatuses(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockDebtService) RecomputeDebtList(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtListResponse, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DebtListResponse), args.Error(1)
+}
+
+func (m *MockDebtService) RestoreDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtItem, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DebtItem), args.Error(1)
+}
+
+func (m *MockDebtService) GetDebtPerspective(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtPerspective, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DebtPerspective), args.Error(1)
+}
+
+func (m *MockDebtService) UploadDebtDocument(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID, file io.Reader, filename string, contentType string) (*entities.DebtDocument, error) {
+	args := m.Called(ctx, debtListID, userID, file, filename, contentType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DebtDocument), args.Error(1)
+}
+
+func (m *MockDebtService) ImportPayments(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID, file io.Reader, mapping entities.PaymentImportMapping) (*entities.PaymentImportResult, error) {
+	args := m.Called(ctx, debtListID, userID, file, mapping)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PaymentImportResult), args.Error(1)
+}
+
+func (m *MockDebtService) GetDebtDocument(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID, filename string) ([]byte, string, error) {
+	args := m.Called(ctx, debtListID, userID, filename)
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+	return args.Get(0).([]byte), args.String(1), args.Error(2)
+}
+
 func (m *MockDebtService) CreateDebtItem(ctx context.Context, userID uuid.UUID, req *entities.CreateDebtItemRequest) (*entities.DebtItem, error) {
 	args := m.Called(ctx, userID, req)
 	if args.Get(0) == nil {
@@ -88,20 +176,73 @@ func (m *MockDebtService) DeleteDebtItem(ctx context.Context, id uuid.UUID, user
 	return args.Error(0)
 }
 
-func (m *MockDebtService) GetOverdueItems(ctx context.Context, userID uuid.UUID) ([]entities.DebtList, error) {
+func (m *MockDebtService) DeleteDebtItems(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID, ids []uuid.UUID) error {
+	args := m.Called(ctx, debtListID, userID, ids)
+	return args.Error(0)
+}
+
+func (m *MockDebtService) MoveDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID, targetDebtListID uuid.UUID) (*entities.DebtItem, error) {
+	args := m.Called(ctx, id, userID, targetDebtListID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DebtItem), args.Error(1)
+}
+
+func (m *MockDebtService) GetOverdueItems(ctx context.Context, userID uuid.UUID, direction string) ([]entities.DebtList, error) {
+	args := m.Called(ctx, userID, direction)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.DebtList), args.Error(1)
+}
+
+func (m *MockDebtService) GetOverdueTotals(ctx context.Context, userID uuid.UUID) ([]entities.OverdueTotal, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.OverdueTotal), args.Error(1)
+}
+
+func (m *MockDebtService) AcknowledgeOverdue(ctx context.Context, userID uuid.UUID) (*entities.OverdueAcknowledgment, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.OverdueAcknowledgment), args.Error(1)
+}
+
+func (m *MockDebtService) GetOverdueAcknowledgedAt(ctx context.Context, userID uuid.UUID) (*time.Time, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func (m *MockDebtService) GetDueSoonItems(ctx context.Context, userID uuid.UUID, days int, direction string) ([]entities.DebtList, error) {
+	args := m.Called(ctx, userID, days, direction)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 	return args.Get(0).([]entities.DebtList), args.Error(1)
 }
 
-func (m *MockDebtService) GetDueSoonItems(ctx context.Context, userID uuid.UUID, days int) ([]entities.DebtList, error) {
+func (m *MockDebtService) GetAttention(ctx context.Context, userID uuid.UUID, days int) (*entities.DebtAttention, error) {
 	args := m.Called(ctx, userID, days)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]entities.DebtList), args.Error(1)
+	return args.Get(0).(*entities.DebtAttention), args.Error(1)
+}
+
+func (m *MockDebtService) GetScheduleVariance(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) ([]entities.ScheduleVariance, error) {
+	args := m.Called(ctx, debtListID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.ScheduleVariance), args.Error(1)
 }
 
 func (m *MockDebtService) GetPaymentSchedule(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) ([]entities.PaymentScheduleItem, error) {
@@ -112,6 +253,38 @@ func (m *MockDebtService) GetPaymentSchedule(ctx context.Context, debtListID uui
 	return args.Get(0).([]entities.PaymentScheduleItem), args.Error(1)
 }
 
+func (m *MockDebtService) GetPaymentScheduleInCurrency(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID, displayCurrency string) ([]entities.PaymentScheduleItem, error) {
+	args := m.Called(ctx, debtListID, userID, displayCurrency)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.PaymentScheduleItem), args.Error(1)
+}
+
+func (m *MockDebtService) GetNextPayment(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) (*entities.NextPayment, error) {
+	args := m.Called(ctx, debtListID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.NextPayment), args.Error(1)
+}
+
+func (m *MockDebtService) GetPayoffProjection(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) (*entities.PayoffProjection, error) {
+	args := m.Called(ctx, debtListID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PayoffProjection), args.Error(1)
+}
+
+func (m *MockDebtService) PreviewPaymentSchedule(ctx context.Context, userID uuid.UUID, req *entities.CreateDebtListRequest) ([]entities.PaymentScheduleItem, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.PaymentScheduleItem), args.Error(1)
+}
+
 func (m *MockDebtService) GetUpcomingPayments(ctx context.Context, userID uuid.UUID, days int) ([]entities.UpcomingPayment, error) {
 	args := m.Called(ctx, userID, days)
 	if args.Get(0) == nil {
@@ -128,7 +301,63 @@ func (m *MockDebtService) GetTotalPaymentsForDebtList(ctx context.Context, debtL
 	return args.Get(0).(*entities.PaymentSummary), args.Error(1)
 }
 
+func (m *MockDebtService) GetBalanceHistory(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) ([]entities.BalancePoint, error) {
+	args := m.Called(ctx, debtListID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.BalancePoint), args.Error(1)
+}
+
+func (m *MockDebtService) GetDebtListHistory(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) ([]entities.DebtListHistoryEntry, error) {
+	args := m.Called(ctx, debtListID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.DebtListHistoryEntry), args.Error(1)
+}
+
+func (m *MockDebtService) GetDebtCounts(ctx context.Context, userID uuid.UUID) (*entities.DebtStatusCounts, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DebtStatusCounts), args.Error(1)
+}
+
+func (m *MockDebtService) GetActivitySummary(ctx context.Context, userID uuid.UUID, from, to time.Time) (*entities.ActivitySummary, error) {
+	args := m.Called(ctx, userID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ActivitySummary), args.Error(1)
+}
+
+func (m *MockDebtService) GetPaymentMethodStats(ctx context.Context, userID uuid.UUID) ([]entities.PaymentMethodStats, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.PaymentMethodStats), args.Error(1)
+}
+
+func (m *MockDebtService) GetCurrencies(ctx context.Context, userID uuid.UUID) (*entities.UserCurrencies, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.UserCurrencies), args.Error(1)
+}
+
 // Payment verification methods
+func (m *MockDebtService) ReversePayment(ctx context.Context, id uuid.UUID, userID uuid.UUID, amount decimal.Decimal, reason string) (*entities.DebtItem, error) {
+	args := m.Called(ctx, id, userID, amount, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DebtItem), args.Error(1)
+}
+
 func (m *MockDebtService) VerifyDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *entities.VerifyDebtItemRequest) (*entities.DebtItem, error) {
 	args := m.Called(ctx, id, userID, req)
 	if args.Get(0) == nil {
@@ -137,6 +366,14 @@ func (m *MockDebtService) VerifyDebtItem(ctx context.Context, id uuid.UUID, user
 	return args.Get(0).(*entities.DebtItem), args.Error(1)
 }
 
+func (m *MockDebtService) AutoMatchPayment(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.PaymentMatch, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PaymentMatch), args.Error(1)
+}
+
 func (m *MockDebtService) GetPendingVerifications(ctx context.Context, userID uuid.UUID) ([]entities.DebtItem, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
@@ -145,6 +382,54 @@ func (m *MockDebtService) GetPendingVerifications(ctx context.Context, userID uu
 	return args.Get(0).([]entities.DebtItem), args.Error(1)
 }
 
+func (m *MockDebtService) GetPaymentVerifier(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.PaymentVerifier, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PaymentVerifier), args.Error(1)
+}
+
+func (m *MockDebtService) GetPendingVerificationQueue(ctx context.Context, userID uuid.UUID, oldestFirst bool) ([]entities.PendingVerification, error) {
+	args := m.Called(ctx, userID, oldestFirst)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.PendingVerification), args.Error(1)
+}
+
+func (m *MockDebtService) GetUserPayments(ctx context.Context, userID uuid.UUID, filter entities.PaymentFilter) ([]entities.UserPayment, int, error) {
+	args := m.Called(ctx, userID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]entities.UserPayment), args.Int(1), args.Error(2)
+}
+
+func (m *MockDebtService) GetContactSummary(ctx context.Context, contactID uuid.UUID, userID uuid.UUID) (*entities.ContactSummary, error) {
+	args := m.Called(ctx, contactID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ContactSummary), args.Error(1)
+}
+
+func (m *MockDebtService) GetContactReconciliation(ctx context.Context, contactID uuid.UUID, userID uuid.UUID) (*entities.ContactReconciliation, error) {
+	args := m.Called(ctx, contactID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ContactReconciliation), args.Error(1)
+}
+
+func (m *MockDebtService) GetDebtsByContact(ctx context.Context, userID uuid.UUID) ([]entities.ContactDebts, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.ContactDebts), args.Error(1)
+}
+
 func (m *MockDebtService) RejectDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID, notes *string) (*entities.DebtItem, error) {
 	args := m.Called(ctx, id, userID, notes)
 	if args.Get(0) == nil {
@@ -152,3 +437,27 @@ func (m *MockDebtService) RejectDebtItem(ctx context.Context, id uuid.UUID, user
 	}
 	return args.Get(0).(*entities.DebtItem), args.Error(1)
 }
+
+func (m *MockDebtService) DisputeDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *entities.DisputeDebtItemRequest) (*entities.DebtItem, error) {
+	args := m.Called(ctx, id, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DebtItem), args.Error(1)
+}
+
+func (m *MockDebtService) ResubmitDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *entities.ResubmitDebtItemRequest) (*entities.DebtItem, error) {
+	args := m.Called(ctx, id, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DebtItem), args.Error(1)
+}
+
+func (m *MockDebtService) NudgeDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtItem, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DebtItem), args.Error(1)
+}
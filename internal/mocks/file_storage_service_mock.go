@@ -32,3 +32,21 @@ func (m *MockFileStorageService) GetReceiptFile(ctx context.Context, fileURL str
 	args := m.Called(ctx, fileURL)
 	return args.Get(0).([]byte), args.String(1), args.Error(2)
 }
+
+func (m *MockFileStorageService) GetReceiptFilename(ctx context.Context, fileURL string) (string, error) {
+	args := m.Called(ctx, fileURL)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockFileStorageService) UploadDocument(ctx context.Context, file io.Reader, filename string, contentType string, debtID uuid.UUID) (string, error) {
+	args := m.Called(ctx, file, filename, contentType, debtID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockFileStorageService) GetDocumentFile(ctx context.Context, fileURL string) ([]byte, string, error) {
+	args := m.Called(ctx, fileURL)
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+	return args.Get(0).([]byte), args.String(1), args.Error(2)
+}
@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"pay-your-dues/internal/domain/entities"
+)
+
+// MockUserSettingsRepository is a mock implementation of UserSettingsRepository
+type MockUserSettingsRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserSettingsRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*entities.UserSettings, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.UserSettings), args.Error(1)
+}
+
+func (m *MockUserSettingsRepository) Upsert(ctx context.Context, settings *entities.UserSettings) error {
+	args := m.Called(ctx, settings)
+	return args.Error(0)
+}
@@ -22,15 +22,17 @@ type Contact struct {
 // UserContact represents the many-to-many relationship between users and contacts
 // with user-specific contact information
 type UserContact struct {
-	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
-	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;not null;index"`
-	ContactID uuid.UUID `json:"contact_id" gorm:"type:uuid;not null;index"`
-	Name      string    `json:"name" gorm:"not null;index"`
-	Email     *string   `json:"email" gorm:"index"`
-	Phone     *string   `json:"phone"`
-	Notes     *string   `json:"notes"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	UserID     uuid.UUID `json:"user_id" gorm:"type:uuid;not null;index"`
+	ContactID  uuid.UUID `json:"contact_id" gorm:"type:uuid;not null;index"`
+	Name       string    `json:"name" gorm:"not null;index"`
+	Email      *string   `json:"email" gorm:"index"`
+	Phone      *string   `json:"phone"`
+	Notes      *string   `json:"notes"`
+	IsFavorite bool      `json:"is_favorite" gorm:"not null;default:false"`
+	IsBlocked  bool      `json:"is_blocked" gorm:"not null;default:false"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
 	
 	// Relationships
 	User    User    `json:"user,omitempty" gorm:"foreignKey:UserID"`
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// DebtBalance is the amount a debt list owes in one additional currency
+type DebtBalance struct {
+	ID          uuid.UUID       `json:"id" gorm:"type:uuid;primary_key"`
+	DebtListID  uuid.UUID       `json:"debt_list_id" gorm:"type:uuid;not null;uniqueIndex:idx_debt_balances_debt_list_currency"`
+	Currency    string          `json:"currency" gorm:"not null;uniqueIndex:idx_debt_balances_debt_list_currency"`
+	TotalAmount decimal.Decimal `json:"total_amount" gorm:"type:decimal(15,2);not null"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
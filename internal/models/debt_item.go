@@ -5,23 +5,32 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
 )
 
 type DebtItem struct {
 	ID                uuid.UUID     `json:"id" gorm:"type:uuid;primary_key"`
 	DebtListID        uuid.UUID     `json:"debt_list_id" gorm:"type:uuid;not null;index"`
 	Amount            decimal.Decimal `json:"amount" gorm:"type:decimal(15,2);not null"`
-	Currency          string        `json:"currency" gorm:"default:'Php'"`
+	Currency          string        `json:"currency" gorm:"default:'PHP'"`
 	PaymentDate       time.Time     `json:"payment_date" gorm:"not null"`
 	PaymentMethod     string        `json:"payment_method" gorm:"default:'cash';check:payment_method IN ('cash', 'bank_transfer', 'check', 'digital_wallet', 'other')"`
+	PaymentType       string        `json:"payment_type" gorm:"default:'payment';check:payment_type IN ('payment', 'adjustment')"`
 	Description       *string       `json:"description"`
-	Status            string        `json:"status" gorm:"default:'pending';index;check:status IN ('completed', 'pending', 'failed', 'refunded', 'rejected')"`
+	Reference         *string       `json:"reference" gorm:"size:100"`
+	Status            string        `json:"status" gorm:"default:'pending';index;check:status IN ('completed', 'pending', 'failed', 'refunded', 'rejected', 'disputed')"`
 	ReceiptPhotoURL   *string       `json:"receipt_photo_url"`
 	VerifiedBy        *uuid.UUID    `json:"verified_by" gorm:"type:uuid"`
 	VerifiedAt        *time.Time    `json:"verified_at"`
 	VerificationNotes *string       `json:"verification_notes"`
+	ResubmissionCount int           `json:"resubmission_count" gorm:"default:0"`
+	ResubmittedAt     *time.Time    `json:"resubmitted_at"`
+	LastNudgedAt      *time.Time    `json:"last_nudged_at"`
+	CreatedBy         uuid.UUID     `json:"created_by" gorm:"type:uuid;index"`
+	ReversalOf        *uuid.UUID    `json:"reversal_of" gorm:"type:uuid;index"`
 	CreatedAt         time.Time     `json:"created_at"`
 	UpdatedAt         time.Time     `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `json:"-" gorm:"index"`
 	
 	// Relationships
 	DebtList DebtList `json:"debt_list,omitempty" gorm:"foreignKey:DebtListID"`
@@ -34,6 +43,7 @@ type CreateDebtItemRequest struct {
 	PaymentDate       time.Time `json:"payment_date" binding:"required"`
 	PaymentMethod     string    `json:"payment_method" binding:"required,oneof=cash bank_transfer check digital_wallet other"`
 	Description       *string   `json:"description"`
+	Reference         *string   `json:"reference"`
 	ReceiptPhotoURL   *string   `json:"receipt_photo_url"`
 	VerificationNotes *string   `json:"verification_notes"`
 }
@@ -10,12 +10,10 @@ import (
 
 // Struct-level validation for CreateDebtListRequest
 func (req CreateDebtListRequest) Validate() error {
-	hasDueDate := req.DueDate != nil
 	hasNumberOfPayments := req.NumberOfPayments != nil && *req.NumberOfPayments > 0
 	
-	if !hasDueDate && !hasNumberOfPayments {
-		return fmt.Errorf("either due_date or number_of_payments must be provided")
-	}
+	// Without a due date or number of payments the debt falls due after the
+	// configured default offset, so neither is required
 	
 	// If number_of_payments is provided, installment_plan is required
 	if hasNumberOfPayments && req.InstallmentPlan == "" {
@@ -34,12 +32,14 @@ type DebtList struct {
 	InstallmentAmount decimal.Decimal `json:"installment_amount" gorm:"type:decimal(15,2);not null"`
 	TotalPaymentsMade decimal.Decimal `json:"total_payments_made" gorm:"type:decimal(15,2);default:0"`
 	TotalRemainingDebt decimal.Decimal `json:"total_remaining_debt" gorm:"type:decimal(15,2);not null"`
-	Currency        string        `json:"currency" gorm:"default:'Php'"`
+	Currency        string        `json:"currency" gorm:"default:'PHP'"`
 	Status          string        `json:"status" gorm:"default:'active';index;check:status IN ('active', 'settled', 'archived', 'overdue')"`
 	DueDate         time.Time     `json:"due_date" gorm:"not null"`
 	NextPaymentDate time.Time     `json:"next_payment_date" gorm:"not null"`
-	InstallmentPlan string        `json:"installment_plan" gorm:"default:'monthly';check:installment_plan IN ('onetime', 'weekly', 'biweekly', 'monthly', 'quarterly', 'yearly')"`
+	InstallmentPlan string        `json:"installment_plan" gorm:"default:'monthly';check:installment_plan IN ('onetime', 'daily', 'weekly', 'biweekly', 'monthly', 'quarterly', 'yearly')"`
 	NumberOfPayments *int         `json:"number_of_payments" gorm:"default:null"`
+	PaymentWeekday  *int          `json:"payment_weekday" gorm:"default:null"`
+	RoundingMode    string        `json:"rounding_mode" gorm:"default:'last';check:rounding_mode IN ('first', 'last', 'spread')"`
 	Description     *string       `json:"description"`
 	Notes           *string       `json:"notes"`
 	CreatedAt       time.Time     `json:"created_at"`
@@ -49,6 +49,7 @@ type DebtList struct {
 	User      User        `json:"user,omitempty" gorm:"foreignKey:UserID"`
 	Contact   Contact     `json:"contact,omitempty" gorm:"foreignKey:ContactID"`
 	Payments  []DebtItem  `json:"payments,omitempty" gorm:"foreignKey:DebtListID"`
+	Balances  []DebtBalance `json:"balances,omitempty" gorm:"foreignKey:DebtListID;constraint:OnDelete:CASCADE"`
 }
 
 type CreateDebtListRequest struct {
@@ -57,7 +58,7 @@ type CreateDebtListRequest struct {
 	TotalAmount       string    `json:"total_amount" binding:"required"`
 	Currency          string    `json:"currency"`
 	DueDate           *time.Time `json:"due_date"`
-	InstallmentPlan   string    `json:"installment_plan" binding:"omitempty,oneof=onetime weekly biweekly monthly quarterly yearly"`
+	InstallmentPlan   string    `json:"installment_plan" binding:"omitempty,oneof=onetime daily weekly biweekly monthly quarterly yearly"`
 	NumberOfPayments  *int      `json:"number_of_payments"`
 	Description       *string   `json:"description"`
 	Notes             *string   `json:"notes"`
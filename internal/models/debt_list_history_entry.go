@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DebtListHistoryEntry records one field of a debt list changed by an update
+type DebtListHistoryEntry struct {
+	ID         uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	DebtListID uuid.UUID `json:"debt_list_id" gorm:"type:uuid;not null;index"`
+	ChangedBy  uuid.UUID `json:"changed_by" gorm:"type:uuid;not null"`
+	Field      string    `json:"field" gorm:"not null"`
+	OldValue   *string   `json:"old_value"`
+	NewValue   *string   `json:"new_value"`
+	ChangedAt  time.Time `json:"changed_at" gorm:"not null"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type DebtProposal struct {
+	ID               uuid.UUID       `json:"id" gorm:"type:uuid;primary_key"`
+	ProposerID       uuid.UUID       `json:"proposer_id" gorm:"type:uuid;not null;index"`
+	RecipientID      uuid.UUID       `json:"recipient_id" gorm:"type:uuid;not null;index"`
+	ContactID        uuid.UUID       `json:"contact_id" gorm:"type:uuid;not null"`
+	DebtType         string          `json:"debt_type" gorm:"not null;check:debt_type IN ('to_receive', 'to_pay')"`
+	TotalAmount      decimal.Decimal `json:"total_amount" gorm:"type:decimal(15,2);not null"`
+	Currency         string          `json:"currency"`
+	DueDate          *time.Time      `json:"due_date"`
+	InstallmentPlan  string          `json:"installment_plan"`
+	NumberOfPayments *int            `json:"number_of_payments" gorm:"default:null"`
+	Description      *string         `json:"description"`
+	Notes            *string         `json:"notes"`
+	Status           string          `json:"status" gorm:"default:'pending';index;check:status IN ('pending', 'accepted', 'rejected', 'cancelled')"`
+	DebtListID       *uuid.UUID      `json:"debt_list_id" gorm:"type:uuid"`
+	RespondedAt      *time.Time      `json:"responded_at"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Deletion is a tombstone left by a debt list or payment deleted for good, so
+// clients syncing incrementally learn to drop their copy. Debt list tombstones
+// record both parties, as the row that said who they were is gone.
+type Deletion struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	EntityType    string     `json:"entity_type" gorm:"not null;check:entity_type IN ('debt_list', 'payment')"`
+	EntityID      uuid.UUID  `json:"entity_id" gorm:"type:uuid;not null"`
+	DebtListID    uuid.UUID  `json:"debt_list_id" gorm:"type:uuid;not null;index"`
+	UserID        *uuid.UUID `json:"user_id" gorm:"type:uuid;index"`
+	ContactUserID *uuid.UUID `json:"contact_user_id" gorm:"type:uuid;index"`
+	DeletedAt     time.Time  `json:"deleted_at" gorm:"not null;index"`
+}
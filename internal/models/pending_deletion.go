@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PendingDeletion struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	FileURL        string     `json:"file_url" gorm:"not null"`
+	Attempts       int        `json:"attempts" gorm:"not null;default:0"`
+	LastError      string     `json:"last_error"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at" gorm:"not null;index"`
+	DeadLetteredAt *time.Time `json:"dead_lettered_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
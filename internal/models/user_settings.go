@@ -12,8 +12,16 @@ type UserSettings struct {
 	NotificationEmail   bool      `json:"notification_email" gorm:"default:true"`
 	NotificationSMS     bool      `json:"notification_sms" gorm:"default:false"`
 	NotificationFacebook bool     `json:"notification_facebook" gorm:"default:false"`
-	DefaultCurrency     string    `json:"default_currency" gorm:"default:'Php'"`
+	DefaultCurrency     string    `json:"default_currency" gorm:"default:'PHP'"`
+	DefaultInstallmentPlan string `json:"default_installment_plan" gorm:"default:'onetime';check:default_installment_plan IN ('onetime', 'daily', 'weekly', 'biweekly', 'monthly', 'quarterly', 'yearly')"`
 	Timezone           string    `json:"timezone" gorm:"default:'UTC'"`
+	// Notification preferences are pointers so that false and 0 are saved rather
+	// than replaced by the column defaults
+	EmailReminders     *bool     `json:"email_reminders" gorm:"not null;default:true"`
+	ReminderLeadDays   *int      `json:"reminder_lead_days" gorm:"not null;default:3"`
+	OverdueAlerts      *bool     `json:"overdue_alerts" gorm:"not null;default:true"`
+	VerificationAlerts *bool     `json:"verification_alerts" gorm:"not null;default:true"`
+	OverdueAcknowledgedAt *time.Time `json:"overdue_acknowledged_at"`
 	CreatedAt          time.Time `json:"created_at"`
 	UpdatedAt          time.Time `json:"updated_at"`
 	
@@ -26,5 +34,6 @@ type UpdateUserSettingsRequest struct {
 	NotificationSMS     *bool   `json:"notification_sms"`
 	NotificationFacebook *bool  `json:"notification_facebook"`
 	DefaultCurrency     *string `json:"default_currency"`
+	DefaultInstallmentPlan *string `json:"default_installment_plan"`
 	Timezone           *string `json:"timezone"`
 } 
\ No newline at end of file
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/domain/interfaces"
+	"pay-your-dues/internal/models"
+)
+
+// apiKeyRepositoryGORM implements the APIKeyRepository interface using GORM
+type apiKeyRepositoryGORM struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepositoryGORM creates a new API key repository with GORM
+func NewAPIKeyRepositoryGORM(db *gorm.DB) interfaces.APIKeyRepository {
+	return &apiKeyRepositoryGORM{
+		db: db,
+	}
+}
+
+func (r *apiKeyRepositoryGORM) Create(ctx context.Context, apiKey *entities.APIKey) error {
+	gormAPIKey := r.entityToGORM(apiKey)
+	if err := r.db.WithContext(ctx).Create(gormAPIKey).Error; err != nil {
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+	apiKey.CreatedAt = gormAPIKey.CreatedAt
+	apiKey.UpdatedAt = gormAPIKey.UpdatedAt
+	return nil
+}
+
+func (r *apiKeyRepositoryGORM) GetByHash(ctx context.Context, keyHash string) (*entities.APIKey, error) {
+	var gormAPIKey models.APIKey
+	if err := r.db.WithContext(ctx).Where("key_hash = ?", keyHash).First(&gormAPIKey).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	return r.gormToEntity(&gormAPIKey), nil
+}
+
+// GetByUserID returns all of the user's keys, including revoked ones, newest first
+func (r *apiKeyRepositoryGORM) GetByUserID(ctx context.Context, userID uuid.UUID) ([]entities.APIKey, error) {
+	var gormAPIKeys []models.APIKey
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&gormAPIKeys).Error; err != nil {
+		return nil, fmt.Errorf("failed to get API keys: %w", err)
+	}
+
+	apiKeys := make([]entities.APIKey, len(gormAPIKeys))
+	for i, gormAPIKey := range gormAPIKeys {
+		apiKeys[i] = *r.gormToEntity(&gormAPIKey)
+	}
+	return apiKeys, nil
+}
+
+func (r *apiKeyRepositoryGORM) Revoke(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	now := time.Now()
+	result := r.db.WithContext(ctx).Model(&models.APIKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Updates(map[string]interface{}{
+			"revoked_at": now,
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to revoke API key: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+func (r *apiKeyRepositoryGORM) UpdateLastUsed(ctx context.Context, id uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Model(&models.APIKey{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_at", time.Now()).Error; err != nil {
+		return fmt.Errorf("failed to update API key last use: %w", err)
+	}
+	return nil
+}
+
+// entityToGORM converts a domain entity to GORM model
+func (r *apiKeyRepositoryGORM) entityToGORM(apiKey *entities.APIKey) *models.APIKey {
+	return &models.APIKey{
+		ID:         apiKey.ID,
+		UserID:     apiKey.UserID,
+		Name:       apiKey.Name,
+		KeyPrefix:  apiKey.KeyPrefix,
+		KeyHash:    apiKey.KeyHash,
+		Scopes:     strings.Join(apiKey.Scopes, ","),
+		LastUsedAt: apiKey.LastUsedAt,
+		RevokedAt:  apiKey.RevokedAt,
+		CreatedAt:  apiKey.CreatedAt,
+		UpdatedAt:  apiKey.UpdatedAt,
+	}
+}
+
+// gormToEntity converts a GORM model to domain entity
+func (r *apiKeyRepositoryGORM) gormToEntity(gormAPIKey *models.APIKey) *entities.APIKey {
+	var scopes []string
+	if gormAPIKey.Scopes != "" {
+		scopes = strings.Split(gormAPIKey.Scopes, ",")
+	}
+	return &entities.APIKey{
+		ID:         gormAPIKey.ID,
+		UserID:     gormAPIKey.UserID,
+		Name:       gormAPIKey.Name,
+		KeyPrefix:  gormAPIKey.KeyPrefix,
+		KeyHash:    gormAPIKey.KeyHash,
+		Scopes:     scopes,
+		LastUsedAt: gormAPIKey.LastUsedAt,
+		RevokedAt:  gormAPIKey.RevokedAt,
+		CreatedAt:  gormAPIKey.CreatedAt,
+		UpdatedAt:  gormAPIKey.UpdatedAt,
+	}
+}
@@ -14,14 +14,31 @@ import (
 
 // contactRepositoryGORM implements the ContactRepository interface using GORM
 type contactRepositoryGORM struct {
-	db *gorm.DB
+	db                   *gorm.DB
+	caseInsensitiveEmail bool
+}
+
+// ContactRepositoryOption configures optional behaviour of the contact repository
+type ContactRepositoryOption func(*contactRepositoryGORM)
+
+// WithContactCaseInsensitiveEmails sets whether contact emails are stored
+// lower-cased and looked up ignoring case. It is enabled by default.
+func WithContactCaseInsensitiveEmails(enabled bool) ContactRepositoryOption {
+	return func(r *contactRepositoryGORM) {
+		r.caseInsensitiveEmail = enabled
+	}
 }
 
 // NewContactRepositoryGORM creates a new contact repository with GORM
-func NewContactRepositoryGORM(db *gorm.DB) interfaces.ContactRepository {
-	return &contactRepositoryGORM{
-		db: db,
+func NewContactRepositoryGORM(db *gorm.DB, opts ...ContactRepositoryOption) interfaces.ContactRepository {
+	r := &contactRepositoryGORM{
+		db:                   db,
+		caseInsensitiveEmail: true,
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 func (r *contactRepositoryGORM) Create(ctx context.Context, contact *entities.Contact) error {
@@ -38,7 +55,9 @@ func (r *contactRepositoryGORM) Create(ctx context.Context, contact *entities.Co
 
 func (r *contactRepositoryGORM) GetByID(ctx context.Context, id uuid.UUID) (*entities.Contact, error) {
 	var gormContact models.Contact
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&gormContact).Error; err != nil {
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).Where("id = ?", id).First(&gormContact).Error
+	}); err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, entities.ErrContactNotFound
 		}
@@ -47,10 +66,38 @@ func (r *contactRepositoryGORM) GetByID(ctx context.Context, id uuid.UUID) (*ent
 	return r.gormToEntity(&gormContact), nil
 }
 
+// GetContactWithUser gets a contact along with the registered user it links to.
+// The user is only attached for contacts marked as app users.
+func (r *contactRepositoryGORM) GetContactWithUser(ctx context.Context, id uuid.UUID) (*entities.ContactWithUser, error) {
+	var gormContact models.Contact
+	if err := r.db.WithContext(ctx).Preload("UserRef").Where("id = ?", id).First(&gormContact).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrContactNotFound
+		}
+		return nil, fmt.Errorf("failed to get contact with user: %w", err)
+	}
+
+	contactWithUser := &entities.ContactWithUser{Contact: *r.gormToEntity(&gormContact)}
+	if gormContact.IsUser && gormContact.UserRef != nil {
+		contactWithUser.User = &entities.User{
+			ID:        gormContact.UserRef.ID,
+			Email:     gormContact.UserRef.Email,
+			FirstName: gormContact.UserRef.FirstName,
+			LastName:  gormContact.UserRef.LastName,
+			Phone:     gormContact.UserRef.Phone,
+			CreatedAt: gormContact.UserRef.CreatedAt,
+			UpdatedAt: gormContact.UserRef.UpdatedAt,
+		}
+	}
+	return contactWithUser, nil
+}
+
 
 func (r *contactRepositoryGORM) GetUserContacts(ctx context.Context, userID uuid.UUID) ([]entities.UserContact, error) {
 	var userContacts []models.UserContact
-	if err := r.db.WithContext(ctx).Joins("Contact").Where("user_contacts.user_id = ?", userID).Order("user_contacts.name ASC").Find(&userContacts).Error; err != nil {
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).Joins("Contact").Where("user_contacts.user_id = ?", userID).Order("user_contacts.name ASC").Find(&userContacts).Error
+	}); err != nil {
 		return nil, fmt.Errorf("failed to get user contacts: %w", err)
 	}
 
@@ -90,7 +137,9 @@ func (r *contactRepositoryGORM) Delete(ctx context.Context, id uuid.UUID) error
 
 func (r *contactRepositoryGORM) GetUserContactRelation(ctx context.Context, userID, contactID uuid.UUID) (*entities.UserContact, error) {
 	var gormUserContact models.UserContact
-	if err := r.db.WithContext(ctx).Where("user_id = ? AND contact_id = ?", userID, contactID).First(&gormUserContact).Error; err != nil {
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).Where("user_id = ? AND contact_id = ?", userID, contactID).First(&gormUserContact).Error
+	}); err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, entities.ErrContactNotFound
 		}
@@ -104,8 +153,9 @@ func (r *contactRepositoryGORM) CreateUserContactRelation(ctx context.Context, u
 	if err := r.db.WithContext(ctx).Create(gormUserContact).Error; err != nil {
 		return fmt.Errorf("failed to create user contact relation: %w", err)
 	}
-	// Update the entity with the created ID and timestamps
+	// Update the entity with the created ID, stored email and timestamps
 	userContact.ID = gormUserContact.ID
+	userContact.Email = gormUserContact.Email
 	userContact.CreatedAt = gormUserContact.CreatedAt
 	userContact.UpdatedAt = gormUserContact.UpdatedAt
 	return nil
@@ -124,16 +174,42 @@ func (r *contactRepositoryGORM) DeleteUserContactRelation(ctx context.Context, u
 
 func (r *contactRepositoryGORM) ExistsByEmailForUser(ctx context.Context, userID uuid.UUID, email string) (bool, error) {
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&models.UserContact{}).Where("user_id = ? AND email = ?", userID, email).Count(&count).Error; err != nil {
+	if err := r.db.WithContext(ctx).Model(&models.UserContact{}).Where("user_id = ? AND email = ?", userID, normalizeEmail(email, r.caseInsensitiveEmail)).Count(&count).Error; err != nil {
 		return false, fmt.Errorf("failed to check if contact exists by email for user: %w", err)
 	}
 	return count > 0, nil
 }
 
+// GetUserContactByEmail gets the user's contact relation with the given email
+func (r *contactRepositoryGORM) GetUserContactByEmail(ctx context.Context, userID uuid.UUID, email string) (*entities.UserContact, error) {
+	var gormUserContact models.UserContact
+	if err := r.db.WithContext(ctx).Where("user_id = ? AND email = ?", userID, normalizeEmail(email, r.caseInsensitiveEmail)).First(&gormUserContact).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrContactNotFound
+		}
+		return nil, fmt.Errorf("failed to get user contact by email: %w", err)
+	}
+	return r.userContactGormToEntity(&gormUserContact), nil
+}
+
+// IsBlockedBy checks the blocker's contacts referencing the user, so the block
+// holds whichever email either of them saved for the other
+func (r *contactRepositoryGORM) IsBlockedBy(ctx context.Context, blockerID, userID uuid.UUID) (bool, error) {
+	var count int64
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).Model(&models.UserContact{}).
+			Joins("JOIN contacts ON contacts.id = user_contacts.contact_id").
+			Where("user_contacts.user_id = ? AND contacts.user_id_ref = ? AND user_contacts.is_blocked = ?", blockerID, userID, true).
+			Count(&count).Error
+	}); err != nil {
+		return false, fmt.Errorf("failed to check if user is blocked: %w", err)
+	}
+	return count > 0, nil
+}
 
 func (r *contactRepositoryGORM) GetUserContactsByEmail(ctx context.Context, email string) ([]entities.UserContact, error) {
 	var gormUserContacts []models.UserContact
-	if err := r.db.WithContext(ctx).Joins("Contact").Where("user_contacts.email = ?", email).Find(&gormUserContacts).Error; err != nil {
+	if err := r.db.WithContext(ctx).Joins("Contact").Where("user_contacts.email = ?", normalizeEmail(email, r.caseInsensitiveEmail)).Find(&gormUserContacts).Error; err != nil {
 		return nil, fmt.Errorf("failed to get user contacts by email: %w", err)
 	}
 
@@ -164,7 +240,8 @@ func (r *contactRepositoryGORM) UpdateUserContactRelation(ctx context.Context, u
 	if err := r.db.WithContext(ctx).Save(gormUserContact).Error; err != nil {
 		return fmt.Errorf("failed to update user contact relation: %w", err)
 	}
-	// Update the entity with the updated timestamp
+	// Update the entity with the stored email and updated timestamp
+	userContact.Email = gormUserContact.Email
 	userContact.UpdatedAt = gormUserContact.UpdatedAt
 	return nil
 }
@@ -194,29 +271,33 @@ func (r *contactRepositoryGORM) gormToEntity(gormContact *models.Contact) *entit
 // userContactEntityToGORM converts a domain entity to GORM model
 func (r *contactRepositoryGORM) userContactEntityToGORM(userContact *entities.UserContact) *models.UserContact {
 	return &models.UserContact{
-		ID:        userContact.ID,
-		UserID:    userContact.UserID,
-		ContactID: userContact.ContactID,
-		Name:      userContact.Name,
-		Email:     userContact.Email,
-		Phone:     userContact.Phone,
-		Notes:     userContact.Notes,
-		CreatedAt: userContact.CreatedAt,
-		UpdatedAt: userContact.UpdatedAt,
+		ID:         userContact.ID,
+		UserID:     userContact.UserID,
+		ContactID:  userContact.ContactID,
+		Name:       userContact.Name,
+		Email:      normalizeEmailPtr(userContact.Email, r.caseInsensitiveEmail),
+		Phone:      userContact.Phone,
+		Notes:      userContact.Notes,
+		IsFavorite: userContact.IsFavorite,
+		IsBlocked:  userContact.IsBlocked,
+		CreatedAt:  userContact.CreatedAt,
+		UpdatedAt:  userContact.UpdatedAt,
 	}
 }
 
 // userContactGormToEntity converts a GORM model to domain entity
 func (r *contactRepositoryGORM) userContactGormToEntity(gormUserContact *models.UserContact) *entities.UserContact {
 	return &entities.UserContact{
-		ID:        gormUserContact.ID,
-		UserID:    gormUserContact.UserID,
-		ContactID: gormUserContact.ContactID,
-		Name:      gormUserContact.Name,
-		Email:     gormUserContact.Email,
-		Phone:     gormUserContact.Phone,
-		Notes:     gormUserContact.Notes,
-		CreatedAt: gormUserContact.CreatedAt,
-		UpdatedAt: gormUserContact.UpdatedAt,
+		ID:         gormUserContact.ID,
+		UserID:     gormUserContact.UserID,
+		ContactID:  gormUserContact.ContactID,
+		Name:       gormUserContact.Name,
+		Email:      gormUserContact.Email,
+		Phone:      gormUserContact.Phone,
+		Notes:      gormUserContact.Notes,
+		IsFavorite: gormUserContact.IsFavorite,
+		IsBlocked:  gormUserContact.IsBlocked,
+		CreatedAt:  gormUserContact.CreatedAt,
+		UpdatedAt:  gormUserContact.UpdatedAt,
 	}
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"pay-your-dues/internal/domain/entities"
 	"pay-your-dues/internal/domain/interfaces"
@@ -38,9 +39,59 @@ func (r *debtItemRepositoryGORM) Create(ctx context.Context, debtItem *entities.
 	return nil
 }
 
+// CreateReversal creates a reversal of a completed payment. The payment row is
+// locked while its completed reversals are summed, so concurrent reversals can't
+// together reverse more than the payment.
+func (r *debtItemRepositoryGORM) CreateReversal(ctx context.Context, reversal *entities.DebtItem) error {
+	gormDebtItem := r.entityToGORM(reversal)
+	if err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkReversalLimit(tx, *reversal.ReversalOf, reversal.Amount.Neg()); err != nil {
+			return err
+		}
+		if err := tx.Create(gormDebtItem).Error; err != nil {
+			return fmt.Errorf("failed to create debt item: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	reversal.ID = gormDebtItem.ID
+	reversal.CreatedAt = gormDebtItem.CreatedAt
+	reversal.UpdatedAt = gormDebtItem.UpdatedAt
+	return nil
+}
+
+// checkReversalLimit locks the completed payment with SELECT ... FOR UPDATE and
+// checks that amount fits in what its completed reversals have left of it
+func checkReversalLimit(tx *gorm.DB, paymentID uuid.UUID, amount decimal.Decimal) error {
+	var payment models.DebtItem
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND status = ?", paymentID, entities.PaymentStatusCompleted).
+		First(&payment).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return entities.ErrDebtItemNotFound
+		}
+		return fmt.Errorf("failed to lock reversed payment: %w", err)
+	}
+
+	var reversed decimal.Decimal
+	if err := tx.Model(&models.DebtItem{}).
+		Where("reversal_of = ? AND status = ?", paymentID, entities.PaymentStatusCompleted).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&reversed).Error; err != nil {
+		return fmt.Errorf("failed to get reversed amount: %w", err)
+	}
+	if amount.GreaterThan(payment.Amount.Add(reversed)) {
+		return entities.ErrReversalExceedsPayment
+	}
+	return nil
+}
+
 func (r *debtItemRepositoryGORM) GetByID(ctx context.Context, id uuid.UUID) (*entities.DebtItem, error) {
 	var gormDebtItem models.DebtItem
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&gormDebtItem).Error; err != nil {
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).Where("id = ?", id).First(&gormDebtItem).Error
+	}); err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, entities.ErrDebtItemNotFound
 		}
@@ -51,10 +102,12 @@ func (r *debtItemRepositoryGORM) GetByID(ctx context.Context, id uuid.UUID) (*en
 
 func (r *debtItemRepositoryGORM) GetByDebtListID(ctx context.Context, debtListID uuid.UUID) ([]entities.DebtItem, error) {
 	var gormDebtItems []models.DebtItem
-	if err := r.db.WithContext(ctx).
-		Where("debt_list_id = ?", debtListID).
-		Order("payment_date DESC").
-		Find(&gormDebtItems).Error; err != nil {
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Where("debt_list_id = ?", debtListID).
+			Order("payment_date DESC").
+			Find(&gormDebtItems).Error
+	}); err != nil {
 		return nil, fmt.Errorf("failed to get debt items by debt list ID: %w", err)
 	}
 
@@ -76,6 +129,8 @@ func (r *debtItemRepositoryGORM) Update(ctx context.Context, debtItem *entities.
 	return nil
 }
 
+// Delete soft-deletes a debt item. It is hidden from every query, and so from
+// totals, until restored.
 func (r *debtItemRepositoryGORM) Delete(ctx context.Context, id uuid.UUID) error {
 	result := r.db.WithContext(ctx).Delete(&models.DebtItem{}, "id = ?", id)
 	if result.Error != nil {
@@ -87,12 +142,157 @@ func (r *debtItemRepositoryGORM) Delete(ctx context.Context, id uuid.UUID) error
 	return nil
 }
 
+// DeleteByIDs soft-deletes several debt items of one debt list in a single transaction.
+// Nothing is deleted unless every ID belongs to the debt list.
+func (r *debtItemRepositoryGORM) DeleteByIDs(ctx context.Context, debtListID uuid.UUID, ids []uuid.UUID) error {
+	return r.deleteByIDs(r.db.WithContext(ctx), debtListID, ids, false)
+}
+
+// Purge permanently deletes a debt item, including one that was soft-deleted,
+// and leaves a tombstone in its place
+func (r *debtItemRepositoryGORM) Purge(ctx context.Context, id uuid.UUID) error {
+	var gormDebtItem models.DebtItem
+	if err := r.db.WithContext(ctx).Unscoped().Select("debt_list_id").First(&gormDebtItem, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return entities.ErrDebtItemNotFound
+		}
+		return fmt.Errorf("failed to get debt item: %w", err)
+	}
+	return r.deleteByIDs(r.db.WithContext(ctx).Unscoped(), gormDebtItem.DebtListID, []uuid.UUID{id}, true)
+}
+
+// PurgeByIDs permanently deletes several debt items of one debt list in a single
+// transaction, leaving tombstones in their place. Nothing is deleted unless every
+// ID belongs to the debt list.
+func (r *debtItemRepositoryGORM) PurgeByIDs(ctx context.Context, debtListID uuid.UUID, ids []uuid.UUID) error {
+	return r.deleteByIDs(r.db.WithContext(ctx).Unscoped(), debtListID, ids, true)
+}
+
+func (r *debtItemRepositoryGORM) deleteByIDs(db *gorm.DB, debtListID uuid.UUID, ids []uuid.UUID, purge bool) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("debt_list_id = ? AND id IN ?", debtListID, ids).Delete(&models.DebtItem{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete debt items: %w", result.Error)
+		}
+		if result.RowsAffected != int64(len(ids)) {
+			return entities.ErrDebtItemNotFound
+		}
+		if !purge {
+			return nil
+		}
+
+		now := time.Now()
+		tombstones := make([]models.Deletion, len(ids))
+		for i, id := range ids {
+			tombstones[i] = models.Deletion{
+				ID:         uuid.New(),
+				EntityType: deletionEntityPayment,
+				EntityID:   id,
+				DebtListID: debtListID,
+				DeletedAt:  now,
+			}
+		}
+		if err := tx.Create(&tombstones).Error; err != nil {
+			return fmt.Errorf("failed to record debt item deletions: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetDeletedByID gets a soft-deleted debt item. Items that were never deleted
+// are reported as not found.
+func (r *debtItemRepositoryGORM) GetDeletedByID(ctx context.Context, id uuid.UUID) (*entities.DebtItem, error) {
+	var gormDebtItem models.DebtItem
+	if err := r.db.WithContext(ctx).Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&gormDebtItem).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrDebtItemNotFound
+		}
+		return nil, fmt.Errorf("failed to get deleted debt item by ID: %w", err)
+	}
+	return r.gormToEntity(&gormDebtItem), nil
+}
+
+// Restore undoes the soft-delete of a debt item
+func (r *debtItemRepositoryGORM) Restore(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Unscoped().Model(&models.DebtItem{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return fmt.Errorf("failed to restore debt item: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrDebtItemNotFound
+	}
+	return nil
+}
+
+// RestoreReversal undoes the soft-delete of a payment reversal, under the same
+// lock and limit as CreateReversal. A reversal of a payment that is gone or no
+// longer completed is reported as not found.
+func (r *debtItemRepositoryGORM) RestoreReversal(ctx context.Context, reversal *entities.DebtItem) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkReversalLimit(tx, *reversal.ReversalOf, reversal.Amount.Neg()); err != nil {
+			return err
+		}
+		result := tx.Unscoped().Model(&models.DebtItem{}).
+			Where("id = ? AND deleted_at IS NOT NULL", reversal.ID).
+			Update("deleted_at", nil)
+		if result.Error != nil {
+			return fmt.Errorf("failed to restore debt item: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return entities.ErrDebtItemNotFound
+		}
+		return nil
+	})
+}
+
+// GetDeletedSince gets the debt items of the given debt lists that were
+// soft-deleted or purged after since. Purged items are known only by their ID
+// and debt list.
+func (r *debtItemRepositoryGORM) GetDeletedSince(ctx context.Context, debtListIDs []uuid.UUID, since time.Time) ([]entities.DebtItem, error) {
+	if len(debtListIDs) == 0 {
+		return nil, nil
+	}
+
+	var gormDebtItems []models.DebtItem
+	var tombstones []models.Deletion
+	if err := retryRead(ctx, func() error {
+		if err := r.db.WithContext(ctx).Unscoped().
+			Where("debt_list_id IN ? AND deleted_at > ?", debtListIDs, since).
+			Order("deleted_at ASC").
+			Find(&gormDebtItems).Error; err != nil {
+			return err
+		}
+		return r.db.WithContext(ctx).
+			Where("entity_type = ? AND debt_list_id IN ? AND deleted_at > ?", deletionEntityPayment, debtListIDs, since).
+			Order("deleted_at ASC").
+			Find(&tombstones).Error
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get deleted debt items: %w", err)
+	}
+
+	debtItems := make([]entities.DebtItem, 0, len(gormDebtItems)+len(tombstones))
+	for _, gormDebtItem := range gormDebtItems {
+		debtItems = append(debtItems, *r.gormToEntity(&gormDebtItem))
+	}
+	for _, tombstone := range tombstones {
+		debtItems = append(debtItems, entities.DebtItem{ID: tombstone.EntityID, DebtListID: tombstone.DebtListID})
+	}
+
+	return debtItems, nil
+}
+
 func (r *debtItemRepositoryGORM) GetTotalPaidForDebtList(ctx context.Context, debtListID uuid.UUID) (decimal.Decimal, error) {
 	var totalPaid decimal.Decimal
-	if err := r.db.WithContext(ctx).Model(&models.DebtItem{}).
-		Where("debt_list_id = ? AND status = ?", debtListID, "completed").
-		Select("COALESCE(SUM(amount), 0)").
-		Scan(&totalPaid).Error; err != nil {
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).Model(&models.DebtItem{}).
+			Joins("JOIN debt_lists ON debt_lists.id = debt_items.debt_list_id").
+			Where("debt_items.debt_list_id = ? AND debt_items.status = ?", debtListID, "completed").
+			Where("UPPER(debt_items.currency) = UPPER(debt_lists.currency)").
+			Select("COALESCE(SUM(debt_items.amount), 0)").
+			Scan(&totalPaid).Error
+	}); err != nil {
 		return decimal.Zero, fmt.Errorf("failed to get total paid for debt list: %w", err)
 	}
 	return totalPaid, nil
@@ -100,10 +300,14 @@ func (r *debtItemRepositoryGORM) GetTotalPaidForDebtList(ctx context.Context, de
 
 func (r *debtItemRepositoryGORM) GetCompletedPaymentsForDebtList(ctx context.Context, debtListID uuid.UUID) ([]entities.DebtItem, error) {
 	var gormDebtItems []models.DebtItem
-	if err := r.db.WithContext(ctx).
-		Where("debt_list_id = ? AND status = ?", debtListID, "completed").
-		Order("payment_date ASC").
-		Find(&gormDebtItems).Error; err != nil {
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Joins("JOIN debt_lists ON debt_lists.id = debt_items.debt_list_id").
+			Where("debt_items.debt_list_id = ? AND debt_items.status = ?", debtListID, "completed").
+			Where("UPPER(debt_items.currency) = UPPER(debt_lists.currency)").
+			Order("debt_items.payment_date ASC").
+			Find(&gormDebtItems).Error
+	}); err != nil {
 		return nil, fmt.Errorf("failed to get completed payments for debt list: %w", err)
 	}
 
@@ -115,18 +319,40 @@ func (r *debtItemRepositoryGORM) GetCompletedPaymentsForDebtList(ctx context.Con
 	return debtItems, nil
 }
 
-func (r *debtItemRepositoryGORM) GetLastPaymentDate(ctx context.Context, debtListID uuid.UUID) (*time.Time, error) {
-	var lastPayment models.DebtItem
-	if err := r.db.WithContext(ctx).
-		Where("debt_list_id = ? AND status = ?", debtListID, "completed").
-		Order("payment_date DESC").
-		First(&lastPayment).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, nil // No payments found
-		}
-		return nil, fmt.Errorf("failed to get last payment date: %w", err)
+// GetCompletedPaymentsForBalance returns the completed payments of a debt list
+// made in the given currency, oldest first
+func (r *debtItemRepositoryGORM) GetCompletedPaymentsForBalance(ctx context.Context, debtListID uuid.UUID, currency string) ([]entities.DebtItem, error) {
+	var gormDebtItems []models.DebtItem
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Where("debt_list_id = ? AND status = ? AND UPPER(currency) = UPPER(?)", debtListID, "completed", currency).
+			Order("payment_date ASC").
+			Find(&gormDebtItems).Error
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get completed payments for balance: %w", err)
 	}
-	return &lastPayment.PaymentDate, nil
+
+	debtItems := make([]entities.DebtItem, len(gormDebtItems))
+	for i, gormDebtItem := range gormDebtItems {
+		debtItems[i] = *r.gormToEntity(&gormDebtItem)
+	}
+
+	return debtItems, nil
+}
+
+// GetReversedAmount returns how much of a payment its completed reversals have
+// taken back, as a positive amount
+func (r *debtItemRepositoryGORM) GetReversedAmount(ctx context.Context, paymentID uuid.UUID) (decimal.Decimal, error) {
+	var reversed decimal.Decimal
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).Model(&models.DebtItem{}).
+			Where("reversal_of = ? AND status = ?", paymentID, "completed").
+			Select("COALESCE(SUM(amount), 0)").
+			Scan(&reversed).Error
+	}); err != nil {
+		return decimal.Zero, fmt.Errorf("failed to get reversed amount: %w", err)
+	}
+	return reversed.Neg(), nil
 }
 
 func (r *debtItemRepositoryGORM) BelongsToUserDebtList(ctx context.Context, debtItemID, userID uuid.UUID) (bool, error) {
@@ -150,20 +376,62 @@ func (r *debtItemRepositoryGORM) CanUserVerifyDebtItem(ctx context.Context, debt
 		Joins("JOIN debt_lists ON debt_items.debt_list_id = debt_lists.id").
 		Joins("LEFT JOIN contacts ON debt_lists.contact_id = contacts.id").
 		Where("debt_items.id = ? AND ((debt_lists.debt_type = ? AND debt_lists.user_id = ?) OR (debt_lists.debt_type = ? AND contacts.user_id_ref = ?))", 
-			debtItemID, "to_receive", userID, "to_pay", userID).
+			debtItemID, entities.DebtTypeToReceive, userID, entities.DebtTypeToPay, userID).
 		Count(&count).Error; err != nil {
 		return false, fmt.Errorf("failed to check debt item verification permission: %w", err)
 	}
 	return count > 0, nil
 }
 
-// GetPendingVerifications gets all pending debt items that need verification
+// GetVerifier returns the user who can verify a debt item, matched by the same
+// rules as CanUserVerifyDebtItem: the owner of a "to_receive" debt list or the
+// registered user behind the contact of a "to_pay" one
+func (r *debtItemRepositoryGORM) GetVerifier(ctx context.Context, debtItemID uuid.UUID) (*entities.User, error) {
+	var gormUsers []models.User
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).Raw(`
+			SELECT users.* FROM debt_items
+			JOIN debt_lists ON debt_items.debt_list_id = debt_lists.id
+			LEFT JOIN contacts ON debt_lists.contact_id = contacts.id
+			JOIN users ON (debt_lists.debt_type = ? AND users.id = debt_lists.user_id)
+				OR (debt_lists.debt_type = ? AND users.id = contacts.user_id_ref)
+			WHERE debt_items.id = ? AND debt_items.deleted_at IS NULL
+			LIMIT 1`,
+			entities.DebtTypeToReceive, entities.DebtTypeToPay, debtItemID).
+			Scan(&gormUsers).Error
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get debt item verifier: %w", err)
+	}
+	if len(gormUsers) == 0 {
+		return nil, nil
+	}
+	return &entities.User{
+		ID:        gormUsers[0].ID,
+		Email:     gormUsers[0].Email,
+		FirstName: gormUsers[0].FirstName,
+		LastName:  gormUsers[0].LastName,
+		Phone:     gormUsers[0].Phone,
+		CreatedAt: gormUsers[0].CreatedAt,
+		UpdatedAt: gormUsers[0].UpdatedAt,
+	}, nil
+}
+
+// GetPendingVerifications gets all pending debt items that need verification.
+// The debt lists the user verifies are resolved by two indexed lookups, one per
+// side of the debt, so only pending items of those lists are read through the
+// partial index on pending payments.
 func (r *debtItemRepositoryGORM) GetPendingVerifications(ctx context.Context, userID uuid.UUID) ([]entities.DebtItem, error) {
+	verifiableLists := r.db.Raw(`
+		SELECT id FROM debt_lists WHERE user_id = ? AND debt_type = ?
+		UNION
+		SELECT debt_lists.id FROM debt_lists
+		JOIN contacts ON debt_lists.contact_id = contacts.id
+		WHERE contacts.user_id_ref = ? AND debt_lists.debt_type = ?`,
+		userID, entities.DebtTypeToReceive, userID, entities.DebtTypeToPay)
+
 	var gormDebtItems []models.DebtItem
 	if err := r.db.WithContext(ctx).
-		Joins("JOIN debt_lists ON debt_items.debt_list_id = debt_lists.id").
-		Joins("LEFT JOIN contacts ON debt_lists.contact_id = contacts.id").
-		Where("((debt_lists.user_id = ? AND debt_lists.debt_type = ?) OR (contacts.user_id_ref = ? AND debt_lists.debt_type = ?)) AND debt_items.status = ?", userID, "to_receive", userID, "to_pay", "pending").
+		Where("debt_items.status = ? AND debt_items.debt_list_id IN (?)", entities.PaymentStatusPending, verifiableLists).
 		Order("debt_items.created_at DESC").
 		Find(&gormDebtItems).Error; err != nil {
 		return nil, fmt.Errorf("failed to get pending verifications: %w", err)
@@ -177,25 +445,177 @@ func (r *debtItemRepositoryGORM) GetPendingVerifications(ctx context.Context, us
 	return debtItems, nil
 }
 
-// UpdatePaymentStatus updates the payment status and verification details
+// GetForUser gets a page of the payments on the user's debt lists in one query,
+// joining each payment to its debt list, and counts the matching payments in
+// another. Contact-referenced lists are found through the contacts referencing
+// the user, and their debt type is flipped to the user's side.
+func (r *debtItemRepositoryGORM) GetForUser(ctx context.Context, userID uuid.UUID, filter entities.PaymentFilter) ([]entities.UserPayment, int, error) {
+	contactLists := r.db.Model(&models.Contact{}).Select("id").Where("user_id_ref = ?", userID)
+	matching := func() *gorm.DB {
+		query := r.db.WithContext(ctx).Model(&models.DebtItem{}).
+			InnerJoins("DebtList").
+			Where(`("DebtList"."user_id" = ? OR "DebtList"."contact_id" IN (?))`, userID, contactLists)
+		if filter.Status != "" {
+			query = query.Where("debt_items.status = ?", filter.Status)
+		}
+		if filter.From != nil {
+			query = query.Where("debt_items.payment_date >= ?", *filter.From)
+		}
+		if filter.To != nil {
+			query = query.Where("debt_items.payment_date < ?", *filter.To)
+		}
+		return query
+	}
+
+	var total int64
+	var gormDebtItems []models.DebtItem
+	if err := retryRead(ctx, func() error {
+		if err := matching().Count(&total).Error; err != nil {
+			return err
+		}
+		query := matching().
+			Order("debt_items.payment_date DESC").
+			Order("debt_items.id")
+		if filter.Limit > 0 {
+			query = query.Limit(filter.Limit).Offset(filter.Offset)
+		}
+		return query.Find(&gormDebtItems).Error
+	}); err != nil {
+		return nil, 0, fmt.Errorf("failed to get payments for user: %w", err)
+	}
+
+	payments := make([]entities.UserPayment, len(gormDebtItems))
+	for i, gormDebtItem := range gormDebtItems {
+		debtList := gormDebtItem.DebtList
+		isOwner := debtList.UserID == userID
+		debtType := debtList.DebtType
+		if !isOwner {
+			debtType = entities.OppositeDebtType(debtType)
+		}
+		payments[i] = entities.UserPayment{
+			Payment: *r.gormToEntity(&gormDebtItem),
+			DebtList: entities.PaymentDebtList{
+				ID:                 debtList.ID,
+				DebtType:           debtType,
+				TotalAmount:        debtList.TotalAmount,
+				TotalRemainingDebt: debtList.TotalRemainingDebt,
+				Currency:           debtList.Currency,
+				Status:             debtList.Status,
+				Description:        debtList.Description,
+				IsOwner:            isOwner,
+			},
+		}
+	}
+
+	return payments, int(total), nil
+}
+
+// UpdatePaymentStatus updates the payment status and verification details.
+// Only open payments are updated, so concurrent verifications apply at most once.
+// A disputed payment is still open and can be verified or rejected once resolved,
+// but only a pending payment can be disputed. Verification details are recorded
+// only once a payment is completed or rejected.
 func (r *debtItemRepositoryGORM) UpdatePaymentStatus(ctx context.Context, debtItemID uuid.UUID, status string, verifiedBy uuid.UUID, notes *string) error {
+	openStatuses := []string{entities.PaymentStatusPending, entities.PaymentStatusDisputed}
+	if status == entities.PaymentStatusDisputed {
+		openStatuses = []string{entities.PaymentStatusPending}
+	}
+
 	updates := map[string]interface{}{
 		"status":             status,
-		"verified_by":        verifiedBy,
-		"verified_at":        time.Now(),
 		"verification_notes": notes,
 		"updated_at":         time.Now(),
 	}
+	// A dispute leaves the payment open, so it is not yet verified by anyone
+	if status != entities.PaymentStatusDisputed {
+		updates["verified_by"] = verifiedBy
+		updates["verified_at"] = time.Now()
+	}
 
 	result := r.db.WithContext(ctx).Model(&models.DebtItem{}).
-		Where("id = ?", debtItemID).
+		Where("id = ? AND status IN ?", debtItemID, openStatuses).
 		Updates(updates)
 
 	if result.Error != nil {
 		return fmt.Errorf("failed to update payment status: %w", result.Error)
 	}
 	if result.RowsAffected == 0 {
-		return entities.ErrDebtItemNotFound
+		var count int64
+		if err := r.db.WithContext(ctx).Model(&models.DebtItem{}).Where("id = ?", debtItemID).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to check debt item existence: %w", err)
+		}
+		if count == 0 {
+			return entities.ErrDebtItemNotFound
+		}
+		return entities.ErrPaymentAlreadyProcessed
+	}
+	return nil
+}
+
+// ResubmitPayment moves a rejected payment back to pending, clearing the previous
+// verification and counting the resubmission. A nil receipt or notes keeps the current value.
+func (r *debtItemRepositoryGORM) ResubmitPayment(ctx context.Context, debtItemID uuid.UUID, photoURL *string, notes *string) error {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":             entities.PaymentStatusPending,
+		"verified_by":        nil,
+		"verified_at":        nil,
+		"resubmission_count": gorm.Expr("resubmission_count + 1"),
+		"resubmitted_at":     now,
+		"updated_at":         now,
+	}
+	if photoURL != nil {
+		updates["receipt_photo_url"] = photoURL
+	}
+	if notes != nil {
+		updates["verification_notes"] = notes
+	}
+
+	result := r.db.WithContext(ctx).Model(&models.DebtItem{}).
+		Where("id = ? AND status = ?", debtItemID, entities.PaymentStatusRejected).
+		Updates(updates)
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to resubmit payment: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		var count int64
+		if err := r.db.WithContext(ctx).Model(&models.DebtItem{}).Where("id = ?", debtItemID).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to check debt item existence: %w", err)
+		}
+		if count == 0 {
+			return entities.ErrDebtItemNotFound
+		}
+		return entities.ErrPaymentNotRejected
+	}
+	return nil
+}
+
+// MarkNudged records that the payment's verifier was reminded of it at nudgedAt.
+// It applies only while the payment is pending and was not already nudged after
+// since, so concurrent nudges within the same window record at most one. The
+// payment itself is unchanged, so updated_at is left alone.
+func (r *debtItemRepositoryGORM) MarkNudged(ctx context.Context, debtItemID uuid.UUID, nudgedAt, since time.Time) error {
+	result := r.db.WithContext(ctx).Model(&models.DebtItem{}).
+		Where("id = ? AND status = ?", debtItemID, entities.PaymentStatusPending).
+		Where("last_nudged_at IS NULL OR last_nudged_at <= ?", since).
+		UpdateColumn("last_nudged_at", nudgedAt)
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to mark payment nudged: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		var gormDebtItems []models.DebtItem
+		if err := r.db.WithContext(ctx).Select("status").Where("id = ?", debtItemID).Limit(1).Find(&gormDebtItems).Error; err != nil {
+			return fmt.Errorf("failed to check debt item status: %w", err)
+		}
+		if len(gormDebtItems) == 0 {
+			return entities.ErrDebtItemNotFound
+		}
+		if gormDebtItems[0].Status != entities.PaymentStatusPending {
+			return entities.ErrPaymentNotPending
+		}
+		return entities.ErrNudgeTooSoon
 	}
 	return nil
 }
@@ -220,6 +640,28 @@ func (r *debtItemRepositoryGORM) UpdateReceiptPhoto(ctx context.Context, debtIte
 	return nil
 }
 
+// GetReceiptsForSettledDebts returns the debt items that still have a receipt
+// on debt lists settled before the given time. A settled debt list is no longer
+// updated, so its last update marks when it was settled.
+func (r *debtItemRepositoryGORM) GetReceiptsForSettledDebts(ctx context.Context, settledBefore time.Time) ([]entities.DebtItem, error) {
+	var gormDebtItems []models.DebtItem
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN debt_lists ON debt_items.debt_list_id = debt_lists.id").
+		Where("debt_lists.status = ? AND debt_lists.updated_at < ?", "settled", settledBefore).
+		Where("debt_items.receipt_photo_url IS NOT NULL AND debt_items.receipt_photo_url <> ''").
+		Order("debt_items.created_at ASC").
+		Find(&gormDebtItems).Error; err != nil {
+		return nil, fmt.Errorf("failed to get receipts for settled debts: %w", err)
+	}
+
+	debtItems := make([]entities.DebtItem, len(gormDebtItems))
+	for i, gormDebtItem := range gormDebtItems {
+		debtItems[i] = *r.gormToEntity(&gormDebtItem)
+	}
+
+	return debtItems, nil
+}
+
 // entityToGORM converts a domain entity to GORM model
 func (r *debtItemRepositoryGORM) entityToGORM(debtItem *entities.DebtItem) *models.DebtItem {
 	return &models.DebtItem{
@@ -229,12 +671,19 @@ func (r *debtItemRepositoryGORM) entityToGORM(debtItem *entities.DebtItem) *mode
 		Currency:          debtItem.Currency,
 		PaymentDate:       debtItem.PaymentDate,
 		PaymentMethod:     debtItem.PaymentMethod,
+		PaymentType:       debtItem.PaymentType,
 		Description:       debtItem.Description,
+		Reference:         debtItem.Reference,
 		Status:            debtItem.Status,
 		ReceiptPhotoURL:   debtItem.ReceiptPhotoURL,
 		VerifiedBy:        debtItem.VerifiedBy,
 		VerifiedAt:        debtItem.VerifiedAt,
 		VerificationNotes: debtItem.VerificationNotes,
+		ResubmissionCount: debtItem.ResubmissionCount,
+		ResubmittedAt:     debtItem.ResubmittedAt,
+		LastNudgedAt:      debtItem.LastNudgedAt,
+		CreatedBy:         debtItem.CreatedBy,
+		ReversalOf:        debtItem.ReversalOf,
 		CreatedAt:         debtItem.CreatedAt,
 		UpdatedAt:         debtItem.UpdatedAt,
 	}
@@ -249,12 +698,19 @@ func (r *debtItemRepositoryGORM) gormToEntity(gormDebtItem *models.DebtItem) *en
 		Currency:          gormDebtItem.Currency,
 		PaymentDate:       gormDebtItem.PaymentDate,
 		PaymentMethod:     gormDebtItem.PaymentMethod,
+		PaymentType:       gormDebtItem.PaymentType,
 		Description:       gormDebtItem.Description,
+		Reference:         gormDebtItem.Reference,
 		Status:            gormDebtItem.Status,
 		ReceiptPhotoURL:   gormDebtItem.ReceiptPhotoURL,
 		VerifiedBy:        gormDebtItem.VerifiedBy,
 		VerifiedAt:        gormDebtItem.VerifiedAt,
 		VerificationNotes: gormDebtItem.VerificationNotes,
+		ResubmissionCount: gormDebtItem.ResubmissionCount,
+		ResubmittedAt:     gormDebtItem.ResubmittedAt,
+		LastNudgedAt:      gormDebtItem.LastNudgedAt,
+		CreatedBy:         gormDebtItem.CreatedBy,
+		ReversalOf:        gormDebtItem.ReversalOf,
 		CreatedAt:         gormDebtItem.CreatedAt,
 		UpdatedAt:         gormDebtItem.UpdatedAt,
 	}
@@ -2,12 +2,16 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"pay-your-dues/internal/domain/entities"
 	"pay-your-dues/internal/domain/interfaces"
@@ -16,20 +20,47 @@ import (
 
 // debtListRepositoryGORM implements the DebtListRepository interface using GORM
 type debtListRepositoryGORM struct {
-	db          *gorm.DB
-	contactRepo interfaces.ContactRepository
+	db                *gorm.DB
+	contactRepo       interfaces.ContactRepository
+	contactNameSource string
+}
+
+// DebtListRepositoryOption configures optional behaviour of the debt list repository
+type DebtListRepositoryOption func(*debtListRepositoryGORM)
+
+// WithDebtListContactNameSource sets which name debt list responses show for
+// contacts that are registered users, see entities.ContactResponse.ApplyNameSource.
+// Defaults to the name the viewing user saved for the contact.
+func WithDebtListContactNameSource(source string) DebtListRepositoryOption {
+	return func(r *debtListRepositoryGORM) {
+		r.contactNameSource = source
+	}
 }
 
 // NewDebtListRepositoryGORM creates a new debt list repository with GORM
-func NewDebtListRepositoryGORM(db *gorm.DB, contactRepo interfaces.ContactRepository) interfaces.DebtListRepository {
-	return &debtListRepositoryGORM{
-		db:          db,
-		contactRepo: contactRepo,
+func NewDebtListRepositoryGORM(db *gorm.DB, contactRepo interfaces.ContactRepository, opts ...DebtListRepositoryOption) interfaces.DebtListRepository {
+	r := &debtListRepositoryGORM{
+		db:                db,
+		contactRepo:       contactRepo,
+		contactNameSource: entities.ContactNameSourceContact,
+	}
+	for _, opt := range opts {
+		opt(r)
 	}
+	return r
 }
 
 func (r *debtListRepositoryGORM) Create(ctx context.Context, debtList *entities.DebtList) error {
 	gormDebtList := r.entityToGORM(debtList)
+	// Additional currency balances are created together with the list
+	for _, balance := range debtList.Balances {
+		gormDebtList.Balances = append(gormDebtList.Balances, models.DebtBalance{
+			ID:          balance.ID,
+			DebtListID:  debtList.ID,
+			Currency:    balance.Currency,
+			TotalAmount: balance.TotalAmount,
+		})
+	}
 	if err := r.db.WithContext(ctx).Create(gormDebtList).Error; err != nil {
 		return fmt.Errorf("failed to create debt list: %w", err)
 	}
@@ -42,7 +73,9 @@ func (r *debtListRepositoryGORM) Create(ctx context.Context, debtList *entities.
 
 func (r *debtListRepositoryGORM) GetByID(ctx context.Context, id uuid.UUID) (*entities.DebtList, error) {
 	var gormDebtList models.DebtList
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&gormDebtList).Error; err != nil {
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).Where("id = ?", id).First(&gormDebtList).Error
+	}); err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, entities.ErrDebtListNotFound
 		}
@@ -53,51 +86,83 @@ func (r *debtListRepositoryGORM) GetByID(ctx context.Context, id uuid.UUID) (*en
 
 func (r *debtListRepositoryGORM) GetByIDWithRelations(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtListResponse, error) {
 	var gormDebtList models.DebtList
-	if err := r.db.WithContext(ctx).
-		Preload("Contact").
-		Preload("User").
-		Preload("Payments").
-		Where("id = ?", id).
-		First(&gormDebtList).Error; err != nil {
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Contact").
+			Preload("User").
+			Preload("Payments").
+			Where("id = ?", id).
+			First(&gormDebtList).Error
+	}); err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, entities.ErrDebtListNotFound
 		}
 		return nil, fmt.Errorf("failed to get debt list with relations: %w", err)
 	}
-	return r.gormToResponseEntity(ctx, &gormDebtList, userID), nil
+	return r.gormToResponseEntity(ctx, &gormDebtList, userID)
 }
 
 func (r *debtListRepositoryGORM) GetUserDebtLists(ctx context.Context, userID uuid.UUID) ([]entities.DebtListResponse, error) {
+	return r.getUserDebtLists(ctx, userID)
+}
+
+// GetUserDebtListsModifiedSince gets the user's debt lists that they or their
+// payments changed after since
+func (r *debtListRepositoryGORM) GetUserDebtListsModifiedSince(ctx context.Context, userID uuid.UUID, since time.Time) ([]entities.DebtListResponse, error) {
+	return r.getUserDebtLists(ctx, userID, modifiedSince(since))
+}
+
+func (r *debtListRepositoryGORM) getUserDebtLists(ctx context.Context, userID uuid.UUID, scopes ...func(*gorm.DB) *gorm.DB) ([]entities.DebtListResponse, error) {
 	var gormDebtLists []models.DebtList
-	if err := r.db.WithContext(ctx).
-		Preload("Contact").
-		Preload("User").
-		Preload("Payments").
-		Where("user_id = ?", userID).
-		Order("created_at DESC").
-		Find(&gormDebtLists).Error; err != nil {
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Contact").
+			Preload("User").
+			Preload("Payments").
+			Where("user_id = ?", userID).
+			Scopes(scopes...).
+			Order("created_at DESC").
+			Find(&gormDebtLists).Error
+	}); err != nil {
 		return nil, fmt.Errorf("failed to get user debt lists: %w", err)
 	}
 
 	debtLists := make([]entities.DebtListResponse, len(gormDebtLists))
 	for i, gormDebtList := range gormDebtLists {
-		debtLists[i] = *r.gormToResponseEntity(ctx, &gormDebtList, userID)
+		debtListResponse, err := r.gormToResponseEntity(ctx, &gormDebtList, userID)
+		if err != nil {
+			return nil, err
+		}
+		debtLists[i] = *debtListResponse
 	}
 
 	return debtLists, nil
 }
 
 func (r *debtListRepositoryGORM) GetDebtListsWhereUserIsContact(ctx context.Context, userID uuid.UUID) ([]entities.DebtListResponse, error) {
+	return r.getDebtListsWhereUserIsContact(ctx, userID)
+}
+
+// GetDebtListsWhereUserIsContactModifiedSince gets the debt lists where the user
+// is referenced as a contact that they or their payments changed after since
+func (r *debtListRepositoryGORM) GetDebtListsWhereUserIsContactModifiedSince(ctx context.Context, userID uuid.UUID, since time.Time) ([]entities.DebtListResponse, error) {
+	return r.getDebtListsWhereUserIsContact(ctx, userID, modifiedSince(since))
+}
+
+func (r *debtListRepositoryGORM) getDebtListsWhereUserIsContact(ctx context.Context, userID uuid.UUID, scopes ...func(*gorm.DB) *gorm.DB) ([]entities.DebtListResponse, error) {
 	// Find debt lists where the current user is referenced as a contact
 	var gormDebtLists []models.DebtList
-	if err := r.db.WithContext(ctx).
-		Preload("Contact").
-		Preload("User").
-		Preload("Payments").
-		Joins("JOIN contacts ON debt_lists.contact_id = contacts.id").
-		Where("contacts.user_id_ref = ?", userID).
-		Order("debt_lists.created_at DESC").
-		Find(&gormDebtLists).Error; err != nil {
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Contact").
+			Preload("User").
+			Preload("Payments").
+			Joins("JOIN contacts ON debt_lists.contact_id = contacts.id").
+			Where("contacts.user_id_ref = ?", userID).
+			Scopes(scopes...).
+			Order("debt_lists.created_at DESC").
+			Find(&gormDebtLists).Error
+	}); err != nil {
 		return nil, fmt.Errorf("failed to get debt lists where user is contact: %w", err)
 	}
 
@@ -105,23 +170,34 @@ func (r *debtListRepositoryGORM) GetDebtListsWhereUserIsContact(ctx context.Cont
 	for i, gormDebtList := range gormDebtLists {
 		// When a user views a debt list where they are the contact,
 		// the Contact field should represent the debt list owner (User), not themselves
-		debtListResponse := *r.gormToResponseEntity(ctx, &gormDebtList, userID)
-		
+		debtListResponse, err := r.gormToResponseEntity(ctx, &gormDebtList, userID)
+		if err != nil {
+			return nil, err
+		}
+
 		// Replace the Contact information with the User (debt list owner) information from their perspective
 		// Build ContactResponse with User data (from the perspective of the contact/userID)
+		ownerName := strings.TrimSpace(gormDebtList.User.FirstName + " " + gormDebtList.User.LastName)
+		if ownerName == "" {
+			ownerName = entities.UnknownContactName
+		}
 		debtListResponse.Contact = entities.ContactResponse{
-			ID:        gormDebtList.User.ID,
-			Name:      gormDebtList.User.FirstName + " " + gormDebtList.User.LastName,
-			Email:     &gormDebtList.User.Email,
-			Phone:     gormDebtList.User.Phone,
-			Notes:     nil,
-			IsUser:    true,
-			UserIDRef: &gormDebtList.User.ID,
-			CreatedAt: gormDebtList.User.CreatedAt,
-			UpdatedAt: gormDebtList.User.UpdatedAt,
+			ID:           gormDebtList.User.ID,
+			Name:         ownerName,
+			Email:        &gormDebtList.User.Email,
+			Phone:        gormDebtList.User.Phone,
+			Notes:        nil,
+			IsUser:       true,
+			UserIDRef:    &gormDebtList.User.ID,
+			IsActiveUser: gormDebtList.User.ID != uuid.Nil,
+			CreatedAt:    gormDebtList.User.CreatedAt,
+			UpdatedAt:    gormDebtList.User.UpdatedAt,
+		}
+		if debtListResponse.Contact.IsActiveUser {
+			debtListResponse.Contact.VerifiedName = &ownerName
 		}
 		
-		debtLists[i] = debtListResponse
+		debtLists[i] = *debtListResponse
 	}
 
 	return debtLists, nil
@@ -137,21 +213,60 @@ func (r *debtListRepositoryGORM) Update(ctx context.Context, debtList *entities.
 	return nil
 }
 
+// Delete deletes a debt list and leaves a tombstone for both of its parties
 func (r *debtListRepositoryGORM) Delete(ctx context.Context, id uuid.UUID) error {
-	result := r.db.WithContext(ctx).Delete(&models.DebtList{}, "id = ?", id)
-	if result.Error != nil {
-		return fmt.Errorf("failed to delete debt list: %w", result.Error)
-	}
-	if result.RowsAffected == 0 {
-		return entities.ErrDebtListNotFound
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var gormDebtList models.DebtList
+		if err := tx.Preload("Contact").First(&gormDebtList, "id = ?", id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return entities.ErrDebtListNotFound
+			}
+			return fmt.Errorf("failed to get debt list: %w", err)
+		}
+
+		result := tx.Delete(&models.DebtList{}, "id = ?", id)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete debt list: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return entities.ErrDebtListNotFound
+		}
+
+		tombstone := models.Deletion{
+			ID:            uuid.New(),
+			EntityType:    deletionEntityDebtList,
+			EntityID:      id,
+			DebtListID:    id,
+			UserID:        &gormDebtList.UserID,
+			ContactUserID: gormDebtList.Contact.UserIDRef,
+			DeletedAt:     time.Now(),
+		}
+		if err := tx.Create(&tombstone).Error; err != nil {
+			return fmt.Errorf("failed to record debt list deletion: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetDeletedSince gets the IDs of the debt lists the user owned or was the
+// contact of that were deleted after since
+func (r *debtListRepositoryGORM) GetDeletedSince(ctx context.Context, userID uuid.UUID, since time.Time) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).Model(&models.Deletion{}).
+			Where("entity_type = ? AND (user_id = ? OR contact_user_id = ?) AND deleted_at > ?", deletionEntityDebtList, userID, userID, since).
+			Order("deleted_at ASC").
+			Pluck("entity_id", &ids).Error
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get deleted debt lists: %w", err)
 	}
-	return nil
+	return ids, nil
 }
 
 func (r *debtListRepositoryGORM) GetOverdueForUser(ctx context.Context, userID uuid.UUID) ([]entities.DebtList, error) {
 	var gormDebtLists []models.DebtList
 	if err := r.db.WithContext(ctx).
-		Where("user_id = ? AND next_payment_date < ? AND status = ?", userID, time.Now(), "active").
+		Where("user_id = ? AND next_payment_date < ? AND status IN ?", userID, time.Now(), []string{"active", "overdue"}).
 		Order("next_payment_date ASC").
 		Find(&gormDebtLists).Error; err != nil {
 		return nil, fmt.Errorf("failed to get overdue debt lists: %w", err)
@@ -182,6 +297,42 @@ func (r *debtListRepositoryGORM) GetDueSoonForUser(ctx context.Context, userID u
 	return debtLists, nil
 }
 
+func (r *debtListRepositoryGORM) GetOverdueWhereUserIsContact(ctx context.Context, userID uuid.UUID) ([]entities.DebtList, error) {
+	var gormDebtLists []models.DebtList
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN contacts ON debt_lists.contact_id = contacts.id").
+		Where("contacts.user_id_ref = ? AND debt_lists.next_payment_date < ? AND debt_lists.status IN ?", userID, time.Now(), []string{"active", "overdue"}).
+		Order("debt_lists.next_payment_date ASC").
+		Find(&gormDebtLists).Error; err != nil {
+		return nil, fmt.Errorf("failed to get overdue debt lists where user is contact: %w", err)
+	}
+
+	debtLists := make([]entities.DebtList, len(gormDebtLists))
+	for i, gormDebtList := range gormDebtLists {
+		debtLists[i] = *r.gormToEntity(&gormDebtList)
+	}
+
+	return debtLists, nil
+}
+
+func (r *debtListRepositoryGORM) GetDueSoonWhereUserIsContact(ctx context.Context, userID uuid.UUID, dueDate time.Time) ([]entities.DebtList, error) {
+	var gormDebtLists []models.DebtList
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN contacts ON debt_lists.contact_id = contacts.id").
+		Where("contacts.user_id_ref = ? AND debt_lists.next_payment_date BETWEEN ? AND ? AND debt_lists.status = ?", userID, time.Now(), dueDate, "active").
+		Order("debt_lists.next_payment_date ASC").
+		Find(&gormDebtLists).Error; err != nil {
+		return nil, fmt.Errorf("failed to get due soon debt lists where user is contact: %w", err)
+	}
+
+	debtLists := make([]entities.DebtList, len(gormDebtLists))
+	for i, gormDebtList := range gormDebtLists {
+		debtLists[i] = *r.gormToEntity(&gormDebtList)
+	}
+
+	return debtLists, nil
+}
+
 func (r *debtListRepositoryGORM) BelongsToUser(ctx context.Context, debtListID, userID uuid.UUID) (bool, error) {
 	var count int64
 	if err := r.db.WithContext(ctx).Model(&models.DebtList{}).
@@ -203,19 +354,154 @@ func (r *debtListRepositoryGORM) IsContactOfDebtList(ctx context.Context, debtLi
 	return count > 0, nil
 }
 
-func (r *debtListRepositoryGORM) UpdatePaymentTotals(ctx context.Context, debtListID uuid.UUID, totalPaid, remaining decimal.Decimal) error {
-	if err := r.db.WithContext(ctx).Model(&models.DebtList{}).
-		Where("id = ?", debtListID).
+// TransferOwnership moves a debt list from one owner to another, pointing it at
+// the new owner's contact and storing the debt type from their perspective.
+// Only a list still owned by fromUserID is updated, so concurrent transfers apply once.
+func (r *debtListRepositoryGORM) TransferOwnership(ctx context.Context, debtListID, fromUserID, toUserID, contactID uuid.UUID, debtType string) error {
+	result := r.db.WithContext(ctx).Model(&models.DebtList{}).
+		Where("id = ? AND user_id = ?", debtListID, fromUserID).
 		Updates(map[string]interface{}{
-			"total_payments_made":   totalPaid,
-			"total_remaining_debt":  remaining,
-			"updated_at":           time.Now(),
-		}).Error; err != nil {
-		return fmt.Errorf("failed to update payment totals: %w", err)
+			"user_id":    toUserID,
+			"contact_id": contactID,
+			"debt_type":  debtType,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to transfer debt list ownership: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrDebtListNotFound
 	}
 	return nil
 }
 
+// GetBalances returns the additional currency balances of a debt list, ordered by currency
+func (r *debtListRepositoryGORM) GetBalances(ctx context.Context, debtListID uuid.UUID) ([]entities.DebtBalance, error) {
+	var gormBalances []models.DebtBalance
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Where("debt_list_id = ?", debtListID).
+			Order("currency ASC").
+			Find(&gormBalances).Error
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get debt list balances: %w", err)
+	}
+
+	balances := make([]entities.DebtBalance, len(gormBalances))
+	for i, gormBalance := range gormBalances {
+		balances[i] = entities.DebtBalance{
+			ID:          gormBalance.ID,
+			DebtListID:  gormBalance.DebtListID,
+			Currency:    gormBalance.Currency,
+			TotalAmount: gormBalance.TotalAmount,
+			CreatedAt:   gormBalance.CreatedAt,
+			UpdatedAt:   gormBalance.UpdatedAt,
+		}
+	}
+	return balances, nil
+}
+
+func (r *debtListRepositoryGORM) CreateHistoryEntries(ctx context.Context, entries []entities.DebtListHistoryEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	gormEntries := make([]models.DebtListHistoryEntry, len(entries))
+	for i, entry := range entries {
+		gormEntries[i] = models.DebtListHistoryEntry{
+			ID:         entry.ID,
+			DebtListID: entry.DebtListID,
+			ChangedBy:  entry.ChangedBy,
+			Field:      entry.Field,
+			OldValue:   entry.OldValue,
+			NewValue:   entry.NewValue,
+			ChangedAt:  entry.ChangedAt,
+		}
+	}
+	if err := r.db.WithContext(ctx).Create(&gormEntries).Error; err != nil {
+		return fmt.Errorf("failed to create debt list history: %w", err)
+	}
+	return nil
+}
+
+func (r *debtListRepositoryGORM) GetHistory(ctx context.Context, debtListID uuid.UUID) ([]entities.DebtListHistoryEntry, error) {
+	var gormEntries []models.DebtListHistoryEntry
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Where("debt_list_id = ?", debtListID).
+			Order("changed_at DESC, field ASC").
+			Find(&gormEntries).Error
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get debt list history: %w", err)
+	}
+
+	entries := make([]entities.DebtListHistoryEntry, len(gormEntries))
+	for i, gormEntry := range gormEntries {
+		entries[i] = entities.DebtListHistoryEntry{
+			ID:         gormEntry.ID,
+			DebtListID: gormEntry.DebtListID,
+			ChangedBy:  gormEntry.ChangedBy,
+			Field:      gormEntry.Field,
+			OldValue:   gormEntry.OldValue,
+			NewValue:   gormEntry.NewValue,
+			ChangedAt:  gormEntry.ChangedAt,
+		}
+	}
+	return entries, nil
+}
+
+// GetActivePastDueIDs returns the IDs of active debt lists whose next payment date
+// is before the given time, across all users
+func (r *debtListRepositoryGORM) GetActivePastDueIDs(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).Model(&models.DebtList{}).
+			Where("status = ? AND next_payment_date < ?", "active", before).
+			Order("next_payment_date ASC").
+			Pluck("id", &ids).Error
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get active past due debt lists: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *debtListRepositoryGORM) CountUnsettledForContact(ctx context.Context, userID, contactID uuid.UUID) (int64, error) {
+	var count int64
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).Model(&models.DebtList{}).
+			Where("user_id = ? AND contact_id = ? AND status <> ?", userID, contactID, "settled").
+			Count(&count).Error
+	}); err != nil {
+		return 0, fmt.Errorf("failed to count unsettled debt lists for contact: %w", err)
+	}
+	return count, nil
+}
+
+// GetCurrenciesForUser returns the distinct currencies, upper-cased and sorted, of the
+// debts the user owns or is the contact of, including their additional balances
+func (r *debtListRepositoryGORM) GetCurrenciesForUser(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	var currencies []string
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).Raw(`
+		SELECT UPPER(debt_lists.currency) AS currency
+		FROM debt_lists
+		LEFT JOIN contacts ON debt_lists.contact_id = contacts.id
+		WHERE debt_lists.user_id = ? OR contacts.user_id_ref = ?
+		UNION
+		SELECT UPPER(debt_balances.currency) AS currency
+		FROM debt_balances
+		JOIN debt_lists ON debt_balances.debt_list_id = debt_lists.id
+		LEFT JOIN contacts ON debt_lists.contact_id = contacts.id
+		WHERE debt_lists.user_id = ? OR contacts.user_id_ref = ?
+		ORDER BY currency`,
+			userID, userID, userID, userID,
+		).Scan(&currencies).Error
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get currencies for user: %w", err)
+	}
+	return currencies, nil
+}
+
 func (r *debtListRepositoryGORM) UpdateStatus(ctx context.Context, debtListID uuid.UUID, status string) error {
 	if err := r.db.WithContext(ctx).Model(&models.DebtList{}).
 		Where("id = ?", debtListID).
@@ -228,20 +514,262 @@ func (r *debtListRepositoryGORM) UpdateStatus(ctx context.Context, debtListID uu
 	return nil
 }
 
-func (r *debtListRepositoryGORM) UpdateNextPaymentDate(ctx context.Context, debtListID uuid.UUID, nextPaymentDate time.Time) error {
-	if err := r.db.WithContext(ctx).Model(&models.DebtList{}).
-		Where("id = ?", debtListID).
-		Updates(map[string]interface{}{
-			"next_payment_date": nextPaymentDate,
-			"updated_at":        time.Now(),
-		}).Error; err != nil {
-		return fmt.Errorf("failed to update next payment date: %w", err)
+// RecalculatePaymentTotals locks the debt list row with SELECT ... FOR UPDATE,
+// aggregates its completed payments in a single query and saves the totals compute
+// derives from them, all in one transaction. A payment committed while the row is
+// locked is picked up by the next recalculation, which waits for this one.
+func (r *debtListRepositoryGORM) RecalculatePaymentTotals(ctx context.Context, debtListID uuid.UUID, compute func(debtList *entities.DebtList, payments entities.PaymentAggregate) entities.PaymentTotals) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var gormDebtList models.DebtList
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", debtListID).First(&gormDebtList).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return entities.ErrDebtListNotFound
+			}
+			return fmt.Errorf("failed to lock debt list: %w", err)
+		}
+
+		var aggregate struct {
+			TotalPaid       decimal.Decimal
+			PaymentCount    int
+			LastPaymentDate nullTimestamp
+		}
+		if err := tx.Model(&models.DebtItem{}).
+			Select(`COALESCE(SUM(CASE WHEN UPPER(debt_items.currency) = UPPER(debt_lists.currency) THEN debt_items.amount ELSE 0 END), 0) AS total_paid,
+				COUNT(CASE WHEN debt_items.payment_type <> ? THEN 1 END) AS payment_count,
+				MAX(CASE WHEN debt_items.payment_type <> ? THEN debt_items.payment_date END) AS last_payment_date`,
+				entities.PaymentTypeAdjustment, entities.PaymentTypeAdjustment).
+			Joins("JOIN debt_lists ON debt_lists.id = debt_items.debt_list_id").
+			Where("debt_items.debt_list_id = ? AND debt_items.status = ?", debtListID, entities.PaymentStatusCompleted).
+			Scan(&aggregate).Error; err != nil {
+			return fmt.Errorf("failed to aggregate payments: %w", err)
+		}
+
+		totals := compute(r.gormToEntity(&gormDebtList), entities.PaymentAggregate{
+			TotalPaid:       aggregate.TotalPaid,
+			PaymentCount:    aggregate.PaymentCount,
+			LastPaymentDate: aggregate.LastPaymentDate.Ptr(),
+		})
+
+		if err := tx.Model(&models.DebtList{}).
+			Where("id = ?", debtListID).
+			Updates(map[string]interface{}{
+				"total_payments_made":  totals.TotalPaid,
+				"total_remaining_debt": totals.RemainingDebt,
+				"status":               totals.Status,
+				"next_payment_date":    totals.NextPaymentDate,
+				"updated_at":           time.Now(),
+			}).Error; err != nil {
+			return fmt.Errorf("failed to update payment totals: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetStatusCounts counts the debts the user owns or is the contact of by status, along
+// with the payments awaiting the user's verification, in a single query
+func (r *debtListRepositoryGORM) GetStatusCounts(ctx context.Context, userID uuid.UUID) (*entities.DebtStatusCounts, error) {
+	var rows []struct {
+		Status string
+		Count  int64
 	}
-	return nil
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT debt_lists.status AS status, COUNT(*) AS count
+		FROM debt_lists
+		LEFT JOIN contacts ON debt_lists.contact_id = contacts.id
+		WHERE debt_lists.user_id = ? OR contacts.user_id_ref = ?
+		GROUP BY debt_lists.status
+		UNION ALL
+		SELECT ? AS status, COUNT(*) AS count
+		FROM debt_items
+		JOIN debt_lists ON debt_items.debt_list_id = debt_lists.id
+		LEFT JOIN contacts ON debt_lists.contact_id = contacts.id
+		WHERE ((debt_lists.user_id = ? AND debt_lists.debt_type = ?) OR (contacts.user_id_ref = ? AND debt_lists.debt_type = ?))
+			AND debt_items.status = ? AND debt_items.deleted_at IS NULL`,
+		userID, userID,
+		entities.PaymentStatusPending,
+		userID, entities.DebtTypeToReceive, userID, entities.DebtTypeToPay,
+		entities.PaymentStatusPending,
+	).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count debt lists by status: %w", err)
+	}
+
+	// Debt list statuses never include "pending", so it safely labels the verification count
+	counts := &entities.DebtStatusCounts{}
+	for _, row := range rows {
+		switch row.Status {
+		case "active":
+			counts.Active = row.Count
+		case "overdue":
+			counts.Overdue = row.Count
+		case "settled":
+			counts.Settled = row.Count
+		case entities.PaymentStatusPending:
+			counts.PendingVerification = row.Count
+		}
+	}
+	return counts, nil
+}
+
+// activityDirection is the debt type of a debt list as seen by the user bound to
+// its first placeholder, flipped when the user is the contact rather than the owner
+const activityDirection = `CASE WHEN debt_lists.user_id = ? THEN debt_lists.debt_type
+	WHEN debt_lists.debt_type = 'to_receive' THEN 'to_pay' ELSE 'to_receive' END`
+
+// GetActivitySummary aggregates, per currency, the debts created, payments made
+// and verification decisions between from and to on the debt lists the user owns
+// or is the contact of
+func (r *debtListRepositoryGORM) GetActivitySummary(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]entities.CurrencyActivity, error) {
+	var debtRows []struct {
+		Currency  string
+		Direction string
+		Count     int64
+		Amount    decimal.Decimal
+	}
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).Raw(`
+			SELECT UPPER(debt_lists.currency) AS currency, `+activityDirection+` AS direction,
+				COUNT(*) AS count, COALESCE(SUM(debt_lists.total_amount), 0) AS amount
+			FROM debt_lists
+			LEFT JOIN contacts ON debt_lists.contact_id = contacts.id
+			WHERE (debt_lists.user_id = ? OR contacts.user_id_ref = ?)
+				AND debt_lists.created_at >= ? AND debt_lists.created_at < ?
+			GROUP BY UPPER(debt_lists.currency), direction`,
+			userID, userID, userID, from, to,
+		).Scan(&debtRows).Error
+	}); err != nil {
+		return nil, fmt.Errorf("failed to aggregate debts created: %w", err)
+	}
+
+	var paymentRows []struct {
+		Currency  string
+		Direction string
+		Count     int64
+		Amount    decimal.Decimal
+	}
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).Raw(`
+			SELECT UPPER(debt_items.currency) AS currency, `+activityDirection+` AS direction,
+				COUNT(*) AS count, COALESCE(SUM(debt_items.amount), 0) AS amount
+			FROM debt_items
+			JOIN debt_lists ON debt_items.debt_list_id = debt_lists.id
+			LEFT JOIN contacts ON debt_lists.contact_id = contacts.id
+			WHERE (debt_lists.user_id = ? OR contacts.user_id_ref = ?)
+				AND debt_items.status = ? AND debt_items.payment_type = ? AND debt_items.deleted_at IS NULL
+				AND debt_items.payment_date >= ? AND debt_items.payment_date < ?
+			GROUP BY UPPER(debt_items.currency), direction`,
+			userID, userID, userID,
+			entities.PaymentStatusCompleted, entities.PaymentTypePayment,
+			from, to,
+		).Scan(&paymentRows).Error
+	}); err != nil {
+		return nil, fmt.Errorf("failed to aggregate payments: %w", err)
+	}
+
+	var verificationRows []struct {
+		Currency string
+		Status   string
+		Count    int64
+	}
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).Raw(`
+			SELECT UPPER(debt_items.currency) AS currency, debt_items.status AS status, COUNT(*) AS count
+			FROM debt_items
+			JOIN debt_lists ON debt_items.debt_list_id = debt_lists.id
+			LEFT JOIN contacts ON debt_lists.contact_id = contacts.id
+			WHERE (debt_lists.user_id = ? OR contacts.user_id_ref = ?)
+				AND debt_items.deleted_at IS NULL
+				AND CASE WHEN debt_items.status = ? THEN debt_items.updated_at ELSE debt_items.verified_at END >= ?
+				AND CASE WHEN debt_items.status = ? THEN debt_items.updated_at ELSE debt_items.verified_at END < ?
+			GROUP BY UPPER(debt_items.currency), debt_items.status`,
+			userID, userID,
+			entities.PaymentStatusDisputed, from,
+			entities.PaymentStatusDisputed, to,
+		).Scan(&verificationRows).Error
+	}); err != nil {
+		return nil, fmt.Errorf("failed to aggregate verification outcomes: %w", err)
+	}
+
+	byCurrency := make(map[string]*entities.CurrencyActivity)
+	activityFor := func(currency string) *entities.CurrencyActivity {
+		activity, ok := byCurrency[currency]
+		if !ok {
+			activity = &entities.CurrencyActivity{Currency: currency}
+			byCurrency[currency] = activity
+		}
+		return activity
+	}
+	for _, row := range debtRows {
+		activity := activityFor(row.Currency)
+		activity.DebtsCreated += row.Count
+		if row.Direction == entities.DebtTypeToReceive {
+			activity.AmountLent = activity.AmountLent.Add(row.Amount)
+		} else {
+			activity.AmountBorrowed = activity.AmountBorrowed.Add(row.Amount)
+		}
+	}
+	for _, row := range paymentRows {
+		activity := activityFor(row.Currency)
+		if row.Direction == entities.DebtTypeToReceive {
+			activity.PaymentsReceived += row.Count
+			activity.AmountReceived = activity.AmountReceived.Add(row.Amount)
+		} else {
+			activity.PaymentsMade += row.Count
+			activity.AmountPaid = activity.AmountPaid.Add(row.Amount)
+		}
+	}
+	for _, row := range verificationRows {
+		activity := activityFor(row.Currency)
+		switch row.Status {
+		case entities.PaymentStatusCompleted:
+			activity.PaymentsVerified += row.Count
+		case entities.PaymentStatusRejected:
+			activity.PaymentsRejected += row.Count
+		case entities.PaymentStatusDisputed:
+			activity.PaymentsDisputed += row.Count
+		}
+	}
+
+	activities := make([]entities.CurrencyActivity, 0, len(byCurrency))
+	for _, activity := range byCurrency {
+		activities = append(activities, *activity)
+	}
+	sort.Slice(activities, func(i, j int) bool {
+		return activities[i].Currency < activities[j].Currency
+	})
+	return activities, nil
+}
+
+// GetPaymentMethodStats counts and totals the completed payments on the debt lists
+// the user owns or is the contact of, per payment method and currency
+func (r *debtListRepositoryGORM) GetPaymentMethodStats(ctx context.Context, userID uuid.UUID) ([]entities.PaymentMethodStats, error) {
+	stats := []entities.PaymentMethodStats{}
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).Raw(`
+			SELECT debt_items.payment_method AS payment_method, UPPER(debt_items.currency) AS currency,
+				COUNT(*) AS count, COALESCE(SUM(debt_items.amount), 0) AS total_amount
+			FROM debt_items
+			JOIN debt_lists ON debt_items.debt_list_id = debt_lists.id
+			LEFT JOIN contacts ON debt_lists.contact_id = contacts.id
+			WHERE (debt_lists.user_id = ? OR contacts.user_id_ref = ?)
+				AND debt_items.status = ? AND debt_items.payment_type = ? AND debt_items.deleted_at IS NULL
+			GROUP BY debt_items.payment_method, UPPER(debt_items.currency)
+			ORDER BY payment_method, currency`,
+			userID, userID, entities.PaymentStatusCompleted, entities.PaymentTypePayment,
+		).Scan(&stats).Error
+	}); err != nil {
+		return nil, fmt.Errorf("failed to aggregate payment methods: %w", err)
+	}
+	return stats, nil
 }
 
 // entityToGORM converts a domain entity to GORM model
 func (r *debtListRepositoryGORM) entityToGORM(debtList *entities.DebtList) *models.DebtList {
+	// Debt lists built without a rounding mode keep the original behaviour
+	roundingMode := debtList.RoundingMode
+	if roundingMode == "" {
+		roundingMode = entities.RoundingModeLast
+	}
+
 	return &models.DebtList{
 		ID:                  debtList.ID,
 		UserID:              debtList.UserID,
@@ -257,6 +785,8 @@ func (r *debtListRepositoryGORM) entityToGORM(debtList *entities.DebtList) *mode
 		NextPaymentDate:     debtList.NextPaymentDate,
 		InstallmentPlan:     debtList.InstallmentPlan,
 		NumberOfPayments:    debtList.NumberOfPayments,
+		PaymentWeekday:      debtList.PaymentWeekday,
+		RoundingMode:        roundingMode,
 		Description:         debtList.Description,
 		Notes:               debtList.Notes,
 		CreatedAt:           debtList.CreatedAt,
@@ -281,6 +811,8 @@ func (r *debtListRepositoryGORM) gormToEntity(gormDebtList *models.DebtList) *en
 		NextPaymentDate:     gormDebtList.NextPaymentDate,
 		InstallmentPlan:     gormDebtList.InstallmentPlan,
 		NumberOfPayments:    gormDebtList.NumberOfPayments,
+		PaymentWeekday:      gormDebtList.PaymentWeekday,
+		RoundingMode:        gormDebtList.RoundingMode,
 		Description:         gormDebtList.Description,
 		Notes:               gormDebtList.Notes,
 		CreatedAt:           gormDebtList.CreatedAt,
@@ -289,34 +821,12 @@ func (r *debtListRepositoryGORM) gormToEntity(gormDebtList *models.DebtList) *en
 }
 
 // gormToResponseEntity converts a GORM model to response entity with relations
-func (r *debtListRepositoryGORM) gormToResponseEntity(ctx context.Context, gormDebtList *models.DebtList, userID uuid.UUID) *entities.DebtListResponse {
-	// Get user-specific contact information from UserContact
-	var contactResponse entities.ContactResponse
-	userContact, err := r.contactRepo.GetUserContactRelation(ctx, userID, gormDebtList.ContactID)
-	if err == nil && userContact != nil {
-		// User has this contact with custom information
-		contactResponse = entities.ContactResponse{
-			ID:        gormDebtList.Contact.ID,
-			Name:      userContact.Name,
-			Email:     userContact.Email,
-			Phone:     userContact.Phone,
-			Notes:     userContact.Notes,
-			IsUser:    gormDebtList.Contact.IsUser,
-			UserIDRef: gormDebtList.Contact.UserIDRef,
-			CreatedAt: userContact.CreatedAt,
-			UpdatedAt: userContact.UpdatedAt,
-		}
-	} else {
-		// Fallback to basic contact info (shouldn't happen in normal flow)
-		contactResponse = entities.ContactResponse{
-			ID:        gormDebtList.Contact.ID,
-			Name:      "Unknown",
-			IsUser:    gormDebtList.Contact.IsUser,
-			UserIDRef: gormDebtList.Contact.UserIDRef,
-			CreatedAt: gormDebtList.Contact.CreatedAt,
-			UpdatedAt: gormDebtList.Contact.UpdatedAt,
-		}
+func (r *debtListRepositoryGORM) gormToResponseEntity(ctx context.Context, gormDebtList *models.DebtList, userID uuid.UUID) (*entities.DebtListResponse, error) {
+	contactResponse, err := r.resolveContactResponse(ctx, gormDebtList, userID)
+	if err != nil {
+		return nil, err
 	}
+	contactResponse.ApplyNameSource(r.contactNameSource)
 
 	// Convert payments
 	payments := make([]entities.DebtItem, len(gormDebtList.Payments))
@@ -328,8 +838,11 @@ func (r *debtListRepositoryGORM) gormToResponseEntity(ctx context.Context, gormD
 			Currency:      payment.Currency,
 			PaymentDate:   payment.PaymentDate,
 			PaymentMethod: payment.PaymentMethod,
+			PaymentType:   payment.PaymentType,
 			Description:   payment.Description,
+			Reference:     payment.Reference,
 			Status:        payment.Status,
+			CreatedBy:     payment.CreatedBy,
 			CreatedAt:     payment.CreatedAt,
 			UpdatedAt:     payment.UpdatedAt,
 		}
@@ -350,13 +863,56 @@ func (r *debtListRepositoryGORM) gormToResponseEntity(ctx context.Context, gormD
 		NextPaymentDate:     gormDebtList.NextPaymentDate,
 		InstallmentPlan:     gormDebtList.InstallmentPlan,
 		NumberOfPayments:    gormDebtList.NumberOfPayments,
+		PaymentWeekday:      gormDebtList.PaymentWeekday,
+		RoundingMode:        gormDebtList.RoundingMode,
 		Description:         gormDebtList.Description,
 		Notes:               gormDebtList.Notes,
 		CreatedAt:           gormDebtList.CreatedAt,
 		UpdatedAt:           gormDebtList.UpdatedAt,
 		Contact:             contactResponse,
 		Payments:            payments,
+	}, nil
+}
+
+// resolveContactResponse builds the contact as seen by userID. When the user's
+// UserContact relation has been removed, a placeholder is returned instead of
+// failing so the debt list stays readable.
+func (r *debtListRepositoryGORM) resolveContactResponse(ctx context.Context, gormDebtList *models.DebtList, userID uuid.UUID) (entities.ContactResponse, error) {
+	contact, err := r.contactRepo.GetContactWithUser(ctx, gormDebtList.ContactID)
+	if err != nil {
+		return entities.ContactResponse{}, fmt.Errorf("failed to resolve debt list contact: %w", err)
 	}
+
+	userContact, err := r.contactRepo.GetUserContactRelation(ctx, userID, gormDebtList.ContactID)
+	if err != nil {
+		if errors.Is(err, entities.ErrContactNotFound) {
+			return entities.ContactResponse{
+				ID:           gormDebtList.ContactID,
+				Name:         entities.UnknownContactName,
+				IsUser:       contact.IsUser,
+				UserIDRef:    contact.UserIDRef,
+				IsActiveUser: contact.IsActiveUser(),
+				VerifiedName: contact.VerifiedName(),
+				CreatedAt:    contact.CreatedAt,
+				UpdatedAt:    contact.UpdatedAt,
+			}, nil
+		}
+		return entities.ContactResponse{}, fmt.Errorf("failed to resolve debt list contact: %w", err)
+	}
+
+	return entities.ContactResponse{
+		ID:           gormDebtList.ContactID,
+		Name:         userContact.Name,
+		Email:        userContact.Email,
+		Phone:        userContact.Phone,
+		Notes:        userContact.Notes,
+		IsUser:       contact.IsUser,
+		UserIDRef:    contact.UserIDRef,
+		IsActiveUser: contact.IsActiveUser(),
+		VerifiedName: contact.VerifiedName(),
+		CreatedAt:    userContact.CreatedAt,
+		UpdatedAt:    userContact.UpdatedAt,
+	}, nil
 }
 
 
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/domain/interfaces"
+	"pay-your-dues/internal/models"
+)
+
+// debtProposalRepositoryGORM implements the DebtProposalRepository interface using GORM
+type debtProposalRepositoryGORM struct {
+	db *gorm.DB
+}
+
+// NewDebtProposalRepositoryGORM creates a new debt proposal repository with GORM
+func NewDebtProposalRepositoryGORM(db *gorm.DB) interfaces.DebtProposalRepository {
+	return &debtProposalRepositoryGORM{
+		db: db,
+	}
+}
+
+func (r *debtProposalRepositoryGORM) Create(ctx context.Context, proposal *entities.DebtProposal) error {
+	gormProposal := r.entityToGORM(proposal)
+	if err := r.db.WithContext(ctx).Create(gormProposal).Error; err != nil {
+		return fmt.Errorf("failed to create debt proposal: %w", err)
+	}
+	proposal.CreatedAt = gormProposal.CreatedAt
+	proposal.UpdatedAt = gormProposal.UpdatedAt
+	return nil
+}
+
+func (r *debtProposalRepositoryGORM) GetByID(ctx context.Context, id uuid.UUID) (*entities.DebtProposal, error) {
+	var gormProposal models.DebtProposal
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&gormProposal).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrDebtProposalNotFound
+		}
+		return nil, fmt.Errorf("failed to get debt proposal: %w", err)
+	}
+	return r.gormToEntity(&gormProposal), nil
+}
+
+// GetByUserID returns proposals the user has sent or received, newest first
+func (r *debtProposalRepositoryGORM) GetByUserID(ctx context.Context, userID uuid.UUID) ([]entities.DebtProposal, error) {
+	var gormProposals []models.DebtProposal
+	if err := r.db.WithContext(ctx).
+		Where("proposer_id = ? OR recipient_id = ?", userID, userID).
+		Order("created_at DESC").
+		Find(&gormProposals).Error; err != nil {
+		return nil, fmt.Errorf("failed to get debt proposals: %w", err)
+	}
+
+	proposals := make([]entities.DebtProposal, len(gormProposals))
+	for i, gormProposal := range gormProposals {
+		proposals[i] = *r.gormToEntity(&gormProposal)
+	}
+	return proposals, nil
+}
+
+func (r *debtProposalRepositoryGORM) Respond(ctx context.Context, id uuid.UUID, status string, debtListID *uuid.UUID) error {
+	now := time.Now()
+	result := r.db.WithContext(ctx).Model(&models.DebtProposal{}).
+		Where("id = ? AND status = ?", id, entities.DebtProposalStatusPending).
+		Updates(map[string]interface{}{
+			"status":       status,
+			"debt_list_id": debtListID,
+			"responded_at": now,
+			"updated_at":   now,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update debt proposal: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrDebtProposalNotPending
+	}
+	return nil
+}
+
+// SetDebtList links an accepted proposal to the debt list created from it
+func (r *debtProposalRepositoryGORM) SetDebtList(ctx context.Context, id uuid.UUID, debtListID uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Model(&models.DebtProposal{}).
+		Where("id = ? AND status = ?", id, entities.DebtProposalStatusAccepted).
+		Updates(map[string]interface{}{
+			"debt_list_id": debtListID,
+			"updated_at":   time.Now(),
+		}).Error; err != nil {
+		return fmt.Errorf("failed to link debt proposal to debt list: %w", err)
+	}
+	return nil
+}
+
+// Reopen puts an accepted proposal that has no debt list yet back to pending,
+// for when creating its debt list failed
+func (r *debtProposalRepositoryGORM) Reopen(ctx context.Context, id uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Model(&models.DebtProposal{}).
+		Where("id = ? AND status = ? AND debt_list_id IS NULL", id, entities.DebtProposalStatusAccepted).
+		Updates(map[string]interface{}{
+			"status":       entities.DebtProposalStatusPending,
+			"responded_at": nil,
+			"updated_at":   time.Now(),
+		}).Error; err != nil {
+		return fmt.Errorf("failed to reopen debt proposal: %w", err)
+	}
+	return nil
+}
+
+// entityToGORM converts a domain entity to GORM model
+func (r *debtProposalRepositoryGORM) entityToGORM(proposal *entities.DebtProposal) *models.DebtProposal {
+	return &models.DebtProposal{
+		ID:               proposal.ID,
+		ProposerID:       proposal.ProposerID,
+		RecipientID:      proposal.RecipientID,
+		ContactID:        proposal.ContactID,
+		DebtType:         proposal.DebtType,
+		TotalAmount:      proposal.TotalAmount,
+		Currency:         proposal.Currency,
+		DueDate:          proposal.DueDate,
+		InstallmentPlan:  proposal.InstallmentPlan,
+		NumberOfPayments: proposal.NumberOfPayments,
+		Description:      proposal.Description,
+		Notes:            proposal.Notes,
+		Status:           proposal.Status,
+		DebtListID:       proposal.DebtListID,
+		RespondedAt:      proposal.RespondedAt,
+		CreatedAt:        proposal.CreatedAt,
+		UpdatedAt:        proposal.UpdatedAt,
+	}
+}
+
+// gormToEntity converts a GORM model to domain entity
+func (r *debtProposalRepositoryGORM) gormToEntity(gormProposal *models.DebtProposal) *entities.DebtProposal {
+	return &entities.DebtProposal{
+		ID:               gormProposal.ID,
+		ProposerID:       gormProposal.ProposerID,
+		RecipientID:      gormProposal.RecipientID,
+		ContactID:        gormProposal.ContactID,
+		DebtType:         gormProposal.DebtType,
+		TotalAmount:      gormProposal.TotalAmount,
+		Currency:         gormProposal.Currency,
+		DueDate:          gormProposal.DueDate,
+		InstallmentPlan:  gormProposal.InstallmentPlan,
+		NumberOfPayments: gormProposal.NumberOfPayments,
+		Description:      gormProposal.Description,
+		Notes:            gormProposal.Notes,
+		Status:           gormProposal.Status,
+		DebtListID:       gormProposal.DebtListID,
+		RespondedAt:      gormProposal.RespondedAt,
+		CreatedAt:        gormProposal.CreatedAt,
+		UpdatedAt:        gormProposal.UpdatedAt,
+	}
+}
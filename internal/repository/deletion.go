@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Entity types of the tombstones in models.Deletion
+const (
+	deletionEntityDebtList = "debt_list"
+	deletionEntityPayment  = "payment"
+)
+
+// modifiedSince scopes a debt list query to the lists updated after since, or
+// whose payments were created, updated, soft-deleted or purged after since
+func modifiedSince(since time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(`(debt_lists.updated_at > ?
+			OR EXISTS (SELECT 1 FROM debt_items WHERE debt_items.debt_list_id = debt_lists.id AND (debt_items.updated_at > ? OR debt_items.deleted_at > ?))
+			OR EXISTS (SELECT 1 FROM deletions WHERE deletions.debt_list_id = debt_lists.id AND deletions.entity_type = ? AND deletions.deleted_at > ?))`,
+			since, since, since, deletionEntityPayment, since)
+	}
+}
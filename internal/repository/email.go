@@ -0,0 +1,24 @@
+package repository
+
+import "strings"
+
+// normalizeEmail returns the form an email is stored and looked up in. With
+// case-insensitive emails it is trimmed and lower-cased, so Bob@x.com and
+// bob@x.com are the same address whatever the database collation. Lookups
+// compare the column as stored, so emails stored before must be normalized
+// once with database.NormalizeEmails.
+func normalizeEmail(email string, caseInsensitive bool) string {
+	if !caseInsensitive {
+		return email
+	}
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// normalizeEmailPtr is normalizeEmail for optional emails
+func normalizeEmailPtr(email *string, caseInsensitive bool) *string {
+	if email == nil {
+		return nil
+	}
+	normalized := normalizeEmail(*email, caseInsensitive)
+	return &normalized
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/domain/interfaces"
+	"pay-your-dues/internal/models"
+)
+
+// pendingDeletionRepositoryGORM implements the PendingDeletionRepository interface using GORM
+type pendingDeletionRepositoryGORM struct {
+	db *gorm.DB
+}
+
+// NewPendingDeletionRepositoryGORM creates a new pending deletion repository with GORM
+func NewPendingDeletionRepositoryGORM(db *gorm.DB) interfaces.PendingDeletionRepository {
+	return &pendingDeletionRepositoryGORM{
+		db: db,
+	}
+}
+
+func (r *pendingDeletionRepositoryGORM) Create(ctx context.Context, pendingDeletion *entities.PendingDeletion) error {
+	gormPendingDeletion := r.entityToGORM(pendingDeletion)
+	if err := r.db.WithContext(ctx).Create(gormPendingDeletion).Error; err != nil {
+		return fmt.Errorf("failed to create pending deletion: %w", err)
+	}
+	pendingDeletion.CreatedAt = gormPendingDeletion.CreatedAt
+	pendingDeletion.UpdatedAt = gormPendingDeletion.UpdatedAt
+	return nil
+}
+
+func (r *pendingDeletionRepositoryGORM) GetDue(ctx context.Context, now time.Time, limit int) ([]entities.PendingDeletion, error) {
+	var gormPendingDeletions []models.PendingDeletion
+	err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Where("dead_lettered_at IS NULL AND next_attempt_at <= ?", now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&gormPendingDeletions).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due pending deletions: %w", err)
+	}
+
+	pendingDeletions := make([]entities.PendingDeletion, len(gormPendingDeletions))
+	for i, gormPendingDeletion := range gormPendingDeletions {
+		pendingDeletions[i] = *r.gormToEntity(&gormPendingDeletion)
+	}
+	return pendingDeletions, nil
+}
+
+func (r *pendingDeletionRepositoryGORM) Update(ctx context.Context, pendingDeletion *entities.PendingDeletion) error {
+	pendingDeletion.UpdatedAt = time.Now()
+	if err := r.db.WithContext(ctx).Model(&models.PendingDeletion{}).
+		Where("id = ?", pendingDeletion.ID).
+		Updates(map[string]interface{}{
+			"attempts":         pendingDeletion.Attempts,
+			"last_error":       pendingDeletion.LastError,
+			"next_attempt_at":  pendingDeletion.NextAttemptAt,
+			"dead_lettered_at": pendingDeletion.DeadLetteredAt,
+			"updated_at":       pendingDeletion.UpdatedAt,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to update pending deletion: %w", err)
+	}
+	return nil
+}
+
+func (r *pendingDeletionRepositoryGORM) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Delete(&models.PendingDeletion{}, "id = ?", id).Error; err != nil {
+		return fmt.Errorf("failed to delete pending deletion: %w", err)
+	}
+	return nil
+}
+
+// entityToGORM converts a domain entity to GORM model
+func (r *pendingDeletionRepositoryGORM) entityToGORM(pendingDeletion *entities.PendingDeletion) *models.PendingDeletion {
+	return &models.PendingDeletion{
+		ID:             pendingDeletion.ID,
+		FileURL:        pendingDeletion.FileURL,
+		Attempts:       pendingDeletion.Attempts,
+		LastError:      pendingDeletion.LastError,
+		NextAttemptAt:  pendingDeletion.NextAttemptAt,
+		DeadLetteredAt: pendingDeletion.DeadLetteredAt,
+		CreatedAt:      pendingDeletion.CreatedAt,
+		UpdatedAt:      pendingDeletion.UpdatedAt,
+	}
+}
+
+// gormToEntity converts a GORM model to domain entity
+func (r *pendingDeletionRepositoryGORM) gormToEntity(gormPendingDeletion *models.PendingDeletion) *entities.PendingDeletion {
+	return &entities.PendingDeletion{
+		ID:             gormPendingDeletion.ID,
+		FileURL:        gormPendingDeletion.FileURL,
+		Attempts:       gormPendingDeletion.Attempts,
+		LastError:      gormPendingDeletion.LastError,
+		NextAttemptAt:  gormPendingDeletion.NextAttemptAt,
+		DeadLetteredAt: gormPendingDeletion.DeadLetteredAt,
+		CreatedAt:      gormPendingDeletion.CreatedAt,
+		UpdatedAt:      gormPendingDeletion.UpdatedAt,
+	}
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Reads failing with a transient database error are attempted up to
+// maxReadAttempts times, waiting readRetryBackoff before the first retry and
+// doubling the wait after each one
+const (
+	maxReadAttempts  = 3
+	readRetryBackoff = 50 * time.Millisecond
+)
+
+// retryRead runs an idempotent read, retrying it with backoff while it fails
+// with a transient database error. Writes are not retried here; retrying them
+// safely is up to the transaction that wraps them.
+func retryRead(ctx context.Context, read func() error) error {
+	backoff := readRetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := read()
+		if err == nil || attempt == maxReadAttempts || !isTransientDBError(err) {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// isTransientDBError reports whether err is a failure that may succeed when
+// retried: a broken connection, a deadlock or a serialization failure
+func isTransientDBError(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	// Postgres reports its error class through SQLSTATE codes
+	var sqlStateErr interface{ SQLState() string }
+	if errors.As(err, &sqlStateErr) {
+		code := sqlStateErr.SQLState()
+		switch {
+		case code == "40001", // serialization_failure
+			code == "40P01",               // deadlock_detected
+			strings.HasPrefix(code, "08"): // connection exceptions
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// textTimestampLayouts are the layouts a timestamp may come back in as text. Drivers
+// such as SQLite's only convert a column to time.Time when it is selected directly,
+// so aggregates like MAX(payment_date) arrive as strings.
+var textTimestampLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// nullTimestamp scans a nullable timestamp whether the driver returns it as a
+// time.Time or as text
+type nullTimestamp struct {
+	Time  time.Time
+	Valid bool
+}
+
+// Scan implements sql.Scanner
+func (t *nullTimestamp) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		t.Time, t.Valid = time.Time{}, false
+		return nil
+	case time.Time:
+		t.Time, t.Valid = v, true
+		return nil
+	case []byte:
+		return t.parse(string(v))
+	case string:
+		return t.parse(v)
+	}
+	return fmt.Errorf("cannot scan %T into a timestamp", value)
+}
+
+// Value implements driver.Valuer
+func (t nullTimestamp) Value() (driver.Value, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+	return t.Time, nil
+}
+
+func (t *nullTimestamp) parse(text string) error {
+	for _, layout := range textTimestampLayouts {
+		if parsed, err := time.Parse(layout, text); err == nil {
+			t.Time, t.Valid = parsed, true
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot parse %q as a timestamp", text)
+}
+
+// Ptr returns the timestamp, or nil when it is NULL
+func (t nullTimestamp) Ptr() *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
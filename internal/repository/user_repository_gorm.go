@@ -14,14 +14,31 @@ import (
 
 // userRepositoryGORM implements the UserRepository interface using GORM
 type userRepositoryGORM struct {
-	db *gorm.DB
+	db                   *gorm.DB
+	caseInsensitiveEmail bool
+}
+
+// UserRepositoryOption configures optional behaviour of the user repository
+type UserRepositoryOption func(*userRepositoryGORM)
+
+// WithUserCaseInsensitiveEmails sets whether user emails are stored lower-cased
+// and looked up ignoring case. It is enabled by default.
+func WithUserCaseInsensitiveEmails(enabled bool) UserRepositoryOption {
+	return func(r *userRepositoryGORM) {
+		r.caseInsensitiveEmail = enabled
+	}
 }
 
 // NewUserRepositoryGORM creates a new user repository with GORM
-func NewUserRepositoryGORM(db *gorm.DB) interfaces.UserRepository {
-	return &userRepositoryGORM{
-		db: db,
+func NewUserRepositoryGORM(db *gorm.DB, opts ...UserRepositoryOption) interfaces.UserRepository {
+	r := &userRepositoryGORM{
+		db:                   db,
+		caseInsensitiveEmail: true,
+	}
+	for _, opt := range opts {
+		opt(r)
 	}
+	return r
 }
 
 func (r *userRepositoryGORM) Create(ctx context.Context, user *entities.User) error {
@@ -31,6 +48,7 @@ func (r *userRepositoryGORM) Create(ctx context.Context, user *entities.User) er
 	}
 	// Update the entity with the created ID if it was auto-generated
 	user.ID = gormUser.ID
+	user.Email = gormUser.Email
 	user.CreatedAt = gormUser.CreatedAt
 	user.UpdatedAt = gormUser.UpdatedAt
 	return nil
@@ -38,7 +56,9 @@ func (r *userRepositoryGORM) Create(ctx context.Context, user *entities.User) er
 
 func (r *userRepositoryGORM) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
 	var gormUser models.User
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&gormUser).Error; err != nil {
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).Where("id = ?", id).First(&gormUser).Error
+	}); err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, entities.ErrUserNotFound
 		}
@@ -49,7 +69,9 @@ func (r *userRepositoryGORM) GetByID(ctx context.Context, id uuid.UUID) (*entiti
 
 func (r *userRepositoryGORM) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
 	var gormUser models.User
-	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&gormUser).Error; err != nil {
+	if err := retryRead(ctx, func() error {
+		return r.db.WithContext(ctx).Where("email = ?", normalizeEmail(email, r.caseInsensitiveEmail)).First(&gormUser).Error
+	}); err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, entities.ErrUserNotFound
 		}
@@ -63,7 +85,8 @@ func (r *userRepositoryGORM) Update(ctx context.Context, user *entities.User) er
 	if err := r.db.WithContext(ctx).Save(gormUser).Error; err != nil {
 		return fmt.Errorf("failed to update user: %w", err)
 	}
-	// Update the entity with the updated timestamp
+	// Update the entity with the stored email and updated timestamp
+	user.Email = gormUser.Email
 	user.UpdatedAt = gormUser.UpdatedAt
 	return nil
 }
@@ -81,7 +104,7 @@ func (r *userRepositoryGORM) Delete(ctx context.Context, id uuid.UUID) error {
 
 func (r *userRepositoryGORM) ExistsByEmail(ctx context.Context, email string) (bool, error) {
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&models.User{}).Where("email = ?", email).Count(&count).Error; err != nil {
+	if err := r.db.WithContext(ctx).Model(&models.User{}).Where("email = ?", normalizeEmail(email, r.caseInsensitiveEmail)).Count(&count).Error; err != nil {
 		return false, fmt.Errorf("failed to check if user exists by email: %w", err)
 	}
 	return count > 0, nil
@@ -100,7 +123,7 @@ func (r *userRepositoryGORM) ExistsByPhone(ctx context.Context, phone string) (b
 func (r *userRepositoryGORM) entityToGORM(user *entities.User) *models.User {
 	return &models.User{
 		ID:           user.ID,
-		Email:        user.Email,
+		Email:        normalizeEmail(user.Email, r.caseInsensitiveEmail),
 		PasswordHash: user.PasswordHash,
 		FirstName:    user.FirstName,
 		LastName:     user.LastName,
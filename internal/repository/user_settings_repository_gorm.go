@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/domain/interfaces"
+	"pay-your-dues/internal/models"
+)
+
+// userSettingsRepositoryGORM implements the UserSettingsRepository interface using GORM
+type userSettingsRepositoryGORM struct {
+	db *gorm.DB
+}
+
+// NewUserSettingsRepositoryGORM creates a new user settings repository with GORM
+func NewUserSettingsRepositoryGORM(db *gorm.DB) interfaces.UserSettingsRepository {
+	return &userSettingsRepositoryGORM{
+		db: db,
+	}
+}
+
+func (r *userSettingsRepositoryGORM) GetByUserID(ctx context.Context, userID uuid.UUID) (*entities.UserSettings, error) {
+	var gormSettings models.UserSettings
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&gormSettings).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrUserSettingsNotFound
+		}
+		return nil, fmt.Errorf("failed to get user settings: %w", err)
+	}
+	return r.gormToEntity(&gormSettings), nil
+}
+
+// Upsert creates the settings row for a user or updates it if one already exists
+func (r *userSettingsRepositoryGORM) Upsert(ctx context.Context, settings *entities.UserSettings) error {
+	if settings.ID == uuid.Nil {
+		settings.ID = uuid.New()
+	}
+	gormSettings := r.entityToGORM(settings)
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		UpdateAll: true,
+	}).Create(gormSettings).Error; err != nil {
+		return fmt.Errorf("failed to save user settings: %w", err)
+	}
+	settings.CreatedAt = gormSettings.CreatedAt
+	settings.UpdatedAt = gormSettings.UpdatedAt
+	return nil
+}
+
+// entityToGORM converts a domain entity to GORM model
+func (r *userSettingsRepositoryGORM) entityToGORM(settings *entities.UserSettings) *models.UserSettings {
+	return &models.UserSettings{
+		ID:                     settings.ID,
+		UserID:                 settings.UserID,
+		NotificationEmail:      settings.NotificationEmail,
+		NotificationSMS:        settings.NotificationSMS,
+		NotificationFacebook:   settings.NotificationFacebook,
+		DefaultCurrency:        settings.DefaultCurrency,
+		DefaultInstallmentPlan: settings.DefaultInstallmentPlan,
+		Timezone:               settings.Timezone,
+		EmailReminders:         &settings.EmailReminders,
+		ReminderLeadDays:       &settings.ReminderLeadDays,
+		OverdueAlerts:          &settings.OverdueAlerts,
+		VerificationAlerts:     &settings.VerificationAlerts,
+		OverdueAcknowledgedAt:  settings.OverdueAcknowledgedAt,
+		CreatedAt:              settings.CreatedAt,
+		UpdatedAt:              settings.UpdatedAt,
+	}
+}
+
+// gormToEntity converts a GORM model to domain entity
+func (r *userSettingsRepositoryGORM) gormToEntity(gormSettings *models.UserSettings) *entities.UserSettings {
+	settings := &entities.UserSettings{
+		ID:                     gormSettings.ID,
+		UserID:                 gormSettings.UserID,
+		NotificationEmail:      gormSettings.NotificationEmail,
+		NotificationSMS:        gormSettings.NotificationSMS,
+		NotificationFacebook:   gormSettings.NotificationFacebook,
+		DefaultCurrency:        gormSettings.DefaultCurrency,
+		DefaultInstallmentPlan: gormSettings.DefaultInstallmentPlan,
+		Timezone:               gormSettings.Timezone,
+		EmailReminders:         true,
+		ReminderLeadDays:       entities.DefaultReminderLeadDays,
+		OverdueAlerts:          true,
+		VerificationAlerts:     true,
+		OverdueAcknowledgedAt:  gormSettings.OverdueAcknowledgedAt,
+		CreatedAt:              gormSettings.CreatedAt,
+		UpdatedAt:              gormSettings.UpdatedAt,
+	}
+	if gormSettings.EmailReminders != nil {
+		settings.EmailReminders = *gormSettings.EmailReminders
+	}
+	if gormSettings.ReminderLeadDays != nil {
+		settings.ReminderLeadDays = *gormSettings.ReminderLeadDays
+	}
+	if gormSettings.OverdueAlerts != nil {
+		settings.OverdueAlerts = *gormSettings.OverdueAlerts
+	}
+	if gormSettings.VerificationAlerts != nil {
+		settings.VerificationAlerts = *gormSettings.VerificationAlerts
+	}
+	return settings
+}
@@ -2,6 +2,10 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"time"
 
@@ -20,6 +24,29 @@ type authService struct {
 	contactService   interfaces.ContactService
 	jwtSecret        string
 	jwtExpiry        time.Duration
+	apiKeyRepo       interfaces.APIKeyRepository
+	jwtIssuer        string
+	jwtAudience      string
+}
+
+// AuthServiceOption configures optional dependencies of the auth service
+type AuthServiceOption func(*authService)
+
+// WithAPIKeyRepository enables issuing and authenticating with API keys
+func WithAPIKeyRepository(apiKeyRepo interfaces.APIKeyRepository) AuthServiceOption {
+	return func(s *authService) {
+		s.apiKeyRepo = apiKeyRepo
+	}
+}
+
+// WithJWTIssuerAndAudience scopes tokens to this service. Issued tokens carry the
+// iss and aud claims, and tokens whose claims do not match are rejected. An empty
+// issuer or audience leaves that claim unset and unchecked.
+func WithJWTIssuerAndAudience(issuer, audience string) AuthServiceOption {
+	return func(s *authService) {
+		s.jwtIssuer = issuer
+		s.jwtAudience = audience
+	}
 }
 
 // NewAuthService creates a new auth service
@@ -28,18 +55,23 @@ func NewAuthService(
 	contactService interfaces.ContactService,
 	jwtSecret string,
 	jwtExpiry string,
+	opts ...AuthServiceOption,
 ) (interfaces.AuthService, error) {
 	duration, err := time.ParseDuration(jwtExpiry)
 	if err != nil {
 		return nil, fmt.Errorf("invalid JWT expiry duration: %w", err)
 	}
 
-	return &authService{
+	s := &authService{
 		userRepo:       userRepo,
 		contactService: contactService,
 		jwtSecret:      jwtSecret,
 		jwtExpiry:      duration,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
 }
 
 func (s *authService) Register(ctx context.Context, req *entities.CreateUserRequest) (*entities.RegisterResponse, error) {
@@ -145,47 +177,108 @@ func (s *authService) Login(ctx context.Context, req *entities.LoginRequest) (*e
 }
 
 func (s *authService) ValidateToken(ctx context.Context, tokenString string) (uuid.UUID, error) {
+	claims, err := s.ValidateTokenClaims(ctx, tokenString)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return claims.UserID, nil
+}
+
+// ValidateTokenClaims validates a JWT and returns its user and scope. Tokens issued
+// before scopes existed carry no scope claim and are treated as full access.
+func (s *authService) ValidateTokenClaims(ctx context.Context, tokenString string) (*entities.TokenClaims, error) {
+	var parserOptions []jwt.ParserOption
+	if s.jwtIssuer != "" {
+		parserOptions = append(parserOptions, jwt.WithIssuer(s.jwtIssuer))
+	}
+	if s.jwtAudience != "" {
+		parserOptions = append(parserOptions, jwt.WithAudience(s.jwtAudience))
+	}
+
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 		return []byte(s.jwtSecret), nil
-	})
+	}, parserOptions...)
 
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("failed to parse token: %w", err)
+		// Tokens issued for another service, or before claims were configured, are invalid here
+		if errors.Is(err, jwt.ErrTokenInvalidIssuer) || errors.Is(err, jwt.ErrTokenInvalidAudience) || errors.Is(err, jwt.ErrTokenRequiredClaimMissing) {
+			return nil, entities.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to parse token: %w", err)
 	}
 
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
 		userIDStr, ok := claims["user_id"].(string)
 		if !ok {
-			return uuid.Nil, entities.ErrInvalidToken
+			return nil, entities.ErrInvalidToken
 		}
 
 		userID, err := uuid.Parse(userIDStr)
 		if err != nil {
-			return uuid.Nil, fmt.Errorf("invalid user ID in token: %w", err)
+			return nil, fmt.Errorf("invalid user ID in token: %w", err)
 		}
 
 		// Check if token is expired
 		if exp, ok := claims["exp"].(float64); ok {
 			if time.Unix(int64(exp), 0).Before(time.Now()) {
-				return uuid.Nil, entities.ErrTokenExpired
+				return nil, entities.ErrTokenExpired
 			}
 		}
 
-		return userID, nil
+		scope := entities.ScopeFull
+		if claim, ok := claims["scope"]; ok {
+			scopeStr, ok := claim.(string)
+			if !ok || !entities.IsValidScope(scopeStr) {
+				return nil, entities.ErrInvalidToken
+			}
+			scope = scopeStr
+		}
+
+		return &entities.TokenClaims{
+			UserID: userID,
+			Scope:  scope,
+		}, nil
 	}
 
-	return uuid.Nil, entities.ErrInvalidToken
+	return nil, entities.ErrInvalidToken
 }
 
+// GenerateJWT issues a full-access token for the user
 func (s *authService) GenerateJWT(ctx context.Context, userID uuid.UUID) (string, error) {
+	return s.generateScopedJWT(userID, entities.ScopeFull, time.Now().Add(s.jwtExpiry))
+}
+
+// CreateReadOnlyToken issues a token that can read but not modify the user's data
+func (s *authService) CreateReadOnlyToken(ctx context.Context, userID uuid.UUID) (*entities.ScopedTokenResponse, error) {
+	expiresAt := time.Now().Add(s.jwtExpiry)
+	token, err := s.generateScopedJWT(userID, entities.ScopeRead, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.ScopedTokenResponse{
+		Token:     token,
+		Scope:     entities.ScopeRead,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (s *authService) generateScopedJWT(userID uuid.UUID, scope string, expiresAt time.Time) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id": userID.String(),
-		"exp":     time.Now().Add(s.jwtExpiry).Unix(),
+		"scope":   scope,
+		"exp":     expiresAt.Unix(),
 		"iat":     time.Now().Unix(),
 	}
+	if s.jwtIssuer != "" {
+		claims["iss"] = s.jwtIssuer
+	}
+	if s.jwtAudience != "" {
+		claims["aud"] = s.jwtAudience
+	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte(s.jwtSecret))
@@ -196,6 +289,121 @@ func (s *authService) GenerateJWT(ctx context.Context, userID uuid.UUID) (string
 	return tokenString, nil
 }
 
+func (s *authService) CreateAPIKey(ctx context.Context, userID uuid.UUID, req *entities.CreateAPIKeyRequest) (*entities.CreateAPIKeyResponse, error) {
+	if s.apiKeyRepo == nil {
+		return nil, errAPIKeysNotConfigured
+	}
+
+	if req.Name == "" {
+		return nil, fmt.Errorf("validation failed: %w", entities.ErrInvalidInput)
+	}
+
+	// Keys without explicit scopes get full access
+	scopes := req.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{entities.ScopeFull}
+	}
+	for _, scope := range scopes {
+		if !entities.IsValidScope(scope) {
+			return nil, fmt.Errorf("validation failed: %w", entities.ErrInvalidInput)
+		}
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+	key := entities.APIKeyPrefix + hex.EncodeToString(secret)
+
+	now := time.Now()
+	apiKey := &entities.APIKey{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      req.Name,
+		KeyPrefix: key[:len(entities.APIKeyPrefix)+8],
+		KeyHash:   hashAPIKey(key),
+		Scopes:    scopes,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.apiKeyRepo.Create(ctx, apiKey); err != nil {
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	return &entities.CreateAPIKeyResponse{
+		APIKeyResponse: apiKey.ToResponse(),
+		Key:            key,
+	}, nil
+}
+
+func (s *authService) GetAPIKeys(ctx context.Context, userID uuid.UUID) ([]entities.APIKeyResponse, error) {
+	if s.apiKeyRepo == nil {
+		return nil, errAPIKeysNotConfigured
+	}
+
+	apiKeys, err := s.apiKeyRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API keys: %w", err)
+	}
+
+	responses := make([]entities.APIKeyResponse, len(apiKeys))
+	for i := range apiKeys {
+		responses[i] = apiKeys[i].ToResponse()
+	}
+	return responses, nil
+}
+
+func (s *authService) RevokeAPIKey(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	if s.apiKeyRepo == nil {
+		return errAPIKeysNotConfigured
+	}
+
+	if err := s.apiKeyRepo.Revoke(ctx, id, userID); err != nil {
+		if errors.Is(err, entities.ErrAPIKeyNotFound) {
+			return entities.ErrAPIKeyNotFound
+		}
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	return nil
+}
+
+// ValidateAPIKey resolves an API key to its stored record, rejecting unknown and revoked keys
+func (s *authService) ValidateAPIKey(ctx context.Context, key string) (*entities.APIKey, error) {
+	if s.apiKeyRepo == nil || len(key) <= len(entities.APIKeyPrefix) || key[:len(entities.APIKeyPrefix)] != entities.APIKeyPrefix {
+		return nil, entities.ErrInvalidAPIKey
+	}
+
+	apiKey, err := s.apiKeyRepo.GetByHash(ctx, hashAPIKey(key))
+	if err != nil {
+		if errors.Is(err, entities.ErrAPIKeyNotFound) {
+			return nil, entities.ErrInvalidAPIKey
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	if apiKey.IsRevoked() {
+		return nil, entities.ErrInvalidAPIKey
+	}
+
+	if err := s.apiKeyRepo.UpdateLastUsed(ctx, apiKey.ID); err != nil {
+		// Log the error but don't fail authentication
+		logger := zerolog.Ctx(ctx)
+		logger.Warn().Err(err).Str("api_key_id", apiKey.ID.String()).Msg("Failed to record API key use")
+	}
+
+	return apiKey, nil
+}
+
+// errAPIKeysNotConfigured is returned when the service was built without an API key repository
+var errAPIKeysNotConfigured = errors.New("API keys are not configured")
+
+// hashAPIKey returns the hex SHA-256 digest stored in place of an API key. Keys are
+// random and long, so a fast hash is enough and lets keys be looked up by hash.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
 func (s *authService) validateCreateUserRequest(req *entities.CreateUserRequest) error {
 	if req.Email == "" {
 		return entities.ErrInvalidEmail
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -14,18 +15,67 @@ import (
 
 // contactService implements the ContactService interface
 type contactService struct {
-	contactRepo interfaces.ContactRepository
-	userRepo    interfaces.UserRepository
+	contactRepo        interfaces.ContactRepository
+	userRepo           interfaces.UserRepository
+	debtListRepo       interfaces.DebtListRepository
+	reciprocalContacts bool
+	contactNameSource  string
+	deletionPolicy     string
 }
 
-// NewContactService creates a new contact service
-func NewContactService(contactRepo interfaces.ContactRepository, userRepo interfaces.UserRepository) interfaces.ContactService {
-	return &contactService{
-		contactRepo: contactRepo,
-		userRepo:    userRepo,
+// ContactServiceOption configures optional behaviour of the contact service
+type ContactServiceOption func(*contactService)
+
+// WithReciprocalContacts sets whether contacts are linked to the users who own
+// their email, with a reciprocal contact created for that user. It is enabled by
+// default; when disabled, contacts are never linked across users.
+func WithReciprocalContacts(enabled bool) ContactServiceOption {
+	return func(s *contactService) {
+		s.reciprocalContacts = enabled
+	}
+}
+
+// WithContactNameSource sets which name is shown for contacts that are registered
+// users, see entities.ContactResponse.ApplyNameSource. Defaults to the name the
+// user saved for the contact.
+func WithContactNameSource(source string) ContactServiceOption {
+	return func(s *contactService) {
+		s.contactNameSource = source
+	}
+}
+
+// WithContactDeletionPolicy sets what happens when the user deletes a contact
+// they still have unsettled debts with, see entities.ContactDeletionPolicyBlock
+// and entities.ContactDeletionPolicyDetach. Defaults to blocking the deletion.
+func WithContactDeletionPolicy(policy string) ContactServiceOption {
+	return func(s *contactService) {
+		s.deletionPolicy = policy
+	}
+}
+
+// WithContactDebtListRepository sets the repository the blocking deletion policy
+// checks for unsettled debts. Without it, contacts are deleted as if detached.
+func WithContactDebtListRepository(debtListRepo interfaces.DebtListRepository) ContactServiceOption {
+	return func(s *contactService) {
+		s.debtListRepo = debtListRepo
 	}
 }
 
+// NewContactService creates a new contact service
+func NewContactService(contactRepo interfaces.ContactRepository, userRepo interfaces.UserRepository, opts ...ContactServiceOption) interfaces.ContactService {
+	s := &contactService{
+		contactRepo:        contactRepo,
+		userRepo:           userRepo,
+		reciprocalContacts: true,
+		contactNameSource:  entities.ContactNameSourceContact,
+		deletionPolicy:     entities.ContactDeletionPolicyBlock,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
 func (s *contactService) CreateContact(ctx context.Context, userID uuid.UUID, req *entities.CreateContactRequest) (*entities.ContactResponse, error) {
 	// Validate input
 	if err := s.validateCreateContactRequest(req); err != nil {
@@ -34,23 +84,30 @@ func (s *contactService) CreateContact(ctx context.Context, userID uuid.UUID, re
 
 	// Check if contact with same email already exists for this user
 	if req.Email != nil && *req.Email != "" {
-		exists, err := s.contactRepo.ExistsByEmailForUser(ctx, userID, *req.Email)
-		if err != nil {
-			return nil, fmt.Errorf("failed to check if contact exists: %w", err)
+		existing, err := s.contactRepo.GetUserContactByEmail(ctx, userID, *req.Email)
+		if err == nil {
+			return nil, &entities.DuplicateContactError{ExistingContactID: existing.ContactID}
 		}
-		if exists {
-			return nil, entities.ErrContactAlreadyExists
+		if !errors.Is(err, entities.ErrContactNotFound) {
+			return nil, fmt.Errorf("failed to check if contact exists: %w", err)
 		}
 	}
 
-	// Check if this contact is also a user (by email)
+	// Check if this contact is also a user (by email), unless linking is disabled
 	var isUser bool
 	var userIDRef *uuid.UUID
-	if req.Email != nil && *req.Email != "" {
+	if s.reciprocalContacts && req.Email != nil && *req.Email != "" {
 		user, err := s.userRepo.GetByEmail(ctx, *req.Email)
 		if err == nil {
-			isUser = true
-			userIDRef = &user.ID
+			// A user who blocked the creator is not linked back to them
+			blocked, err := s.contactRepo.IsBlockedBy(ctx, user.ID, userID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check if contact blocked the user: %w", err)
+			}
+			if !blocked {
+				isUser = true
+				userIDRef = &user.ID
+			}
 		} else if err != entities.ErrUserNotFound {
 			return nil, fmt.Errorf("failed to check if email belongs to user: %w", err)
 		}
@@ -126,24 +183,15 @@ func (s *contactService) GetContact(ctx context.Context, id uuid.UUID, userID uu
 		return nil, fmt.Errorf("failed to verify contact access: %w", err)
 	}
 
-	// Get the contact entity for IsUser and UserIDRef
-	contact, err := s.contactRepo.GetByID(ctx, id)
+	// Get the contact entity for IsUser and UserIDRef, with the linked user if any
+	contact, err := s.contactRepo.GetContactWithUser(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get contact: %w", err)
 	}
 
 	// Build and return ContactResponse combining both
-	return &entities.ContactResponse{
-		ID:        contact.ID,
-		Name:      userContact.Name,
-		Email:     userContact.Email,
-		Phone:     userContact.Phone,
-		Notes:     userContact.Notes,
-		IsUser:    contact.IsUser,
-		UserIDRef: contact.UserIDRef,
-		CreatedAt: userContact.CreatedAt,
-		UpdatedAt: userContact.UpdatedAt,
-	}, nil
+	response := entities.NewContactResponse(contact, userContact, s.contactNameSource)
+	return &response, nil
 }
 
 func (s *contactService) GetUserContacts(ctx context.Context, userID uuid.UUID) ([]entities.ContactResponse, error) {
@@ -156,24 +204,14 @@ func (s *contactService) GetUserContacts(ctx context.Context, userID uuid.UUID)
 	// Build ContactResponse for each user contact
 	responses := make([]entities.ContactResponse, 0, len(userContacts))
 	for _, uc := range userContacts {
-		// Get the contact to retrieve IsUser and UserIDRef
-		contact, err := s.contactRepo.GetByID(ctx, uc.ContactID)
+		// Get the contact to retrieve IsUser and UserIDRef, with the linked user if any
+		contact, err := s.contactRepo.GetContactWithUser(ctx, uc.ContactID)
 		if err != nil {
 			// Log but continue
 			continue
 		}
 		
-		responses = append(responses, entities.ContactResponse{
-			ID:        contact.ID,
-			Name:      uc.Name,
-			Email:     uc.Email,
-			Phone:     uc.Phone,
-			Notes:     uc.Notes,
-			IsUser:    contact.IsUser,
-			UserIDRef: contact.UserIDRef,
-			CreatedAt: uc.CreatedAt,
-			UpdatedAt: uc.UpdatedAt,
-		})
+		responses = append(responses, entities.NewContactResponse(contact, &uc, s.contactNameSource))
 	}
 
 	return responses, nil
@@ -218,27 +256,40 @@ func (s *contactService) UpdateContact(ctx context.Context, id uuid.UUID, userID
 		return nil, fmt.Errorf("invalid updated contact entity: %w", err)
 	}
 
-	// Check if email changed and if new email belongs to a user
+	// Check if email changed and if new email belongs to a user. Only linking
+	// depends on reciprocal contacts: a contact whose email no longer matches
+	// its user is always unlinked
 	if req.Email != nil && *req.Email != "" {
 		user, err := s.userRepo.GetByEmail(ctx, *req.Email)
 		if err == nil {
-			// Email belongs to a user - update Contact entity
-			contact.IsUser = true
-			contact.UserIDRef = &user.ID
-			contact.UpdatedAt = time.Now()
-			if err := s.contactRepo.Update(ctx, contact); err != nil {
-				return nil, fmt.Errorf("failed to update contact: %w", err)
+			// A user who blocked the owner is not linked back to them, and
+			// loses any link they already had
+			blocked, err := s.contactRepo.IsBlockedBy(ctx, user.ID, userID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check if contact blocked the user: %w", err)
+			}
+			linked := contact.UserIDRef != nil && *contact.UserIDRef == user.ID
+			if blocked {
+				if err := s.unlinkContact(ctx, contact); err != nil {
+					return nil, err
+				}
+			} else if !linked {
+				if s.reciprocalContacts {
+					// Email belongs to a user - update Contact entity
+					contact.IsUser = true
+					contact.UserIDRef = &user.ID
+					contact.UpdatedAt = time.Now()
+					if err := s.contactRepo.Update(ctx, contact); err != nil {
+						return nil, fmt.Errorf("failed to update contact: %w", err)
+					}
+				} else if err := s.unlinkContact(ctx, contact); err != nil {
+					return nil, err
+				}
 			}
 		} else if err == entities.ErrUserNotFound {
 			// Email doesn't belong to a user
-			if contact.IsUser {
-				// Was a user before, now isn't
-				contact.IsUser = false
-				contact.UserIDRef = nil
-				contact.UpdatedAt = time.Now()
-				if err := s.contactRepo.Update(ctx, contact); err != nil {
-					return nil, fmt.Errorf("failed to update contact: %w", err)
-				}
+			if err := s.unlinkContact(ctx, contact); err != nil {
+				return nil, err
 			}
 		} else {
 			return nil, fmt.Errorf("failed to check if email belongs to user: %w", err)
@@ -250,18 +301,77 @@ func (s *contactService) UpdateContact(ctx context.Context, id uuid.UUID, userID
 		return nil, fmt.Errorf("failed to update user contact relation: %w", err)
 	}
 
-	// Build and return ContactResponse
-	return &entities.ContactResponse{
-		ID:        contact.ID,
-		Name:      userContact.Name,
-		Email:     userContact.Email,
-		Phone:     userContact.Phone,
-		Notes:     userContact.Notes,
-		IsUser:    contact.IsUser,
-		UserIDRef: contact.UserIDRef,
-		CreatedAt: userContact.CreatedAt,
-		UpdatedAt: userContact.UpdatedAt,
-	}, nil
+	// Create reciprocal contact if the new email belongs to a user, as on creation
+	if req.Email != nil && *req.Email != "" && contact.IsUser {
+		if err := s.CreateReciprocalContact(ctx, *req.Email, userID); err != nil {
+			// Log the error but don't fail the contact update
+			logger := zerolog.Ctx(ctx)
+			logger.Warn().
+				Err(err).
+				Str("user_id", userID.String()).
+				Str("contact_email", *req.Email).
+				Str("contact_id", contact.ID.String()).
+				Msg("Failed to create reciprocal contact")
+		}
+	}
+
+	// Respond as GetContact does, with the linked user's verified name
+	contactWithUser, err := s.contactRepo.GetContactWithUser(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact: %w", err)
+	}
+	response := entities.NewContactResponse(contactWithUser, userContact, s.contactNameSource)
+	return &response, nil
+}
+
+// unlinkContact clears the contact's link to a user it no longer matches
+func (s *contactService) unlinkContact(ctx context.Context, contact *entities.Contact) error {
+	if !contact.IsUser && contact.UserIDRef == nil {
+		return nil
+	}
+	contact.IsUser = false
+	contact.UserIDRef = nil
+	contact.UpdatedAt = time.Now()
+	if err := s.contactRepo.Update(ctx, contact); err != nil {
+		return fmt.Errorf("failed to update contact: %w", err)
+	}
+	return nil
+}
+
+// ToggleFavorite flips whether the contact is one of the user's favorites
+func (s *contactService) ToggleFavorite(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.ContactResponse, error) {
+	userContact, err := s.contactRepo.GetUserContactRelation(ctx, userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify contact access: %w", err)
+	}
+
+	userContact.IsFavorite = !userContact.IsFavorite
+	userContact.UpdatedAt = time.Now()
+	if err := s.contactRepo.UpdateUserContactRelation(ctx, userContact); err != nil {
+		return nil, fmt.Errorf("failed to update user contact relation: %w", err)
+	}
+
+	return s.GetContact(ctx, id, userID)
+}
+
+// SetBlocked blocks or unblocks the contact for the user. While blocked, the user
+// can't start new debts with the contact, and a registered user behind it can't
+// start debts with the user or be linked to them as a contact.
+func (s *contactService) SetBlocked(ctx context.Context, id uuid.UUID, userID uuid.UUID, blocked bool) (*entities.ContactResponse, error) {
+	userContact, err := s.contactRepo.GetUserContactRelation(ctx, userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify contact access: %w", err)
+	}
+
+	if userContact.IsBlocked != blocked {
+		userContact.IsBlocked = blocked
+		userContact.UpdatedAt = time.Now()
+		if err := s.contactRepo.UpdateUserContactRelation(ctx, userContact); err != nil {
+			return nil, fmt.Errorf("failed to update user contact relation: %w", err)
+		}
+	}
+
+	return s.GetContact(ctx, id, userID)
 }
 
 func (s *contactService) DeleteContact(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
@@ -271,6 +381,17 @@ func (s *contactService) DeleteContact(ctx context.Context, id uuid.UUID, userID
 		return fmt.Errorf("failed to verify contact access: %w", err)
 	}
 
+	// Unless detaching is allowed, contacts are kept while debts with them are unsettled
+	if s.deletionPolicy == entities.ContactDeletionPolicyBlock && s.debtListRepo != nil {
+		unsettled, err := s.debtListRepo.CountUnsettledForContact(ctx, userID, id)
+		if err != nil {
+			return fmt.Errorf("failed to check for unsettled debts: %w", err)
+		}
+		if unsettled > 0 {
+			return entities.ErrContactHasActiveDebts
+		}
+	}
+
 	// Delete the user-contact relationship
 	if err := s.contactRepo.DeleteUserContactRelation(ctx, userID, id); err != nil {
 		return fmt.Errorf("failed to delete user contact relation: %w", err)
@@ -279,7 +400,41 @@ func (s *contactService) DeleteContact(ctx context.Context, id uuid.UUID, userID
 	return nil
 }
 
+// LookupContact reports whether the user adding a contact with email would link
+// it to a registered user, as CreateContact does. A user who blocked them looks
+// like any unregistered email.
+func (s *contactService) LookupContact(ctx context.Context, userID uuid.UUID, email string) (*entities.ContactLookup, error) {
+	lookup := &entities.ContactLookup{Email: email}
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, entities.ErrUserNotFound) {
+			return lookup, nil
+		}
+		return nil, fmt.Errorf("failed to check if email belongs to user: %w", err)
+	}
+
+	blocked, err := s.contactRepo.IsBlockedBy(ctx, user.ID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if contact blocked the user: %w", err)
+	}
+	if blocked {
+		return lookup, nil
+	}
+
+	displayName := user.FullName()
+	lookup.IsUser = true
+	lookup.DisplayName = &displayName
+	lookup.WillLink = s.reciprocalContacts
+	return lookup, nil
+}
+
 func (s *contactService) CreateContactsForNewUser(ctx context.Context, userID uuid.UUID, userEmail string) error {
+	// Deployments may opt out of linking a new user to contacts others made of them
+	if !s.reciprocalContacts {
+		return nil
+	}
+
 	// Find all UserContact entries that have this user's email
 	userContactsWithEmail, err := s.contactRepo.GetUserContactsByEmail(ctx, userEmail)
 	if err != nil {
@@ -355,6 +510,10 @@ func (s *contactService) CreateContactsForNewUser(ctx context.Context, userID uu
 }
 
 func (s *contactService) CreateReciprocalContact(ctx context.Context, contactEmail string, contactOwnerID uuid.UUID) error {
+	if !s.reciprocalContacts {
+		return nil
+	}
+
 	// Find the user who owns this email
 	existingUser, err := s.userRepo.GetByEmail(ctx, contactEmail)
 	if err != nil {
@@ -364,6 +523,15 @@ func (s *contactService) CreateReciprocalContact(ctx context.Context, contactEma
 		return fmt.Errorf("failed to get user by email: %w", err)
 	}
 
+	// Users are never linked to someone they blocked
+	blocked, err := s.contactRepo.IsBlockedBy(ctx, existingUser.ID, contactOwnerID)
+	if err != nil {
+		return fmt.Errorf("failed to check if contact owner is blocked: %w", err)
+	}
+	if blocked {
+		return entities.ErrContactBlocked
+	}
+
 	// Get the contact owner's details
 	contactOwner, err := s.userRepo.GetByID(ctx, contactOwnerID)
 	if err != nil {
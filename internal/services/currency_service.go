@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/domain/interfaces"
+)
+
+// exchangeRatePrecision is the number of decimal places kept for rates derived
+// by inverting a configured one
+const exchangeRatePrecision = 10
+
+// staticCurrencyService implements the CurrencyService interface over a fixed set of rates
+type staticCurrencyService struct {
+	rates map[entities.CurrencyPair]decimal.Decimal
+}
+
+// NewStaticCurrencyService creates a currency service that converts with the given
+// rates. Each rate also converts in the opposite direction by its inverse, unless
+// that direction has a rate of its own.
+func NewStaticCurrencyService(rates map[entities.CurrencyPair]decimal.Decimal) interfaces.CurrencyService {
+	return &staticCurrencyService{rates: rates}
+}
+
+func (s *staticCurrencyService) GetExchangeRate(ctx context.Context, from, to string) (decimal.Decimal, error) {
+	from = strings.ToUpper(from)
+	to = strings.ToUpper(to)
+	if from == to {
+		return decimal.NewFromInt(1), nil
+	}
+	if rate, ok := s.rates[entities.CurrencyPair{From: from, To: to}]; ok {
+		return rate, nil
+	}
+	if rate, ok := s.rates[entities.CurrencyPair{From: to, To: from}]; ok {
+		return decimal.NewFromInt(1).DivRound(rate, exchangeRatePrecision), nil
+	}
+	return decimal.Zero, entities.ErrExchangeRateUnavailable
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/domain/interfaces"
+)
+
+// debtProposalService implements the DebtProposalService interface
+type debtProposalService struct {
+	proposalRepo interfaces.DebtProposalRepository
+	contactRepo  interfaces.ContactRepository
+	debtService  interfaces.DebtService
+}
+
+// NewDebtProposalService creates a new debt proposal service
+func NewDebtProposalService(
+	proposalRepo interfaces.DebtProposalRepository,
+	contactRepo interfaces.ContactRepository,
+	debtService interfaces.DebtService,
+) interfaces.DebtProposalService {
+	return &debtProposalService{
+		proposalRepo: proposalRepo,
+		contactRepo:  contactRepo,
+		debtService:  debtService,
+	}
+}
+
+// ProposeDebt proposes a debt to a contact who is an app user. The terms are
+// validated as creating the debt list would, so a proposal cannot be accepted
+// into a debt the recipient's acceptance then fails to create.
+func (s *debtProposalService) ProposeDebt(ctx context.Context, userID uuid.UUID, req *entities.CreateDebtListRequest) (*entities.DebtProposal, error) {
+	// Building the schedule runs debt list creation's validation, including the
+	// contact and block checks, without saving anything
+	if _, err := s.debtService.PreviewPaymentSchedule(ctx, userID, req); err != nil {
+		return nil, err
+	}
+
+	// Both parties must be app users so the recipient can respond
+	contact, err := s.contactRepo.GetByID(ctx, req.ContactID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact: %w", err)
+	}
+	if !contact.IsUser || contact.UserIDRef == nil {
+		return nil, entities.ErrContactNotAppUser
+	}
+	if *contact.UserIDRef == userID {
+		return nil, entities.ErrInvalidInput
+	}
+
+	totalAmount, _ := decimal.NewFromString(req.TotalAmount)
+
+	now := time.Now()
+	proposal := &entities.DebtProposal{
+		ID:               uuid.New(),
+		ProposerID:       userID,
+		RecipientID:      *contact.UserIDRef,
+		ContactID:        req.ContactID,
+		DebtType:         req.DebtType,
+		TotalAmount:      totalAmount,
+		Currency:         req.Currency,
+		DueDate:          req.DueDate,
+		InstallmentPlan:  req.InstallmentPlan,
+		NumberOfPayments: req.NumberOfPayments,
+		Description:      req.Description,
+		Notes:            req.Notes,
+		Status:           entities.DebtProposalStatusPending,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	if err := proposal.IsValid(); err != nil {
+		return nil, fmt.Errorf("invalid debt proposal entity: %w", err)
+	}
+
+	if err := s.proposalRepo.Create(ctx, proposal); err != nil {
+		return nil, fmt.Errorf("failed to create debt proposal: %w", err)
+	}
+
+	return proposal, nil
+}
+
+func (s *debtProposalService) GetUserDebtProposals(ctx context.Context, userID uuid.UUID) ([]entities.DebtProposal, error) {
+	proposals, err := s.proposalRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debt proposals: %w", err)
+	}
+	return proposals, nil
+}
+
+// AcceptDebtProposal materializes a pending proposal into a debt list owned by the proposer.
+// Only the recipient may accept. The proposal is claimed before the debt list is
+// created, so a concurrent or retried acceptance can't create a second debt, and
+// goes back to pending if the debt list can't be created.
+func (s *debtProposalService) AcceptDebtProposal(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtList, error) {
+	proposal, err := s.getPendingProposal(ctx, id, userID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.proposalRepo.Respond(ctx, id, entities.DebtProposalStatusAccepted, nil); err != nil {
+		if errors.Is(err, entities.ErrDebtProposalNotPending) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to accept debt proposal: %w", err)
+	}
+
+	debtList, err := s.debtService.CreateDebtList(ctx, proposal.ProposerID, proposal.ToCreateDebtListRequest())
+	if err != nil {
+		if reopenErr := s.proposalRepo.Reopen(context.WithoutCancel(ctx), id); reopenErr != nil {
+			return nil, fmt.Errorf("failed to reopen debt proposal: %w", reopenErr)
+		}
+		return nil, fmt.Errorf("failed to create debt list from proposal: %w", err)
+	}
+
+	if err := s.proposalRepo.SetDebtList(context.WithoutCancel(ctx), id, debtList.ID); err != nil {
+		return nil, fmt.Errorf("failed to accept debt proposal: %w", err)
+	}
+
+	return debtList, nil
+}
+
+// RejectDebtProposal declines a pending proposal without creating a debt. Only the recipient may reject.
+func (s *debtProposalService) RejectDebtProposal(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtProposal, error) {
+	proposal, err := s.getPendingProposal(ctx, id, userID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.proposalRepo.Respond(ctx, id, entities.DebtProposalStatusRejected, nil); err != nil {
+		if errors.Is(err, entities.ErrDebtProposalNotPending) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to reject debt proposal: %w", err)
+	}
+
+	now := time.Now()
+	proposal.Status = entities.DebtProposalStatusRejected
+	proposal.RespondedAt = &now
+	proposal.UpdatedAt = now
+
+	return proposal, nil
+}
+
+// CancelDebtProposal withdraws a pending proposal, such as one whose due date
+// passed before it was answered. Only the proposer may cancel.
+func (s *debtProposalService) CancelDebtProposal(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtProposal, error) {
+	proposal, err := s.getPendingProposal(ctx, id, userID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.proposalRepo.Respond(ctx, id, entities.DebtProposalStatusCancelled, nil); err != nil {
+		if errors.Is(err, entities.ErrDebtProposalNotPending) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to cancel debt proposal: %w", err)
+	}
+
+	now := time.Now()
+	proposal.Status = entities.DebtProposalStatusCancelled
+	proposal.RespondedAt = &now
+	proposal.UpdatedAt = now
+
+	return proposal, nil
+}
+
+// Helper methods
+
+// getPendingProposal gets a pending proposal for its recipient, or for its
+// proposer when asProposer is set, hiding it from anyone else
+func (s *debtProposalService) getPendingProposal(ctx context.Context, id uuid.UUID, userID uuid.UUID, asProposer bool) (*entities.DebtProposal, error) {
+	proposal, err := s.proposalRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, entities.ErrDebtProposalNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get debt proposal: %w", err)
+	}
+
+	party := proposal.RecipientID
+	if asProposer {
+		party = proposal.ProposerID
+	}
+	if party != userID {
+		return nil, entities.ErrDebtProposalNotFound
+	}
+	if !proposal.IsPending() {
+		return nil, entities.ErrDebtProposalNotPending
+	}
+
+	return proposal, nil
+}
@@ -2,10 +2,19 @@ package services
 
 import (
 	"context"
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
+	"github.com/rs/zerolog"
 	"github.com/shopspring/decimal"
 
 	"pay-your-dues/internal/domain/entities"
@@ -19,6 +28,175 @@ type debtService struct {
 	contactRepo            interfaces.ContactRepository
 	paymentScheduleService interfaces.PaymentScheduleService
 	fileStorageService     interfaces.FileStorageService
+	userSettingsRepo       interfaces.UserSettingsRepository
+	futurePaymentWindow    time.Duration
+	defaultDueDateOffset   time.Duration
+	maxNumberOfPayments    int
+	maxTotalAmount         decimal.Decimal
+	softDeletePayments     bool
+	strictScheduleChecks   bool
+	settledTolerance       decimal.Decimal
+	autoMatchTolerance     decimal.Decimal
+	autoVerifyMatches      bool
+	eventPublisher         interfaces.DebtEventPublisher
+	nudgeInterval          time.Duration
+	contactNameSource      string
+	receiptDeletionService interfaces.ReceiptDeletionService
+	currencyService        interfaces.CurrencyService
+}
+
+// DefaultFuturePaymentWindow is how far ahead of now a payment may be dated
+// unless configured otherwise
+const DefaultFuturePaymentWindow = 24 * time.Hour
+
+// DefaultDueDateOffset is when a one-time debt created without a due date or
+// number of payments falls due, relative to its creation, unless configured otherwise
+const DefaultDueDateOffset = 30 * 24 * time.Hour
+
+// DefaultMaxNumberOfPayments is the most payments a debt list may be split into
+// unless configured otherwise, keeping generated schedules bounded
+const DefaultMaxNumberOfPayments = 600
+
+// DefaultMaxTotalAmount is the largest total amount a debt may have unless
+// configured otherwise, well within what the amount columns can store
+var DefaultMaxTotalAmount = decimal.New(1, 12)
+
+// DefaultSettledTolerance is the remaining balance, one minor unit of most
+// currencies, at or below which a debt counts as settled unless configured otherwise
+var DefaultSettledTolerance = decimal.New(1, -2)
+
+// DefaultAutoMatchTolerance is how far a pending payment's amount may be from the
+// expected installment and still match it, unless configured otherwise
+var DefaultAutoMatchTolerance = decimal.New(1, -2)
+
+// DefaultNudgeInterval is how long the submitter of a pending payment waits
+// between reminders to its verifier unless configured otherwise
+const DefaultNudgeInterval = 24 * time.Hour
+
+// dueDateConflictTolerance is how far a supplied due date may be from the one
+// implied by the number of payments before the two are considered contradictory
+const dueDateConflictTolerance = 24 * time.Hour
+
+// DebtServiceOption configures optional dependencies of the debt service
+type DebtServiceOption func(*debtService)
+
+// WithUserSettingsRepository lets the debt service resolve per-user defaults
+func WithUserSettingsRepository(userSettingsRepo interfaces.UserSettingsRepository) DebtServiceOption {
+	return func(s *debtService) {
+		s.userSettingsRepo = userSettingsRepo
+	}
+}
+
+// WithFuturePaymentWindow sets how far in the future a payment date may be.
+// Past dates are always accepted so payments can be backfilled.
+func WithFuturePaymentWindow(window time.Duration) DebtServiceOption {
+	return func(s *debtService) {
+		s.futurePaymentWindow = window
+	}
+}
+
+// WithDefaultDueDateOffset sets how long after creation a one-time debt falls
+// due when neither a due date nor a number of payments is given
+func WithDefaultDueDateOffset(offset time.Duration) DebtServiceOption {
+	return func(s *debtService) {
+		s.defaultDueDateOffset = offset
+	}
+}
+
+// WithMaxNumberOfPayments sets the most payments a debt list may be split into
+func WithMaxNumberOfPayments(max int) DebtServiceOption {
+	return func(s *debtService) {
+		s.maxNumberOfPayments = max
+	}
+}
+
+// WithMaxTotalAmount sets the largest total amount a debt may have
+func WithMaxTotalAmount(max decimal.Decimal) DebtServiceOption {
+	return func(s *debtService) {
+		s.maxTotalAmount = max
+	}
+}
+
+// WithSoftDeletePayments sets whether deleted payments are kept, with their
+// receipts, so they can be restored. Payments are soft-deleted by default.
+func WithSoftDeletePayments(enabled bool) DebtServiceOption {
+	return func(s *debtService) {
+		s.softDeletePayments = enabled
+	}
+}
+
+// WithStrictScheduleValidation sets whether a debt whose due date contradicts its
+// number of payments is rejected. Otherwise the number of payments wins and the
+// created debt carries a warning.
+func WithStrictScheduleValidation(strict bool) DebtServiceOption {
+	return func(s *debtService) {
+		s.strictScheduleChecks = strict
+	}
+}
+
+// WithSettledTolerance sets the remaining balance at or below which a debt is
+// settled, so rounding leftovers such as 0.001 do not keep it open
+func WithSettledTolerance(tolerance decimal.Decimal) DebtServiceOption {
+	return func(s *debtService) {
+		s.settledTolerance = tolerance
+	}
+}
+
+// WithAutoMatchTolerance sets how far a pending payment's amount may be from the
+// expected installment and still match it
+func WithAutoMatchTolerance(tolerance decimal.Decimal) DebtServiceOption {
+	return func(s *debtService) {
+		s.autoMatchTolerance = tolerance
+	}
+}
+
+// WithAutoVerifyMatches sets whether a pending payment that matches the expected
+// installment is verified right away. Otherwise the match is only reported.
+func WithAutoVerifyMatches(enabled bool) DebtServiceOption {
+	return func(s *debtService) {
+		s.autoVerifyMatches = enabled
+	}
+}
+
+// WithEventPublisher sets where debt events, such as a debt becoming overdue, are delivered
+func WithEventPublisher(publisher interfaces.DebtEventPublisher) DebtServiceOption {
+	return func(s *debtService) {
+		s.eventPublisher = publisher
+	}
+}
+
+// WithNudgeInterval sets how long the submitter of a pending payment waits
+// between reminders to its verifier
+func WithNudgeInterval(interval time.Duration) DebtServiceOption {
+	return func(s *debtService) {
+		s.nudgeInterval = interval
+	}
+}
+
+// WithDebtContactNameSource sets which name contact summaries, debts grouped by
+// contact and upcoming payments show for contacts that are registered users, see
+// entities.ContactResponse.ApplyNameSource. Defaults to the name the user saved
+// for the contact.
+func WithDebtContactNameSource(source string) DebtServiceOption {
+	return func(s *debtService) {
+		s.contactNameSource = source
+	}
+}
+
+// WithReceiptDeletionService routes receipt deletions through a service that
+// queues failed deletions for retry. Without it failed deletions are only logged.
+func WithReceiptDeletionService(receiptDeletionService interfaces.ReceiptDeletionService) DebtServiceOption {
+	return func(s *debtService) {
+		s.receiptDeletionService = receiptDeletionService
+	}
+}
+
+// WithCurrencyService lets the debt service convert amounts into another currency
+// for display. Without it amounts are only shown in the debt's currency.
+func WithCurrencyService(currencyService interfaces.CurrencyService) DebtServiceOption {
+	return func(s *debtService) {
+		s.currencyService = currencyService
+	}
 }
 
 // NewDebtService creates a new debt service
@@ -28,29 +206,80 @@ func NewDebtService(
 	contactRepo interfaces.ContactRepository,
 	paymentScheduleService interfaces.PaymentScheduleService,
 	fileStorageService interfaces.FileStorageService,
+	opts ...DebtServiceOption,
 ) interfaces.DebtService {
-	return &debtService{
+	s := &debtService{
 		debtListRepo:           debtListRepo,
 		debtItemRepo:           debtItemRepo,
 		contactRepo:            contactRepo,
 		paymentScheduleService: paymentScheduleService,
 		fileStorageService:     fileStorageService,
-	}
+		futurePaymentWindow:    DefaultFuturePaymentWindow,
+		defaultDueDateOffset:   DefaultDueDateOffset,
+		maxNumberOfPayments:    DefaultMaxNumberOfPayments,
+		maxTotalAmount:         DefaultMaxTotalAmount,
+		softDeletePayments:     true,
+		settledTolerance:       DefaultSettledTolerance,
+		autoMatchTolerance:     DefaultAutoMatchTolerance,
+		contactNameSource:      entities.ContactNameSourceContact,
+		nudgeInterval:          DefaultNudgeInterval,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // Debt List operations
 
 func (s *debtService) CreateDebtList(ctx context.Context, userID uuid.UUID, req *entities.CreateDebtListRequest) (*entities.DebtList, error) {
+	debtList, err := s.buildDebtList(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.debtListRepo.Create(ctx, debtList); err != nil {
+		return nil, fmt.Errorf("failed to create debt list: %w", err)
+	}
+
+	return debtList, nil
+}
+
+// PreviewPaymentSchedule returns the schedule the given terms would produce without
+// persisting anything. The request goes through the same validation as CreateDebtList.
+func (s *debtService) PreviewPaymentSchedule(ctx context.Context, userID uuid.UUID, req *entities.CreateDebtListRequest) ([]entities.PaymentScheduleItem, error) {
+	debtList, err := s.buildDebtList(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.paymentScheduleService.CalculatePaymentSchedule(debtList, nil), nil
+}
+
+// buildDebtList validates a creation request and derives the debt list it describes
+func (s *debtService) buildDebtList(ctx context.Context, userID uuid.UUID, req *entities.CreateDebtListRequest) (*entities.DebtList, error) {
 	// Validate input
 	if err := s.validateCreateDebtListRequest(req); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
+	// Stop early if the request was cancelled or timed out
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Verify contact exists and belongs to user
-	_, err := s.contactRepo.GetUserContactRelation(ctx, userID, req.ContactID)
+	userContact, err := s.contactRepo.GetUserContactRelation(ctx, userID, req.ContactID)
 	if err != nil {
 		return nil, fmt.Errorf("contact verification failed: %w", err)
 	}
+	if err := checkContactNotBlocked(ctx, s.contactRepo, userID, userContact); err != nil {
+		return nil, err
+	}
 
 	// Parse total amount
 	totalAmount, err := decimal.NewFromString(req.TotalAmount)
@@ -62,46 +291,90 @@ func (s *debtService) CreateDebtList(ctx context.Context, userID uuid.UUID, req
 	if totalAmount.LessThanOrEqual(decimal.Zero) {
 		return nil, entities.ErrInvalidAmount
 	}
+	if err := s.validateTotalAmount(totalAmount); err != nil {
+		return nil, err
+	}
 
 	// Set default currency if not provided
 	currency := req.Currency
 	if currency == "" {
-		currency = "Php"
+		currency = entities.DefaultUserCurrency
+	}
+
+	// Amounts owed in other currencies become separate balances of the same debt
+	balances, err := s.buildDebtBalances(currency, req.AdditionalBalances)
+	if err != nil {
+		return nil, err
 	}
 
 	// Validation: If number_of_payments is provided, installment_plan is required
 	if req.NumberOfPayments != nil && *req.NumberOfPayments > 0 && req.InstallmentPlan == "" {
 		return nil, fmt.Errorf("installment_plan is required when number_of_payments is provided")
 	}
+	if err := s.validateNumberOfPayments(req.NumberOfPayments); err != nil {
+		return nil, err
+	}
 
-	// Validation: If due_date is provided but installment_plan is not, default to 1-time payment
+	// Validation: If installment_plan is not provided, use the user's default plan,
+	// falling back to a 1-time payment
 	installmentPlan := req.InstallmentPlan
-	if req.DueDate != nil && installmentPlan == "" {
-		installmentPlan = "onetime" // Default to onetime for 1-time payment calculation
+	if installmentPlan == "" {
+		installmentPlan, err = s.getUserDefaultInstallmentPlan(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// A payment weekday only makes sense for weekly and biweekly plans
+	if req.PaymentWeekday != nil && installmentPlan != "weekly" && installmentPlan != "biweekly" {
+		return nil, entities.ErrInvalidPaymentWeekday
+	}
+
+	// The last installment absorbs any rounding remainder unless the debt says otherwise
+	roundingMode := req.RoundingMode
+	if roundingMode == "" {
+		roundingMode = entities.RoundingModeLast
 	}
 
 	// Determine due date and installment amount based on input
 	var dueDate time.Time
 	var installmentAmount decimal.Decimal
 	var numberOfPayments *int
+	var warnings []string
 
 	createdAt := time.Now()
 
 	if req.NumberOfPayments != nil && *req.NumberOfPayments > 0 {
 		// Use number of payments to calculate due date and installment amount
 		numberOfPayments = req.NumberOfPayments
-		dueDate = s.paymentScheduleService.CalculateDueDateFromNumberOfPayments(createdAt, *req.NumberOfPayments, installmentPlan)
-		installmentAmount = s.paymentScheduleService.CalculateInstallmentAmountFromNumberOfPayments(totalAmount, *req.NumberOfPayments)
+		dueDate = alignToPaymentWeekday(s.paymentScheduleService.CalculateDueDateFromNumberOfPayments(createdAt, *req.NumberOfPayments, installmentPlan), req.PaymentWeekday)
+		installmentAmount = s.paymentScheduleService.CalculateInstallmentAmountFromNumberOfPayments(totalAmount, *req.NumberOfPayments, roundingMode)
+
+		// A due date supplied alongside is ignored, so flag one that disagrees
+		if req.DueDate != nil {
+			if diff := req.DueDate.Sub(dueDate); diff > dueDateConflictTolerance || diff < -dueDateConflictTolerance {
+				if s.strictScheduleChecks {
+					return nil, fmt.Errorf("%w: %d %s payments end on %s, not %s", entities.ErrConflictingSchedule,
+						*req.NumberOfPayments, installmentPlan, dueDate.Format("2006-01-02"), req.DueDate.Format("2006-01-02"))
+				}
+				warnings = append(warnings, fmt.Sprintf("due_date %s was ignored: %d %s payments end on %s",
+					req.DueDate.Format("2006-01-02"), *req.NumberOfPayments, installmentPlan, dueDate.Format("2006-01-02")))
+			}
+		}
 	} else if req.DueDate != nil {
 		// Use provided due date (existing behavior)
 		dueDate = *req.DueDate
-		installmentAmount = s.paymentScheduleService.CalculateInstallmentAmount(totalAmount, installmentPlan, createdAt, dueDate)
+		installmentAmount = s.paymentScheduleService.CalculateInstallmentAmount(totalAmount, installmentPlan, createdAt, dueDate, roundingMode)
 	} else {
 		// Default to 1 payment if neither is provided
 		defaultPayments := 1
 		numberOfPayments = &defaultPayments
 		dueDate = s.paymentScheduleService.CalculateDueDateFromNumberOfPayments(createdAt, defaultPayments, installmentPlan)
-		installmentAmount = s.paymentScheduleService.CalculateInstallmentAmountFromNumberOfPayments(totalAmount, defaultPayments)
+		installmentAmount = s.paymentScheduleService.CalculateInstallmentAmountFromNumberOfPayments(totalAmount, defaultPayments, roundingMode)
+		// A single one-time payment would be due right away, so give it the default window
+		if !dueDate.After(createdAt) {
+			dueDate = createdAt.Add(s.defaultDueDateOffset)
+		}
 	}
 
 	// Validate that due date is in the future
@@ -117,6 +390,7 @@ func (s *debtService) CreateDebtList(ctx context.Context, userID uuid.UUID, req
 	} else {
 		nextPaymentDate = s.paymentScheduleService.CalculateNextPaymentDate(&entities.DebtList{
 			InstallmentPlan: installmentPlan,
+			PaymentWeekday:  req.PaymentWeekday,
 			CreatedAt:       createdAt,
 			DueDate:         dueDate,
 		}, nil)
@@ -128,7 +402,7 @@ func (s *debtService) CreateDebtList(ctx context.Context, userID uuid.UUID, req
 		ContactID:           req.ContactID,
 		DebtType:            req.DebtType,
 		TotalAmount:         totalAmount,
-		InstallmentAmount:   installmentAmount,
+		InstallmentAmount:   installmentAmount.Round(2), // Matches the stored decimal(15,2) precision
 		TotalPaymentsMade:   decimal.Zero,
 		TotalRemainingDebt:  totalAmount,
 		Currency:            currency,
@@ -137,10 +411,19 @@ func (s *debtService) CreateDebtList(ctx context.Context, userID uuid.UUID, req
 		NextPaymentDate:     nextPaymentDate,
 		InstallmentPlan:     installmentPlan,
 		NumberOfPayments:    numberOfPayments,
+		PaymentWeekday:      req.PaymentWeekday,
+		RoundingMode:        roundingMode,
 		Description:         req.Description,
 		Notes:               req.Notes,
 		CreatedAt:           createdAt,
 		UpdatedAt:           createdAt,
+		Balances:            balances,
+		Warnings:            warnings,
+	}
+	for i := range debtList.Balances {
+		debtList.Balances[i].DebtListID = debtList.ID
+		debtList.Balances[i].CreatedAt = createdAt
+		debtList.Balances[i].UpdatedAt = createdAt
 	}
 
 	// Validate debt list entity
@@ -148,11 +431,33 @@ func (s *debtService) CreateDebtList(ctx context.Context, userID uuid.UUID, req
 		return nil, fmt.Errorf("invalid debt list entity: %w", err)
 	}
 
-	if err := s.debtListRepo.Create(ctx, debtList); err != nil {
-		return nil, fmt.Errorf("failed to create debt list: %w", err)
+	return debtList, nil
+}
+
+// CreateQuickDebt creates a onetime debt from the minimum set of fields.
+// Currency defaults to the user's configured default and the due date to the end of the current month in UTC.
+func (s *debtService) CreateQuickDebt(ctx context.Context, userID uuid.UUID, req *entities.QuickDebtRequest) (*entities.DebtList, error) {
+	currency, err := s.getUserDefaultCurrency(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	return debtList, nil
+	// The end of the month is taken in UTC like every other stored date, and in
+	// the month's last second moves on to the end of the next month
+	now := time.Now().UTC()
+	dueDate := endOfMonth(now)
+	if !dueDate.After(now) {
+		dueDate = endOfMonth(dueDate.Add(time.Second))
+	}
+
+	return s.CreateDebtList(ctx, userID, &entities.CreateDebtListRequest{
+		ContactID:       req.ContactID,
+		DebtType:        req.DebtType,
+		TotalAmount:     req.Amount,
+		Currency:        currency,
+		DueDate:         &dueDate,
+		InstallmentPlan: "onetime",
+	})
 }
 
 func (s *debtService) GetDebtList(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtListResponse, error) {
@@ -184,11 +489,7 @@ func (s *debtService) GetDebtList(ctx context.Context, id uuid.UUID, userID uuid
 			// Flip the debt type for the user's perspective
 			// When a user views a debt list where they are the contact,
 			// the debt type should be from their perspective
-			if contactDebtList.DebtType == "to_receive" {
-				contactDebtList.DebtType = "to_pay"
-			} else if contactDebtList.DebtType == "to_pay" {
-				contactDebtList.DebtType = "to_receive"
-			}
+			contactDebtList.DebtType = entities.OppositeDebtType(contactDebtList.DebtType)
 			return &contactDebtList, nil
 		}
 	}
@@ -205,24 +506,119 @@ func (s *debtService) GetUserDebtLists(ctx context.Context, userID uuid.UUID) ([
 	}
 
 	// Get debt lists where the user is referenced as a contact (other users owe them or they owe other users)
+	contactDebtLists, err := s.GetSharedDebtLists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Combine both lists
+	allDebtLists := append(ownedDebtLists, contactDebtLists...)
+
+	return allDebtLists, nil
+}
+
+// GetSharedDebtLists returns the debt lists other users track with this user as
+// their contact, with debt types flipped to this user's perspective. Lists the
+// user owns are never included, even if they added themselves as the contact.
+func (s *debtService) GetSharedDebtLists(ctx context.Context, userID uuid.UUID) ([]entities.DebtListResponse, error) {
 	contactDebtLists, err := s.debtListRepo.GetDebtListsWhereUserIsContact(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get debt lists where user is contact: %w", err)
 	}
 
-	// Flip the debt type for debt lists where the user is the contact
-	for i := range contactDebtLists {
-		if contactDebtLists[i].DebtType == "to_receive" {
-			contactDebtLists[i].DebtType = "to_pay"
-		} else if contactDebtLists[i].DebtType == "to_pay" {
-			contactDebtLists[i].DebtType = "to_receive"
+	return sharedDebtLists(contactDebtLists, userID), nil
+}
+
+// sharedDebtLists drops the lists the user owns from the debt lists they are the
+// contact of, and flips the rest to the user's perspective
+func sharedDebtLists(contactDebtLists []entities.DebtListResponse, userID uuid.UUID) []entities.DebtListResponse {
+	shared := make([]entities.DebtListResponse, 0, len(contactDebtLists))
+	for _, debtList := range contactDebtLists {
+		if debtList.UserID == userID {
+			continue
 		}
+
+		// Flip the debt type since the user is the contact
+		debtList.DebtType = entities.OppositeDebtType(debtList.DebtType)
+		shared = append(shared, debtList)
 	}
+	return shared
+}
 
-	// Combine both lists
-	allDebtLists := append(ownedDebtLists, contactDebtLists...)
+// ownAndSharedDebtLists returns the debt lists the user owns and the ones other
+// users recorded with them as their contact. Shared lists are flipped to the
+// user's perspective, so every debt type reads from the user's side.
+func (s *debtService) ownAndSharedDebtLists(ctx context.Context, userID uuid.UUID) ([]entities.DebtListResponse, []entities.DebtListResponse, error) {
+	ownDebtLists, err := s.debtListRepo.GetUserDebtLists(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get user debt lists: %w", err)
+	}
+	sharedDebtLists, err := s.GetSharedDebtLists(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ownDebtLists, sharedDebtLists, nil
+}
 
-	return allDebtLists, nil
+// GetDebtListsModifiedSince returns the debt lists GetUserDebtLists would list
+// that changed after since, for clients syncing incrementally. A debt list
+// counts as changed when it was updated or any of its payments was created,
+// updated or deleted. Debt lists deleted since follow as markers with only
+// their ID, so clients can drop them.
+func (s *debtService) GetDebtListsModifiedSince(ctx context.Context, userID uuid.UUID, since time.Time, includePayments bool) ([]entities.DebtListChange, error) {
+	debtLists, err := s.debtListRepo.GetUserDebtListsModifiedSince(ctx, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get modified user debt lists: %w", err)
+	}
+	contactDebtLists, err := s.debtListRepo.GetDebtListsWhereUserIsContactModifiedSince(ctx, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get modified debt lists where user is contact: %w", err)
+	}
+	debtLists = append(debtLists, sharedDebtLists(contactDebtLists, userID)...)
+
+	debtListIDs := make([]uuid.UUID, len(debtLists))
+	for i, debtList := range debtLists {
+		debtListIDs[i] = debtList.ID
+	}
+	deletedPayments, err := s.debtItemRepo.GetDeletedSince(ctx, debtListIDs, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted payments: %w", err)
+	}
+	deletedByDebtList := make(map[uuid.UUID][]uuid.UUID)
+	for _, payment := range deletedPayments {
+		deletedByDebtList[payment.DebtListID] = append(deletedByDebtList[payment.DebtListID], payment.ID)
+	}
+
+	changes := make([]entities.DebtListChange, 0)
+	for _, debtList := range debtLists {
+		changedPayments := make([]entities.DebtItem, 0)
+		for _, payment := range debtList.Payments {
+			if payment.UpdatedAt.After(since) {
+				changedPayments = append(changedPayments, payment)
+			}
+		}
+
+		change := entities.DebtListChange{DebtListResponse: debtList}
+		change.Payments = nil
+		if includePayments {
+			change.Payments = changedPayments
+			change.DeletedPaymentIDs = deletedByDebtList[debtList.ID]
+		}
+		changes = append(changes, change)
+	}
+
+	deletedDebtListIDs, err := s.debtListRepo.GetDeletedSince(ctx, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted debt lists: %w", err)
+	}
+	for _, id := range deletedDebtListIDs {
+		changes = append(changes, entities.DebtListChange{
+			DebtListResponse: entities.DebtListResponse{ID: id},
+			Deleted:          true,
+		})
+	}
+
+	return changes, nil
 }
 
 func (s *debtService) UpdateDebtList(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *entities.UpdateDebtListRequest) (*entities.DebtList, error) {
@@ -245,6 +641,8 @@ func (s *debtService) UpdateDebtList(ctx context.Context, id uuid.UUID, userID u
 	if err != nil {
 		return nil, fmt.Errorf("failed to get debt list: %w", err)
 	}
+	// Kept to record which fields the update changed
+	before := *debtList
 
 	// Step 1: Update simple fields first
 	if req.Currency != nil {
@@ -259,6 +657,9 @@ func (s *debtService) UpdateDebtList(ctx context.Context, id uuid.UUID, userID u
 	if req.Notes != nil {
 		debtList.Notes = req.Notes
 	}
+	if req.RoundingMode != nil {
+		debtList.RoundingMode = *req.RoundingMode
+	}
 
 	// Step 2: Update InstallmentPlan (affects all calculations)
 	if req.InstallmentPlan != nil {
@@ -270,17 +671,35 @@ func (s *debtService) UpdateDebtList(ctx context.Context, id uuid.UUID, userID u
 		}
 	}
 
+	// A payment weekday only applies to weekly and biweekly plans, so it is dropped
+	// when the plan changes to another one unless the request sets it again
+	if req.PaymentWeekday != nil {
+		debtList.PaymentWeekday = req.PaymentWeekday
+	}
+	if debtList.PaymentWeekday != nil && debtList.InstallmentPlan != "weekly" && debtList.InstallmentPlan != "biweekly" {
+		if req.PaymentWeekday != nil {
+			return nil, entities.ErrInvalidPaymentWeekday
+		}
+		debtList.PaymentWeekday = nil
+	}
+
 	// Step 3: Update TotalAmount
 	if req.TotalAmount != nil {
 		totalAmount, err := decimal.NewFromString(*req.TotalAmount)
 		if err != nil {
 			return nil, entities.ErrInvalidAmount
 		}
+		if err := s.validateTotalAmount(totalAmount); err != nil {
+			return nil, err
+		}
 		debtList.TotalAmount = totalAmount
 	}
 
 	// Step 4: Update NumberOfPayments (but respect onetime constraint)
 	if req.NumberOfPayments != nil {
+		if err := s.validateNumberOfPayments(req.NumberOfPayments); err != nil {
+			return nil, err
+		}
 		if debtList.InstallmentPlan == "onetime" {
 			// For onetime payment, always force to 1 regardless of what user provides
 			onePayment := 1
@@ -315,23 +734,44 @@ func (s *debtService) UpdateDebtList(ctx context.Context, id uuid.UUID, userID u
 		// Non-onetime payment: calculate based on NumberOfPayments or DueDate
 		if debtList.NumberOfPayments != nil && *debtList.NumberOfPayments > 0 {
 			// Use NumberOfPayments to calculate installment amount and due date
-			debtList.InstallmentAmount = s.paymentScheduleService.CalculateInstallmentAmountFromNumberOfPayments(debtList.TotalAmount, *debtList.NumberOfPayments)
+			debtList.InstallmentAmount = s.paymentScheduleService.CalculateInstallmentAmountFromNumberOfPayments(debtList.TotalAmount, *debtList.NumberOfPayments, debtList.RoundingMode)
 			// Only recalculate due date if it wasn't explicitly set in this request
 			if req.DueDate == nil {
-				debtList.DueDate = s.paymentScheduleService.CalculateDueDateFromNumberOfPayments(debtList.CreatedAt, *debtList.NumberOfPayments, debtList.InstallmentPlan)
+				debtList.DueDate = alignToPaymentWeekday(s.paymentScheduleService.CalculateDueDateFromNumberOfPayments(debtList.CreatedAt, *debtList.NumberOfPayments, debtList.InstallmentPlan), debtList.PaymentWeekday)
 			}
 		} else {
 			// Use DueDate to calculate installment amount and number of payments
-			debtList.InstallmentAmount = s.paymentScheduleService.CalculateInstallmentAmount(debtList.TotalAmount, debtList.InstallmentPlan, debtList.CreatedAt, debtList.DueDate)
+			debtList.InstallmentAmount = s.paymentScheduleService.CalculateInstallmentAmount(debtList.TotalAmount, debtList.InstallmentPlan, debtList.CreatedAt, debtList.DueDate, debtList.RoundingMode)
 			// Calculate number of payments if not explicitly set
 			if debtList.NumberOfPayments == nil && debtList.InstallmentAmount.GreaterThan(decimal.Zero) {
+				// A remainder gets its own installment only when the last one absorbs it
 				paymentsNeeded := debtList.TotalAmount.Div(debtList.InstallmentAmount).Ceil().IntPart()
+				if debtList.RoundingMode == entities.RoundingModeFirst || debtList.RoundingMode == entities.RoundingModeSpread {
+					paymentsNeeded = debtList.TotalAmount.Div(debtList.InstallmentAmount).Floor().IntPart()
+				}
 				paymentsNeededInt := int(paymentsNeeded)
 				debtList.NumberOfPayments = &paymentsNeededInt
 			}
 		}
 	}
 
+	// Step 7: A requested status must agree with the state computed from payments and
+	// schedule; settling an unpaid debt or reopening a paid one is not a status edit.
+	// The schedule edits above move the next payment date, so it is recalculated
+	// from the payments made first.
+	if req.Status != nil {
+		lastPaymentDate, err := s.lastPaymentDate(ctx, debtList.ID)
+		if err != nil {
+			return nil, err
+		}
+		debtList.NextPaymentDate = s.paymentScheduleService.CalculateNextPaymentDate(debtList, lastPaymentDate)
+
+		remainingAmount := debtList.TotalAmount.Sub(debtList.TotalPaymentsMade)
+		if *req.Status != debtListStatusFor(remainingAmount, debtList.NextPaymentDate, s.settledTolerance) {
+			return nil, entities.ErrConflictingDebtStatus
+		}
+	}
+
 	debtList.UpdatedAt = time.Now()
 
 	// Validate updated debt list entity
@@ -355,9 +795,106 @@ func (s *debtService) UpdateDebtList(ctx context.Context, id uuid.UUID, userID u
 		return nil, fmt.Errorf("failed to get updated debt list: %w", err)
 	}
 
+	// Fields derived from the request, such as a recalculated due date, are recorded too
+	history := debtListHistoryEntries(&before, updatedDebtList, userID, updatedDebtList.UpdatedAt)
+	if err := s.debtListRepo.CreateHistoryEntries(ctx, history); err != nil {
+		return nil, fmt.Errorf("failed to record debt list history: %w", err)
+	}
+
 	return updatedDebtList, nil
 }
 
+// lastPaymentDate returns the date of the debt list's latest completed payment,
+// leaving adjustments out as RecalculatePaymentTotals does, or nil when there is none
+func (s *debtService) lastPaymentDate(ctx context.Context, debtListID uuid.UUID) (*time.Time, error) {
+	debtItems, err := s.debtItemRepo.GetByDebtListID(ctx, debtListID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debt items: %w", err)
+	}
+	var last *time.Time
+	for i := range debtItems {
+		debtItem := &debtItems[i]
+		if debtItem.Status != entities.PaymentStatusCompleted || debtItem.IsAdjustment() {
+			continue
+		}
+		if last == nil || debtItem.PaymentDate.After(*last) {
+			last = &debtItem.PaymentDate
+		}
+	}
+	return last, nil
+}
+
+// debtListHistoryEntries lists the tracked fields that differ between a debt list
+// before and after an update
+func debtListHistoryEntries(before, after *entities.DebtList, changedBy uuid.UUID, changedAt time.Time) []entities.DebtListHistoryEntry {
+	fields := []struct {
+		name     string
+		old, new *string
+	}{
+		{"total_amount", historyAmount(before.TotalAmount), historyAmount(after.TotalAmount)},
+		{"currency", &before.Currency, &after.Currency},
+		{"installment_plan", &before.InstallmentPlan, &after.InstallmentPlan},
+		{"number_of_payments", historyInt(before.NumberOfPayments), historyInt(after.NumberOfPayments)},
+		{"installment_amount", historyAmount(before.InstallmentAmount), historyAmount(after.InstallmentAmount)},
+		{"payment_weekday", historyInt(before.PaymentWeekday), historyInt(after.PaymentWeekday)},
+		{"rounding_mode", &before.RoundingMode, &after.RoundingMode},
+		{"due_date", historyTime(before.DueDate), historyTime(after.DueDate)},
+		{"status", &before.Status, &after.Status},
+	}
+
+	var entries []entities.DebtListHistoryEntry
+	for _, field := range fields {
+		if (field.old == nil) == (field.new == nil) && (field.old == nil || *field.old == *field.new) {
+			continue
+		}
+		entries = append(entries, entities.DebtListHistoryEntry{
+			ID:         uuid.New(),
+			DebtListID: after.ID,
+			ChangedBy:  changedBy,
+			Field:      field.name,
+			OldValue:   field.old,
+			NewValue:   field.new,
+			ChangedAt:  changedAt,
+		})
+	}
+	return entries
+}
+
+// historyAmount renders an amount as a history value
+func historyAmount(amount decimal.Decimal) *string {
+	value := amount.StringFixed(2)
+	return &value
+}
+
+// historyInt renders an optional number as a history value, nil when unset
+func historyInt(n *int) *string {
+	if n == nil {
+		return nil
+	}
+	value := strconv.Itoa(*n)
+	return &value
+}
+
+// historyTime renders a date as an RFC 3339 history value in UTC
+func historyTime(t time.Time) *string {
+	value := t.UTC().Format(time.RFC3339)
+	return &value
+}
+
+// GetDebtListHistory returns the recorded field changes of a debt list the user
+// owns or is the contact of, newest first
+func (s *debtService) GetDebtListHistory(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) ([]entities.DebtListHistoryEntry, error) {
+	if err := s.requireDebtListAccess(ctx, debtListID, userID); err != nil {
+		return nil, err
+	}
+
+	history, err := s.debtListRepo.GetHistory(ctx, debtListID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debt list history: %w", err)
+	}
+	return history, nil
+}
+
 func (s *debtService) DeleteDebtList(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
 	// Check if debt list belongs to user
 	belongs, err := s.debtListRepo.BelongsToUser(ctx, id, userID)
@@ -375,84 +912,524 @@ func (s *debtService) DeleteDebtList(ctx context.Context, id uuid.UUID, userID u
 	return nil
 }
 
-// Debt Item (Payment) operations
-
-func (s *debtService) CreateDebtItem(ctx context.Context, userID uuid.UUID, req *entities.CreateDebtItemRequest) (*entities.DebtItem, error) {
-	// Validate input
-	if err := s.validateCreateDebtItemRequest(req); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
-	}
-
-	// First check if debt list belongs to user (user is the owner)
-	belongs, err := s.debtListRepo.BelongsToUser(ctx, req.DebtListID, userID)
+// TransferOwnership hands a debt list over to the registered user it is shared
+// with. The list is re-pointed at the new owner's contact for the previous owner
+// and its debt type is flipped, so each party keeps the same perspective on the debt.
+func (s *debtService) TransferOwnership(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *entities.TransferOwnershipRequest) (*entities.DebtList, error) {
+	belongs, err := s.debtListRepo.BelongsToUser(ctx, id, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to verify debt list ownership: %w", err)
+		return nil, fmt.Errorf("failed to verify ownership: %w", err)
 	}
-	
-	if belongs {
-		// User owns the debt list, proceed with creation
-	} else {
-		// If user doesn't own it, check if they are a contact in the debt list
-		// This allows users to create debt items for debt lists where they owe money or are owed money
-		contactDebtLists, err := s.debtListRepo.GetDebtListsWhereUserIsContact(ctx, userID)
+	if !belongs {
+		isContact, err := s.debtListRepo.IsContactOfDebtList(ctx, id, userID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get debt lists where user is contact: %w", err)
-		}
-		
-		// Check if the user is a contact in the specific debt list
-		userIsContact := false
-		for _, contactDebtList := range contactDebtLists {
-			if contactDebtList.ID == req.DebtListID {
-				userIsContact = true
-				break
-			}
+			return nil, fmt.Errorf("failed to verify contact association: %w", err)
 		}
-		
-		if !userIsContact {
-			// User neither owns the debt list nor is a contact in it
-			return nil, entities.ErrDebtListNotFound
+		if isContact {
+			return nil, entities.ErrForbidden
 		}
+		return nil, entities.ErrDebtListNotFound
 	}
 
-	// Get debt list for currency default
-	debtList, err := s.debtListRepo.GetByID(ctx, req.DebtListID)
+	debtList, err := s.debtListRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get debt list: %w", err)
 	}
 
-	// Parse amount
-	amount, err := decimal.NewFromString(req.Amount)
+	contact, err := s.contactRepo.GetByID(ctx, debtList.ContactID)
 	if err != nil {
-		return nil, entities.ErrInvalidAmount
+		return nil, fmt.Errorf("failed to get contact: %w", err)
 	}
-	
-	// Validate that amount is positive
-	if amount.LessThanOrEqual(decimal.Zero) {
-		return nil, entities.ErrInvalidAmount
+	if !contact.IsUser || contact.UserIDRef == nil || *contact.UserIDRef != req.NewOwnerID || req.NewOwnerID == userID {
+		return nil, entities.ErrInvalidNewOwner
 	}
 
-	// Set default currency if not provided
-	currency := req.Currency
-	if currency == "" {
-		currency = debtList.Currency
+	// The new owner's contact for the previous owner becomes the list's contact
+	newContactID, err := s.findContactForUser(ctx, req.NewOwnerID, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Determine initial status based on the user's perspective
-	// When a user creates a payment, we need to consider their perspective:
-	// - If they owe money (to_pay from their perspective), payments are pending until verified
-	// - If they are owed money (to_receive from their perspective), payments are completed
-	initialStatus := "completed"
-	
+	debtType := entities.OppositeDebtType(debtList.DebtType)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.debtListRepo.TransferOwnership(ctx, id, userID, req.NewOwnerID, newContactID, debtType); err != nil {
+		if errors.Is(err, entities.ErrDebtListNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to transfer ownership: %w", err)
+	}
+
+	transferred, err := s.debtListRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transferred debt list: %w", err)
+	}
+	return transferred, nil
+}
+
+// RecomputeDebtList recalculates a debt list's payment totals, status and next
+// payment date from its payments, repairing totals that have drifted
+func (s *debtService) RecomputeDebtList(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtListResponse, error) {
+	belongs, err := s.debtListRepo.BelongsToUser(ctx, id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify ownership: %w", err)
+	}
+	if !belongs {
+		isContact, err := s.debtListRepo.IsContactOfDebtList(ctx, id, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify contact association: %w", err)
+		}
+		if isContact {
+			return nil, entities.ErrForbidden
+		}
+		return nil, entities.ErrDebtListNotFound
+	}
+
+	if err := s.updateDebtListStatusAndPaymentTotals(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to recompute debt list: %w", err)
+	}
+
+	debtListResponse, err := s.debtListRepo.GetByIDWithRelations(ctx, id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debt list: %w", err)
+	}
+	return debtListResponse, nil
+}
+
+// RecomputeOverdueStatuses recomputes every active debt whose next payment date has
+// passed, so debts without recent activity still turn overdue. It is run by a
+// background job rather than on behalf of a user. A debt that fails to recompute
+// does not stop the others; the failures are returned together. It returns how
+// many debts were recomputed.
+func (s *debtService) RecomputeOverdueStatuses(ctx context.Context) (int, error) {
+	ids, err := s.debtListRepo.GetActivePastDueIDs(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to get past due debt lists: %w", err)
+	}
+
+	recomputed := 0
+	var errs []error
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return recomputed, err
+		}
+		if err := s.updateDebtListStatusAndPaymentTotals(ctx, id); err != nil {
+			errs = append(errs, fmt.Errorf("failed to recompute debt list %s: %w", id, err))
+			continue
+		}
+		recomputed++
+	}
+
+	return recomputed, errors.Join(errs...)
+}
+
+// GetDebtPerspective reports the debt type as stored for the owner alongside the
+// requesting user's effective view of it, flipped when they are the contact
+func (s *debtService) GetDebtPerspective(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtPerspective, error) {
+	role := entities.DebtRoleOwner
+	belongs, err := s.debtListRepo.BelongsToUser(ctx, id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify ownership: %w", err)
+	}
+	if !belongs {
+		isContact, err := s.debtListRepo.IsContactOfDebtList(ctx, id, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify contact association: %w", err)
+		}
+		if !isContact {
+			return nil, entities.ErrDebtListNotFound
+		}
+		role = entities.DebtRoleContact
+	}
+
+	debtList, err := s.debtListRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debt list: %w", err)
+	}
+
+	effectiveType := debtList.DebtType
+	if role == entities.DebtRoleContact {
+		effectiveType = entities.OppositeDebtType(effectiveType)
+	}
+
+	return &entities.DebtPerspective{
+		DebtListID: debtList.ID,
+		Role:       role,
+		Flipped:    effectiveType != debtList.DebtType,
+		OwnerView: entities.DebtView{
+			DebtType:  debtList.DebtType,
+			Direction: entities.DebtDirectionFor(debtList.DebtType),
+		},
+		EffectiveView: entities.DebtView{
+			DebtType:  effectiveType,
+			Direction: entities.DebtDirectionFor(effectiveType),
+		},
+	}, nil
+}
+
+// findContactForUser returns the ID of the contact in ownerID's contact list
+// that is linked to the registered user targetUserID
+func (s *debtService) findContactForUser(ctx context.Context, ownerID, targetUserID uuid.UUID) (uuid.UUID, error) {
+	userContacts, err := s.contactRepo.GetUserContacts(ctx, ownerID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to get user contacts: %w", err)
+	}
+	for _, userContact := range userContacts {
+		contact, err := s.contactRepo.GetByID(ctx, userContact.ContactID)
+		if err != nil {
+			if errors.Is(err, entities.ErrContactNotFound) {
+				continue
+			}
+			return uuid.Nil, fmt.Errorf("failed to get contact: %w", err)
+		}
+		if contact.UserIDRef != nil && *contact.UserIDRef == targetUserID {
+			return contact.ID, nil
+		}
+	}
+	return uuid.Nil, entities.ErrReciprocalContactNotFound
+}
+
+// Debt list document operations
+
+// UploadDebtDocument attaches a document such as a signed agreement to a debt list.
+// Only the owner of the debt list may upload; the contact gets ErrForbidden.
+func (s *debtService) UploadDebtDocument(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID, file io.Reader, filename string, contentType string) (*entities.DebtDocument, error) {
+	belongs, err := s.debtListRepo.BelongsToUser(ctx, debtListID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify ownership: %w", err)
+	}
+	if !belongs {
+		isContact, err := s.debtListRepo.IsContactOfDebtList(ctx, debtListID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify contact association: %w", err)
+		}
+		if isContact {
+			return nil, entities.ErrForbidden
+		}
+		return nil, entities.ErrDebtListNotFound
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	documentURL, err := s.fileStorageService.UploadDocument(ctx, file, filename, contentType, debtListID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload document: %w", err)
+	}
+
+	return &entities.DebtDocument{
+		DebtListID: debtListID,
+		Filename:   filepath.Base(documentURL),
+		URL:        documentURL,
+	}, nil
+}
+
+// GetDebtDocument retrieves a document attached to a debt list for either party
+// to the debt, returning the file content and its content type
+func (s *debtService) GetDebtDocument(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID, filename string) ([]byte, string, error) {
+	if err := s.requireDebtListAccess(ctx, debtListID, userID); err != nil {
+		return nil, "", err
+	}
+
+	// Filenames are generated on upload, so anything that is not a bare name cannot be a document
+	if filename == "" || filepath.Base(filename) != filename {
+		return nil, "", entities.ErrDebtDocumentNotFound
+	}
+
+	documentURL := fmt.Sprintf("/api/v1/debts/%s/documents/%s", debtListID.String(), filename)
+	content, contentType, err := s.fileStorageService.GetDocumentFile(ctx, documentURL)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, "", ctxErr
+		}
+		return nil, "", fmt.Errorf("%w: %v", entities.ErrDebtDocumentNotFound, err)
+	}
+
+	return content, contentType, nil
+}
+
+// Debt Item (Payment) operations
+
+// ImportPayments records a payment for each data row of a bank statement CSV, reading
+// the columns named by mapping. Rows that fail to parse or validate are skipped and
+// reported alongside the imported ones; a file that cannot be read imports nothing.
+func (s *debtService) ImportPayments(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID, file io.Reader, mapping entities.PaymentImportMapping) (*entities.PaymentImportResult, error) {
+	// Anyone who may record payments on the debt may import them
+	if err := s.requireDebtListAccess(ctx, debtListID, userID); err != nil {
+		return nil, err
+	}
+
+	if mapping.DateColumn == "" {
+		mapping.DateColumn = "date"
+	}
+	if mapping.AmountColumn == "" {
+		mapping.AmountColumn = "amount"
+	}
+	if mapping.DateFormat == "" {
+		mapping.DateFormat = "2006-01-02"
+	}
+	if mapping.PaymentMethod == "" {
+		mapping.PaymentMethod = "bank_transfer"
+	}
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: file is empty", entities.ErrInvalidImportFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", entities.ErrInvalidImportFile, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	column := func(name string) (int, error) {
+		index, ok := columns[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return 0, fmt.Errorf("%w: no %q column", entities.ErrInvalidImportFile, name)
+		}
+		return index, nil
+	}
+	dateIndex, err := column(mapping.DateColumn)
+	if err != nil {
+		return nil, err
+	}
+	amountIndex, err := column(mapping.AmountColumn)
+	if err != nil {
+		return nil, err
+	}
+	referenceIndex := -1
+	if mapping.ReferenceColumn != "" {
+		if referenceIndex, err = column(mapping.ReferenceColumn); err != nil {
+			return nil, err
+		}
+	}
+	descriptionIndex := -1
+	if mapping.DescriptionColumn != "" {
+		if descriptionIndex, err = column(mapping.DescriptionColumn); err != nil {
+			return nil, err
+		}
+	}
+
+	// Read every row first so an oversized file is rejected before anything is imported
+	type importRow struct {
+		line   int
+		record []string
+		err    error
+	}
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, importRow{line: parseErr.StartLine, err: parseErr.Err})
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: %v", entities.ErrInvalidImportFile, err)
+		} else {
+			line, _ := reader.FieldPos(0)
+			rows = append(rows, importRow{line: line, record: record})
+		}
+		if len(rows) > entities.MaxImportRows {
+			return nil, fmt.Errorf("%w: more than %d rows", entities.ErrInvalidImportFile, entities.MaxImportRows)
+		}
+	}
+
+	result := &entities.PaymentImportResult{Rows: make([]entities.PaymentImportRowResult, 0, len(rows))}
+	skip := func(line int, reason string) {
+		result.Skipped++
+		result.Rows = append(result.Rows, entities.PaymentImportRowResult{
+			Line:   line,
+			Status: entities.ImportRowSkipped,
+			Error:  reason,
+		})
+	}
+	field := func(record []string, index int) string {
+		if index < 0 || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	for _, row := range rows {
+		if row.err != nil {
+			skip(row.line, row.err.Error())
+			continue
+		}
+
+		paymentDate, err := time.Parse(mapping.DateFormat, field(row.record, dateIndex))
+		if err != nil {
+			skip(row.line, fmt.Sprintf("invalid date %q", field(row.record, dateIndex)))
+			continue
+		}
+		// Bank exports often group thousands, e.g. "1,250.00"
+		amount := strings.ReplaceAll(field(row.record, amountIndex), ",", "")
+		if _, err := decimal.NewFromString(amount); err != nil {
+			skip(row.line, fmt.Sprintf("invalid amount %q", field(row.record, amountIndex)))
+			continue
+		}
+
+		req := &entities.CreateDebtItemRequest{
+			DebtListID:    debtListID,
+			Amount:        amount,
+			Currency:      mapping.Currency,
+			PaymentDate:   paymentDate,
+			PaymentMethod: mapping.PaymentMethod,
+		}
+		if reference := field(row.record, referenceIndex); reference != "" {
+			req.Reference = &reference
+		}
+		if description := field(row.record, descriptionIndex); description != "" {
+			req.Description = &description
+		}
+
+		debtItem, err := s.CreateDebtItem(ctx, userID, req)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			skip(row.line, err.Error())
+			continue
+		}
+		result.Imported++
+		result.Rows = append(result.Rows, entities.PaymentImportRowResult{
+			Line:      row.line,
+			Status:    entities.ImportRowImported,
+			PaymentID: &debtItem.ID,
+		})
+	}
+
+	return result, nil
+}
+
+func (s *debtService) CreateDebtItem(ctx context.Context, userID uuid.UUID, req *entities.CreateDebtItemRequest) (*entities.DebtItem, error) {
+	// Validate input
+	if err := s.validateCreateDebtItemRequest(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	// Reject post-dated payments beyond the allowed window
+	if err := s.validatePaymentDate(req.PaymentDate); err != nil {
+		return nil, err
+	}
+
+	// Stop early if the request was cancelled or timed out
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// First check if debt list belongs to user (user is the owner)
+	belongs, err := s.debtListRepo.BelongsToUser(ctx, req.DebtListID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify debt list ownership: %w", err)
+	}
+	
+	if belongs {
+		// User owns the debt list, proceed with creation
+	} else {
+		// If user doesn't own it, check if they are a contact in the debt list
+		// This allows users to create debt items for debt lists where they owe money or are owed money
+		contactDebtLists, err := s.debtListRepo.GetDebtListsWhereUserIsContact(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get debt lists where user is contact: %w", err)
+		}
+		
+		// Check if the user is a contact in the specific debt list
+		userIsContact := false
+		for _, contactDebtList := range contactDebtLists {
+			if contactDebtList.ID == req.DebtListID {
+				userIsContact = true
+				break
+			}
+		}
+		
+		if !userIsContact {
+			// User neither owns the debt list nor is a contact in it
+			return nil, entities.ErrDebtListNotFound
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Get debt list for currency default
+	debtList, err := s.debtListRepo.GetByID(ctx, req.DebtListID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debt list: %w", err)
+	}
+
+	// Parse amount
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil {
+		return nil, entities.ErrInvalidAmount
+	}
+	
+	// Payment type defaults to a regular payment
+	paymentType := req.PaymentType
+	if paymentType == "" {
+		paymentType = entities.PaymentTypePayment
+	}
+
+	// Validate that amount is positive; only adjustments may be negative
+	if amount.IsZero() || (amount.IsNegative() && paymentType != entities.PaymentTypeAdjustment) {
+		return nil, entities.ErrInvalidAmount
+	}
+
+	// Set default currency if not provided
+	currency := req.Currency
+	if currency == "" {
+		currency = debtList.Currency
+	}
+
+	// A payment in another currency is recorded against the matching balance
+	if !strings.EqualFold(currency, debtList.Currency) {
+		balances, err := s.debtListRepo.GetBalances(ctx, debtList.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get debt list balances: %w", err)
+		}
+		matched := false
+		for _, balance := range balances {
+			if strings.EqualFold(currency, balance.Currency) {
+				currency = balance.Currency
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("%w: debt has no %s balance", entities.ErrInvalidCurrency, currency)
+		}
+	}
+
+	// Determine initial status based on the user's perspective
+	// When a user creates a payment, we need to consider their perspective:
+	// - If they owe money (to_pay from their perspective), payments are pending until verified
+	// - If they are owed money (to_receive from their perspective), payments are completed
+	initialStatus := "completed"
+	
 	// Check if the user is the owner or a contact to determine their perspective
 	if belongs {
 		// User owns the debt list, use the debt list's debt type
-		if debtList.DebtType == "to_pay" {
+		if debtList.DebtType == entities.DebtTypeToPay {
 			initialStatus = "pending"
 		}
 	} else {
 		// User is a contact, determine their perspective by flipping the debt type
 		// If the debt list is "to_receive" (someone owes them), then from the contact's perspective it's "to_pay"
-		if debtList.DebtType == "to_receive" {
+		if debtList.DebtType == entities.DebtTypeToReceive {
 			initialStatus = "pending"
 		}
 	}
@@ -464,10 +1441,13 @@ func (s *debtService) CreateDebtItem(ctx context.Context, userID uuid.UUID, req
 		Currency:          currency,
 		PaymentDate:       req.PaymentDate,
 		PaymentMethod:     req.PaymentMethod,
+		PaymentType:       paymentType,
 		Description:       req.Description,
+		Reference:         req.Reference,
 		Status:            initialStatus,
 		ReceiptPhotoURL:   req.ReceiptPhotoURL,
 		VerificationNotes: req.VerificationNotes,
+		CreatedBy:         userID,
 		CreatedAt:         time.Now(),
 		UpdatedAt:         time.Now(),
 	}
@@ -477,6 +1457,10 @@ func (s *debtService) CreateDebtItem(ctx context.Context, userID uuid.UUID, req
 		return nil, fmt.Errorf("invalid debt item entity: %w", err)
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if err := s.debtItemRepo.Create(ctx, debtItem); err != nil {
 		return nil, fmt.Errorf("failed to create debt item: %w", err)
 	}
@@ -527,9 +1511,60 @@ func (s *debtService) GetDebtItemForVerification(ctx context.Context, id uuid.UU
 	return debtItem, nil
 }
 
-func (s *debtService) GetDebtListItems(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) ([]entities.DebtItem, error) {
-	// First check if debt list belongs to user (user is the owner)
-	belongs, err := s.debtListRepo.BelongsToUser(ctx, debtListID, userID)
+// GetPaymentVerifier identifies who can verify a payment, by the same rules as
+// CanUserVerifyDebtItem. Both parties of the payment's debt list can ask.
+func (s *debtService) GetPaymentVerifier(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.PaymentVerifier, error) {
+	debtItem, err := s.debtItemRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, entities.ErrDebtItemNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get debt item: %w", err)
+	}
+
+	// Users who cannot see the payment are told it does not exist
+	if err := s.requireDebtListAccess(ctx, debtItem.DebtListID, userID); err != nil {
+		if errors.Is(err, entities.ErrDebtListNotFound) {
+			return nil, entities.ErrDebtItemNotFound
+		}
+		return nil, err
+	}
+
+	debtList, err := s.debtListRepo.GetByID(ctx, debtItem.DebtListID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debt list: %w", err)
+	}
+
+	// The creditor verifies: the owner when they are owed, the contact otherwise
+	verifier := &entities.PaymentVerifier{
+		DebtItemID: debtItem.ID,
+		Role:       entities.DebtRoleOwner,
+	}
+	if debtList.DebtType == entities.DebtTypeToPay {
+		verifier.Role = entities.DebtRoleContact
+	}
+
+	user, err := s.debtItemRepo.GetVerifier(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get verifier: %w", err)
+	}
+	if user != nil {
+		name := user.FullName()
+		verifier.UserID = &user.ID
+		verifier.Name = &name
+	}
+
+	verifier.IsRequester, err = s.debtItemRepo.CanUserVerifyDebtItem(ctx, id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify permission: %w", err)
+	}
+
+	return verifier, nil
+}
+
+func (s *debtService) GetDebtListItems(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) ([]entities.DebtItem, error) {
+	// First check if debt list belongs to user (user is the owner)
+	belongs, err := s.debtListRepo.BelongsToUser(ctx, debtListID, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify ownership: %w", err)
 	}
@@ -566,202 +1601,1146 @@ func (s *debtService) GetDebtListItems(ctx context.Context, debtListID uuid.UUID
 	return nil, entities.ErrDebtListNotFound
 }
 
-func (s *debtService) UpdateDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *entities.UpdateDebtItemRequest) (*entities.DebtItem, error) {
-	// Validate input
-	if err := s.validateUpdateDebtItemRequest(req); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+func (s *debtService) UpdateDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *entities.UpdateDebtItemRequest) (*entities.DebtItem, error) {
+	// Validate input
+	if err := s.validateUpdateDebtItemRequest(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	// First check if debt item belongs to user's debt list (user is the owner)
+	belongs, err := s.debtItemRepo.BelongsToUserDebtList(ctx, id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify ownership: %w", err)
+	}
+	
+	if belongs {
+		// User owns the debt list, proceed with update
+	} else {
+		// If user doesn't own it, check if they are a contact in the debt list
+		// This allows users to update debt items for debt lists where they owe money or are owed money
+		debtItem, err := s.debtItemRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get debt item: %w", err)
+		}
+		
+		// Check if the user is a contact in this debt list
+		contactDebtLists, err := s.debtListRepo.GetDebtListsWhereUserIsContact(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get debt lists where user is contact: %w", err)
+		}
+		
+		// Check if the user is a contact in the specific debt list
+		userIsContact := false
+		for _, contactDebtList := range contactDebtLists {
+			if contactDebtList.ID == debtItem.DebtListID {
+				userIsContact = true
+				break
+			}
+		}
+		
+		if !userIsContact {
+			// User neither owns the debt list nor is a contact in it
+			return nil, entities.ErrDebtItemNotFound
+		}
+	}
+
+	// Get existing debt item
+	debtItem, err := s.debtItemRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debt item: %w", err)
+	}
+
+	// Update fields if provided
+	if req.Amount != nil {
+		amount, err := decimal.NewFromString(*req.Amount)
+		if err != nil {
+			return nil, entities.ErrInvalidAmount
+		}
+		debtItem.Amount = amount
+	}
+	if req.Currency != nil {
+		debtItem.Currency = *req.Currency
+	}
+	if req.PaymentDate != nil {
+		if err := s.validatePaymentDate(*req.PaymentDate); err != nil {
+			return nil, err
+		}
+		debtItem.PaymentDate = *req.PaymentDate
+	}
+	if req.PaymentMethod != nil {
+		debtItem.PaymentMethod = *req.PaymentMethod
+	}
+	if req.Description != nil {
+		debtItem.Description = req.Description
+	}
+	if req.Status != nil {
+		debtItem.Status = *req.Status
+	}
+	if req.ReceiptPhotoURL != nil {
+		// If there's an old receipt photo, delete it from S3
+		if debtItem.ReceiptPhotoURL != nil && *debtItem.ReceiptPhotoURL != "" {
+			s.deleteReceipt(ctx, *debtItem.ReceiptPhotoURL)
+		}
+		debtItem.ReceiptPhotoURL = req.ReceiptPhotoURL
+	}
+	if req.VerificationNotes != nil {
+		debtItem.VerificationNotes = req.VerificationNotes
+	}
+
+	debtItem.UpdatedAt = time.Now()
+
+	// Validate updated debt item entity
+	if err := debtItem.IsValid(); err != nil {
+		return nil, fmt.Errorf("invalid updated debt item entity: %w", err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.debtItemRepo.Update(ctx, debtItem); err != nil {
+		return nil, fmt.Errorf("failed to update debt item: %w", err)
+	}
+
+	// Update debt list status, next payment date, and payment totals
+	if err := s.updateDebtListStatusAndPaymentTotals(ctx, debtItem.DebtListID); err != nil {
+		return nil, fmt.Errorf("failed to update debt list totals: %w", err)
+	}
+
+	return debtItem, nil
+}
+
+// deleteReceipt removes a receipt from storage without failing the caller. A
+// failed deletion is queued for retry when a receipt deletion service is set and
+// only logged otherwise.
+func (s *debtService) deleteReceipt(ctx context.Context, receiptURL string) {
+	var err error
+	if s.receiptDeletionService != nil {
+		err = s.receiptDeletionService.DeleteReceipt(ctx, receiptURL)
+	} else {
+		err = s.fileStorageService.DeleteReceipt(ctx, receiptURL)
+	}
+	if err != nil {
+		logger := zerolog.Ctx(ctx)
+		logger.Warn().
+			Err(err).
+			Str("receipt_url", receiptURL).
+			Msg("Failed to delete receipt photo")
+	}
+}
+
+func (s *debtService) DeleteDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	// Check if debt item belongs to user's debt list
+	belongs, err := s.debtItemRepo.BelongsToUserDebtList(ctx, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to verify ownership: %w", err)
+	}
+	if !belongs {
+		return entities.ErrDebtItemNotFound
+	}
+
+	// Get debt item to know which debt list to update
+	debtItem, err := s.debtItemRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get debt item: %w", err)
+	}
+
+	debtListID := debtItem.DebtListID
+
+	// Deleting a partly reversed payment would leave its reversals taking back
+	// an amount that was never recorded
+	reversed, err := s.debtItemRepo.GetReversedAmount(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get reversed amount: %w", err)
+	}
+	if !reversed.IsZero() {
+		return entities.ErrPaymentHasReversals
+	}
+
+	if s.softDeletePayments {
+		// Keep the receipt so the payment can be restored
+		if err := s.debtItemRepo.Delete(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete debt item: %w", err)
+		}
+	} else {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := s.debtItemRepo.Purge(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete debt item: %w", err)
+		}
+
+		// The receipt is removed only once the payment no longer points at it
+		if debtItem.ReceiptPhotoURL != nil && *debtItem.ReceiptPhotoURL != "" {
+			s.deleteReceipt(ctx, *debtItem.ReceiptPhotoURL)
+		}
+	}
+
+	// Update debt list status, next payment date, and payment totals
+	if err := s.updateDebtListStatusAndPaymentTotals(ctx, debtListID); err != nil {
+		return fmt.Errorf("failed to update debt list totals: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreDebtItem brings back a soft-deleted payment and recomputes the debt
+// list's totals with it. Only the debt list owner, who may delete payments, may
+// restore them.
+func (s *debtService) RestoreDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtItem, error) {
+	debtItem, err := s.debtItemRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, entities.ErrDebtItemNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get deleted debt item: %w", err)
+	}
+
+	belongs, err := s.debtListRepo.BelongsToUser(ctx, debtItem.DebtListID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify ownership: %w", err)
+	}
+	if !belongs {
+		return nil, entities.ErrDebtItemNotFound
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// A reversal comes back only while its payment does and it still fits in
+	// what the payment's other reversals left
+	if debtItem.ReversalOf != nil {
+		err = s.debtItemRepo.RestoreReversal(ctx, debtItem)
+	} else {
+		err = s.debtItemRepo.Restore(ctx, id)
+	}
+	if err != nil {
+		if errors.Is(err, entities.ErrDebtItemNotFound) || errors.Is(err, entities.ErrReversalExceedsPayment) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to restore debt item: %w", err)
+	}
+
+	// Update debt list status, next payment date, and payment totals
+	if err := s.updateDebtListStatusAndPaymentTotals(ctx, debtItem.DebtListID); err != nil {
+		return nil, fmt.Errorf("failed to update debt list totals: %w", err)
+	}
+
+	restored, err := s.debtItemRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get restored debt item: %w", err)
+	}
+	return restored, nil
+}
+
+// MoveDebtItem moves a payment recorded on the wrong debt list to another one and
+// recomputes the totals of both. The user must own or be the contact of both
+// lists. Payments tied to a reversal stay put, as moving one would separate it
+// from the other, and a payment moved to a debt with another counterparty goes
+// back to pending for them to verify.
+func (s *debtService) MoveDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID, targetDebtListID uuid.UUID) (*entities.DebtItem, error) {
+	debtItem, err := s.debtItemRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, entities.ErrDebtItemNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get debt item: %w", err)
+	}
+	sourceDebtListID := debtItem.DebtListID
+
+	if err := s.requireDebtListAccess(ctx, sourceDebtListID, userID); err != nil {
+		if errors.Is(err, entities.ErrDebtListNotFound) {
+			return nil, entities.ErrDebtItemNotFound
+		}
+		return nil, fmt.Errorf("failed to verify access to debt item: %w", err)
+	}
+	if err := s.requireDebtListAccess(ctx, targetDebtListID, userID); err != nil {
+		if errors.Is(err, entities.ErrDebtListNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to verify access to target debt list: %w", err)
+	}
+
+	if targetDebtListID == sourceDebtListID {
+		return nil, entities.ErrPaymentAlreadyOnDebtList
+	}
+	if debtItem.ReversalOf != nil {
+		return nil, entities.ErrPaymentNotMovable
+	}
+	reversed, err := s.debtItemRepo.GetReversedAmount(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reversed amount: %w", err)
+	}
+	if !reversed.IsZero() {
+		return nil, entities.ErrPaymentNotMovable
+	}
+
+	// The target must carry a balance in the payment's currency
+	target, err := s.debtListRepo.GetByID(ctx, targetDebtListID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target debt list: %w", err)
+	}
+	if !strings.EqualFold(debtItem.Currency, target.Currency) {
+		balances, err := s.debtListRepo.GetBalances(ctx, targetDebtListID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get debt list balances: %w", err)
+		}
+		matched := false
+		for _, balance := range balances {
+			if strings.EqualFold(debtItem.Currency, balance.Currency) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("%w: target debt has no %s balance", entities.ErrInvalidCurrency, debtItem.Currency)
+		}
+	}
+
+	// A verification only vouches for the counterparty who gave it, so a payment
+	// moved to a debt with someone else has to be verified again
+	source, err := s.debtListRepo.GetByID(ctx, sourceDebtListID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debt list: %w", err)
+	}
+	sameParties, err := s.haveSameParties(ctx, source, target)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	debtItem.DebtListID = targetDebtListID
+	if !sameParties && debtItem.Status != entities.PaymentStatusPending {
+		debtItem.Status = entities.PaymentStatusPending
+		debtItem.VerifiedBy = nil
+		debtItem.VerifiedAt = nil
+		debtItem.VerificationNotes = nil
+	}
+	if err := s.debtItemRepo.Update(ctx, debtItem); err != nil {
+		return nil, fmt.Errorf("failed to move debt item: %w", err)
+	}
+
+	// Update status, next payment date, and payment totals of both debt lists
+	for _, debtListID := range []uuid.UUID{sourceDebtListID, targetDebtListID} {
+		if err := s.updateDebtListStatusAndPaymentTotals(ctx, debtListID); err != nil {
+			return nil, fmt.Errorf("failed to update debt list totals: %w", err)
+		}
+	}
+
+	return debtItem, nil
+}
+
+// DeleteDebtItems removes several payments of a debt list at once, e.g. to clean up
+// a bad import. Only the debt list owner may do this, and totals are recomputed once.
+func (s *debtService) DeleteDebtItems(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return entities.ErrInvalidInput
+	}
+
+	// Only the owner may bulk-delete payments
+	belongs, err := s.debtListRepo.BelongsToUser(ctx, debtListID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to verify ownership: %w", err)
+	}
+	if !belongs {
+		return entities.ErrDebtListNotFound
+	}
+
+	// Load the debt list's payments to validate the IDs and find their receipts
+	debtItems, err := s.debtItemRepo.GetByDebtListID(ctx, debtListID)
+	if err != nil {
+		return fmt.Errorf("failed to get debt list items: %w", err)
+	}
+
+	itemsByID := make(map[uuid.UUID]entities.DebtItem, len(debtItems))
+	for _, item := range debtItems {
+		itemsByID[item.ID] = item
+	}
+
+	uniqueIDs := make([]uuid.UUID, 0, len(ids))
+	seen := make(map[uuid.UUID]bool, len(ids))
+	var receiptURLs []string
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		item, ok := itemsByID[id]
+		if !ok {
+			return entities.ErrDebtItemNotFound
+		}
+		uniqueIDs = append(uniqueIDs, id)
+		if item.ReceiptPhotoURL != nil && *item.ReceiptPhotoURL != "" {
+			receiptURLs = append(receiptURLs, *item.ReceiptPhotoURL)
+		}
+	}
+
+	// A payment's reversals go with it or the payment stays
+	for _, item := range debtItems {
+		if item.ReversalOf != nil && seen[*item.ReversalOf] && !seen[item.ID] {
+			return entities.ErrPaymentHasReversals
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if s.softDeletePayments {
+		// Soft-deleted payments keep their receipts so they can be restored
+		if err := s.debtItemRepo.DeleteByIDs(ctx, debtListID, uniqueIDs); err != nil {
+			return fmt.Errorf("failed to delete debt items: %w", err)
+		}
+	} else {
+		if err := s.debtItemRepo.PurgeByIDs(ctx, debtListID, uniqueIDs); err != nil {
+			return fmt.Errorf("failed to delete debt items: %w", err)
+		}
+
+		// Receipts are removed only once the payments are gone
+		for _, receiptURL := range receiptURLs {
+			s.deleteReceipt(ctx, receiptURL)
+		}
+	}
+
+	// Update debt list status, next payment date, and payment totals
+	if err := s.updateDebtListStatusAndPaymentTotals(ctx, debtListID); err != nil {
+		return fmt.Errorf("failed to update debt list totals: %w", err)
+	}
+
+	return nil
+}
+
+// Debt analytics and reporting
+
+func (s *debtService) GetOverdueItems(ctx context.Context, userID uuid.UUID, direction string) ([]entities.DebtList, error) {
+	if !entities.IsValidDebtDirection(direction) {
+		return nil, entities.ErrInvalidDebtDirection
+	}
+
+	ownedDebtLists, err := s.debtListRepo.GetOverdueForUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overdue items: %w", err)
+	}
+
+	contactDebtLists, err := s.debtListRepo.GetOverdueWhereUserIsContact(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overdue items where user is contact: %w", err)
+	}
+
+	return filterByDirection(ownedDebtLists, contactDebtLists, direction), nil
+}
+
+// AcknowledgeOverdue records that the user has seen their overdue debts as of
+// now. It only stores the timestamp and leaves every debt's status unchanged.
+func (s *debtService) AcknowledgeOverdue(ctx context.Context, userID uuid.UUID) (*entities.OverdueAcknowledgment, error) {
+	if s.userSettingsRepo == nil {
+		return nil, errors.New("user settings repository is not configured")
+	}
+
+	settings, err := s.userSettingsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, entities.ErrUserSettingsNotFound) {
+			return nil, fmt.Errorf("failed to get user settings: %w", err)
+		}
+		settings = entities.NewDefaultUserSettings(userID)
+	}
+
+	acknowledgedAt := time.Now()
+	settings.OverdueAcknowledgedAt = &acknowledgedAt
+	if err := s.userSettingsRepo.Upsert(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to save overdue acknowledgment: %w", err)
+	}
+
+	return &entities.OverdueAcknowledgment{AcknowledgedAt: acknowledgedAt}, nil
+}
+
+// GetOverdueAcknowledgedAt returns when the user last acknowledged their overdue
+// debts, or nil if they never have
+func (s *debtService) GetOverdueAcknowledgedAt(ctx context.Context, userID uuid.UUID) (*time.Time, error) {
+	if s.userSettingsRepo == nil {
+		return nil, nil
+	}
+
+	settings, err := s.userSettingsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, entities.ErrUserSettingsNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get user settings: %w", err)
+	}
+
+	return settings.OverdueAcknowledgedAt, nil
+}
+
+// GetOverdueTotals sums the remaining balance of the user's overdue debts, owned or
+// shared with them as the contact, per currency and direction, ordered by currency
+func (s *debtService) GetOverdueTotals(ctx context.Context, userID uuid.UUID) ([]entities.OverdueTotal, error) {
+	overdueDebtLists, err := s.GetOverdueItems(ctx, userID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return totalsByCurrency(overdueDebtLists), nil
+}
+
+// GetAttention returns the totals of the user's overdue debts and of those with a
+// payment due within days, owned or shared with them as the contact
+func (s *debtService) GetAttention(ctx context.Context, userID uuid.UUID, days int) (*entities.DebtAttention, error) {
+	overdueDebtLists, err := s.GetOverdueItems(ctx, userID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	dueSoonDebtLists, err := s.GetDueSoonItems(ctx, userID, days, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.DebtAttention{
+		Days:    days,
+		Overdue: totalsByCurrency(overdueDebtLists),
+		DueSoon: totalsByCurrency(dueSoonDebtLists),
+	}, nil
+}
+
+// totalsByCurrency sums the remaining balance of debt lists, already in the user's
+// perspective, per currency and direction, ordered by currency
+func totalsByCurrency(debtLists []entities.DebtList) []entities.OverdueTotal {
+	totalsByCurrency := make(map[string]*entities.OverdueTotal)
+	for _, debtList := range debtLists {
+		total, ok := totalsByCurrency[debtList.Currency]
+		if !ok {
+			total = &entities.OverdueTotal{
+				Currency: debtList.Currency,
+				OwedToMe: decimal.Zero,
+				IOwe:     decimal.Zero,
+			}
+			totalsByCurrency[debtList.Currency] = total
+		}
+		if debtList.MatchesDirection(entities.DebtDirectionOwedToMe) {
+			total.OwedToMe = total.OwedToMe.Add(debtList.TotalRemainingDebt)
+		} else {
+			total.IOwe = total.IOwe.Add(debtList.TotalRemainingDebt)
+		}
+		total.Count++
+	}
+
+	totals := make([]entities.OverdueTotal, 0, len(totalsByCurrency))
+	for _, total := range totalsByCurrency {
+		totals = append(totals, *total)
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		return totals[i].Currency < totals[j].Currency
+	})
+
+	return totals
+}
+
+func (s *debtService) GetDueSoonItems(ctx context.Context, userID uuid.UUID, days int, direction string) ([]entities.DebtList, error) {
+	if !entities.IsValidDebtDirection(direction) {
+		return nil, entities.ErrInvalidDebtDirection
+	}
+
+	dueDate := time.Now().AddDate(0, 0, days)
+	ownedDebtLists, err := s.debtListRepo.GetDueSoonForUser(ctx, userID, dueDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due soon items: %w", err)
+	}
+
+	contactDebtLists, err := s.debtListRepo.GetDueSoonWhereUserIsContact(ctx, userID, dueDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due soon items where user is contact: %w", err)
+	}
+
+	return filterByDirection(ownedDebtLists, contactDebtLists, direction), nil
+}
+
+// filterByDirection merges owned debt lists with those where the user is the contact,
+// flipping the latter to the user's perspective, and keeps the ones matching direction
+// ordered by next payment date
+func filterByDirection(ownedDebtLists, contactDebtLists []entities.DebtList, direction string) []entities.DebtList {
+	for i := range contactDebtLists {
+		contactDebtLists[i].DebtType = entities.OppositeDebtType(contactDebtLists[i].DebtType)
+	}
+
+	debtLists := make([]entities.DebtList, 0, len(ownedDebtLists)+len(contactDebtLists))
+	for _, debtList := range append(ownedDebtLists, contactDebtLists...) {
+		if debtList.MatchesDirection(direction) {
+			debtLists = append(debtLists, debtList)
+		}
+	}
+
+	sort.SliceStable(debtLists, func(i, j int) bool {
+		return debtLists[i].NextPaymentDate.Before(debtLists[j].NextPaymentDate)
+	})
+
+	return debtLists
+}
+
+func (s *debtService) GetPaymentSchedule(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) ([]entities.PaymentScheduleItem, error) {
+	_, schedule, err := s.paymentSchedule(ctx, debtListID, userID)
+	return schedule, err
+}
+
+// GetPaymentScheduleInCurrency returns the payment schedule with each item's
+// amounts also converted into displayCurrency. Stored amounts are left as they
+// are, and without a rate into displayCurrency the schedule is returned unconverted.
+func (s *debtService) GetPaymentScheduleInCurrency(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID, displayCurrency string) ([]entities.PaymentScheduleItem, error) {
+	debtList, schedule, err := s.paymentSchedule(ctx, debtListID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if s.currencyService == nil || strings.EqualFold(debtList.Currency, displayCurrency) {
+		return schedule, nil
+	}
+
+	rate, err := s.currencyService.GetExchangeRate(ctx, debtList.Currency, displayCurrency)
+	if err != nil {
+		if errors.Is(err, entities.ErrExchangeRateUnavailable) {
+			return schedule, nil
+		}
+		return nil, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+
+	for i := range schedule {
+		schedule[i].Display = &entities.ScheduleItemDisplay{
+			Currency:        displayCurrency,
+			ExchangeRate:    rate,
+			Amount:          schedule[i].Amount.Mul(rate).Round(2),
+			ScheduledAmount: schedule[i].ScheduledAmount.Mul(rate).Round(2),
+			PaidAmount:      schedule[i].PaidAmount.Mul(rate).Round(2),
+		}
+	}
+	return schedule, nil
+}
+
+// requireDebtListAccess returns ErrDebtListNotFound unless the user owns the
+// debt list or is its contact
+func (s *debtService) requireDebtListAccess(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) error {
+	belongs, err := s.debtListRepo.BelongsToUser(ctx, debtListID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to verify ownership: %w", err)
+	}
+	if belongs {
+		return nil
+	}
+	isContact, err := s.debtListRepo.IsContactOfDebtList(ctx, debtListID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to verify contact association: %w", err)
+	}
+	if !isContact {
+		return entities.ErrDebtListNotFound
+	}
+	return nil
+}
+
+// paymentSchedule returns a debt list the user owns or is the contact of,
+// together with its payment schedule
+func (s *debtService) paymentSchedule(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) (*entities.DebtList, []entities.PaymentScheduleItem, error) {
+	if err := s.requireDebtListAccess(ctx, debtListID, userID); err != nil {
+		return nil, nil, err
+	}
+
+	// Get debt list
+	debtList, err := s.debtListRepo.GetByID(ctx, debtListID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get debt list: %w", err)
+	}
+
+	// Get payments
+	payments, err := s.debtItemRepo.GetCompletedPaymentsForDebtList(ctx, debtListID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get payments: %w", err)
+	}
+
+	schedule := s.paymentScheduleService.CalculatePaymentSchedule(debtList, payments)
+	return debtList, schedule, nil
+}
+
+// GetScheduleVariance compares each scheduled installment of a debt list with the
+// completed payments, allocated to installments in payment date order
+func (s *debtService) GetScheduleVariance(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) ([]entities.ScheduleVariance, error) {
+	// Owners and contacts can both analyse the schedule
+	if err := s.requireDebtListAccess(ctx, debtListID, userID); err != nil {
+		return nil, err
+	}
+
+	debtList, err := s.debtListRepo.GetByID(ctx, debtListID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debt list: %w", err)
+	}
+
+	payments, err := s.debtItemRepo.GetCompletedPaymentsForDebtList(ctx, debtListID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payments: %w", err)
+	}
+
+	// The schedule as planned, before any payment was made
+	schedule := s.paymentScheduleService.CalculatePaymentSchedule(debtList, nil)
+	variances := make([]entities.ScheduleVariance, len(schedule))
+	for i, item := range schedule {
+		variances[i] = entities.ScheduleVariance{
+			PaymentNumber:   item.PaymentNumber,
+			ScheduledDate:   item.DueDate,
+			ScheduledAmount: item.ScheduledAmount,
+			ActualAmount:    decimal.Zero,
+		}
+	}
+
+	// Each payment fills the earliest installments that are not yet fully paid.
+	// Reversals and other negative adjustments then take back from the latest
+	// filled installments, so the net amount paid covers the same installments
+	// the payment schedule shows as paid.
+	installment := 0
+	takenBack := decimal.Zero
+	overpaid := decimal.Zero
+	for _, payment := range payments {
+		if !payment.Amount.IsPositive() {
+			takenBack = takenBack.Sub(payment.Amount)
+			continue
+		}
+		remaining := payment.Amount
+		for remaining.IsPositive() && installment < len(variances) {
+			v := &variances[installment]
+			applied := decimal.Min(remaining, v.ScheduledAmount.Sub(v.ActualAmount))
+			v.ActualAmount = v.ActualAmount.Add(applied)
+			paymentDate := payment.PaymentDate
+			v.ActualDate = &paymentDate
+			remaining = remaining.Sub(applied)
+			if v.ActualAmount.GreaterThanOrEqual(v.ScheduledAmount) {
+				installment++
+			}
+		}
+		overpaid = overpaid.Add(remaining)
+	}
+	takenBack = takenBack.Sub(overpaid)
+	for i := len(variances) - 1; i >= 0 && takenBack.IsPositive(); i-- {
+		v := &variances[i]
+		taken := decimal.Min(takenBack, v.ActualAmount)
+		v.ActualAmount = v.ActualAmount.Sub(taken)
+		takenBack = takenBack.Sub(taken)
+		if v.ActualAmount.IsZero() {
+			v.ActualDate = nil
+		}
+	}
+
+	for i := range variances {
+		v := &variances[i]
+		v.AmountVariance = v.ActualAmount.Sub(v.ScheduledAmount)
+		if v.ActualDate == nil {
+			v.Status = entities.VarianceUnpaid
+			continue
+		}
+		days := calendarDaysBetween(v.ScheduledDate, *v.ActualDate)
+		v.DaysVariance = &days
+		switch {
+		case v.ActualAmount.LessThan(v.ScheduledAmount):
+			v.Status = entities.VariancePartial
+		case days < 0:
+			v.Status = entities.VarianceEarly
+		case days > 0:
+			v.Status = entities.VarianceLate
+		default:
+			v.Status = entities.VarianceOnTime
+		}
+	}
+
+	return variances, nil
+}
+
+// calendarDaysBetween returns how many calendar days to is after from, negative
+// when it is before, ignoring the time of day
+func calendarDaysBetween(from, to time.Time) int {
+	fromDay := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDay := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDay.Sub(fromDay).Hours() / 24)
+}
+
+// GetNextPayment returns the first installment of the debt list's schedule that is
+// not fully paid, or nil when every installment has been paid
+func (s *debtService) GetNextPayment(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) (*entities.NextPayment, error) {
+	// Owners and contacts can both see the next payment
+	if err := s.requireDebtListAccess(ctx, debtListID, userID); err != nil {
+		return nil, err
+	}
+
+	debtList, err := s.debtListRepo.GetByID(ctx, debtListID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debt list: %w", err)
+	}
+
+	payments, err := s.debtItemRepo.GetCompletedPaymentsForDebtList(ctx, debtListID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payments: %w", err)
+	}
+
+	// Contacts see the debt type from their own perspective
+	debtType := debtList.DebtType
+	if debtList.UserID != userID {
+		debtType = entities.OppositeDebtType(debtType)
+	}
+
+	for _, item := range s.paymentScheduleService.CalculatePaymentSchedule(debtList, payments) {
+		if item.Status == "paid" {
+			continue
+		}
+		return &entities.NextPayment{
+			DebtListID:    debtList.ID,
+			DebtType:      debtType,
+			PaymentNumber: item.PaymentNumber,
+			DueDate:       item.DueDate,
+			Amount:        item.Amount,
+			Currency:      debtList.Currency,
+			Status:        item.Status,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// GetPayoffProjection projects when the debt list will be paid off if its remaining
+// installments are paid on schedule. Payments already made, including partial ones,
+// reduce what is left, and overdue installments are projected from today.
+func (s *debtService) GetPayoffProjection(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) (*entities.PayoffProjection, error) {
+	// Owners and contacts can both see the projection
+	if err := s.requireDebtListAccess(ctx, debtListID, userID); err != nil {
+		return nil, err
 	}
 
-	// First check if debt item belongs to user's debt list (user is the owner)
-	belongs, err := s.debtItemRepo.BelongsToUserDebtList(ctx, id, userID)
+	debtList, err := s.debtListRepo.GetByID(ctx, debtListID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to verify ownership: %w", err)
+		return nil, fmt.Errorf("failed to get debt list: %w", err)
 	}
-	
-	if belongs {
-		// User owns the debt list, proceed with update
-	} else {
-		// If user doesn't own it, check if they are a contact in the debt list
-		// This allows users to update debt items for debt lists where they owe money or are owed money
-		debtItem, err := s.debtItemRepo.GetByID(ctx, id)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get debt item: %w", err)
-		}
-		
-		// Check if the user is a contact in this debt list
-		contactDebtLists, err := s.debtListRepo.GetDebtListsWhereUserIsContact(ctx, userID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get debt lists where user is contact: %w", err)
+
+	payments, err := s.debtItemRepo.GetCompletedPaymentsForDebtList(ctx, debtListID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payments: %w", err)
+	}
+
+	projection := &entities.PayoffProjection{
+		DebtListID:      debtList.ID,
+		RemainingAmount: decimal.Zero,
+		Currency:        debtList.Currency,
+		OnTrack:         true,
+	}
+
+	// Each unpaid installment is paid on its due date, or a period after the
+	// previous projected payment when the schedule has fallen behind
+	now := time.Now()
+	var projected *time.Time
+	for _, item := range s.paymentScheduleService.CalculatePaymentSchedule(debtList, payments) {
+		projection.ScheduledPayoffDate = item.DueDate
+		if item.Status == "paid" {
+			continue
 		}
-		
-		// Check if the user is a contact in the specific debt list
-		userIsContact := false
-		for _, contactDebtList := range contactDebtLists {
-			if contactDebtList.ID == debtItem.DebtListID {
-				userIsContact = true
-				break
-			}
+
+		earliest := now
+		if projected != nil {
+			earliest = s.paymentScheduleService.CalculateNextPaymentDate(debtList, projected)
 		}
-		
-		if !userIsContact {
-			// User neither owns the debt list nor is a contact in it
-			return nil, entities.ErrDebtItemNotFound
+		paymentDate := item.DueDate
+		if paymentDate.Before(earliest) {
+			paymentDate = earliest
 		}
-	}
+		projected = &paymentDate
 
-	// Get existing debt item
-	debtItem, err := s.debtItemRepo.GetByID(ctx, id)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get debt item: %w", err)
+		projection.RemainingPayments++
+		projection.RemainingAmount = projection.RemainingAmount.Add(item.Amount)
 	}
 
-	// Update fields if provided
-	if req.Amount != nil {
-		amount, err := decimal.NewFromString(*req.Amount)
-		if err != nil {
-			return nil, entities.ErrInvalidAmount
+	if projected != nil {
+		projection.ProjectedPayoffDate = projected
+		if days := calendarDaysBetween(projection.ScheduledPayoffDate, *projected); days > 0 {
+			projection.DaysBehind = days
+			projection.OnTrack = false
 		}
-		debtItem.Amount = amount
 	}
-	if req.Currency != nil {
-		debtItem.Currency = *req.Currency
-	}
-	if req.PaymentDate != nil {
-		debtItem.PaymentDate = *req.PaymentDate
+
+	return projection, nil
+}
+
+// GetContactSummary summarizes the user's debts with one of their contacts: the
+// debts the user recorded against the contact and, when the contact is a registered
+// user, the debts that user recorded with the requesting user as their contact
+func (s *debtService) GetContactSummary(ctx context.Context, contactID uuid.UUID, userID uuid.UUID) (*entities.ContactSummary, error) {
+	userContact, err := s.contactRepo.GetUserContactRelation(ctx, userID, contactID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify contact access: %w", err)
 	}
-	if req.PaymentMethod != nil {
-		debtItem.PaymentMethod = *req.PaymentMethod
+	contact, err := s.contactRepo.GetContactWithUser(ctx, contactID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact: %w", err)
 	}
-	if req.Description != nil {
-		debtItem.Description = req.Description
+
+	ownDebtLists, sharedDebtLists, err := s.ownAndSharedDebtLists(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
-	if req.Status != nil {
-		debtItem.Status = *req.Status
+	var debtLists []entities.DebtListResponse
+	for _, debtList := range ownDebtLists {
+		if debtList.ContactID == contactID {
+			debtLists = append(debtLists, debtList)
+		}
 	}
-	if req.ReceiptPhotoURL != nil {
-		// If there's an old receipt photo, delete it from S3
-		if debtItem.ReceiptPhotoURL != nil && *debtItem.ReceiptPhotoURL != "" {
-			if err := s.fileStorageService.DeleteReceipt(ctx, *debtItem.ReceiptPhotoURL); err != nil {
-				// Log the error but don't fail the update
-				// In production, you might want to handle this differently
-				fmt.Printf("Warning: failed to delete old receipt photo: %v\n", err)
+	if contact.UserIDRef != nil {
+		for _, debtList := range sharedDebtLists {
+			if debtList.UserID == *contact.UserIDRef {
+				debtLists = append(debtLists, debtList)
 			}
 		}
-		debtItem.ReceiptPhotoURL = req.ReceiptPhotoURL
 	}
-	if req.VerificationNotes != nil {
-		debtItem.VerificationNotes = req.VerificationNotes
-	}
-
-	debtItem.UpdatedAt = time.Now()
 
-	// Validate updated debt item entity
-	if err := debtItem.IsValid(); err != nil {
-		return nil, fmt.Errorf("invalid updated debt item entity: %w", err)
+	summary := &entities.ContactSummary{
+		Contact:     entities.NewContactResponse(contact, userContact, s.contactNameSource),
+		IsAppUser:   contact.IsActiveUser(),
+		NetBalances: contactNetBalances(debtLists),
 	}
 
-	if err := s.debtItemRepo.Update(ctx, debtItem); err != nil {
-		return nil, fmt.Errorf("failed to update debt item: %w", err)
-	}
+	for _, debtList := range debtLists {
+		if entities.DebtDirectionFor(debtList.DebtType) == entities.DebtDirectionIOwe {
+			summary.IOweCount++
+		} else {
+			summary.OwedToMeCount++
+		}
 
-	// Update debt list status, next payment date, and payment totals
-	if err := s.updateDebtListStatusAndPaymentTotals(ctx, debtItem.DebtListID); err != nil {
-		return nil, fmt.Errorf("failed to update debt list totals: %w", err)
+		// Only open debts have an upcoming payment
+		if debtList.Status != "active" && debtList.Status != "overdue" {
+			continue
+		}
+
+		nextPayment, err := s.GetNextPayment(ctx, debtList.ID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next payment: %w", err)
+		}
+		if nextPayment != nil && (summary.NextPayment == nil || nextPayment.DueDate.Before(summary.NextPayment.DueDate)) {
+			summary.NextPayment = nextPayment
+		}
 	}
 
-	return debtItem, nil
+	return summary, nil
 }
 
-func (s *debtService) DeleteDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
-	// Check if debt item belongs to user's debt list
-	belongs, err := s.debtItemRepo.BelongsToUserDebtList(ctx, id, userID)
+// GetContactReconciliation compares the debts the user recorded with a contact who
+// is a registered user against the debts that user recorded with them. Every total
+// the two sides disagree on is reported as a discrepancy; a side without records in
+// a currency counts as zero.
+func (s *debtService) GetContactReconciliation(ctx context.Context, contactID uuid.UUID, userID uuid.UUID) (*entities.ContactReconciliation, error) {
+	if _, err := s.contactRepo.GetUserContactRelation(ctx, userID, contactID); err != nil {
+		return nil, fmt.Errorf("failed to verify contact access: %w", err)
+	}
+	contact, err := s.contactRepo.GetContactWithUser(ctx, contactID)
 	if err != nil {
-		return fmt.Errorf("failed to verify ownership: %w", err)
+		return nil, fmt.Errorf("failed to get contact: %w", err)
 	}
-	if !belongs {
-		return entities.ErrDebtItemNotFound
+	if !contact.IsActiveUser() {
+		return nil, entities.ErrContactNotAppUser
 	}
 
-	// Get debt item to know which debt list to update
-	debtItem, err := s.debtItemRepo.GetByID(ctx, id)
+	ownDebtLists, sharedDebtLists, err := s.ownAndSharedDebtLists(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("failed to get debt item: %w", err)
+		return nil, err
 	}
 
-	debtListID := debtItem.DebtListID
-
-	// If there's a receipt photo, delete it from S3
-	if debtItem.ReceiptPhotoURL != nil && *debtItem.ReceiptPhotoURL != "" {
-		if err := s.fileStorageService.DeleteReceipt(ctx, *debtItem.ReceiptPhotoURL); err != nil {
-			// Log the error but don't fail the deletion
-			// In production, you might want to handle this differently
-			fmt.Printf("Warning: failed to delete receipt photo: %v\n", err)
+	currencies := make(map[string]*entities.CurrencyReconciliation)
+	add := func(debtList entities.DebtListResponse, mine bool) {
+		currency, ok := currencies[debtList.Currency]
+		if !ok {
+			currency = &entities.CurrencyReconciliation{
+				Currency: debtList.Currency,
+				Mine:     newReconciliationTotals(),
+				Theirs:   newReconciliationTotals(),
+			}
+			currencies[debtList.Currency] = currency
+		}
+		totals := &currency.Theirs
+		if mine {
+			totals = &currency.Mine
+		}
+		totals.DebtCount++
+		if entities.DebtDirectionFor(debtList.DebtType) == entities.DebtDirectionIOwe {
+			totals.IOwe = totals.IOwe.Add(debtList.TotalAmount)
+			totals.PaidByMe = totals.PaidByMe.Add(debtList.TotalPaymentsMade)
+		} else {
+			totals.OwedToMe = totals.OwedToMe.Add(debtList.TotalAmount)
+			totals.PaidToMe = totals.PaidToMe.Add(debtList.TotalPaymentsMade)
+		}
+	}
+	for _, debtList := range ownDebtLists {
+		if debtList.ContactID == contactID {
+			add(debtList, true)
+		}
+	}
+	for _, debtList := range sharedDebtLists {
+		if debtList.UserID == *contact.UserIDRef {
+			add(debtList, false)
 		}
 	}
 
-	if err := s.debtItemRepo.Delete(ctx, id); err != nil {
-		return fmt.Errorf("failed to delete debt item: %w", err)
+	reconciliation := &entities.ContactReconciliation{
+		ContactID:     contactID,
+		Currencies:    make([]entities.CurrencyReconciliation, 0, len(currencies)),
+		Discrepancies: []entities.ReconciliationDiscrepancy{},
+	}
+	for _, currency := range currencies {
+		reconciliation.Currencies = append(reconciliation.Currencies, *currency)
 	}
+	sort.Slice(reconciliation.Currencies, func(i, j int) bool {
+		return reconciliation.Currencies[i].Currency < reconciliation.Currencies[j].Currency
+	})
 
-	// Update debt list status, next payment date, and payment totals
-	if err := s.updateDebtListStatusAndPaymentTotals(ctx, debtListID); err != nil {
-		return fmt.Errorf("failed to update debt list totals: %w", err)
+	for _, currency := range reconciliation.Currencies {
+		compared := []struct {
+			field        string
+			mine, theirs decimal.Decimal
+		}{
+			{entities.ReconciliationOwedToMe, currency.Mine.OwedToMe, currency.Theirs.OwedToMe},
+			{entities.ReconciliationIOwe, currency.Mine.IOwe, currency.Theirs.IOwe},
+			{entities.ReconciliationPaidToMe, currency.Mine.PaidToMe, currency.Theirs.PaidToMe},
+			{entities.ReconciliationPaidByMe, currency.Mine.PaidByMe, currency.Theirs.PaidByMe},
+		}
+		for _, c := range compared {
+			if c.mine.Equal(c.theirs) {
+				continue
+			}
+			reconciliation.Discrepancies = append(reconciliation.Discrepancies, entities.ReconciliationDiscrepancy{
+				Currency:   currency.Currency,
+				Field:      c.field,
+				Mine:       c.mine,
+				Theirs:     c.theirs,
+				Difference: c.mine.Sub(c.theirs),
+			})
+		}
 	}
+	reconciliation.Reconciled = len(reconciliation.Discrepancies) == 0
 
-	return nil
+	return reconciliation, nil
 }
 
-// Debt analytics and reporting
-
-func (s *debtService) GetOverdueItems(ctx context.Context, userID uuid.UUID) ([]entities.DebtList, error) {
-	debtLists, err := s.debtListRepo.GetOverdueForUser(ctx, userID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get overdue items: %w", err)
+// newReconciliationTotals returns reconciliation totals with every amount at zero
+func newReconciliationTotals() entities.ReconciliationTotals {
+	return entities.ReconciliationTotals{
+		OwedToMe: decimal.Zero,
+		IOwe:     decimal.Zero,
+		PaidToMe: decimal.Zero,
+		PaidByMe: decimal.Zero,
 	}
-
-	return debtLists, nil
 }
 
-func (s *debtService) GetDueSoonItems(ctx context.Context, userID uuid.UUID, days int) ([]entities.DebtList, error) {
-	dueDate := time.Now().AddDate(0, 0, days)
-	debtLists, err := s.debtListRepo.GetDueSoonForUser(ctx, userID, dueDate)
+// GetDebtsByContact groups the user's debts under the contact they are with. Debts
+// other users recorded with the user as their contact are grouped under the user's
+// own contact for that user, or under the owner when the user has none. Groups are
+// sorted by their largest absolute net balance, largest first.
+func (s *debtService) GetDebtsByContact(ctx context.Context, userID uuid.UUID) ([]entities.ContactDebts, error) {
+	ownDebtLists, sharedDebtLists, err := s.ownAndSharedDebtLists(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get due soon items: %w", err)
+		return nil, err
 	}
 
-	return debtLists, nil
-}
+	var groups []*entities.ContactDebts
+	groupsByKey := make(map[uuid.UUID]*entities.ContactDebts)
+	addToGroup := func(key uuid.UUID, contact entities.ContactResponse, debtList entities.DebtListResponse) {
+		group, ok := groupsByKey[key]
+		if !ok {
+			group = &entities.ContactDebts{Contact: contact}
+			groupsByKey[key] = group
+			groups = append(groups, group)
+		}
+		group.Debts = append(group.Debts, debtList)
+	}
 
-func (s *debtService) GetPaymentSchedule(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) ([]entities.PaymentScheduleItem, error) {
-	// Check if debt list belongs to user
-	belongs, err := s.debtListRepo.BelongsToUser(ctx, debtListID, userID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to verify ownership: %w", err)
+	for _, debtList := range ownDebtLists {
+		addToGroup(debtList.ContactID, debtList.Contact, debtList)
 	}
-	if !belongs {
-		isContact, err := s.debtListRepo.IsContactOfDebtList(ctx, debtListID, userID)
+
+	if len(sharedDebtLists) > 0 {
+		userContacts, err := s.contactRepo.GetUserContacts(ctx, userID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to verify contact association: %w", err)
+			return nil, fmt.Errorf("failed to get user contacts: %w", err)
 		}
-		if !isContact {
-			return nil, entities.ErrDebtListNotFound
+		contactsByUser := make(map[uuid.UUID]entities.ContactResponse)
+		for _, userContact := range userContacts {
+			contact, err := s.contactRepo.GetContactWithUser(ctx, userContact.ContactID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get contact: %w", err)
+			}
+			if contact.UserIDRef == nil {
+				continue
+			}
+			contactsByUser[*contact.UserIDRef] = entities.NewContactResponse(contact, &userContact, s.contactNameSource)
+		}
+
+		for _, debtList := range sharedDebtLists {
+			if contact, ok := contactsByUser[debtList.UserID]; ok {
+				addToGroup(contact.ID, contact, debtList)
+			} else {
+				addToGroup(debtList.UserID, debtList.Contact, debtList)
+			}
 		}
 	}
 
-	// Get debt list
-	debtList, err := s.debtListRepo.GetByID(ctx, debtListID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get debt list: %w", err)
+	result := make([]entities.ContactDebts, len(groups))
+	largestNet := make(map[uuid.UUID]decimal.Decimal, len(groups))
+	for i, group := range groups {
+		group.NetBalances = contactNetBalances(group.Debts)
+		largest := decimal.Zero
+		for _, balance := range group.NetBalances {
+			largest = decimal.Max(largest, balance.Net.Abs())
+		}
+		largestNet[group.Contact.ID] = largest
+		result[i] = *group
 	}
+	sort.SliceStable(result, func(i, j int) bool {
+		left, right := largestNet[result[i].Contact.ID], largestNet[result[j].Contact.ID]
+		if !left.Equal(right) {
+			return left.GreaterThan(right)
+		}
+		return result[i].Contact.Name < result[j].Contact.Name
+	})
 
-	// Get payments
-	payments, err := s.debtItemRepo.GetCompletedPaymentsForDebtList(ctx, debtListID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get payments: %w", err)
+	return result, nil
+}
+
+// contactNetBalances totals what is still owed on the open debts in each currency,
+// sorted by currency
+func contactNetBalances(debtLists []entities.DebtListResponse) []entities.ContactNetBalance {
+	balancesByCurrency := make(map[string]*entities.ContactNetBalance)
+	for _, debtList := range debtLists {
+		// Only open debts still have something owed
+		if debtList.Status != "active" && debtList.Status != "overdue" {
+			continue
+		}
+
+		balance, ok := balancesByCurrency[debtList.Currency]
+		if !ok {
+			balance = &entities.ContactNetBalance{
+				Currency: debtList.Currency,
+				OwedToMe: decimal.Zero,
+				IOwe:     decimal.Zero,
+			}
+			balancesByCurrency[debtList.Currency] = balance
+		}
+		if entities.DebtDirectionFor(debtList.DebtType) == entities.DebtDirectionIOwe {
+			balance.IOwe = balance.IOwe.Add(debtList.TotalRemainingDebt)
+		} else {
+			balance.OwedToMe = balance.OwedToMe.Add(debtList.TotalRemainingDebt)
+		}
 	}
 
-	schedule := s.paymentScheduleService.CalculatePaymentSchedule(debtList, payments)
-	return schedule, nil
+	balances := make([]entities.ContactNetBalance, 0, len(balancesByCurrency))
+	for _, balance := range balancesByCurrency {
+		balance.Net = balance.OwedToMe.Sub(balance.IOwe)
+		balances = append(balances, *balance)
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		return balances[i].Currency < balances[j].Currency
+	})
+	return balances
 }
 
 func (s *debtService) GetUpcomingPayments(ctx context.Context, userID uuid.UUID, days int) ([]entities.UpcomingPayment, error) {
@@ -821,6 +2800,7 @@ func (s *debtService) GetUpcomingPayments(ctx context.Context, userID uuid.UUID,
 					NextPaymentDate:     debtList.NextPaymentDate,
 					InstallmentPlan:     debtList.InstallmentPlan,
 					NumberOfPayments:    debtList.NumberOfPayments,
+					PaymentWeekday:      debtList.PaymentWeekday,
 					Description:         debtList.Description,
 					Notes:               debtList.Notes,
 					CreatedAt:           debtList.CreatedAt,
@@ -861,11 +2841,24 @@ func (s *debtService) GetUpcomingPayments(ctx context.Context, userID uuid.UUID,
 			
 			if isOverdue || isUpcoming {
 				// Get contact name from UserContact (user-specific)
+				// Falls back to a placeholder if the user removed the contact
+				contactName := entities.UnknownContactName
 				userContact, err := s.contactRepo.GetUserContactRelation(ctx, userID, debtList.ContactID)
-				contactName := "Unknown"
-				if err == nil && userContact != nil {
+				if err != nil && !errors.Is(err, entities.ErrContactNotFound) {
+					return nil, fmt.Errorf("failed to get contact for debt list: %w", err)
+				}
+				if userContact != nil {
 					contactName = userContact.Name
 				}
+				if s.contactNameSource == entities.ContactNameSourceVerified {
+					contact, err := s.contactRepo.GetContactWithUser(ctx, debtList.ContactID)
+					if err != nil {
+						return nil, fmt.Errorf("failed to get contact for debt list: %w", err)
+					}
+					if verifiedName := contact.VerifiedName(); verifiedName != nil {
+						contactName = *verifiedName
+					}
+				}
 				
 				upcomingPayment := entities.UpcomingPayment{
 					DebtListID:      debtList.ID,
@@ -882,58 +2875,237 @@ func (s *debtService) GetUpcomingPayments(ctx context.Context, userID uuid.UUID,
 		}
 	}
 
-	return upcomingPayments, nil
+	return upcomingPayments, nil
+}
+
+func (s *debtService) GetTotalPaymentsForDebtList(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) (*entities.PaymentSummary, error) {
+	// Check if debt list belongs to user
+	belongs, err := s.debtListRepo.BelongsToUser(ctx, debtListID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify ownership: %w", err)
+	}
+	if !belongs {
+		return nil, entities.ErrDebtListNotFound
+	}
+
+	// Get debt list
+	debtList, err := s.debtListRepo.GetByID(ctx, debtListID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debt list: %w", err)
+	}
+
+	// Get all completed payments for this debt list
+	payments, err := s.debtItemRepo.GetCompletedPaymentsForDebtList(ctx, debtListID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get completed payments: %w", err)
+	}
+
+	// The main currency comes first, followed by each additional balance
+	mainSummary := summarizeBalance(debtList.Currency, debtList.TotalAmount, payments)
+	summaries := []entities.CurrencySummary{mainSummary}
+	allPayments := payments
+
+	balances, err := s.debtListRepo.GetBalances(ctx, debtListID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debt list balances: %w", err)
+	}
+	for _, balance := range balances {
+		balancePayments, err := s.debtItemRepo.GetCompletedPaymentsForBalance(ctx, debtListID, balance.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get completed payments for %s balance: %w", balance.Currency, err)
+		}
+		summaries = append(summaries, summarizeBalance(balance.Currency, balance.TotalAmount, balancePayments))
+		allPayments = append(allPayments, balancePayments...)
+	}
+	if len(balances) > 0 {
+		sort.SliceStable(allPayments, func(i, j int) bool {
+			return allPayments[i].PaymentDate.Before(allPayments[j].PaymentDate)
+		})
+	}
+
+	return &entities.PaymentSummary{
+		DebtListID:       debtListID,
+		Currency:         debtList.Currency,
+		TotalAmount:      debtList.TotalAmount,
+		TotalPaid:        mainSummary.TotalPaid,
+		RemainingDebt:    mainSummary.RemainingDebt,
+		PercentagePaid:   mainSummary.PercentagePaid,
+		NumberOfPayments: mainSummary.NumberOfPayments,
+		Balances:         summaries,
+		Payments:         allPayments,
+	}, nil
+}
+
+// summarizeBalance totals the completed payments made against one currency balance
+func summarizeBalance(currency string, totalAmount decimal.Decimal, payments []entities.DebtItem) entities.CurrencySummary {
+	// Adjustments count towards the total paid but are not payments themselves,
+	// and can't take it below zero
+	totalPaid := decimal.Zero
+	numberOfPayments := 0
+	for _, payment := range payments {
+		totalPaid = totalPaid.Add(payment.Amount)
+		if !payment.IsAdjustment() {
+			numberOfPayments++
+		}
+	}
+	if totalPaid.LessThan(decimal.Zero) {
+		totalPaid = decimal.Zero
+	}
+	remaining := totalAmount.Sub(totalPaid)
+	if remaining.LessThan(decimal.Zero) {
+		remaining = decimal.Zero
+	}
+	return entities.CurrencySummary{
+		Currency:         currency,
+		TotalAmount:      totalAmount,
+		TotalPaid:        totalPaid,
+		RemainingDebt:    remaining,
+		PercentagePaid:   entities.PercentagePaid(totalPaid, totalAmount),
+		NumberOfPayments: numberOfPayments,
+	}
+}
+
+// buildDebtBalances validates the additional currency balances of a new debt list.
+// Each needs a positive amount and a currency distinct from the main one and each other.
+func (s *debtService) buildDebtBalances(mainCurrency string, requests []entities.DebtBalanceRequest) ([]entities.DebtBalance, error) {
+	if len(requests) == 0 {
+		return nil, nil
+	}
+	seen := map[string]bool{strings.ToUpper(mainCurrency): true}
+	balances := make([]entities.DebtBalance, 0, len(requests))
+	for _, req := range requests {
+		currency := strings.TrimSpace(req.Currency)
+		if currency == "" || seen[strings.ToUpper(currency)] {
+			return nil, entities.ErrInvalidCurrency
+		}
+		seen[strings.ToUpper(currency)] = true
+
+		amount, err := decimal.NewFromString(req.TotalAmount)
+		if err != nil || amount.LessThanOrEqual(decimal.Zero) {
+			return nil, entities.ErrInvalidAmount
+		}
+		if err := s.validateTotalAmount(amount); err != nil {
+			return nil, err
+		}
+		balances = append(balances, entities.DebtBalance{
+			ID:          uuid.New(),
+			Currency:    currency,
+			TotalAmount: amount,
+		})
+	}
+	return balances, nil
+}
+
+// GetDebtCounts returns how many of the user's debts, owned or shared with them as the
+// contact, are in each status, plus the payments awaiting their verification
+func (s *debtService) GetDebtCounts(ctx context.Context, userID uuid.UUID) (*entities.DebtStatusCounts, error) {
+	counts, err := s.debtListRepo.GetStatusCounts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debt counts: %w", err)
+	}
+	return counts, nil
+}
+
+// GetActivitySummary aggregates the debt activity the user took part in between
+// from and to, per currency
+func (s *debtService) GetActivitySummary(ctx context.Context, userID uuid.UUID, from, to time.Time) (*entities.ActivitySummary, error) {
+	if !to.After(from) {
+		return nil, entities.ErrInvalidDateRange
+	}
+
+	currencies, err := s.debtListRepo.GetActivitySummary(ctx, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activity summary: %w", err)
+	}
+	return &entities.ActivitySummary{
+		From:       from,
+		To:         to,
+		Currencies: currencies,
+	}, nil
 }
 
-func (s *debtService) GetTotalPaymentsForDebtList(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) (*entities.PaymentSummary, error) {
-	// Check if debt list belongs to user
-	belongs, err := s.debtListRepo.BelongsToUser(ctx, debtListID, userID)
+// GetPaymentMethodStats returns how many completed payments, and for how much, were
+// made with each payment method on the user's debts, per currency
+func (s *debtService) GetPaymentMethodStats(ctx context.Context, userID uuid.UUID) ([]entities.PaymentMethodStats, error) {
+	stats, err := s.debtListRepo.GetPaymentMethodStats(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to verify ownership: %w", err)
+		return nil, fmt.Errorf("failed to get payment method statistics: %w", err)
 	}
-	if !belongs {
-		return nil, entities.ErrDebtListNotFound
+	return stats, nil
+}
+
+// GetCurrencies returns the distinct currencies of the user's debts, owned or shared
+// with them as the contact, together with their default currency
+func (s *debtService) GetCurrencies(ctx context.Context, userID uuid.UUID) (*entities.UserCurrencies, error) {
+	currencies, err := s.debtListRepo.GetCurrenciesForUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get currencies: %w", err)
+	}
+
+	defaultCurrency, err := s.getUserDefaultCurrency(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if defaultCurrency == "" {
+		defaultCurrency = entities.DefaultUserCurrency
+	}
+	defaultCurrency = strings.ToUpper(defaultCurrency)
+
+	// The repository returns currencies sorted and upper-cased, so only the default may be missing
+	index := sort.SearchStrings(currencies, defaultCurrency)
+	if index == len(currencies) || currencies[index] != defaultCurrency {
+		currencies = append(currencies, "")
+		copy(currencies[index+1:], currencies[index:])
+		currencies[index] = defaultCurrency
+	}
+
+	return &entities.UserCurrencies{
+		DefaultCurrency: defaultCurrency,
+		Currencies:      currencies,
+	}, nil
+}
+
+// GetBalanceHistory returns the remaining balance of a debt list after each completed
+// payment, in payment date order, starting from the total amount at creation
+func (s *debtService) GetBalanceHistory(ctx context.Context, debtListID uuid.UUID, userID uuid.UUID) ([]entities.BalancePoint, error) {
+	// Owners and contacts can both view the balance history
+	if err := s.requireDebtListAccess(ctx, debtListID, userID); err != nil {
+		return nil, err
 	}
 
-	// Get debt list
 	debtList, err := s.debtListRepo.GetByID(ctx, debtListID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get debt list: %w", err)
 	}
 
-	// Get all completed payments for this debt list
 	payments, err := s.debtItemRepo.GetCompletedPaymentsForDebtList(ctx, debtListID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get completed payments: %w", err)
 	}
 
-	// Calculate total payments made
-	totalPaid := decimal.Zero
-	for _, payment := range payments {
-		totalPaid = totalPaid.Add(payment.Amount)
-	}
+	sort.SliceStable(payments, func(i, j int) bool {
+		return payments[i].PaymentDate.Before(payments[j].PaymentDate)
+	})
 
-	// Calculate remaining debt
-	remainingDebt := debtList.TotalAmount.Sub(totalPaid)
-	if remainingDebt.LessThan(decimal.Zero) {
-		remainingDebt = decimal.Zero
-	}
+	history := make([]entities.BalancePoint, 0, len(payments)+1)
+	balance := debtList.TotalAmount
+	history = append(history, entities.BalancePoint{
+		Date:    debtList.CreatedAt,
+		Balance: balance,
+	})
 
-	// Calculate percentage paid
-	percentagePaid := decimal.Zero
-	if debtList.TotalAmount.GreaterThan(decimal.Zero) {
-		percentagePaid = totalPaid.Div(debtList.TotalAmount).Mul(decimal.NewFromInt(100))
+	for _, payment := range payments {
+		balance = balance.Sub(payment.Amount)
+		if balance.LessThan(decimal.Zero) {
+			balance = decimal.Zero
+		}
+		history = append(history, entities.BalancePoint{
+			Date:    payment.PaymentDate,
+			Balance: balance,
+		})
 	}
 
-	return &entities.PaymentSummary{
-		DebtListID:       debtListID,
-		TotalAmount:      debtList.TotalAmount,
-		TotalPaid:        totalPaid,
-		RemainingDebt:    remainingDebt,
-		PercentagePaid:   percentagePaid,
-		NumberOfPayments: len(payments),
-		Payments:         payments,
-	}, nil
+	return history, nil
 }
 
 // Payment verification operations
@@ -966,10 +3138,193 @@ func (s *debtService) VerifyDebtItem(ctx context.Context, id uuid.UUID, userID u
 	return updatedDebtItem, nil
 }
 
+// AutoMatchPayment compares a pending payment with the debt's next expected
+// installment. It matches when it is in the debt's currency and its amount is
+// within the configured tolerance of the installment; a matching payment is
+// verified when auto-verification is enabled. Anything else is left pending for
+// the verifier to review.
+func (s *debtService) AutoMatchPayment(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.PaymentMatch, error) {
+	debtItem, err := s.GetDebtItemForVerification(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if debtItem.Status != entities.PaymentStatusPending {
+		return nil, entities.ErrPaymentAlreadyProcessed
+	}
+
+	match := &entities.PaymentMatch{ExpectedAmount: decimal.Zero, Payment: debtItem}
+	nextPayment, err := s.GetNextPayment(ctx, debtItem.DebtListID, userID)
+	if err != nil {
+		return nil, err
+	}
+	// A debt with nothing left to pay has no installment to match
+	if nextPayment == nil {
+		return match, nil
+	}
+	match.ExpectedAmount = nextPayment.Amount
+	match.PaymentNumber = nextPayment.PaymentNumber
+	match.Matched = strings.EqualFold(debtItem.Currency, nextPayment.Currency) &&
+		debtItem.Amount.Sub(nextPayment.Amount).Abs().LessThanOrEqual(s.autoMatchTolerance)
+
+	if !match.Matched || !s.autoVerifyMatches {
+		return match, nil
+	}
+
+	notes := fmt.Sprintf("Automatically verified: amount matches installment %d", nextPayment.PaymentNumber)
+	verified, err := s.VerifyDebtItem(ctx, id, userID, &entities.VerifyDebtItemRequest{
+		Status:            entities.PaymentStatusCompleted,
+		VerificationNotes: &notes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	match.AutoVerified = true
+	match.Payment = verified
+	return match, nil
+}
+
+// ReversePayment takes back part of a completed payment that was over-recorded. The
+// payment itself is left as it was; a completed negative adjustment linked to it
+// records the reversed amount and reason, so the debt's remaining balance grows by
+// that amount. Only the creditor, who verifies the debt's payments, may reverse,
+// and never more than what earlier reversals left of the payment.
+func (s *debtService) ReversePayment(ctx context.Context, id uuid.UUID, userID uuid.UUID, amount decimal.Decimal, reason string) (*entities.DebtItem, error) {
+	payment, err := s.GetDebtItemForVerification(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if payment.Status != entities.PaymentStatusCompleted || payment.IsAdjustment() {
+		return nil, entities.ErrPaymentNotReversible
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, entities.ErrInvalidInput
+	}
+	if !amount.IsPositive() {
+		return nil, entities.ErrInvalidAmount
+	}
+
+	now := time.Now()
+	reversal := &entities.DebtItem{
+		ID:            uuid.New(),
+		DebtListID:    payment.DebtListID,
+		Amount:        amount.Neg(),
+		Currency:      payment.Currency,
+		PaymentDate:   now,
+		PaymentMethod: payment.PaymentMethod,
+		PaymentType:   entities.PaymentTypeAdjustment,
+		Description:   &reason,
+		Status:        entities.PaymentStatusCompleted,
+		VerifiedBy:    &userID,
+		VerifiedAt:    &now,
+		CreatedBy:     userID,
+		ReversalOf:    &payment.ID,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := reversal.IsValid(); err != nil {
+		return nil, fmt.Errorf("invalid debt item entity: %w", err)
+	}
+
+	// The repository checks the amount against the payment's other reversals
+	// while holding a lock on it
+	if err := s.debtItemRepo.CreateReversal(ctx, reversal); err != nil {
+		if errors.Is(err, entities.ErrReversalExceedsPayment) || errors.Is(err, entities.ErrDebtItemNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create payment reversal: %w", err)
+	}
+
+	if err := s.updateDebtListStatusAndPaymentTotals(ctx, payment.DebtListID); err != nil {
+		return nil, fmt.Errorf("failed to update debt list totals: %w", err)
+	}
+
+	return reversal, nil
+}
+
 func (s *debtService) GetPendingVerifications(ctx context.Context, userID uuid.UUID) ([]entities.DebtItem, error) {
 	return s.debtItemRepo.GetPendingVerifications(ctx, userID)
 }
 
+// GetPendingVerificationQueue returns the payments awaiting the user's verification
+// ordered by submission date, newest first unless oldestFirst is set. Each entry
+// carries its debt list and counterparty as seen by the user.
+func (s *debtService) GetPendingVerificationQueue(ctx context.Context, userID uuid.UUID, oldestFirst bool) ([]entities.PendingVerification, error) {
+	pending, err := s.debtItemRepo.GetPendingVerifications(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending verifications: %w", err)
+	}
+	if len(pending) == 0 {
+		return []entities.PendingVerification{}, nil
+	}
+
+	ownDebtLists, sharedDebtLists, err := s.ownAndSharedDebtLists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	debtListsByID := make(map[uuid.UUID]entities.DebtListResponse, len(ownDebtLists)+len(sharedDebtLists))
+	for _, debtList := range append(ownDebtLists, sharedDebtLists...) {
+		debtListsByID[debtList.ID] = debtList
+	}
+
+	queue := make([]entities.PendingVerification, 0, len(pending))
+	for _, payment := range pending {
+		debtList, ok := debtListsByID[payment.DebtListID]
+		if !ok {
+			continue
+		}
+		queue = append(queue, entities.PendingVerification{
+			Payment:       payment,
+			ClaimedAmount: payment.Amount,
+			Currency:      payment.Currency,
+			SubmittedAt:   payment.CreatedAt,
+			DebtList: entities.PendingVerificationDebt{
+				ID:                 debtList.ID,
+				DebtType:           debtList.DebtType,
+				TotalAmount:        debtList.TotalAmount,
+				TotalRemainingDebt: debtList.TotalRemainingDebt,
+				Currency:           debtList.Currency,
+				Status:             debtList.Status,
+				Description:        debtList.Description,
+			},
+			Contact: debtList.Contact,
+		})
+	}
+
+	// Payments submitted at the same instant keep a stable order across pages
+	sort.SliceStable(queue, func(i, j int) bool {
+		a, b := queue[i].SubmittedAt, queue[j].SubmittedAt
+		if a.Equal(b) {
+			return queue[i].Payment.ID.String() < queue[j].Payment.ID.String()
+		}
+		if oldestFirst {
+			return a.Before(b)
+		}
+		return a.After(b)
+	})
+
+	return queue, nil
+}
+
+// GetUserPayments returns a page of the payments on every debt list the user
+// owns or is the contact of, newest payment first, narrowed by filter, and how
+// many payments match in total
+func (s *debtService) GetUserPayments(ctx context.Context, userID uuid.UUID, filter entities.PaymentFilter) ([]entities.UserPayment, int, error) {
+	if filter.Status != "" && !entities.IsValidPaymentStatus(filter.Status) {
+		return nil, 0, entities.ErrInvalidPaymentStatus
+	}
+	if filter.From != nil && filter.To != nil && !filter.To.After(*filter.From) {
+		return nil, 0, entities.ErrInvalidDateRange
+	}
+
+	payments, total, err := s.debtItemRepo.GetForUser(ctx, userID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get user payments: %w", err)
+	}
+	return payments, total, nil
+}
+
 func (s *debtService) RejectDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID, notes *string) (*entities.DebtItem, error) {
 	// Check if the debt item exists and user can verify it
 	_, err := s.GetDebtItemForVerification(ctx, id, userID)
@@ -991,19 +3346,261 @@ func (s *debtService) RejectDebtItem(ctx context.Context, id uuid.UUID, userID u
 	return updatedDebtItem, nil
 }
 
+// DisputeDebtItem flags a pending payment as disputed so it can be discussed
+// before being verified or rejected. Disputed payments don't count toward totals.
+func (s *debtService) DisputeDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *entities.DisputeDebtItemRequest) (*entities.DebtItem, error) {
+	// Only the party who verifies the payment may dispute it
+	if _, err := s.GetDebtItemForVerification(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.debtItemRepo.UpdatePaymentStatus(ctx, id, entities.PaymentStatusDisputed, userID, &req.Reason); err != nil {
+		if errors.Is(err, entities.ErrPaymentAlreadyProcessed) || errors.Is(err, entities.ErrDebtItemNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to dispute payment: %w", err)
+	}
+
+	return s.GetDebtItemForVerification(ctx, id, userID)
+}
+
+// ResubmitDebtItem moves a rejected payment back to pending so it can be verified again.
+// Only the party who submitted it, the one who owes from their perspective, may resubmit.
+func (s *debtService) ResubmitDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *entities.ResubmitDebtItemRequest) (*entities.DebtItem, error) {
+	debtItem, err := s.debtItemRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, entities.ErrDebtItemNotFound) {
+			return nil, entities.ErrDebtItemNotFound
+		}
+		return nil, fmt.Errorf("failed to get debt item: %w", err)
+	}
+
+	debtList, err := s.debtListRepo.GetByID(ctx, debtItem.DebtListID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debt list: %w", err)
+	}
+
+	if err := s.checkPaymentSubmitter(ctx, debtList, userID); err != nil {
+		return nil, err
+	}
+
+	if debtItem.Status != entities.PaymentStatusRejected {
+		return nil, entities.ErrPaymentNotRejected
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.debtItemRepo.ResubmitPayment(ctx, id, req.ReceiptPhotoURL, req.VerificationNotes); err != nil {
+		if errors.Is(err, entities.ErrPaymentNotRejected) || errors.Is(err, entities.ErrDebtItemNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to resubmit payment: %w", err)
+	}
+
+	updatedDebtItem, err := s.debtItemRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debt item: %w", err)
+	}
+
+	return updatedDebtItem, nil
+}
+
+// NudgeDebtItem reminds the verifier of a pending payment to verify it by
+// publishing a nudge event addressed to them. Only the submitter may nudge, at
+// most once per nudge interval for each payment.
+func (s *debtService) NudgeDebtItem(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entities.DebtItem, error) {
+	debtItem, err := s.debtItemRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, entities.ErrDebtItemNotFound) {
+			return nil, entities.ErrDebtItemNotFound
+		}
+		return nil, fmt.Errorf("failed to get debt item: %w", err)
+	}
+
+	debtList, err := s.debtListRepo.GetByID(ctx, debtItem.DebtListID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debt list: %w", err)
+	}
+
+	if err := s.checkPaymentSubmitter(ctx, debtList, userID); err != nil {
+		return nil, err
+	}
+
+	if debtItem.Status != entities.PaymentStatusPending {
+		return nil, entities.ErrPaymentNotPending
+	}
+
+	// Only a registered verifier can be notified
+	verifier, err := s.debtItemRepo.GetVerifier(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get verifier: %w", err)
+	}
+	if verifier == nil {
+		return nil, entities.ErrContactNotAppUser
+	}
+	// Without a publisher the nudge would be recorded, and rate limited, unsent
+	if s.eventPublisher == nil {
+		return nil, entities.ErrNotificationsUnavailable
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.debtItemRepo.MarkNudged(ctx, id, now, now.Add(-s.nudgeInterval)); err != nil {
+		if errors.Is(err, entities.ErrNudgeTooSoon) || errors.Is(err, entities.ErrPaymentNotPending) || errors.Is(err, entities.ErrDebtItemNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to nudge payment: %w", err)
+	}
+
+	s.publishEvent(ctx, entities.DebtEvent{
+		Type:          entities.DebtEventPaymentNudged,
+		DebtListID:    debtList.ID,
+		UserID:        debtList.UserID,
+		ContactID:     debtList.ContactID,
+		DebtType:      debtList.DebtType,
+		Status:        debtList.Status,
+		RemainingDebt: debtList.TotalRemainingDebt,
+		Currency:      debtList.Currency,
+		DueDate:       debtList.DueDate,
+		OccurredAt:    now,
+		PaymentID:     &debtItem.ID,
+		RecipientID:   &verifier.ID,
+	})
+
+	debtItem.LastNudgedAt = &now
+	return debtItem, nil
+}
+
 // Helper methods
 
-func (s *debtService) updateDebtListStatusAndPaymentTotals(ctx context.Context, debtListID uuid.UUID) error {
-	// Get the debt list
-	debtList, err := s.debtListRepo.GetByID(ctx, debtListID)
+// haveSameParties reports whether two debt lists are between the same two people,
+// whichever of them owns each list. A contact stands for its registered user
+// when it has one.
+func (s *debtService) haveSameParties(ctx context.Context, a, b *entities.DebtList) (bool, error) {
+	counterparty := func(debtList *entities.DebtList) (uuid.UUID, error) {
+		contact, err := s.contactRepo.GetByID(ctx, debtList.ContactID)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("failed to get contact: %w", err)
+		}
+		if contact.UserIDRef != nil {
+			return *contact.UserIDRef, nil
+		}
+		return contact.ID, nil
+	}
+	aOther, err := counterparty(a)
+	if err != nil {
+		return false, err
+	}
+	bOther, err := counterparty(b)
+	if err != nil {
+		return false, err
+	}
+	return (a.UserID == b.UserID && aOther == bOther) || (a.UserID == bOther && aOther == b.UserID), nil
+}
+
+// checkContactNotBlocked returns ErrContactBlocked when the user blocked the
+// contact, or when the registered user behind the contact blocked the user
+func checkContactNotBlocked(ctx context.Context, contactRepo interfaces.ContactRepository, userID uuid.UUID, userContact *entities.UserContact) error {
+	if userContact.IsBlocked {
+		return entities.ErrContactBlocked
+	}
+
+	contact, err := contactRepo.GetByID(ctx, userContact.ContactID)
+	if err != nil {
+		return fmt.Errorf("failed to get contact: %w", err)
+	}
+	if contact.UserIDRef == nil {
+		return nil
+	}
+	blocked, err := contactRepo.IsBlockedBy(ctx, *contact.UserIDRef, userID)
+	if err != nil {
+		return fmt.Errorf("failed to check if contact blocked the user: %w", err)
+	}
+	if blocked {
+		return entities.ErrContactBlocked
+	}
+	return nil
+}
+
+// checkPaymentSubmitter returns ErrForbidden unless the user is the one who
+// submits the debt list's payments for verification, and ErrDebtItemNotFound when
+// the user is not party to the debt list at all. Payments start out pending only
+// when created by the debtor, so the debtor is the submitter.
+func (s *debtService) checkPaymentSubmitter(ctx context.Context, debtList *entities.DebtList, userID uuid.UUID) error {
+	belongs, err := s.debtListRepo.BelongsToUser(ctx, debtList.ID, userID)
 	if err != nil {
-		return fmt.Errorf("failed to get debt list: %w", err)
+		return fmt.Errorf("failed to verify ownership: %w", err)
+	}
+	isSubmitter := belongs && debtList.DebtType == entities.DebtTypeToPay
+	if !belongs {
+		isContact, err := s.debtListRepo.IsContactOfDebtList(ctx, debtList.ID, userID)
+		if err != nil {
+			return fmt.Errorf("failed to verify contact association: %w", err)
+		}
+		if !isContact {
+			return entities.ErrDebtItemNotFound
+		}
+		isSubmitter = debtList.DebtType == entities.DebtTypeToReceive
+	}
+	if !isSubmitter {
+		return entities.ErrForbidden
 	}
+	return nil
+}
 
-	// Calculate total payments made
-	totalPaid, err := s.debtItemRepo.GetTotalPaidForDebtList(ctx, debtListID)
+func (s *debtService) updateDebtListStatusAndPaymentTotals(ctx context.Context, debtListID uuid.UUID) error {
+	// Callers have already written the payment change, so the totals are brought
+	// in line with it even when the request is cancelled meanwhile
+	ctx = context.WithoutCancel(ctx)
+
+	// The totals are derived inside the repository's transaction, from the locked
+	// debt list and its payments as they stand at that moment
+	var overdueEvent *entities.DebtEvent
+	err := s.debtListRepo.RecalculatePaymentTotals(ctx, debtListID, func(debtList *entities.DebtList, payments entities.PaymentAggregate) entities.PaymentTotals {
+		totals := s.paymentTotalsFor(debtList, payments)
+
+		// Announce only the transition into overdue, not every recalculation while overdue
+		overdueEvent = nil
+		if totals.Status == "overdue" && debtList.Status != "overdue" {
+			overdueEvent = &entities.DebtEvent{
+				Type:          entities.DebtEventOverdue,
+				DebtListID:    debtList.ID,
+				UserID:        debtList.UserID,
+				ContactID:     debtList.ContactID,
+				DebtType:      debtList.DebtType,
+				Status:        totals.Status,
+				RemainingDebt: totals.RemainingDebt,
+				Currency:      debtList.Currency,
+				DueDate:       debtList.DueDate,
+				OccurredAt:    time.Now(),
+			}
+		}
+		return totals
+	})
 	if err != nil {
-		return fmt.Errorf("failed to get total paid: %w", err)
+		return fmt.Errorf("failed to recalculate payment totals: %w", err)
+	}
+
+	// Publish only once the new status is committed
+	if overdueEvent != nil {
+		s.publishEvent(ctx, *overdueEvent)
+	}
+
+	return nil
+}
+
+// paymentTotalsFor derives a debt list's totals, status and next payment date
+// from the aggregate of its payments
+func (s *debtService) paymentTotalsFor(debtList *entities.DebtList, payments entities.PaymentAggregate) entities.PaymentTotals {
+	// Adjustments can outweigh the payments they correct, but never below nothing paid
+	totalPaid := payments.TotalPaid
+	if totalPaid.LessThan(decimal.Zero) {
+		totalPaid = decimal.Zero
 	}
 
 	// Calculate remaining amount
@@ -1012,41 +3609,75 @@ func (s *debtService) updateDebtListStatusAndPaymentTotals(ctx context.Context,
 		remainingAmount = decimal.Zero
 	}
 
-	// Determine new status
-	var newStatus string
-	if remainingAmount.LessThanOrEqual(decimal.Zero) {
-		newStatus = "settled"
-	} else if time.Now().After(debtList.NextPaymentDate) {
-		newStatus = "overdue"
-	} else {
-		newStatus = "active"
+	return entities.PaymentTotals{
+		TotalPaid:       totalPaid,
+		RemainingDebt:   remainingAmount,
+		Status:          debtListStatusFor(remainingAmount, debtList.NextPaymentDate, s.settledTolerance),
+		NextPaymentDate: s.paymentScheduleService.CalculateNextPaymentDate(debtList, payments.LastPaymentDate),
+	}
+}
+
+// publishEvent hands a debt event to the configured publisher. Delivery failures
+// are logged rather than returned so they never undo the change that caused them.
+func (s *debtService) publishEvent(ctx context.Context, event entities.DebtEvent) {
+	if s.eventPublisher == nil {
+		return
+	}
+	if err := s.eventPublisher.Publish(ctx, event); err != nil {
+		logger := zerolog.Ctx(ctx)
+		logger.Warn().
+			Err(err).
+			Str("event_type", event.Type).
+			Str("debt_list_id", event.DebtListID.String()).
+			Msg("Failed to publish debt event")
+	}
+}
+
+// getUserDefaultCurrency returns the user's preferred currency, or an empty string
+// so that CreateDebtList applies the system default
+func (s *debtService) getUserDefaultCurrency(ctx context.Context, userID uuid.UUID) (string, error) {
+	if s.userSettingsRepo == nil {
+		return "", nil
 	}
 
-	// Get the last payment date to calculate next payment
-	lastPaymentDate, err := s.debtItemRepo.GetLastPaymentDate(ctx, debtListID)
+	settings, err := s.userSettingsRepo.GetByUserID(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("failed to get last payment date: %w", err)
+		if err == entities.ErrUserSettingsNotFound {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get user settings: %w", err)
 	}
 
-	// Calculate next payment date
-	nextPaymentDate := s.paymentScheduleService.CalculateNextPaymentDate(debtList, lastPaymentDate)
+	return settings.DefaultCurrency, nil
+}
 
-	// Update debt list totals
-	if err := s.debtListRepo.UpdatePaymentTotals(ctx, debtListID, totalPaid, remainingAmount); err != nil {
-		return fmt.Errorf("failed to update payment totals: %w", err)
+// getUserDefaultInstallmentPlan returns the user's preferred installment plan,
+// or onetime when none is configured
+func (s *debtService) getUserDefaultInstallmentPlan(ctx context.Context, userID uuid.UUID) (string, error) {
+	if s.userSettingsRepo == nil {
+		return entities.DefaultUserInstallmentPlan, nil
 	}
 
-	// Update status
-	if err := s.debtListRepo.UpdateStatus(ctx, debtListID, newStatus); err != nil {
-		return fmt.Errorf("failed to update status: %w", err)
+	settings, err := s.userSettingsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if err == entities.ErrUserSettingsNotFound {
+			return entities.DefaultUserInstallmentPlan, nil
+		}
+		return "", fmt.Errorf("failed to get user settings: %w", err)
 	}
 
-	// Update next payment date
-	if err := s.debtListRepo.UpdateNextPaymentDate(ctx, debtListID, nextPaymentDate); err != nil {
-		return fmt.Errorf("failed to update next payment date: %w", err)
+	// Ignore stored values that are no longer a known plan
+	if !entities.IsValidInstallmentPlan(settings.DefaultInstallmentPlan) {
+		return entities.DefaultUserInstallmentPlan, nil
 	}
 
-	return nil
+	return settings.DefaultInstallmentPlan, nil
+}
+
+// endOfMonth returns the last second of the month containing t
+func endOfMonth(t time.Time) time.Time {
+	firstOfNextMonth := time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+	return firstOfNextMonth.Add(-time.Second)
 }
 
 // Validation methods
@@ -1055,17 +3686,41 @@ func (s *debtService) validateCreateDebtListRequest(req *entities.CreateDebtList
 	if req.ContactID == uuid.Nil {
 		return entities.ErrInvalidInput
 	}
-	if req.DebtType != "to_receive" && req.DebtType != "to_pay" {
-		return entities.ErrInvalidDebtType
+	debtType, err := entities.NormalizeDebtType(req.DebtType)
+	if err != nil {
+		return err
 	}
+	req.DebtType = debtType
 	if req.TotalAmount == "" {
 		return entities.ErrInvalidAmount
 	}
-	// Validate that either due_date or number_of_payments is provided
-	hasDueDate := req.DueDate != nil
-	hasNumberOfPayments := req.NumberOfPayments != nil && *req.NumberOfPayments > 0
-	if !hasDueDate && !hasNumberOfPayments {
-		return fmt.Errorf("either due_date or number_of_payments must be provided")
+	if err := validateTextLength(req.Description, req.Notes); err != nil {
+		return err
+	}
+	// Without a due date or number of payments the debt falls due after the default offset
+	return nil
+}
+
+// validateNumberOfPayments rejects payment counts above the configured maximum
+func (s *debtService) validateNumberOfPayments(numberOfPayments *int) error {
+	if numberOfPayments != nil && *numberOfPayments > s.maxNumberOfPayments {
+		return fmt.Errorf("%w: at most %d payments are allowed", entities.ErrTooManyPayments, s.maxNumberOfPayments)
+	}
+	return nil
+}
+
+// validateTotalAmount rejects total amounts above the configured maximum
+func (s *debtService) validateTotalAmount(totalAmount decimal.Decimal) error {
+	if totalAmount.GreaterThan(s.maxTotalAmount) {
+		return fmt.Errorf("%w: at most %s is allowed", entities.ErrTotalAmountTooLarge, s.maxTotalAmount.String())
+	}
+	return nil
+}
+
+// validatePaymentDate rejects payment dates further in the future than the configured window
+func (s *debtService) validatePaymentDate(paymentDate time.Time) error {
+	if paymentDate.After(time.Now().Add(s.futurePaymentWindow)) {
+		return entities.ErrPaymentDateTooFarInFuture
 	}
 	return nil
 }
@@ -1077,9 +3732,38 @@ func (s *debtService) validateUpdateDebtListRequest(req *entities.UpdateDebtList
 	if req.Currency != nil && *req.Currency == "" {
 		return entities.ErrInvalidCurrency
 	}
+	if req.Status != nil {
+		switch *req.Status {
+		case "active", "overdue", "settled":
+		default:
+			return entities.ErrInvalidDebtStatus
+		}
+	}
+	return validateTextLength(req.Description, req.Notes)
+}
+
+// validateTextLength rejects free-text fields longer than entities.MaxTextLength characters
+func validateTextLength(values ...*string) error {
+	for _, value := range values {
+		if value != nil && utf8.RuneCountInString(*value) > entities.MaxTextLength {
+			return entities.ErrTextTooLong
+		}
+	}
 	return nil
 }
 
+// debtListStatusFor computes the status a debt list is in given its remaining
+// balance and next payment date. A balance within tolerance counts as settled.
+func debtListStatusFor(remainingAmount decimal.Decimal, nextPaymentDate time.Time, tolerance decimal.Decimal) string {
+	if remainingAmount.LessThanOrEqual(decimal.Max(tolerance, decimal.Zero)) {
+		return "settled"
+	}
+	if time.Now().After(nextPaymentDate) {
+		return "overdue"
+	}
+	return "active"
+}
+
 func (s *debtService) validateCreateDebtItemRequest(req *entities.CreateDebtItemRequest) error {
 	if req.DebtListID == uuid.Nil {
 		return entities.ErrInvalidInput
@@ -1102,8 +3786,14 @@ func (s *debtService) validateCreateDebtItemRequest(req *entities.CreateDebtItem
 	if !validPaymentMethods[req.PaymentMethod] {
 		return entities.ErrInvalidPaymentMethod
 	}
+	if req.PaymentType != "" && req.PaymentType != entities.PaymentTypePayment && req.PaymentType != entities.PaymentTypeAdjustment {
+		return entities.ErrInvalidPaymentType
+	}
+	if req.Reference != nil && utf8.RuneCountInString(*req.Reference) > entities.MaxReferenceLength {
+		return entities.ErrReferenceTooLong
+	}
 	
-	return nil
+	return validateTextLength(req.Description, req.VerificationNotes)
 }
 
 func (s *debtService) validateUpdateDebtItemRequest(req *entities.UpdateDebtItemRequest) error {
@@ -1116,7 +3806,7 @@ func (s *debtService) validateUpdateDebtItemRequest(req *entities.UpdateDebtItem
 	if req.PaymentMethod != nil && *req.PaymentMethod == "" {
 		return entities.ErrInvalidPaymentMethod
 	}
-	return nil
+	return validateTextLength(req.Description, req.VerificationNotes)
 }
 
 
@@ -1,8 +1,10 @@
 package services
 
 import (
+	"sort"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 
 	"pay-your-dues/internal/domain/entities"
@@ -32,10 +34,12 @@ func (s *paymentScheduleService) CalculateNextPaymentDate(debtList *entities.Deb
 	case "onetime":
 		// For 1-time payments, return the due date itself
 		return debtList.DueDate
+	case "daily":
+		return startDate.AddDate(0, 0, 1)
 	case "weekly":
-		return startDate.AddDate(0, 0, 7)
+		return alignToPaymentWeekday(startDate.AddDate(0, 0, 7), debtList.PaymentWeekday)
 	case "biweekly":
-		return startDate.AddDate(0, 0, 14)
+		return alignToPaymentWeekday(startDate.AddDate(0, 0, 14), debtList.PaymentWeekday)
 	case "monthly":
 		return startDate.AddDate(0, 1, 0)
 	case "quarterly":
@@ -46,6 +50,17 @@ func (s *paymentScheduleService) CalculateNextPaymentDate(debtList *entities.Deb
 		return debtList.DueDate // Default to onetime
 	}
 }
+// alignToPaymentWeekday moves date forward to the next occurrence of weekday, so an
+// installment is never due earlier than a full period after the previous one. A nil
+// weekday leaves the date unchanged.
+func alignToPaymentWeekday(date time.Time, weekday *int) time.Time {
+	if weekday == nil {
+		return date
+	}
+	offset := (time.Weekday(*weekday) - date.Weekday() + 7) % 7
+	return date.AddDate(0, 0, int(offset))
+}
+
 func (s *paymentScheduleService) CalculatePaymentSchedule(debtList *entities.DebtList, payments []entities.DebtItem) []entities.PaymentScheduleItem {
 	var schedule []entities.PaymentScheduleItem
 	currentDate := debtList.CreatedAt
@@ -59,25 +74,15 @@ func (s *paymentScheduleService) CalculatePaymentSchedule(debtList *entities.Deb
 		}
 	}
 
-	// Use the original installment amount from the debt list
-	originalInstallmentAmount := debtList.InstallmentAmount
-
 	// Variable 1: Track how much of the USER'S PAYMENTS remains to be allocated to installments
 	// This is used to determine which installments should be marked as "paid" or "partially paid"
 	remainingPaymentAmount := totalPaymentsMade
 
-	// Variable 2: Track how much of the TOTAL DEBT remains to be scheduled as installments
-	// This starts at the full debt amount and decrements by each installment amount scheduled
-	remainingDebtToSchedule := debtList.TotalAmount
-
-	// Continue generating payment schedule items until all debt is accounted for
-	for remainingDebtToSchedule.GreaterThan(decimal.Zero) {
-		// Use the original installment amount, but cap it at remaining debt
-		paymentAmount := originalInstallmentAmount
-		if remainingDebtToSchedule.LessThan(paymentAmount) {
-			paymentAmount = remainingDebtToSchedule
-		}
+	// Payments fill installments in payment date order, so each installment can
+	// list the payments that contributed to it
+	contributions := paymentContributions(payments)
 
+	for _, paymentAmount := range installmentAmounts(debtList) {
 		// Determine if this installment is already paid
 		status := "pending"
 		amountNeeded := paymentAmount
@@ -104,12 +109,12 @@ func (s *paymentScheduleService) CalculatePaymentSchedule(debtList *entities.Deb
 			ScheduledAmount: paymentAmount,
 			PaidAmount:      paidAmount,
 			Status:          status,
+			PaymentIDs:      allocateContributions(&contributions, paidAmount),
 		}
 
 		schedule = append(schedule, scheduleItem)
 
 		// Update for next iteration
-		remainingDebtToSchedule = remainingDebtToSchedule.Sub(paymentAmount)
 		currentDate = nextDate
 		paymentNumber++
 	}
@@ -117,6 +122,101 @@ func (s *paymentScheduleService) CalculatePaymentSchedule(debtList *entities.Deb
 	return schedule
 }
 
+// installmentAmounts splits the debt's total into the amounts of its installments.
+// Installments are for the debt's installment amount, and the debt's rounding mode
+// decides which of them absorb what is left over.
+func installmentAmounts(debtList *entities.DebtList) []decimal.Decimal {
+	installment := debtList.InstallmentAmount
+	mode := debtList.RoundingMode
+
+	if installment.IsPositive() && (mode == entities.RoundingModeFirst || mode == entities.RoundingModeSpread) {
+		count := debtList.TotalAmount.Div(installment).Floor().IntPart()
+		if count < 1 {
+			return []decimal.Decimal{debtList.TotalAmount}
+		}
+		amounts := make([]decimal.Decimal, count)
+		for i := range amounts {
+			amounts[i] = installment
+		}
+		remainder := debtList.TotalAmount.Sub(installment.Mul(decimal.NewFromInt(count)))
+		if mode == entities.RoundingModeSpread {
+			for i := 0; i < len(amounts) && remainder.GreaterThanOrEqual(minorUnit); i++ {
+				amounts[i] = amounts[i].Add(minorUnit)
+				remainder = remainder.Sub(minorUnit)
+			}
+		}
+		amounts[0] = amounts[0].Add(remainder)
+		return amounts
+	}
+
+	// The last installment is capped at what remains of the debt
+	var amounts []decimal.Decimal
+	remaining := debtList.TotalAmount
+	for remaining.GreaterThan(decimal.Zero) {
+		amount := installment
+		if remaining.LessThan(amount) {
+			amount = remaining
+		}
+		amounts = append(amounts, amount)
+		remaining = remaining.Sub(amount)
+	}
+	return amounts
+}
+
+// contribution is the part of a payment still available to fill installments
+type contribution struct {
+	paymentID uuid.UUID
+	amount    decimal.Decimal
+}
+
+// paymentContributions returns the completed payments in payment date order, each
+// net of the amount later reversed. Adjustments correct totals rather than record
+// a payment, so they are not linked to installments.
+func paymentContributions(payments []entities.DebtItem) []contribution {
+	reversed := make(map[uuid.UUID]decimal.Decimal)
+	for _, payment := range payments {
+		if payment.Status == "completed" && payment.ReversalOf != nil {
+			reversed[*payment.ReversalOf] = reversed[*payment.ReversalOf].Add(payment.Amount.Abs())
+		}
+	}
+
+	sorted := make([]entities.DebtItem, 0, len(payments))
+	for _, payment := range payments {
+		if payment.Status == "completed" && payment.PaymentType != entities.PaymentTypeAdjustment && payment.Amount.IsPositive() {
+			sorted = append(sorted, payment)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].PaymentDate.Before(sorted[j].PaymentDate)
+	})
+
+	contributions := make([]contribution, 0, len(sorted))
+	for _, payment := range sorted {
+		amount := payment.Amount.Sub(reversed[payment.ID])
+		if amount.IsPositive() {
+			contributions = append(contributions, contribution{paymentID: payment.ID, amount: amount})
+		}
+	}
+	return contributions
+}
+
+// allocateContributions takes amount from the front of contributions and returns
+// the IDs of the payments it was taken from
+func allocateContributions(contributions *[]contribution, amount decimal.Decimal) []uuid.UUID {
+	paymentIDs := []uuid.UUID{}
+	for amount.IsPositive() && len(*contributions) > 0 {
+		next := &(*contributions)[0]
+		applied := decimal.Min(amount, next.amount)
+		paymentIDs = append(paymentIDs, next.paymentID)
+		next.amount = next.amount.Sub(applied)
+		amount = amount.Sub(applied)
+		if !next.amount.IsPositive() {
+			*contributions = (*contributions)[1:]
+		}
+	}
+	return paymentIDs
+}
+
 func (s *paymentScheduleService) CalculateDueDateFromNumberOfPayments(createdAt time.Time, numberOfPayments int, installmentPlan string) time.Time {
 	if numberOfPayments <= 0 {
 		numberOfPayments = 1
@@ -127,6 +227,9 @@ func (s *paymentScheduleService) CalculateDueDateFromNumberOfPayments(createdAt
 	case "onetime":
 		// For 1-time payments, due date is the creation date (single payment)
 		dueDate = createdAt
+	case "daily":
+		// Each payment is 1 day apart, final payment is N days from creation
+		dueDate = createdAt.AddDate(0, 0, numberOfPayments)
 	case "weekly":
 		// Each payment is 7 days apart, final payment is N weeks from creation
 		dueDate = createdAt.AddDate(0, 0, numberOfPayments*7)
@@ -150,19 +253,35 @@ func (s *paymentScheduleService) CalculateDueDateFromNumberOfPayments(createdAt
 	return dueDate
 }
 
-func (s *paymentScheduleService) CalculateInstallmentAmountFromNumberOfPayments(totalAmount decimal.Decimal, numberOfPayments int) decimal.Decimal {
+func (s *paymentScheduleService) CalculateInstallmentAmountFromNumberOfPayments(totalAmount decimal.Decimal, numberOfPayments int, roundingMode string) decimal.Decimal {
 	if numberOfPayments <= 0 {
 		numberOfPayments = 1
 	}
-	return totalAmount.Div(decimal.NewFromInt(int64(numberOfPayments)))
+	return regularInstallment(totalAmount.Div(decimal.NewFromInt(int64(numberOfPayments))), roundingMode)
 }
 
-func (s *paymentScheduleService) CalculateInstallmentAmount(totalAmount decimal.Decimal, installmentPlan string, createdAt time.Time, dueDate time.Time) decimal.Decimal {
+func (s *paymentScheduleService) CalculateInstallmentAmount(totalAmount decimal.Decimal, installmentPlan string, createdAt time.Time, dueDate time.Time, roundingMode string) decimal.Decimal {
 	numberOfPayments := s.CalculateNumberOfPayments(installmentPlan, createdAt, dueDate)
 	if numberOfPayments <= 0 {
 		numberOfPayments = 1 // At least 1 payment
 	}
-	return totalAmount.Div(decimal.NewFromInt(int64(numberOfPayments)))
+	return regularInstallment(totalAmount.Div(decimal.NewFromInt(int64(numberOfPayments))), roundingMode)
+}
+
+// minorUnit is the smallest amount installments are split into, one cent of most currencies
+var minorUnit = decimal.New(1, -2)
+
+// regularInstallment returns the installment amount for an even share of a debt.
+// When the first or earliest installments absorb the remainder, the share is
+// rounded down to whole minor units so the remainder is never negative.
+func regularInstallment(share decimal.Decimal, roundingMode string) decimal.Decimal {
+	if roundingMode != entities.RoundingModeFirst && roundingMode != entities.RoundingModeSpread {
+		return share
+	}
+	if regular := share.RoundFloor(2); regular.IsPositive() {
+		return regular
+	}
+	return minorUnit
 }
 
 // Helper methods
@@ -175,6 +294,12 @@ func (s *paymentScheduleService) CalculateNumberOfPayments(installmentPlan strin
 	case "onetime":
 		// For 1-time payments, always return 1
 		return 1
+	case "daily":
+		// One payment for each day between creation and due date
+		if days < 1 {
+			days = 1
+		}
+		return days
 	case "weekly":
 		// Calculate weeks between creation and due date
 		weeks := days / 7
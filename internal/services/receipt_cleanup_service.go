@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/domain/interfaces"
+)
+
+// receiptCleanupService implements the ReceiptCleanupService interface
+type receiptCleanupService struct {
+	debtItemRepo       interfaces.DebtItemRepository
+	fileStorageService interfaces.FileStorageService
+	retention          time.Duration
+	logger             zerolog.Logger
+}
+
+// NewReceiptCleanupService creates a service that deletes the receipts of debts
+// settled more than retention ago
+func NewReceiptCleanupService(
+	debtItemRepo interfaces.DebtItemRepository,
+	fileStorageService interfaces.FileStorageService,
+	retention time.Duration,
+	logger zerolog.Logger,
+) interfaces.ReceiptCleanupService {
+	return &receiptCleanupService{
+		debtItemRepo:       debtItemRepo,
+		fileStorageService: fileStorageService,
+		retention:          retention,
+		logger:             logger.With().Str("service", "receipt_cleanup").Logger(),
+	}
+}
+
+// CleanupReceipts deletes receipts past the retention window from storage and
+// clears their URLs. A receipt whose deletion fails keeps its URL so the next
+// run retries it. In a dry run the targeted receipts are only reported.
+func (s *receiptCleanupService) CleanupReceipts(ctx context.Context, dryRun bool) (*entities.ReceiptCleanupResult, error) {
+	settledBefore := time.Now().Add(-s.retention)
+
+	debtItems, err := s.debtItemRepo.GetReceiptsForSettledDebts(ctx, settledBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipts past retention: %w", err)
+	}
+
+	result := &entities.ReceiptCleanupResult{
+		DryRun:      dryRun,
+		ReceiptURLs: make([]string, 0, len(debtItems)),
+	}
+	for _, debtItem := range debtItems {
+		result.ReceiptURLs = append(result.ReceiptURLs, *debtItem.ReceiptPhotoURL)
+	}
+
+	if dryRun {
+		s.logger.Info().Int("receipts", len(result.ReceiptURLs)).Time("settled_before", settledBefore).Msg("Receipt cleanup dry run")
+		return result, nil
+	}
+
+	for _, debtItem := range debtItems {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		logger := s.logger.With().Str("debt_item_id", debtItem.ID.String()).Str("receipt_url", *debtItem.ReceiptPhotoURL).Logger()
+
+		if err := s.fileStorageService.DeleteReceipt(ctx, *debtItem.ReceiptPhotoURL); err != nil {
+			logger.Error().Err(err).Msg("Failed to delete receipt past retention")
+			result.Failed++
+			continue
+		}
+		if err := s.debtItemRepo.UpdateReceiptPhoto(ctx, debtItem.ID, nil); err != nil {
+			logger.Error().Err(err).Msg("Failed to clear receipt URL after deletion")
+			result.Failed++
+			continue
+		}
+		result.Deleted++
+	}
+
+	s.logger.Info().Int("deleted", result.Deleted).Int("failed", result.Failed).Time("settled_before", settledBefore).Msg("Receipt cleanup completed")
+	return result, nil
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/domain/interfaces"
+)
+
+// DefaultReceiptDeletionBackoff is how long after a failed receipt deletion it is
+// first retried unless configured otherwise. The wait doubles with every attempt.
+const DefaultReceiptDeletionBackoff = time.Minute
+
+// DefaultReceiptDeletionMaxAttempts is how many times a receipt deletion is
+// attempted before it is dead-lettered unless configured otherwise
+const DefaultReceiptDeletionMaxAttempts = 10
+
+// maxReceiptDeletionBackoff caps the wait between retries of a receipt deletion
+const maxReceiptDeletionBackoff = 24 * time.Hour
+
+// receiptDeletionBatchSize bounds how many queued deletions one retry run attempts
+const receiptDeletionBatchSize = 100
+
+// receiptDeletionService implements the ReceiptDeletionService interface
+type receiptDeletionService struct {
+	pendingDeletionRepo interfaces.PendingDeletionRepository
+	fileStorageService  interfaces.FileStorageService
+	backoff             time.Duration
+	maxAttempts         int
+	logger              zerolog.Logger
+}
+
+// NewReceiptDeletionService creates a service that deletes receipts from storage
+// and retries failed deletions, waiting backoff before the first retry and
+// dead-lettering a deletion after maxAttempts failures. Non-positive values fall
+// back to the defaults.
+func NewReceiptDeletionService(
+	pendingDeletionRepo interfaces.PendingDeletionRepository,
+	fileStorageService interfaces.FileStorageService,
+	backoff time.Duration,
+	maxAttempts int,
+	logger zerolog.Logger,
+) interfaces.ReceiptDeletionService {
+	if backoff <= 0 {
+		backoff = DefaultReceiptDeletionBackoff
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultReceiptDeletionMaxAttempts
+	}
+	return &receiptDeletionService{
+		pendingDeletionRepo: pendingDeletionRepo,
+		fileStorageService:  fileStorageService,
+		backoff:             backoff,
+		maxAttempts:         maxAttempts,
+		logger:              logger.With().Str("service", "receipt_deletion").Logger(),
+	}
+}
+
+func (s *receiptDeletionService) DeleteReceipt(ctx context.Context, fileURL string) error {
+	deleteErr := s.fileStorageService.DeleteReceipt(ctx, fileURL)
+	if deleteErr == nil {
+		return nil
+	}
+
+	now := time.Now()
+	pendingDeletion := &entities.PendingDeletion{
+		ID:        uuid.New(),
+		FileURL:   fileURL,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.recordFailure(pendingDeletion, deleteErr, now)
+
+	// The queue entry must outlive a request that was cancelled mid-deletion
+	if err := s.pendingDeletionRepo.Create(context.WithoutCancel(ctx), pendingDeletion); err != nil {
+		return fmt.Errorf("failed to queue receipt deletion after %v: %w", deleteErr, err)
+	}
+
+	s.logger.Warn().Err(deleteErr).Str("receipt_url", fileURL).Time("next_attempt_at", pendingDeletion.NextAttemptAt).Msg("Receipt deletion failed, queued for retry")
+	return nil
+}
+
+// RetryPendingDeletions attempts the queued deletions that are due. A deletion
+// that succeeds leaves the queue; one that fails again is rescheduled with a
+// longer wait, or dead-lettered once it has used up its attempts.
+func (s *receiptDeletionService) RetryPendingDeletions(ctx context.Context) (*entities.PendingDeletionRetryResult, error) {
+	pendingDeletions, err := s.pendingDeletionRepo.GetDue(ctx, time.Now(), receiptDeletionBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due receipt deletions: %w", err)
+	}
+
+	result := &entities.PendingDeletionRetryResult{}
+	for i := range pendingDeletions {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		pendingDeletion := &pendingDeletions[i]
+		logger := s.logger.With().Str("pending_deletion_id", pendingDeletion.ID.String()).Str("receipt_url", pendingDeletion.FileURL).Logger()
+
+		if deleteErr := s.fileStorageService.DeleteReceipt(ctx, pendingDeletion.FileURL); deleteErr != nil {
+			s.recordFailure(pendingDeletion, deleteErr, time.Now())
+			if err := s.pendingDeletionRepo.Update(ctx, pendingDeletion); err != nil {
+				logger.Error().Err(err).Msg("Failed to reschedule receipt deletion")
+			}
+			if pendingDeletion.DeadLetteredAt != nil {
+				logger.Error().Err(deleteErr).Int("attempts", pendingDeletion.Attempts).Msg("Receipt deletion dead-lettered")
+				result.DeadLettered++
+			} else {
+				logger.Warn().Err(deleteErr).Int("attempts", pendingDeletion.Attempts).Time("next_attempt_at", pendingDeletion.NextAttemptAt).Msg("Receipt deletion retry failed")
+				result.Failed++
+			}
+			continue
+		}
+
+		if err := s.pendingDeletionRepo.Delete(ctx, pendingDeletion.ID); err != nil {
+			// The receipt is gone; deleting it again on the next run is harmless
+			logger.Error().Err(err).Msg("Failed to remove completed receipt deletion from the queue")
+		}
+		result.Deleted++
+	}
+
+	if len(pendingDeletions) > 0 {
+		s.logger.Info().Int("deleted", result.Deleted).Int("failed", result.Failed).Int("dead_lettered", result.DeadLettered).Msg("Receipt deletion retries completed")
+	}
+	return result, nil
+}
+
+// recordFailure counts a failed attempt on the deletion and schedules the next
+// one, doubling the wait each time, or dead-letters it once attempts run out
+func (s *receiptDeletionService) recordFailure(pendingDeletion *entities.PendingDeletion, deleteErr error, now time.Time) {
+	pendingDeletion.Attempts++
+	pendingDeletion.LastError = deleteErr.Error()
+
+	if pendingDeletion.Attempts >= s.maxAttempts {
+		pendingDeletion.DeadLetteredAt = &now
+		return
+	}
+
+	wait := s.backoff
+	for i := 1; i < pendingDeletion.Attempts && wait < maxReceiptDeletionBackoff; i++ {
+		wait *= 2
+	}
+	pendingDeletion.NextAttemptAt = now.Add(min(wait, maxReceiptDeletionBackoff))
+}
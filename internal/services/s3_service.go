@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -20,9 +21,12 @@ import (
 
 // S3Service implements the FileStorageService interface
 type S3Service struct {
-	s3Client   *s3.Client
-	bucketName string
-	logger     zerolog.Logger
+	s3Client            *s3.Client
+	bucketName          string
+	maxReceiptSize      int64
+	receiptContentTypes []string
+	receiptExtensions   []string
+	logger              zerolog.Logger
 }
 
 // NewS3Service creates a new S3 service instance
@@ -71,17 +75,20 @@ func NewS3Service(cfg *config.Config, logger zerolog.Logger) (*S3Service, error)
 	}
 
 	return &S3Service{
-		s3Client:   s3Client,
-		bucketName: cfg.S3BucketName,
-		logger:     logger,
+		s3Client:            s3Client,
+		bucketName:          cfg.S3BucketName,
+		maxReceiptSize:      cfg.MaxReceiptSize,
+		receiptContentTypes: cfg.ReceiptContentTypes,
+		receiptExtensions:   cfg.ReceiptExtensions,
+		logger:              logger,
 	}, nil
 }
 
 // UploadReceipt uploads a receipt photo and returns the relative path
 func (s *S3Service) UploadReceipt(ctx context.Context, file io.Reader, filename string, contentType string, debtID uuid.UUID) (string, error) {
 	// Validate file type
-	if !s.IsValidImageType(contentType) {
-		return "", fmt.Errorf("invalid file type: %s. Only images are allowed", contentType)
+	if !s.IsValidReceiptType(contentType) {
+		return "", fmt.Errorf("invalid file type: %s. Only %s are allowed", contentType, strings.Join(s.allowedReceiptContentTypes(), ", "))
 	}
 
 	// Generate unique filename with timestamp and UUID
@@ -195,20 +202,104 @@ func (s *S3Service) GetReceiptFile(ctx context.Context, fileURL string) ([]byte,
 	return fileContent, contentType, nil
 }
 
-// IsValidImageType checks if the content type is a valid image type
-func (s *S3Service) IsValidImageType(contentType string) bool {
-	validTypes := map[string]bool{
-		"image/jpeg": true,
-		"image/jpg":  true,
-		"image/png":  true,
-		"image/gif":  true,
-		"image/webp": true,
+// GetReceiptFilename returns the name a receipt file was uploaded with, as recorded
+// in its S3 metadata
+func (s *S3Service) GetReceiptFilename(ctx context.Context, fileURL string) (string, error) {
+	key, err := s.ExtractKeyFromURL(fileURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid file path: %w", err)
+	}
+
+	result, err := s.s3Client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		s.logger.Error().Err(err).Str("key", key).Msg("Failed to retrieve receipt metadata from S3")
+		return "", fmt.Errorf("failed to retrieve file metadata from S3: %w", err)
+	}
+
+	return result.Metadata["original-filename"], nil
+}
+
+// UploadDocument uploads a document attached to a debt list and returns the relative path
+func (s *S3Service) UploadDocument(ctx context.Context, file io.Reader, filename string, contentType string, debtID uuid.UUID) (string, error) {
+	// Validate file type
+	if !s.IsValidDocumentType(contentType) {
+		return "", fmt.Errorf("invalid file type: %s. Only PDF documents and images are allowed", contentType)
+	}
+
+	// Generate unique filename with timestamp and UUID
+	ext := filepath.Ext(filename)
+	uuidStr := uuid.New().String()
+	timestamp := time.Now().Format("20060102-150405") // YYYYMMDD-HHMMSS format
+	s3Key := fmt.Sprintf("%s/documents/%s-%s%s", debtID.String(), timestamp, uuidStr, ext)
+
+	// Upload file to S3
+	_, err := s.s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucketName),
+		Key:         aws.String(s3Key),
+		Body:        file,
+		ContentType: aws.String(contentType),
+		Metadata: map[string]string{
+			"original-filename": filename,
+			"uploaded-at":       time.Now().Format(time.RFC3339),
+			"debt-id":           debtID.String(),
+		},
+	})
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to upload document to S3")
+		return "", fmt.Errorf("failed to upload file to S3: %w", err)
 	}
-	return validTypes[contentType]
+
+	// Return API path format
+	apiPath := fmt.Sprintf("/api/v1/debts/%s/documents/%s-%s%s", debtID.String(), timestamp, uuidStr, ext)
+	s.logger.Info().Str("s3_key", s3Key).Str("api_path", apiPath).Msg("Document uploaded successfully to S3")
+
+	return apiPath, nil
+}
+
+// GetDocumentFile retrieves a debt list document from S3. Documents are stored
+// the same way as receipts, so this shares the receipt retrieval path.
+func (s *S3Service) GetDocumentFile(ctx context.Context, fileURL string) ([]byte, string, error) {
+	return s.GetReceiptFile(ctx, fileURL)
+}
+
+// IsValidDocumentType checks if the content type is accepted for debt list
+// documents, which allow PDF in addition to the receipt types
+func (s *S3Service) IsValidDocumentType(contentType string) bool {
+	return contentType == "application/pdf" || s.IsValidReceiptType(contentType)
+}
+
+// IsValidReceiptType checks if the content type is accepted for receipts. These
+// are the configured receipt types, which default to common image formats.
+func (s *S3Service) IsValidReceiptType(contentType string) bool {
+	return slices.Contains(s.allowedReceiptContentTypes(), strings.ToLower(contentType))
+}
+
+// allowedReceiptContentTypes returns the configured receipt content types, or the
+// defaults when none are configured
+func (s *S3Service) allowedReceiptContentTypes() []string {
+	if len(s.receiptContentTypes) == 0 {
+		return config.DefaultReceiptContentTypes
+	}
+	return s.receiptContentTypes
 }
 
 // ExtractKeyFromURL extracts the S3 key from a relative path or S3 URL
 func (s *S3Service) ExtractKeyFromURL(fileURL string) (string, error) {
+	// Debt list documents share the API path layout of receipts
+	if strings.HasPrefix(fileURL, "/api/v1/debts/") && strings.Contains(fileURL, "/documents/") {
+		parts := strings.Split(fileURL, "/")
+		if len(parts) < 7 || parts[6] == "" {
+			return "", fmt.Errorf("invalid API path format: %s", fileURL)
+		}
+		if _, err := uuid.Parse(parts[4]); err != nil {
+			return "", fmt.Errorf("invalid debt ID in path: %s", parts[4])
+		}
+		return fmt.Sprintf("%s/documents/%s", parts[4], parts[6]), nil
+	}
+
 	// Handle API path format: /api/v1/debts/{debt-id}/receipts/{timestamp}-{uuid}.{ext}
 	if strings.HasPrefix(fileURL, "/api/v1/debts/") && strings.Contains(fileURL, "/receipts/") {
 		// Extract debt ID and filename from the path
@@ -261,28 +352,28 @@ func (s *S3Service) ExtractKeyFromURL(fileURL string) (string, error) {
 
 // ValidateFile validates if the uploaded file is acceptable
 func (s *S3Service) ValidateFile(filename string, contentType string, size int64) error {
-	// Check file size (max 10MB)
-	const maxSize = 10 * 1024 * 1024 // 10MB
+	// Check file size against the configured limit
+	maxSize := s.maxReceiptSize
+	if maxSize <= 0 {
+		maxSize = config.DefaultMaxReceiptSize
+	}
 	if size > maxSize {
 		return fmt.Errorf("file size %d bytes exceeds maximum allowed size of %d bytes", size, maxSize)
 	}
 
 	// Check file type
-	if !s.IsValidImageType(contentType) {
-		return fmt.Errorf("invalid file type: %s. Only images (JPEG, PNG, GIF, WebP) are allowed", contentType)
+	if !s.IsValidReceiptType(contentType) {
+		return fmt.Errorf("invalid file type: %s. Only %s are allowed", contentType, strings.Join(s.allowedReceiptContentTypes(), ", "))
 	}
 
 	// Check file extension
 	ext := strings.ToLower(filepath.Ext(filename))
-	validExtensions := map[string]bool{
-		".jpg":  true,
-		".jpeg": true,
-		".png":  true,
-		".gif":  true,
-		".webp": true,
+	extensions := s.receiptExtensions
+	if len(extensions) == 0 {
+		extensions = config.DefaultReceiptExtensions
 	}
-	if !validExtensions[ext] {
-		return fmt.Errorf("invalid file extension: %s. Only .jpg, .jpeg, .png, .gif, .webp are allowed", ext)
+	if !slices.Contains(extensions, ext) {
+		return fmt.Errorf("invalid file extension: %s. Only %s are allowed", ext, strings.Join(extensions, ", "))
 	}
 
 	return nil
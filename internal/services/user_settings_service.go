@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/domain/interfaces"
+)
+
+// userSettingsService implements the UserSettingsService interface
+type userSettingsService struct {
+	userSettingsRepo interfaces.UserSettingsRepository
+}
+
+// NewUserSettingsService creates a new user settings service
+func NewUserSettingsService(userSettingsRepo interfaces.UserSettingsRepository) interfaces.UserSettingsService {
+	return &userSettingsService{
+		userSettingsRepo: userSettingsRepo,
+	}
+}
+
+// GetUserSettings returns the user's saved settings, or the defaults if none were saved
+func (s *userSettingsService) GetUserSettings(ctx context.Context, userID uuid.UUID) (*entities.UserSettings, error) {
+	settings, err := s.userSettingsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, entities.ErrUserSettingsNotFound) {
+			return entities.NewDefaultUserSettings(userID), nil
+		}
+		return nil, fmt.Errorf("failed to get user settings: %w", err)
+	}
+	return settings, nil
+}
+
+func (s *userSettingsService) UpdateUserSettings(ctx context.Context, userID uuid.UUID, req *entities.UpdateUserSettingsRequest) (*entities.UserSettings, error) {
+	settings, err := s.GetUserSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update only provided fields
+	if req.DefaultCurrency != nil {
+		settings.DefaultCurrency = strings.TrimSpace(*req.DefaultCurrency)
+	}
+	if req.DefaultInstallmentPlan != nil {
+		settings.DefaultInstallmentPlan = strings.TrimSpace(*req.DefaultInstallmentPlan)
+	}
+	if req.Timezone != nil {
+		settings.Timezone = strings.TrimSpace(*req.Timezone)
+	}
+
+	if err := settings.IsValid(); err != nil {
+		return nil, fmt.Errorf("invalid user settings: %w", err)
+	}
+
+	if err := s.userSettingsRepo.Upsert(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to update user settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// UpdateNotificationPreferences updates which notifications the user receives,
+// leaving their other settings unchanged
+func (s *userSettingsService) UpdateNotificationPreferences(ctx context.Context, userID uuid.UUID, req *entities.UpdateNotificationPreferencesRequest) (*entities.UserSettings, error) {
+	settings, err := s.GetUserSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update only provided fields
+	if req.EmailReminders != nil {
+		settings.EmailReminders = *req.EmailReminders
+	}
+	if req.ReminderLeadDays != nil {
+		settings.ReminderLeadDays = *req.ReminderLeadDays
+	}
+	if req.OverdueAlerts != nil {
+		settings.OverdueAlerts = *req.OverdueAlerts
+	}
+	if req.VerificationAlerts != nil {
+		settings.VerificationAlerts = *req.VerificationAlerts
+	}
+
+	if err := settings.IsValid(); err != nil {
+		return nil, fmt.Errorf("invalid notification preferences: %w", err)
+	}
+
+	if err := s.userSettingsRepo.Upsert(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to update notification preferences: %w", err)
+	}
+
+	return settings, nil
+}
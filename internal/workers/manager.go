@@ -0,0 +1,138 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// ErrShutdownTimeout is returned by Stop when workers are still running after
+// the shutdown timeout has elapsed
+var ErrShutdownTimeout = errors.New("workers did not stop before the shutdown timeout")
+
+// Worker is a long-running background task. Run must return promptly once ctx
+// is cancelled.
+type Worker interface {
+	Name() string
+	Run(ctx context.Context) error
+}
+
+// funcWorker adapts a plain function to the Worker interface
+type funcWorker struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// NewFuncWorker creates a Worker that runs fn under the given name
+func NewFuncWorker(name string, fn func(ctx context.Context) error) Worker {
+	return &funcWorker{name: name, run: fn}
+}
+
+func (w *funcWorker) Name() string {
+	return w.name
+}
+
+func (w *funcWorker) Run(ctx context.Context) error {
+	return w.run(ctx)
+}
+
+// Manager starts background workers together and drains them on shutdown
+type Manager struct {
+	workers         []Worker
+	shutdownTimeout time.Duration
+	logger          zerolog.Logger
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewManager creates a worker manager that gives workers shutdownTimeout to
+// return once Stop is called
+func NewManager(logger zerolog.Logger, shutdownTimeout time.Duration) *Manager {
+	return &Manager{
+		shutdownTimeout: shutdownTimeout,
+		logger:          logger.With().Str("component", "workers").Logger(),
+	}
+}
+
+// Register adds a worker to be run by Start. Workers registered after Start
+// are not run.
+func (m *Manager) Register(worker Worker) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.workers = append(m.workers, worker)
+}
+
+// Start runs every registered worker in its own goroutine. The workers are
+// cancelled when ctx is done or Stop is called. Calling Start again while the
+// workers are running has no effect.
+func (m *Manager) Start(ctx context.Context) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.cancel != nil {
+		return
+	}
+
+	workerCtx, cancel := context.WithCancel(ctx)
+	m.cancel = cancel
+
+	for _, worker := range m.workers {
+		m.wg.Add(1)
+		go m.run(workerCtx, worker)
+	}
+
+	m.logger.Info().Int("workers", len(m.workers)).Msg("Background workers started")
+}
+
+func (m *Manager) run(ctx context.Context, worker Worker) {
+	defer m.wg.Done()
+
+	logger := m.logger.With().Str("worker", worker.Name()).Logger()
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error().Interface("panic", r).Msg("Background worker panicked")
+		}
+	}()
+
+	if err := worker.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		logger.Error().Err(err).Msg("Background worker stopped with error")
+		return
+	}
+	logger.Debug().Msg("Background worker stopped")
+}
+
+// Stop cancels the workers and waits up to the shutdown timeout for them to
+// return. It returns ErrShutdownTimeout if any worker is still running.
+func (m *Manager) Stop() error {
+	m.mu.Lock()
+	cancel := m.cancel
+	m.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(m.shutdownTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		m.logger.Info().Msg("Background workers stopped")
+		return nil
+	case <-timer.C:
+		m.logger.Error().Dur("timeout", m.shutdownTimeout).Msg("Background workers did not stop in time")
+		return ErrShutdownTimeout
+	}
+}
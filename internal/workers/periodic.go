@@ -0,0 +1,47 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// periodicWorker runs a task on a fixed interval until cancelled
+type periodicWorker struct {
+	name     string
+	interval time.Duration
+	task     func(ctx context.Context) error
+	logger   zerolog.Logger
+}
+
+// NewPeriodicWorker creates a Worker that runs task every interval. A failing
+// run is logged and retried on the next tick rather than stopping the worker.
+func NewPeriodicWorker(name string, interval time.Duration, logger zerolog.Logger, task func(ctx context.Context) error) Worker {
+	return &periodicWorker{
+		name:     name,
+		interval: interval,
+		task:     task,
+		logger:   logger.With().Str("component", "workers").Str("worker", name).Logger(),
+	}
+}
+
+func (w *periodicWorker) Name() string {
+	return w.name
+}
+
+func (w *periodicWorker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := w.task(ctx); err != nil && ctx.Err() == nil {
+				w.logger.Error().Err(err).Msg("Periodic task failed")
+			}
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+)
+
+func TestGetActivitySummary(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	aliceID := f.register("alice-report@example.com", "Alice")
+	bobID := f.register("bob-report@example.com", "Bob")
+
+	newDebt := func(ownerID, contactID uuid.UUID, amount, currency string) *entities.DebtList {
+		debtList, err := f.debtService.CreateDebtList(ctx, ownerID, &entities.CreateDebtListRequest{
+			ContactID:   contactID,
+			DebtType:    "to_receive",
+			TotalAmount: amount,
+			Currency:    currency,
+			DueDate:     timePtr(time.Now().AddDate(0, 6, 0)),
+		})
+		require.NoError(t, err)
+		return debtList
+	}
+	pay := func(userID, debtListID uuid.UUID, amount, currency string, paidOn time.Time) *entities.DebtItem {
+		payment, err := f.debtService.CreateDebtItem(ctx, userID, &entities.CreateDebtItemRequest{
+			DebtListID:    debtListID,
+			Amount:        amount,
+			Currency:      currency,
+			PaymentDate:   paidOn,
+			PaymentMethod: "bank_transfer",
+		})
+		require.NoError(t, err)
+		return payment
+	}
+	now := time.Now()
+
+	// Alice lent Bob 1000 USD two months ago, before the reported range
+	old := newDebt(aliceID, f.contactFor(aliceID, "bob-report@example.com"), "1000.00", "USD")
+	require.NoError(t, f.db.Model(&models.DebtList{}).Where("id = ?", old.ID).UpdateColumn("created_at", now.AddDate(0, -2, 0)).Error)
+
+	// This week Alice lent Bob 300 USD and recorded a 100 repayment herself
+	lent := newDebt(aliceID, f.contactFor(aliceID, "bob-report@example.com"), "300.00", "USD")
+	pay(aliceID, lent.ID, "100.00", "USD", now)
+	// Payments Bob records await Alice's verification: one verified, one rejected, one disputed
+	verified := pay(bobID, lent.ID, "50.00", "USD", now)
+	_, err := f.debtService.VerifyDebtItem(ctx, verified.ID, aliceID, &entities.VerifyDebtItemRequest{Status: entities.PaymentStatusCompleted})
+	require.NoError(t, err)
+	rejected := pay(bobID, lent.ID, "20.00", "USD", now)
+	_, err = f.debtService.RejectDebtItem(ctx, rejected.ID, aliceID, nil)
+	require.NoError(t, err)
+	disputed := pay(bobID, lent.ID, "5.00", "USD", now)
+	_, err = f.debtService.DisputeDebtItem(ctx, disputed.ID, aliceID, &entities.DisputeDebtItemRequest{Reason: "No transfer arrived"})
+	require.NoError(t, err)
+	// A payment dated before the range is left out
+	pay(aliceID, old.ID, "40.00", "USD", now.AddDate(0, -1, 0))
+
+	// Bob lent Alice 80 EUR and she repaid 30
+	borrowed := newDebt(bobID, f.contactFor(bobID, "alice-report@example.com"), "80.00", "EUR")
+	pay(bobID, borrowed.ID, "30.00", "EUR", now)
+
+	debtHandler := handlers.NewDebtHandler(f.debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", aliceID)
+	})
+	router.GET("/api/v1/reports/summary", debtHandler.GetActivitySummary)
+
+	get := func(query string) (int, entities.ActivitySummary) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/reports/summary?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var body struct {
+			Data entities.ActivitySummary `json:"data"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return w.Code, body.Data
+	}
+	assertAmount := func(t *testing.T, expected string, actual decimal.Decimal) {
+		assert.True(t, decimal.RequireFromString(expected).Equal(actual), "expected %s, got %s", expected, actual)
+	}
+
+	// Dates cover whole days, so the range ends after today
+	from := now.AddDate(0, 0, -7).Format(time.DateOnly)
+	code, summary := get("from=" + from + "&to=" + now.Format(time.DateOnly))
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, summary.Currencies, 2)
+
+	eur, usd := summary.Currencies[0], summary.Currencies[1]
+	assert.Equal(t, "EUR", eur.Currency)
+	assert.Equal(t, int64(1), eur.DebtsCreated)
+	assertAmount(t, "0", eur.AmountLent)
+	assertAmount(t, "80.00", eur.AmountBorrowed)
+	assert.Equal(t, int64(1), eur.PaymentsMade)
+	assertAmount(t, "30.00", eur.AmountPaid)
+	assert.Equal(t, int64(0), eur.PaymentsReceived)
+
+	assert.Equal(t, "USD", usd.Currency)
+	assert.Equal(t, int64(1), usd.DebtsCreated)
+	assertAmount(t, "300.00", usd.AmountLent)
+	assertAmount(t, "0", usd.AmountBorrowed)
+	assert.Equal(t, int64(2), usd.PaymentsReceived)
+	assertAmount(t, "150.00", usd.AmountReceived)
+	assert.Equal(t, int64(0), usd.PaymentsMade)
+	assert.Equal(t, int64(1), usd.PaymentsVerified)
+	assert.Equal(t, int64(1), usd.PaymentsRejected)
+	assert.Equal(t, int64(1), usd.PaymentsDisputed)
+
+	// Bob sees the same activity from his side
+	bobSummary, err := f.debtService.GetActivitySummary(ctx, bobID, summary.From, summary.To)
+	require.NoError(t, err)
+	require.Len(t, bobSummary.Currencies, 2)
+	eur, usd = bobSummary.Currencies[0], bobSummary.Currencies[1]
+	assertAmount(t, "80.00", eur.AmountLent)
+	assert.Equal(t, int64(1), eur.PaymentsReceived)
+	assertAmount(t, "30.00", eur.AmountReceived)
+	assertAmount(t, "300.00", usd.AmountBorrowed)
+	assert.Equal(t, int64(2), usd.PaymentsMade)
+	assertAmount(t, "150.00", usd.AmountPaid)
+	assert.Equal(t, int64(1), usd.PaymentsRejected)
+
+	// Widening the range picks up the older debt and payment
+	code, summary = get("from=" + now.AddDate(0, -3, 0).Format(time.DateOnly))
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, summary.Currencies, 2)
+	usd = summary.Currencies[1]
+	assert.Equal(t, int64(2), usd.DebtsCreated)
+	assertAmount(t, "1300.00", usd.AmountLent)
+	assert.Equal(t, int64(3), usd.PaymentsReceived)
+	assertAmount(t, "190.00", usd.AmountReceived)
+
+	// A range with no activity has no currencies
+	code, summary = get("from=2020-01-01&to=2020-01-31")
+	require.Equal(t, http.StatusOK, code)
+	assert.Empty(t, summary.Currencies)
+
+	code, _ = get("from=" + now.Format(time.DateOnly) + "&to=" + from)
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = get("from=last-week")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
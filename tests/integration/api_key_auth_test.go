@@ -0,0 +1,156 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/middleware"
+	"pay-your-dues/internal/models"
+	"pay-your-dues/internal/repository"
+	"pay-your-dues/internal/services"
+)
+
+func TestAPIKeyAuthentication(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	f := newTestFixture(t, &models.APIKey{})
+
+	authService, err := services.NewAuthService(f.userRepo, f.contactService, "test-secret", "24h",
+		services.WithAPIKeyRepository(repository.NewAPIKeyRepositoryGORM(f.db)),
+	)
+	require.NoError(t, err)
+
+	registered, err := authService.Register(context.Background(), &entities.CreateUserRequest{
+		Email:     "scripter@example.com",
+		Password:  "password123",
+		FirstName: "Script",
+		LastName:  "Er",
+	})
+	require.NoError(t, err)
+	userID := registered.User.ID
+
+	login, err := authService.Login(context.Background(), &entities.LoginRequest{
+		Email:    "scripter@example.com",
+		Password: "password123",
+	})
+	require.NoError(t, err)
+
+	authHandler := handlers.NewAuthHandler(authService, zerolog.Nop())
+	authMiddleware := middleware.NewAuthMiddleware(authService, zerolog.Nop())
+
+	router := gin.New()
+	protected := router.Group("/api/v1")
+	protected.Use(authMiddleware.Authenticate())
+	protected.GET("/whoami", func(c *gin.Context) {
+		userID, _ := c.Get("user_id")
+		c.JSON(http.StatusOK, gin.H{"user_id": userID})
+	})
+	protected.POST("/auth/api-keys", authHandler.CreateAPIKey)
+	protected.GET("/auth/api-keys", authHandler.GetAPIKeys)
+	protected.DELETE("/auth/api-keys/:id", authHandler.RevokeAPIKey)
+
+	serve := func(method, path string, body interface{}, headers map[string]string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var responseBody map[string]interface{}
+		_ = json.Unmarshal(w.Body.Bytes(), &responseBody)
+		return w, responseBody
+	}
+	bearer := map[string]string{"Authorization": "Bearer " + login.Token}
+
+	// Issue a key using the JWT
+	w, body := serve(http.MethodPost, "/api/v1/auth/api-keys", map[string]interface{}{"name": "nightly export"}, bearer)
+	require.Equal(t, http.StatusCreated, w.Code)
+	data := body["data"].(map[string]interface{})
+	key := data["key"].(string)
+	keyID := data["id"].(string)
+	assert.Contains(t, key, entities.APIKeyPrefix)
+	assert.Equal(t, []interface{}{entities.ScopeFull}, data["scopes"])
+
+	// Only the hash is stored
+	var stored models.APIKey
+	require.NoError(t, f.db.First(&stored, "id = ?", keyID).Error)
+	assert.NotEqual(t, key, stored.KeyHash)
+	assert.NotContains(t, stored.KeyHash, key)
+
+	// The key authenticates as its owner on a protected route
+	w, body = serve(http.MethodGet, "/api/v1/whoami", nil, map[string]string{"X-API-Key": key})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, userID.String(), body["user_id"])
+
+	// Listing never returns the secret
+	w, body = serve(http.MethodGet, "/api/v1/auth/api-keys", nil, map[string]string{"X-API-Key": key})
+	require.Equal(t, http.StatusOK, w.Code)
+	keys := body["data"].([]interface{})
+	require.Len(t, keys, 1)
+	listed := keys[0].(map[string]interface{})
+	assert.NotContains(t, listed, "key")
+	assert.NotNil(t, listed["last_used_at"])
+
+	// Unknown keys are rejected
+	w, body = serve(http.MethodGet, "/api/v1/whoami", nil, map[string]string{"X-API-Key": entities.APIKeyPrefix + "not-a-real-key"})
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, "Invalid API key", body["error"])
+
+	// Revoked keys stop working
+	w, _ = serve(http.MethodDelete, "/api/v1/auth/api-keys/"+keyID, nil, bearer)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w, body = serve(http.MethodGet, "/api/v1/whoami", nil, map[string]string{"X-API-Key": key})
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, "Invalid API key", body["error"])
+
+	// Revoking twice reports the key as not found
+	w, _ = serve(http.MethodDelete, "/api/v1/auth/api-keys/"+keyID, nil, bearer)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAPIKeyCreation_RejectsUnknownScope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	authService, err := services.NewAuthService(nil, nil, "test-secret", "24h")
+	require.NoError(t, err)
+	authHandler := handlers.NewAuthHandler(authService, zerolog.Nop())
+
+	router := gin.New()
+	router.POST("/api/v1/auth/api-keys", func(c *gin.Context) {
+		c.Set("user_id", uuid.New())
+		authHandler.CreateAPIKey(c)
+	})
+
+	payload, _ := json.Marshal(map[string]interface{}{"name": "bad", "scopes": []string{"admin"}})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/api-keys", bytes.NewBuffer(payload))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var responseBody map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &responseBody))
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"field": "scopes[0]", "message": "scopes[0] must be one of: read, full"},
+	}, responseBody["errors"])
+}
@@ -86,11 +86,17 @@ func TestAuthHandler_Register(t *testing.T) {
 				"password": "123",
 			},
 			setupMock: func(mockAuthService *mocks.MockAuthService) {
-				mockAuthService.On("Register", mock.Anything, mock.AnythingOfType("*entities.CreateUserRequest")).Return(nil, entities.ErrInvalidEmail)
+				// Rejected by request validation before reaching the service
 			},
 			expectedStatus: http.StatusBadRequest,
 			validateBody: func(t *testing.T, body map[string]interface{}) {
 				assert.Equal(t, "Invalid input", body["error"])
+				assert.ElementsMatch(t, []interface{}{
+					map[string]interface{}{"field": "email", "message": "email must be a valid email address"},
+					map[string]interface{}{"field": "password", "message": "password must be at least 6 characters"},
+					map[string]interface{}{"field": "first_name", "message": "first_name is required"},
+					map[string]interface{}{"field": "last_name", "message": "last_name is required"},
+				}, body["errors"])
 			},
 		},
 		{
@@ -207,12 +213,14 @@ func TestAuthHandler_Login(t *testing.T) {
 				"password": "password123",
 			},
 			setupMock: func(mockAuthService *mocks.MockAuthService) {
-				// The handler will call the service with empty email, so we need to mock it
-				mockAuthService.On("Login", mock.Anything, mock.AnythingOfType("*entities.LoginRequest")).Return(nil, entities.ErrInvalidEmail)
+				// Rejected by request validation before reaching the service
 			},
 			expectedStatus: http.StatusBadRequest,
 			validateBody: func(t *testing.T, body map[string]interface{}) {
 				assert.Equal(t, "Invalid input", body["error"])
+				assert.Equal(t, []interface{}{
+					map[string]interface{}{"field": "email", "message": "email is required"},
+				}, body["errors"])
 			},
 		},
 	}
@@ -0,0 +1,155 @@
+package integration
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"pay-your-dues/internal/domain/entities"
+)
+
+func TestConcurrentPaymentsKeepTotalsConsistent(t *testing.T) {
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	// Every connection to :memory: is a separate database, so the goroutines
+	// below share a single one and take turns on it
+	sqlDB, err := f.db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "lender-concurrent@example.com",
+		Password:  "password123",
+		FirstName: "Concurrent",
+		LastName:  "Lender",
+	})
+	require.NoError(t, err)
+	lenderID := resp.User.ID
+
+	contact, err := f.contactService.CreateContact(ctx, lenderID, &entities.CreateContactRequest{Name: "Busy Payer"})
+	require.NoError(t, err)
+	debtList, err := f.debtService.CreateDebtList(ctx, lenderID, &entities.CreateDebtListRequest{
+		ContactID:   contact.ID,
+		DebtType:    "to_receive",
+		TotalAmount: "1000.00",
+		Currency:    "USD",
+		DueDate:     timePtr(time.Now().AddDate(0, 6, 0)),
+	})
+	require.NoError(t, err)
+
+	const payments = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, payments)
+	for i := 0; i < payments; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := f.debtService.CreateDebtItem(ctx, lenderID, &entities.CreateDebtItemRequest{
+				DebtListID:    debtList.ID,
+				Amount:        "12.50",
+				Currency:      "USD",
+				PaymentDate:   time.Now(),
+				PaymentMethod: "cash",
+			})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	// The stored totals account for every payment, whichever recalculation ran last
+	stored, err := f.debtListRepo.GetByID(ctx, debtList.ID)
+	require.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("250.00").Equal(stored.TotalPaymentsMade), stored.TotalPaymentsMade.String())
+	assert.True(t, decimal.RequireFromString("750.00").Equal(stored.TotalRemainingDebt), stored.TotalRemainingDebt.String())
+	assert.Equal(t, "active", stored.Status)
+
+	items, err := f.debtItemRepo.GetByDebtListID(ctx, debtList.ID)
+	require.NoError(t, err)
+	assert.Len(t, items, payments)
+}
+
+func TestConcurrentReversalsStayWithinThePayment(t *testing.T) {
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	sqlDB, err := f.db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	lenderID := f.register("lender-reversals@example.com", "Lender")
+	contact, err := f.contactService.CreateContact(ctx, lenderID, &entities.CreateContactRequest{Name: "Bounced Payer"})
+	require.NoError(t, err)
+	debtList, err := f.debtService.CreateDebtList(ctx, lenderID, &entities.CreateDebtListRequest{
+		ContactID:   contact.ID,
+		DebtType:    "to_receive",
+		TotalAmount: "500.00",
+		Currency:    "USD",
+		DueDate:     timePtr(time.Now().AddDate(0, 6, 0)),
+	})
+	require.NoError(t, err)
+	payment, err := f.debtService.CreateDebtItem(ctx, lenderID, &entities.CreateDebtItemRequest{
+		DebtListID:    debtList.ID,
+		Amount:        "100.00",
+		Currency:      "USD",
+		PaymentDate:   time.Now(),
+		PaymentMethod: "bank_transfer",
+	})
+	require.NoError(t, err)
+	require.Equal(t, entities.PaymentStatusCompleted, payment.Status)
+
+	// Slow down inserts so a reversal checked against the payment is still being
+	// written while the others are checked
+	require.NoError(t, f.db.Callback().Create().Before("gorm:create").Register("test:slow_create", func(*gorm.DB) {
+		time.Sleep(5 * time.Millisecond)
+	}))
+
+	// The reversals start together so their checks overlap
+	const reversals = 10
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	errs := make(chan error, reversals)
+	for i := 0; i < reversals; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, err := f.debtService.ReversePayment(ctx, payment.ID, lenderID, decimal.RequireFromString("30.00"), "Bounced")
+			errs <- err
+		}()
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	// Only as many reversals as fit in the payment go through
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, entities.ErrReversalExceedsPayment)
+	}
+	assert.Equal(t, 3, succeeded)
+
+	reversed, err := f.debtItemRepo.GetReversedAmount(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("90.00").Equal(reversed), reversed.String())
+
+	stored, err := f.debtListRepo.GetByID(ctx, debtList.ID)
+	require.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("10.00").Equal(stored.TotalPaymentsMade), stored.TotalPaymentsMade.String())
+}
@@ -0,0 +1,168 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/models"
+	"pay-your-dues/internal/repository"
+	"pay-your-dues/internal/services"
+)
+
+func TestContactBlocking(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t, &models.DebtProposal{})
+
+	proposalService := services.NewDebtProposalService(repository.NewDebtProposalRepositoryGORM(f.db), f.contactRepo, f.debtService)
+
+	aliceID := f.register("alice-blocking@example.com", "Alice")
+	bobID := f.register("bob-blocking@example.com", "Bob")
+	carolID := f.register("carol-blocking@example.com", "Carol")
+
+	newDebt := func(ownerID, contactID uuid.UUID) error {
+		_, err := f.debtService.CreateDebtList(ctx, ownerID, &entities.CreateDebtListRequest{
+			ContactID:   contactID,
+			DebtType:    "to_receive",
+			TotalAmount: "300.00",
+			Currency:    "USD",
+			DueDate:     timePtr(time.Now().AddDate(0, 3, 0)),
+		})
+		return err
+	}
+
+	bob, err := f.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{
+		Name:  "Bob",
+		Email: stringPtr("bob-blocking@example.com"),
+	})
+	require.NoError(t, err)
+	require.True(t, bob.IsUser)
+	require.NoError(t, newDebt(aliceID, bob.ID))
+
+	// Adding Bob as a contact gave him a reciprocal contact for Alice
+	var aliceForBob uuid.UUID
+	userContacts, err := f.contactRepo.GetUserContactsByEmail(ctx, "alice-blocking@example.com")
+	require.NoError(t, err)
+	for _, uc := range userContacts {
+		if uc.UserID == bobID {
+			aliceForBob = uc.ContactID
+		}
+	}
+	require.NotEqual(t, uuid.Nil, aliceForBob)
+
+	contactHandler := handlers.NewContactHandler(f.contactService, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		userID, err := uuid.Parse(c.GetHeader("X-Test-User"))
+		require.NoError(t, err)
+		c.Set("user_id", userID)
+	})
+	router.POST("/api/v1/contacts/:id/block", contactHandler.BlockContact)
+	router.DELETE("/api/v1/contacts/:id/block", contactHandler.UnblockContact)
+
+	setBlocked := func(userID, contactID uuid.UUID, method string) (int, entities.ContactResponse) {
+		req := httptest.NewRequest(method, "/api/v1/contacts/"+contactID.String()+"/block", nil)
+		req.Header.Set("X-Test-User", userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var body struct {
+			Data entities.ContactResponse `json:"data"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return w.Code, body.Data
+	}
+
+	t.Run("a blocked contact can't be used for a new debt", func(t *testing.T) {
+		code, contact := setBlocked(aliceID, bob.ID, http.MethodPost)
+		require.Equal(t, http.StatusOK, code)
+		assert.True(t, contact.IsBlocked)
+
+		assert.ErrorIs(t, newDebt(aliceID, bob.ID), entities.ErrContactBlocked)
+	})
+
+	t.Run("the blocked user can't start a debt with the blocker either", func(t *testing.T) {
+		assert.ErrorIs(t, newDebt(bobID, aliceForBob), entities.ErrContactBlocked)
+	})
+
+	t.Run("the blocked user can't propose a debt to the blocker", func(t *testing.T) {
+		_, err := proposalService.ProposeDebt(ctx, bobID, &entities.CreateDebtListRequest{
+			ContactID:   aliceForBob,
+			DebtType:    "to_receive",
+			TotalAmount: "300.00",
+			Currency:    "USD",
+			DueDate:     timePtr(time.Now().AddDate(0, 3, 0)),
+		})
+		assert.ErrorIs(t, err, entities.ErrContactBlocked)
+	})
+
+	t.Run("the blocker looks unregistered to the blocked user", func(t *testing.T) {
+		lookup, err := f.contactService.LookupContact(ctx, bobID, "alice-blocking@example.com")
+		require.NoError(t, err)
+		assert.False(t, lookup.IsUser)
+		assert.False(t, lookup.WillLink)
+		assert.Nil(t, lookup.DisplayName)
+
+		lookup, err = f.contactService.LookupContact(ctx, carolID, "alice-blocking@example.com")
+		require.NoError(t, err)
+		assert.True(t, lookup.WillLink)
+	})
+
+	t.Run("the blocked user isn't linked to the blocker as a new contact", func(t *testing.T) {
+		err := f.contactService.CreateReciprocalContact(ctx, "alice-blocking@example.com", bobID)
+		assert.ErrorIs(t, err, entities.ErrContactBlocked)
+
+		// Carol adding Alice is unaffected by Alice blocking Bob
+		alice, err := f.contactService.CreateContact(ctx, carolID, &entities.CreateContactRequest{
+			Name:  "Alice",
+			Email: stringPtr("alice-blocking@example.com"),
+		})
+		require.NoError(t, err)
+		assert.True(t, alice.IsUser)
+	})
+
+	t.Run("unblocking allows new debts again", func(t *testing.T) {
+		code, contact := setBlocked(aliceID, bob.ID, http.MethodDelete)
+		require.Equal(t, http.StatusOK, code)
+		assert.False(t, contact.IsBlocked)
+
+		assert.NoError(t, newDebt(aliceID, bob.ID))
+		assert.NoError(t, newDebt(bobID, aliceForBob))
+	})
+
+	t.Run("the blocked user's existing link to the blocker is cleared on update", func(t *testing.T) {
+		code, _ := setBlocked(aliceID, bob.ID, http.MethodPost)
+		require.Equal(t, http.StatusOK, code)
+
+		updated, err := f.contactService.UpdateContact(ctx, aliceForBob, bobID, &entities.UpdateContactRequest{
+			Email: stringPtr("alice-blocking@example.com"),
+		})
+		require.NoError(t, err)
+		assert.False(t, updated.IsUser)
+		assert.Nil(t, updated.UserIDRef)
+
+		code, _ = setBlocked(aliceID, bob.ID, http.MethodDelete)
+		require.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("unknown contact", func(t *testing.T) {
+		code, _ := setBlocked(aliceID, uuid.New(), http.MethodPost)
+		assert.Equal(t, http.StatusNotFound, code)
+
+		// Bob's contacts aren't Carol's to block
+		code, _ = setBlocked(carolID, aliceForBob, http.MethodPost)
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
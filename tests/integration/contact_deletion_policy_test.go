@@ -0,0 +1,132 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/domain/interfaces"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+	"pay-your-dues/internal/repository"
+	"pay-your-dues/internal/services"
+)
+
+func TestContactDeletionPolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	// setup returns a router deleting Alice's contacts under policy, with the debt
+	// service and Alice's ID to arrange debts with
+	setup := func(t *testing.T, policy string) (*gin.Engine, interfaces.ContactService, interfaces.DebtService, uuid.UUID) {
+		db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		require.NoError(t, err)
+		require.NoError(t, db.AutoMigrate(&models.User{}, &models.Contact{}, &models.UserContact{}, &models.DebtList{}, &models.DebtItem{}))
+
+		userRepo := repository.NewUserRepositoryGORM(db)
+		contactRepo := repository.NewContactRepositoryGORM(db)
+		debtListRepo := repository.NewDebtListRepositoryGORM(db, contactRepo)
+		contactService := services.NewContactService(contactRepo, userRepo,
+			services.WithContactDeletionPolicy(policy),
+			services.WithContactDebtListRepository(debtListRepo))
+		authService, err := services.NewAuthService(userRepo, contactService, "test-secret", "24h")
+		require.NoError(t, err)
+		debtService := services.NewDebtService(debtListRepo, repository.NewDebtItemRepositoryGORM(db), contactRepo,
+			services.NewPaymentScheduleService(), &mocks.MockFileStorageService{})
+
+		resp, err := authService.Register(ctx, &entities.CreateUserRequest{
+			Email:     "alice-deletion@example.com",
+			Password:  "password123",
+			FirstName: "Alice",
+			LastName:  "Deletion",
+		})
+		require.NoError(t, err)
+		aliceID := resp.User.ID
+
+		contactHandler := handlers.NewContactHandler(contactService, zerolog.Nop())
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("user_id", aliceID)
+		})
+		router.DELETE("/api/v1/contacts/:id", contactHandler.DeleteContact)
+		return router, contactService, debtService, aliceID
+	}
+	deleteContact := func(router *gin.Engine, contactID uuid.UUID) int {
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/contacts/"+contactID.String(), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	// arrange gives Alice a contact with one 100 USD debt, paid in full when settled
+	arrange := func(t *testing.T, contactService interfaces.ContactService, debtService interfaces.DebtService, aliceID uuid.UUID, settled bool) (uuid.UUID, uuid.UUID) {
+		contact, err := contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{Name: "Bob"})
+		require.NoError(t, err)
+		debtList, err := debtService.CreateDebtList(ctx, aliceID, &entities.CreateDebtListRequest{
+			ContactID:   contact.ID,
+			DebtType:    "to_receive",
+			TotalAmount: "100.00",
+			Currency:    "USD",
+			DueDate:     timePtr(time.Now().AddDate(0, 1, 0)),
+		})
+		require.NoError(t, err)
+		if settled {
+			_, err := debtService.CreateDebtItem(ctx, aliceID, &entities.CreateDebtItemRequest{
+				DebtListID:    debtList.ID,
+				Amount:        "100.00",
+				Currency:      "USD",
+				PaymentDate:   time.Now(),
+				PaymentMethod: "cash",
+			})
+			require.NoError(t, err)
+		}
+		return contact.ID, debtList.ID
+	}
+
+	t.Run("block keeps a contact with unsettled debts", func(t *testing.T) {
+		router, contactService, debtService, aliceID := setup(t, entities.ContactDeletionPolicyBlock)
+		contactID, _ := arrange(t, contactService, debtService, aliceID, false)
+
+		assert.Equal(t, http.StatusConflict, deleteContact(router, contactID))
+
+		contact, err := contactService.GetContact(ctx, contactID, aliceID)
+		require.NoError(t, err)
+		assert.Equal(t, "Bob", contact.Name)
+	})
+
+	t.Run("block deletes a contact whose debts are settled", func(t *testing.T) {
+		router, contactService, debtService, aliceID := setup(t, entities.ContactDeletionPolicyBlock)
+		contactID, _ := arrange(t, contactService, debtService, aliceID, true)
+
+		assert.Equal(t, http.StatusOK, deleteContact(router, contactID))
+
+		_, err := contactService.GetContact(ctx, contactID, aliceID)
+		assert.ErrorIs(t, err, entities.ErrContactNotFound)
+	})
+
+	t.Run("detach deletes the contact and keeps its debts", func(t *testing.T) {
+		router, contactService, debtService, aliceID := setup(t, entities.ContactDeletionPolicyDetach)
+		contactID, debtListID := arrange(t, contactService, debtService, aliceID, false)
+
+		assert.Equal(t, http.StatusOK, deleteContact(router, contactID))
+
+		_, err := contactService.GetContact(ctx, contactID, aliceID)
+		assert.ErrorIs(t, err, entities.ErrContactNotFound)
+		debtList, err := debtService.GetDebtList(ctx, debtListID, aliceID)
+		require.NoError(t, err)
+		assert.Equal(t, contactID, debtList.Contact.ID)
+		assert.Equal(t, entities.UnknownContactName, debtList.Contact.Name)
+		assert.Equal(t, "active", debtList.Status)
+	})
+}
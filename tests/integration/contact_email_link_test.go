@@ -0,0 +1,78 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+)
+
+func TestUpdateContactEmail_LinksRegisteredUser(t *testing.T) {
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	owner, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "owner-relink@example.com",
+		Password:  "password123",
+		FirstName: "Olive",
+		LastName:  "Owner",
+	})
+	require.NoError(t, err)
+	friend, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "friend-relink@example.com",
+		Password:  "password123",
+		FirstName: "Fred",
+		LastName:  "Friend",
+	})
+	require.NoError(t, err)
+	ownerID := owner.User.ID
+	friendID := friend.User.ID
+
+	// The contact starts out without an email, so it is not linked to anyone
+	contact, err := f.contactService.CreateContact(ctx, ownerID, &entities.CreateContactRequest{Name: "Freddy"})
+	require.NoError(t, err)
+	assert.False(t, contact.IsUser)
+
+	friendContacts, err := f.contactService.GetUserContacts(ctx, friendID)
+	require.NoError(t, err)
+	assert.Empty(t, friendContacts)
+
+	// Setting the email of a registered user links the contact to them
+	updated, err := f.contactService.UpdateContact(ctx, contact.ID, ownerID, &entities.UpdateContactRequest{
+		Email: stringPtr("friend-relink@example.com"),
+	})
+	require.NoError(t, err)
+	assert.True(t, updated.IsUser)
+	require.NotNil(t, updated.UserIDRef)
+	assert.Equal(t, friendID, *updated.UserIDRef)
+
+	stored, err := f.contactRepo.GetByID(ctx, contact.ID)
+	require.NoError(t, err)
+	assert.True(t, stored.IsUser)
+	require.NotNil(t, stored.UserIDRef)
+	assert.Equal(t, friendID, *stored.UserIDRef)
+
+	// and gives that user a reciprocal contact for the owner
+	friendContacts, err = f.contactService.GetUserContacts(ctx, friendID)
+	require.NoError(t, err)
+	require.Len(t, friendContacts, 1)
+	assert.Equal(t, "Olive Owner", friendContacts[0].Name)
+	require.NotNil(t, friendContacts[0].Email)
+	assert.Equal(t, "owner-relink@example.com", *friendContacts[0].Email)
+	assert.True(t, friendContacts[0].IsUser)
+	require.NotNil(t, friendContacts[0].UserIDRef)
+	assert.Equal(t, ownerID, *friendContacts[0].UserIDRef)
+
+	// Updating again does not duplicate the reciprocal contact
+	_, err = f.contactService.UpdateContact(ctx, contact.ID, ownerID, &entities.UpdateContactRequest{
+		Email: stringPtr("friend-relink@example.com"),
+	})
+	require.NoError(t, err)
+	friendContacts, err = f.contactService.GetUserContacts(ctx, friendID)
+	require.NoError(t, err)
+	assert.Len(t, friendContacts, 1)
+}
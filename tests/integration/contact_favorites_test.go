@@ -0,0 +1,121 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+)
+
+func TestContactFavorites(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "alice-favorites@example.com",
+		Password:  "password123",
+		FirstName: "Alice",
+		LastName:  "Favorites",
+	})
+	require.NoError(t, err)
+	aliceID := resp.User.ID
+
+	newContact := func(name string) uuid.UUID {
+		contact, err := f.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{Name: name})
+		require.NoError(t, err)
+		return contact.ID
+	}
+	// Listed by name by default
+	annaID := newContact("Anna")
+	newContact("Ben")
+	carlID := newContact("Carl")
+
+	contactHandler := handlers.NewContactHandler(f.contactService, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", aliceID)
+	})
+	router.GET("/api/v1/contacts", contactHandler.GetUserContacts)
+	router.POST("/api/v1/contacts/:id/favorite", contactHandler.ToggleFavorite)
+
+	toggle := func(contactID uuid.UUID) (int, entities.ContactResponse) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/contacts/"+contactID.String()+"/favorite", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var body struct {
+			Data entities.ContactResponse `json:"data"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return w.Code, body.Data
+	}
+	list := func(query string) (int, []string) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/contacts?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var body struct {
+			Data []entities.ContactResponse `json:"data"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		names := make([]string, len(body.Data))
+		for i, contact := range body.Data {
+			names[i] = contact.Name
+		}
+		return w.Code, names
+	}
+
+	code, carl := toggle(carlID)
+	require.Equal(t, http.StatusOK, code)
+	assert.True(t, carl.IsFavorite)
+	stored, err := f.contactService.GetContact(ctx, carlID, aliceID)
+	require.NoError(t, err)
+	assert.True(t, stored.IsFavorite)
+
+	t.Run("favorites are listed first on request", func(t *testing.T) {
+		code, names := list("favorites_first=true")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []string{"Carl", "Anna", "Ben"}, names)
+
+		code, names = list("")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []string{"Anna", "Ben", "Carl"}, names)
+	})
+
+	t.Run("favorites keep their order among themselves", func(t *testing.T) {
+		code, _ := toggle(annaID)
+		require.Equal(t, http.StatusOK, code)
+
+		_, names := list("favorites_first=true")
+		assert.Equal(t, []string{"Anna", "Carl", "Ben"}, names)
+	})
+
+	t.Run("toggling again unmarks the favorite", func(t *testing.T) {
+		code, anna := toggle(annaID)
+		require.Equal(t, http.StatusOK, code)
+		assert.False(t, anna.IsFavorite)
+
+		_, names := list("favorites_first=true")
+		assert.Equal(t, []string{"Carl", "Anna", "Ben"}, names)
+	})
+
+	t.Run("invalid favorites_first flag", func(t *testing.T) {
+		code, _ := list("favorites_first=maybe")
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("unknown contact", func(t *testing.T) {
+		code, _ := toggle(uuid.New())
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
@@ -0,0 +1,278 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+)
+
+func TestContactHandler_CreateContact_Validation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := uuid.New()
+	existingContactID := uuid.New()
+
+	tests := []struct {
+		name           string
+		query          string
+		requestBody    interface{}
+		setupMock      func(*mocks.MockContactService)
+		expectedStatus int
+		validateBody   func(*testing.T, map[string]interface{})
+	}{
+		{
+			name: "empty optional email is accepted",
+			requestBody: map[string]interface{}{
+				"name":  "John Doe",
+				"email": "",
+			},
+			setupMock: func(mockContactService *mocks.MockContactService) {
+				mockContactService.On("CreateContact", mock.Anything, userID, mock.AnythingOfType("*entities.CreateContactRequest")).Return(&entities.ContactResponse{
+					ID:   uuid.New(),
+					Name: "John Doe",
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Contact created successfully", body["message"])
+			},
+		},
+		{
+			name: "malformed email is rejected",
+			requestBody: map[string]interface{}{
+				"name":  "John Doe",
+				"email": "not-an-email",
+			},
+			setupMock: func(mockContactService *mocks.MockContactService) {
+				// No mock setup needed
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Invalid input", body["error"])
+				assert.Equal(t, []interface{}{
+					map[string]interface{}{"field": "email", "message": "email must be a valid email address"},
+				}, body["errors"])
+			},
+		},
+		{
+			name: "duplicate email points at the existing contact",
+			requestBody: map[string]interface{}{
+				"name":  "John Again",
+				"email": "john@example.com",
+			},
+			setupMock: func(mockContactService *mocks.MockContactService) {
+				mockContactService.On("CreateContact", mock.Anything, userID, mock.AnythingOfType("*entities.CreateContactRequest")).Return(nil, &entities.DuplicateContactError{
+					ExistingContactID: existingContactID,
+				})
+			},
+			expectedStatus: http.StatusConflict,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "contact_already_exists", body["code"])
+				assert.Equal(t, map[string]interface{}{"existing_contact_id": existingContactID.String()}, body["data"])
+			},
+		},
+		{
+			name:  "upsert returns the existing contact",
+			query: "?upsert=true",
+			requestBody: map[string]interface{}{
+				"name":  "John Again",
+				"email": "john@example.com",
+			},
+			setupMock: func(mockContactService *mocks.MockContactService) {
+				mockContactService.On("CreateContact", mock.Anything, userID, mock.AnythingOfType("*entities.CreateContactRequest")).Return(nil, &entities.DuplicateContactError{
+					ExistingContactID: existingContactID,
+				})
+				mockContactService.On("GetContact", mock.Anything, existingContactID, userID).Return(&entities.ContactResponse{
+					ID:   existingContactID,
+					Name: "John Doe",
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "existing_contact_returned", body["code"])
+				data := body["data"].(map[string]interface{})
+				assert.Equal(t, existingContactID.String(), data["id"])
+				assert.Equal(t, "John Doe", data["name"])
+			},
+		},
+		{
+			name:  "upsert creates a contact that does not exist yet",
+			query: "?upsert=true",
+			requestBody: map[string]interface{}{
+				"name":  "Jane Doe",
+				"email": "jane@example.com",
+			},
+			setupMock: func(mockContactService *mocks.MockContactService) {
+				mockContactService.On("CreateContact", mock.Anything, userID, mock.AnythingOfType("*entities.CreateContactRequest")).Return(&entities.ContactResponse{
+					ID:   uuid.New(),
+					Name: "Jane Doe",
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Contact created successfully", body["message"])
+			},
+		},
+		{
+			name:  "duplicate without upsert still conflicts",
+			query: "?upsert=false",
+			requestBody: map[string]interface{}{
+				"name":  "John Again",
+				"email": "john@example.com",
+			},
+			setupMock: func(mockContactService *mocks.MockContactService) {
+				mockContactService.On("CreateContact", mock.Anything, userID, mock.AnythingOfType("*entities.CreateContactRequest")).Return(nil, &entities.DuplicateContactError{
+					ExistingContactID: existingContactID,
+				})
+			},
+			expectedStatus: http.StatusConflict,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "contact_already_exists", body["code"])
+			},
+		},
+		{
+			name:  "invalid upsert flag",
+			query: "?upsert=maybe",
+			requestBody: map[string]interface{}{
+				"name": "John Doe",
+			},
+			setupMock: func(mockContactService *mocks.MockContactService) {
+				// No mock setup needed
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Invalid upsert flag", body["error"])
+			},
+		},
+		{
+			name: "missing name",
+			requestBody: map[string]interface{}{
+				"phone": "+1234567890",
+			},
+			setupMock: func(mockContactService *mocks.MockContactService) {
+				// No mock setup needed
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Invalid input", body["error"])
+				assert.Equal(t, []interface{}{
+					map[string]interface{}{"field": "name", "message": "name is required"},
+				}, body["errors"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockContactService := &mocks.MockContactService{}
+			tt.setupMock(mockContactService)
+
+			contactHandler := handlers.NewContactHandler(mockContactService, zerolog.New(nil))
+
+			router := gin.New()
+			router.POST("/api/contacts", func(c *gin.Context) {
+				c.Set("user_id", userID)
+				contactHandler.CreateContact(c)
+			})
+
+			body, _ := json.Marshal(tt.requestBody)
+
+			// Execute
+			req := httptest.NewRequest(http.MethodPost, "/api/contacts"+tt.query, bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var responseBody map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &responseBody)
+			assert.NoError(t, err)
+			tt.validateBody(t, responseBody)
+
+			mockContactService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestContactHandler_GetContact_Localization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := uuid.New()
+	contactID := uuid.New()
+
+	tests := []struct {
+		name            string
+		acceptLanguage  string
+		expectedMessage string
+	}{
+		{
+			name:            "spanish is translated",
+			acceptLanguage:  "es-MX,es;q=0.9,en;q=0.8",
+			expectedMessage: "Contacto no encontrado",
+		},
+		{
+			name:            "preferred english wins over spanish",
+			acceptLanguage:  "en-US,es;q=0.5",
+			expectedMessage: "Contact not found",
+		},
+		{
+			name:            "unsupported locale falls back to english",
+			acceptLanguage:  "fr-FR",
+			expectedMessage: "Contact not found",
+		},
+		{
+			name:            "missing header defaults to english",
+			expectedMessage: "Contact not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockContactService := &mocks.MockContactService{}
+			mockContactService.On("GetContact", mock.Anything, contactID, userID).Return(nil, entities.ErrContactNotFound)
+
+			contactHandler := handlers.NewContactHandler(mockContactService, zerolog.New(nil))
+
+			router := gin.New()
+			router.GET("/api/contacts/:id", func(c *gin.Context) {
+				c.Set("user_id", userID)
+				contactHandler.GetContact(c)
+			})
+
+			// Execute
+			req := httptest.NewRequest(http.MethodGet, "/api/contacts/"+contactID.String(), nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusNotFound, w.Code)
+
+			var responseBody map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &responseBody)
+			assert.NoError(t, err)
+			assert.Equal(t, "contact_not_found", responseBody["code"])
+			assert.Equal(t, tt.expectedMessage, responseBody["error"])
+
+			mockContactService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/services"
+)
+
+func TestLookupContact(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	register := func(email, firstName string, phone *string) *entities.User {
+		resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+			Email:     email,
+			Password:  "password123",
+			FirstName: firstName,
+			LastName:  "Lookup",
+			Phone:     phone,
+		})
+		require.NoError(t, err)
+		return &resp.User
+	}
+	alice := register("alice-lookup@example.com", "Alice", nil)
+	register("bob-lookup@example.com", "Bob", stringPtr("+15550100"))
+
+	lookup := func(t *testing.T, handler *handlers.ContactHandler, email string) (int, string) {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("user_id", alice.ID)
+		})
+		router.GET("/api/v1/contacts/lookup", handler.LookupContact)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/contacts/lookup?email="+url.QueryEscape(email), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code, w.Body.String()
+	}
+	decode := func(t *testing.T, body string) entities.ContactLookup {
+		var response struct {
+			Data entities.ContactLookup `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(body), &response))
+		return response.Data
+	}
+	handler := handlers.NewContactHandler(f.contactService, zerolog.Nop())
+
+	t.Run("known user", func(t *testing.T) {
+		code, body := lookup(t, handler, " Bob-Lookup@Example.com ")
+		require.Equal(t, http.StatusOK, code)
+
+		result := decode(t, body)
+		assert.Equal(t, "bob-lookup@example.com", result.Email)
+		assert.True(t, result.IsUser)
+		assert.True(t, result.WillLink)
+		require.NotNil(t, result.DisplayName)
+		assert.Equal(t, "Bob Lookup", *result.DisplayName)
+
+		// Nothing about the user beyond their name is revealed
+		assert.NotContains(t, body, "+15550100")
+		assert.NotContains(t, body, `"id"`)
+	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		code, body := lookup(t, handler, "nobody-lookup@example.com")
+		require.Equal(t, http.StatusOK, code)
+
+		result := decode(t, body)
+		assert.False(t, result.IsUser)
+		assert.False(t, result.WillLink)
+		assert.Nil(t, result.DisplayName)
+	})
+
+	t.Run("known user without reciprocal contacts", func(t *testing.T) {
+		unlinked := handlers.NewContactHandler(services.NewContactService(f.contactRepo, f.userRepo, services.WithReciprocalContacts(false)), zerolog.Nop())
+		code, body := lookup(t, unlinked, "bob-lookup@example.com")
+		require.Equal(t, http.StatusOK, code)
+
+		result := decode(t, body)
+		assert.True(t, result.IsUser)
+		assert.False(t, result.WillLink)
+	})
+
+	t.Run("invalid email", func(t *testing.T) {
+		code, _ := lookup(t, handler, "not-an-email")
+		assert.Equal(t, http.StatusBadRequest, code)
+		code, _ = lookup(t, handler, "")
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
@@ -0,0 +1,129 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+	"pay-your-dues/internal/repository"
+	"pay-your-dues/internal/services"
+)
+
+func TestContactNameSource(t *testing.T) {
+	tests := []struct {
+		source       string
+		expectedName string
+	}{
+		{source: entities.ContactNameSourceContact, expectedName: "Janey"},
+		{source: entities.ContactNameSourceVerified, expectedName: "Jane Smith"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			ctx := context.Background()
+
+			db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+			require.NoError(t, err)
+			require.NoError(t, db.AutoMigrate(&models.User{}, &models.Contact{}, &models.UserContact{}, &models.DebtList{}, &models.DebtItem{}))
+
+			userRepo := repository.NewUserRepositoryGORM(db)
+			contactRepo := repository.NewContactRepositoryGORM(db)
+			debtListRepo := repository.NewDebtListRepositoryGORM(db, contactRepo, repository.WithDebtListContactNameSource(tt.source))
+			debtItemRepo := repository.NewDebtItemRepositoryGORM(db)
+			contactService := services.NewContactService(contactRepo, userRepo, services.WithContactNameSource(tt.source))
+			authService, err := services.NewAuthService(userRepo, contactService, "test-secret", "24h")
+			require.NoError(t, err)
+			debtService := services.NewDebtService(debtListRepo, debtItemRepo, contactRepo, services.NewPaymentScheduleService(), &mocks.MockFileStorageService{},
+				services.WithDebtContactNameSource(tt.source))
+
+			owner, err := authService.Register(ctx, &entities.CreateUserRequest{
+				Email:     "owner-name@example.com",
+				Password:  "password123",
+				FirstName: "Olive",
+				LastName:  "Owner",
+			})
+			require.NoError(t, err)
+			_, err = authService.Register(ctx, &entities.CreateUserRequest{
+				Email:     "jane-name@example.com",
+				Password:  "password123",
+				FirstName: "Jane",
+				LastName:  "Smith",
+			})
+			require.NoError(t, err)
+			ownerID := owner.User.ID
+
+			linked, err := contactService.CreateContact(ctx, ownerID, &entities.CreateContactRequest{
+				Name:  "Janey",
+				Email: stringPtr("jane-name@example.com"),
+			})
+			require.NoError(t, err)
+			offline, err := contactService.CreateContact(ctx, ownerID, &entities.CreateContactRequest{
+				Name: "Cash Only Carl",
+			})
+			require.NoError(t, err)
+
+			debtList, err := debtService.CreateDebtList(ctx, ownerID, &entities.CreateDebtListRequest{
+				ContactID:   linked.ID,
+				DebtType:    entities.DebtTypeToReceive,
+				TotalAmount: "100.00",
+				Currency:    "USD",
+				DueDate:     timePtr(time.Now().AddDate(0, 0, 3)),
+			})
+			require.NoError(t, err)
+
+			// Contact responses
+			contact, err := contactService.GetContact(ctx, linked.ID, ownerID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedName, contact.Name)
+			require.NotNil(t, contact.VerifiedName)
+			assert.Equal(t, "Jane Smith", *contact.VerifiedName)
+
+			contacts, err := contactService.GetUserContacts(ctx, ownerID)
+			require.NoError(t, err)
+			names := make(map[string]string)
+			for _, c := range contacts {
+				names[c.ID.String()] = c.Name
+			}
+			assert.Equal(t, tt.expectedName, names[linked.ID.String()])
+			// Contacts that are not app users always show the saved name
+			assert.Equal(t, "Cash Only Carl", names[offline.ID.String()])
+
+			// Updating the contact responds the same way as reading it
+			updated, err := contactService.UpdateContact(ctx, linked.ID, ownerID, &entities.UpdateContactRequest{
+				Notes: stringPtr("Met at work"),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedName, updated.Name)
+			assert.True(t, updated.IsActiveUser)
+			require.NotNil(t, updated.VerifiedName)
+			assert.Equal(t, "Jane Smith", *updated.VerifiedName)
+
+			// Debt responses
+			debtResponse, err := debtService.GetDebtList(ctx, debtList.ID, ownerID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedName, debtResponse.Contact.Name)
+
+			summary, err := debtService.GetContactSummary(ctx, linked.ID, ownerID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedName, summary.Contact.Name)
+
+			byContact, err := debtService.GetDebtsByContact(ctx, ownerID)
+			require.NoError(t, err)
+			require.Len(t, byContact, 1)
+			assert.Equal(t, tt.expectedName, byContact[0].Contact.Name)
+
+			upcoming, err := debtService.GetUpcomingPayments(ctx, ownerID, 7)
+			require.NoError(t, err)
+			require.Len(t, upcoming, 1)
+			assert.Equal(t, tt.expectedName, upcoming[0].ContactName)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+)
+
+func TestGetContactReconciliation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	aliceID := f.register("alice-reconcile@example.com", "Alice")
+	bobID := f.register("bob-reconcile@example.com", "Bob")
+
+	newDebt := func(ownerID, contactID uuid.UUID, debtType, amount, currency string) *entities.DebtList {
+		debtList, err := f.debtService.CreateDebtList(ctx, ownerID, &entities.CreateDebtListRequest{
+			ContactID:   contactID,
+			DebtType:    debtType,
+			TotalAmount: amount,
+			Currency:    currency,
+			DueDate:     timePtr(time.Now().AddDate(0, 6, 0)),
+		})
+		require.NoError(t, err)
+		return debtList
+	}
+	pay := func(ownerID, debtListID uuid.UUID, amount, currency string) {
+		_, err := f.debtService.CreateDebtItem(ctx, ownerID, &entities.CreateDebtItemRequest{
+			DebtListID:    debtListID,
+			Amount:        amount,
+			Currency:      currency,
+			PaymentDate:   time.Now(),
+			PaymentMethod: "cash",
+		})
+		require.NoError(t, err)
+	}
+	aliceBob := f.contactFor(aliceID, "bob-reconcile@example.com")
+	bobAlice := f.contactFor(bobID, "alice-reconcile@example.com")
+
+	// Alice recorded lending Bob 300 USD where Bob recorded 250
+	newDebt(aliceID, aliceBob, "to_receive", "300.00", "USD")
+	newDebt(bobID, bobAlice, "to_pay", "250.00", "USD")
+	// Both recorded Alice borrowing 50 EUR, but only Bob recorded her 20 repayment
+	newDebt(aliceID, aliceBob, "to_pay", "50.00", "EUR")
+	bobsLoan := newDebt(bobID, bobAlice, "to_receive", "50.00", "EUR")
+	pay(bobID, bobsLoan.ID, "20.00", "EUR")
+	// Only Bob recorded lending Alice 20 GBP
+	newDebt(bobID, bobAlice, "to_receive", "20.00", "GBP")
+
+	debtHandler := handlers.NewDebtHandler(f.debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", aliceID)
+	})
+	router.GET("/api/v1/contacts/:id/reconciliation", debtHandler.GetContactReconciliation)
+
+	get := func(contactID uuid.UUID) (int, entities.ContactReconciliation) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/contacts/"+contactID.String()+"/reconciliation", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var body struct {
+			Data entities.ContactReconciliation `json:"data"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return w.Code, body.Data
+	}
+	assertAmount := func(t *testing.T, expected string, actual decimal.Decimal) {
+		assert.True(t, decimal.RequireFromString(expected).Equal(actual), "expected %s, got %s", expected, actual)
+	}
+
+	code, reconciliation := get(aliceBob)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, aliceBob, reconciliation.ContactID)
+	assert.False(t, reconciliation.Reconciled)
+
+	require.Len(t, reconciliation.Currencies, 3)
+	eur, gbp, usd := reconciliation.Currencies[0], reconciliation.Currencies[1], reconciliation.Currencies[2]
+	assert.Equal(t, "EUR", eur.Currency)
+	assertAmount(t, "50.00", eur.Mine.IOwe)
+	assertAmount(t, "50.00", eur.Theirs.IOwe)
+	assertAmount(t, "0", eur.Mine.PaidByMe)
+	assertAmount(t, "20.00", eur.Theirs.PaidByMe)
+	assert.Equal(t, "GBP", gbp.Currency)
+	assert.Equal(t, 0, gbp.Mine.DebtCount)
+	assert.Equal(t, 1, gbp.Theirs.DebtCount)
+	// Bob's records are expressed from Alice's side
+	assert.Equal(t, "USD", usd.Currency)
+	assertAmount(t, "300.00", usd.Mine.OwedToMe)
+	assertAmount(t, "250.00", usd.Theirs.OwedToMe)
+
+	require.Len(t, reconciliation.Discrepancies, 3)
+	payment, missing, amount := reconciliation.Discrepancies[0], reconciliation.Discrepancies[1], reconciliation.Discrepancies[2]
+	assert.Equal(t, "EUR", payment.Currency)
+	assert.Equal(t, entities.ReconciliationPaidByMe, payment.Field)
+	assertAmount(t, "-20.00", payment.Difference)
+	assert.Equal(t, "GBP", missing.Currency)
+	assert.Equal(t, entities.ReconciliationIOwe, missing.Field)
+	assertAmount(t, "0", missing.Mine)
+	assertAmount(t, "20.00", missing.Theirs)
+	assertAmount(t, "-20.00", missing.Difference)
+	assert.Equal(t, "USD", amount.Currency)
+	assert.Equal(t, entities.ReconciliationOwedToMe, amount.Field)
+	assertAmount(t, "300.00", amount.Mine)
+	assertAmount(t, "250.00", amount.Theirs)
+	assertAmount(t, "50.00", amount.Difference)
+
+	t.Run("the contact sees the mirrored discrepancies", func(t *testing.T) {
+		bobs, err := f.debtService.GetContactReconciliation(ctx, bobAlice, bobID)
+		require.NoError(t, err)
+		require.Len(t, bobs.Discrepancies, 3)
+		assert.Equal(t, entities.ReconciliationPaidToMe, bobs.Discrepancies[0].Field)
+		assertAmount(t, "20.00", bobs.Discrepancies[0].Difference)
+		assert.Equal(t, entities.ReconciliationOwedToMe, bobs.Discrepancies[1].Field)
+		assertAmount(t, "20.00", bobs.Discrepancies[1].Difference)
+		assert.Equal(t, entities.ReconciliationIOwe, bobs.Discrepancies[2].Field)
+		assertAmount(t, "-50.00", bobs.Discrepancies[2].Difference)
+	})
+
+	t.Run("matching records are reconciled", func(t *testing.T) {
+		carolID := f.register("carol-reconcile@example.com", "Carol")
+		aliceCarol := f.contactFor(aliceID, "carol-reconcile@example.com")
+		newDebt(aliceID, aliceCarol, "to_receive", "75.00", "USD")
+		newDebt(carolID, f.contactFor(carolID, "alice-reconcile@example.com"), "to_pay", "75.00", "USD")
+
+		code, reconciliation := get(aliceCarol)
+		require.Equal(t, http.StatusOK, code)
+		assert.True(t, reconciliation.Reconciled)
+		assert.Empty(t, reconciliation.Discrepancies)
+	})
+
+	t.Run("contact who is not a registered user", func(t *testing.T) {
+		dave, err := f.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{Name: "Dave"})
+		require.NoError(t, err)
+
+		code, _ := get(dave.ID)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+	})
+
+	t.Run("unknown contact", func(t *testing.T) {
+		code, _ := get(uuid.New())
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
@@ -0,0 +1,146 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+)
+
+func TestGetContactSummary(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	aliceID := f.register("alice-summary@example.com", "Alice")
+	bobID := f.register("bob-summary@example.com", "Bob")
+
+	// contactFor returns the owner's contact for email, which registration may
+	// already have created as the reciprocal of the other user's contact
+	contactFor := func(ownerID uuid.UUID, email string) uuid.UUID {
+		userContacts, err := f.contactRepo.GetUserContactsByEmail(ctx, email)
+		require.NoError(t, err)
+		for _, uc := range userContacts {
+			if uc.UserID == ownerID {
+				return uc.ContactID
+			}
+		}
+		contact, err := f.contactService.CreateContact(ctx, ownerID, &entities.CreateContactRequest{
+			Name:  "Bob",
+			Email: stringPtr(email),
+		})
+		require.NoError(t, err)
+		return contact.ID
+	}
+	bobContactID := contactFor(aliceID, "bob-summary@example.com")
+	carol, err := f.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{Name: "Carol"})
+	require.NoError(t, err)
+
+	newDebt := func(ownerID, contactID uuid.UUID, debtType, amount, currency string, dueInDays int) *entities.DebtList {
+		debtList, err := f.debtService.CreateDebtList(ctx, ownerID, &entities.CreateDebtListRequest{
+			ContactID:       contactID,
+			DebtType:        debtType,
+			TotalAmount:     amount,
+			Currency:        currency,
+			InstallmentPlan: "onetime",
+			DueDate:         timePtr(time.Now().AddDate(0, 0, dueInDays)),
+		})
+		require.NoError(t, err)
+		return debtList
+	}
+
+	// Alice lent Bob 100 USD and has been repaid 30
+	lent := newDebt(aliceID, bobContactID, "to_receive", "100.00", "USD", 20)
+	_, err = f.debtService.CreateDebtItem(ctx, aliceID, &entities.CreateDebtItemRequest{
+		DebtListID:    lent.ID,
+		Amount:        "30.00",
+		Currency:      "USD",
+		PaymentDate:   time.Now(),
+		PaymentMethod: "cash",
+	})
+	require.NoError(t, err)
+	// Alice owes Bob 20 EUR
+	newDebt(aliceID, bobContactID, "to_pay", "20.00", "EUR", 30)
+	// Bob recorded lending Alice 50 USD, due soonest
+	bobLent := newDebt(bobID, contactFor(bobID, "alice-summary@example.com"), "to_receive", "50.00", "USD", 5)
+	// Debts with other contacts are not part of Bob's summary
+	newDebt(aliceID, carol.ID, "to_receive", "999.00", "USD", 2)
+
+	debtHandler := handlers.NewDebtHandler(f.debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		userID, err := uuid.Parse(c.GetHeader("X-Test-User"))
+		require.NoError(t, err)
+		c.Set("user_id", userID)
+	})
+	router.GET("/api/v1/contacts/:id/summary", debtHandler.GetContactSummary)
+
+	get := func(userID uuid.UUID, contactID string) (int, entities.ContactSummary) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/contacts/"+contactID+"/summary", nil)
+		req.Header.Set("X-Test-User", userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var body struct {
+			Data entities.ContactSummary `json:"data"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return w.Code, body.Data
+	}
+
+	code, summary := get(aliceID, bobContactID.String())
+	require.Equal(t, http.StatusOK, code)
+
+	assert.Equal(t, bobContactID, summary.Contact.ID)
+	require.NotNil(t, summary.Contact.Email)
+	assert.Equal(t, "bob-summary@example.com", *summary.Contact.Email)
+	assert.True(t, summary.IsAppUser)
+	assert.Equal(t, 1, summary.OwedToMeCount)
+	assert.Equal(t, 2, summary.IOweCount)
+
+	require.Len(t, summary.NetBalances, 2)
+	eur, usd := summary.NetBalances[0], summary.NetBalances[1]
+	assert.Equal(t, "EUR", eur.Currency)
+	assert.True(t, decimal.Zero.Equal(eur.OwedToMe), eur.OwedToMe.String())
+	assert.True(t, decimal.RequireFromString("20.00").Equal(eur.IOwe), eur.IOwe.String())
+	assert.True(t, decimal.RequireFromString("-20.00").Equal(eur.Net), eur.Net.String())
+	assert.Equal(t, "USD", usd.Currency)
+	assert.True(t, decimal.RequireFromString("70.00").Equal(usd.OwedToMe), usd.OwedToMe.String())
+	assert.True(t, decimal.RequireFromString("50.00").Equal(usd.IOwe), usd.IOwe.String())
+	assert.True(t, decimal.RequireFromString("20.00").Equal(usd.Net), usd.Net.String())
+
+	// The soonest payment is on the debt Bob recorded, owed by Alice
+	require.NotNil(t, summary.NextPayment)
+	assert.Equal(t, bobLent.ID, summary.NextPayment.DebtListID)
+	assert.Equal(t, "to_pay", summary.NextPayment.DebtType)
+	assert.True(t, decimal.RequireFromString("50.00").Equal(summary.NextPayment.Amount), summary.NextPayment.Amount.String())
+
+	// A contact who is not an app user only has the debts Alice recorded
+	code, summary = get(aliceID, carol.ID.String())
+	require.Equal(t, http.StatusOK, code)
+	assert.False(t, summary.IsAppUser)
+	assert.Equal(t, 1, summary.OwedToMeCount)
+	assert.Equal(t, 0, summary.IOweCount)
+	require.Len(t, summary.NetBalances, 1)
+	assert.True(t, decimal.RequireFromString("999.00").Equal(summary.NetBalances[0].Net))
+
+	// Other users' contacts and malformed IDs are rejected
+	code, _ = get(bobID, carol.ID.String())
+	assert.Equal(t, http.StatusNotFound, code)
+	code, _ = get(aliceID, "not-a-uuid")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,102 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+)
+
+func TestContactResponses_ShowLinkedUserStatus(t *testing.T) {
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	owner, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "owner-link@example.com",
+		Password:  "password123",
+		FirstName: "Olive",
+		LastName:  "Owner",
+	})
+	require.NoError(t, err)
+	_, err = f.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "jane-link@example.com",
+		Password:  "password123",
+		FirstName: "Jane",
+		LastName:  "Smith",
+	})
+	require.NoError(t, err)
+	ownerID := owner.User.ID
+
+	linked, err := f.contactService.CreateContact(ctx, ownerID, &entities.CreateContactRequest{
+		Name:  "Janey",
+		Email: stringPtr("jane-link@example.com"),
+	})
+	require.NoError(t, err)
+	offline, err := f.contactService.CreateContact(ctx, ownerID, &entities.CreateContactRequest{
+		Name: "Cash Only Carl",
+	})
+	require.NoError(t, err)
+
+	// The repository only preloads the user for contacts linked to one
+	withUser, err := f.contactRepo.GetContactWithUser(ctx, linked.ID)
+	require.NoError(t, err)
+	require.NotNil(t, withUser.User)
+	assert.Equal(t, "jane-link@example.com", withUser.User.Email)
+	assert.True(t, withUser.IsActiveUser())
+
+	withoutUser, err := f.contactRepo.GetContactWithUser(ctx, offline.ID)
+	require.NoError(t, err)
+	assert.Nil(t, withoutUser.User)
+	assert.False(t, withoutUser.IsActiveUser())
+
+	// Contact responses keep the owner's chosen name alongside the verified one
+	contact, err := f.contactService.GetContact(ctx, linked.ID, ownerID)
+	require.NoError(t, err)
+	assert.Equal(t, "Janey", contact.Name)
+	assert.True(t, contact.IsActiveUser)
+	require.NotNil(t, contact.VerifiedName)
+	assert.Equal(t, "Jane Smith", *contact.VerifiedName)
+
+	contact, err = f.contactService.GetContact(ctx, offline.ID, ownerID)
+	require.NoError(t, err)
+	assert.False(t, contact.IsActiveUser)
+	assert.Nil(t, contact.VerifiedName)
+
+	contacts, err := f.contactService.GetUserContacts(ctx, ownerID)
+	require.NoError(t, err)
+	require.Len(t, contacts, 2)
+	for _, c := range contacts {
+		assert.Equal(t, c.ID == linked.ID, c.IsActiveUser, c.Name)
+	}
+
+	// Debt responses carry the same information about their contact
+	for _, c := range []*entities.ContactResponse{linked, offline} {
+		_, err := f.debtService.CreateDebtList(ctx, ownerID, &entities.CreateDebtListRequest{
+			ContactID:   c.ID,
+			DebtType:    "to_receive",
+			TotalAmount: "100.00",
+			Currency:    "USD",
+			DueDate:     timePtr(time.Now().AddDate(0, 1, 0)),
+		})
+		require.NoError(t, err)
+	}
+
+	debts, err := f.debtService.GetUserDebtLists(ctx, ownerID)
+	require.NoError(t, err)
+	require.Len(t, debts, 2)
+	for _, debt := range debts {
+		if debt.ContactID == linked.ID {
+			assert.True(t, debt.Contact.IsActiveUser)
+			require.NotNil(t, debt.Contact.VerifiedName)
+			assert.Equal(t, "Jane Smith", *debt.Contact.VerifiedName)
+		} else {
+			assert.False(t, debt.Contact.IsActiveUser)
+			assert.Nil(t, debt.Contact.VerifiedName)
+		}
+	}
+}
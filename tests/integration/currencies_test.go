@@ -0,0 +1,109 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+	"pay-your-dues/internal/repository"
+	"pay-your-dues/internal/services"
+)
+
+func TestGetCurrencies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t, &models.UserSettings{}, &models.DebtBalance{})
+
+	userSettingsRepo := repository.NewUserSettingsRepositoryGORM(f.db)
+	userSettingsService := services.NewUserSettingsService(userSettingsRepo)
+	debtService := f.newDebtService(services.WithUserSettingsRepository(userSettingsRepo))
+
+	aliceID := f.register("alice-currency@example.com", "Alice")
+	bobID := f.register("bob-currency@example.com", "Bob")
+	carolID := f.register("carol-currency@example.com", "Carol")
+
+	_, err := userSettingsService.UpdateUserSettings(ctx, aliceID, &entities.UpdateUserSettingsRequest{DefaultCurrency: stringPtr("eur")})
+	require.NoError(t, err)
+
+	newDebt := func(ownerID, contactID uuid.UUID, currency string, balances ...entities.DebtBalanceRequest) {
+		_, err := debtService.CreateDebtList(ctx, ownerID, &entities.CreateDebtListRequest{
+			ContactID:          contactID,
+			DebtType:           "to_receive",
+			TotalAmount:        "100.00",
+			Currency:           currency,
+			DueDate:            timePtr(time.Now().AddDate(0, 1, 0)),
+			AdditionalBalances: balances,
+		})
+		require.NoError(t, err)
+	}
+	friend, err := f.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{Name: "Friend"})
+	require.NoError(t, err)
+
+	// Alice's own debts, with a legacy mixed-case code and an additional balance
+	newDebt(aliceID, friend.ID, "USD")
+	newDebt(aliceID, friend.ID, "USD")
+	newDebt(aliceID, friend.ID, "Php")
+	newDebt(aliceID, friend.ID, "PHP")
+	newDebt(aliceID, friend.ID, "JPY", entities.DebtBalanceRequest{Currency: "GBP", TotalAmount: "10.00"})
+
+	// Bob's debt with Alice as the contact is hers too; Carol's debts are not
+	alice, err := f.contactService.CreateContact(ctx, bobID, &entities.CreateContactRequest{
+		Name:  "Alice",
+		Email: stringPtr("alice-currency@example.com"),
+	})
+	require.NoError(t, err)
+	newDebt(bobID, alice.ID, "CAD")
+	stranger, err := f.contactService.CreateContact(ctx, carolID, &entities.CreateContactRequest{Name: "Stranger"})
+	require.NoError(t, err)
+	newDebt(carolID, stranger.ID, "AUD")
+
+	debtHandler := handlers.NewDebtHandler(debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		userID, err := uuid.Parse(c.GetHeader("X-Test-User"))
+		require.NoError(t, err)
+		c.Set("user_id", userID)
+	})
+	router.GET("/api/v1/currencies", debtHandler.GetCurrencies)
+
+	get := func(userID uuid.UUID) entities.UserCurrencies {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/currencies", nil)
+		req.Header.Set("X-Test-User", userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var body struct {
+			Data entities.UserCurrencies `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return body.Data
+	}
+
+	currencies := get(aliceID)
+	assert.Equal(t, "EUR", currencies.DefaultCurrency)
+	assert.Equal(t, []string{"CAD", "EUR", "GBP", "JPY", "PHP", "USD"}, currencies.Currencies)
+
+	// Bob only has his own debt and the system default currency
+	currencies = get(bobID)
+	assert.Equal(t, "PHP", currencies.DefaultCurrency)
+	assert.Equal(t, []string{"CAD", "PHP"}, currencies.Currencies)
+
+	// A user without debts still gets their default currency
+	currencies = get(f.register("dave-currency@example.com", "Dave"))
+	assert.Equal(t, []string{"PHP"}, currencies.Currencies)
+}
@@ -0,0 +1,98 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"pay-your-dues/internal/database"
+	"pay-your-dues/internal/models"
+)
+
+func TestNormalizeLegacyCurrency(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.DebtList{}, &models.DebtItem{}, &models.UserSettings{}))
+
+	newDebtList := func(currency string) models.DebtList {
+		return models.DebtList{
+			ID:                 uuid.New(),
+			UserID:             uuid.New(),
+			ContactID:          uuid.New(),
+			DebtType:           "to_receive",
+			TotalAmount:        decimal.RequireFromString("100.00"),
+			InstallmentAmount:  decimal.RequireFromString("100.00"),
+			TotalRemainingDebt: decimal.RequireFromString("100.00"),
+			Currency:           currency,
+			DueDate:            time.Now().AddDate(0, 1, 0),
+			NextPaymentDate:    time.Now().AddDate(0, 1, 0),
+			InstallmentPlan:    "onetime",
+		}
+	}
+
+	legacyList := newDebtList("Php")
+	usdList := newDebtList("USD")
+	require.NoError(t, db.Create(&legacyList).Error)
+	require.NoError(t, db.Create(&usdList).Error)
+
+	legacyItem := models.DebtItem{
+		ID:          uuid.New(),
+		DebtListID:  legacyList.ID,
+		Amount:      decimal.RequireFromString("25.00"),
+		Currency:    "Php",
+		PaymentDate: time.Now(),
+	}
+	require.NoError(t, db.Create(&legacyItem).Error)
+	deletedItem := models.DebtItem{
+		ID:          uuid.New(),
+		DebtListID:  legacyList.ID,
+		Amount:      decimal.RequireFromString("5.00"),
+		Currency:    "Php",
+		PaymentDate: time.Now(),
+	}
+	require.NoError(t, db.Create(&deletedItem).Error)
+	require.NoError(t, db.Delete(&deletedItem).Error)
+
+	legacySettings := models.UserSettings{
+		ID:              uuid.New(),
+		UserID:          uuid.New(),
+		DefaultCurrency: "Php",
+	}
+	require.NoError(t, db.Create(&legacySettings).Error)
+
+	updated, err := database.NormalizeLegacyCurrency(db)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), updated)
+
+	var debtList models.DebtList
+	require.NoError(t, db.First(&debtList, "id = ?", legacyList.ID).Error)
+	assert.Equal(t, "PHP", debtList.Currency)
+
+	var untouchedList models.DebtList
+	require.NoError(t, db.First(&untouchedList, "id = ?", usdList.ID).Error)
+	assert.Equal(t, "USD", untouchedList.Currency)
+
+	var debtItem models.DebtItem
+	require.NoError(t, db.First(&debtItem, "id = ?", legacyItem.ID).Error)
+	assert.Equal(t, "PHP", debtItem.Currency)
+
+	// Soft-deleted payments are normalized too, so restoring one keeps the ISO code
+	var restorable models.DebtItem
+	require.NoError(t, db.Unscoped().First(&restorable, "id = ?", deletedItem.ID).Error)
+	assert.Equal(t, "PHP", restorable.Currency)
+
+	var settings models.UserSettings
+	require.NoError(t, db.First(&settings, "id = ?", legacySettings.ID).Error)
+	assert.Equal(t, "PHP", settings.DefaultCurrency)
+
+	// Running again changes nothing
+	updated, err = database.NormalizeLegacyCurrency(db)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), updated)
+}
@@ -0,0 +1,152 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+)
+
+func TestGetAttention(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	register := func(email string) uuid.UUID {
+		resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+			Email:     email,
+			Password:  "password123",
+			FirstName: "Attention",
+			LastName:  "User",
+		})
+		require.NoError(t, err)
+		return resp.User.ID
+	}
+	aliceID := register("alice-attention@example.com")
+	bobID := register("bob-attention@example.com")
+
+	offline, err := f.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{Name: "Cash Only Carl"})
+	require.NoError(t, err)
+	_, err = f.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{
+		Name:  "Bob",
+		Email: stringPtr("bob-attention@example.com"),
+	})
+	require.NoError(t, err)
+
+	// Adding Bob as a contact gave him a reciprocal contact for Alice
+	var aliceForBob uuid.UUID
+	userContacts, err := f.contactRepo.GetUserContactsByEmail(ctx, "alice-attention@example.com")
+	require.NoError(t, err)
+	for _, uc := range userContacts {
+		if uc.UserID == bobID {
+			aliceForBob = uc.ContactID
+		}
+	}
+	require.NotEqual(t, uuid.Nil, aliceForBob)
+
+	newDebtList := func(ownerID, contactID uuid.UUID, debtType, currency, remaining, status string, nextPaymentDate time.Time) {
+		debtList := models.DebtList{
+			ID:                 uuid.New(),
+			UserID:             ownerID,
+			ContactID:          contactID,
+			DebtType:           debtType,
+			TotalAmount:        decimal.RequireFromString("1000.00"),
+			InstallmentAmount:  decimal.RequireFromString("100.00"),
+			TotalRemainingDebt: decimal.RequireFromString(remaining),
+			Currency:           currency,
+			Status:             status,
+			DueDate:            time.Now().AddDate(0, 6, 0),
+			NextPaymentDate:    nextPaymentDate,
+			InstallmentPlan:    "monthly",
+		}
+		require.NoError(t, f.db.Create(&debtList).Error)
+	}
+	lastWeek := time.Now().AddDate(0, 0, -7)
+	inThreeDays := time.Now().AddDate(0, 0, 3)
+	inTwoWeeks := time.Now().AddDate(0, 0, 14)
+
+	// Overdue: Alice is owed USD and owes Carl USD
+	newDebtList(aliceID, offline.ID, "to_receive", "USD", "150.00", "overdue", lastWeek)
+	newDebtList(aliceID, offline.ID, "to_pay", "USD", "30.00", "overdue", lastWeek)
+	// Due soon: Alice is owed EUR, and Bob recorded lending her PHP
+	newDebtList(aliceID, offline.ID, "to_receive", "EUR", "80.00", "active", inThreeDays)
+	newDebtList(bobID, aliceForBob, "to_receive", "PHP", "1200.00", "active", inThreeDays)
+	// Due after the default window
+	newDebtList(aliceID, offline.ID, "to_receive", "USD", "999.00", "active", inTwoWeeks)
+	// Settled debts never need attention
+	newDebtList(aliceID, offline.ID, "to_receive", "USD", "0", "settled", lastWeek)
+
+	debtHandler := handlers.NewDebtHandler(f.debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		userID, err := uuid.Parse(c.GetHeader("X-Test-User"))
+		require.NoError(t, err)
+		c.Set("user_id", userID)
+	})
+	router.GET("/api/v1/debts/attention", debtHandler.GetAttention)
+
+	getAttention := func(userID uuid.UUID, query string) entities.DebtAttention {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/debts/attention?"+query, nil)
+		req.Header.Set("X-Test-User", userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var body struct {
+			Data entities.DebtAttention `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return body.Data
+	}
+	assertTotal := func(total entities.OverdueTotal, currency, owedToMe, iOwe string, count int) {
+		t.Helper()
+		assert.Equal(t, currency, total.Currency)
+		assert.True(t, decimal.RequireFromString(owedToMe).Equal(total.OwedToMe), "%s owed to me: %s", currency, total.OwedToMe)
+		assert.True(t, decimal.RequireFromString(iOwe).Equal(total.IOwe), "%s i owe: %s", currency, total.IOwe)
+		assert.Equal(t, count, total.Count, currency)
+	}
+
+	attention := getAttention(aliceID, "")
+	assert.Equal(t, 7, attention.Days)
+	require.Len(t, attention.Overdue, 1)
+	assertTotal(attention.Overdue[0], "USD", "150.00", "30.00", 2)
+	require.Len(t, attention.DueSoon, 2)
+	assertTotal(attention.DueSoon[0], "EUR", "80.00", "0", 1)
+	assertTotal(attention.DueSoon[1], "PHP", "0", "1200.00", 1)
+
+	t.Run("days widens the due-soon window", func(t *testing.T) {
+		attention := getAttention(aliceID, "days=30")
+		assert.Equal(t, 30, attention.Days)
+		require.Len(t, attention.DueSoon, 3)
+		assertTotal(attention.DueSoon[2], "USD", "999.00", "0", 1)
+		assert.Len(t, attention.Overdue, 1)
+	})
+
+	t.Run("the contact sees their side", func(t *testing.T) {
+		attention := getAttention(bobID, "")
+		assert.Empty(t, attention.Overdue)
+		require.Len(t, attention.DueSoon, 1)
+		assertTotal(attention.DueSoon[0], "PHP", "1200.00", "0", 1)
+	})
+
+	t.Run("nothing needing attention", func(t *testing.T) {
+		attention := getAttention(register("carol-attention@example.com"), "")
+		assert.Empty(t, attention.Overdue)
+		assert.Empty(t, attention.DueSoon)
+	})
+}
@@ -0,0 +1,121 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/models"
+)
+
+func TestDebtWithTwoCurrencyBalances(t *testing.T) {
+	ctx := context.Background()
+
+	f := newTestFixture(t, &models.DebtBalance{})
+
+	resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "lender-balances@example.com",
+		Password:  "password123",
+		FirstName: "Balance",
+		LastName:  "Lender",
+	})
+	require.NoError(t, err)
+	lenderID := resp.User.ID
+
+	contact, err := f.contactService.CreateContact(ctx, lenderID, &entities.CreateContactRequest{Name: "Traveller"})
+	require.NoError(t, err)
+
+	newRequest := func(balances ...entities.DebtBalanceRequest) *entities.CreateDebtListRequest {
+		return &entities.CreateDebtListRequest{
+			ContactID:          contact.ID,
+			DebtType:           "to_receive",
+			TotalAmount:        "200.00",
+			Currency:           "USD",
+			DueDate:            timePtr(time.Now().AddDate(0, 2, 0)),
+			AdditionalBalances: balances,
+		}
+	}
+
+	// A balance must use a currency of its own and a positive amount
+	_, err = f.debtService.CreateDebtList(ctx, lenderID, newRequest(entities.DebtBalanceRequest{Currency: "usd", TotalAmount: "10.00"}))
+	assert.True(t, errors.Is(err, entities.ErrInvalidCurrency), err)
+	_, err = f.debtService.CreateDebtList(ctx, lenderID, newRequest(
+		entities.DebtBalanceRequest{Currency: "EUR", TotalAmount: "10.00"},
+		entities.DebtBalanceRequest{Currency: "eur", TotalAmount: "20.00"},
+	))
+	assert.True(t, errors.Is(err, entities.ErrInvalidCurrency), err)
+	_, err = f.debtService.CreateDebtList(ctx, lenderID, newRequest(entities.DebtBalanceRequest{Currency: "EUR", TotalAmount: "0"}))
+	assert.True(t, errors.Is(err, entities.ErrInvalidAmount), err)
+
+	debtList, err := f.debtService.CreateDebtList(ctx, lenderID, newRequest(entities.DebtBalanceRequest{Currency: "EUR", TotalAmount: "150.00"}))
+	require.NoError(t, err)
+
+	balances, err := f.debtListRepo.GetBalances(ctx, debtList.ID)
+	require.NoError(t, err)
+	require.Len(t, balances, 1)
+	assert.Equal(t, "EUR", balances[0].Currency)
+	assert.True(t, decimal.RequireFromString("150.00").Equal(balances[0].TotalAmount))
+
+	pay := func(amount, currency string) (*entities.DebtItem, error) {
+		return f.debtService.CreateDebtItem(ctx, lenderID, &entities.CreateDebtItemRequest{
+			DebtListID:    debtList.ID,
+			Amount:        amount,
+			Currency:      currency,
+			PaymentDate:   time.Now(),
+			PaymentMethod: "cash",
+		})
+	}
+
+	_, err = pay("50.00", "USD")
+	require.NoError(t, err)
+	_, err = pay("30.00", "USD")
+	require.NoError(t, err)
+	// The currency is matched case-insensitively and stored as the balance's
+	eurPayment, err := pay("60.00", "eur")
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", eurPayment.Currency)
+
+	// A payment in a currency the debt has no balance for is rejected
+	_, err = pay("5.00", "JPY")
+	assert.True(t, errors.Is(err, entities.ErrInvalidCurrency), err)
+
+	// The list's own totals only track its main currency
+	stored, err := f.debtListRepo.GetByID(ctx, debtList.ID)
+	require.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("80.00").Equal(stored.TotalPaymentsMade), stored.TotalPaymentsMade.String())
+	assert.True(t, decimal.RequireFromString("120.00").Equal(stored.TotalRemainingDebt), stored.TotalRemainingDebt.String())
+
+	summary, err := f.debtService.GetTotalPaymentsForDebtList(ctx, debtList.ID, lenderID)
+	require.NoError(t, err)
+	assert.Equal(t, "USD", summary.Currency)
+	assert.True(t, decimal.RequireFromString("80.00").Equal(summary.TotalPaid), summary.TotalPaid.String())
+	assert.Equal(t, 2, summary.NumberOfPayments)
+	assert.Len(t, summary.Payments, 3)
+
+	require.Len(t, summary.Balances, 2)
+	usd, eur := summary.Balances[0], summary.Balances[1]
+	assert.Equal(t, "USD", usd.Currency)
+	assert.True(t, decimal.RequireFromString("200.00").Equal(usd.TotalAmount))
+	assert.True(t, decimal.RequireFromString("80.00").Equal(usd.TotalPaid), usd.TotalPaid.String())
+	assert.True(t, decimal.RequireFromString("120.00").Equal(usd.RemainingDebt), usd.RemainingDebt.String())
+	assert.Equal(t, "40", usd.PercentagePaid.String())
+	assert.Equal(t, 2, usd.NumberOfPayments)
+
+	assert.Equal(t, "EUR", eur.Currency)
+	assert.True(t, decimal.RequireFromString("150.00").Equal(eur.TotalAmount))
+	assert.True(t, decimal.RequireFromString("60.00").Equal(eur.TotalPaid), eur.TotalPaid.String())
+	assert.True(t, decimal.RequireFromString("90.00").Equal(eur.RemainingDebt), eur.RemainingDebt.String())
+	assert.Equal(t, "40", eur.PercentagePaid.String())
+	assert.Equal(t, 1, eur.NumberOfPayments)
+
+	// Other users cannot see the summary
+	_, err = f.debtService.GetTotalPaymentsForDebtList(ctx, debtList.ID, uuid.New())
+	assert.ErrorIs(t, err, entities.ErrDebtListNotFound)
+}
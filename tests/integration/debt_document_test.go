@@ -0,0 +1,157 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/services"
+)
+
+func TestDebtDocumentUploadAndRetrieval(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	fileStorage := &mocks.MockFileStorageService{}
+	debtService := services.NewDebtService(f.debtListRepo, f.debtItemRepo, f.contactRepo, services.NewPaymentScheduleService(), fileStorage)
+
+	register := func(email string) uuid.UUID {
+		resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+			Email:     email,
+			Password:  "password123",
+			FirstName: "Doc",
+			LastName:  "User",
+		})
+		require.NoError(t, err)
+		return resp.User.ID
+	}
+	ownerID := register("owner-docs@example.com")
+	contactUserID := register("contact-docs@example.com")
+	strangerID := register("stranger-docs@example.com")
+
+	contact, err := f.contactService.CreateContact(ctx, ownerID, &entities.CreateContactRequest{
+		Name:  "Contact Docs",
+		Email: stringPtr("contact-docs@example.com"),
+	})
+	require.NoError(t, err)
+
+	debtList, err := debtService.CreateDebtList(ctx, ownerID, &entities.CreateDebtListRequest{
+		ContactID:   contact.ID,
+		DebtType:    "to_receive",
+		TotalAmount: "1000.00",
+		Currency:    "USD",
+		DueDate:     timePtr(time.Now().AddDate(0, 6, 0)),
+	})
+	require.NoError(t, err)
+
+	pdf := []byte("%PDF-1.4\n1 0 obj << /Type /Catalog >> endobj\n%%EOF\n")
+	documentURL := "/api/v1/debts/" + debtList.ID.String() + "/documents/20240101-120000-" + uuid.New().String() + ".pdf"
+	var uploaded []byte
+	fileStorage.On("UploadDocument", mock.Anything, mock.Anything, "agreement.pdf", "application/pdf", debtList.ID).
+		Run(func(args mock.Arguments) {
+			uploaded, _ = io.ReadAll(args.Get(1).(io.Reader))
+		}).
+		Return(documentURL, nil)
+	fileStorage.On("GetDocumentFile", mock.Anything, documentURL).Return(pdf, "application/pdf", nil)
+
+	debtHandler := handlers.NewDebtHandler(debtService, fileStorage, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		userID, err := uuid.Parse(c.GetHeader("X-Test-User"))
+		require.NoError(t, err)
+		c.Set("user_id", userID)
+	})
+	router.POST("/api/v1/debts/:id/documents", debtHandler.UploadDebtDocument)
+	router.GET("/api/v1/debts/:id/documents/:filename", debtHandler.GetDebtDocument)
+
+	upload := func(userID uuid.UUID, filename string, contentType string, content []byte) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		partHeader := make(textproto.MIMEHeader)
+		partHeader.Set("Content-Disposition", `form-data; name="document"; filename="`+filename+`"`)
+		partHeader.Set("Content-Type", contentType)
+		part, err := writer.CreatePart(partHeader)
+		require.NoError(t, err)
+		_, err = part.Write(content)
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/debts/"+debtList.ID.String()+"/documents", &body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req.Header.Set("X-Test-User", userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	download := func(userID uuid.UUID, filename string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/debts/"+debtList.ID.String()+"/documents/"+filename, nil)
+		req.Header.Set("X-Test-User", userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// The owner uploads a signed PDF agreement
+	w := upload(ownerID, "agreement.pdf", "application/pdf", pdf)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var response struct {
+		Data entities.DebtDocument `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, debtList.ID, response.Data.DebtListID)
+	assert.Equal(t, documentURL, response.Data.URL)
+	assert.Equal(t, path.Base(documentURL), response.Data.Filename)
+	assert.Equal(t, pdf, uploaded)
+	filename := response.Data.Filename
+
+	// Both parties can retrieve it
+	for _, userID := range []uuid.UUID{ownerID, contactUserID} {
+		w = download(userID, filename)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+		assert.Equal(t, pdf, w.Body.Bytes())
+	}
+
+	// Users outside the debt cannot see it exists
+	w = download(strangerID, filename)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// Unknown documents are reported as missing
+	fileStorage.On("GetDocumentFile", mock.Anything, mock.Anything).Return(nil, "", errors.New("NoSuchKey"))
+	w = download(ownerID, "missing.pdf")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// Only the owner may upload
+	w = upload(contactUserID, "agreement.pdf", "application/pdf", pdf)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = upload(strangerID, "agreement.pdf", "application/pdf", pdf)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// Documents accept PDF but not arbitrary files
+	w = upload(ownerID, "agreement.exe", "application/octet-stream", []byte("MZ"))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	fileStorage.AssertNumberOfCalls(t, "UploadDocument", 1)
+}
@@ -2,9 +2,13 @@ package integration
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/textproto"
 	"testing"
 	"time"
 
@@ -15,6 +19,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"pay-your-dues/internal/config"
 	"pay-your-dues/internal/domain/entities"
 	"pay-your-dues/internal/handlers"
 	"pay-your-dues/internal/mocks"
@@ -100,7 +105,7 @@ func TestDebtHandler_CreateDebtList(t *testing.T) {
 				"total_amount": "500.00",
 			},
 			setupMock: func(mockDebtService *mocks.MockDebtService) {
-				mockDebtService.On("CreateDebtList", mock.Anything, userID, mock.AnythingOfType("*entities.CreateDebtListRequest")).Return(nil, entities.ErrInvalidDebtType)
+				// Rejected by request validation before reaching the service
 			},
 			setupContext: func(c *gin.Context) {
 				c.Set("user_id", userID)
@@ -108,6 +113,52 @@ func TestDebtHandler_CreateDebtList(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			validateBody: func(t *testing.T, body map[string]interface{}) {
 				assert.Equal(t, "Invalid input", body["error"])
+				assert.Equal(t, []interface{}{
+					map[string]interface{}{"field": "debt_type", "message": "debt_type must be one of: to_receive, to_pay"},
+				}, body["errors"])
+			},
+		},
+		{
+			name: "missing required fields",
+			requestBody: map[string]interface{}{
+				"currency": "USD",
+			},
+			setupMock: func(mockDebtService *mocks.MockDebtService) {
+				// Rejected by request validation before reaching the service
+			},
+			setupContext: func(c *gin.Context) {
+				c.Set("user_id", userID)
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Invalid input", body["error"])
+				assert.Nil(t, body["details"])
+				assert.Equal(t, []interface{}{
+					map[string]interface{}{"field": "contact_id", "message": "contact_id is required"},
+					map[string]interface{}{"field": "debt_type", "message": "debt_type is required"},
+					map[string]interface{}{"field": "total_amount", "message": "total_amount is required"},
+				}, body["errors"])
+			},
+		},
+		{
+			name: "wrong field type",
+			requestBody: map[string]interface{}{
+				"contact_id":   contactID.String(),
+				"debt_type":    "to_pay",
+				"total_amount": 500,
+			},
+			setupMock: func(mockDebtService *mocks.MockDebtService) {
+				// No mock setup needed
+			},
+			setupContext: func(c *gin.Context) {
+				c.Set("user_id", userID)
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Invalid request body", body["error"])
+				assert.Equal(t, []interface{}{
+					map[string]interface{}{"field": "total_amount", "message": "total_amount must be of type string"},
+				}, body["errors"])
 			},
 		},
 		{
@@ -287,6 +338,101 @@ func TestDebtHandler_GetUserDebtLists(t *testing.T) {
 	}
 }
 
+func TestDebtHandler_GetUserDebtLists_Pagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := uuid.New()
+	debtLists := make([]entities.DebtListResponse, 5)
+	for i := range debtLists {
+		debtLists[i] = entities.DebtListResponse{
+			ID:          uuid.New(),
+			UserID:      userID,
+			DebtType:    "to_pay",
+			TotalAmount: decimal.RequireFromString("100.00"),
+			Currency:    "USD",
+			Status:      "active",
+		}
+	}
+
+	tests := []struct {
+		name               string
+		query              string
+		expectedLen        int
+		expectedMeta       map[string]interface{}
+		expectedNextCursor interface{}
+	}{
+		{
+			name:               "default page returns everything",
+			query:              "",
+			expectedLen:        5,
+			expectedMeta:       map[string]interface{}{"total": float64(5), "limit": float64(50), "offset": float64(0)},
+			expectedNextCursor: nil,
+		},
+		{
+			name:               "first page with next cursor",
+			query:              "?limit=2",
+			expectedLen:        2,
+			expectedMeta:       map[string]interface{}{"total": float64(5), "limit": float64(2), "offset": float64(0)},
+			expectedNextCursor: "2",
+		},
+		{
+			name:               "cursor continues from previous page",
+			query:              "?limit=2&cursor=4",
+			expectedLen:        1,
+			expectedMeta:       map[string]interface{}{"total": float64(5), "limit": float64(2), "offset": float64(4)},
+			expectedNextCursor: nil,
+		},
+		{
+			name:               "offset past the end returns empty page",
+			query:              "?offset=10",
+			expectedLen:        0,
+			expectedMeta:       map[string]interface{}{"total": float64(5), "limit": float64(50), "offset": float64(10)},
+			expectedNextCursor: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockDebtService := &mocks.MockDebtService{}
+			mockDebtService.On("GetUserDebtLists", mock.Anything, userID).Return(debtLists, nil)
+
+			mockFileStorageService := &mocks.MockFileStorageService{}
+			debtHandler := handlers.NewDebtHandler(mockDebtService, mockFileStorageService, zerolog.New(nil))
+
+			router := gin.New()
+			router.GET("/api/debt-lists", func(c *gin.Context) {
+				c.Set("user_id", userID)
+				debtHandler.GetUserDebtLists(c)
+			})
+
+			// Execute
+			req := httptest.NewRequest(http.MethodGet, "/api/debt-lists"+tt.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var responseBody map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &responseBody)
+			assert.NoError(t, err)
+
+			assert.Len(t, responseBody["data"].([]interface{}), tt.expectedLen)
+
+			meta, ok := responseBody["meta"].(map[string]interface{})
+			if assert.True(t, ok, "response should contain a meta object") {
+				for key, value := range tt.expectedMeta {
+					assert.Equal(t, value, meta[key], key)
+				}
+				assert.Equal(t, tt.expectedNextCursor, meta["next_cursor"])
+			}
+
+			mockDebtService.AssertExpectations(t)
+		})
+	}
+}
+
 func TestDebtHandler_CreateDebtItem(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -376,6 +522,44 @@ func TestDebtHandler_CreateDebtItem(t *testing.T) {
 				assert.Equal(t, "Debt list not found", body["error"])
 			},
 		},
+		{
+			name: "request cancelled by client",
+			requestBody: map[string]interface{}{
+				"debt_list_id":   debtListID.String(),
+				"amount":         "100.00",
+				"payment_date":   paymentDate.Format(time.RFC3339),
+				"payment_method": "cash",
+			},
+			setupMock: func(mockDebtService *mocks.MockDebtService) {
+				mockDebtService.On("CreateDebtItem", mock.Anything, userID, mock.AnythingOfType("*entities.CreateDebtItemRequest")).Return(nil, fmt.Errorf("failed to create debt item: %w", context.Canceled))
+			},
+			setupContext: func(c *gin.Context) {
+				c.Set("user_id", userID)
+			},
+			expectedStatus: handlers.StatusClientClosedRequest,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Request cancelled", body["error"])
+			},
+		},
+		{
+			name: "request timed out",
+			requestBody: map[string]interface{}{
+				"debt_list_id":   debtListID.String(),
+				"amount":         "100.00",
+				"payment_date":   paymentDate.Format(time.RFC3339),
+				"payment_method": "cash",
+			},
+			setupMock: func(mockDebtService *mocks.MockDebtService) {
+				mockDebtService.On("CreateDebtItem", mock.Anything, userID, mock.AnythingOfType("*entities.CreateDebtItemRequest")).Return(nil, context.DeadlineExceeded)
+			},
+			setupContext: func(c *gin.Context) {
+				c.Set("user_id", userID)
+			},
+			expectedStatus: http.StatusRequestTimeout,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Request timeout", body["error"])
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -420,3 +604,184 @@ func TestDebtHandler_CreateDebtItem(t *testing.T) {
 		})
 	}
 }
+
+func TestDebtHandler_UploadReceipt_MaxReceiptSize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := uuid.New()
+	debtItemID := uuid.New()
+	const megabyte = 1 << 20
+
+	tests := []struct {
+		name           string
+		opts           []handlers.DebtHandlerOption
+		fileSize       int
+		expectUpload   bool
+		expectedStatus int
+	}{
+		{
+			name:           "default limit rejects file over 10MB",
+			fileSize:       10*megabyte + megabyte/2,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "default limit rejects oversized body before parsing",
+			fileSize:       20 * megabyte,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "custom limit accepts file between old and new limits",
+			opts:           []handlers.DebtHandlerOption{handlers.WithMaxReceiptSize(12 * megabyte)},
+			fileSize:       10*megabyte + megabyte/2,
+			expectUpload:   true,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockDebtService := &mocks.MockDebtService{}
+			mockFileStorageService := &mocks.MockFileStorageService{}
+			if tt.expectUpload {
+				photoURL := "/api/v1/debts/receipts/receipt.jpg"
+				mockFileStorageService.On("UploadReceipt", mock.Anything, mock.Anything, "receipt.jpg", "image/jpeg", debtItemID).Return(photoURL, nil)
+				mockDebtService.On("UpdateDebtItem", mock.Anything, debtItemID, userID, mock.AnythingOfType("*entities.UpdateDebtItemRequest")).Return(&entities.DebtItem{
+					ID:              debtItemID,
+					ReceiptPhotoURL: &photoURL,
+				}, nil)
+			}
+			debtHandler := handlers.NewDebtHandler(mockDebtService, mockFileStorageService, zerolog.New(nil), tt.opts...)
+
+			router := gin.New()
+			router.POST("/api/payments/:id/receipt", func(c *gin.Context) {
+				c.Set("user_id", userID)
+				debtHandler.UploadReceipt(c)
+			})
+
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			partHeader := textproto.MIMEHeader{}
+			partHeader.Set("Content-Disposition", `form-data; name="receipt"; filename="receipt.jpg"`)
+			partHeader.Set("Content-Type", "image/jpeg")
+			part, err := writer.CreatePart(partHeader)
+			assert.NoError(t, err)
+			_, err = part.Write(bytes.Repeat([]byte{0xff}, tt.fileSize))
+			assert.NoError(t, err)
+			assert.NoError(t, writer.Close())
+
+			// Execute
+			req := httptest.NewRequest(http.MethodPost, "/api/payments/"+debtItemID.String()+"/receipt", body)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockDebtService.AssertExpectations(t)
+			mockFileStorageService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDebtHandler_UploadReceipt_AllowedTypes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := uuid.New()
+	debtItemID := uuid.New()
+	allowPDF := handlers.WithReceiptTypes(
+		append([]string{"application/pdf"}, config.DefaultReceiptContentTypes...),
+		append([]string{".pdf"}, config.DefaultReceiptExtensions...),
+	)
+
+	tests := []struct {
+		name           string
+		opts           []handlers.DebtHandlerOption
+		filename       string
+		contentType    string
+		expectUpload   bool
+		expectedStatus int
+	}{
+		{
+			name:           "default types reject PDF",
+			filename:       "receipt.pdf",
+			contentType:    "application/pdf",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "configured types accept PDF",
+			opts:           []handlers.DebtHandlerOption{allowPDF},
+			filename:       "receipt.pdf",
+			contentType:    "application/pdf",
+			expectUpload:   true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "configured types still accept default images",
+			opts:           []handlers.DebtHandlerOption{allowPDF},
+			filename:       "receipt.jpg",
+			contentType:    "image/jpeg",
+			expectUpload:   true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "allowed content type with unlisted extension is rejected",
+			opts:           []handlers.DebtHandlerOption{allowPDF},
+			filename:       "receipt.heic",
+			contentType:    "application/pdf",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "listed extension with unlisted content type is rejected",
+			opts:           []handlers.DebtHandlerOption{allowPDF},
+			filename:       "receipt.pdf",
+			contentType:    "image/heic",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockDebtService := &mocks.MockDebtService{}
+			mockFileStorageService := &mocks.MockFileStorageService{}
+			if tt.expectUpload {
+				photoURL := "/api/v1/debts/receipts/" + tt.filename
+				mockFileStorageService.On("UploadReceipt", mock.Anything, mock.Anything, tt.filename, tt.contentType, debtItemID).Return(photoURL, nil)
+				mockDebtService.On("UpdateDebtItem", mock.Anything, debtItemID, userID, mock.AnythingOfType("*entities.UpdateDebtItemRequest")).Return(&entities.DebtItem{
+					ID:              debtItemID,
+					ReceiptPhotoURL: &photoURL,
+				}, nil)
+			}
+			debtHandler := handlers.NewDebtHandler(mockDebtService, mockFileStorageService, zerolog.New(nil), tt.opts...)
+
+			router := gin.New()
+			router.POST("/api/payments/:id/receipt", func(c *gin.Context) {
+				c.Set("user_id", userID)
+				debtHandler.UploadReceipt(c)
+			})
+
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			partHeader := textproto.MIMEHeader{}
+			partHeader.Set("Content-Disposition", `form-data; name="receipt"; filename="`+tt.filename+`"`)
+			partHeader.Set("Content-Type", tt.contentType)
+			part, err := writer.CreatePart(partHeader)
+			assert.NoError(t, err)
+			_, err = part.Write([]byte("receipt contents"))
+			assert.NoError(t, err)
+			assert.NoError(t, writer.Close())
+
+			// Execute
+			req := httptest.NewRequest(http.MethodPost, "/api/payments/"+debtItemID.String()+"/receipt", body)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockDebtService.AssertExpectations(t)
+			mockFileStorageService.AssertExpectations(t)
+		})
+	}
+}
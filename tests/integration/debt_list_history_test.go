@@ -0,0 +1,119 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+)
+
+func TestGetDebtListHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t, &models.DebtListHistoryEntry{})
+
+	aliceID := f.register("alice-history@example.com", "Alice")
+	bobID := f.register("bob-history@example.com", "Bob")
+	carolID := f.register("carol-history@example.com", "Carol")
+
+	bob, err := f.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{
+		Name:  "Bob",
+		Email: stringPtr("bob-history@example.com"),
+	})
+	require.NoError(t, err)
+	debtList, err := f.debtService.CreateDebtList(ctx, aliceID, &entities.CreateDebtListRequest{
+		ContactID:   bob.ID,
+		DebtType:    "to_receive",
+		TotalAmount: "300.00",
+		Currency:    "USD",
+		DueDate:     timePtr(time.Now().AddDate(0, 3, 0)),
+	})
+	require.NoError(t, err)
+
+	debtHandler := handlers.NewDebtHandler(f.debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	get := func(userID, debtListID uuid.UUID) (int, []entities.DebtListHistoryEntry) {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("user_id", userID)
+		})
+		router.GET("/api/v1/debts/:id/history", debtHandler.GetDebtListHistory)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/debts/"+debtListID.String()+"/history", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var body struct {
+			Data []entities.DebtListHistoryEntry `json:"data"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return w.Code, body.Data
+	}
+
+	code, history := get(aliceID, debtList.ID)
+	require.Equal(t, http.StatusOK, code)
+	assert.Empty(t, history)
+
+	_, err = f.debtService.UpdateDebtList(ctx, debtList.ID, aliceID, &entities.UpdateDebtListRequest{
+		TotalAmount: stringPtr("450.00"),
+		Description: stringPtr("Not tracked"),
+	})
+	require.NoError(t, err)
+
+	code, history = get(aliceID, debtList.ID)
+	require.Equal(t, http.StatusOK, code)
+	// Values derived from the total are recorded alongside it
+	changed := make(map[string]entities.DebtListHistoryEntry, len(history))
+	for _, entry := range history {
+		assert.Equal(t, debtList.ID, entry.DebtListID)
+		assert.Equal(t, aliceID, entry.ChangedBy)
+		changed[entry.Field] = entry
+	}
+	assert.NotContains(t, changed, "description")
+	assert.Contains(t, changed, "installment_amount")
+	entry, ok := changed["total_amount"]
+	require.True(t, ok)
+	require.NotNil(t, entry.OldValue)
+	require.NotNil(t, entry.NewValue)
+	assert.True(t, decimal.RequireFromString("300.00").Equal(decimal.RequireFromString(*entry.OldValue)), "old value %s", *entry.OldValue)
+	assert.True(t, decimal.RequireFromString("450.00").Equal(decimal.RequireFromString(*entry.NewValue)), "new value %s", *entry.NewValue)
+
+	t.Run("unchanged values are not recorded", func(t *testing.T) {
+		_, err := f.debtService.UpdateDebtList(ctx, debtList.ID, aliceID, &entities.UpdateDebtListRequest{
+			TotalAmount: stringPtr("450.00"),
+		})
+		require.NoError(t, err)
+
+		_, unchanged := get(aliceID, debtList.ID)
+		assert.Len(t, unchanged, len(history))
+	})
+
+	t.Run("the contact can view the history", func(t *testing.T) {
+		code, contactHistory := get(bobID, debtList.ID)
+		require.Equal(t, http.StatusOK, code)
+		assert.Len(t, contactHistory, len(history))
+	})
+
+	t.Run("other users cannot view the history", func(t *testing.T) {
+		code, _ := get(carolID, debtList.ID)
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("unknown debt", func(t *testing.T) {
+		code, _ := get(aliceID, uuid.New())
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
@@ -0,0 +1,104 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+)
+
+func TestGetDebtPerspective(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	register := func(email string) uuid.UUID {
+		resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+			Email:     email,
+			Password:  "password123",
+			FirstName: "Perspective",
+			LastName:  "User",
+		})
+		require.NoError(t, err)
+		return resp.User.ID
+	}
+	ownerID := register("owner-perspective@example.com")
+	contactUserID := register("contact-perspective@example.com")
+	strangerID := register("stranger-perspective@example.com")
+
+	contact, err := f.contactService.CreateContact(ctx, ownerID, &entities.CreateContactRequest{
+		Name:  "Contact",
+		Email: stringPtr("contact-perspective@example.com"),
+	})
+	require.NoError(t, err)
+
+	debtList, err := f.debtService.CreateDebtList(ctx, ownerID, &entities.CreateDebtListRequest{
+		ContactID:   contact.ID,
+		DebtType:    "to_receive",
+		TotalAmount: "100.00",
+		Currency:    "USD",
+		DueDate:     timePtr(time.Now().AddDate(0, 1, 0)),
+	})
+	require.NoError(t, err)
+
+	debtHandler := handlers.NewDebtHandler(f.debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		userID, err := uuid.Parse(c.GetHeader("X-Test-User"))
+		require.NoError(t, err)
+		c.Set("user_id", userID)
+	})
+	router.GET("/api/v1/debts/:id/perspective", debtHandler.GetDebtPerspective)
+
+	getPerspective := func(userID uuid.UUID) (int, entities.DebtPerspective) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/debts/"+debtList.ID.String()+"/perspective", nil)
+		req.Header.Set("X-Test-User", userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var responseBody struct {
+			Data entities.DebtPerspective `json:"data"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &responseBody)
+		return w.Code, responseBody.Data
+	}
+	ownerView := entities.DebtView{DebtType: "to_receive", Direction: entities.DebtDirectionOwedToMe}
+
+	// The owner's effective view is the stored one
+	code, perspective := getPerspective(ownerID)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, debtList.ID, perspective.DebtListID)
+	assert.Equal(t, entities.DebtRoleOwner, perspective.Role)
+	assert.False(t, perspective.Flipped)
+	assert.Equal(t, ownerView, perspective.OwnerView)
+	assert.Equal(t, ownerView, perspective.EffectiveView)
+
+	// The contact sees the same stored view but a flipped effective one
+	code, perspective = getPerspective(contactUserID)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, entities.DebtRoleContact, perspective.Role)
+	assert.True(t, perspective.Flipped)
+	assert.Equal(t, ownerView, perspective.OwnerView)
+	assert.Equal(t, entities.DebtView{DebtType: "to_pay", Direction: entities.DebtDirectionIOwe}, perspective.EffectiveView)
+
+	// The effective view agrees with what the contact gets for the debt itself
+	contactDebt, err := f.debtService.GetDebtList(ctx, debtList.ID, contactUserID)
+	require.NoError(t, err)
+	assert.Equal(t, contactDebt.DebtType, perspective.EffectiveView.DebtType)
+
+	code, _ = getPerspective(strangerID)
+	assert.Equal(t, http.StatusNotFound, code)
+}
@@ -0,0 +1,279 @@
+package integration
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/domain/interfaces"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+	"pay-your-dues/internal/repository"
+	"pay-your-dues/internal/services"
+)
+
+type DebtProposalWorkflowTestSuite struct {
+	suite.Suite
+	db              *gorm.DB
+	authService     interfaces.AuthService
+	contactService  interfaces.ContactService
+	debtService     interfaces.DebtService
+	proposalService interfaces.DebtProposalService
+}
+
+func (suite *DebtProposalWorkflowTestSuite) SetupSuite() {
+	// Setup in-memory SQLite database for testing
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	// Auto-migrate all models
+	err = db.AutoMigrate(
+		&models.User{},
+		&models.Contact{},
+		&models.UserContact{},
+		&models.DebtList{},
+		&models.DebtItem{},
+		&models.DebtProposal{},
+	)
+	suite.Require().NoError(err)
+
+	suite.db = db
+
+	// Initialize repositories
+	userRepo := repository.NewUserRepositoryGORM(db)
+	contactRepo := repository.NewContactRepositoryGORM(db)
+	debtListRepo := repository.NewDebtListRepositoryGORM(db, contactRepo)
+	debtItemRepo := repository.NewDebtItemRepositoryGORM(db)
+	proposalRepo := repository.NewDebtProposalRepositoryGORM(db)
+
+	// Initialize services
+	suite.contactService = services.NewContactService(contactRepo, userRepo)
+	suite.debtService = services.NewDebtService(debtListRepo, debtItemRepo, contactRepo, services.NewPaymentScheduleService(), &mocks.MockFileStorageService{})
+	suite.proposalService = services.NewDebtProposalService(proposalRepo, contactRepo, suite.debtService)
+
+	authService, err := services.NewAuthService(userRepo, suite.contactService, "test-secret", "24h")
+	suite.Require().NoError(err)
+	suite.authService = authService
+}
+
+func (suite *DebtProposalWorkflowTestSuite) SetupTest() {
+	// Ensure clean database state before each test
+	suite.db.Exec("DELETE FROM debt_proposals")
+	suite.db.Exec("DELETE FROM debt_items")
+	suite.db.Exec("DELETE FROM debt_lists")
+	suite.db.Exec("DELETE FROM user_contacts")
+	suite.db.Exec("DELETE FROM contacts")
+	suite.db.Exec("DELETE FROM users")
+}
+
+// registerPair registers a proposer and a recipient and returns their IDs along with
+// the proposer's contact ID for the recipient
+func (suite *DebtProposalWorkflowTestSuite) registerPair(ctx context.Context) (uuid.UUID, uuid.UUID, uuid.UUID) {
+	proposer, err := suite.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "lender@example.com",
+		Password:  "password123",
+		FirstName: "Lena",
+		LastName:  "Lender",
+	})
+	suite.Require().NoError(err)
+
+	recipient, err := suite.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "borrower@example.com",
+		Password:  "password456",
+		FirstName: "Ben",
+		LastName:  "Borrower",
+	})
+	suite.Require().NoError(err)
+
+	contact, err := suite.contactService.CreateContact(ctx, proposer.User.ID, &entities.CreateContactRequest{
+		Name:  "Ben Borrower",
+		Email: stringPtr("borrower@example.com"),
+	})
+	suite.Require().NoError(err)
+	suite.Require().True(contact.IsUser)
+
+	return proposer.User.ID, recipient.User.ID, contact.ID
+}
+
+func (suite *DebtProposalWorkflowTestSuite) proposalRequest(contactID uuid.UUID) *entities.CreateDebtListRequest {
+	return &entities.CreateDebtListRequest{
+		ContactID:        contactID,
+		DebtType:         "to_receive",
+		TotalAmount:      "1200.00",
+		Currency:         "USD",
+		InstallmentPlan:  "monthly",
+		NumberOfPayments: intPtr(6),
+		Description:      stringPtr("Laptop loan"),
+	}
+}
+
+func (suite *DebtProposalWorkflowTestSuite) TestProposeAndAcceptCreatesDebt() {
+	ctx := context.Background()
+	proposerID, recipientID, contactID := suite.registerPair(ctx)
+
+	proposal, err := suite.proposalService.ProposeDebt(ctx, proposerID, suite.proposalRequest(contactID))
+	suite.Require().NoError(err)
+	suite.Equal(entities.DebtProposalStatusPending, proposal.Status)
+	suite.Equal(recipientID, proposal.RecipientID)
+
+	// Nothing exists until the recipient accepts
+	debtLists, err := suite.debtService.GetUserDebtLists(ctx, proposerID)
+	suite.NoError(err)
+	suite.Len(debtLists, 0)
+
+	// The proposer cannot accept their own proposal
+	_, err = suite.proposalService.AcceptDebtProposal(ctx, proposal.ID, proposerID)
+	suite.ErrorIs(err, entities.ErrDebtProposalNotFound)
+
+	debtList, err := suite.proposalService.AcceptDebtProposal(ctx, proposal.ID, recipientID)
+	suite.Require().NoError(err)
+	suite.Equal(proposerID, debtList.UserID)
+	suite.Equal(contactID, debtList.ContactID)
+	suite.Equal("to_receive", debtList.DebtType)
+	suite.Equal("1200", debtList.TotalAmount.String())
+	suite.Equal("USD", debtList.Currency)
+	suite.Equal(6, *debtList.NumberOfPayments)
+
+	// Both parties now see the debt
+	debtLists, err = suite.debtService.GetUserDebtLists(ctx, proposerID)
+	suite.NoError(err)
+	suite.Len(debtLists, 1)
+
+	debtLists, err = suite.debtService.GetUserDebtLists(ctx, recipientID)
+	suite.NoError(err)
+	suite.Len(debtLists, 1)
+
+	// The proposal records the outcome and cannot be answered twice
+	proposals, err := suite.proposalService.GetUserDebtProposals(ctx, recipientID)
+	suite.NoError(err)
+	suite.Require().Len(proposals, 1)
+	suite.Equal(entities.DebtProposalStatusAccepted, proposals[0].Status)
+	suite.Equal(debtList.ID, *proposals[0].DebtListID)
+	suite.NotNil(proposals[0].RespondedAt)
+
+	_, err = suite.proposalService.AcceptDebtProposal(ctx, proposal.ID, recipientID)
+	suite.ErrorIs(err, entities.ErrDebtProposalNotPending)
+}
+
+func (suite *DebtProposalWorkflowTestSuite) TestProposeAndRejectLeavesNoDebt() {
+	ctx := context.Background()
+	proposerID, recipientID, contactID := suite.registerPair(ctx)
+
+	proposal, err := suite.proposalService.ProposeDebt(ctx, proposerID, suite.proposalRequest(contactID))
+	suite.Require().NoError(err)
+
+	rejected, err := suite.proposalService.RejectDebtProposal(ctx, proposal.ID, recipientID)
+	suite.Require().NoError(err)
+	suite.Equal(entities.DebtProposalStatusRejected, rejected.Status)
+	suite.Nil(rejected.DebtListID)
+
+	// No debt list exists for either party
+	debtLists, err := suite.debtService.GetUserDebtLists(ctx, proposerID)
+	suite.NoError(err)
+	suite.Len(debtLists, 0)
+
+	debtLists, err = suite.debtService.GetUserDebtLists(ctx, recipientID)
+	suite.NoError(err)
+	suite.Len(debtLists, 0)
+
+	// A rejected proposal cannot be accepted afterwards
+	_, err = suite.proposalService.AcceptDebtProposal(ctx, proposal.ID, recipientID)
+	suite.ErrorIs(err, entities.ErrDebtProposalNotPending)
+}
+
+func (suite *DebtProposalWorkflowTestSuite) TestProposeToNonUserContactFails() {
+	ctx := context.Background()
+	proposer, err := suite.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "lender@example.com",
+		Password:  "password123",
+		FirstName: "Lena",
+		LastName:  "Lender",
+	})
+	suite.Require().NoError(err)
+
+	contact, err := suite.contactService.CreateContact(ctx, proposer.User.ID, &entities.CreateContactRequest{
+		Name: "Offline Friend",
+	})
+	suite.Require().NoError(err)
+
+	req := suite.proposalRequest(contact.ID)
+	req.DueDate = timePtr(time.Now().AddDate(0, 3, 0))
+
+	_, err = suite.proposalService.ProposeDebt(ctx, proposer.User.ID, req)
+	suite.ErrorIs(err, entities.ErrContactNotAppUser)
+}
+
+func (suite *DebtProposalWorkflowTestSuite) TestProposalTermsAreValidatedAsDebtsAre() {
+	ctx := context.Background()
+	proposerID, _, contactID := suite.registerPair(ctx)
+
+	// Terms debt list creation would refuse can't be proposed either
+	req := suite.proposalRequest(contactID)
+	req.NumberOfPayments = intPtr(100000)
+	_, err := suite.proposalService.ProposeDebt(ctx, proposerID, req)
+	suite.ErrorIs(err, entities.ErrTooManyPayments)
+
+	req = suite.proposalRequest(contactID)
+	req.Description = stringPtr(strings.Repeat("x", 100000))
+	_, err = suite.proposalService.ProposeDebt(ctx, proposerID, req)
+	suite.ErrorIs(err, entities.ErrTextTooLong)
+
+	proposals, err := suite.proposalService.GetUserDebtProposals(ctx, proposerID)
+	suite.Require().NoError(err)
+	suite.Empty(proposals)
+}
+
+func (suite *DebtProposalWorkflowTestSuite) TestExpiredProposalStaysPendingUntilCancelled() {
+	ctx := context.Background()
+	proposerID, recipientID, contactID := suite.registerPair(ctx)
+
+	req := suite.proposalRequest(contactID)
+	req.InstallmentPlan = "onetime"
+	req.NumberOfPayments = nil
+	req.DueDate = timePtr(time.Now().AddDate(0, 3, 0))
+	proposal, err := suite.proposalService.ProposeDebt(ctx, proposerID, req)
+	suite.Require().NoError(err)
+
+	// The due date passes before the recipient answers
+	suite.Require().NoError(suite.db.Model(&models.DebtProposal{}).
+		Where("id = ?", proposal.ID).
+		Update("due_date", time.Now().AddDate(0, 0, -1)).Error)
+
+	// Accepting fails without creating a debt and leaves the proposal to answer
+	_, err = suite.proposalService.AcceptDebtProposal(ctx, proposal.ID, recipientID)
+	suite.ErrorIs(err, entities.ErrInvalidDueDate)
+
+	debtLists, err := suite.debtService.GetUserDebtLists(ctx, proposerID)
+	suite.NoError(err)
+	suite.Len(debtLists, 0)
+
+	proposals, err := suite.proposalService.GetUserDebtProposals(ctx, proposerID)
+	suite.Require().NoError(err)
+	suite.Require().Len(proposals, 1)
+	suite.Equal(entities.DebtProposalStatusPending, proposals[0].Status)
+	suite.Nil(proposals[0].RespondedAt)
+
+	// Only the proposer can withdraw it
+	_, err = suite.proposalService.CancelDebtProposal(ctx, proposal.ID, recipientID)
+	suite.ErrorIs(err, entities.ErrDebtProposalNotFound)
+
+	cancelled, err := suite.proposalService.CancelDebtProposal(ctx, proposal.ID, proposerID)
+	suite.Require().NoError(err)
+	suite.Equal(entities.DebtProposalStatusCancelled, cancelled.Status)
+
+	_, err = suite.proposalService.AcceptDebtProposal(ctx, proposal.ID, recipientID)
+	suite.ErrorIs(err, entities.ErrDebtProposalNotPending)
+	_, err = suite.proposalService.CancelDebtProposal(ctx, proposal.ID, proposerID)
+	suite.ErrorIs(err, entities.ErrDebtProposalNotPending)
+}
+
+func TestDebtProposalWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(DebtProposalWorkflowTestSuite))
+}
@@ -0,0 +1,115 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+)
+
+func TestRecomputeDebtList_RepairsCorruptedTotals(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	register := func(email string) uuid.UUID {
+		resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+			Email:     email,
+			Password:  "password123",
+			FirstName: "Recompute",
+			LastName:  "User",
+		})
+		require.NoError(t, err)
+		return resp.User.ID
+	}
+	ownerID := register("owner-recompute@example.com")
+	debtorID := register("debtor-recompute@example.com")
+	strangerID := register("stranger-recompute@example.com")
+
+	contact, err := f.contactService.CreateContact(ctx, ownerID, &entities.CreateContactRequest{
+		Name:  "Debtor",
+		Email: stringPtr("debtor-recompute@example.com"),
+	})
+	require.NoError(t, err)
+
+	debtList, err := f.debtService.CreateDebtList(ctx, ownerID, &entities.CreateDebtListRequest{
+		ContactID:   contact.ID,
+		DebtType:    "to_receive",
+		TotalAmount: "100.00",
+		Currency:    "USD",
+		DueDate:     timePtr(time.Now().AddDate(0, 1, 0)),
+	})
+	require.NoError(t, err)
+
+	// Payments recorded by the owner are completed straight away
+	_, err = f.debtService.CreateDebtItem(ctx, ownerID, &entities.CreateDebtItemRequest{
+		DebtListID:    debtList.ID,
+		Amount:        "30.00",
+		Currency:      "USD",
+		PaymentDate:   time.Now(),
+		PaymentMethod: "cash",
+	})
+	require.NoError(t, err)
+
+	// Simulate totals that drifted through a manual database edit
+	require.NoError(t, f.db.Model(&models.DebtList{}).Where("id = ?", debtList.ID).Updates(map[string]interface{}{
+		"total_payments_made":  decimal.RequireFromString("90.00"),
+		"total_remaining_debt": decimal.RequireFromString("10.00"),
+		"status":               "settled",
+	}).Error)
+
+	debtHandler := handlers.NewDebtHandler(f.debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		userID, err := uuid.Parse(c.GetHeader("X-Test-User"))
+		require.NoError(t, err)
+		c.Set("user_id", userID)
+	})
+	router.POST("/api/v1/debts/:id/recompute", debtHandler.RecomputeDebtList)
+
+	recompute := func(userID uuid.UUID) (*httptest.ResponseRecorder, map[string]interface{}) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/debts/"+debtList.ID.String()+"/recompute", nil)
+		req.Header.Set("X-Test-User", userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var responseBody map[string]interface{}
+		_ = json.Unmarshal(w.Body.Bytes(), &responseBody)
+		return w, responseBody
+	}
+
+	// Only the owner may recompute
+	w, _ := recompute(debtorID)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w, _ = recompute(strangerID)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w, body := recompute(ownerID)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	data := body["data"].(map[string]interface{})
+	assert.Equal(t, debtList.ID.String(), data["id"])
+	assert.Equal(t, "30", data["total_payments_made"])
+	assert.Equal(t, "70", data["total_remaining_debt"])
+	assert.Equal(t, "active", data["status"])
+
+	stored, err := f.debtListRepo.GetByID(ctx, debtList.ID)
+	require.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("30.00").Equal(stored.TotalPaymentsMade), stored.TotalPaymentsMade.String())
+	assert.True(t, decimal.RequireFromString("70.00").Equal(stored.TotalRemainingDebt), stored.TotalRemainingDebt.String())
+	assert.Equal(t, "active", stored.Status)
+}
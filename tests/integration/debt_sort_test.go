@@ -0,0 +1,163 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+)
+
+func TestDebtListSorting(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	now := time.Now()
+
+	// Created oldest first, with amounts and payment dates in other orders
+	newDebt := func(createdDaysAgo, nextPaymentInDays int, amount string) entities.DebtListResponse {
+		return entities.DebtListResponse{
+			ID:              uuid.New(),
+			TotalAmount:     decimal.RequireFromString(amount),
+			NextPaymentDate: now.AddDate(0, 0, nextPaymentInDays),
+			CreatedAt:       now.AddDate(0, 0, -createdDaysAgo),
+		}
+	}
+	oldest := newDebt(30, 5, "200.00")
+	middle := newDebt(20, 15, "50.00")
+	newest := newDebt(10, 1, "100.00")
+
+	mockDebtService := &mocks.MockDebtService{}
+	mockDebtService.On("GetUserDebtLists", mock.Anything, userID).Return([]entities.DebtListResponse{oldest, middle, newest}, nil)
+	mockDebtService.On("GetDebtListsModifiedSince", mock.Anything, userID, mock.Anything, false).Return([]entities.DebtListChange{
+		{DebtListResponse: oldest}, {DebtListResponse: middle}, {DebtListResponse: newest},
+	}, nil)
+	mockDebtService.On("GetDebtsByContact", mock.Anything, userID).Return([]entities.ContactDebts{
+		{Debts: []entities.DebtListResponse{oldest, middle, newest}},
+	}, nil)
+	debtHandler := handlers.NewDebtHandler(mockDebtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+	})
+	router.GET("/api/v1/debts", debtHandler.GetUserDebtLists)
+	router.GET("/api/v1/debts/by-contact", debtHandler.GetDebtsByContact)
+
+	get := func(path, sort string, body interface{}) int {
+		query := url.Values{}
+		if sort != "" {
+			query.Set("sort", sort)
+		}
+		if strings.Contains(path, "?") {
+			path += "&" + query.Encode()
+		} else {
+			path += "?" + query.Encode()
+		}
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		_ = json.Unmarshal(w.Body.Bytes(), body)
+		return w.Code
+	}
+	idsOf := func(debtLists []entities.DebtListResponse) []uuid.UUID {
+		ids := make([]uuid.UUID, len(debtLists))
+		for i, debtList := range debtLists {
+			ids[i] = debtList.ID
+		}
+		return ids
+	}
+	listFrom := func(path, sort string) (int, []uuid.UUID) {
+		var body struct {
+			Data []entities.DebtListResponse `json:"data"`
+		}
+		code := get(path, sort, &body)
+		return code, idsOf(body.Data)
+	}
+	list := func(sort string) (int, []uuid.UUID) {
+		return listFrom("/api/v1/debts", sort)
+	}
+	syncSince := "/api/v1/debts?modified_since=" + url.QueryEscape(now.AddDate(0, -1, 0).Format(time.RFC3339))
+	byContact := func(sort string) (int, []uuid.UUID) {
+		var body struct {
+			Data []entities.ContactDebts `json:"data"`
+		}
+		code := get("/api/v1/debts/by-contact", sort, &body)
+		if len(body.Data) != 1 {
+			return code, nil
+		}
+		return code, idsOf(body.Data[0].Debts)
+	}
+
+	t.Run("newest first by default", func(t *testing.T) {
+		code, ids := list("")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []uuid.UUID{newest.ID, middle.ID, oldest.ID}, ids)
+	})
+
+	t.Run("configured default", func(t *testing.T) {
+		handlers.SetDefaultDebtSort(entities.DebtSort{Field: "next_payment_date"})
+		defer handlers.SetDefaultDebtSort(entities.DebtSort{Field: "created_at", Descending: true})
+
+		code, ids := list("")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []uuid.UUID{newest.ID, oldest.ID, middle.ID}, ids)
+	})
+
+	t.Run("explicit sorts", func(t *testing.T) {
+		code, ids := list("total_amount desc")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []uuid.UUID{oldest.ID, newest.ID, middle.ID}, ids)
+
+		code, ids = list("total_amount")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []uuid.UUID{middle.ID, newest.ID, oldest.ID}, ids)
+
+		code, ids = list("created_at asc")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []uuid.UUID{oldest.ID, middle.ID, newest.ID}, ids)
+	})
+
+	t.Run("fields outside the whitelist are rejected", func(t *testing.T) {
+		for _, sort := range []string{"notes", "contact_id desc", "due_date upward"} {
+			code, _ := list(sort)
+			assert.Equal(t, http.StatusBadRequest, code, sort)
+			code, _ = listFrom(syncSince, sort)
+			assert.Equal(t, http.StatusBadRequest, code, sort)
+			code, _ = byContact(sort)
+			assert.Equal(t, http.StatusBadRequest, code, sort)
+		}
+	})
+
+	t.Run("incremental sync is sorted too", func(t *testing.T) {
+		code, ids := listFrom(syncSince, "")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []uuid.UUID{newest.ID, middle.ID, oldest.ID}, ids)
+
+		code, ids = listFrom(syncSince, "total_amount")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []uuid.UUID{middle.ID, newest.ID, oldest.ID}, ids)
+	})
+
+	t.Run("debts grouped by contact are sorted within each contact", func(t *testing.T) {
+		code, ids := byContact("")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []uuid.UUID{newest.ID, middle.ID, oldest.ID}, ids)
+
+		code, ids = byContact("next_payment_date")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []uuid.UUID{newest.ID, oldest.ID, middle.ID}, ids)
+	})
+}
@@ -0,0 +1,168 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+	"pay-your-dues/internal/services"
+)
+
+func TestGetDebtListsModifiedSince(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t, &models.DebtListHistoryEntry{})
+
+	purgingService := f.newDebtService(services.WithSoftDeletePayments(false))
+
+	resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "lender-sync@example.com",
+		Password:  "password123",
+		FirstName: "Sync",
+		LastName:  "Lender",
+	})
+	require.NoError(t, err)
+	lenderID := resp.User.ID
+
+	contact, err := f.contactService.CreateContact(ctx, lenderID, &entities.CreateContactRequest{Name: "Offline Borrower"})
+	require.NoError(t, err)
+	newDebt := func(amount string) *entities.DebtList {
+		debtList, err := f.debtService.CreateDebtList(ctx, lenderID, &entities.CreateDebtListRequest{
+			ContactID:   contact.ID,
+			DebtType:    "to_receive",
+			TotalAmount: amount,
+			Currency:    "USD",
+			DueDate:     timePtr(time.Now().AddDate(0, 3, 0)),
+		})
+		require.NoError(t, err)
+		return debtList
+	}
+	pay := func(debtListID uuid.UUID) *entities.DebtItem {
+		payment, err := f.debtService.CreateDebtItem(ctx, lenderID, &entities.CreateDebtItemRequest{
+			DebtListID:    debtListID,
+			Amount:        "10.00",
+			Currency:      "USD",
+			PaymentDate:   time.Now(),
+			PaymentMethod: "cash",
+		})
+		require.NoError(t, err)
+		return payment
+	}
+
+	untouched := newDebt("100.00")
+	pay(untouched.ID)
+	updated := newDebt("200.00")
+	pay(updated.ID)
+	withNewPayment := newDebt("300.00")
+	withDeletedPayment := newDebt("400.00")
+	deletedPayment := pay(withDeletedPayment.ID)
+	withPurgedPayment := newDebt("500.00")
+	purgedPayment := pay(withPurgedPayment.ID)
+	deleted := newDebt("600.00")
+
+	// Everything so far last changed an hour ago, before the last sync
+	lastSync := time.Now().Add(-30 * time.Minute)
+	hourAgo := time.Now().Add(-time.Hour)
+	require.NoError(t, f.db.Session(&gorm.Session{AllowGlobalUpdate: true}).Model(&models.DebtList{}).UpdateColumn("updated_at", hourAgo).Error)
+	require.NoError(t, f.db.Session(&gorm.Session{AllowGlobalUpdate: true}).Model(&models.DebtItem{}).UpdateColumn("updated_at", hourAgo).Error)
+
+	_, err = f.debtService.UpdateDebtList(ctx, updated.ID, lenderID, &entities.UpdateDebtListRequest{Notes: stringPtr("Agreed to pay weekly")})
+	require.NoError(t, err)
+	newPayment := pay(withNewPayment.ID)
+	require.NoError(t, f.debtService.DeleteDebtItem(ctx, deletedPayment.ID, lenderID))
+	require.NoError(t, purgingService.DeleteDebtItem(ctx, purgedPayment.ID, lenderID))
+	require.NoError(t, f.debtService.DeleteDebtList(ctx, deleted.ID, lenderID))
+
+	debtHandler := handlers.NewDebtHandler(f.debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", lenderID)
+	})
+	router.GET("/api/v1/debts", debtHandler.GetUserDebtLists)
+
+	type syncResponse struct {
+		Data []entities.DebtListChange `json:"data"`
+		Meta handlers.PaginationMeta   `json:"meta"`
+	}
+	get := func(query url.Values) (int, syncResponse) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/debts?"+query.Encode(), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var body syncResponse
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return w.Code, body
+	}
+	byID := func(changes []entities.DebtListChange) map[uuid.UUID]entities.DebtListChange {
+		result := make(map[uuid.UUID]entities.DebtListChange, len(changes))
+		for _, change := range changes {
+			result[change.ID] = change
+		}
+		return result
+	}
+
+	// Only the lists changed since the last sync are returned
+	requestedAt := time.Now()
+	code, body := get(url.Values{"modified_since": {lastSync.Format(time.RFC3339)}, "include_payments": {"true"}})
+	require.Equal(t, http.StatusOK, code)
+	changes := byID(body.Data)
+	require.Len(t, changes, 5)
+	assert.NotContains(t, changes, untouched.ID)
+	require.NotNil(t, body.Meta.SyncedAt)
+	assert.WithinDuration(t, requestedAt, *body.Meta.SyncedAt, 5*time.Second)
+
+	// Payments that did not change are left out
+	assert.Empty(t, changes[updated.ID].Payments)
+	assert.Empty(t, changes[updated.ID].DeletedPaymentIDs)
+	assert.False(t, changes[updated.ID].Deleted)
+
+	require.Len(t, changes[withNewPayment.ID].Payments, 1)
+	assert.Equal(t, newPayment.ID, changes[withNewPayment.ID].Payments[0].ID)
+
+	// Deleted and purged payments and deleted lists are marked so clients can
+	// reconcile them
+	assert.Empty(t, changes[withDeletedPayment.ID].Payments)
+	assert.Equal(t, []uuid.UUID{deletedPayment.ID}, changes[withDeletedPayment.ID].DeletedPaymentIDs)
+	assert.Empty(t, changes[withPurgedPayment.ID].Payments)
+	assert.Equal(t, []uuid.UUID{purgedPayment.ID}, changes[withPurgedPayment.ID].DeletedPaymentIDs)
+	assert.True(t, changes[deleted.ID].Deleted)
+	assert.False(t, changes[withPurgedPayment.ID].Deleted)
+
+	// Payments are only included when asked for
+	code, body = get(url.Values{"modified_since": {lastSync.Format(time.RFC3339)}})
+	require.Equal(t, http.StatusOK, code)
+	changes = byID(body.Data)
+	require.Len(t, changes, 5)
+	assert.Empty(t, changes[withNewPayment.ID].Payments)
+	assert.Empty(t, changes[withDeletedPayment.ID].DeletedPaymentIDs)
+
+	// Nothing changed after a later sync point
+	code, body = get(url.Values{"modified_since": {time.Now().Add(time.Minute).Format(time.RFC3339)}})
+	require.Equal(t, http.StatusOK, code)
+	assert.Empty(t, body.Data)
+
+	// Without modified_since every list is returned as before
+	code, body = get(url.Values{})
+	require.Equal(t, http.StatusOK, code)
+	assert.Len(t, body.Data, 5)
+
+	code, _ = get(url.Values{"modified_since": {"yesterday"}})
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = get(url.Values{"modified_since": {lastSync.Format(time.RFC3339)}, "include_payments": {"maybe"}})
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,174 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"pay-your-dues/internal/database"
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+	"pay-your-dues/internal/repository"
+	"pay-your-dues/internal/services"
+)
+
+func TestNormalizeLegacyDebtTypes(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	// Tables from before the debt type check constraint, holding legacy values
+	require.NoError(t, db.Exec("CREATE TABLE debt_lists (id TEXT PRIMARY KEY, debt_type TEXT NOT NULL, deleted_at DATETIME)").Error)
+	require.NoError(t, db.Exec("CREATE TABLE debt_proposals (id TEXT PRIMARY KEY, debt_type TEXT NOT NULL)").Error)
+	require.NoError(t, db.Exec(`INSERT INTO debt_lists (id, debt_type, deleted_at) VALUES
+		('owed', 'owed_to_me', NULL), ('owe', 'i_owe', NULL), ('current', 'to_pay', NULL), ('deleted', 'i_owe', CURRENT_TIMESTAMP)`).Error)
+	require.NoError(t, db.Exec("INSERT INTO debt_proposals (id, debt_type) VALUES ('proposal', 'owed_to_me')").Error)
+
+	updated, err := database.NormalizeLegacyDebtTypes(db)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), updated)
+
+	debtTypeOf := func(table, id string) string {
+		var debtType string
+		require.NoError(t, db.Raw("SELECT debt_type FROM "+table+" WHERE id = ?", id).Scan(&debtType).Error)
+		return debtType
+	}
+	assert.Equal(t, entities.DebtTypeToReceive, debtTypeOf("debt_lists", "owed"))
+	assert.Equal(t, entities.DebtTypeToPay, debtTypeOf("debt_lists", "owe"))
+	assert.Equal(t, entities.DebtTypeToPay, debtTypeOf("debt_lists", "current"))
+	assert.Equal(t, entities.DebtTypeToPay, debtTypeOf("debt_lists", "deleted"))
+	assert.Equal(t, entities.DebtTypeToReceive, debtTypeOf("debt_proposals", "proposal"))
+
+	// Running again changes nothing
+	updated, err = database.NormalizeLegacyDebtTypes(db)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), updated)
+
+	// A fresh database without the tables is left alone
+	fresh, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	updated, err = database.NormalizeLegacyDebtTypes(fresh)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), updated)
+}
+
+func TestCreateDebtListNormalizesDebtType(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Contact{}, &models.UserContact{}, &models.DebtList{}, &models.DebtItem{}))
+
+	userRepo := repository.NewUserRepositoryGORM(db)
+	contactRepo := repository.NewContactRepositoryGORM(db)
+	debtListRepo := repository.NewDebtListRepositoryGORM(db, contactRepo)
+	debtItemRepo := repository.NewDebtItemRepositoryGORM(db)
+	contactService := services.NewContactService(contactRepo, userRepo)
+	authService, err := services.NewAuthService(userRepo, contactService, "test-secret", "24h")
+	require.NoError(t, err)
+	debtService := services.NewDebtService(debtListRepo, debtItemRepo, contactRepo, services.NewPaymentScheduleService(), &mocks.MockFileStorageService{})
+
+	resp, err := authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "alice-debt-type@example.com",
+		Password:  "password123",
+		FirstName: "Alice",
+		LastName:  "Lender",
+	})
+	require.NoError(t, err)
+	aliceID := resp.User.ID
+	bob, err := contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{Name: "Bob"})
+	require.NoError(t, err)
+
+	debtHandler := handlers.NewDebtHandler(debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", aliceID)
+	})
+	router.POST("/api/v1/debts", debtHandler.CreateDebtList)
+	router.POST("/api/v1/debts/quick", debtHandler.CreateQuickDebt)
+
+	post := func(path string, payload map[string]interface{}) *httptest.ResponseRecorder {
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	tests := []struct {
+		name         string
+		debtType     string
+		expectedType string
+	}{
+		{name: "current value", debtType: "to_pay", expectedType: entities.DebtTypeToPay},
+		{name: "legacy owed_to_me", debtType: "owed_to_me", expectedType: entities.DebtTypeToReceive},
+		{name: "legacy i_owe", debtType: "i_owe", expectedType: entities.DebtTypeToPay},
+		{name: "mixed case", debtType: "To_Receive", expectedType: entities.DebtTypeToReceive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := post("/api/v1/debts", map[string]interface{}{
+				"contact_id":   bob.ID,
+				"debt_type":    tt.debtType,
+				"total_amount": "100.00",
+				"currency":     "USD",
+			})
+			require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+			var response struct {
+				Data entities.DebtList `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			stored, err := debtListRepo.GetByID(ctx, response.Data.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedType, stored.DebtType)
+		})
+	}
+
+	t.Run("quick debt with a legacy value", func(t *testing.T) {
+		w := post("/api/v1/debts/quick", map[string]interface{}{
+			"contact_id": bob.ID,
+			"amount":     "20.00",
+			"debt_type":  "i_owe",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var response struct {
+			Data entities.DebtList `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		stored, err := debtListRepo.GetByID(ctx, response.Data.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.DebtTypeToPay, stored.DebtType)
+	})
+
+	t.Run("unknown value is rejected", func(t *testing.T) {
+		w := post("/api/v1/debts", map[string]interface{}{
+			"contact_id":   bob.ID,
+			"debt_type":    "to_borrow",
+			"total_amount": "100.00",
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "debt_type must be one of: to_receive, to_pay")
+
+		// Only the debts created above exist
+		var count int64
+		require.NoError(t, db.Model(&models.DebtList{}).Count(&count).Error)
+		assert.Equal(t, int64(len(tests)+1), count)
+	})
+}
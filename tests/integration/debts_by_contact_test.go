@@ -0,0 +1,147 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+)
+
+func TestGetDebtsByContact(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	aliceID := f.register("alice-grouped@example.com", "Alice")
+	bobID := f.register("bob-grouped@example.com", "Bob")
+
+	bobContactID := f.contactFor(aliceID, "bob-grouped@example.com")
+	carol, err := f.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{Name: "Carol"})
+	require.NoError(t, err)
+	dave, err := f.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{Name: "Dave"})
+	require.NoError(t, err)
+
+	newDebt := func(ownerID, contactID uuid.UUID, debtType, amount, currency string) *entities.DebtList {
+		debtList, err := f.debtService.CreateDebtList(ctx, ownerID, &entities.CreateDebtListRequest{
+			ContactID:       contactID,
+			DebtType:        debtType,
+			TotalAmount:     amount,
+			Currency:        currency,
+			InstallmentPlan: "onetime",
+			DueDate:         timePtr(time.Now().AddDate(0, 1, 0)),
+		})
+		require.NoError(t, err)
+		return debtList
+	}
+
+	// Alice lent Bob 100 USD and has been repaid 30, and owes him 10 EUR
+	lent := newDebt(aliceID, bobContactID, "to_receive", "100.00", "USD")
+	_, err = f.debtService.CreateDebtItem(ctx, aliceID, &entities.CreateDebtItemRequest{
+		DebtListID:    lent.ID,
+		Amount:        "30.00",
+		Currency:      "USD",
+		PaymentDate:   time.Now(),
+		PaymentMethod: "cash",
+	})
+	require.NoError(t, err)
+	borrowed := newDebt(aliceID, bobContactID, "to_pay", "10.00", "EUR")
+	// Bob recorded lending Alice 50 USD, which is grouped under Alice's contact for him
+	bobLent := newDebt(bobID, f.contactFor(bobID, "alice-grouped@example.com"), "to_receive", "50.00", "USD")
+	// Alice owes Carol 300 USD, the largest balance
+	owedToCarol := newDebt(aliceID, carol.ID, "to_pay", "300.00", "USD")
+	// Dave's only debt is settled, so nothing is owed either way
+	settled := newDebt(aliceID, dave.ID, "to_receive", "5.00", "USD")
+	_, err = f.debtService.CreateDebtItem(ctx, aliceID, &entities.CreateDebtItemRequest{
+		DebtListID:    settled.ID,
+		Amount:        "5.00",
+		Currency:      "USD",
+		PaymentDate:   time.Now(),
+		PaymentMethod: "cash",
+	})
+	require.NoError(t, err)
+
+	debtHandler := handlers.NewDebtHandler(f.debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		userID, err := uuid.Parse(c.GetHeader("X-Test-User"))
+		require.NoError(t, err)
+		c.Set("user_id", userID)
+	})
+	router.GET("/api/v1/debts/by-contact", debtHandler.GetDebtsByContact)
+
+	get := func(userID uuid.UUID) []entities.ContactDebts {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/debts/by-contact", nil)
+		req.Header.Set("X-Test-User", userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var body struct {
+			Data []entities.ContactDebts `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return body.Data
+	}
+	debtIDs := func(group entities.ContactDebts) []uuid.UUID {
+		ids := make([]uuid.UUID, len(group.Debts))
+		for i, debtList := range group.Debts {
+			ids[i] = debtList.ID
+		}
+		return ids
+	}
+
+	groups := get(aliceID)
+	require.Len(t, groups, 3)
+
+	// Groups are ordered by the size of their balance, largest first
+	assert.Equal(t, carol.ID, groups[0].Contact.ID)
+	assert.Equal(t, bobContactID, groups[1].Contact.ID)
+	assert.Equal(t, dave.ID, groups[2].Contact.ID)
+
+	assert.Equal(t, []uuid.UUID{owedToCarol.ID}, debtIDs(groups[0]))
+	require.Len(t, groups[0].NetBalances, 1)
+	assert.True(t, decimal.RequireFromString("-300.00").Equal(groups[0].NetBalances[0].Net), groups[0].NetBalances[0].Net.String())
+
+	// Bob's group holds Alice's debts with him and the one he recorded
+	bob := groups[1]
+	assert.ElementsMatch(t, []uuid.UUID{lent.ID, borrowed.ID, bobLent.ID}, debtIDs(bob))
+	require.Len(t, bob.NetBalances, 2)
+	eur, usd := bob.NetBalances[0], bob.NetBalances[1]
+	assert.Equal(t, "EUR", eur.Currency)
+	assert.True(t, decimal.RequireFromString("-10.00").Equal(eur.Net), eur.Net.String())
+	assert.Equal(t, "USD", usd.Currency)
+	assert.True(t, decimal.RequireFromString("70.00").Equal(usd.OwedToMe), usd.OwedToMe.String())
+	assert.True(t, decimal.RequireFromString("50.00").Equal(usd.IOwe), usd.IOwe.String())
+	assert.True(t, decimal.RequireFromString("20.00").Equal(usd.Net), usd.Net.String())
+	for _, debtList := range bob.Debts {
+		if debtList.ID == bobLent.ID {
+			assert.Equal(t, "to_pay", debtList.DebtType)
+		}
+	}
+
+	// Settled debts are listed but leave no balance
+	assert.Equal(t, []uuid.UUID{settled.ID}, debtIDs(groups[2]))
+	assert.Empty(t, groups[2].NetBalances)
+
+	// Bob sees the same debts from his side, under his contact for Alice
+	groups = get(bobID)
+	require.Len(t, groups, 1)
+	assert.ElementsMatch(t, []uuid.UUID{lent.ID, borrowed.ID, bobLent.ID}, debtIDs(groups[0]))
+	require.Len(t, groups[0].NetBalances, 2)
+	assert.True(t, decimal.RequireFromString("10.00").Equal(groups[0].NetBalances[0].Net), groups[0].NetBalances[0].Net.String())
+	assert.True(t, decimal.RequireFromString("-20.00").Equal(groups[0].NetBalances[1].Net), groups[0].NetBalances[1].Net.String())
+}
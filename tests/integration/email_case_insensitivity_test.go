@@ -0,0 +1,153 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"pay-your-dues/internal/database"
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/domain/interfaces"
+	"pay-your-dues/internal/models"
+	"pay-your-dues/internal/repository"
+	"pay-your-dues/internal/services"
+)
+
+func TestEmailCaseInsensitivity(t *testing.T) {
+	ctx := context.Background()
+
+	// setup opens a fresh database with repositories matching emails ignoring
+	// case or not
+	setup := func(t *testing.T, caseInsensitive bool) (*gorm.DB, interfaces.ContactRepository, interfaces.ContactService, interfaces.AuthService) {
+		db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		require.NoError(t, err)
+		require.NoError(t, db.AutoMigrate(&models.User{}, &models.Contact{}, &models.UserContact{}))
+
+		userRepo := repository.NewUserRepositoryGORM(db, repository.WithUserCaseInsensitiveEmails(caseInsensitive))
+		contactRepo := repository.NewContactRepositoryGORM(db, repository.WithContactCaseInsensitiveEmails(caseInsensitive))
+		contactService := services.NewContactService(contactRepo, userRepo)
+		authService, err := services.NewAuthService(userRepo, contactService, "test-secret", "24h")
+		require.NoError(t, err)
+		return db, contactRepo, contactService, authService
+	}
+	register := func(t *testing.T, authService interfaces.AuthService, email, firstName string) *entities.User {
+		resp, err := authService.Register(ctx, &entities.CreateUserRequest{
+			Email:     email,
+			Password:  "password123",
+			FirstName: firstName,
+			LastName:  "Casing",
+		})
+		require.NoError(t, err)
+		return &resp.User
+	}
+
+	t.Run("differently-cased contact email links to the user", func(t *testing.T) {
+		_, contactRepo, contactService, authService := setup(t, true)
+		alice := register(t, authService, "alice-case@example.com", "Alice")
+		bob := register(t, authService, "Bob.Case@Example.com", "Bob")
+		assert.Equal(t, "bob.case@example.com", bob.Email)
+
+		contact, err := contactService.CreateContact(ctx, alice.ID, &entities.CreateContactRequest{
+			Name:  "Bob",
+			Email: stringPtr("bob.CASE@example.COM"),
+		})
+		require.NoError(t, err)
+		assert.True(t, contact.IsUser)
+		require.NotNil(t, contact.UserIDRef)
+		assert.Equal(t, bob.ID, *contact.UserIDRef)
+		require.NotNil(t, contact.Email)
+		assert.Equal(t, "bob.case@example.com", *contact.Email)
+
+		// Bob got Alice back as a reciprocal contact
+		exists, err := contactRepo.ExistsByEmailForUser(ctx, bob.ID, "Alice-Case@Example.com")
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		// and a third casing of the same address is a duplicate
+		_, err = contactService.CreateContact(ctx, alice.ID, &entities.CreateContactRequest{
+			Name:  "Bob again",
+			Email: stringPtr("BOB.CASE@EXAMPLE.COM"),
+		})
+		var duplicate *entities.DuplicateContactError
+		require.ErrorAs(t, err, &duplicate)
+		assert.Equal(t, contact.ID, duplicate.ExistingContactID)
+	})
+
+	t.Run("registering a differently-cased email is a duplicate", func(t *testing.T) {
+		_, _, _, authService := setup(t, true)
+		register(t, authService, "carol-case@example.com", "Carol")
+
+		_, err := authService.Register(ctx, &entities.CreateUserRequest{
+			Email:     "Carol-Case@Example.com",
+			Password:  "password123",
+			FirstName: "Carol",
+			LastName:  "Again",
+		})
+		assert.ErrorIs(t, err, entities.ErrUserAlreadyExists)
+	})
+
+	t.Run("emails stored before normalization still match", func(t *testing.T) {
+		db, _, contactService, authService := setup(t, true)
+		alice := register(t, authService, "alice-legacy@example.com", "Alice")
+		legacy := models.User{
+			ID:           uuid.New(),
+			Email:        "Dave.Legacy@Example.com",
+			PasswordHash: "hash",
+			FirstName:    "Dave",
+			LastName:     "Legacy",
+		}
+		require.NoError(t, db.Create(&legacy).Error)
+
+		updated, err := database.NormalizeEmails(db)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), updated)
+		updated, err = database.NormalizeEmails(db)
+		require.NoError(t, err)
+		assert.Zero(t, updated)
+
+		contact, err := contactService.CreateContact(ctx, alice.ID, &entities.CreateContactRequest{
+			Name:  "Dave",
+			Email: stringPtr("dave.legacy@example.com"),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, contact.UserIDRef)
+		assert.Equal(t, legacy.ID, *contact.UserIDRef)
+	})
+
+	t.Run("emails differing only by case are not normalized", func(t *testing.T) {
+		db, _, _, _ := setup(t, true)
+		for _, email := range []string{"Frank.Twice@Example.com", "frank.twice@example.com"} {
+			require.NoError(t, db.Create(&models.User{
+				ID:           uuid.New(),
+				Email:        email,
+				PasswordHash: "hash",
+				FirstName:    "Frank",
+				LastName:     "Twice",
+			}).Error)
+		}
+
+		_, err := database.NormalizeEmails(db)
+		assert.Error(t, err)
+		var count int64
+		require.NoError(t, db.Model(&models.User{}).Where("email = ?", "Frank.Twice@Example.com").Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("case-sensitive emails are matched exactly", func(t *testing.T) {
+		_, _, contactService, authService := setup(t, false)
+		alice := register(t, authService, "alice-exact@example.com", "Alice")
+		register(t, authService, "Erin.Exact@Example.com", "Erin")
+
+		contact, err := contactService.CreateContact(ctx, alice.ID, &entities.CreateContactRequest{
+			Name:  "Erin",
+			Email: stringPtr("erin.exact@example.com"),
+		})
+		require.NoError(t, err)
+		assert.False(t, contact.IsUser)
+	})
+}
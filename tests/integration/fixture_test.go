@@ -0,0 +1,90 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/domain/interfaces"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+	"pay-your-dues/internal/repository"
+	"pay-your-dues/internal/services"
+)
+
+// testFixture is a fresh in-memory database with the repositories and services
+// most integration tests are built on
+type testFixture struct {
+	t *testing.T
+
+	db             *gorm.DB
+	userRepo       interfaces.UserRepository
+	contactRepo    interfaces.ContactRepository
+	debtListRepo   interfaces.DebtListRepository
+	debtItemRepo   interfaces.DebtItemRepository
+	contactService interfaces.ContactService
+	authService    interfaces.AuthService
+	debtService    interfaces.DebtService
+}
+
+// newTestFixture opens an in-memory database with users, contacts, debt lists
+// and payments migrated, along with extraModels, and wires the services over it
+func newTestFixture(t *testing.T, extraModels ...interface{}) *testFixture {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	migrated := []interface{}{&models.User{}, &models.Contact{}, &models.UserContact{}, &models.DebtList{}, &models.DebtItem{}, &models.Deletion{}}
+	require.NoError(t, db.AutoMigrate(append(migrated, extraModels...)...))
+
+	f := &testFixture{t: t, db: db}
+	f.userRepo = repository.NewUserRepositoryGORM(db)
+	f.contactRepo = repository.NewContactRepositoryGORM(db)
+	f.debtListRepo = repository.NewDebtListRepositoryGORM(db, f.contactRepo)
+	f.debtItemRepo = repository.NewDebtItemRepositoryGORM(db)
+	f.contactService = services.NewContactService(f.contactRepo, f.userRepo)
+	f.authService, err = services.NewAuthService(f.userRepo, f.contactService, "test-secret", "24h")
+	require.NoError(t, err)
+	f.debtService = f.newDebtService()
+	return f
+}
+
+// newDebtService creates another debt service over the fixture's repositories,
+// for tests that need it configured differently
+func (f *testFixture) newDebtService(opts ...services.DebtServiceOption) interfaces.DebtService {
+	return services.NewDebtService(f.debtListRepo, f.debtItemRepo, f.contactRepo, services.NewPaymentScheduleService(), &mocks.MockFileStorageService{}, opts...)
+}
+
+// register registers a user and returns their ID
+func (f *testFixture) register(email, firstName string) uuid.UUID {
+	resp, err := f.authService.Register(context.Background(), &entities.CreateUserRequest{
+		Email:     email,
+		Password:  "password123",
+		FirstName: firstName,
+		LastName:  "Tester",
+	})
+	require.NoError(f.t, err)
+	return resp.User.ID
+}
+
+// contactFor returns the owner's contact for email, which registration may
+// already have created as the reciprocal of the other user's contact
+func (f *testFixture) contactFor(ownerID uuid.UUID, email string) uuid.UUID {
+	ctx := context.Background()
+	userContacts, err := f.contactRepo.GetUserContactsByEmail(ctx, email)
+	require.NoError(f.t, err)
+	for _, uc := range userContacts {
+		if uc.UserID == ownerID {
+			return uc.ContactID
+		}
+	}
+	contact, err := f.contactService.CreateContact(ctx, ownerID, &entities.CreateContactRequest{
+		Name:  email,
+		Email: stringPtr(email),
+	})
+	require.NoError(f.t, err)
+	return contact.ID
+}
@@ -0,0 +1,91 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/domain/interfaces"
+	"pay-your-dues/internal/middleware"
+	"pay-your-dues/internal/repository"
+	"pay-your-dues/internal/services"
+)
+
+func TestJWTIssuerAndAudienceValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	contactService := services.NewContactService(repository.NewContactRepositoryGORM(f.db), f.userRepo)
+
+	// Services sharing the signing secret, as in a multi-service deployment
+	newAuthService := func(opts ...services.AuthServiceOption) interfaces.AuthService {
+		authService, err := services.NewAuthService(f.userRepo, contactService, "shared-secret", "24h", opts...)
+		require.NoError(t, err)
+		return authService
+	}
+	authService := newAuthService(services.WithJWTIssuerAndAudience("pay-your-dues", "pay-your-dues-api"))
+	otherAudience := newAuthService(services.WithJWTIssuerAndAudience("pay-your-dues", "reporting-api"))
+	otherIssuer := newAuthService(services.WithJWTIssuerAndAudience("identity-service", "pay-your-dues-api"))
+	unscoped := newAuthService()
+
+	_, err := authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "claims@example.com",
+		Password:  "password123",
+		FirstName: "Claims",
+		LastName:  "User",
+	})
+	require.NoError(t, err)
+	login := func(authService interfaces.AuthService) string {
+		resp, err := authService.Login(ctx, &entities.LoginRequest{Email: "claims@example.com", Password: "password123"})
+		require.NoError(t, err)
+		return resp.Token
+	}
+
+	authMiddleware := middleware.NewAuthMiddleware(authService, zerolog.Nop())
+	router := gin.New()
+	protected := router.Group("/api/v1")
+	protected.Use(authMiddleware.Authenticate())
+	protected.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	serve := func(token string) (int, string) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/health", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var body struct {
+			Error string `json:"error"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return w.Code, body.Error
+	}
+
+	// Tokens issued by this service for this audience are accepted
+	code, _ := serve(login(authService))
+	assert.Equal(t, http.StatusOK, code)
+
+	// Tokens for another audience, from another issuer, or without the claims are not
+	for name, token := range map[string]string{
+		"mismatched audience": login(otherAudience),
+		"mismatched issuer":   login(otherIssuer),
+		"missing claims":      login(unscoped),
+	} {
+		code, message := serve(token)
+		assert.Equal(t, http.StatusUnauthorized, code, name)
+		assert.Equal(t, "Invalid token", message, name)
+	}
+
+	// Without configured claims, any correctly signed token is still accepted
+	_, err = unscoped.ValidateTokenClaims(ctx, login(otherAudience))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,144 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+)
+
+func TestGetNextPayment_MatchesFirstUnpaidScheduleItem(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	register := func(email string) uuid.UUID {
+		resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+			Email:     email,
+			Password:  "password123",
+			FirstName: "Next",
+			LastName:  "Payment",
+		})
+		require.NoError(t, err)
+		return resp.User.ID
+	}
+	ownerID := register("owner-next@example.com")
+	debtorID := register("debtor-next@example.com")
+	strangerID := register("stranger-next@example.com")
+
+	contact, err := f.contactService.CreateContact(ctx, ownerID, &entities.CreateContactRequest{
+		Name:  "Debtor",
+		Email: stringPtr("debtor-next@example.com"),
+	})
+	require.NoError(t, err)
+
+	debtList, err := f.debtService.CreateDebtList(ctx, ownerID, &entities.CreateDebtListRequest{
+		ContactID:        contact.ID,
+		DebtType:         "to_receive",
+		TotalAmount:      "300.00",
+		Currency:         "USD",
+		InstallmentPlan:  "monthly",
+		NumberOfPayments: intPtr(3),
+	})
+	require.NoError(t, err)
+
+	recordPayment := func(amount string) {
+		_, err := f.debtService.CreateDebtItem(ctx, ownerID, &entities.CreateDebtItemRequest{
+			DebtListID:    debtList.ID,
+			Amount:        amount,
+			Currency:      "USD",
+			PaymentDate:   time.Now(),
+			PaymentMethod: "cash",
+		})
+		require.NoError(t, err)
+	}
+
+	debtHandler := handlers.NewDebtHandler(f.debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		userID, err := uuid.Parse(c.GetHeader("X-Test-User"))
+		require.NoError(t, err)
+		c.Set("user_id", userID)
+	})
+	router.GET("/api/v1/debts/:id/next-payment", debtHandler.GetNextPayment)
+
+	getNextPayment := func(userID uuid.UUID) (*httptest.ResponseRecorder, map[string]interface{}) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/debts/"+debtList.ID.String()+"/next-payment", nil)
+		req.Header.Set("X-Test-User", userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var responseBody map[string]interface{}
+		_ = json.Unmarshal(w.Body.Bytes(), &responseBody)
+		return w, responseBody
+	}
+	firstUnpaid := func() entities.PaymentScheduleItem {
+		schedule, err := f.debtService.GetPaymentSchedule(ctx, debtList.ID, ownerID)
+		require.NoError(t, err)
+		for _, item := range schedule {
+			if item.Status != "paid" {
+				return item
+			}
+		}
+		t.Fatal("schedule has no unpaid installment")
+		return entities.PaymentScheduleItem{}
+	}
+	assertMatches := func(data map[string]interface{}, item entities.PaymentScheduleItem) {
+		assert.Equal(t, float64(item.PaymentNumber), data["payment_number"])
+		assert.True(t, item.Amount.Equal(decimal.RequireFromString(data["amount"].(string))), data["amount"])
+		dueDate, err := time.Parse(time.RFC3339Nano, data["due_date"].(string))
+		require.NoError(t, err)
+		assert.True(t, item.DueDate.Equal(dueDate), data["due_date"])
+		assert.Equal(t, item.Status, data["status"])
+		assert.Equal(t, "USD", data["currency"])
+	}
+
+	// Nothing paid yet: the first installment is next
+	w, body := getNextPayment(ownerID)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	data := body["data"].(map[string]interface{})
+	assertMatches(data, firstUnpaid())
+	assert.Equal(t, float64(1), data["payment_number"])
+	assert.Equal(t, "to_receive", data["debt_type"])
+
+	// A partial payment covers the first installment and part of the second
+	recordPayment("150.00")
+	item := firstUnpaid()
+	assert.Equal(t, 2, item.PaymentNumber)
+
+	w, body = getNextPayment(ownerID)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assertMatches(body["data"].(map[string]interface{}), item)
+	assert.True(t, decimal.RequireFromString("50").Equal(item.Amount), item.Amount.String())
+
+	// The contact sees the same installment from their perspective
+	w, body = getNextPayment(debtorID)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	data = body["data"].(map[string]interface{})
+	assertMatches(data, item)
+	assert.Equal(t, "to_pay", data["debt_type"])
+
+	// Users unrelated to the debt cannot see it
+	w, _ = getNextPayment(strangerID)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// Once fully paid there is no next payment
+	recordPayment("150.00")
+	w, body = getNextPayment(ownerID)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Nil(t, body["data"])
+}
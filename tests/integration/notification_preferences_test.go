@@ -0,0 +1,125 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/models"
+	"pay-your-dues/internal/repository"
+	"pay-your-dues/internal/services"
+)
+
+func TestNotificationPreferences(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.UserSettings{}))
+
+	userSettingsRepo := repository.NewUserSettingsRepositoryGORM(db)
+	userSettingsService := services.NewUserSettingsService(userSettingsRepo)
+	userSettingsHandler := handlers.NewUserSettingsHandler(userSettingsService, zerolog.Nop())
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		userID, err := uuid.Parse(c.GetHeader("X-Test-User"))
+		require.NoError(t, err)
+		c.Set("user_id", userID)
+	})
+	router.GET("/api/v1/auth/notifications", userSettingsHandler.GetNotificationPreferences)
+	router.PUT("/api/v1/auth/notifications", userSettingsHandler.UpdateNotificationPreferences)
+	router.PUT("/api/v1/auth/settings", userSettingsHandler.UpdateUserSettings)
+
+	send := func(method, path string, userID uuid.UUID, body string) (int, entities.NotificationPreferences) {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Test-User", userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response struct {
+			Data entities.NotificationPreferences `json:"data"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return w.Code, response.Data
+	}
+	get := func(userID uuid.UUID) (int, entities.NotificationPreferences) {
+		return send(http.MethodGet, "/api/v1/auth/notifications", userID, "")
+	}
+	update := func(userID uuid.UUID, body string) (int, entities.NotificationPreferences) {
+		return send(http.MethodPut, "/api/v1/auth/notifications", userID, body)
+	}
+	defaults := entities.NotificationPreferences{
+		EmailReminders:     true,
+		ReminderLeadDays:   3,
+		OverdueAlerts:      true,
+		VerificationAlerts: true,
+	}
+
+	userID := uuid.New()
+
+	// A user who never saved preferences gets the defaults
+	code, preferences := get(userID)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, defaults, preferences)
+
+	// Turning things off and reminding on the due date itself are saved as given
+	code, preferences = update(userID, `{"email_reminders": false, "reminder_lead_days": 0, "overdue_alerts": false}`)
+	require.Equal(t, http.StatusOK, code)
+	expected := entities.NotificationPreferences{
+		EmailReminders:     false,
+		ReminderLeadDays:   0,
+		OverdueAlerts:      false,
+		VerificationAlerts: true,
+	}
+	assert.Equal(t, expected, preferences)
+
+	code, preferences = get(userID)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, expected, preferences)
+
+	// Omitted fields keep their saved values
+	code, preferences = update(userID, `{"reminder_lead_days": 7}`)
+	require.Equal(t, http.StatusOK, code)
+	expected.ReminderLeadDays = 7
+	assert.Equal(t, expected, preferences)
+
+	// Updating other settings leaves the preferences alone
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/auth/settings", bytes.NewBufferString(`{"default_installment_plan": "monthly"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Test-User", userID.String())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	stored, err := userSettingsService.GetUserSettings(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, expected, stored.ToNotificationPreferences())
+	assert.Equal(t, "monthly", stored.DefaultInstallmentPlan)
+
+	// Lead days are bounded
+	code, _ = update(userID, `{"reminder_lead_days": 31}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = update(userID, `{"reminder_lead_days": -1}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// Other users are unaffected
+	code, preferences = get(uuid.New())
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, defaults, preferences)
+}
@@ -0,0 +1,138 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+	"pay-your-dues/internal/repository"
+	"pay-your-dues/internal/services"
+)
+
+func TestAcknowledgeOverdue(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t, &models.UserSettings{})
+
+	userSettingsRepo := repository.NewUserSettingsRepositoryGORM(f.db)
+	debtService := f.newDebtService(services.WithUserSettingsRepository(userSettingsRepo))
+
+	resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "lender-acknowledge@example.com",
+		Password:  "password123",
+		FirstName: "Acknowledge",
+		LastName:  "Lender",
+	})
+	require.NoError(t, err)
+	lenderID := resp.User.ID
+
+	contact, err := f.contactService.CreateContact(ctx, lenderID, &entities.CreateContactRequest{Name: "Late Payer"})
+	require.NoError(t, err)
+	debtList, err := debtService.CreateDebtList(ctx, lenderID, &entities.CreateDebtListRequest{
+		ContactID:       contact.ID,
+		DebtType:        "to_receive",
+		TotalAmount:     "100.00",
+		Currency:        "USD",
+		InstallmentPlan: "onetime",
+		DueDate:         timePtr(time.Now().AddDate(0, 0, 7)),
+	})
+	require.NoError(t, err)
+
+	// Move the debt's schedule into the past so it becomes overdue
+	pastDue := time.Now().AddDate(0, 0, -3)
+	require.NoError(t, f.db.Model(&models.DebtList{}).Where("id = ?", debtList.ID).Updates(map[string]interface{}{
+		"due_date":          pastDue,
+		"next_payment_date": pastDue,
+		"created_at":        pastDue.AddDate(0, 0, -10),
+	}).Error)
+	recomputed, err := debtService.RecomputeDebtList(ctx, debtList.ID, lenderID)
+	require.NoError(t, err)
+	require.Equal(t, "overdue", recomputed.Status)
+
+	debtHandler := handlers.NewDebtHandler(debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		userID, err := uuid.Parse(c.GetHeader("X-Test-User"))
+		require.NoError(t, err)
+		c.Set("user_id", userID)
+	})
+	router.GET("/api/v1/debts/overdue", debtHandler.GetOverdueItems)
+	router.POST("/api/v1/debts/overdue/acknowledge", debtHandler.AcknowledgeOverdue)
+
+	type overdueResponse struct {
+		Data []entities.DebtList     `json:"data"`
+		Meta handlers.PaginationMeta `json:"meta"`
+	}
+	listOverdue := func() overdueResponse {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/debts/overdue", nil)
+		req.Header.Set("X-Test-User", lenderID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var body overdueResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return body
+	}
+	acknowledge := func() entities.OverdueAcknowledgment {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/debts/overdue/acknowledge", nil)
+		req.Header.Set("X-Test-User", lenderID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var body struct {
+			Data entities.OverdueAcknowledgment `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return body.Data
+	}
+
+	// Nothing has been acknowledged yet
+	listing := listOverdue()
+	require.Len(t, listing.Data, 1)
+	assert.Nil(t, listing.Meta.AcknowledgedAt)
+
+	before := time.Now()
+	first := acknowledge()
+	assert.False(t, first.AcknowledgedAt.Before(before))
+
+	// The acknowledgment is stored against the user
+	settings, err := userSettingsRepo.GetByUserID(ctx, lenderID)
+	require.NoError(t, err)
+	require.NotNil(t, settings.OverdueAcknowledgedAt)
+	assert.True(t, first.AcknowledgedAt.Equal(*settings.OverdueAcknowledgedAt))
+
+	// The listing returns it and still includes the debt, whose status is unchanged
+	listing = listOverdue()
+	require.Len(t, listing.Data, 1)
+	assert.Equal(t, debtList.ID, listing.Data[0].ID)
+	assert.Equal(t, "overdue", listing.Data[0].Status)
+	require.NotNil(t, listing.Meta.AcknowledgedAt)
+	assert.True(t, first.AcknowledgedAt.Equal(*listing.Meta.AcknowledgedAt))
+
+	// Acknowledging again moves the timestamp forward
+	second := acknowledge()
+	assert.False(t, second.AcknowledgedAt.Before(first.AcknowledgedAt))
+	listing = listOverdue()
+	require.NotNil(t, listing.Meta.AcknowledgedAt)
+	assert.True(t, second.AcknowledgedAt.Equal(*listing.Meta.AcknowledgedAt))
+
+	stored, err := f.debtListRepo.GetByID(ctx, debtList.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "overdue", stored.Status)
+}
@@ -0,0 +1,133 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/models"
+	"pay-your-dues/internal/services"
+)
+
+func TestOverdueEventFiresOncePerTransition(t *testing.T) {
+	ctx := context.Background()
+
+	// The webhook records every event it receives and can be told to fail
+	var (
+		mu       sync.Mutex
+		received []entities.DebtEvent
+		failing  bool
+	)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event entities.DebtEvent
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		assert.Equal(t, entities.DebtEventOverdue, r.Header.Get("X-Event-Type"))
+
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, event)
+		if failing {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer webhook.Close()
+	events := func() []entities.DebtEvent {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]entities.DebtEvent(nil), received...)
+	}
+
+	f := newTestFixture(t)
+
+	debtService := f.newDebtService(services.WithEventPublisher(services.NewWebhookEventPublisher(webhook.URL, time.Second)))
+
+	resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "lender-overdue-event@example.com",
+		Password:  "password123",
+		FirstName: "Overdue",
+		LastName:  "Lender",
+	})
+	require.NoError(t, err)
+	lenderID := resp.User.ID
+
+	contact, err := f.contactService.CreateContact(ctx, lenderID, &entities.CreateContactRequest{Name: "Late Payer"})
+	require.NoError(t, err)
+
+	newDebt := func() *entities.DebtList {
+		debtList, err := debtService.CreateDebtList(ctx, lenderID, &entities.CreateDebtListRequest{
+			ContactID:       contact.ID,
+			DebtType:        "to_receive",
+			TotalAmount:     "100.00",
+			Currency:        "USD",
+			InstallmentPlan: "onetime",
+			DueDate:         timePtr(time.Now().AddDate(0, 0, 7)),
+		})
+		require.NoError(t, err)
+		return debtList
+	}
+	// Move the debt's schedule into the past, as if time had passed
+	expire := func(debtListID uuid.UUID) {
+		pastDue := time.Now().AddDate(0, 0, -3)
+		require.NoError(t, f.db.Model(&models.DebtList{}).Where("id = ?", debtListID).Updates(map[string]interface{}{
+			"due_date":          pastDue,
+			"next_payment_date": pastDue,
+			"created_at":        pastDue.AddDate(0, 0, -10),
+		}).Error)
+	}
+	recompute := func(debtListID uuid.UUID) string {
+		debtList, err := debtService.RecomputeDebtList(ctx, debtListID, lenderID)
+		require.NoError(t, err)
+		return debtList.Status
+	}
+
+	debtList := newDebt()
+
+	// Recalculating a debt that is not due yet emits nothing
+	assert.Equal(t, "active", recompute(debtList.ID))
+	assert.Empty(t, events())
+
+	// Becoming overdue emits exactly one event across repeated recalculations
+	expire(debtList.ID)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, "overdue", recompute(debtList.ID))
+	}
+	require.Len(t, events(), 1)
+	event := events()[0]
+	assert.Equal(t, entities.DebtEventOverdue, event.Type)
+	assert.Equal(t, debtList.ID, event.DebtListID)
+	assert.Equal(t, lenderID, event.UserID)
+	assert.Equal(t, contact.ID, event.ContactID)
+	assert.Equal(t, "to_receive", event.DebtType)
+	assert.Equal(t, "USD", event.Currency)
+	assert.True(t, decimal.RequireFromString("100.00").Equal(event.RemainingDebt), event.RemainingDebt.String())
+
+	// Payments recorded while overdue do not announce it again
+	_, err = debtService.CreateDebtItem(ctx, lenderID, &entities.CreateDebtItemRequest{
+		DebtListID:    debtList.ID,
+		Amount:        "40.00",
+		Currency:      "USD",
+		PaymentDate:   time.Now(),
+		PaymentMethod: "cash",
+	})
+	require.NoError(t, err)
+	assert.Len(t, events(), 1)
+
+	// A failed delivery is not an error for the recalculation that triggered it
+	mu.Lock()
+	failing = true
+	mu.Unlock()
+	other := newDebt()
+	expire(other.ID)
+	assert.Equal(t, "overdue", recompute(other.ID))
+	assert.Len(t, events(), 2)
+}
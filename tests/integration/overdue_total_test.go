@@ -0,0 +1,139 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+)
+
+func TestGetOverdueTotals(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	register := func(email string) uuid.UUID {
+		resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+			Email:     email,
+			Password:  "password123",
+			FirstName: "Overdue",
+			LastName:  "User",
+		})
+		require.NoError(t, err)
+		return resp.User.ID
+	}
+	aliceID := register("alice-overdue@example.com")
+	bobID := register("bob-overdue@example.com")
+
+	offline, err := f.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{Name: "Cash Only Carl"})
+	require.NoError(t, err)
+	bob, err := f.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{
+		Name:  "Bob",
+		Email: stringPtr("bob-overdue@example.com"),
+	})
+	require.NoError(t, err)
+
+	// Adding Bob as a contact gave him a reciprocal contact for Alice
+	var aliceForBob uuid.UUID
+	userContacts, err := f.contactRepo.GetUserContactsByEmail(ctx, "alice-overdue@example.com")
+	require.NoError(t, err)
+	for _, uc := range userContacts {
+		if uc.UserID == bobID {
+			aliceForBob = uc.ContactID
+		}
+	}
+	require.NotEqual(t, uuid.Nil, aliceForBob)
+
+	newDebtList := func(ownerID, contactID uuid.UUID, debtType, currency, remaining, status string, nextPaymentDate time.Time) {
+		debtList := models.DebtList{
+			ID:                 uuid.New(),
+			UserID:             ownerID,
+			ContactID:          contactID,
+			DebtType:           debtType,
+			TotalAmount:        decimal.RequireFromString("1000.00"),
+			InstallmentAmount:  decimal.RequireFromString("100.00"),
+			TotalRemainingDebt: decimal.RequireFromString(remaining),
+			Currency:           currency,
+			Status:             status,
+			DueDate:            time.Now().AddDate(0, 6, 0),
+			NextPaymentDate:    nextPaymentDate,
+			InstallmentPlan:    "monthly",
+		}
+		require.NoError(t, f.db.Create(&debtList).Error)
+	}
+	lastWeek := time.Now().AddDate(0, 0, -7)
+	nextWeek := time.Now().AddDate(0, 0, 7)
+
+	// Alice is owed money in two currencies
+	newDebtList(aliceID, offline.ID, "to_receive", "USD", "150.00", "active", lastWeek)
+	newDebtList(aliceID, bob.ID, "to_receive", "USD", "50.25", "overdue", lastWeek)
+	newDebtList(aliceID, offline.ID, "to_receive", "EUR", "80.00", "active", lastWeek)
+	// and owes Carl in USD
+	newDebtList(aliceID, offline.ID, "to_pay", "USD", "30.00", "overdue", lastWeek)
+	// Bob recorded that he lent to Alice, so from her side she owes it
+	newDebtList(bobID, aliceForBob, "to_receive", "PHP", "1200.00", "active", lastWeek)
+	// Debts that are not overdue are left out
+	newDebtList(aliceID, offline.ID, "to_receive", "USD", "999.00", "active", nextWeek)
+	newDebtList(aliceID, offline.ID, "to_receive", "USD", "0", "settled", lastWeek)
+
+	debtHandler := handlers.NewDebtHandler(f.debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		userID, err := uuid.Parse(c.GetHeader("X-Test-User"))
+		require.NoError(t, err)
+		c.Set("user_id", userID)
+	})
+	router.GET("/api/v1/debts/overdue/total", debtHandler.GetOverdueTotals)
+
+	getTotals := func(userID uuid.UUID) []entities.OverdueTotal {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/debts/overdue/total", nil)
+		req.Header.Set("X-Test-User", userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var body struct {
+			Data []entities.OverdueTotal `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return body.Data
+	}
+	assertTotal := func(total entities.OverdueTotal, currency, owedToMe, iOwe string, count int) {
+		t.Helper()
+		assert.Equal(t, currency, total.Currency)
+		assert.True(t, decimal.RequireFromString(owedToMe).Equal(total.OwedToMe), "%s owed to me: %s", currency, total.OwedToMe)
+		assert.True(t, decimal.RequireFromString(iOwe).Equal(total.IOwe), "%s i owe: %s", currency, total.IOwe)
+		assert.Equal(t, count, total.Count, currency)
+	}
+
+	totals := getTotals(aliceID)
+	require.Len(t, totals, 3)
+	assertTotal(totals[0], "EUR", "80.00", "0", 1)
+	assertTotal(totals[1], "PHP", "0", "1200.00", 1)
+	assertTotal(totals[2], "USD", "200.25", "30.00", 3)
+
+	// Bob sees his side of both debts he is part of
+	totals = getTotals(bobID)
+	require.Len(t, totals, 2)
+	assertTotal(totals[0], "PHP", "1200.00", "0", 1)
+	assertTotal(totals[1], "USD", "0", "50.25", 1)
+
+	// A user with nothing overdue gets an empty list
+	carolID := register("carol-overdue@example.com")
+	assert.Empty(t, getTotals(carolID))
+}
@@ -0,0 +1,102 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/models"
+)
+
+func TestPaymentAdjustments_ReduceTotalPaymentsMade(t *testing.T) {
+	ctx := context.Background()
+
+	f := newTestFixture(t, &models.DebtBalance{})
+
+	owner, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "owner-adjustment@example.com",
+		Password:  "password123",
+		FirstName: "Adjustment",
+		LastName:  "Owner",
+	})
+	require.NoError(t, err)
+	ownerID := owner.User.ID
+
+	contact, err := f.contactService.CreateContact(ctx, ownerID, &entities.CreateContactRequest{
+		Name: "Borrower",
+	})
+	require.NoError(t, err)
+
+	debtList, err := f.debtService.CreateDebtList(ctx, ownerID, &entities.CreateDebtListRequest{
+		ContactID:   contact.ID,
+		DebtType:    "to_receive",
+		TotalAmount: "100.00",
+		Currency:    "USD",
+		DueDate:     timePtr(time.Now().AddDate(0, 1, 0)),
+	})
+	require.NoError(t, err)
+
+	record := func(amount, paymentType string) (*entities.DebtItem, error) {
+		return f.debtService.CreateDebtItem(ctx, ownerID, &entities.CreateDebtItemRequest{
+			DebtListID:    debtList.ID,
+			Amount:        amount,
+			Currency:      "USD",
+			PaymentDate:   time.Now(),
+			PaymentMethod: "cash",
+			PaymentType:   paymentType,
+		})
+	}
+	assertTotals := func(paid, remaining, status string) {
+		stored, err := f.debtListRepo.GetByID(ctx, debtList.ID)
+		require.NoError(t, err)
+		assert.True(t, decimal.RequireFromString(paid).Equal(stored.TotalPaymentsMade), stored.TotalPaymentsMade.String())
+		assert.True(t, decimal.RequireFromString(remaining).Equal(stored.TotalRemainingDebt), stored.TotalRemainingDebt.String())
+		assert.Equal(t, status, stored.Status)
+	}
+
+	// The borrower overpays and the settled debt is partly refunded
+	payment, err := record("120.00", "")
+	require.NoError(t, err)
+	assert.Equal(t, entities.PaymentTypePayment, payment.PaymentType)
+	assertTotals("120.00", "0", "settled")
+
+	refund, err := record("-30.00", entities.PaymentTypeAdjustment)
+	require.NoError(t, err)
+	assert.Equal(t, entities.PaymentTypeAdjustment, refund.PaymentType)
+	assert.True(t, decimal.RequireFromString("-30.00").Equal(refund.Amount))
+	assertTotals("90.00", "10.00", "active")
+
+	stored, err := f.debtItemRepo.GetByID(ctx, refund.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.PaymentTypeAdjustment, stored.PaymentType)
+
+	// Adjustments can never take the amount paid below zero
+	_, err = record("-500.00", entities.PaymentTypeAdjustment)
+	require.NoError(t, err)
+	assertTotals("0", "100.00", "active")
+
+	// The payment summary agrees, and counts only the one real payment
+	summary, err := f.debtService.GetTotalPaymentsForDebtList(ctx, debtList.ID, ownerID)
+	require.NoError(t, err)
+	assert.True(t, decimal.Zero.Equal(summary.TotalPaid), summary.TotalPaid.String())
+	assert.True(t, decimal.RequireFromString("100.00").Equal(summary.RemainingDebt), summary.RemainingDebt.String())
+	assert.True(t, decimal.Zero.Equal(summary.PercentagePaid), summary.PercentagePaid.String())
+	assert.Equal(t, 1, summary.NumberOfPayments)
+	assert.Len(t, summary.Payments, 3)
+
+	// Only adjustments may be negative, and no payment may be zero
+	_, err = record("-10.00", entities.PaymentTypePayment)
+	assert.ErrorIs(t, err, entities.ErrInvalidAmount)
+	_, err = record("-10.00", "")
+	assert.ErrorIs(t, err, entities.ErrInvalidAmount)
+	_, err = record("0", entities.PaymentTypeAdjustment)
+	assert.ErrorIs(t, err, entities.ErrInvalidAmount)
+	_, err = record("10.00", "gift")
+	assert.ErrorIs(t, err, entities.ErrInvalidPaymentType)
+	assertTotals("0", "100.00", "active")
+}
@@ -0,0 +1,172 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+	"pay-your-dues/internal/services"
+)
+
+func TestAutoMatchPayment(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	autoVerifying := f.newDebtService(services.WithAutoVerifyMatches(true), services.WithAutoMatchTolerance(decimal.RequireFromString("0.50")))
+	reportOnly := f.newDebtService()
+
+	aliceID := f.register("alice-match@example.com", "Alice")
+	bobID := f.register("bob-match@example.com", "Bob")
+
+	// Alice lent Bob 300 USD, repaid in three monthly installments of 100
+	debtList, err := reportOnly.CreateDebtList(ctx, aliceID, &entities.CreateDebtListRequest{
+		ContactID:        f.contactFor(aliceID, "bob-match@example.com"),
+		DebtType:         "to_receive",
+		TotalAmount:      "300.00",
+		Currency:         "USD",
+		InstallmentPlan:  "monthly",
+		NumberOfPayments: intPtr(3),
+	})
+	require.NoError(t, err)
+
+	// Payments Bob records are pending until Alice verifies them
+	bobPays := func(amount, currency string) uuid.UUID {
+		payment, err := reportOnly.CreateDebtItem(ctx, bobID, &entities.CreateDebtItemRequest{
+			DebtListID:    debtList.ID,
+			Amount:        amount,
+			Currency:      currency,
+			PaymentDate:   time.Now(),
+			PaymentMethod: "bank_transfer",
+		})
+		require.NoError(t, err)
+		require.Equal(t, entities.PaymentStatusPending, payment.Status)
+		return payment.ID
+	}
+	statusOf := func(id uuid.UUID) string {
+		payment, err := f.debtItemRepo.GetByID(ctx, id)
+		require.NoError(t, err)
+		return payment.Status
+	}
+	totalPaid := func() decimal.Decimal {
+		stored, err := f.debtListRepo.GetByID(ctx, debtList.ID)
+		require.NoError(t, err)
+		return stored.TotalPaymentsMade
+	}
+
+	t.Run("mismatched amount is left for manual review", func(t *testing.T) {
+		paymentID := bobPays("60.00", "USD")
+
+		match, err := autoVerifying.AutoMatchPayment(ctx, paymentID, aliceID)
+		require.NoError(t, err)
+		assert.False(t, match.Matched)
+		assert.False(t, match.AutoVerified)
+		assert.True(t, decimal.RequireFromString("100.00").Equal(match.ExpectedAmount), match.ExpectedAmount.String())
+		assert.Equal(t, 1, match.PaymentNumber)
+		assert.Equal(t, entities.PaymentStatusPending, statusOf(paymentID))
+		assert.True(t, decimal.Zero.Equal(totalPaid()))
+
+		_, err = reportOnly.RejectDebtItem(ctx, paymentID, aliceID, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("matching amount in another currency is left for manual review", func(t *testing.T) {
+		debtItem, err := reportOnly.CreateDebtItem(ctx, bobID, &entities.CreateDebtItemRequest{
+			DebtListID:    debtList.ID,
+			Amount:        "100.00",
+			Currency:      "USD",
+			PaymentDate:   time.Now(),
+			PaymentMethod: "cash",
+		})
+		require.NoError(t, err)
+		require.NoError(t, f.db.Model(&models.DebtItem{}).Where("id = ?", debtItem.ID).Update("currency", "EUR").Error)
+
+		match, err := autoVerifying.AutoMatchPayment(ctx, debtItem.ID, aliceID)
+		require.NoError(t, err)
+		assert.False(t, match.Matched)
+		assert.Equal(t, entities.PaymentStatusPending, statusOf(debtItem.ID))
+
+		_, err = reportOnly.RejectDebtItem(ctx, debtItem.ID, aliceID, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("match is only reported unless auto-verification is enabled", func(t *testing.T) {
+		paymentID := bobPays("100.00", "USD")
+
+		match, err := reportOnly.AutoMatchPayment(ctx, paymentID, aliceID)
+		require.NoError(t, err)
+		assert.True(t, match.Matched)
+		assert.False(t, match.AutoVerified)
+		assert.Equal(t, entities.PaymentStatusPending, statusOf(paymentID))
+
+		_, err = reportOnly.RejectDebtItem(ctx, paymentID, aliceID, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("exact match is verified automatically", func(t *testing.T) {
+		paymentID := bobPays("100.00", "USD")
+
+		// Bob cannot check his own payment
+		_, err := autoVerifying.AutoMatchPayment(ctx, paymentID, bobID)
+		assert.ErrorIs(t, err, entities.ErrDebtItemNotFound)
+
+		match, err := autoVerifying.AutoMatchPayment(ctx, paymentID, aliceID)
+		require.NoError(t, err)
+		assert.True(t, match.Matched)
+		assert.True(t, match.AutoVerified)
+		assert.Equal(t, 1, match.PaymentNumber)
+		require.NotNil(t, match.Payment)
+		assert.Equal(t, entities.PaymentStatusCompleted, match.Payment.Status)
+		require.NotNil(t, match.Payment.VerifiedBy)
+		assert.Equal(t, aliceID, *match.Payment.VerifiedBy)
+		assert.Equal(t, entities.PaymentStatusCompleted, statusOf(paymentID))
+		assert.True(t, decimal.RequireFromString("100.00").Equal(totalPaid()), totalPaid().String())
+
+		// A verified payment cannot be matched again
+		_, err = autoVerifying.AutoMatchPayment(ctx, paymentID, aliceID)
+		assert.ErrorIs(t, err, entities.ErrPaymentAlreadyProcessed)
+	})
+
+	t.Run("amount within tolerance matches the next installment", func(t *testing.T) {
+		paymentID := bobPays("99.75", "USD")
+
+		match, err := autoVerifying.AutoMatchPayment(ctx, paymentID, aliceID)
+		require.NoError(t, err)
+		assert.True(t, match.Matched)
+		assert.True(t, match.AutoVerified)
+		assert.Equal(t, 2, match.PaymentNumber)
+		assert.Equal(t, entities.PaymentStatusCompleted, statusOf(paymentID))
+	})
+
+	t.Run("endpoint reports processed payments as conflicts", func(t *testing.T) {
+		paymentID := bobPays("50.00", "USD")
+		_, err := reportOnly.RejectDebtItem(ctx, paymentID, aliceID, nil)
+		require.NoError(t, err)
+
+		debtHandler := handlers.NewDebtHandler(autoVerifying, &mocks.MockFileStorageService{}, zerolog.Nop())
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("user_id", aliceID)
+		})
+		router.POST("/api/v1/debts/payments/:id/auto-match", debtHandler.AutoMatchPayment)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/debts/payments/"+paymentID.String()+"/auto-match", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
@@ -0,0 +1,155 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/database"
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+)
+
+func TestDisputePayment(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t, &models.UserSettings{}, &models.DebtProposal{})
+
+	require.NoError(t, database.RefreshCheckConstraints(f.db))
+
+	register := func(email string) uuid.UUID {
+		resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+			Email:     email,
+			Password:  "password123",
+			FirstName: "Dispute",
+			LastName:  "User",
+		})
+		require.NoError(t, err)
+		return resp.User.ID
+	}
+	creditorID := register("creditor-dispute@example.com")
+	debtorID := register("debtor-dispute@example.com")
+
+	contact, err := f.contactService.CreateContact(ctx, creditorID, &entities.CreateContactRequest{
+		Name:  "Debtor",
+		Email: stringPtr("debtor-dispute@example.com"),
+	})
+	require.NoError(t, err)
+
+	debtList, err := f.debtService.CreateDebtList(ctx, creditorID, &entities.CreateDebtListRequest{
+		ContactID:   contact.ID,
+		DebtType:    "to_receive",
+		TotalAmount: "100.00",
+		Currency:    "USD",
+		DueDate:     timePtr(time.Now().AddDate(0, 1, 0)),
+	})
+	require.NoError(t, err)
+
+	// Payments recorded by the debtor wait for the creditor's verification
+	recordPayment := func(amount string) *entities.DebtItem {
+		payment, err := f.debtService.CreateDebtItem(ctx, debtorID, &entities.CreateDebtItemRequest{
+			DebtListID:    debtList.ID,
+			Amount:        amount,
+			Currency:      "USD",
+			PaymentDate:   time.Now(),
+			PaymentMethod: "bank_transfer",
+		})
+		require.NoError(t, err)
+		require.Equal(t, entities.PaymentStatusPending, payment.Status)
+		return payment
+	}
+	disputed := recordPayment("40.00")
+	verified := recordPayment("25.00")
+
+	debtHandler := handlers.NewDebtHandler(f.debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		userID, err := uuid.Parse(c.GetHeader("X-Test-User"))
+		require.NoError(t, err)
+		c.Set("user_id", userID)
+	})
+	router.POST("/api/v1/debts/payments/:id/dispute", debtHandler.DisputeDebtItem)
+	router.POST("/api/v1/debts/payments/:id/verify", debtHandler.VerifyDebtItem)
+
+	post := func(userID uuid.UUID, path string, body interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+		payload, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Test-User", userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var responseBody map[string]interface{}
+		_ = json.Unmarshal(w.Body.Bytes(), &responseBody)
+		return w, responseBody
+	}
+	disputePath := "/api/v1/debts/payments/" + disputed.ID.String() + "/dispute"
+
+	// A reason is required
+	w, _ := post(creditorID, disputePath, map[string]interface{}{})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// The debtor cannot dispute their own payment
+	w, _ = post(debtorID, disputePath, map[string]interface{}{"reason": "Not received"})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// The creditor flags the payment as disputed
+	w, body := post(creditorID, disputePath, map[string]interface{}{"reason": "Transfer not showing in my account"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	data := body["data"].(map[string]interface{})
+	assert.Equal(t, entities.PaymentStatusDisputed, data["Status"])
+
+	stored, err := f.debtItemRepo.GetByID(ctx, disputed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.PaymentStatusDisputed, stored.Status)
+	require.NotNil(t, stored.VerificationNotes)
+	assert.Equal(t, "Transfer not showing in my account", *stored.VerificationNotes)
+	assert.Nil(t, stored.VerifiedBy, "a disputed payment is not verified yet")
+	assert.Nil(t, stored.VerifiedAt, "a disputed payment is not verified yet")
+
+	// Disputing twice conflicts
+	w, _ = post(creditorID, disputePath, map[string]interface{}{"reason": "Still missing"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// Verifying the other payment updates totals without counting the disputed one
+	w, _ = post(creditorID, "/api/v1/debts/payments/"+verified.ID.String()+"/verify", map[string]interface{}{"status": "completed"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	totals, err := f.debtListRepo.GetByID(ctx, debtList.ID)
+	require.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("25.00").Equal(totals.TotalPaymentsMade), totals.TotalPaymentsMade.String())
+	assert.True(t, decimal.RequireFromString("75.00").Equal(totals.TotalRemainingDebt), totals.TotalRemainingDebt.String())
+
+	// Once resolved, the disputed payment can still be verified and then counts
+	w, _ = post(creditorID, "/api/v1/debts/payments/"+disputed.ID.String()+"/verify", map[string]interface{}{"status": "completed"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	stored, err = f.debtItemRepo.GetByID(ctx, disputed.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.VerifiedBy)
+	assert.Equal(t, creditorID, *stored.VerifiedBy)
+	assert.NotNil(t, stored.VerifiedAt)
+
+	totals, err = f.debtListRepo.GetByID(ctx, debtList.ID)
+	require.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("65.00").Equal(totals.TotalPaymentsMade), totals.TotalPaymentsMade.String())
+	assert.True(t, decimal.RequireFromString("35.00").Equal(totals.TotalRemainingDebt), totals.TotalRemainingDebt.String())
+
+	// A completed payment can no longer be disputed
+	w, _ = post(creditorID, disputePath, map[string]interface{}{"reason": "Changed my mind"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
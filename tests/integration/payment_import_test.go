@@ -0,0 +1,164 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+)
+
+func TestImportPaymentsFromCSV(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "lender-import@example.com",
+		Password:  "password123",
+		FirstName: "Import",
+		LastName:  "Lender",
+	})
+	require.NoError(t, err)
+	lenderID := resp.User.ID
+
+	contact, err := f.contactService.CreateContact(ctx, lenderID, &entities.CreateContactRequest{Name: "Borrower"})
+	require.NoError(t, err)
+	debtList, err := f.debtService.CreateDebtList(ctx, lenderID, &entities.CreateDebtListRequest{
+		ContactID:   contact.ID,
+		DebtType:    "to_receive",
+		TotalAmount: "2000.00",
+		Currency:    "USD",
+		DueDate:     timePtr(time.Now().AddDate(0, 6, 0)),
+	})
+	require.NoError(t, err)
+
+	debtHandler := handlers.NewDebtHandler(f.debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		userID, err := uuid.Parse(c.GetHeader("X-Test-User"))
+		require.NoError(t, err)
+		c.Set("user_id", userID)
+	})
+	router.POST("/api/v1/debts/:id/payments/import", debtHandler.ImportPayments)
+
+	upload := func(userID uuid.UUID, csv string, fields map[string]string) (int, entities.PaymentImportResult) {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		for name, value := range fields {
+			require.NoError(t, writer.WriteField(name, value))
+		}
+		part, err := writer.CreateFormFile("file", "statement.csv")
+		require.NoError(t, err)
+		_, err = part.Write([]byte(csv))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/debts/"+debtList.ID.String()+"/payments/import", &body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req.Header.Set("X-Test-User", userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response struct {
+			Data entities.PaymentImportResult `json:"data"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return w.Code, response.Data
+	}
+
+	day := func(daysAgo int) string {
+		return time.Now().AddDate(0, 0, -daysAgo).Format("02/01/2006")
+	}
+	mapping := map[string]string{
+		"date_column":        "Posted Date",
+		"amount_column":      "Credit",
+		"reference_column":   "Reference",
+		"description_column": "Details",
+		"date_format":        "02/01/2006",
+	}
+	statement := fmt.Sprintf(`Posted Date,Credit,Reference,Details,Balance
+%s,"1,000.00",TRX-1001,March instalment,5000.00
+not-a-date,10.00,TRX-1002,,5010.00
+%s,ten,TRX-1003,,5020.00
+%s,12"5,TRX-1004,,5030.00
+%s,-20.00,TRX-1005,,5040.00
+%s,250.50,TRX-1006,,5290.50
+`, day(10), day(9), day(8), day(7), day(6))
+
+	code, result := upload(lenderID, statement, mapping)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, 4, result.Skipped)
+
+	require.Len(t, result.Rows, 6)
+	statuses := make(map[int]string, len(result.Rows))
+	for _, row := range result.Rows {
+		statuses[row.Line] = row.Status
+		if row.Status == entities.ImportRowImported {
+			assert.NotNil(t, row.PaymentID)
+			assert.Empty(t, row.Error)
+		} else {
+			assert.Nil(t, row.PaymentID)
+			assert.NotEmpty(t, row.Error, "line %d", row.Line)
+		}
+	}
+	assert.Equal(t, map[int]string{
+		2: entities.ImportRowImported,
+		3: entities.ImportRowSkipped, // malformed date
+		4: entities.ImportRowSkipped, // malformed amount
+		5: entities.ImportRowSkipped, // malformed CSV quoting
+		6: entities.ImportRowSkipped, // negative amount fails validation
+		7: entities.ImportRowImported,
+	}, statuses)
+
+	// Imported rows are real payments and count towards the debt
+	first, err := f.debtItemRepo.GetByID(ctx, *result.Rows[0].PaymentID)
+	require.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("1000.00").Equal(first.Amount), first.Amount.String())
+	assert.Equal(t, "bank_transfer", first.PaymentMethod)
+	assert.Equal(t, "USD", first.Currency)
+	require.NotNil(t, first.Description)
+	assert.Equal(t, "March instalment", *first.Description)
+	require.NotNil(t, first.Reference)
+	assert.Equal(t, "TRX-1001", *first.Reference)
+
+	// The reference is not used as a description when the row has none
+	last, err := f.debtItemRepo.GetByID(ctx, *result.Rows[5].PaymentID)
+	require.NoError(t, err)
+	assert.Nil(t, last.Description)
+	require.NotNil(t, last.Reference)
+	assert.Equal(t, "TRX-1006", *last.Reference)
+
+	stored, err := f.debtListRepo.GetByID(ctx, debtList.ID)
+	require.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("1250.50").Equal(stored.TotalPaymentsMade), stored.TotalPaymentsMade.String())
+
+	// A mapping naming a column the file lacks imports nothing
+	code, _ = upload(lenderID, statement, map[string]string{"date_column": "Value Date", "amount_column": "Credit"})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// Users without access to the debt cannot import into it
+	code, _ = upload(uuid.New(), statement, mapping)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	payments, err := f.debtItemRepo.GetByDebtListID(ctx, debtList.ID)
+	require.NoError(t, err)
+	assert.Len(t, payments, 2)
+}
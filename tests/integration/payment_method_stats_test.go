@@ -0,0 +1,121 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+)
+
+func TestGetPaymentMethodStats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t, &models.DebtBalance{})
+
+	aliceID := f.register("alice-methods@example.com", "Alice")
+	bobID := f.register("bob-methods@example.com", "Bob")
+
+	newDebt := func(ownerID, contactID uuid.UUID, amount, currency string) *entities.DebtList {
+		debtList, err := f.debtService.CreateDebtList(ctx, ownerID, &entities.CreateDebtListRequest{
+			ContactID:   contactID,
+			DebtType:    entities.DebtTypeToReceive,
+			TotalAmount: amount,
+			Currency:    currency,
+			DueDate:     timePtr(time.Now().AddDate(0, 6, 0)),
+		})
+		require.NoError(t, err)
+		return debtList
+	}
+	pay := func(userID, debtListID uuid.UUID, amount, currency, method string) *entities.DebtItem {
+		payment, err := f.debtService.CreateDebtItem(ctx, userID, &entities.CreateDebtItemRequest{
+			DebtListID:    debtListID,
+			Amount:        amount,
+			Currency:      currency,
+			PaymentDate:   time.Now(),
+			PaymentMethod: method,
+		})
+		require.NoError(t, err)
+		return payment
+	}
+
+	// Alice lent Bob 1000 USD and 500 EUR and recorded his repayments
+	usdLoan := newDebt(aliceID, f.contactFor(aliceID, "bob-methods@example.com"), "1000.00", "USD")
+	eurLoan := newDebt(aliceID, f.contactFor(aliceID, "bob-methods@example.com"), "500.00", "EUR")
+	pay(aliceID, usdLoan.ID, "100.00", "USD", "cash")
+	pay(aliceID, usdLoan.ID, "50.00", "USD", "cash")
+	reversed := pay(aliceID, usdLoan.ID, "200.00", "USD", "bank_transfer")
+	pay(aliceID, eurLoan.ID, "75.00", "EUR", "bank_transfer")
+	// A payment Bob records stays pending until Alice verifies it
+	pay(bobID, usdLoan.ID, "30.00", "USD", "digital_wallet")
+	// Reversing part of a payment records an adjustment, which is not a payment made
+	_, err := f.debtService.ReversePayment(ctx, reversed.ID, aliceID, decimal.RequireFromString("20.00"), "Bank fee")
+	require.NoError(t, err)
+
+	// Bob lent Alice 40 USD, which she repaid by check
+	borrowed := newDebt(bobID, f.contactFor(bobID, "alice-methods@example.com"), "40.00", "USD")
+	pay(bobID, borrowed.ID, "40.00", "USD", "check")
+
+	// Debts between other users are not counted
+	carolID := f.register("carol-methods@example.com", "Carol")
+	dave, err := f.contactService.CreateContact(ctx, carolID, &entities.CreateContactRequest{Name: "Dave"})
+	require.NoError(t, err)
+	pay(carolID, newDebt(carolID, dave.ID, "60.00", "USD").ID, "60.00", "USD", "cash")
+
+	debtHandler := handlers.NewDebtHandler(f.debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", aliceID)
+	})
+	router.GET("/api/v1/reports/payment-methods", debtHandler.GetPaymentMethodStats)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/reports/payment-methods", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var body struct {
+		Data []entities.PaymentMethodStats `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body.Data, 4)
+
+	expected := []struct {
+		method   string
+		currency string
+		count    int64
+		total    string
+	}{
+		{"bank_transfer", "EUR", 1, "75.00"},
+		{"bank_transfer", "USD", 1, "200.00"},
+		{"cash", "USD", 2, "150.00"},
+		{"check", "USD", 1, "40.00"},
+	}
+	for i, e := range expected {
+		stat := body.Data[i]
+		assert.Equal(t, e.method, stat.PaymentMethod)
+		assert.Equal(t, e.currency, stat.Currency)
+		assert.Equal(t, e.count, stat.Count, "%s %s", e.method, e.currency)
+		assert.True(t, decimal.RequireFromString(e.total).Equal(stat.TotalAmount), "%s %s: %s", e.method, e.currency, stat.TotalAmount)
+	}
+
+	// A user without payments gets an empty list
+	stats, err := f.debtService.GetPaymentMethodStats(ctx, f.register("erin-methods@example.com", "Erin"))
+	require.NoError(t, err)
+	assert.NotNil(t, stats)
+	assert.Empty(t, stats)
+}
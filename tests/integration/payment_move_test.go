@@ -0,0 +1,157 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+)
+
+func TestMoveDebtItem(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t, &models.DebtBalance{})
+
+	aliceID := f.register("alice-move@example.com", "Alice")
+	carolID := f.register("carol-move@example.com", "Carol")
+
+	bob, err := f.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{Name: "Bob"})
+	require.NoError(t, err)
+	newDebt := func(ownerID, contactID uuid.UUID, amount, currency string) *entities.DebtList {
+		debtList, err := f.debtService.CreateDebtList(ctx, ownerID, &entities.CreateDebtListRequest{
+			ContactID:   contactID,
+			DebtType:    "to_receive",
+			TotalAmount: amount,
+			Currency:    currency,
+			DueDate:     timePtr(time.Now().AddDate(0, 3, 0)),
+		})
+		require.NoError(t, err)
+		return debtList
+	}
+	pay := func(debtListID uuid.UUID, amount string) *entities.DebtItem {
+		payment, err := f.debtService.CreateDebtItem(ctx, aliceID, &entities.CreateDebtItemRequest{
+			DebtListID:    debtListID,
+			Amount:        amount,
+			Currency:      "USD",
+			PaymentDate:   time.Now(),
+			PaymentMethod: "cash",
+		})
+		require.NoError(t, err)
+		return payment
+	}
+
+	// Bob's payment for the loan was recorded on his rent debt by mistake
+	loan := newDebt(aliceID, bob.ID, "300.00", "USD")
+	rent := newDebt(aliceID, bob.ID, "200.00", "USD")
+	misplaced := pay(rent.ID, "200.00")
+
+	debtHandler := handlers.NewDebtHandler(f.debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", aliceID)
+	})
+	router.POST("/api/v1/debts/payments/:id/move", debtHandler.MoveDebtItem)
+
+	move := func(paymentID, targetID uuid.UUID) (int, entities.DebtItem) {
+		body, err := json.Marshal(map[string]interface{}{"target_debt_list_id": targetID})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/debts/payments/"+paymentID.String()+"/move", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var response struct {
+			Data entities.DebtItem `json:"data"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return w.Code, response.Data
+	}
+	assertTotals := func(t *testing.T, debtListID uuid.UUID, paid, remaining, status string) {
+		stored, err := f.debtListRepo.GetByID(ctx, debtListID)
+		require.NoError(t, err)
+		assert.True(t, decimal.RequireFromString(paid).Equal(stored.TotalPaymentsMade), "paid %s", stored.TotalPaymentsMade)
+		assert.True(t, decimal.RequireFromString(remaining).Equal(stored.TotalRemainingDebt), "remaining %s", stored.TotalRemainingDebt)
+		assert.Equal(t, status, stored.Status)
+	}
+	assertTotals(t, rent.ID, "200.00", "0", "settled")
+	assertTotals(t, loan.ID, "0", "300.00", "active")
+
+	code, moved := move(misplaced.ID, loan.ID)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, loan.ID, moved.DebtListID)
+
+	// Both lists' totals reflect the move
+	assertTotals(t, rent.ID, "0", "200.00", "active")
+	assertTotals(t, loan.ID, "200.00", "100.00", "active")
+	stored, err := f.debtItemRepo.GetByID(ctx, misplaced.ID)
+	require.NoError(t, err)
+	assert.Equal(t, loan.ID, stored.DebtListID)
+
+	t.Run("payment is already on the target", func(t *testing.T) {
+		code, _ := move(misplaced.ID, loan.ID)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("target the user cannot access", func(t *testing.T) {
+		dave, err := f.contactService.CreateContact(ctx, carolID, &entities.CreateContactRequest{Name: "Dave"})
+		require.NoError(t, err)
+		carols := newDebt(carolID, dave.ID, "50.00", "USD")
+
+		code, _ := move(misplaced.ID, carols.ID)
+		assert.Equal(t, http.StatusNotFound, code)
+		assertTotals(t, loan.ID, "200.00", "100.00", "active")
+	})
+
+	t.Run("target without a balance in the payment's currency", func(t *testing.T) {
+		euros := newDebt(aliceID, bob.ID, "100.00", "EUR")
+
+		code, _ := move(misplaced.ID, euros.ID)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("reversed payments stay put", func(t *testing.T) {
+		payment := pay(loan.ID, "50.00")
+		_, err := f.debtService.ReversePayment(ctx, payment.ID, aliceID, decimal.RequireFromString("10.00"), "Counted twice")
+		require.NoError(t, err)
+
+		code, _ := move(payment.ID, rent.ID)
+		assert.Equal(t, http.StatusConflict, code)
+	})
+
+	t.Run("a payment moved to another counterparty is verified again", func(t *testing.T) {
+		erin, err := f.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{Name: "Erin"})
+		require.NoError(t, err)
+		erins := newDebt(aliceID, erin.ID, "100.00", "USD")
+		payment := pay(loan.ID, "40.00")
+		require.Equal(t, entities.PaymentStatusCompleted, payment.Status)
+
+		code, moved := move(payment.ID, erins.ID)
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, entities.PaymentStatusPending, moved.Status)
+		assert.Nil(t, moved.VerifiedBy)
+		assert.Nil(t, moved.VerifiedAt)
+
+		// Until Erin's payment is verified it doesn't reduce her debt
+		assertTotals(t, erins.ID, "0", "100.00", "active")
+	})
+
+	t.Run("unknown payment", func(t *testing.T) {
+		code, _ := move(uuid.New(), rent.ID)
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
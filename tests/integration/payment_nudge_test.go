@@ -0,0 +1,177 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+	"pay-your-dues/internal/services"
+)
+
+func TestNudgePendingPayment(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	publisher := &mocks.MockDebtEventPublisher{}
+	publisher.On("Publish", mock.Anything, mock.Anything).Return(nil)
+
+	debtService := f.newDebtService(services.WithEventPublisher(publisher),
+		services.WithNudgeInterval(time.Hour))
+
+	lenderID := f.register("lender-nudge@example.com", "Lender")
+	debtorID := f.register("debtor-nudge@example.com", "Debtor")
+	strangerID := f.register("stranger-nudge@example.com", "Stranger")
+
+	debtor, err := f.contactService.CreateContact(ctx, lenderID, &entities.CreateContactRequest{
+		Name:  "Debtor",
+		Email: stringPtr("debtor-nudge@example.com"),
+	})
+	require.NoError(t, err)
+	newDebt := func(ownerID, contactID uuid.UUID, debtType string) uuid.UUID {
+		debtList, err := debtService.CreateDebtList(ctx, ownerID, &entities.CreateDebtListRequest{
+			ContactID:   contactID,
+			DebtType:    debtType,
+			TotalAmount: "500.00",
+			Currency:    "USD",
+			DueDate:     timePtr(time.Now().AddDate(0, 3, 0)),
+		})
+		require.NoError(t, err)
+		return debtList.ID
+	}
+	pay := func(userID, debtListID uuid.UUID) *entities.DebtItem {
+		payment, err := debtService.CreateDebtItem(ctx, userID, &entities.CreateDebtItemRequest{
+			DebtListID:    debtListID,
+			Amount:        "50.00",
+			Currency:      "USD",
+			PaymentDate:   time.Now(),
+			PaymentMethod: "cash",
+		})
+		require.NoError(t, err)
+		return payment
+	}
+	debtListID := newDebt(lenderID, debtor.ID, "to_receive")
+
+	debtHandler := handlers.NewDebtHandler(debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		userID, err := uuid.Parse(c.GetHeader("X-Test-User"))
+		require.NoError(t, err)
+		c.Set("user_id", userID)
+	})
+	router.POST("/api/v1/debts/payments/:id/nudge", debtHandler.NudgeDebtItem)
+
+	nudge := func(userID, paymentID uuid.UUID) int {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/debts/payments/"+paymentID.String()+"/nudge", nil)
+		req.Header.Set("X-Test-User", userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	nudges := func() []entities.DebtEvent {
+		var events []entities.DebtEvent
+		for _, call := range publisher.Calls {
+			if event := call.Arguments.Get(1).(entities.DebtEvent); event.Type == entities.DebtEventPaymentNudged {
+				events = append(events, event)
+			}
+		}
+		return events
+	}
+
+	// The debtor's payment awaits the lender's verification
+	pending := pay(debtorID, debtListID)
+	require.Equal(t, entities.PaymentStatusPending, pending.Status)
+
+	require.Equal(t, http.StatusOK, nudge(debtorID, pending.ID))
+	events := nudges()
+	require.Len(t, events, 1)
+	assert.Equal(t, debtListID, events[0].DebtListID)
+	require.NotNil(t, events[0].PaymentID)
+	assert.Equal(t, pending.ID, *events[0].PaymentID)
+	require.NotNil(t, events[0].RecipientID)
+	assert.Equal(t, lenderID, *events[0].RecipientID)
+
+	stored, err := f.debtItemRepo.GetByID(ctx, pending.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.LastNudgedAt)
+
+	t.Run("nudging again within the interval is rate limited", func(t *testing.T) {
+		assert.Equal(t, http.StatusTooManyRequests, nudge(debtorID, pending.ID))
+		assert.Len(t, nudges(), 1)
+	})
+
+	t.Run("nudging is allowed again once the interval has passed", func(t *testing.T) {
+		require.NoError(t, f.db.Model(&models.DebtItem{}).Where("id = ?", pending.ID).
+			UpdateColumn("last_nudged_at", time.Now().Add(-2*time.Hour)).Error)
+
+		assert.Equal(t, http.StatusOK, nudge(debtorID, pending.ID))
+		assert.Len(t, nudges(), 2)
+	})
+
+	t.Run("the rate limit is per payment", func(t *testing.T) {
+		other := pay(debtorID, debtListID)
+		assert.Equal(t, http.StatusOK, nudge(debtorID, other.ID))
+		assert.Len(t, nudges(), 3)
+	})
+
+	t.Run("only the submitter may nudge", func(t *testing.T) {
+		fresh := pay(debtorID, debtListID)
+		assert.Equal(t, http.StatusForbidden, nudge(lenderID, fresh.ID))
+		assert.Equal(t, http.StatusNotFound, nudge(strangerID, fresh.ID))
+		assert.Len(t, nudges(), 3)
+	})
+
+	t.Run("verified payments cannot be nudged", func(t *testing.T) {
+		verified := pay(debtorID, debtListID)
+		_, err := debtService.VerifyDebtItem(ctx, verified.ID, lenderID, &entities.VerifyDebtItemRequest{Status: entities.PaymentStatusCompleted})
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusConflict, nudge(debtorID, verified.ID))
+	})
+
+	t.Run("an unregistered verifier cannot be nudged", func(t *testing.T) {
+		offline, err := f.contactService.CreateContact(ctx, debtorID, &entities.CreateContactRequest{Name: "Cash Only Carl"})
+		require.NoError(t, err)
+		owed := pay(debtorID, newDebt(debtorID, offline.ID, "to_pay"))
+		require.Equal(t, entities.PaymentStatusPending, owed.Status)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, nudge(debtorID, owed.ID))
+	})
+
+	t.Run("unknown payment", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, nudge(debtorID, uuid.New()))
+	})
+
+	t.Run("without notifications the payment is not marked nudged", func(t *testing.T) {
+		fresh := pay(debtorID, debtListID)
+		unpublished := handlers.NewDebtHandler(f.newDebtService(services.WithNudgeInterval(time.Hour)), &mocks.MockFileStorageService{}, zerolog.Nop())
+		router.POST("/api/v1/debts/payments/:id/nudge-unpublished", unpublished.NudgeDebtItem)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/debts/payments/"+fresh.ID.String()+"/nudge-unpublished", nil)
+		req.Header.Set("X-Test-User", debtorID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+		stored, err := f.debtItemRepo.GetByID(ctx, fresh.ID)
+		require.NoError(t, err)
+		assert.Nil(t, stored.LastNudgedAt)
+
+		// So it can still be nudged once notifications are back
+		assert.Equal(t, http.StatusOK, nudge(debtorID, fresh.ID))
+	})
+}
@@ -0,0 +1,131 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+)
+
+func TestPaymentReferenceRoundTrip(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "lender-reference@example.com",
+		Password:  "password123",
+		FirstName: "Reference",
+		LastName:  "Lender",
+	})
+	require.NoError(t, err)
+	lenderID := resp.User.ID
+
+	contact, err := f.contactService.CreateContact(ctx, lenderID, &entities.CreateContactRequest{Name: "Transferring Borrower"})
+	require.NoError(t, err)
+	debtList, err := f.debtService.CreateDebtList(ctx, lenderID, &entities.CreateDebtListRequest{
+		ContactID:   contact.ID,
+		DebtType:    "to_receive",
+		TotalAmount: "500.00",
+		Currency:    "USD",
+		DueDate:     timePtr(time.Now().AddDate(0, 3, 0)),
+	})
+	require.NoError(t, err)
+
+	debtHandler := handlers.NewDebtHandler(f.debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", lenderID)
+	})
+	router.POST("/api/v1/debts/payments", debtHandler.CreateDebtItem)
+	router.GET("/api/v1/debts/:id", debtHandler.GetDebtList)
+	router.GET("/api/v1/debts/:id/payments", debtHandler.GetDebtListItems)
+
+	createPayment := func(reference *string) (int, entities.DebtItem) {
+		payload := map[string]interface{}{
+			"debt_list_id":   debtList.ID,
+			"amount":         "100.00",
+			"currency":       "USD",
+			"payment_date":   time.Now(),
+			"payment_method": "bank_transfer",
+		}
+		if reference != nil {
+			payload["reference"] = *reference
+		}
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/debts/payments", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response struct {
+			Data entities.DebtItem `json:"data"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return w.Code, response.Data
+	}
+
+	// The reference is trimmed before it is stored
+	code, created := createPayment(stringPtr("  FT-2024-0042 "))
+	require.Equal(t, http.StatusCreated, code)
+	require.NotNil(t, created.Reference)
+	assert.Equal(t, "FT-2024-0042", *created.Reference)
+
+	// It comes back on the payment and on the debt's payment list
+	stored, err := f.debtItemRepo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.Reference)
+	assert.Equal(t, "FT-2024-0042", *stored.Reference)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/debts/"+debtList.ID.String()+"/payments", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var listed struct {
+		Data []entities.DebtItem `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &listed))
+	require.Len(t, listed.Data, 1)
+	require.NotNil(t, listed.Data[0].Reference)
+	assert.Equal(t, "FT-2024-0042", *listed.Data[0].Reference)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/debts/"+debtList.ID.String(), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var detail struct {
+		Data entities.DebtListResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &detail))
+	require.Len(t, detail.Data.Payments, 1)
+	require.NotNil(t, detail.Data.Payments[0].Reference)
+	assert.Equal(t, "FT-2024-0042", *detail.Data.Payments[0].Reference)
+
+	// Payments without a reference, or with a blank one, have none
+	code, created = createPayment(nil)
+	require.Equal(t, http.StatusCreated, code)
+	assert.Nil(t, created.Reference)
+	code, created = createPayment(stringPtr("   "))
+	require.Equal(t, http.StatusCreated, code)
+	assert.Nil(t, created.Reference)
+
+	// Overlong references are rejected
+	code, _ = createPayment(stringPtr(strings.Repeat("R", entities.MaxReferenceLength+1)))
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,172 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+)
+
+func TestReversePayment(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	aliceID := f.register("alice-reversal@example.com", "Alice")
+	bobID := f.register("bob-reversal@example.com", "Bob")
+
+	// Alice lent Bob 300, so she verifies the payments he records
+	bob, err := f.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{
+		Name:  "Bob",
+		Email: stringPtr("bob-reversal@example.com"),
+	})
+	require.NoError(t, err)
+	debtList, err := f.debtService.CreateDebtList(ctx, aliceID, &entities.CreateDebtListRequest{
+		ContactID:   bob.ID,
+		DebtType:    "to_receive",
+		TotalAmount: "300.00",
+		Currency:    "USD",
+		DueDate:     timePtr(time.Now().AddDate(0, 3, 0)),
+	})
+	require.NoError(t, err)
+
+	pay := func(amount string) *entities.DebtItem {
+		payment, err := f.debtService.CreateDebtItem(ctx, bobID, &entities.CreateDebtItemRequest{
+			DebtListID:    debtList.ID,
+			Amount:        amount,
+			Currency:      "USD",
+			PaymentDate:   time.Now(),
+			PaymentMethod: "cash",
+		})
+		require.NoError(t, err)
+		return payment
+	}
+	totals := func() (decimal.Decimal, decimal.Decimal) {
+		stored, err := f.debtListRepo.GetByID(ctx, debtList.ID)
+		require.NoError(t, err)
+		return stored.TotalPaymentsMade, stored.TotalRemainingDebt
+	}
+
+	payment := pay("100.00")
+	_, err = f.debtService.VerifyDebtItem(ctx, payment.ID, aliceID, &entities.VerifyDebtItemRequest{Status: entities.PaymentStatusCompleted})
+	require.NoError(t, err)
+	paid, remaining := totals()
+	require.True(t, decimal.RequireFromString("100.00").Equal(paid), paid.String())
+	require.True(t, decimal.RequireFromString("200.00").Equal(remaining), remaining.String())
+
+	debtHandler := handlers.NewDebtHandler(f.debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		userID, err := uuid.Parse(c.GetHeader("X-Test-User"))
+		require.NoError(t, err)
+		c.Set("user_id", userID)
+	})
+	router.POST("/api/v1/debts/payments/:id/reverse", debtHandler.ReversePayment)
+
+	reverse := func(userID, paymentID uuid.UUID, amount, reason string) (int, entities.DebtItem) {
+		body, err := json.Marshal(entities.ReversePaymentRequest{Amount: amount, Reason: reason})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/debts/payments/"+paymentID.String()+"/reverse", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Test-User", userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response struct {
+			Data entities.DebtItem `json:"data"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return w.Code, response.Data
+	}
+
+	// Reversing 30 of the verified payment adds 30 back to the remaining balance
+	code, reversal := reverse(aliceID, payment.ID, "30.00", "Counted twice")
+	require.Equal(t, http.StatusCreated, code)
+	assert.True(t, decimal.RequireFromString("-30.00").Equal(reversal.Amount), reversal.Amount.String())
+	assert.Equal(t, entities.PaymentTypeAdjustment, reversal.PaymentType)
+	assert.Equal(t, entities.PaymentStatusCompleted, reversal.Status)
+	require.NotNil(t, reversal.ReversalOf)
+	assert.Equal(t, payment.ID, *reversal.ReversalOf)
+	require.NotNil(t, reversal.Description)
+	assert.Equal(t, "Counted twice", *reversal.Description)
+
+	paid, remaining = totals()
+	assert.True(t, decimal.RequireFromString("70.00").Equal(paid), paid.String())
+	assert.True(t, decimal.RequireFromString("230.00").Equal(remaining), remaining.String())
+
+	// The original payment keeps its recorded amount
+	original, err := f.debtItemRepo.GetByID(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("100.00").Equal(original.Amount))
+
+	// Only what earlier reversals left of the payment can be reversed
+	code, _ = reverse(aliceID, payment.ID, "70.01", "Too much")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = reverse(aliceID, payment.ID, "0", "Nothing")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = reverse(aliceID, payment.ID, "10.00", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// The debtor cannot reverse, and reversals themselves cannot be reversed
+	code, _ = reverse(bobID, payment.ID, "10.00", "Not mine to reverse")
+	assert.Equal(t, http.StatusNotFound, code)
+	code, _ = reverse(aliceID, reversal.ID, "10.00", "Reverse the reversal")
+	assert.Equal(t, http.StatusConflict, code)
+
+	// Unverified payments cannot be reversed
+	pending := pay("50.00")
+	code, _ = reverse(aliceID, pending.ID, "10.00", "Not verified yet")
+	assert.Equal(t, http.StatusConflict, code)
+
+	// The rest of the payment can still be reversed
+	bounced, err := f.debtService.ReversePayment(ctx, payment.ID, aliceID, decimal.RequireFromString("70.00"), "Payment bounced")
+	require.NoError(t, err)
+	paid, remaining = totals()
+	assert.True(t, paid.IsZero(), paid.String())
+	assert.True(t, decimal.RequireFromString("300.00").Equal(remaining), remaining.String())
+
+	_, err = f.debtService.ReversePayment(ctx, payment.ID, aliceID, decimal.RequireFromString("0.01"), "Nothing left")
+	assert.ErrorIs(t, err, entities.ErrReversalExceedsPayment)
+
+	// A reversed payment is deleted only together with its reversals
+	assert.ErrorIs(t, f.debtService.DeleteDebtItem(ctx, payment.ID, aliceID), entities.ErrPaymentHasReversals)
+	err = f.debtService.DeleteDebtItems(ctx, debtList.ID, aliceID, []uuid.UUID{payment.ID, reversal.ID})
+	assert.ErrorIs(t, err, entities.ErrPaymentHasReversals)
+	require.NoError(t, f.debtService.DeleteDebtItem(ctx, bounced.ID, aliceID))
+	require.NoError(t, f.debtService.DeleteDebtItems(ctx, debtList.ID, aliceID, []uuid.UUID{payment.ID, reversal.ID}))
+	paid, remaining = totals()
+	assert.True(t, paid.IsZero(), paid.String())
+	assert.True(t, decimal.RequireFromString("300.00").Equal(remaining), remaining.String())
+
+	// A reversal is restored only while its payment is there
+	_, err = f.debtService.RestoreDebtItem(ctx, bounced.ID, aliceID)
+	assert.ErrorIs(t, err, entities.ErrDebtItemNotFound)
+
+	// and only while it still fits in what the payment's other reversals left
+	_, err = f.debtService.RestoreDebtItem(ctx, payment.ID, aliceID)
+	require.NoError(t, err)
+	_, err = f.debtService.ReversePayment(ctx, payment.ID, aliceID, decimal.RequireFromString("70.00"), "Bounced again")
+	require.NoError(t, err)
+	_, err = f.debtService.RestoreDebtItem(ctx, bounced.ID, aliceID)
+	assert.ErrorIs(t, err, entities.ErrReversalExceedsPayment)
+	_, err = f.debtService.RestoreDebtItem(ctx, reversal.ID, aliceID)
+	require.NoError(t, err)
+	paid, remaining = totals()
+	assert.True(t, paid.IsZero(), paid.String())
+	assert.True(t, decimal.RequireFromString("300.00").Equal(remaining), remaining.String())
+}
@@ -148,6 +148,7 @@ func (suite *PaymentScheduleIntegrationTestSuite) TestGetPaymentSchedule_WithFul
 		assert.True(t, schedule[i].PaidAmount.Equal(decimal.RequireFromString("250.00")))
 		assert.True(t, schedule[i].ScheduledAmount.Equal(decimal.RequireFromString("250.00")))
 		assert.True(t, schedule[i].Amount.IsZero(), "Should be fully paid")
+		assert.Equal(t, []uuid.UUID{payments[i].ID}, schedule[i].PaymentIDs, "Should link the payment that paid it")
 	}
 
 	// Last two payments should be pending
@@ -155,6 +156,7 @@ func (suite *PaymentScheduleIntegrationTestSuite) TestGetPaymentSchedule_WithFul
 		assert.Equal(t, "pending", schedule[i].Status)
 		assert.True(t, schedule[i].PaidAmount.IsZero())
 		assert.True(t, schedule[i].Amount.Equal(decimal.RequireFromString("250.00")))
+		assert.Empty(t, schedule[i].PaymentIDs)
 	}
 
 	suite.debtListRepo.AssertExpectations(t)
@@ -0,0 +1,136 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+	"pay-your-dues/internal/services"
+)
+
+func TestDeleteThenRestorePayment(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	register := func(email string) uuid.UUID {
+		resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+			Email:     email,
+			Password:  "password123",
+			FirstName: "Restore",
+			LastName:  "User",
+		})
+		require.NoError(t, err)
+		return resp.User.ID
+	}
+	ownerID := register("owner-restore@example.com")
+	strangerID := register("stranger-restore@example.com")
+
+	contact, err := f.contactService.CreateContact(ctx, ownerID, &entities.CreateContactRequest{Name: "Borrower"})
+	require.NoError(t, err)
+	debtList, err := f.debtService.CreateDebtList(ctx, ownerID, &entities.CreateDebtListRequest{
+		ContactID:   contact.ID,
+		DebtType:    "to_receive",
+		TotalAmount: "100.00",
+		Currency:    "USD",
+		DueDate:     timePtr(time.Now().AddDate(0, 1, 0)),
+	})
+	require.NoError(t, err)
+
+	payment, err := f.debtService.CreateDebtItem(ctx, ownerID, &entities.CreateDebtItemRequest{
+		DebtListID:    debtList.ID,
+		Amount:        "40.00",
+		Currency:      "USD",
+		PaymentDate:   time.Now(),
+		PaymentMethod: "cash",
+	})
+	require.NoError(t, err)
+	require.Equal(t, entities.PaymentStatusCompleted, payment.Status)
+
+	assertTotals := func(paid, remaining string) {
+		t.Helper()
+		totals, err := f.debtListRepo.GetByID(ctx, debtList.ID)
+		require.NoError(t, err)
+		assert.True(t, decimal.RequireFromString(paid).Equal(totals.TotalPaymentsMade), totals.TotalPaymentsMade.String())
+		assert.True(t, decimal.RequireFromString(remaining).Equal(totals.TotalRemainingDebt), totals.TotalRemainingDebt.String())
+	}
+	assertTotals("40.00", "60.00")
+
+	// Deleting hides the payment from every read and from the totals
+	require.NoError(t, f.debtService.DeleteDebtItem(ctx, payment.ID, ownerID))
+	assertTotals("0", "100.00")
+
+	_, err = f.debtItemRepo.GetByID(ctx, payment.ID)
+	assert.ErrorIs(t, err, entities.ErrDebtItemNotFound)
+	completed, err := f.debtItemRepo.GetCompletedPaymentsForDebtList(ctx, debtList.ID)
+	require.NoError(t, err)
+	assert.Empty(t, completed)
+	totalPaid, err := f.debtItemRepo.GetTotalPaidForDebtList(ctx, debtList.ID)
+	require.NoError(t, err)
+	assert.True(t, totalPaid.IsZero(), totalPaid.String())
+
+	debtHandler := handlers.NewDebtHandler(f.debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		userID, err := uuid.Parse(c.GetHeader("X-Test-User"))
+		require.NoError(t, err)
+		c.Set("user_id", userID)
+	})
+	router.POST("/api/v1/debts/payments/:id/restore", debtHandler.RestoreDebtItem)
+
+	restore := func(userID, paymentID uuid.UUID) (*httptest.ResponseRecorder, map[string]interface{}) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/debts/payments/"+paymentID.String()+"/restore", nil)
+		req.Header.Set("X-Test-User", userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var responseBody map[string]interface{}
+		_ = json.Unmarshal(w.Body.Bytes(), &responseBody)
+		return w, responseBody
+	}
+
+	// Only the owner of the debt can restore its payments
+	w, _ := restore(strangerID, payment.ID)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w, body := restore(ownerID, payment.ID)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	data := body["data"].(map[string]interface{})
+	assert.Equal(t, payment.ID.String(), data["ID"])
+	assertTotals("40.00", "60.00")
+
+	completed, err = f.debtItemRepo.GetCompletedPaymentsForDebtList(ctx, debtList.ID)
+	require.NoError(t, err)
+	require.Len(t, completed, 1)
+	assert.Equal(t, payment.ID, completed[0].ID)
+
+	// A payment that is not deleted cannot be restored
+	w, _ = restore(ownerID, payment.ID)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// With soft-delete disabled the payment is gone for good
+	purgingService := f.newDebtService(services.WithSoftDeletePayments(false))
+	require.NoError(t, purgingService.DeleteDebtItem(ctx, payment.ID, ownerID))
+	assertTotals("0", "100.00")
+
+	_, err = purgingService.RestoreDebtItem(ctx, payment.ID, ownerID)
+	assert.ErrorIs(t, err, entities.ErrDebtItemNotFound)
+	var count int64
+	require.NoError(t, f.db.Unscoped().Model(&models.DebtItem{}).Where("id = ?", payment.ID).Count(&count).Error)
+	assert.Zero(t, count)
+}
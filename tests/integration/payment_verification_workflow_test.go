@@ -89,7 +89,7 @@ func TestPaymentVerificationWorkflow(t *testing.T) {
 					DebtType: "to_receive",
 				},
 			}, nil).Once()
-			mockDebtListRepo.On("GetByID", ctx, debtListID).Return(debtList, nil).Twice() // Called once for validation, once for update
+			mockDebtListRepo.On("GetByID", ctx, debtListID).Return(debtList, nil).Once()
 			
 			expectedDebtItem := &entities.DebtItem{
 				ID:              debtItemID,
@@ -110,13 +110,11 @@ func TestPaymentVerificationWorkflow(t *testing.T) {
 					item.Amount.Equal(decimal.RequireFromString("250.00"))
 			})).Return(nil).Once()
 			
-			// Mock expectations for updating debt list totals (even for pending payments)
-			mockDebtItemRepo.On("GetTotalPaidForDebtList", ctx, debtListID).Return(decimal.Zero, nil).Once()
-			mockDebtItemRepo.On("GetLastPaymentDate", ctx, debtListID).Return((*time.Time)(nil), nil).Once()
-			mockDebtListRepo.On("UpdatePaymentTotals", ctx, debtListID, decimal.Zero, decimal.RequireFromString("1000.00")).Return(nil).Once()
-			mockDebtListRepo.On("UpdateStatus", ctx, debtListID, "active").Return(nil).Once()
+			// Totals are still recalculated, but a pending payment never counts toward them
+			mockDebtListRepo.On("RecalculatePaymentTotals", mock.Anything, debtListID, mock.Anything).
+				Run(recalculatesTotals(t, debtList, entities.PaymentAggregate{TotalPaid: decimal.Zero}, "0", "1000.00", "active")).
+				Return(nil).Once()
 			mockPaymentScheduleService.On("CalculateNextPaymentDate", debtList, (*time.Time)(nil)).Return(time.Now().AddDate(0, 1, 0)).Once()
-			mockDebtListRepo.On("UpdateNextPaymentDate", ctx, debtListID, mock.AnythingOfType("time.Time")).Return(nil).Once()
 
 			// Create the payment
 			result, err := debtService.CreateDebtItem(ctx, debtorID, createReq)
@@ -209,13 +207,11 @@ func TestPaymentVerificationWorkflow(t *testing.T) {
 			mockDebtItemRepo.On("GetByID", ctx, debtItemID).Return(verifiedPayment, nil).Once()
 			
 			// Mock expectations for updating debt list totals
-			mockDebtListRepo.On("GetByID", ctx, debtListID).Return(debtList, nil).Once()
-			mockDebtItemRepo.On("GetTotalPaidForDebtList", ctx, debtListID).Return(decimal.RequireFromString("250.00"), nil).Once()
-			mockDebtItemRepo.On("GetLastPaymentDate", ctx, debtListID).Return(timePtr(time.Now()), nil).Once()
-			mockDebtListRepo.On("UpdatePaymentTotals", ctx, debtListID, decimal.RequireFromString("250.00"), decimal.RequireFromString("750.00")).Return(nil).Once()
-			mockDebtListRepo.On("UpdateStatus", ctx, debtListID, "active").Return(nil).Once()
+			payments := entities.PaymentAggregate{TotalPaid: decimal.RequireFromString("250.00"), PaymentCount: 1, LastPaymentDate: timePtr(time.Now())}
+			mockDebtListRepo.On("RecalculatePaymentTotals", mock.Anything, debtListID, mock.Anything).
+				Run(recalculatesTotals(t, debtList, payments, "250.00", "750.00", "active")).
+				Return(nil).Once()
 			mockPaymentScheduleService.On("CalculateNextPaymentDate", debtList, mock.AnythingOfType("*time.Time")).Return(time.Now().AddDate(0, 1, 0)).Once()
-			mockDebtListRepo.On("UpdateNextPaymentDate", ctx, debtListID, mock.AnythingOfType("time.Time")).Return(nil).Once()
 
 			// Verify the payment
 			result, err := debtService.VerifyDebtItem(ctx, debtItemID, creditorID, verifyReq)
@@ -284,7 +280,7 @@ func TestPaymentVerificationWorkflow(t *testing.T) {
 
 		// Mock expectations - creditor owns the debt list
 		mockDebtListRepo.On("BelongsToUser", ctx, debtListID, creditorID).Return(true, nil).Once()
-		mockDebtListRepo.On("GetByID", ctx, debtListID).Return(debtList, nil).Twice()
+		mockDebtListRepo.On("GetByID", ctx, debtListID).Return(debtList, nil).Once()
 		mockDebtItemRepo.On("Create", ctx, mock.MatchedBy(func(item *entities.DebtItem) bool {
 			// When creditor creates payment, it should be completed immediately
 			return item.Status == entities.PaymentStatusCompleted &&
@@ -292,12 +288,11 @@ func TestPaymentVerificationWorkflow(t *testing.T) {
 		})).Return(nil).Once()
 		
 		// Mock expectations for updating debt list totals
-		mockDebtItemRepo.On("GetTotalPaidForDebtList", ctx, debtListID).Return(decimal.RequireFromString("250.00"), nil).Once()
-		mockDebtItemRepo.On("GetLastPaymentDate", ctx, debtListID).Return(timePtr(time.Now()), nil).Once()
-		mockDebtListRepo.On("UpdatePaymentTotals", ctx, debtListID, decimal.RequireFromString("250.00"), decimal.RequireFromString("750.00")).Return(nil).Once()
-		mockDebtListRepo.On("UpdateStatus", ctx, debtListID, "active").Return(nil).Once()
+		payments := entities.PaymentAggregate{TotalPaid: decimal.RequireFromString("250.00"), PaymentCount: 1, LastPaymentDate: timePtr(time.Now())}
+		mockDebtListRepo.On("RecalculatePaymentTotals", mock.Anything, debtListID, mock.Anything).
+			Run(recalculatesTotals(t, debtList, payments, "250.00", "750.00", "active")).
+			Return(nil).Once()
 		mockPaymentScheduleService.On("CalculateNextPaymentDate", debtList, mock.AnythingOfType("*time.Time")).Return(time.Now().AddDate(0, 1, 0)).Once()
-		mockDebtListRepo.On("UpdateNextPaymentDate", ctx, debtListID, mock.AnythingOfType("time.Time")).Return(nil).Once()
 
 		// Create payment as creditor
 		result, err := debtService.CreateDebtItem(ctx, creditorID, createReq)
@@ -423,3 +418,15 @@ func TestPaymentVerificationWorkflow(t *testing.T) {
 		mockDebtItemRepo.AssertExpectations(t)
 	})
 }
+
+// recalculatesTotals runs the service's totals computation the way the repository
+// would, against debtList and payments, and asserts the totals it derives
+func recalculatesTotals(t *testing.T, debtList *entities.DebtList, payments entities.PaymentAggregate, totalPaid, remaining, status string) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		compute := args.Get(2).(func(*entities.DebtList, entities.PaymentAggregate) entities.PaymentTotals)
+		totals := compute(debtList, payments)
+		assert.True(t, decimal.RequireFromString(totalPaid).Equal(totals.TotalPaid), totals.TotalPaid.String())
+		assert.True(t, decimal.RequireFromString(remaining).Equal(totals.RemainingDebt), totals.RemainingDebt.String())
+		assert.Equal(t, status, totals.Status)
+	}
+}
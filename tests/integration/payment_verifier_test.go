@@ -0,0 +1,138 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+)
+
+func TestGetPaymentVerifier(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t, &models.DebtBalance{})
+
+	aliceID := f.register("alice-verifier@example.com", "Alice")
+	bobID := f.register("bob-verifier@example.com", "Bob")
+	carolID := f.register("carol-verifier@example.com", "Carol")
+
+	newDebt := func(contactID uuid.UUID, debtType string) *entities.DebtList {
+		debtList, err := f.debtService.CreateDebtList(ctx, aliceID, &entities.CreateDebtListRequest{
+			ContactID:   contactID,
+			DebtType:    debtType,
+			TotalAmount: "100.00",
+			Currency:    "USD",
+			DueDate:     timePtr(time.Now().AddDate(0, 1, 0)),
+		})
+		require.NoError(t, err)
+		return debtList
+	}
+	pay := func(userID, debtListID uuid.UUID) *entities.DebtItem {
+		payment, err := f.debtService.CreateDebtItem(ctx, userID, &entities.CreateDebtItemRequest{
+			DebtListID:    debtListID,
+			Amount:        "25.00",
+			Currency:      "USD",
+			PaymentDate:   time.Now(),
+			PaymentMethod: "cash",
+		})
+		require.NoError(t, err)
+		return payment
+	}
+
+	debtHandler := handlers.NewDebtHandler(f.debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	get := func(userID, paymentID uuid.UUID) (int, entities.PaymentVerifier) {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("user_id", userID)
+		})
+		router.GET("/api/v1/debts/payments/:id/verifier", debtHandler.GetPaymentVerifier)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/debts/payments/"+paymentID.String()+"/verifier", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var body struct {
+			Data entities.PaymentVerifier `json:"data"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return w.Code, body.Data
+	}
+
+	// Bob owes Alice and records a payment she has to verify
+	lent := newDebt(f.contactFor(aliceID, "bob-verifier@example.com"), entities.DebtTypeToReceive)
+	pending := pay(bobID, lent.ID)
+	require.Equal(t, entities.PaymentStatusPending, pending.Status)
+
+	t.Run("creditor", func(t *testing.T) {
+		code, verifier := get(aliceID, pending.ID)
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, pending.ID, verifier.DebtItemID)
+		assert.Equal(t, entities.DebtRoleOwner, verifier.Role)
+		require.NotNil(t, verifier.UserID)
+		assert.Equal(t, aliceID, *verifier.UserID)
+		require.NotNil(t, verifier.Name)
+		assert.Equal(t, "Alice Tester", *verifier.Name)
+		assert.True(t, verifier.IsRequester)
+	})
+
+	t.Run("debtor", func(t *testing.T) {
+		code, verifier := get(bobID, pending.ID)
+		require.Equal(t, http.StatusOK, code)
+		require.NotNil(t, verifier.UserID)
+		assert.Equal(t, aliceID, *verifier.UserID)
+		assert.False(t, verifier.IsRequester)
+	})
+
+	t.Run("unrelated user", func(t *testing.T) {
+		code, _ := get(carolID, pending.ID)
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("contact is the creditor", func(t *testing.T) {
+		// Alice owes Bob, so Bob verifies the payments she records
+		borrowed := newDebt(f.contactFor(aliceID, "bob-verifier@example.com"), entities.DebtTypeToPay)
+		payment := pay(aliceID, borrowed.ID)
+
+		code, verifier := get(aliceID, payment.ID)
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, entities.DebtRoleContact, verifier.Role)
+		require.NotNil(t, verifier.UserID)
+		assert.Equal(t, bobID, *verifier.UserID)
+		assert.False(t, verifier.IsRequester)
+
+		code, verifier = get(bobID, payment.ID)
+		require.Equal(t, http.StatusOK, code)
+		assert.True(t, verifier.IsRequester)
+	})
+
+	t.Run("creditor is not a registered user", func(t *testing.T) {
+		dave, err := f.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{Name: "Dave"})
+		require.NoError(t, err)
+		borrowed := newDebt(dave.ID, entities.DebtTypeToPay)
+		payment := pay(aliceID, borrowed.ID)
+
+		code, verifier := get(aliceID, payment.ID)
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, entities.DebtRoleContact, verifier.Role)
+		assert.Nil(t, verifier.UserID)
+		assert.Nil(t, verifier.Name)
+	})
+
+	t.Run("unknown payment", func(t *testing.T) {
+		code, _ := get(aliceID, uuid.New())
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
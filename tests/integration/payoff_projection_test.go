@@ -0,0 +1,140 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+)
+
+func TestGetPayoffProjection(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t, &models.DebtBalance{})
+
+	resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "alice-payoff@example.com",
+		Password:  "password123",
+		FirstName: "Alice",
+		LastName:  "Payoff",
+	})
+	require.NoError(t, err)
+	aliceID := resp.User.ID
+	bob, err := f.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{Name: "Bob"})
+	require.NoError(t, err)
+
+	newDebt := func(amount string, payments int) *entities.DebtList {
+		debtList, err := f.debtService.CreateDebtList(ctx, aliceID, &entities.CreateDebtListRequest{
+			ContactID:        bob.ID,
+			DebtType:         entities.DebtTypeToReceive,
+			TotalAmount:      amount,
+			Currency:         "USD",
+			InstallmentPlan:  "monthly",
+			NumberOfPayments: intPtr(payments),
+		})
+		require.NoError(t, err)
+		return debtList
+	}
+	pay := func(debtListID uuid.UUID, amount string) {
+		_, err := f.debtService.CreateDebtItem(ctx, aliceID, &entities.CreateDebtItemRequest{
+			DebtListID:    debtListID,
+			Amount:        amount,
+			Currency:      "USD",
+			PaymentDate:   time.Now(),
+			PaymentMethod: "cash",
+		})
+		require.NoError(t, err)
+	}
+
+	debtHandler := handlers.NewDebtHandler(f.debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", aliceID)
+	})
+	router.GET("/api/v1/debts/:id/payoff-projection", debtHandler.GetPayoffProjection)
+
+	get := func(debtListID uuid.UUID) (int, entities.PayoffProjection) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/debts/"+debtListID.String()+"/payoff-projection", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var body struct {
+			Data entities.PayoffProjection `json:"data"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return w.Code, body.Data
+	}
+	sameDay := func(t *testing.T, expected time.Time, actual *time.Time) {
+		require.NotNil(t, actual)
+		assert.Equal(t, expected.Format(time.DateOnly), actual.Format(time.DateOnly))
+	}
+
+	t.Run("on track with a partial payment", func(t *testing.T) {
+		debtList := newDebt("300.00", 3)
+		// Covers the first installment and half of the second
+		pay(debtList.ID, "150.00")
+
+		code, projection := get(debtList.ID)
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, 2, projection.RemainingPayments)
+		assert.True(t, decimal.RequireFromString("150.00").Equal(projection.RemainingAmount), "remaining %s", projection.RemainingAmount)
+		assert.Equal(t, "USD", projection.Currency)
+		assert.True(t, projection.OnTrack)
+		assert.Zero(t, projection.DaysBehind)
+
+		schedule, err := f.debtService.GetPaymentSchedule(ctx, debtList.ID, aliceID)
+		require.NoError(t, err)
+		sameDay(t, schedule[2].DueDate, projection.ProjectedPayoffDate)
+		sameDay(t, schedule[2].DueDate, &projection.ScheduledPayoffDate)
+	})
+
+	t.Run("behind schedule", func(t *testing.T) {
+		debtList := newDebt("400.00", 4)
+		// Three of the four installments fell due without a payment
+		createdAt := time.Now().AddDate(0, -3, 0).Add(-time.Hour)
+		require.NoError(t, f.db.Model(&models.DebtList{}).Where("id = ?", debtList.ID).UpdateColumn("created_at", createdAt).Error)
+		pay(debtList.ID, "50.00")
+
+		code, projection := get(debtList.ID)
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, 4, projection.RemainingPayments)
+		assert.True(t, decimal.RequireFromString("350.00").Equal(projection.RemainingAmount), "remaining %s", projection.RemainingAmount)
+
+		// The overdue installments are paid one a month from today
+		now := time.Now()
+		sameDay(t, now.AddDate(0, 1, 0).AddDate(0, 1, 0).AddDate(0, 1, 0), projection.ProjectedPayoffDate)
+		sameDay(t, createdAt.AddDate(0, 1, 0).AddDate(0, 1, 0).AddDate(0, 1, 0).AddDate(0, 1, 0), &projection.ScheduledPayoffDate)
+		assert.False(t, projection.OnTrack)
+		assert.Greater(t, projection.DaysBehind, 50)
+	})
+
+	t.Run("paid off", func(t *testing.T) {
+		debtList := newDebt("100.00", 2)
+		pay(debtList.ID, "100.00")
+
+		code, projection := get(debtList.ID)
+		require.Equal(t, http.StatusOK, code)
+		assert.Zero(t, projection.RemainingPayments)
+		assert.Nil(t, projection.ProjectedPayoffDate)
+		assert.True(t, projection.OnTrack)
+	})
+
+	t.Run("unknown debt", func(t *testing.T) {
+		code, _ := get(uuid.New())
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
@@ -0,0 +1,79 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+)
+
+func TestPendingPaymentsNeverCountTowardTotals(t *testing.T) {
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	lenderID := f.register("lender-pending-totals@example.com", "Lender")
+	debtorID := f.register("debtor-pending-totals@example.com", "Debtor")
+
+	contact, err := f.contactService.CreateContact(ctx, lenderID, &entities.CreateContactRequest{
+		Name:  "Debtor",
+		Email: stringPtr("debtor-pending-totals@example.com"),
+	})
+	require.NoError(t, err)
+	debtList, err := f.debtService.CreateDebtList(ctx, lenderID, &entities.CreateDebtListRequest{
+		ContactID:   contact.ID,
+		DebtType:    "to_receive",
+		TotalAmount: "1000.00",
+		Currency:    "USD",
+		DueDate:     timePtr(time.Now().AddDate(0, 6, 0)),
+	})
+	require.NoError(t, err)
+
+	pay := func(userID uuid.UUID, amount string) *entities.DebtItem {
+		payment, err := f.debtService.CreateDebtItem(ctx, userID, &entities.CreateDebtItemRequest{
+			DebtListID:    debtList.ID,
+			Amount:        amount,
+			Currency:      "USD",
+			PaymentDate:   time.Now(),
+			PaymentMethod: "cash",
+		})
+		require.NoError(t, err)
+		return payment
+	}
+	assertTotals := func(totalPaid, remaining string) {
+		t.Helper()
+		stored, err := f.debtService.GetDebtList(ctx, debtList.ID, lenderID)
+		require.NoError(t, err)
+		assert.True(t, decimal.RequireFromString(totalPaid).Equal(stored.TotalPaymentsMade), "total paid: %s", stored.TotalPaymentsMade)
+		assert.True(t, decimal.RequireFromString(remaining).Equal(stored.TotalRemainingDebt), "remaining: %s", stored.TotalRemainingDebt)
+	}
+
+	// The debtor's payment awaits the lender's verification
+	pending := pay(debtorID, "250.00")
+	require.Equal(t, entities.PaymentStatusPending, pending.Status)
+	assertTotals("0", "1000.00")
+
+	// A completed payment counts, while the pending one still doesn't
+	completed := pay(lenderID, "100.00")
+	require.Equal(t, entities.PaymentStatusCompleted, completed.Status)
+	assertTotals("100.00", "900.00")
+
+	t.Run("a rejected payment never counts", func(t *testing.T) {
+		rejected := pay(debtorID, "40.00")
+		_, err := f.debtService.VerifyDebtItem(ctx, rejected.ID, lenderID, &entities.VerifyDebtItemRequest{Status: entities.PaymentStatusRejected})
+		require.NoError(t, err)
+		assertTotals("100.00", "900.00")
+	})
+
+	t.Run("verifying the payment counts it", func(t *testing.T) {
+		_, err := f.debtService.VerifyDebtItem(ctx, pending.ID, lenderID, &entities.VerifyDebtItemRequest{Status: entities.PaymentStatusCompleted})
+		require.NoError(t, err)
+		assertTotals("350.00", "650.00")
+	})
+}
@@ -0,0 +1,154 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+)
+
+func TestPendingVerificationQueue(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	aliceID := f.register("alice-queue@example.com", "Alice")
+	bobID := f.register("bob-queue@example.com", "Bob")
+
+	// Alice lent to Bob, and Bob recorded that he owes Alice: she verifies both
+	aliceLent, err := f.debtService.CreateDebtList(ctx, aliceID, &entities.CreateDebtListRequest{
+		ContactID:   f.contactFor(aliceID, "bob-queue@example.com"),
+		DebtType:    "to_receive",
+		TotalAmount: "100.00",
+		Currency:    "USD",
+		Description: stringPtr("Concert tickets"),
+		DueDate:     timePtr(time.Now().AddDate(0, 1, 0)),
+	})
+	require.NoError(t, err)
+	bobOwes, err := f.debtService.CreateDebtList(ctx, bobID, &entities.CreateDebtListRequest{
+		ContactID:   f.contactFor(bobID, "alice-queue@example.com"),
+		DebtType:    "to_pay",
+		TotalAmount: "300.00",
+		Currency:    "USD",
+		DueDate:     timePtr(time.Now().AddDate(0, 1, 0)),
+	})
+	require.NoError(t, err)
+
+	// Submission dates are spread out so the ordering is deterministic
+	submitted := time.Now().Add(-time.Hour)
+	newPayment := func(debtListID uuid.UUID, amount string, age time.Duration) uuid.UUID {
+		item := models.DebtItem{
+			ID:          uuid.New(),
+			DebtListID:  debtListID,
+			Amount:      decimal.RequireFromString(amount),
+			Currency:    "USD",
+			PaymentDate: time.Now(),
+			Status:      entities.PaymentStatusPending,
+			CreatedBy:   bobID,
+			CreatedAt:   submitted.Add(-age),
+		}
+		require.NoError(t, f.db.Create(&item).Error)
+		return item.ID
+	}
+	oldest := newPayment(aliceLent.ID, "10.00", 3*time.Hour)
+	middle := newPayment(bobOwes.ID, "20.00", 2*time.Hour)
+	newest := newPayment(aliceLent.ID, "30.00", time.Hour)
+
+	debtHandler := handlers.NewDebtHandler(f.debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		userID, err := uuid.Parse(c.GetHeader("X-Test-User"))
+		require.NoError(t, err)
+		c.Set("user_id", userID)
+	})
+	router.GET("/api/v1/verifications/pending", debtHandler.GetPendingVerificationQueue)
+
+	type queueResponse struct {
+		Data []entities.PendingVerification `json:"data"`
+		Meta handlers.PaginationMeta        `json:"meta"`
+	}
+	get := func(userID uuid.UUID, query string) (int, queueResponse) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/verifications/pending"+query, nil)
+		req.Header.Set("X-Test-User", userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var body queueResponse
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return w.Code, body
+	}
+	ids := func(queue []entities.PendingVerification) []uuid.UUID {
+		result := make([]uuid.UUID, len(queue))
+		for i, entry := range queue {
+			result[i] = entry.Payment.ID
+		}
+		return result
+	}
+
+	// Newest submissions come first by default
+	code, body := get(aliceID, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []uuid.UUID{newest, middle, oldest}, ids(body.Data))
+	assert.Equal(t, 3, body.Meta.Total)
+
+	code, body = get(aliceID, "?order=asc")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []uuid.UUID{oldest, middle, newest}, ids(body.Data))
+
+	// Pages follow the requested order
+	code, body = get(aliceID, "?order=asc&limit=2")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []uuid.UUID{oldest, middle}, ids(body.Data))
+	assert.Equal(t, 3, body.Meta.Total)
+	require.Equal(t, "2", body.Meta.NextCursor)
+
+	code, body = get(aliceID, "?order=asc&limit=2&cursor="+body.Meta.NextCursor)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []uuid.UUID{newest}, ids(body.Data))
+	assert.Empty(t, body.Meta.NextCursor)
+
+	// Each entry carries the debt and counterparty as Alice sees them
+	code, body = get(aliceID, "?order=asc")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, body.Data, 3)
+
+	lent := body.Data[0]
+	assert.True(t, decimal.RequireFromString("10.00").Equal(lent.ClaimedAmount))
+	assert.Equal(t, "USD", lent.Currency)
+	assert.Equal(t, aliceLent.ID, lent.DebtList.ID)
+	assert.Equal(t, "to_receive", lent.DebtList.DebtType)
+	require.NotNil(t, lent.DebtList.Description)
+	assert.Equal(t, "Concert tickets", *lent.DebtList.Description)
+	require.NotNil(t, lent.Contact.Email)
+	assert.Equal(t, "bob-queue@example.com", *lent.Contact.Email)
+
+	owed := body.Data[1]
+	assert.Equal(t, bobOwes.ID, owed.DebtList.ID)
+	// Bob's "to_pay" debt is money Alice is to receive
+	assert.Equal(t, "to_receive", owed.DebtList.DebtType)
+	assert.Equal(t, "Bob Tester", owed.Contact.Name)
+
+	// Bob has nothing to verify
+	code, body = get(bobID, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Empty(t, body.Data)
+	assert.Equal(t, 0, body.Meta.Total)
+
+	code, _ = get(aliceID, "?order=sideways")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,121 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/database"
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/models"
+)
+
+func TestGetPendingVerifications_OnlyReturnsItemsTheUserVerifies(t *testing.T) {
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	require.NoError(t, database.CreatePendingVerificationIndexes(f.db))
+	// Running the migration again is a no-op
+	require.NoError(t, database.CreatePendingVerificationIndexes(f.db))
+	assert.True(t, f.db.Migrator().HasIndex(&models.DebtItem{}, "idx_debt_items_pending_debt_list"))
+	assert.True(t, f.db.Migrator().HasIndex(&models.DebtList{}, "idx_debt_lists_user_debt_type"))
+
+	register := func(email string) uuid.UUID {
+		resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+			Email:     email,
+			Password:  "password123",
+			FirstName: "Pending",
+			LastName:  "User",
+		})
+		require.NoError(t, err)
+		return resp.User.ID
+	}
+	aliceID := register("alice-pending@example.com")
+	bobID := register("bob-pending@example.com")
+	carolID := register("carol-pending@example.com")
+
+	newDebtList := func(ownerID, contactID uuid.UUID, debtType string) uuid.UUID {
+		debtList := models.DebtList{
+			ID:                 uuid.New(),
+			UserID:             ownerID,
+			ContactID:          contactID,
+			DebtType:           debtType,
+			TotalAmount:        decimal.RequireFromString("100.00"),
+			InstallmentAmount:  decimal.RequireFromString("100.00"),
+			TotalRemainingDebt: decimal.RequireFromString("100.00"),
+			Currency:           "USD",
+			DueDate:            time.Now().AddDate(0, 1, 0),
+			NextPaymentDate:    time.Now().AddDate(0, 1, 0),
+			InstallmentPlan:    "onetime",
+		}
+		require.NoError(t, f.db.Create(&debtList).Error)
+		return debtList.ID
+	}
+	newPayment := func(debtListID uuid.UUID, status string, age time.Duration) uuid.UUID {
+		item := models.DebtItem{
+			ID:          uuid.New(),
+			DebtListID:  debtListID,
+			Amount:      decimal.RequireFromString("10.00"),
+			Currency:    "USD",
+			PaymentDate: time.Now(),
+			Status:      status,
+			CreatedAt:   time.Now().Add(-age),
+		}
+		require.NoError(t, f.db.Create(&item).Error)
+		return item.ID
+	}
+
+	// Alice lent to Bob: Alice verifies the payments on it
+	aliceLent := newDebtList(aliceID, f.contactFor(aliceID, "bob-pending@example.com"), "to_receive")
+	// Bob recorded that he owes Alice: Alice, as his contact, verifies them
+	bobOwes := newDebtList(bobID, f.contactFor(bobID, "alice-pending@example.com"), "to_pay")
+	// Alice recorded that she owes Carol: Carol, as her contact, verifies them
+	aliceOwes := newDebtList(aliceID, f.contactFor(aliceID, "carol-pending@example.com"), "to_pay")
+	// Carol lent to Bob: Carol verifies the payments on it
+	carolLent := newDebtList(carolID, f.contactFor(carolID, "bob-pending@example.com"), "to_receive")
+
+	older := newPayment(aliceLent, entities.PaymentStatusPending, 2*time.Hour)
+	newer := newPayment(bobOwes, entities.PaymentStatusPending, time.Hour)
+	newPayment(aliceLent, entities.PaymentStatusCompleted, 3*time.Hour)
+	newPayment(bobOwes, entities.PaymentStatusRejected, 3*time.Hour)
+	owedToCarol := newPayment(aliceOwes, entities.PaymentStatusPending, time.Hour)
+	carolsOwn := newPayment(carolLent, entities.PaymentStatusPending, 2*time.Hour)
+
+	ids := func(items []entities.DebtItem) []uuid.UUID {
+		result := make([]uuid.UUID, len(items))
+		for i, item := range items {
+			result[i] = item.ID
+		}
+		return result
+	}
+
+	pending, err := f.debtItemRepo.GetPendingVerifications(ctx, aliceID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{newer, older}, ids(pending))
+
+	pending, err = f.debtItemRepo.GetPendingVerifications(ctx, carolID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{owedToCarol, carolsOwn}, ids(pending))
+
+	// Bob is the debtor on every debt he is part of, so he verifies nothing
+	pending, err = f.debtItemRepo.GetPendingVerifications(ctx, bobID)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	// Every returned item is one the user is allowed to verify
+	for _, userID := range []uuid.UUID{aliceID, carolID} {
+		pending, err = f.debtItemRepo.GetPendingVerifications(ctx, userID)
+		require.NoError(t, err)
+		for _, item := range pending {
+			canVerify, err := f.debtItemRepo.CanUserVerifyDebtItem(ctx, item.ID, userID)
+			require.NoError(t, err)
+			assert.True(t, canVerify)
+		}
+	}
+}
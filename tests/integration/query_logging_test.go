@@ -0,0 +1,102 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"pay-your-dues/internal/database"
+	"pay-your-dues/internal/models"
+)
+
+func TestDatabaseQueryLogging(t *testing.T) {
+	// openWithLogging opens a database whose queries are logged to the returned buffer
+	openWithLogging := func(t *testing.T, enabled bool, slowThreshold time.Duration) (*gorm.DB, *bytes.Buffer) {
+		var logs bytes.Buffer
+		queryLogger := database.NewQueryLogger(zerolog.New(&logs).Level(zerolog.DebugLevel), enabled, slowThreshold)
+		db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: queryLogger})
+		require.NoError(t, err)
+		require.NoError(t, db.AutoMigrate(&models.User{}))
+		logs.Reset()
+		return db, &logs
+	}
+	entries := func(t *testing.T, logs *bytes.Buffer) []map[string]interface{} {
+		var result []map[string]interface{}
+		for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			var entry map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(line), &entry))
+			result = append(result, entry)
+		}
+		return result
+	}
+
+	t.Run("queries are logged at debug level when enabled", func(t *testing.T) {
+		db, logs := openWithLogging(t, true, time.Hour)
+
+		var count int64
+		require.NoError(t, db.WithContext(context.Background()).Model(&models.User{}).Where("email = ?", "someone@example.com").Count(&count).Error)
+
+		logged := entries(t, logs)
+		require.Len(t, logged, 1)
+		assert.Equal(t, "debug", logged[0]["level"])
+		assert.Equal(t, "database", logged[0]["component"])
+		assert.Contains(t, logged[0]["sql"], "SELECT count(*) FROM `users`")
+		// Parameters are left out of the log
+		assert.NotContains(t, logged[0]["sql"], "someone@example.com")
+		assert.Contains(t, logged[0], "elapsed")
+	})
+
+	t.Run("queries over the slow threshold are logged as warnings", func(t *testing.T) {
+		db, logs := openWithLogging(t, true, time.Nanosecond)
+
+		var users []models.User
+		require.NoError(t, db.Find(&users).Error)
+
+		logged := entries(t, logs)
+		require.Len(t, logged, 1)
+		assert.Equal(t, "warn", logged[0]["level"])
+		assert.Equal(t, "Slow query", logged[0]["message"])
+	})
+
+	t.Run("failed queries are logged as errors", func(t *testing.T) {
+		db, logs := openWithLogging(t, true, 0)
+
+		require.Error(t, db.Exec("SELECT * FROM missing_table").Error)
+
+		logged := entries(t, logs)
+		require.Len(t, logged, 1)
+		assert.Equal(t, "error", logged[0]["level"])
+		assert.Contains(t, logged[0]["error"], "missing_table")
+	})
+
+	t.Run("only failed and slow queries are logged when disabled", func(t *testing.T) {
+		db, logs := openWithLogging(t, false, time.Hour)
+
+		var users []models.User
+		require.NoError(t, db.Find(&users).Error)
+		assert.Empty(t, logs.String())
+
+		require.Error(t, db.Exec("SELECT * FROM missing_table").Error)
+		logged := entries(t, logs)
+		require.Len(t, logged, 1)
+		assert.Equal(t, "error", logged[0]["level"])
+
+		db, logs = openWithLogging(t, false, time.Nanosecond)
+		require.NoError(t, db.Find(&users).Error)
+		logged = entries(t, logs)
+		require.Len(t, logged, 1)
+		assert.Equal(t, "Slow query", logged[0]["message"])
+	})
+}
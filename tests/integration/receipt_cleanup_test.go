@@ -0,0 +1,125 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+	"pay-your-dues/internal/services"
+)
+
+func TestReceiptCleanup_OnlyTargetsReceiptsPastRetention(t *testing.T) {
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	registered, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "retention@example.com",
+		Password:  "password123",
+		FirstName: "Rita",
+		LastName:  "Tention",
+	})
+	require.NoError(t, err)
+	userID := registered.User.ID
+
+	contact, err := f.contactService.CreateContact(ctx, userID, &entities.CreateContactRequest{Name: "Borrower"})
+	require.NoError(t, err)
+
+	// createDebt records a payment of amount against a 100.00 debt and backdates
+	// the debt's last update, which marks when a settled debt was settled
+	createDebt := func(amount string, receiptURL *string, lastUpdated time.Time) (*entities.DebtList, *entities.DebtItem) {
+		debtList, err := f.debtService.CreateDebtList(ctx, userID, &entities.CreateDebtListRequest{
+			ContactID:   contact.ID,
+			DebtType:    "to_receive",
+			TotalAmount: "100.00",
+			Currency:    "USD",
+			DueDate:     timePtr(time.Now().AddDate(0, 1, 0)),
+		})
+		require.NoError(t, err)
+
+		payment, err := f.debtService.CreateDebtItem(ctx, userID, &entities.CreateDebtItemRequest{
+			DebtListID:      debtList.ID,
+			Amount:          amount,
+			Currency:        "USD",
+			PaymentDate:     time.Now(),
+			PaymentMethod:   "cash",
+			ReceiptPhotoURL: receiptURL,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, f.db.Model(&models.DebtList{}).Where("id = ?", debtList.ID).UpdateColumn("updated_at", lastUpdated).Error)
+		return debtList, payment
+	}
+
+	receiptURL := func() *string {
+		url := "/api/v1/debts/" + uuid.New().String() + "/receipts/20240101-000000-" + uuid.New().String() + ".jpg"
+		return &url
+	}
+
+	retention := 90 * 24 * time.Hour
+	longAgo := time.Now().Add(-retention - 10*24*time.Hour)
+	recently := time.Now().Add(-retention + 10*24*time.Hour)
+
+	expiredList, expired := createDebt("100.00", receiptURL(), longAgo)
+	_, failing := createDebt("100.00", receiptURL(), longAgo)
+	_, withoutReceipt := createDebt("100.00", nil, longAgo)
+	_, recent := createDebt("100.00", receiptURL(), recently)
+	activeList, active := createDebt("40.00", receiptURL(), longAgo)
+
+	settled, err := f.debtListRepo.GetByID(ctx, expiredList.ID)
+	require.NoError(t, err)
+	require.Equal(t, "settled", settled.Status)
+	stillActive, err := f.debtListRepo.GetByID(ctx, activeList.ID)
+	require.NoError(t, err)
+	require.Equal(t, "active", stillActive.Status)
+
+	fileStorage := &mocks.MockFileStorageService{}
+	fileStorage.On("DeleteReceipt", mock.Anything, *expired.ReceiptPhotoURL).Return(nil)
+	fileStorage.On("DeleteReceipt", mock.Anything, *failing.ReceiptPhotoURL).Return(errors.New("access denied"))
+	cleanup := services.NewReceiptCleanupService(f.debtItemRepo, fileStorage, retention, zerolog.Nop())
+
+	receiptPhotoURL := func(id uuid.UUID) *string {
+		item, err := f.debtItemRepo.GetByID(ctx, id)
+		require.NoError(t, err)
+		return item.ReceiptPhotoURL
+	}
+
+	// A dry run reports the settled receipts past retention without touching them
+	result, err := cleanup.CleanupReceipts(ctx, true)
+	require.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.ElementsMatch(t, []string{*expired.ReceiptPhotoURL, *failing.ReceiptPhotoURL}, result.ReceiptURLs)
+	assert.Zero(t, result.Deleted)
+	fileStorage.AssertNotCalled(t, "DeleteReceipt", mock.Anything, mock.Anything)
+	assert.Equal(t, expired.ReceiptPhotoURL, receiptPhotoURL(expired.ID))
+
+	// A real run deletes them and clears the URLs of the ones that were removed
+	result, err = cleanup.CleanupReceipts(ctx, false)
+	require.NoError(t, err)
+	assert.False(t, result.DryRun)
+	assert.ElementsMatch(t, []string{*expired.ReceiptPhotoURL, *failing.ReceiptPhotoURL}, result.ReceiptURLs)
+	assert.Equal(t, 1, result.Deleted)
+	assert.Equal(t, 1, result.Failed)
+	fileStorage.AssertNumberOfCalls(t, "DeleteReceipt", 2)
+
+	assert.Nil(t, receiptPhotoURL(expired.ID))
+	assert.Equal(t, failing.ReceiptPhotoURL, receiptPhotoURL(failing.ID))
+	assert.Nil(t, receiptPhotoURL(withoutReceipt.ID))
+	assert.Equal(t, recent.ReceiptPhotoURL, receiptPhotoURL(recent.ID))
+	assert.Equal(t, active.ReceiptPhotoURL, receiptPhotoURL(active.ID))
+
+	// Only the failed deletion is retried on the next run
+	result, err = cleanup.CleanupReceipts(ctx, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{*failing.ReceiptPhotoURL}, result.ReceiptURLs)
+}
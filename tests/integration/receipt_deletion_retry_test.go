@@ -0,0 +1,151 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/domain/interfaces"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+	"pay-your-dues/internal/repository"
+	"pay-your-dues/internal/services"
+)
+
+func TestFailedReceiptDeletionsAreRetried(t *testing.T) {
+	ctx := context.Background()
+	errStorage := errors.New("storage unavailable")
+
+	// setup returns a debt service whose receipt deletions go through a retry queue
+	// with an hour's backoff, and a payment on one of its debts with a receipt
+	setup := func(t *testing.T, storage *mocks.MockFileStorageService, maxAttempts int) (*gorm.DB, interfaces.DebtService, interfaces.ReceiptDeletionService, *entities.DebtItem, uuid.UUID) {
+		db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		require.NoError(t, err)
+		require.NoError(t, db.AutoMigrate(&models.User{}, &models.Contact{}, &models.UserContact{}, &models.DebtList{}, &models.DebtItem{}, &models.PendingDeletion{}, &models.Deletion{}))
+
+		userRepo := repository.NewUserRepositoryGORM(db)
+		contactRepo := repository.NewContactRepositoryGORM(db)
+		contactService := services.NewContactService(contactRepo, userRepo)
+		authService, err := services.NewAuthService(userRepo, contactService, "test-secret", "24h")
+		require.NoError(t, err)
+		receiptDeletionService := services.NewReceiptDeletionService(repository.NewPendingDeletionRepositoryGORM(db), storage, time.Hour, maxAttempts, zerolog.Nop())
+		debtService := services.NewDebtService(repository.NewDebtListRepositoryGORM(db, contactRepo), repository.NewDebtItemRepositoryGORM(db), contactRepo,
+			services.NewPaymentScheduleService(), storage,
+			services.WithSoftDeletePayments(false),
+			services.WithReceiptDeletionService(receiptDeletionService))
+
+		resp, err := authService.Register(ctx, &entities.CreateUserRequest{
+			Email:     "alice-receipts@example.com",
+			Password:  "password123",
+			FirstName: "Alice",
+			LastName:  "Receipts",
+		})
+		require.NoError(t, err)
+		aliceID := resp.User.ID
+		bob, err := contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{Name: "Bob"})
+		require.NoError(t, err)
+		debtList, err := debtService.CreateDebtList(ctx, aliceID, &entities.CreateDebtListRequest{
+			ContactID:   bob.ID,
+			DebtType:    "to_receive",
+			TotalAmount: "100.00",
+			Currency:    "USD",
+			DueDate:     timePtr(time.Now().AddDate(0, 1, 0)),
+		})
+		require.NoError(t, err)
+		payment, err := debtService.CreateDebtItem(ctx, aliceID, &entities.CreateDebtItemRequest{
+			DebtListID:      debtList.ID,
+			Amount:          "40.00",
+			Currency:        "USD",
+			PaymentDate:     time.Now(),
+			PaymentMethod:   "cash",
+			ReceiptPhotoURL: stringPtr("https://bucket.example/receipts/one.jpg"),
+		})
+		require.NoError(t, err)
+
+		return db, debtService, receiptDeletionService, payment, aliceID
+	}
+	queued := func(t *testing.T, db *gorm.DB) []models.PendingDeletion {
+		var pendingDeletions []models.PendingDeletion
+		require.NoError(t, db.Find(&pendingDeletions).Error)
+		return pendingDeletions
+	}
+	// makeDue moves every queued deletion's next attempt into the past
+	makeDue := func(t *testing.T, db *gorm.DB) {
+		require.NoError(t, db.Model(&models.PendingDeletion{}).Where("1 = 1").UpdateColumn("next_attempt_at", time.Now().Add(-time.Minute)).Error)
+	}
+
+	t.Run("failed deletion is queued and retried after the backoff", func(t *testing.T) {
+		storage := &mocks.MockFileStorageService{}
+		storage.On("DeleteReceipt", mock.Anything, "https://bucket.example/receipts/one.jpg").Return(errStorage).Once()
+		db, debtService, receiptDeletionService, payment, aliceID := setup(t, storage, 5)
+
+		// The payment is purged even though its receipt is not
+		require.NoError(t, debtService.DeleteDebtItem(ctx, payment.ID, aliceID))
+
+		pending := queued(t, db)
+		require.Len(t, pending, 1)
+		assert.Equal(t, "https://bucket.example/receipts/one.jpg", pending[0].FileURL)
+		assert.Equal(t, 1, pending[0].Attempts)
+		assert.Equal(t, errStorage.Error(), pending[0].LastError)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), pending[0].NextAttemptAt, time.Minute)
+
+		// Nothing is retried before the backoff has passed
+		result, err := receiptDeletionService.RetryPendingDeletions(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, entities.PendingDeletionRetryResult{}, *result)
+		storage.AssertNumberOfCalls(t, "DeleteReceipt", 1)
+
+		makeDue(t, db)
+		storage.On("DeleteReceipt", mock.Anything, "https://bucket.example/receipts/one.jpg").Return(nil).Once()
+		result, err = receiptDeletionService.RetryPendingDeletions(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Deleted)
+		storage.AssertNumberOfCalls(t, "DeleteReceipt", 2)
+		assert.Empty(t, queued(t, db))
+	})
+
+	t.Run("repeated failures back off and are dead-lettered", func(t *testing.T) {
+		storage := &mocks.MockFileStorageService{}
+		storage.On("DeleteReceipt", mock.Anything, mock.Anything).Return(errStorage)
+		db, debtService, receiptDeletionService, payment, aliceID := setup(t, storage, 3)
+
+		require.NoError(t, debtService.DeleteDebtItem(ctx, payment.ID, aliceID))
+
+		// The second failure waits twice the backoff
+		makeDue(t, db)
+		result, err := receiptDeletionService.RetryPendingDeletions(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Failed)
+		pending := queued(t, db)
+		require.Len(t, pending, 1)
+		assert.Equal(t, 2, pending[0].Attempts)
+		assert.WithinDuration(t, time.Now().Add(2*time.Hour), pending[0].NextAttemptAt, time.Minute)
+		assert.Nil(t, pending[0].DeadLetteredAt)
+
+		makeDue(t, db)
+		result, err = receiptDeletionService.RetryPendingDeletions(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.DeadLettered)
+		pending = queued(t, db)
+		require.Len(t, pending, 1)
+		assert.Equal(t, 3, pending[0].Attempts)
+		assert.NotNil(t, pending[0].DeadLetteredAt)
+
+		// Dead-lettered deletions are kept but no longer attempted
+		makeDue(t, db)
+		result, err = receiptDeletionService.RetryPendingDeletions(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, entities.PendingDeletionRetryResult{}, *result)
+		storage.AssertNumberOfCalls(t, "DeleteReceipt", 3)
+	})
+}
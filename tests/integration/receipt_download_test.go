@@ -0,0 +1,71 @@
+package integration
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+)
+
+func TestReceiptDownloadDisposition(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	debtID := uuid.New()
+	storedName := "20240101-000000-" + uuid.New().String() + ".jpg"
+	missingName := "20240101-000000-" + uuid.New().String() + ".png"
+	receiptPath := func(name string) string {
+		return "/api/v1/debts/" + debtID.String() + "/receipts/" + name
+	}
+
+	mockFileStorageService := &mocks.MockFileStorageService{}
+	mockFileStorageService.On("GetReceiptFile", mock.Anything, mock.Anything).Return([]byte("\xff\xd8\xff\xe0 receipt"), "image/jpeg", nil)
+	mockFileStorageService.On("GetReceiptFilename", mock.Anything, receiptPath(storedName)).Return("lunch receipt.jpg", nil)
+	mockFileStorageService.On("GetReceiptFilename", mock.Anything, receiptPath(missingName)).Return("", errors.New("no metadata"))
+	debtHandler := handlers.NewDebtHandler(&mocks.MockDebtService{}, mockFileStorageService, zerolog.Nop())
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", uuid.New())
+	})
+	router.GET("/api/v1/debts/:id/receipts/:filename", debtHandler.GetReceiptPhoto)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Receipts are shown inline by default
+	w := get(receiptPath(storedName))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "inline", w.Header().Get("Content-Disposition"))
+
+	w = get(receiptPath(storedName) + "?download=false")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "inline", w.Header().Get("Content-Disposition"))
+
+	// Downloads are saved under the name the receipt was uploaded with
+	w = get(receiptPath(storedName) + "?download=true")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `attachment; filename="lunch receipt.jpg"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "image/jpeg", w.Header().Get("Content-Type"))
+
+	// Without a recorded name the stored filename is used
+	w = get(receiptPath(missingName) + "?download=true")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "attachment; filename="+missingName, w.Header().Get("Content-Disposition"))
+
+	w = get(receiptPath(storedName) + "?download=maybe")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
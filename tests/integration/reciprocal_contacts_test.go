@@ -0,0 +1,177 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/domain/interfaces"
+	"pay-your-dues/internal/models"
+	"pay-your-dues/internal/repository"
+	"pay-your-dues/internal/services"
+)
+
+func TestReciprocalContactsFlag(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T, enabled bool) (interfaces.ContactService, interfaces.AuthService) {
+		db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		require.NoError(t, err)
+		require.NoError(t, db.AutoMigrate(&models.User{}, &models.Contact{}, &models.UserContact{}))
+
+		userRepo := repository.NewUserRepositoryGORM(db)
+		contactRepo := repository.NewContactRepositoryGORM(db)
+		contactService := services.NewContactService(contactRepo, userRepo, services.WithReciprocalContacts(enabled))
+		authService, err := services.NewAuthService(userRepo, contactService, "test-secret", "24h")
+		require.NoError(t, err)
+		return contactService, authService
+	}
+	register := func(t *testing.T, authService interfaces.AuthService, email, firstName string) uuid.UUID {
+		resp, err := authService.Register(ctx, &entities.CreateUserRequest{
+			Email:     email,
+			Password:  "password123",
+			FirstName: firstName,
+			LastName:  "Reciprocal",
+		})
+		require.NoError(t, err)
+		return resp.User.ID
+	}
+	contactsOf := func(t *testing.T, contactService interfaces.ContactService, userID uuid.UUID) []entities.ContactResponse {
+		contacts, err := contactService.GetUserContacts(ctx, userID)
+		require.NoError(t, err)
+		return contacts
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		contactService, authService := setup(t, true)
+
+		// Alice adds Bob before he registers: registering links him back
+		aliceID := register(t, authService, "alice-reciprocal@example.com", "Alice")
+		bob, err := contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{
+			Name:  "Bob",
+			Email: stringPtr("bob-reciprocal@example.com"),
+		})
+		require.NoError(t, err)
+		assert.False(t, bob.IsUser)
+
+		bobID := register(t, authService, "bob-reciprocal@example.com", "Bob")
+		bobContacts := contactsOf(t, contactService, bobID)
+		require.Len(t, bobContacts, 1)
+		assert.Equal(t, "alice-reciprocal@example.com", *bobContacts[0].Email)
+		linked, err := contactService.GetContact(ctx, bob.ID, aliceID)
+		require.NoError(t, err)
+		assert.True(t, linked.IsUser)
+		require.NotNil(t, linked.UserIDRef)
+		assert.Equal(t, bobID, *linked.UserIDRef)
+
+		// Adding an already registered user links them straight away
+		carolID := register(t, authService, "carol-reciprocal@example.com", "Carol")
+		carol, err := contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{
+			Name:  "Carol",
+			Email: stringPtr("carol-reciprocal@example.com"),
+		})
+		require.NoError(t, err)
+		assert.True(t, carol.IsUser)
+		carolContacts := contactsOf(t, contactService, carolID)
+		require.Len(t, carolContacts, 1)
+		assert.Equal(t, "Alice Reciprocal", carolContacts[0].Name)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		contactService, authService := setup(t, false)
+
+		aliceID := register(t, authService, "alice-reciprocal@example.com", "Alice")
+		bob, err := contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{
+			Name:  "Bob",
+			Email: stringPtr("bob-reciprocal@example.com"),
+		})
+		require.NoError(t, err)
+
+		// Registering does not link Bob to Alice's contact or give him one of her
+		bobID := register(t, authService, "bob-reciprocal@example.com", "Bob")
+		assert.Empty(t, contactsOf(t, contactService, bobID))
+		unlinked, err := contactService.GetContact(ctx, bob.ID, aliceID)
+		require.NoError(t, err)
+		assert.False(t, unlinked.IsUser)
+		assert.Nil(t, unlinked.UserIDRef)
+
+		// Nor does adding or updating a contact with a registered user's email
+		carolID := register(t, authService, "carol-reciprocal@example.com", "Carol")
+		carol, err := contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{
+			Name:  "Carol",
+			Email: stringPtr("carol-reciprocal@example.com"),
+		})
+		require.NoError(t, err)
+		assert.False(t, carol.IsUser)
+		assert.Nil(t, carol.UserIDRef)
+
+		updated, err := contactService.UpdateContact(ctx, bob.ID, aliceID, &entities.UpdateContactRequest{
+			Email: stringPtr("carol-reciprocal@example.com"),
+		})
+		require.NoError(t, err)
+		assert.False(t, updated.IsUser)
+		assert.Empty(t, contactsOf(t, contactService, carolID))
+	})
+}
+
+func TestUpdateContactUnlinksWithReciprocalContactsDisabled(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFixture(t)
+
+	// Bob's contact was linked while reciprocal contacts were still enabled
+	aliceID := f.register("alice-unlink@example.com", "Alice")
+	bobID := f.register("bob-unlink@example.com", "Bob")
+	f.register("carol-unlink@example.com", "Carol")
+	bobContactID := f.contactFor(aliceID, "bob-unlink@example.com")
+	debtList, err := f.debtService.CreateDebtList(ctx, aliceID, &entities.CreateDebtListRequest{
+		ContactID:   bobContactID,
+		DebtType:    "to_receive",
+		TotalAmount: "100.00",
+		Currency:    "USD",
+		DueDate:     timePtr(time.Now().AddDate(0, 1, 0)),
+	})
+	require.NoError(t, err)
+	isContact, err := f.debtListRepo.IsContactOfDebtList(ctx, debtList.ID, bobID)
+	require.NoError(t, err)
+	require.True(t, isContact)
+
+	contactService := services.NewContactService(f.contactRepo, f.userRepo, services.WithReciprocalContacts(false))
+
+	// Keeping Bob's email keeps the link
+	kept, err := contactService.UpdateContact(ctx, bobContactID, aliceID, &entities.UpdateContactRequest{
+		Email: stringPtr("bob-unlink@example.com"),
+	})
+	require.NoError(t, err)
+	assert.True(t, kept.IsUser)
+
+	// Another user's email unlinks Bob without linking Carol
+	updated, err := contactService.UpdateContact(ctx, bobContactID, aliceID, &entities.UpdateContactRequest{
+		Email: stringPtr("carol-unlink@example.com"),
+	})
+	require.NoError(t, err)
+	assert.False(t, updated.IsUser)
+	assert.Nil(t, updated.UserIDRef)
+	isContact, err = f.debtListRepo.IsContactOfDebtList(ctx, debtList.ID, bobID)
+	require.NoError(t, err)
+	assert.False(t, isContact)
+
+	// As does an email that belongs to no user
+	contact, err := f.contactRepo.GetByID(ctx, bobContactID)
+	require.NoError(t, err)
+	contact.IsUser = true
+	contact.UserIDRef = &bobID
+	require.NoError(t, f.contactRepo.Update(ctx, contact))
+	updated, err = contactService.UpdateContact(ctx, bobContactID, aliceID, &entities.UpdateContactRequest{
+		Email: stringPtr("nobody-unlink@example.com"),
+	})
+	require.NoError(t, err)
+	assert.False(t, updated.IsUser)
+	assert.Nil(t, updated.UserIDRef)
+}
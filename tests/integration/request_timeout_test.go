@@ -0,0 +1,64 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/middleware"
+	"pay-your-dues/internal/mocks"
+)
+
+func TestRouteGroupTimeouts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+
+	// The service records how long the handler gave it to work
+	var budget time.Duration
+	mockDebtService := &mocks.MockDebtService{}
+	mockDebtService.On("GetDebtCounts", mock.Anything, userID).Run(func(args mock.Arguments) {
+		deadline, ok := args.Get(0).(context.Context).Deadline()
+		require.True(t, ok, "handler context has no deadline")
+		budget = time.Until(deadline)
+	}).Return(&entities.DebtStatusCounts{}, nil)
+	debtHandler := handlers.NewDebtHandler(mockDebtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+	})
+	router.GET("/default/counts", debtHandler.GetDebtCounts)
+	api := router.Group("/api", middleware.Timeout(5*time.Second))
+	api.GET("/counts", debtHandler.GetDebtCounts)
+	analytics := api.Group("/analytics", middleware.Timeout(2*time.Minute))
+	analytics.GET("/counts", debtHandler.GetDebtCounts)
+
+	budgetFor := func(path string) time.Duration {
+		budget = 0
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		return budget
+	}
+
+	// Without a configured timeout handlers fall back to the default
+	assert.InDelta(t, handlers.DefaultRequestTimeout.Seconds(), budgetFor("/default/counts").Seconds(), 1)
+
+	// A group's timeout applies to its routes
+	assert.InDelta(t, (5 * time.Second).Seconds(), budgetFor("/api/counts").Seconds(), 1)
+
+	// A nested group's timeout overrides its parent's
+	assert.InDelta(t, (2 * time.Minute).Seconds(), budgetFor("/api/analytics/counts").Seconds(), 1)
+}
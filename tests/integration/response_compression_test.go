@@ -0,0 +1,125 @@
+package integration
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/middleware"
+	"pay-your-dues/internal/mocks"
+)
+
+func TestResponseCompression(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := uuid.New()
+	debtLists := make([]entities.DebtListResponse, 200)
+	for i := range debtLists {
+		debtLists[i] = entities.DebtListResponse{
+			ID:          uuid.New(),
+			UserID:      userID,
+			DebtType:    "to_pay",
+			TotalAmount: decimal.RequireFromString("100.00"),
+			Currency:    "USD",
+			Status:      "active",
+		}
+	}
+	receipt := []byte("\xff\xd8\xff\xe0 not really a jpeg")
+
+	mockDebtService := &mocks.MockDebtService{}
+	mockDebtService.On("GetUserDebtLists", mock.Anything, userID).Return(debtLists, nil)
+	mockFileStorageService := &mocks.MockFileStorageService{}
+	mockFileStorageService.On("GetReceiptFile", mock.Anything, mock.Anything).Return(receipt, "image/jpeg", nil)
+	debtHandler := handlers.NewDebtHandler(mockDebtService, mockFileStorageService, zerolog.Nop())
+
+	router := gin.New()
+	router.Use(middleware.Gzip())
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+	})
+	router.GET("/api/v1/debts", debtHandler.GetUserDebtLists)
+	router.GET("/api/v1/debts/:id/receipts/:filename", debtHandler.GetReceiptPhoto)
+
+	get := func(path, acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		return w
+	}
+	decodeList := func(body []byte) (int, float64) {
+		var responseBody struct {
+			Data []entities.DebtListResponse `json:"data"`
+			Meta map[string]interface{}      `json:"meta"`
+		}
+		require.NoError(t, json.Unmarshal(body, &responseBody))
+		return len(responseBody.Data), responseBody.Meta["limit"].(float64)
+	}
+
+	t.Run("large list is gzip-encoded when requested", func(t *testing.T) {
+		w := get("/api/v1/debts?limit=100", "br;q=1.0, gzip;q=0.8")
+
+		assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+		assert.Contains(t, w.Header().Values("Vary"), "Accept-Encoding")
+
+		reader, err := gzip.NewReader(w.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Less(t, w.Body.Len(), len(body))
+
+		count, _ := decodeList(body)
+		assert.Equal(t, 100, count)
+	})
+
+	t.Run("uncompressed without Accept-Encoding", func(t *testing.T) {
+		w := get("/api/v1/debts?limit=100", "")
+
+		assert.Empty(t, w.Header().Get("Content-Encoding"))
+		count, _ := decodeList(w.Body.Bytes())
+		assert.Equal(t, 100, count)
+	})
+
+	t.Run("uncompressed when gzip is refused", func(t *testing.T) {
+		w := get("/api/v1/debts", "gzip;q=0, identity")
+
+		assert.Empty(t, w.Header().Get("Content-Encoding"))
+	})
+
+	t.Run("receipt images are served as is", func(t *testing.T) {
+		w := get("/api/v1/debts/"+uuid.New().String()+"/receipts/receipt.jpg", "gzip")
+
+		assert.Empty(t, w.Header().Get("Content-Encoding"))
+		assert.Equal(t, "image/jpeg", w.Header().Get("Content-Type"))
+		assert.Equal(t, receipt, w.Body.Bytes())
+	})
+
+	t.Run("configured default page size applies without a limit", func(t *testing.T) {
+		handlers.SetDefaultPageSize(20)
+		defer handlers.SetDefaultPageSize(50)
+
+		count, limit := decodeList(get("/api/v1/debts", "").Body.Bytes())
+		assert.Equal(t, 20, count)
+		assert.Equal(t, float64(20), limit)
+
+		// An explicit limit still wins
+		count, _ = decodeList(get("/api/v1/debts?limit=30", "").Body.Bytes())
+		assert.Equal(t, 30, count)
+	})
+}
@@ -0,0 +1,81 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+)
+
+func TestRichTextIsSanitizedBeforeStoring(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "script element", input: "<script>alert(document.cookie)</script>Pay by Friday", expected: "&lt;script&gt;alert(document.cookie)&lt;/script&gt;Pay by Friday"},
+		{name: "event handler attribute", input: `<img src=x onerror="alert(1)">Lunch money`, expected: `&lt;img src=x onerror="alert(1)"&gt;Lunch money`},
+		{name: "unterminated tag", input: `Lunch money <img src=x onerror=alert(1)`, expected: `Lunch money &lt;img src=x onerror=alert(1)`},
+		{name: "entity-encoded tag", input: "&lt;b&gt;Rent&lt;/b&gt; &amp; bills", expected: "&lt;b&gt;Rent&lt;/b&gt; &amp; bills"},
+		{name: "markdown javascript link", input: "See [receipt](javascript:alert(1))", expected: "See [receipt](#alert(1))"},
+		{name: "markdown data link", input: "See [receipt]( DATA:text/html;base64,PHNjcmlwdD4=)", expected: "See [receipt]( #text/html;base64,PHNjcmlwdD4=)"},
+		{name: "scheme split by a tab", input: "See [receipt](java\tscript:alert(1))", expected: "See [receipt](#alert(1))"},
+		{name: "entity-encoded colon", input: "See [receipt](javascript&#58;alert(1))", expected: "See [receipt](#alert(1))"},
+		{name: "reference link", input: "See [receipt]\n\n[receipt]: javascript:alert(1)", expected: "See [receipt]\n\n[receipt]: #alert(1)"},
+		{name: "reference link on the next line", input: "See [receipt]\n\n[receipt]:\n  vbscript:msgbox(1)", expected: "See [receipt]\n\n[receipt]:\n  #msgbox(1)"},
+		{name: "allowed links", input: "See [notes](https://example.com) or [mail](mailto:a@example.com)", expected: "See [notes](https://example.com) or [mail](mailto:a@example.com)"},
+		{name: "plain text", input: "  Split 50 < 60 & rent, see [notes](https://example.com)  ", expected: "Split 50 &lt; 60 &amp; rent, see [notes](https://example.com)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stored *entities.CreateDebtListRequest
+			mockDebtService := &mocks.MockDebtService{}
+			mockDebtService.On("CreateDebtList", mock.Anything, userID, mock.Anything).Run(func(args mock.Arguments) {
+				stored = args.Get(2).(*entities.CreateDebtListRequest)
+			}).Return(&entities.DebtList{ID: uuid.New()}, nil)
+			debtHandler := handlers.NewDebtHandler(mockDebtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set("user_id", userID)
+			})
+			router.POST("/api/v1/debts", debtHandler.CreateDebtList)
+
+			body, err := json.Marshal(map[string]interface{}{
+				"contact_id":   uuid.New(),
+				"debt_type":    "to_receive",
+				"total_amount": "100.00",
+				"description":  tt.input,
+				"notes":        tt.input,
+			})
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/debts", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusCreated, w.Code)
+
+			require.NotNil(t, stored)
+			require.NotNil(t, stored.Description)
+			require.NotNil(t, stored.Notes)
+			assert.Equal(t, tt.expected, *stored.Description)
+			assert.Equal(t, tt.expected, *stored.Notes)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/models"
+)
+
+func TestDebtListRoundingMode(t *testing.T) {
+	ctx := context.Background()
+
+	f := newTestFixture(t, &models.DebtBalance{}, &models.DebtListHistoryEntry{})
+
+	resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "alice-rounding@example.com",
+		Password:  "password123",
+		FirstName: "Alice",
+		LastName:  "Rounder",
+	})
+	require.NoError(t, err)
+	aliceID := resp.User.ID
+	bob, err := f.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{Name: "Bob"})
+	require.NoError(t, err)
+
+	newDebt := func(roundingMode string) *entities.DebtList {
+		debtList, err := f.debtService.CreateDebtList(ctx, aliceID, &entities.CreateDebtListRequest{
+			ContactID:        bob.ID,
+			DebtType:         entities.DebtTypeToReceive,
+			TotalAmount:      "100.00",
+			Currency:         "USD",
+			InstallmentPlan:  "monthly",
+			NumberOfPayments: intPtr(3),
+			RoundingMode:     roundingMode,
+		})
+		require.NoError(t, err)
+		return debtList
+	}
+	assertSchedule := func(t *testing.T, debtListID uuid.UUID, expected ...string) {
+		schedule, err := f.debtService.GetPaymentSchedule(ctx, debtListID, aliceID)
+		require.NoError(t, err)
+		require.Len(t, schedule, len(expected))
+		for i, item := range schedule {
+			assert.True(t, item.ScheduledAmount.Equal(decimal.RequireFromString(expected[i])), "installment %d should be %s, got %s", i+1, expected[i], item.ScheduledAmount)
+		}
+	}
+
+	t.Run("defaults to the last installment", func(t *testing.T) {
+		debtList := newDebt("")
+		stored, err := f.debtListRepo.GetByID(ctx, debtList.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.RoundingModeLast, stored.RoundingMode)
+	})
+
+	t.Run("first installment absorbs the remainder", func(t *testing.T) {
+		debtList := newDebt(entities.RoundingModeFirst)
+		stored, err := f.debtListRepo.GetByID(ctx, debtList.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.RoundingModeFirst, stored.RoundingMode)
+		assert.True(t, stored.InstallmentAmount.Equal(decimal.RequireFromString("33.33")))
+		assertSchedule(t, debtList.ID, "33.34", "33.33", "33.33")
+	})
+
+	t.Run("changing the mode recalculates the installments", func(t *testing.T) {
+		debtList := newDebt(entities.RoundingModeFirst)
+		spread := entities.RoundingModeSpread
+		_, err := f.debtService.UpdateDebtList(ctx, debtList.ID, aliceID, &entities.UpdateDebtListRequest{
+			TotalAmount:  stringPtr("200.00"),
+			RoundingMode: &spread,
+		})
+		require.NoError(t, err)
+		assertSchedule(t, debtList.ID, "66.67", "66.67", "66.66")
+	})
+}
@@ -0,0 +1,151 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/services"
+)
+
+func TestGetPaymentScheduleInDisplayCurrency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	currencyService := services.NewStaticCurrencyService(map[entities.CurrencyPair]decimal.Decimal{
+		{From: "USD", To: "PHP"}: decimal.RequireFromString("56.25"),
+		{From: "EUR", To: "USD"}: decimal.RequireFromString("1.25"),
+	})
+	debtService := f.newDebtService(services.WithCurrencyService(currencyService))
+
+	resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "alice-display@example.com",
+		Password:  "password123",
+		FirstName: "Alice",
+		LastName:  "Display",
+	})
+	require.NoError(t, err)
+	aliceID := resp.User.ID
+	bob, err := f.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{Name: "Bob"})
+	require.NoError(t, err)
+
+	// 300 USD over three monthly installments, the first of them paid
+	debtList, err := debtService.CreateDebtList(ctx, aliceID, &entities.CreateDebtListRequest{
+		ContactID:        bob.ID,
+		DebtType:         "to_receive",
+		TotalAmount:      "300.00",
+		Currency:         "USD",
+		InstallmentPlan:  "monthly",
+		NumberOfPayments: intPtr(3),
+	})
+	require.NoError(t, err)
+	_, err = debtService.CreateDebtItem(ctx, aliceID, &entities.CreateDebtItemRequest{
+		DebtListID:    debtList.ID,
+		Amount:        "100.00",
+		Currency:      "USD",
+		PaymentDate:   time.Now(),
+		PaymentMethod: "cash",
+	})
+	require.NoError(t, err)
+
+	debtHandler := handlers.NewDebtHandler(debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", aliceID)
+	})
+	router.GET("/api/v1/debts/:id/schedule", debtHandler.GetPaymentSchedule)
+
+	get := func(debtListID uuid.UUID, query string) (int, []entities.PaymentScheduleItem) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/debts/"+debtListID.String()+"/schedule?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var body struct {
+			Data []entities.PaymentScheduleItem `json:"data"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return w.Code, body.Data
+	}
+	assertAmount := func(t *testing.T, expected string, actual decimal.Decimal) {
+		assert.True(t, decimal.RequireFromString(expected).Equal(actual), "expected %s, got %s", expected, actual)
+	}
+
+	t.Run("converted when a rate is available", func(t *testing.T) {
+		code, schedule := get(debtList.ID, "display_currency=php")
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, schedule, 3)
+
+		// Stored amounts stay in dollars alongside the converted ones
+		first := schedule[0]
+		assertAmount(t, "100.00", first.ScheduledAmount)
+		require.NotNil(t, first.Display)
+		assert.Equal(t, "PHP", first.Display.Currency)
+		assertAmount(t, "56.25", first.Display.ExchangeRate)
+		assertAmount(t, "5625.00", first.Display.ScheduledAmount)
+		assertAmount(t, "5625.00", first.Display.PaidAmount)
+		assertAmount(t, "0", first.Display.Amount)
+		require.NotNil(t, schedule[1].Display)
+		assertAmount(t, "5625.00", schedule[1].Display.Amount)
+		assertAmount(t, "0", schedule[1].Display.PaidAmount)
+
+		stored, err := f.debtListRepo.GetByID(ctx, debtList.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "USD", stored.Currency)
+		assertAmount(t, "300.00", stored.TotalAmount)
+	})
+
+	t.Run("the whole schedule is returned whatever the page size", func(t *testing.T) {
+		code, schedule := get(debtList.ID, "limit=1&offset=1")
+		require.Equal(t, http.StatusOK, code)
+		assert.Len(t, schedule, 3)
+	})
+
+	t.Run("inverse of a configured rate", func(t *testing.T) {
+		code, schedule := get(debtList.ID, "display_currency=EUR")
+		require.Equal(t, http.StatusOK, code)
+		require.NotNil(t, schedule[1].Display)
+		assertAmount(t, "0.8", schedule[1].Display.ExchangeRate)
+		assertAmount(t, "80.00", schedule[1].Display.Amount)
+	})
+
+	t.Run("original amounts without a rate", func(t *testing.T) {
+		code, schedule := get(debtList.ID, "display_currency=JPY")
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, schedule, 3)
+		for _, item := range schedule {
+			assert.Nil(t, item.Display)
+		}
+		assertAmount(t, "100.00", schedule[1].Amount)
+	})
+
+	t.Run("original amounts without a display currency", func(t *testing.T) {
+		code, schedule := get(debtList.ID, "")
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, schedule, 3)
+		assert.Nil(t, schedule[0].Display)
+	})
+
+	t.Run("invalid display currency", func(t *testing.T) {
+		code, _ := get(debtList.ID, "display_currency=dollars")
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("unknown debt", func(t *testing.T) {
+		code, _ := get(uuid.New(), "display_currency=PHP")
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
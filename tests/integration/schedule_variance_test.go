@@ -0,0 +1,164 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+)
+
+func TestScheduleVariance(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "lender-variance@example.com",
+		Password:  "password123",
+		FirstName: "Variance",
+		LastName:  "Lender",
+	})
+	require.NoError(t, err)
+	lenderID := resp.User.ID
+
+	contact, err := f.contactService.CreateContact(ctx, lenderID, &entities.CreateContactRequest{Name: "Instalment Payer"})
+	require.NoError(t, err)
+	debtList, err := f.debtService.CreateDebtList(ctx, lenderID, &entities.CreateDebtListRequest{
+		ContactID:        contact.ID,
+		DebtType:         "to_receive",
+		TotalAmount:      "400.00",
+		Currency:         "USD",
+		InstallmentPlan:  "monthly",
+		NumberOfPayments: intPtr(4),
+		DueDate:          timePtr(time.Now().AddDate(0, 4, 0)),
+	})
+	require.NoError(t, err)
+
+	// Move the debt into the past so every installment has fallen due
+	created := time.Now().AddDate(0, -5, 0)
+	require.NoError(t, f.db.Model(&models.DebtList{}).Where("id = ?", debtList.ID).Updates(map[string]interface{}{
+		"created_at":        created,
+		"due_date":          created.AddDate(0, 4, 0),
+		"next_payment_date": created.AddDate(0, 1, 0),
+	}).Error)
+
+	schedule, err := f.debtService.GetPaymentSchedule(ctx, debtList.ID, lenderID)
+	require.NoError(t, err)
+	require.Len(t, schedule, 4)
+
+	pay := func(amount string, date time.Time) *entities.DebtItem {
+		payment, err := f.debtService.CreateDebtItem(ctx, lenderID, &entities.CreateDebtItemRequest{
+			DebtListID:    debtList.ID,
+			Amount:        amount,
+			Currency:      "USD",
+			PaymentDate:   date,
+			PaymentMethod: "cash",
+		})
+		require.NoError(t, err)
+		return payment
+	}
+	// The first installment is paid early, the second late and the third only partly
+	pay("100.00", schedule[0].DueDate.AddDate(0, 0, -5))
+	second := pay("100.00", schedule[1].DueDate.AddDate(0, 0, 3))
+	pay("40.00", schedule[2].DueDate.AddDate(0, 0, 1))
+
+	debtHandler := handlers.NewDebtHandler(f.debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		userID, err := uuid.Parse(c.GetHeader("X-Test-User"))
+		require.NoError(t, err)
+		c.Set("user_id", userID)
+	})
+	router.GET("/api/v1/debts/:id/variance", debtHandler.GetScheduleVariance)
+
+	get := func(userID uuid.UUID, debtListID string) (int, []entities.ScheduleVariance) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/debts/"+debtListID+"/variance", nil)
+		req.Header.Set("X-Test-User", userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var body struct {
+			Data []entities.ScheduleVariance `json:"data"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return w.Code, body.Data
+	}
+
+	code, variances := get(lenderID, debtList.ID.String())
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, variances, 4)
+
+	for i, v := range variances {
+		assert.Equal(t, i+1, v.PaymentNumber)
+		assert.True(t, schedule[i].DueDate.Equal(v.ScheduledDate), "installment %d", i+1)
+		assert.True(t, decimal.RequireFromString("100.00").Equal(v.ScheduledAmount), v.ScheduledAmount.String())
+	}
+
+	early := variances[0]
+	assert.Equal(t, entities.VarianceEarly, early.Status)
+	require.NotNil(t, early.DaysVariance)
+	assert.Equal(t, -5, *early.DaysVariance)
+	assert.True(t, decimal.RequireFromString("100.00").Equal(early.ActualAmount), early.ActualAmount.String())
+	assert.True(t, early.AmountVariance.IsZero(), early.AmountVariance.String())
+
+	late := variances[1]
+	assert.Equal(t, entities.VarianceLate, late.Status)
+	require.NotNil(t, late.DaysVariance)
+	assert.Equal(t, 3, *late.DaysVariance)
+	assert.True(t, late.AmountVariance.IsZero(), late.AmountVariance.String())
+
+	partial := variances[2]
+	assert.Equal(t, entities.VariancePartial, partial.Status)
+	require.NotNil(t, partial.DaysVariance)
+	assert.Equal(t, 1, *partial.DaysVariance)
+	assert.True(t, decimal.RequireFromString("40.00").Equal(partial.ActualAmount), partial.ActualAmount.String())
+	assert.True(t, decimal.RequireFromString("-60.00").Equal(partial.AmountVariance), partial.AmountVariance.String())
+
+	unpaid := variances[3]
+	assert.Equal(t, entities.VarianceUnpaid, unpaid.Status)
+	assert.Nil(t, unpaid.ActualDate)
+	assert.Nil(t, unpaid.DaysVariance)
+	assert.True(t, decimal.RequireFromString("-100.00").Equal(unpaid.AmountVariance), unpaid.AmountVariance.String())
+
+	// Reversing 50 of the second payment takes it back from the latest installments
+	// paid, as the payment schedule does
+	_, err = f.debtService.ReversePayment(ctx, second.ID, lenderID, decimal.RequireFromString("50.00"), "Partly bounced")
+	require.NoError(t, err)
+
+	code, variances = get(lenderID, debtList.ID.String())
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, variances, 4)
+	schedule, err = f.debtService.GetPaymentSchedule(ctx, debtList.ID, lenderID)
+	require.NoError(t, err)
+	for i, v := range variances {
+		assert.True(t, schedule[i].PaidAmount.Equal(v.ActualAmount), "installment %d: %s", i+1, v.ActualAmount)
+	}
+
+	assert.Equal(t, entities.VarianceEarly, variances[0].Status)
+	assert.Equal(t, entities.VariancePartial, variances[1].Status)
+	assert.True(t, decimal.RequireFromString("90.00").Equal(variances[1].ActualAmount), variances[1].ActualAmount.String())
+	assert.Equal(t, entities.VarianceUnpaid, variances[2].Status)
+	assert.Nil(t, variances[2].ActualDate)
+	assert.Equal(t, entities.VarianceUnpaid, variances[3].Status)
+
+	// Users without access to the debt cannot see its variance
+	code, _ = get(uuid.New(), debtList.ID.String())
+	assert.Equal(t, http.StatusNotFound, code)
+	code, _ = get(lenderID, "not-a-uuid")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
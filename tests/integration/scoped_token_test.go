@@ -0,0 +1,133 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/middleware"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+	"pay-your-dues/internal/repository"
+	"pay-your-dues/internal/services"
+)
+
+func TestReadOnlyTokenCannotMutate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	f := newTestFixture(t, &models.APIKey{})
+
+	authService, err := services.NewAuthService(f.userRepo, f.contactService, "test-secret", "24h",
+		services.WithAPIKeyRepository(repository.NewAPIKeyRepositoryGORM(f.db)),
+	)
+	require.NoError(t, err)
+
+	registered, err := authService.Register(context.Background(), &entities.CreateUserRequest{
+		Email:     "dashboard@example.com",
+		Password:  "password123",
+		FirstName: "Dash",
+		LastName:  "Board",
+	})
+	require.NoError(t, err)
+	userID := registered.User.ID
+
+	login, err := authService.Login(context.Background(), &entities.LoginRequest{
+		Email:    "dashboard@example.com",
+		Password: "password123",
+	})
+	require.NoError(t, err)
+
+	authHandler := handlers.NewAuthHandler(authService, zerolog.Nop())
+	contactHandler := handlers.NewContactHandler(f.contactService, zerolog.Nop())
+	authMiddleware := middleware.NewAuthMiddleware(authService, zerolog.Nop())
+	requireFull := authMiddleware.RequireScope(entities.ScopeFull)
+
+	router := gin.New()
+	protected := router.Group("/api/v1")
+	protected.Use(authMiddleware.Authenticate())
+	protected.POST("/auth/tokens/read-only", requireFull, authHandler.CreateReadOnlyToken)
+	protected.GET("/contacts", contactHandler.GetUserContacts)
+	protected.POST("/contacts", requireFull, contactHandler.CreateContact)
+
+	serve := func(method, path, token string, body interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var responseBody map[string]interface{}
+		_ = json.Unmarshal(w.Body.Bytes(), &responseBody)
+		return w, responseBody
+	}
+	newContact := map[string]interface{}{"name": "Jane Doe"}
+
+	// Login tokens carry the full scope
+	claims, err := authService.ValidateTokenClaims(context.Background(), login.Token)
+	require.NoError(t, err)
+	assert.Equal(t, userID, claims.UserID)
+	assert.Equal(t, entities.ScopeFull, claims.Scope)
+
+	w, body := serve(http.MethodPost, "/api/v1/auth/tokens/read-only", login.Token, nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+	data := body["data"].(map[string]interface{})
+	assert.Equal(t, entities.ScopeRead, data["scope"])
+	readToken := data["token"].(string)
+
+	// A read token can GET
+	w, _ = serve(http.MethodGet, "/api/v1/contacts", readToken, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// but not POST
+	w, body = serve(http.MethodPost, "/api/v1/contacts", readToken, newContact)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, "Insufficient scope", body["error"])
+
+	// nor mint further tokens
+	w, _ = serve(http.MethodPost, "/api/v1/auth/tokens/read-only", readToken, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// The full token can still write
+	w, _ = serve(http.MethodPost, "/api/v1/contacts", login.Token, newContact)
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestReadScopedAPIKeyCannotMutate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := uuid.New()
+	mockAuthService := &mocks.MockAuthService{}
+	authMiddleware := middleware.NewAuthMiddleware(mockAuthService, zerolog.Nop())
+
+	router := gin.New()
+	protected := router.Group("/api/v1")
+	protected.Use(authMiddleware.Authenticate())
+	protected.GET("/contacts", func(c *gin.Context) { c.Status(http.StatusOK) })
+	protected.POST("/contacts", authMiddleware.RequireScope(entities.ScopeFull), func(c *gin.Context) { c.Status(http.StatusCreated) })
+
+	mockAuthService.On("ValidateAPIKey", mock.Anything, "pyd_read").Return(&entities.APIKey{ID: uuid.New(), UserID: userID, Scopes: []string{entities.ScopeRead}}, nil)
+
+	for method, expected := range map[string]int{http.MethodGet: http.StatusOK, http.MethodPost: http.StatusForbidden} {
+		req := httptest.NewRequest(method, "/api/v1/contacts", nil)
+		req.Header.Set("X-API-Key", "pyd_read")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, expected, w.Code, method)
+	}
+}
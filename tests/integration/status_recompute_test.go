@@ -0,0 +1,92 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/models"
+	"pay-your-dues/internal/workers"
+)
+
+func TestStatusRecomputeJobFlipsStaleDebtsToOverdue(t *testing.T) {
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "lender-recompute-job@example.com",
+		Password:  "password123",
+		FirstName: "Recompute",
+		LastName:  "Lender",
+	})
+	require.NoError(t, err)
+	lenderID := resp.User.ID
+
+	contact, err := f.contactService.CreateContact(ctx, lenderID, &entities.CreateContactRequest{Name: "Quiet Borrower"})
+	require.NoError(t, err)
+
+	newDebt := func() *entities.DebtList {
+		debtList, err := f.debtService.CreateDebtList(ctx, lenderID, &entities.CreateDebtListRequest{
+			ContactID:       contact.ID,
+			DebtType:        "to_receive",
+			TotalAmount:     "100.00",
+			Currency:        "USD",
+			InstallmentPlan: "onetime",
+			DueDate:         timePtr(time.Now().AddDate(0, 0, 7)),
+		})
+		require.NoError(t, err)
+		return debtList
+	}
+	status := func(debtListID uuid.UUID) string {
+		debtList, err := f.debtListRepo.GetByID(ctx, debtListID)
+		require.NoError(t, err)
+		return debtList.Status
+	}
+
+	// The stale debt's schedule is moved into the past without any read or write
+	// through the service, so nothing has recomputed its status yet
+	stale := newDebt()
+	pastDue := time.Now().AddDate(0, 0, -3)
+	require.NoError(t, f.db.Model(&models.DebtList{}).Where("id = ?", stale.ID).Updates(map[string]interface{}{
+		"due_date":          pastDue,
+		"next_payment_date": pastDue,
+		"created_at":        pastDue.AddDate(0, 0, -10),
+	}).Error)
+	current := newDebt()
+	require.Equal(t, "active", status(stale.ID))
+
+	// Run the job the way the server does until it has flipped the stale debt
+	jobCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = workers.NewPeriodicWorker("status-recompute", 5*time.Millisecond, zerolog.Nop(), func(ctx context.Context) error {
+			_, err := f.debtService.RecomputeOverdueStatuses(ctx)
+			return err
+		}).Run(jobCtx)
+	}()
+	assert.Eventually(t, func() bool { return status(stale.ID) == "overdue" }, 2*time.Second, 5*time.Millisecond)
+	cancel()
+	<-done
+
+	// Debts that are not past due are left active
+	assert.Equal(t, "active", status(current.ID))
+
+	overdue, err := f.debtService.GetOverdueItems(ctx, lenderID, "")
+	require.NoError(t, err)
+	require.Len(t, overdue, 1)
+	assert.Equal(t, stale.ID, overdue[0].ID)
+	assert.Equal(t, "overdue", overdue[0].Status)
+
+	// Debts that are already overdue are not picked up again
+	recomputed, err := f.debtService.RecomputeOverdueStatuses(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, recomputed)
+}
@@ -0,0 +1,101 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+)
+
+func TestStrictJSONBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	contactID := uuid.New()
+
+	mockDebtService := &mocks.MockDebtService{}
+	mockDebtService.On("CreateDebtList", mock.Anything, userID, mock.Anything).Return(&entities.DebtList{ID: uuid.New()}, nil)
+	mockContactService := &mocks.MockContactService{}
+	mockContactService.On("UpdateContact", mock.Anything, contactID, userID, mock.Anything).Return(&entities.ContactResponse{ID: contactID}, nil)
+
+	debtHandler := handlers.NewDebtHandler(mockDebtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	contactHandler := handlers.NewContactHandler(mockContactService, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+	})
+	router.POST("/api/v1/debts", debtHandler.CreateDebtList)
+	router.PUT("/api/v1/contacts/:id", contactHandler.UpdateContact)
+
+	send := func(method, path string, body map[string]interface{}) *httptest.ResponseRecorder {
+		encoded, err := json.Marshal(body)
+		require.NoError(t, err)
+		req := httptest.NewRequest(method, path, bytes.NewReader(encoded))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	// The due date is misspelled, so it would silently be left out
+	createDebt := func() *httptest.ResponseRecorder {
+		return send(http.MethodPost, "/api/v1/debts", map[string]interface{}{
+			"contact_id":   uuid.New(),
+			"debt_type":    "to_receive",
+			"total_amount": "100.00",
+			"due_dat":      "2030-01-01T00:00:00Z",
+		})
+	}
+	updateContact := func() *httptest.ResponseRecorder {
+		return send(http.MethodPut, "/api/v1/contacts/"+contactID.String(), map[string]interface{}{
+			"name":  "Bob",
+			"phnoe": "+15550100",
+		})
+	}
+
+	t.Run("unknown fields are ignored by default", func(t *testing.T) {
+		assert.Equal(t, http.StatusCreated, createDebt().Code)
+		assert.Equal(t, http.StatusOK, updateContact().Code)
+	})
+
+	t.Run("strict mode rejects unknown fields", func(t *testing.T) {
+		handlers.SetStrictJSONBinding(true)
+		defer handlers.SetStrictJSONBinding(false)
+
+		w := createDebt()
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		var response handlers.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Errors, 1)
+		assert.Equal(t, "due_dat", response.Errors[0].Field)
+		assert.Equal(t, "due_dat is not a known field", response.Errors[0].Message)
+
+		assert.Equal(t, http.StatusBadRequest, updateContact().Code)
+	})
+
+	t.Run("strict mode accepts known fields", func(t *testing.T) {
+		handlers.SetStrictJSONBinding(true)
+		defer handlers.SetStrictJSONBinding(false)
+
+		w := send(http.MethodPost, "/api/v1/debts", map[string]interface{}{
+			"contact_id":   uuid.New(),
+			"debt_type":    "to_receive",
+			"total_amount": "100.00",
+			"due_date":     "2030-01-01T00:00:00Z",
+		})
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	mockDebtService.AssertNumberOfCalls(t, "CreateDebtList", 2)
+	mockContactService.AssertNumberOfCalls(t, "UpdateContact", 1)
+}
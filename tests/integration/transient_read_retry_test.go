@@ -0,0 +1,91 @@
+package integration
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/models"
+)
+
+func TestRepositoryReads_RetryTransientErrors(t *testing.T) {
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	debtList := models.DebtList{
+		ID:                 uuid.New(),
+		UserID:             uuid.New(),
+		ContactID:          uuid.New(),
+		DebtType:           "to_receive",
+		TotalAmount:        decimal.RequireFromString("100.00"),
+		InstallmentAmount:  decimal.RequireFromString("100.00"),
+		TotalRemainingDebt: decimal.RequireFromString("100.00"),
+		Currency:           "USD",
+		DueDate:            time.Now().AddDate(0, 1, 0),
+		NextPaymentDate:    time.Now().AddDate(0, 1, 0),
+		InstallmentPlan:    "onetime",
+	}
+	require.NoError(t, f.db.Create(&debtList).Error)
+
+	// failQueries makes the next n queries fail with err before reaching the database
+	var attempts, failures int
+	var failWith error
+	require.NoError(t, f.db.Callback().Query().Before("gorm:query").Register("test:fail_queries", func(tx *gorm.DB) {
+		attempts++
+		if failures > 0 {
+			failures--
+			_ = tx.AddError(failWith)
+		}
+	}))
+	failQueries := func(n int, err error) {
+		attempts, failures, failWith = 0, n, err
+	}
+
+	t.Run("read succeeds after failing twice", func(t *testing.T) {
+		failQueries(2, driver.ErrBadConn)
+
+		found, err := f.debtListRepo.GetByID(ctx, debtList.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, debtList.ID, found.ID)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("retries are bounded", func(t *testing.T) {
+		failQueries(5, driver.ErrBadConn)
+
+		_, err := f.debtItemRepo.GetByDebtListID(ctx, debtList.ID)
+
+		assert.ErrorIs(t, err, driver.ErrBadConn)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("other errors are not retried", func(t *testing.T) {
+		failQueries(0, nil)
+
+		_, err := f.debtListRepo.GetByID(ctx, uuid.New())
+
+		assert.ErrorIs(t, err, entities.ErrDebtListNotFound)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("cancelled context stops retrying", func(t *testing.T) {
+		failQueries(2, driver.ErrBadConn)
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := f.debtListRepo.GetByID(cancelled, debtList.ID)
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, attempts)
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -42,6 +44,7 @@ func (suite *UserContactDebtWorkflowTestSuite) SetupSuite() {
 		&models.UserContact{},
 		&models.DebtList{},
 		&models.DebtItem{},
+		&models.DebtBalance{},
 	)
 	suite.Require().NoError(err)
 
@@ -250,10 +253,28 @@ func (suite *UserContactDebtWorkflowTestSuite) TestCompleteUserContactDebtWorkfl
 	// Update the debt to be overdue by setting next payment date in the past
 	suite.db.Model(&models.DebtList{}).Where("id = ?", debtList2.ID).Update("next_payment_date", time.Now().AddDate(0, 0, -5))
 
-	overdueItems, err := suite.debtService.GetOverdueItems(ctx, user2ID)
+	overdueItems, err := suite.debtService.GetOverdueItems(ctx, user2ID, "")
 	suite.NoError(err)
 	suite.Len(overdueItems, 1)
 	suite.Equal(debtList2.ID, overdueItems[0].ID)
+
+	// The owner is owed the overdue debt
+	overdueItems, err = suite.debtService.GetOverdueItems(ctx, user2ID, entities.DebtDirectionOwedToMe)
+	suite.NoError(err)
+	suite.Len(overdueItems, 1)
+	overdueItems, err = suite.debtService.GetOverdueItems(ctx, user2ID, entities.DebtDirectionIOwe)
+	suite.NoError(err)
+	suite.Len(overdueItems, 0)
+
+	// The contact sees the same debt as one they owe
+	overdueItems, err = suite.debtService.GetOverdueItems(ctx, user1ID, entities.DebtDirectionIOwe)
+	suite.NoError(err)
+	suite.Require().Len(overdueItems, 1)
+	suite.Equal(debtList2.ID, overdueItems[0].ID)
+	suite.Equal("to_pay", overdueItems[0].DebtType)
+	overdueItems, err = suite.debtService.GetOverdueItems(ctx, user1ID, entities.DebtDirectionOwedToMe)
+	suite.NoError(err)
+	suite.Len(overdueItems, 0)
 }
 
 func (suite *UserContactDebtWorkflowTestSuite) TestReciprocalContactCreation() {
@@ -427,6 +448,68 @@ func (suite *UserContactDebtWorkflowTestSuite) TestDebtPerspectives() {
 	suite.Equal("User A", userBDebts[0].Contact.Name)
 }
 
+func (suite *UserContactDebtWorkflowTestSuite) TestSchedulePreviewMatchesCreatedSchedule() {
+	ctx := context.Background()
+
+	userResp, err := suite.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "planner@example.com",
+		Password:  "password123",
+		FirstName: "Plan",
+		LastName:  "Ahead",
+	})
+	suite.Require().NoError(err)
+	userID := userResp.User.ID
+
+	contact, err := suite.contactService.CreateContact(ctx, userID, &entities.CreateContactRequest{
+		Name: "Borrower",
+	})
+	suite.Require().NoError(err)
+
+	debtReq := &entities.CreateDebtListRequest{
+		ContactID:        contact.ID,
+		DebtType:         "to_receive",
+		TotalAmount:      "1000.00",
+		Currency:         "USD",
+		InstallmentPlan:  "monthly",
+		NumberOfPayments: intPtr(3),
+	}
+
+	// Previewing persists nothing
+	preview, err := suite.debtService.PreviewPaymentSchedule(ctx, userID, debtReq)
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(preview)
+	suite.Equal("333.33", preview[0].ScheduledAmount.StringFixed(2))
+
+	debtLists, err := suite.debtService.GetUserDebtLists(ctx, userID)
+	suite.NoError(err)
+	suite.Len(debtLists, 0)
+
+	// Creating with the same terms yields the same schedule
+	debtList, err := suite.debtService.CreateDebtList(ctx, userID, debtReq)
+	suite.Require().NoError(err)
+
+	schedule, err := suite.debtService.GetPaymentSchedule(ctx, debtList.ID, userID)
+	suite.Require().NoError(err)
+	suite.Require().Len(schedule, len(preview))
+
+	for i := range preview {
+		suite.Equal(schedule[i].PaymentNumber, preview[i].PaymentNumber)
+		suite.True(schedule[i].ScheduledAmount.Equal(preview[i].ScheduledAmount), "payment %d amount", i+1)
+		suite.True(schedule[i].Amount.Equal(preview[i].Amount), "payment %d remaining", i+1)
+		suite.Equal(schedule[i].Status, preview[i].Status)
+		suite.WithinDuration(schedule[i].DueDate, preview[i].DueDate, time.Minute)
+	}
+
+	// Invalid terms are rejected just like on creation
+	_, err = suite.debtService.PreviewPaymentSchedule(ctx, userID, &entities.CreateDebtListRequest{
+		ContactID:   contact.ID,
+		DebtType:    "to_receive",
+		TotalAmount: "-5",
+		DueDate:     timePtr(time.Now().AddDate(0, 1, 0)),
+	})
+	suite.ErrorIs(err, entities.ErrInvalidAmount)
+}
+
 func TestUserContactDebtWorkflowTestSuite(t *testing.T) {
 	suite.Run(t, new(UserContactDebtWorkflowTestSuite))
 }
@@ -443,3 +526,547 @@ func intPtr(i int) *int {
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
+
+func (suite *UserContactDebtWorkflowTestSuite) TestDeletedContactRelationDegradesToPlaceholder() {
+	ctx := context.Background()
+
+	userResp, err := suite.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "lender@example.com",
+		Password:  "password123",
+		FirstName: "Len",
+		LastName:  "Der",
+	})
+	suite.Require().NoError(err)
+	userID := userResp.User.ID
+
+	contact, err := suite.contactService.CreateContact(ctx, userID, &entities.CreateContactRequest{
+		Name: "Soon Forgotten",
+	})
+	suite.Require().NoError(err)
+
+	debtList, err := suite.debtService.CreateDebtList(ctx, userID, &entities.CreateDebtListRequest{
+		ContactID:   contact.ID,
+		DebtType:    "to_receive",
+		TotalAmount: "250.00",
+		Currency:    "USD",
+		DueDate:     timePtr(time.Now().AddDate(0, 0, 5)),
+	})
+	suite.Require().NoError(err)
+
+	// Removing the contact only drops the user's relation; the debt list remains
+	suite.Require().NoError(suite.contactService.DeleteContact(ctx, contact.ID, userID))
+
+	debtLists, err := suite.debtService.GetUserDebtLists(ctx, userID)
+	suite.Require().NoError(err)
+	suite.Require().Len(debtLists, 1)
+	suite.Equal(contact.ID, debtLists[0].Contact.ID)
+	suite.Equal(entities.UnknownContactName, debtLists[0].Contact.Name)
+	suite.Nil(debtLists[0].Contact.Email)
+
+	fetched, err := suite.debtService.GetDebtList(ctx, debtList.ID, userID)
+	suite.Require().NoError(err)
+	suite.Equal(contact.ID, fetched.Contact.ID)
+	suite.Equal(entities.UnknownContactName, fetched.Contact.Name)
+
+	upcoming, err := suite.debtService.GetUpcomingPayments(ctx, userID, 30)
+	suite.Require().NoError(err)
+	suite.Require().Len(upcoming, 1)
+	suite.Equal(debtList.ID, upcoming[0].DebtListID)
+	suite.Equal(entities.UnknownContactName, upcoming[0].ContactName)
+}
+
+func (suite *UserContactDebtWorkflowTestSuite) TestDoubleVerifyAppliesOnce() {
+	ctx := context.Background()
+
+	debtorResp, err := suite.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "debtor@example.com",
+		Password:  "password123",
+		FirstName: "Dee",
+		LastName:  "Btor",
+	})
+	suite.Require().NoError(err)
+	debtorID := debtorResp.User.ID
+
+	creditorResp, err := suite.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "creditor@example.com",
+		Password:  "password123",
+		FirstName: "Cred",
+		LastName:  "Itor",
+	})
+	suite.Require().NoError(err)
+	creditorID := creditorResp.User.ID
+
+	contact, err := suite.contactService.CreateContact(ctx, debtorID, &entities.CreateContactRequest{
+		Name:  "Cred Itor",
+		Email: stringPtr("creditor@example.com"),
+	})
+	suite.Require().NoError(err)
+
+	debtList, err := suite.debtService.CreateDebtList(ctx, debtorID, &entities.CreateDebtListRequest{
+		ContactID:   contact.ID,
+		DebtType:    "to_pay",
+		TotalAmount: "600.00",
+		Currency:    "USD",
+		DueDate:     timePtr(time.Now().AddDate(0, 1, 0)),
+	})
+	suite.Require().NoError(err)
+
+	payment, err := suite.debtService.CreateDebtItem(ctx, debtorID, &entities.CreateDebtItemRequest{
+		DebtListID:    debtList.ID,
+		Amount:        "200.00",
+		Currency:      "USD",
+		PaymentDate:   time.Now(),
+		PaymentMethod: "cash",
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(entities.PaymentStatusPending, payment.Status)
+
+	verifyReq := &entities.VerifyDebtItemRequest{Status: entities.PaymentStatusCompleted}
+
+	verified, err := suite.debtService.VerifyDebtItem(ctx, payment.ID, creditorID, verifyReq)
+	suite.Require().NoError(err)
+	suite.Equal(entities.PaymentStatusCompleted, verified.Status)
+
+	// A second verification of the same payment is refused
+	_, err = suite.debtService.VerifyDebtItem(ctx, payment.ID, creditorID, verifyReq)
+	suite.ErrorIs(err, entities.ErrPaymentAlreadyProcessed)
+
+	_, err = suite.debtService.RejectDebtItem(ctx, payment.ID, creditorID, nil)
+	suite.ErrorIs(err, entities.ErrPaymentAlreadyProcessed)
+
+	// Totals reflect the payment exactly once
+	updated, err := suite.debtService.GetDebtList(ctx, debtList.ID, debtorID)
+	suite.Require().NoError(err)
+	suite.Equal("200.00", updated.TotalPaymentsMade.StringFixed(2))
+	suite.Equal("400.00", updated.TotalRemainingDebt.StringFixed(2))
+
+	item, err := suite.debtService.GetDebtItem(ctx, payment.ID, debtorID)
+	suite.Require().NoError(err)
+	suite.Equal(entities.PaymentStatusCompleted, item.Status)
+}
+
+func (suite *UserContactDebtWorkflowTestSuite) TestRejectResubmitVerifyCycle() {
+	ctx := context.Background()
+
+	debtorResp, err := suite.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "resubmit-debtor@example.com",
+		Password:  "password123",
+		FirstName: "Dee",
+		LastName:  "Btor",
+	})
+	suite.Require().NoError(err)
+	debtorID := debtorResp.User.ID
+
+	creditorResp, err := suite.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "resubmit-creditor@example.com",
+		Password:  "password123",
+		FirstName: "Cred",
+		LastName:  "Itor",
+	})
+	suite.Require().NoError(err)
+	creditorID := creditorResp.User.ID
+
+	contact, err := suite.contactService.CreateContact(ctx, debtorID, &entities.CreateContactRequest{
+		Name:  "Cred Itor",
+		Email: stringPtr("resubmit-creditor@example.com"),
+	})
+	suite.Require().NoError(err)
+
+	debtList, err := suite.debtService.CreateDebtList(ctx, debtorID, &entities.CreateDebtListRequest{
+		ContactID:   contact.ID,
+		DebtType:    "to_pay",
+		TotalAmount: "500.00",
+		Currency:    "USD",
+		DueDate:     timePtr(time.Now().AddDate(0, 1, 0)),
+	})
+	suite.Require().NoError(err)
+
+	payment, err := suite.debtService.CreateDebtItem(ctx, debtorID, &entities.CreateDebtItemRequest{
+		DebtListID:    debtList.ID,
+		Amount:        "150.00",
+		Currency:      "USD",
+		PaymentDate:   time.Now(),
+		PaymentMethod: "bank_transfer",
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(entities.PaymentStatusPending, payment.Status)
+
+	resubmitReq := &entities.ResubmitDebtItemRequest{
+		ReceiptPhotoURL:   stringPtr("https://example.com/receipts/clear.jpg"),
+		VerificationNotes: stringPtr("Clearer photo attached"),
+	}
+
+	// Only rejected payments can be resubmitted
+	_, err = suite.debtService.ResubmitDebtItem(ctx, payment.ID, debtorID, resubmitReq)
+	suite.ErrorIs(err, entities.ErrPaymentNotRejected)
+
+	rejected, err := suite.debtService.RejectDebtItem(ctx, payment.ID, creditorID, stringPtr("Receipt is unreadable"))
+	suite.Require().NoError(err)
+	suite.Equal(entities.PaymentStatusRejected, rejected.Status)
+
+	// The creditor did not submit the payment and cannot resubmit it
+	_, err = suite.debtService.ResubmitDebtItem(ctx, payment.ID, creditorID, resubmitReq)
+	suite.ErrorIs(err, entities.ErrForbidden)
+
+	resubmitted, err := suite.debtService.ResubmitDebtItem(ctx, payment.ID, debtorID, resubmitReq)
+	suite.Require().NoError(err)
+	suite.Equal(entities.PaymentStatusPending, resubmitted.Status)
+	suite.Equal(1, resubmitted.ResubmissionCount)
+	suite.NotNil(resubmitted.ResubmittedAt)
+	suite.Nil(resubmitted.VerifiedBy)
+	suite.Nil(resubmitted.VerifiedAt)
+	suite.Require().NotNil(resubmitted.ReceiptPhotoURL)
+	suite.Equal("https://example.com/receipts/clear.jpg", *resubmitted.ReceiptPhotoURL)
+	suite.Require().NotNil(resubmitted.VerificationNotes)
+	suite.Equal("Clearer photo attached", *resubmitted.VerificationNotes)
+
+	// A pending resubmission cannot be resubmitted again
+	_, err = suite.debtService.ResubmitDebtItem(ctx, payment.ID, debtorID, resubmitReq)
+	suite.ErrorIs(err, entities.ErrPaymentNotRejected)
+
+	verified, err := suite.debtService.VerifyDebtItem(ctx, payment.ID, creditorID, &entities.VerifyDebtItemRequest{Status: entities.PaymentStatusCompleted})
+	suite.Require().NoError(err)
+	suite.Equal(entities.PaymentStatusCompleted, verified.Status)
+	suite.Equal(1, verified.ResubmissionCount)
+
+	updated, err := suite.debtService.GetDebtList(ctx, debtList.ID, debtorID)
+	suite.Require().NoError(err)
+	suite.Equal("150.00", updated.TotalPaymentsMade.StringFixed(2))
+	suite.Equal("350.00", updated.TotalRemainingDebt.StringFixed(2))
+}
+
+func (suite *UserContactDebtWorkflowTestSuite) TestDebtCountsAcrossPerspectives() {
+	ctx := context.Background()
+
+	aliceResp, err := suite.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "alice-counts@example.com",
+		Password:  "password123",
+		FirstName: "Alice",
+		LastName:  "Counts",
+	})
+	suite.Require().NoError(err)
+	aliceID := aliceResp.User.ID
+
+	bobResp, err := suite.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "bob-counts@example.com",
+		Password:  "password123",
+		FirstName: "Bob",
+		LastName:  "Counts",
+	})
+	suite.Require().NoError(err)
+	bobID := bobResp.User.ID
+
+	// Alice's contact for Bob also gives Bob a reciprocal contact for Alice
+	bobContact, err := suite.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{
+		Name:  "Bob Counts",
+		Email: stringPtr("bob-counts@example.com"),
+	})
+	suite.Require().NoError(err)
+	bobContacts, err := suite.contactService.GetUserContacts(ctx, bobID)
+	suite.Require().NoError(err)
+	suite.Require().Len(bobContacts, 1)
+	aliceContact := bobContacts[0]
+
+	createDebt := func(ownerID uuid.UUID, contactID uuid.UUID, debtType string) *entities.DebtList {
+		debtList, err := suite.debtService.CreateDebtList(ctx, ownerID, &entities.CreateDebtListRequest{
+			ContactID:   contactID,
+			DebtType:    debtType,
+			TotalAmount: "300.00",
+			Currency:    "USD",
+			DueDate:     timePtr(time.Now().AddDate(0, 1, 0)),
+		})
+		suite.Require().NoError(err)
+		return debtList
+	}
+
+	// Alice owns one debt in each status
+	createDebt(aliceID, bobContact.ID, "to_receive")
+	settled := createDebt(aliceID, bobContact.ID, "to_receive")
+	suite.Require().NoError(suite.debtListRepo.UpdateStatus(ctx, settled.ID, "settled"))
+	overdue := createDebt(aliceID, bobContact.ID, "to_pay")
+	suite.Require().NoError(suite.debtListRepo.UpdateStatus(ctx, overdue.ID, "overdue"))
+
+	// Bob owes Alice on a debt he owns; his payment awaits Alice's verification
+	bobOwes := createDebt(bobID, aliceContact.ID, "to_pay")
+	payment, err := suite.debtService.CreateDebtItem(ctx, bobID, &entities.CreateDebtItemRequest{
+		DebtListID:    bobOwes.ID,
+		Amount:        "50.00",
+		Currency:      "USD",
+		PaymentDate:   time.Now(),
+		PaymentMethod: "cash",
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(entities.PaymentStatusPending, payment.Status)
+
+	aliceCounts, err := suite.debtService.GetDebtCounts(ctx, aliceID)
+	suite.Require().NoError(err)
+	suite.Equal(entities.DebtStatusCounts{Active: 2, Overdue: 1, Settled: 1, PendingVerification: 1}, *aliceCounts)
+
+	// Bob sees the same shared debts from his side, and has nothing to verify
+	bobCounts, err := suite.debtService.GetDebtCounts(ctx, bobID)
+	suite.Require().NoError(err)
+	suite.Equal(entities.DebtStatusCounts{Active: 2, Overdue: 1, Settled: 1, PendingVerification: 0}, *bobCounts)
+
+	// A user with no debts gets zeroes
+	emptyCounts, err := suite.debtService.GetDebtCounts(ctx, uuid.New())
+	suite.Require().NoError(err)
+	suite.Equal(entities.DebtStatusCounts{}, *emptyCounts)
+}
+
+func (suite *UserContactDebtWorkflowTestSuite) TestTransferOwnershipPreservesPerspectives() {
+	ctx := context.Background()
+
+	aliceResp, err := suite.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "alice-transfer@example.com",
+		Password:  "password123",
+		FirstName: "Alice",
+		LastName:  "Transfer",
+	})
+	suite.Require().NoError(err)
+	aliceID := aliceResp.User.ID
+
+	bobResp, err := suite.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "bob-transfer@example.com",
+		Password:  "password123",
+		FirstName: "Bob",
+		LastName:  "Transfer",
+	})
+	suite.Require().NoError(err)
+	bobID := bobResp.User.ID
+
+	// Alice's contact for Bob also gives Bob a reciprocal contact for Alice
+	bobContact, err := suite.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{
+		Name:  "Bob Transfer",
+		Email: stringPtr("bob-transfer@example.com"),
+	})
+	suite.Require().NoError(err)
+	bobContacts, err := suite.contactService.GetUserContacts(ctx, bobID)
+	suite.Require().NoError(err)
+	suite.Require().Len(bobContacts, 1)
+	aliceContact := bobContacts[0]
+
+	// Bob owes Alice
+	debtList, err := suite.debtService.CreateDebtList(ctx, aliceID, &entities.CreateDebtListRequest{
+		ContactID:   bobContact.ID,
+		DebtType:    "to_receive",
+		TotalAmount: "400.00",
+		Currency:    "USD",
+		DueDate:     timePtr(time.Now().AddDate(0, 2, 0)),
+	})
+	suite.Require().NoError(err)
+
+	_, err = suite.debtService.CreateDebtItem(ctx, aliceID, &entities.CreateDebtItemRequest{
+		DebtListID:    debtList.ID,
+		Amount:        "100.00",
+		Currency:      "USD",
+		PaymentDate:   time.Now(),
+		PaymentMethod: "cash",
+	})
+	suite.Require().NoError(err)
+
+	// Only the owner can transfer, and only to the user the debt is shared with
+	_, err = suite.debtService.TransferOwnership(ctx, debtList.ID, bobID, &entities.TransferOwnershipRequest{NewOwnerID: bobID})
+	suite.ErrorIs(err, entities.ErrForbidden)
+	_, err = suite.debtService.TransferOwnership(ctx, debtList.ID, aliceID, &entities.TransferOwnershipRequest{NewOwnerID: uuid.New()})
+	suite.ErrorIs(err, entities.ErrInvalidNewOwner)
+	_, err = suite.debtService.TransferOwnership(ctx, debtList.ID, uuid.New(), &entities.TransferOwnershipRequest{NewOwnerID: bobID})
+	suite.ErrorIs(err, entities.ErrDebtListNotFound)
+
+	transferred, err := suite.debtService.TransferOwnership(ctx, debtList.ID, aliceID, &entities.TransferOwnershipRequest{NewOwnerID: bobID})
+	suite.Require().NoError(err)
+	suite.Equal(bobID, transferred.UserID)
+	suite.Equal(aliceContact.ID, transferred.ContactID)
+	suite.Equal("to_pay", transferred.DebtType)
+	suite.True(transferred.TotalPaymentsMade.Equal(decimal.RequireFromString("100.00")))
+
+	// Bob still owes Alice, seen from either side
+	bobView, err := suite.debtService.GetDebtList(ctx, debtList.ID, bobID)
+	suite.Require().NoError(err)
+	suite.Equal(bobID, bobView.UserID)
+	suite.Equal("to_pay", bobView.DebtType)
+
+	aliceView, err := suite.debtService.GetDebtList(ctx, debtList.ID, aliceID)
+	suite.Require().NoError(err)
+	suite.Equal("to_receive", aliceView.DebtType)
+
+	// The payments moved with the list
+	items, err := suite.debtService.GetDebtListItems(ctx, debtList.ID, bobID)
+	suite.Require().NoError(err)
+	suite.Len(items, 1)
+
+	// Alice is now the contact and can no longer transfer or delete it
+	_, err = suite.debtService.TransferOwnership(ctx, debtList.ID, aliceID, &entities.TransferOwnershipRequest{NewOwnerID: bobID})
+	suite.ErrorIs(err, entities.ErrForbidden)
+	suite.ErrorIs(suite.debtService.DeleteDebtList(ctx, debtList.ID, aliceID), entities.ErrDebtListNotFound)
+}
+
+func (suite *UserContactDebtWorkflowTestSuite) TestSharedDebtListsExcludeOwnedLists() {
+	ctx := context.Background()
+
+	aliceResp, err := suite.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "alice-shared@example.com",
+		Password:  "password123",
+		FirstName: "Alice",
+		LastName:  "Shared",
+	})
+	suite.Require().NoError(err)
+	aliceID := aliceResp.User.ID
+
+	bobResp, err := suite.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "bob-shared@example.com",
+		Password:  "password123",
+		FirstName: "Bob",
+		LastName:  "Shared",
+	})
+	suite.Require().NoError(err)
+	bobID := bobResp.User.ID
+
+	bobContact, err := suite.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{
+		Name:  "Bob Shared",
+		Email: stringPtr("bob-shared@example.com"),
+	})
+	suite.Require().NoError(err)
+	bobContacts, err := suite.contactService.GetUserContacts(ctx, bobID)
+	suite.Require().NoError(err)
+	suite.Require().Len(bobContacts, 1)
+	aliceContact := bobContacts[0]
+
+	createDebt := func(ownerID uuid.UUID, contactID uuid.UUID, debtType string, amount string) *entities.DebtList {
+		debtList, err := suite.debtService.CreateDebtList(ctx, ownerID, &entities.CreateDebtListRequest{
+			ContactID:   contactID,
+			DebtType:    debtType,
+			TotalAmount: amount,
+			Currency:    "USD",
+			DueDate:     timePtr(time.Now().AddDate(0, 1, 0)),
+		})
+		suite.Require().NoError(err)
+		return debtList
+	}
+
+	// Alice tracks a loan to Bob; Bob tracks what he owes Alice on his own list
+	aliceLent := createDebt(aliceID, bobContact.ID, "to_receive", "500.00")
+	bobOwes := createDebt(bobID, aliceContact.ID, "to_pay", "80.00")
+
+	// Bob's shared view holds only Alice's list, from his perspective
+	bobShared, err := suite.debtService.GetSharedDebtLists(ctx, bobID)
+	suite.Require().NoError(err)
+	suite.Require().Len(bobShared, 1)
+	suite.Equal(aliceLent.ID, bobShared[0].ID)
+	suite.Equal(aliceID, bobShared[0].UserID)
+	suite.Equal("to_pay", bobShared[0].DebtType)
+	suite.Equal("Alice Shared", bobShared[0].Contact.Name)
+
+	// Even a list Alice tracks against a contact for her own account stays hers
+	selfContact, err := suite.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{
+		Name:  "Me",
+		Email: stringPtr("alice-shared@example.com"),
+	})
+	suite.Require().NoError(err)
+	suite.Require().True(selfContact.IsUser)
+	createDebt(aliceID, selfContact.ID, "to_pay", "20.00")
+
+	// Alice's shared view holds only Bob's list, flipped to what she is owed
+	aliceShared, err := suite.debtService.GetSharedDebtLists(ctx, aliceID)
+	suite.Require().NoError(err)
+	suite.Require().Len(aliceShared, 1)
+	suite.Equal(bobOwes.ID, aliceShared[0].ID)
+	suite.Equal("to_receive", aliceShared[0].DebtType)
+
+	// The combined listing still contains both records
+	bobAll, err := suite.debtService.GetUserDebtLists(ctx, bobID)
+	suite.Require().NoError(err)
+	suite.Len(bobAll, 2)
+
+	// A user nobody tracks debts with has nothing shared
+	carolResp, err := suite.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "carol-shared@example.com",
+		Password:  "password123",
+		FirstName: "Carol",
+		LastName:  "Shared",
+	})
+	suite.Require().NoError(err)
+	carolShared, err := suite.debtService.GetSharedDebtLists(ctx, carolResp.User.ID)
+	suite.Require().NoError(err)
+	suite.Empty(carolShared)
+}
+
+func (suite *UserContactDebtWorkflowTestSuite) TestPaymentsRecordTheirCreator() {
+	ctx := context.Background()
+
+	creditorResp, err := suite.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "creditor-creator@example.com",
+		Password:  "password123",
+		FirstName: "Cred",
+		LastName:  "Itor",
+	})
+	suite.Require().NoError(err)
+	creditorID := creditorResp.User.ID
+
+	debtorResp, err := suite.authService.Register(ctx, &entities.CreateUserRequest{
+		Email:     "debtor-creator@example.com",
+		Password:  "password123",
+		FirstName: "Deb",
+		LastName:  "Tor",
+	})
+	suite.Require().NoError(err)
+	debtorID := debtorResp.User.ID
+
+	debtorContact, err := suite.contactService.CreateContact(ctx, creditorID, &entities.CreateContactRequest{
+		Name:  "Deb Tor",
+		Email: stringPtr("debtor-creator@example.com"),
+	})
+	suite.Require().NoError(err)
+
+	debtList, err := suite.debtService.CreateDebtList(ctx, creditorID, &entities.CreateDebtListRequest{
+		ContactID:   debtorContact.ID,
+		DebtType:    "to_receive",
+		TotalAmount: "200.00",
+		Currency:    "USD",
+		DueDate:     timePtr(time.Now().AddDate(0, 1, 0)),
+	})
+	suite.Require().NoError(err)
+
+	recordPayment := func(userID uuid.UUID, amount string) *entities.DebtItem {
+		payment, err := suite.debtService.CreateDebtItem(ctx, userID, &entities.CreateDebtItemRequest{
+			DebtListID:    debtList.ID,
+			Amount:        amount,
+			Currency:      "USD",
+			PaymentDate:   time.Now(),
+			PaymentMethod: "cash",
+		})
+		suite.Require().NoError(err)
+		return payment
+	}
+
+	// The debtor's payment awaits verification and remembers who submitted it
+	pending := recordPayment(debtorID, "30.00")
+	suite.Equal(entities.PaymentStatusPending, pending.Status)
+	suite.Equal(debtorID, pending.CreatedBy)
+
+	stored, err := suite.debtItemRepo.GetByID(ctx, pending.ID)
+	suite.Require().NoError(err)
+	suite.Equal(debtorID, stored.CreatedBy)
+
+	// The creditor's own record of a payment is attributed to them
+	completed := recordPayment(creditorID, "20.00")
+	suite.Equal(entities.PaymentStatusCompleted, completed.Status)
+	suite.Equal(creditorID, completed.CreatedBy)
+
+	// Both parties see who recorded each payment
+	for _, userID := range []uuid.UUID{creditorID, debtorID} {
+		payments, err := suite.debtService.GetDebtListItems(ctx, debtList.ID, userID)
+		suite.Require().NoError(err)
+		createdBy := make(map[uuid.UUID]uuid.UUID, len(payments))
+		for _, payment := range payments {
+			createdBy[payment.ID] = payment.CreatedBy
+		}
+		suite.Equal(map[uuid.UUID]uuid.UUID{pending.ID: debtorID, completed.ID: creditorID}, createdBy)
+	}
+
+	details, err := suite.debtService.GetDebtList(ctx, debtList.ID, creditorID)
+	suite.Require().NoError(err)
+	suite.Require().Len(details.Payments, 2)
+	for _, payment := range details.Payments {
+		suite.NotEqual(uuid.Nil, payment.CreatedBy)
+	}
+}
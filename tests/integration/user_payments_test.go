@@ -0,0 +1,236 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/handlers"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/models"
+)
+
+func TestGetUserPayments(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	f := newTestFixture(t)
+
+	register := func(email string) uuid.UUID {
+		resp, err := f.authService.Register(ctx, &entities.CreateUserRequest{
+			Email:     email,
+			Password:  "password123",
+			FirstName: "Ledger",
+			LastName:  "User",
+		})
+		require.NoError(t, err)
+		return resp.User.ID
+	}
+	aliceID := register("alice-ledger@example.com")
+	bobID := register("bob-ledger@example.com")
+	carolID := register("carol-ledger@example.com")
+
+	offline, err := f.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{Name: "Cash Only Carl"})
+	require.NoError(t, err)
+	_, err = f.contactService.CreateContact(ctx, aliceID, &entities.CreateContactRequest{
+		Name:  "Bob",
+		Email: stringPtr("bob-ledger@example.com"),
+	})
+	require.NoError(t, err)
+	carolsContact, err := f.contactService.CreateContact(ctx, carolID, &entities.CreateContactRequest{Name: "Someone Else"})
+	require.NoError(t, err)
+
+	// Adding Bob as a contact gave him a reciprocal contact for Alice
+	var aliceForBob uuid.UUID
+	userContacts, err := f.contactRepo.GetUserContactsByEmail(ctx, "alice-ledger@example.com")
+	require.NoError(t, err)
+	for _, uc := range userContacts {
+		if uc.UserID == bobID {
+			aliceForBob = uc.ContactID
+		}
+	}
+	require.NotEqual(t, uuid.Nil, aliceForBob)
+
+	newDebtList := func(ownerID, contactID uuid.UUID, debtType, currency string) uuid.UUID {
+		debtList := models.DebtList{
+			ID:                 uuid.New(),
+			UserID:             ownerID,
+			ContactID:          contactID,
+			DebtType:           debtType,
+			TotalAmount:        decimal.RequireFromString("1000.00"),
+			InstallmentAmount:  decimal.RequireFromString("100.00"),
+			TotalRemainingDebt: decimal.RequireFromString("1000.00"),
+			Currency:           currency,
+			Status:             "active",
+			DueDate:            time.Now().AddDate(0, 6, 0),
+			NextPaymentDate:    time.Now().AddDate(0, 1, 0),
+			InstallmentPlan:    "monthly",
+		}
+		require.NoError(t, f.db.Create(&debtList).Error)
+		return debtList.ID
+	}
+	newPayment := func(debtListID, createdBy uuid.UUID, amount, status string, paymentDate time.Time) uuid.UUID {
+		payment := models.DebtItem{
+			ID:            uuid.New(),
+			DebtListID:    debtListID,
+			Amount:        decimal.RequireFromString(amount),
+			Currency:      "USD",
+			PaymentDate:   paymentDate,
+			PaymentMethod: "cash",
+			PaymentType:   "payment",
+			Status:        status,
+			CreatedBy:     createdBy,
+		}
+		require.NoError(t, f.db.Create(&payment).Error)
+		return payment.ID
+	}
+	day := func(d int) time.Time {
+		return time.Date(2024, time.March, d, 12, 0, 0, 0, time.UTC)
+	}
+
+	// Alice lent to Carl and received two payments
+	carlDebt := newDebtList(aliceID, offline.ID, "to_receive", "USD")
+	carlFirst := newPayment(carlDebt, aliceID, "100.00", "completed", day(1))
+	carlSecond := newPayment(carlDebt, aliceID, "50.00", "pending", day(10))
+	// Alice owes Carl on another debt and paid once
+	aliceOwes := newDebtList(aliceID, offline.ID, "to_pay", "USD")
+	alicePaid := newPayment(aliceOwes, aliceID, "20.00", "completed", day(15))
+	// Bob recorded lending to Alice, and Alice paid him back
+	bobDebt := newDebtList(bobID, aliceForBob, "to_receive", "USD")
+	toBob := newPayment(bobDebt, aliceID, "75.00", "completed", day(5))
+	// Carol's debt doesn't involve Alice
+	newPayment(newDebtList(carolID, carolsContact.ID, "to_receive", "USD"), carolID, "999.00", "completed", day(7))
+
+	debtHandler := handlers.NewDebtHandler(f.debtService, &mocks.MockFileStorageService{}, zerolog.Nop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		userID, err := uuid.Parse(c.GetHeader("X-Test-User"))
+		require.NoError(t, err)
+		c.Set("user_id", userID)
+	})
+	router.GET("/api/v1/payments", debtHandler.GetUserPayments)
+
+	type listBody struct {
+		Data []entities.UserPayment  `json:"data"`
+		Meta handlers.PaginationMeta `json:"meta"`
+	}
+	list := func(userID uuid.UUID, query string) (int, listBody) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/payments?"+query, nil)
+		req.Header.Set("X-Test-User", userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var body listBody
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return w.Code, body
+	}
+	paymentIDs := func(payments []entities.UserPayment) []uuid.UUID {
+		ids := make([]uuid.UUID, len(payments))
+		for i, payment := range payments {
+			ids[i] = payment.Payment.ID
+		}
+		return ids
+	}
+
+	code, body := list(aliceID, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 4, body.Meta.Total)
+	assert.Equal(t, []uuid.UUID{alicePaid, carlSecond, toBob, carlFirst}, paymentIDs(body.Data))
+
+	t.Run("each payment carries its debt list from the user's side", func(t *testing.T) {
+		byID := make(map[uuid.UUID]entities.UserPayment, len(body.Data))
+		for _, payment := range body.Data {
+			byID[payment.Payment.ID] = payment
+		}
+
+		own := byID[carlFirst]
+		assert.Equal(t, carlDebt, own.DebtList.ID)
+		assert.Equal(t, "to_receive", own.DebtList.DebtType)
+		assert.True(t, own.DebtList.IsOwner)
+		assert.True(t, decimal.RequireFromString("1000.00").Equal(own.DebtList.TotalAmount))
+		assert.Equal(t, "USD", own.DebtList.Currency)
+
+		shared := byID[toBob]
+		assert.Equal(t, bobDebt, shared.DebtList.ID)
+		assert.Equal(t, "to_pay", shared.DebtList.DebtType)
+		assert.False(t, shared.DebtList.IsOwner)
+		assert.True(t, decimal.RequireFromString("75.00").Equal(shared.Payment.Amount))
+	})
+
+	t.Run("the debt's owner sees only their debt's payments", func(t *testing.T) {
+		code, body := list(bobID, "")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []uuid.UUID{toBob}, paymentIDs(body.Data))
+		assert.Equal(t, "to_receive", body.Data[0].DebtList.DebtType)
+		assert.True(t, body.Data[0].DebtList.IsOwner)
+	})
+
+	t.Run("pages keep the newest-first order", func(t *testing.T) {
+		code, first := list(aliceID, "limit=3")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []uuid.UUID{alicePaid, carlSecond, toBob}, paymentIDs(first.Data))
+		assert.Equal(t, 4, first.Meta.Total)
+		require.Equal(t, "3", first.Meta.NextCursor)
+
+		code, second := list(aliceID, "limit=3&cursor="+first.Meta.NextCursor)
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []uuid.UUID{carlFirst}, paymentIDs(second.Data))
+		assert.Empty(t, second.Meta.NextCursor)
+	})
+
+	t.Run("pages count only the filtered payments", func(t *testing.T) {
+		code, page := list(aliceID, "status=completed&limit=1&offset=1")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []uuid.UUID{toBob}, paymentIDs(page.Data))
+		assert.Equal(t, 3, page.Meta.Total)
+		assert.Equal(t, "2", page.Meta.NextCursor)
+
+		code, page = list(aliceID, "offset=10")
+		require.Equal(t, http.StatusOK, code)
+		assert.Empty(t, page.Data)
+		assert.Equal(t, 4, page.Meta.Total)
+		assert.Empty(t, page.Meta.NextCursor)
+	})
+
+	t.Run("filtering by status", func(t *testing.T) {
+		code, body := list(aliceID, "status=pending")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []uuid.UUID{carlSecond}, paymentIDs(body.Data))
+	})
+
+	t.Run("filtering by payment date", func(t *testing.T) {
+		code, body := list(aliceID, "from=2024-03-05&to=2024-03-10")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []uuid.UUID{carlSecond, toBob}, paymentIDs(body.Data))
+
+		code, body = list(aliceID, "status=completed&from=2024-03-02")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, []uuid.UUID{alicePaid, toBob}, paymentIDs(body.Data))
+	})
+
+	t.Run("invalid filters", func(t *testing.T) {
+		code, _ := list(aliceID, "status=lost")
+		assert.Equal(t, http.StatusBadRequest, code)
+		code, _ = list(aliceID, "from=yesterday")
+		assert.Equal(t, http.StatusBadRequest, code)
+		code, _ = list(aliceID, "from=2024-03-10&to=2024-03-01")
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("no payments", func(t *testing.T) {
+		code, body := list(register("dave-ledger@example.com"), "")
+		require.Equal(t, http.StatusOK, code)
+		assert.Empty(t, body.Data)
+		assert.Equal(t, 0, body.Meta.Total)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"pay-your-dues/internal/domain/entities"
 	"pay-your-dues/internal/mocks"
@@ -17,6 +18,7 @@ import (
 func TestContactService_CreateContact(t *testing.T) {
 	userID := uuid.New()
 	existingUserID := uuid.New()
+	existingContactID := uuid.New()
 
 	tests := []struct {
 		name           string
@@ -26,6 +28,7 @@ func TestContactService_CreateContact(t *testing.T) {
 		expectedError  error
 		expectSuccess  bool
 		validateResult func(*testing.T, *entities.ContactResponse)
+		validateError  func(*testing.T, error)
 	}{
 		{
 			name:   "create regular contact (non-user)",
@@ -37,7 +40,7 @@ func TestContactService_CreateContact(t *testing.T) {
 				Notes: stringPtr("Friend from college"),
 			},
 			setupMocks: func(contactRepo *mocks.MockContactRepository, userRepo *mocks.MockUserRepository) {
-				contactRepo.On("ExistsByEmailForUser", mock.Anything, userID, "alice@example.com").Return(false, nil)
+				contactRepo.On("GetUserContactByEmail", mock.Anything, userID, "alice@example.com").Return(nil, entities.ErrContactNotFound)
 				userRepo.On("GetByEmail", mock.Anything, "alice@example.com").Return(nil, entities.ErrUserNotFound)
 				contactRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Contact")).Return(nil)
 				contactRepo.On("CreateUserContactRelation", mock.Anything, mock.AnythingOfType("*entities.UserContact")).Return(nil)
@@ -60,7 +63,7 @@ func TestContactService_CreateContact(t *testing.T) {
 			},
 			setupMocks: func(contactRepo *mocks.MockContactRepository, userRepo *mocks.MockUserRepository) {
 				// Mock the initial check for existing contact with same email
-				contactRepo.On("ExistsByEmailForUser", mock.Anything, userID, "bob@example.com").Return(false, nil)
+				contactRepo.On("GetUserContactByEmail", mock.Anything, userID, "bob@example.com").Return(nil, entities.ErrContactNotFound)
 				
 				existingUser := &entities.User{
 					ID:        existingUserID,
@@ -69,6 +72,7 @@ func TestContactService_CreateContact(t *testing.T) {
 					LastName:  "Smith",
 				}
 				userRepo.On("GetByEmail", mock.Anything, "bob@example.com").Return(existingUser, nil)
+				contactRepo.On("IsBlockedBy", mock.Anything, existingUserID, userID).Return(false, nil)
 				contactRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Contact")).Return(nil)
 				contactRepo.On("CreateUserContactRelation", mock.Anything, mock.AnythingOfType("*entities.UserContact")).Return(nil)
 				
@@ -100,10 +104,21 @@ func TestContactService_CreateContact(t *testing.T) {
 				Email: stringPtr("existing@example.com"),
 			},
 			setupMocks: func(contactRepo *mocks.MockContactRepository, userRepo *mocks.MockUserRepository) {
-				contactRepo.On("ExistsByEmailForUser", mock.Anything, userID, "existing@example.com").Return(true, nil)
+				contactRepo.On("GetUserContactByEmail", mock.Anything, userID, "existing@example.com").Return(&entities.UserContact{
+					ID:        uuid.New(),
+					UserID:    userID,
+					ContactID: existingContactID,
+					Name:      "Existing Contact",
+					Email:     stringPtr("existing@example.com"),
+				}, nil)
 			},
 			expectedError: entities.ErrContactAlreadyExists,
 			expectSuccess: false,
+			validateError: func(t *testing.T, err error) {
+				var duplicate *entities.DuplicateContactError
+				require.ErrorAs(t, err, &duplicate)
+				assert.Equal(t, existingContactID, duplicate.ExistingContactID)
+			},
 		},
 		{
 			name:   "missing contact name",
@@ -124,7 +139,7 @@ func TestContactService_CreateContact(t *testing.T) {
 				Email: stringPtr("shared@example.com"),
 			},
 			setupMocks: func(contactRepo *mocks.MockContactRepository, userRepo *mocks.MockUserRepository) {
-				contactRepo.On("ExistsByEmailForUser", mock.Anything, userID, "shared@example.com").Return(false, nil)
+				contactRepo.On("GetUserContactByEmail", mock.Anything, userID, "shared@example.com").Return(nil, entities.ErrContactNotFound)
 				userRepo.On("GetByEmail", mock.Anything, "shared@example.com").Return(nil, entities.ErrUserNotFound)
 				contactRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Contact")).Return(nil)
 				contactRepo.On("CreateUserContactRelation", mock.Anything, mock.AnythingOfType("*entities.UserContact")).Return(nil)
@@ -163,6 +178,9 @@ func TestContactService_CreateContact(t *testing.T) {
 				assert.Error(t, err)
 				assert.ErrorIs(t, err, tt.expectedError)
 				assert.Nil(t, result)
+				if tt.validateError != nil {
+					tt.validateError(t, err)
+				}
 			}
 
 			// Verify mock expectations
@@ -176,6 +194,7 @@ func TestContactService_GetContact(t *testing.T) {
 	userID := uuid.New()
 	contactID := uuid.New()
 	otherUserID := uuid.New()
+	linkedUserID := uuid.New()
 
 	tests := []struct {
 		name          string
@@ -184,6 +203,7 @@ func TestContactService_GetContact(t *testing.T) {
 		setupMocks    func(*mocks.MockContactRepository, *mocks.MockUserRepository)
 		expectedError error
 		expectSuccess bool
+		validate      func(t *testing.T, result *entities.ContactResponse)
 	}{
 		{
 			name:      "successful contact retrieval",
@@ -198,14 +218,87 @@ func TestContactService_GetContact(t *testing.T) {
 					Email:     stringPtr("test@example.com"),
 				}
 				contactRepo.On("GetUserContactRelation", mock.Anything, userID, contactID).Return(userContact, nil)
-				contact := &entities.Contact{
-					ID:     contactID,
-					IsUser: false,
+				contact := &entities.ContactWithUser{
+					Contact: entities.Contact{
+						ID:     contactID,
+						IsUser: false,
+					},
 				}
-				contactRepo.On("GetByID", mock.Anything, contactID).Return(contact, nil)
+				contactRepo.On("GetContactWithUser", mock.Anything, contactID).Return(contact, nil)
+			},
+			expectedError: nil,
+			expectSuccess: true,
+			validate: func(t *testing.T, result *entities.ContactResponse) {
+				assert.False(t, result.IsActiveUser)
+				assert.Nil(t, result.VerifiedName)
+			},
+		},
+		{
+			name:      "contact linked to an app user shows their verified name",
+			contactID: contactID,
+			userID:    userID,
+			setupMocks: func(contactRepo *mocks.MockContactRepository, userRepo *mocks.MockUserRepository) {
+				userContact := &entities.UserContact{
+					ID:        uuid.New(),
+					UserID:    userID,
+					ContactID: contactID,
+					Name:      "Mom",
+					Email:     stringPtr("jane@example.com"),
+				}
+				contactRepo.On("GetUserContactRelation", mock.Anything, userID, contactID).Return(userContact, nil)
+				contact := &entities.ContactWithUser{
+					Contact: entities.Contact{
+						ID:        contactID,
+						IsUser:    true,
+						UserIDRef: &linkedUserID,
+					},
+					User: &entities.User{
+						ID:        linkedUserID,
+						Email:     "jane@example.com",
+						FirstName: "Jane",
+						LastName:  "Smith",
+					},
+				}
+				contactRepo.On("GetContactWithUser", mock.Anything, contactID).Return(contact, nil)
 			},
 			expectedError: nil,
 			expectSuccess: true,
+			validate: func(t *testing.T, result *entities.ContactResponse) {
+				assert.Equal(t, "Mom", result.Name)
+				assert.True(t, result.IsUser)
+				assert.True(t, result.IsActiveUser)
+				require.NotNil(t, result.VerifiedName)
+				assert.Equal(t, "Jane Smith", *result.VerifiedName)
+			},
+		},
+		{
+			name:      "contact whose linked user no longer exists is not active",
+			contactID: contactID,
+			userID:    userID,
+			setupMocks: func(contactRepo *mocks.MockContactRepository, userRepo *mocks.MockUserRepository) {
+				userContact := &entities.UserContact{
+					ID:        uuid.New(),
+					UserID:    userID,
+					ContactID: contactID,
+					Name:      "Old Friend",
+				}
+				contactRepo.On("GetUserContactRelation", mock.Anything, userID, contactID).Return(userContact, nil)
+				contact := &entities.ContactWithUser{
+					Contact: entities.Contact{
+						ID:        contactID,
+						IsUser:    true,
+						UserIDRef: &linkedUserID,
+					},
+				}
+				contactRepo.On("GetContactWithUser", mock.Anything, contactID).Return(contact, nil)
+			},
+			expectedError: nil,
+			expectSuccess: true,
+			validate: func(t *testing.T, result *entities.ContactResponse) {
+				assert.True(t, result.IsUser)
+				assert.False(t, result.IsActiveUser)
+				assert.Nil(t, result.VerifiedName)
+			},
 		},
 		{
 			name:      "contact access denied",
@@ -238,6 +331,9 @@ func TestContactService_GetContact(t *testing.T) {
 				assert.NoError(t, err)
 				assert.NotNil(t, result)
 				assert.Equal(t, tt.contactID, result.ID)
+				if tt.validate != nil {
+					tt.validate(t, result)
+				}
 			} else {
 				assert.Error(t, err)
 				assert.Nil(t, result)
@@ -263,6 +359,9 @@ func TestContactService_UpdateContact(t *testing.T) {
 		setupMocks    func(*mocks.MockContactRepository, *mocks.MockUserRepository)
 		expectedError error
 		expectSuccess bool
+
+		expectedName         string
+		expectedVerifiedName *string
 	}{
 		{
 			name:      "successful contact update",
@@ -289,7 +388,9 @@ func TestContactService_UpdateContact(t *testing.T) {
 				}
 				contactRepo.On("GetByID", mock.Anything, contactID).Return(contact, nil)
 				contactRepo.On("UpdateUserContactRelation", mock.Anything, mock.AnythingOfType("*entities.UserContact")).Return(nil)
+				contactRepo.On("GetContactWithUser", mock.Anything, contactID).Return(&entities.ContactWithUser{Contact: *contact}, nil)
 			},
+			expectedName: "Updated Name",
 			expectedError: nil,
 			expectSuccess: true,
 		},
@@ -324,9 +425,36 @@ func TestContactService_UpdateContact(t *testing.T) {
 					LastName:  "User",
 				}
 				userRepo.On("GetByEmail", mock.Anything, "existing.user@example.com").Return(existingUser, nil)
-				contactRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.Contact")).Return(nil)
+				contactRepo.On("IsBlockedBy", mock.Anything, existingUserID, userID).Return(false, nil)
+				contactRepo.On("Update", mock.Anything, mock.MatchedBy(func(c *entities.Contact) bool {
+					return c.IsUser && c.UserIDRef != nil && *c.UserIDRef == existingUserID
+				})).Return(nil)
 				contactRepo.On("UpdateUserContactRelation", mock.Anything, mock.AnythingOfType("*entities.UserContact")).Return(nil)
+
+				// Reciprocal contact for the linked user
+				owner := &entities.User{
+					ID:        userID,
+					Email:     "owner@example.com",
+					FirstName: "Contact",
+					LastName:  "Owner",
+				}
+				userRepo.On("GetByID", mock.Anything, userID).Return(owner, nil)
+				contactRepo.On("ExistsByEmailForUser", mock.Anything, existingUserID, "owner@example.com").Return(false, nil)
+				contactRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *entities.Contact) bool {
+					return c.IsUser && c.UserIDRef != nil && *c.UserIDRef == userID
+				})).Return(nil)
+				contactRepo.On("CreateUserContactRelation", mock.Anything, mock.MatchedBy(func(uc *entities.UserContact) bool {
+					return uc.UserID == existingUserID && uc.Name == "Contact Owner"
+				})).Return(nil)
+
+				// The response carries the linked user's verified name, as GetContact's does
+				contactRepo.On("GetContactWithUser", mock.Anything, contactID).Return(&entities.ContactWithUser{
+					Contact: entities.Contact{ID: contactID, IsUser: true, UserIDRef: &existingUserID},
+					User:    existingUser,
+				}, nil)
 			},
+			expectedName:         "Contact Name",
+			expectedVerifiedName: stringPtr("Existing User"),
 			expectedError: nil,
 			expectSuccess: true,
 		},
@@ -360,7 +488,11 @@ func TestContactService_UpdateContact(t *testing.T) {
 			// Assert
 			if tt.expectSuccess {
 				assert.NoError(t, err)
-				assert.NotNil(t, result)
+				if assert.NotNil(t, result) {
+					assert.Equal(t, tt.expectedName, result.Name)
+					assert.Equal(t, tt.expectedVerifiedName, result.VerifiedName)
+					assert.Equal(t, tt.expectedVerifiedName != nil, result.IsActiveUser)
+				}
 			} else {
 				assert.Error(t, err)
 				assert.ErrorIs(t, err, tt.expectedError)
@@ -399,6 +531,7 @@ func TestContactService_CreateReciprocalContact(t *testing.T) {
 					LastName:  "B",
 				}
 				userRepo.On("GetByEmail", mock.Anything, "userb@example.com").Return(userB, nil)
+				contactRepo.On("IsBlockedBy", mock.Anything, userBID, userAID).Return(false, nil)
 				
 				// User A (contact owner) details
 				userA := &entities.User{
@@ -432,6 +565,7 @@ func TestContactService_CreateReciprocalContact(t *testing.T) {
 					LastName:  "B",
 				}
 				userRepo.On("GetByEmail", mock.Anything, "userb@example.com").Return(userB, nil)
+				contactRepo.On("IsBlockedBy", mock.Anything, userBID, userAID).Return(false, nil)
 				
 				// User A (contact owner) details
 				userA := &entities.User{
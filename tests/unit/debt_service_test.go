@@ -2,6 +2,7 @@ package unit
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -9,8 +10,10 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/domain/interfaces"
 	"pay-your-dues/internal/mocks"
 	"pay-your-dues/internal/services"
 )
@@ -48,7 +51,8 @@ func TestDebtService_CreateDebtList(t *testing.T) {
 					ContactID: contactID,
 				}
 				contactRepo.On("GetUserContactRelation", mock.Anything, userID, contactID).Return(userContact, nil)
-				paymentService.On("CalculateInstallmentAmount", decimal.RequireFromString("1000.00"), "onetime", mock.AnythingOfType("time.Time"), futureDate).Return(decimal.RequireFromString("1000.00"))
+				contactRepo.On("GetByID", mock.Anything, contactID).Return(&entities.Contact{ID: contactID}, nil)
+				paymentService.On("CalculateInstallmentAmount", decimal.RequireFromString("1000.00"), "onetime", mock.AnythingOfType("time.Time"), futureDate, "last").Return(decimal.RequireFromString("1000.00"))
 				debtListRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.DebtList")).Return(nil)
 			},
 			expectedError: nil,
@@ -82,9 +86,10 @@ func TestDebtService_CreateDebtList(t *testing.T) {
 					ContactID: contactID,
 				}
 				contactRepo.On("GetUserContactRelation", mock.Anything, userID, contactID).Return(userContact, nil)
+				contactRepo.On("GetByID", mock.Anything, contactID).Return(&entities.Contact{ID: contactID}, nil)
 				calculatedDueDate := time.Now().AddDate(0, 12, 0)
 				paymentService.On("CalculateDueDateFromNumberOfPayments", mock.AnythingOfType("time.Time"), 12, "monthly").Return(calculatedDueDate)
-				paymentService.On("CalculateInstallmentAmountFromNumberOfPayments", decimal.RequireFromString("2400.00"), 12).Return(decimal.RequireFromString("200.00"))
+				paymentService.On("CalculateInstallmentAmountFromNumberOfPayments", decimal.RequireFromString("2400.00"), 12, "last").Return(decimal.RequireFromString("200.00"))
 				paymentService.On("CalculateNextPaymentDate", mock.AnythingOfType("*entities.DebtList"), (*time.Time)(nil)).Return(time.Now().AddDate(0, 1, 0))
 				debtListRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.DebtList")).Return(nil)
 			},
@@ -127,6 +132,7 @@ func TestDebtService_CreateDebtList(t *testing.T) {
 					ContactID: contactID,
 				}
 				contactRepo.On("GetUserContactRelation", mock.Anything, userID, contactID).Return(userContact, nil)
+				contactRepo.On("GetByID", mock.Anything, contactID).Return(&entities.Contact{ID: contactID}, nil)
 			},
 			expectedError: entities.ErrInvalidAmount,
 			expectSuccess: false,
@@ -147,7 +153,8 @@ func TestDebtService_CreateDebtList(t *testing.T) {
 					ContactID: contactID,
 				}
 				contactRepo.On("GetUserContactRelation", mock.Anything, userID, contactID).Return(userContact, nil)
-				paymentService.On("CalculateInstallmentAmount", decimal.RequireFromString("500.00"), "onetime", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(decimal.RequireFromString("500.00"))
+				contactRepo.On("GetByID", mock.Anything, contactID).Return(&entities.Contact{ID: contactID}, nil)
+				paymentService.On("CalculateInstallmentAmount", decimal.RequireFromString("500.00"), "onetime", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), "last").Return(decimal.RequireFromString("500.00"))
 			},
 			expectedError: entities.ErrInvalidDueDate,
 			expectSuccess: false,
@@ -167,6 +174,21 @@ func TestDebtService_CreateDebtList(t *testing.T) {
 			expectedError: entities.ErrContactNotFound,
 			expectSuccess: false,
 		},
+		{
+			name:   "blocked contact",
+			userID: userID,
+			request: &entities.CreateDebtListRequest{
+				ContactID:   contactID,
+				DebtType:    "to_pay",
+				TotalAmount: "500.00",
+				DueDate:     &futureDate,
+			},
+			setupMocks: func(debtListRepo *mocks.MockDebtListRepository, debtItemRepo *mocks.MockDebtItemRepository, contactRepo *mocks.MockContactRepository, paymentService *mocks.MockPaymentScheduleService) {
+				contactRepo.On("GetUserContactRelation", mock.Anything, userID, contactID).Return(&entities.UserContact{UserID: userID, ContactID: contactID, IsBlocked: true}, nil)
+			},
+			expectedError: entities.ErrContactBlocked,
+			expectSuccess: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -247,12 +269,10 @@ func TestDebtService_CreateDebtItem(t *testing.T) {
 				debtItemRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.DebtItem")).Return(nil)
 				
 				// Mock the update calls
-				debtItemRepo.On("GetTotalPaidForDebtList", mock.Anything, debtListID).Return(decimal.RequireFromString("200.00"), nil)
-				debtItemRepo.On("GetLastPaymentDate", mock.Anything, debtListID).Return(&paymentDate, nil)
+				debtListRepo.On("RecalculatePaymentTotals", mock.Anything, debtListID, mock.Anything).
+					Run(recalculatesTotals(t, debtList, entities.PaymentAggregate{TotalPaid: decimal.RequireFromString("200.00"), PaymentCount: 1, LastPaymentDate: &paymentDate}, "200.00", "800.00", "active")).
+					Return(nil)
 				paymentService.On("CalculateNextPaymentDate", mock.AnythingOfType("*entities.DebtList"), &paymentDate).Return(time.Now().AddDate(0, 1, 0))
-				debtListRepo.On("UpdatePaymentTotals", mock.Anything, debtListID, decimal.RequireFromString("200.00"), decimal.RequireFromString("800.00")).Return(nil)
-				debtListRepo.On("UpdateStatus", mock.Anything, debtListID, "active").Return(nil)
-				debtListRepo.On("UpdateNextPaymentDate", mock.Anything, debtListID, mock.AnythingOfType("time.Time")).Return(nil)
 			},
 			expectedError: nil,
 			expectSuccess: true,
@@ -355,13 +375,11 @@ func TestDebtService_CreateDebtItem(t *testing.T) {
 				// Mock debt item creation
 				debtItemRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.DebtItem")).Return(nil)
 				
-				// Mock the update calls
-				debtItemRepo.On("GetTotalPaidForDebtList", mock.Anything, debtListID).Return(decimal.RequireFromString("150.00"), nil)
-				debtItemRepo.On("GetLastPaymentDate", mock.Anything, debtListID).Return(&paymentDate, nil)
-				paymentService.On("CalculateNextPaymentDate", mock.AnythingOfType("*entities.DebtList"), &paymentDate).Return(time.Now().AddDate(0, 1, 0))
-				debtListRepo.On("UpdatePaymentTotals", mock.Anything, debtListID, decimal.RequireFromString("150.00"), decimal.RequireFromString("350.00")).Return(nil)
-				debtListRepo.On("UpdateStatus", mock.Anything, debtListID, "active").Return(nil)
-				debtListRepo.On("UpdateNextPaymentDate", mock.Anything, debtListID, mock.AnythingOfType("time.Time")).Return(nil)
+				// The payment is pending, so the completed payments it aggregates are unchanged
+				debtListRepo.On("RecalculatePaymentTotals", mock.Anything, debtListID, mock.Anything).
+					Run(recalculatesTotals(t, debtList, entities.PaymentAggregate{TotalPaid: decimal.Zero}, "0", "500.00", "active")).
+					Return(nil)
+				paymentService.On("CalculateNextPaymentDate", mock.AnythingOfType("*entities.DebtList"), (*time.Time)(nil)).Return(time.Now().AddDate(0, 1, 0))
 			},
 			expectedError: nil,
 			expectSuccess: true,
@@ -450,6 +468,7 @@ func TestDebtService_GetOverdueItems(t *testing.T) {
 					},
 				}
 				debtListRepo.On("GetOverdueForUser", mock.Anything, userID).Return(overdueDebts, nil)
+				debtListRepo.On("GetOverdueWhereUserIsContact", mock.Anything, userID).Return([]entities.DebtList{}, nil)
 			},
 			expectedError: nil,
 			expectSuccess: true,
@@ -460,6 +479,7 @@ func TestDebtService_GetOverdueItems(t *testing.T) {
 			userID: userID,
 			setupMocks: func(debtListRepo *mocks.MockDebtListRepository, debtItemRepo *mocks.MockDebtItemRepository, contactRepo *mocks.MockContactRepository, paymentService *mocks.MockPaymentScheduleService) {
 				debtListRepo.On("GetOverdueForUser", mock.Anything, userID).Return([]entities.DebtList{}, nil)
+				debtListRepo.On("GetOverdueWhereUserIsContact", mock.Anything, userID).Return([]entities.DebtList{}, nil)
 			},
 			expectedError: nil,
 			expectSuccess: true,
@@ -482,7 +502,7 @@ func TestDebtService_GetOverdueItems(t *testing.T) {
 
 			// Execute
 			ctx := context.Background()
-			result, err := debtService.GetOverdueItems(ctx, tt.userID)
+			result, err := debtService.GetOverdueItems(ctx, tt.userID, "")
 
 			// Assert
 			if tt.expectSuccess {
@@ -504,11 +524,1376 @@ func TestDebtService_GetOverdueItems(t *testing.T) {
 	}
 }
 
-// Helper functions
-func intPtr(i int) *int {
-	return &i
+func TestDebtService_CreateQuickDebt(t *testing.T) {
+	userID := uuid.New()
+	contactID := uuid.New()
+
+	now := time.Now().UTC()
+	expectedDueDate := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC).Add(-time.Second)
+
+	tests := []struct {
+		name             string
+		setupSettings    func(*mocks.MockUserSettingsRepository)
+		expectedCurrency string
+	}{
+		{
+			name: "uses the user's default currency",
+			setupSettings: func(settingsRepo *mocks.MockUserSettingsRepository) {
+				settingsRepo.On("GetByUserID", mock.Anything, userID).Return(&entities.UserSettings{
+					UserID:          userID,
+					DefaultCurrency: "USD",
+				}, nil)
+			},
+			expectedCurrency: "USD",
+		},
+		{
+			name: "falls back to the system default without settings",
+			setupSettings: func(settingsRepo *mocks.MockUserSettingsRepository) {
+				settingsRepo.On("GetByUserID", mock.Anything, userID).Return(nil, entities.ErrUserSettingsNotFound)
+			},
+			expectedCurrency: "PHP",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			debtListRepo := &mocks.MockDebtListRepository{}
+			debtItemRepo := &mocks.MockDebtItemRepository{}
+			contactRepo := &mocks.MockContactRepository{}
+			paymentService := &mocks.MockPaymentScheduleService{}
+			settingsRepo := &mocks.MockUserSettingsRepository{}
+			tt.setupSettings(settingsRepo)
+
+			contactRepo.On("GetUserContactRelation", mock.Anything, userID, contactID).Return(&entities.UserContact{
+				ID:        uuid.New(),
+				UserID:    userID,
+				ContactID: contactID,
+			}, nil)
+			contactRepo.On("GetByID", mock.Anything, contactID).Return(&entities.Contact{ID: contactID}, nil)
+			paymentService.On("CalculateInstallmentAmount", decimal.RequireFromString("750.00"), "onetime", mock.AnythingOfType("time.Time"), expectedDueDate, "last").Return(decimal.RequireFromString("750.00"))
+			debtListRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.DebtList")).Return(nil)
+
+			// Create service
+			fileStorageService := &mocks.MockFileStorageService{}
+			debtService := services.NewDebtService(debtListRepo, debtItemRepo, contactRepo, paymentService, fileStorageService,
+				services.WithUserSettingsRepository(settingsRepo),
+			)
+
+			// Execute
+			ctx := context.Background()
+			result, err := debtService.CreateQuickDebt(ctx, userID, &entities.QuickDebtRequest{
+				ContactID: contactID,
+				Amount:    "750.00",
+				DebtType:  "to_receive",
+			})
+
+			// Assert
+			assert.NoError(t, err)
+			if assert.NotNil(t, result) {
+				assert.Equal(t, tt.expectedCurrency, result.Currency)
+				assert.Equal(t, "onetime", result.InstallmentPlan)
+				assert.True(t, expectedDueDate.Equal(result.DueDate), "due date should be the end of the current month")
+				assert.Equal(t, time.UTC, result.DueDate.Location())
+				assert.True(t, expectedDueDate.Equal(result.NextPaymentDate))
+				assert.Equal(t, "750", result.InstallmentAmount.String())
+				assert.Nil(t, result.NumberOfPayments)
+			}
+
+			// Verify mock expectations
+			debtListRepo.AssertExpectations(t)
+			contactRepo.AssertExpectations(t)
+			paymentService.AssertExpectations(t)
+			settingsRepo.AssertExpectations(t)
+		})
+	}
 }
 
-func timePtr(t time.Time) *time.Time {
-	return &t
+func TestDebtService_CreateDebtItem_CancelledContext(t *testing.T) {
+	// Setup mocks with no expectations so any repository call fails the test
+	debtListRepo := &mocks.MockDebtListRepository{}
+	debtItemRepo := &mocks.MockDebtItemRepository{}
+	contactRepo := &mocks.MockContactRepository{}
+	paymentService := &mocks.MockPaymentScheduleService{}
+	fileStorageService := &mocks.MockFileStorageService{}
+
+	debtService := services.NewDebtService(debtListRepo, debtItemRepo, contactRepo, paymentService, fileStorageService)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := debtService.CreateDebtItem(ctx, uuid.New(), &entities.CreateDebtItemRequest{
+		DebtListID:    uuid.New(),
+		Amount:        "100.00",
+		PaymentDate:   time.Now(),
+		PaymentMethod: "cash",
+	})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, context.Canceled)
+	debtListRepo.AssertNotCalled(t, "BelongsToUser", mock.Anything, mock.Anything, mock.Anything)
+	debtItemRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestDebtService_DeleteDebtItems(t *testing.T) {
+	userID := uuid.New()
+	debtListID := uuid.New()
+	firstID := uuid.New()
+	secondID := uuid.New()
+	keptID := uuid.New()
+	receiptURL := "https://bucket.s3.amazonaws.com/receipts/first.jpg"
+	paymentDate := time.Now().AddDate(0, 0, -3)
+
+	existingItems := []entities.DebtItem{
+		{ID: firstID, DebtListID: debtListID, Amount: decimal.RequireFromString("100.00"), Status: "completed", ReceiptPhotoURL: &receiptURL},
+		{ID: secondID, DebtListID: debtListID, Amount: decimal.RequireFromString("50.00"), Status: "completed"},
+		{ID: keptID, DebtListID: debtListID, Amount: decimal.RequireFromString("200.00"), Status: "completed"},
+	}
+
+	tests := []struct {
+		name          string
+		ids           []uuid.UUID
+		opts          []services.DebtServiceOption
+		setupMocks    func(*mocks.MockDebtListRepository, *mocks.MockDebtItemRepository, *mocks.MockPaymentScheduleService, *mocks.MockFileStorageService)
+		expectedError error
+	}{
+		{
+			name: "delete several payments and recompute totals once",
+			ids:  []uuid.UUID{firstID, secondID, firstID},
+			setupMocks: func(debtListRepo *mocks.MockDebtListRepository, debtItemRepo *mocks.MockDebtItemRepository, paymentService *mocks.MockPaymentScheduleService, fileStorageService *mocks.MockFileStorageService) {
+				debtListRepo.On("BelongsToUser", mock.Anything, debtListID, userID).Return(true, nil)
+				debtItemRepo.On("GetByDebtListID", mock.Anything, debtListID).Return(existingItems, nil)
+				// Soft-deleted payments keep their receipts so they can be restored
+				debtItemRepo.On("DeleteByIDs", mock.Anything, debtListID, []uuid.UUID{firstID, secondID}).Return(nil).Once()
+
+				// Totals recompute
+				debtList := &entities.DebtList{
+					ID:              debtListID,
+					UserID:          userID,
+					TotalAmount:     decimal.RequireFromString("1000.00"),
+					InstallmentPlan: "monthly",
+					NextPaymentDate: time.Now().AddDate(0, 1, 0),
+				}
+				debtListRepo.On("RecalculatePaymentTotals", mock.Anything, debtListID, mock.Anything).
+					Run(recalculatesTotals(t, debtList, entities.PaymentAggregate{TotalPaid: decimal.RequireFromString("200.00"), PaymentCount: 1, LastPaymentDate: &paymentDate}, "200.00", "800.00", "active")).
+					Return(nil).Once()
+				paymentService.On("CalculateNextPaymentDate", mock.AnythingOfType("*entities.DebtList"), &paymentDate).Return(time.Now().AddDate(0, 1, 0)).Once()
+			},
+		},
+		{
+			name: "permanent deletion removes receipts",
+			ids:  []uuid.UUID{firstID, secondID},
+			opts: []services.DebtServiceOption{services.WithSoftDeletePayments(false)},
+			setupMocks: func(debtListRepo *mocks.MockDebtListRepository, debtItemRepo *mocks.MockDebtItemRepository, paymentService *mocks.MockPaymentScheduleService, fileStorageService *mocks.MockFileStorageService) {
+				debtListRepo.On("BelongsToUser", mock.Anything, debtListID, userID).Return(true, nil)
+				debtItemRepo.On("GetByDebtListID", mock.Anything, debtListID).Return(existingItems, nil)
+				debtItemRepo.On("PurgeByIDs", mock.Anything, debtListID, []uuid.UUID{firstID, secondID}).Return(nil).Once()
+				fileStorageService.On("DeleteReceipt", mock.Anything, receiptURL).Return(nil).Once()
+
+				// Totals recompute
+				debtList := &entities.DebtList{
+					ID:              debtListID,
+					UserID:          userID,
+					TotalAmount:     decimal.RequireFromString("1000.00"),
+					InstallmentPlan: "monthly",
+					NextPaymentDate: time.Now().AddDate(0, 1, 0),
+				}
+				debtListRepo.On("RecalculatePaymentTotals", mock.Anything, debtListID, mock.Anything).
+					Run(recalculatesTotals(t, debtList, entities.PaymentAggregate{TotalPaid: decimal.RequireFromString("200.00"), PaymentCount: 1, LastPaymentDate: &paymentDate}, "200.00", "800.00", "active")).
+					Return(nil).Once()
+				paymentService.On("CalculateNextPaymentDate", mock.AnythingOfType("*entities.DebtList"), &paymentDate).Return(time.Now().AddDate(0, 1, 0)).Once()
+			},
+		},
+		{
+			name: "non-owner cannot bulk delete",
+			ids:  []uuid.UUID{firstID},
+			setupMocks: func(debtListRepo *mocks.MockDebtListRepository, debtItemRepo *mocks.MockDebtItemRepository, paymentService *mocks.MockPaymentScheduleService, fileStorageService *mocks.MockFileStorageService) {
+				debtListRepo.On("BelongsToUser", mock.Anything, debtListID, userID).Return(false, nil)
+			},
+			expectedError: entities.ErrDebtListNotFound,
+		},
+		{
+			name: "payment from another debt list",
+			ids:  []uuid.UUID{firstID, uuid.New()},
+			setupMocks: func(debtListRepo *mocks.MockDebtListRepository, debtItemRepo *mocks.MockDebtItemRepository, paymentService *mocks.MockPaymentScheduleService, fileStorageService *mocks.MockFileStorageService) {
+				debtListRepo.On("BelongsToUser", mock.Anything, debtListID, userID).Return(true, nil)
+				debtItemRepo.On("GetByDebtListID", mock.Anything, debtListID).Return(existingItems, nil)
+			},
+			expectedError: entities.ErrDebtItemNotFound,
+		},
+		{
+			name: "no ids",
+			ids:  nil,
+			setupMocks: func(debtListRepo *mocks.MockDebtListRepository, debtItemRepo *mocks.MockDebtItemRepository, paymentService *mocks.MockPaymentScheduleService, fileStorageService *mocks.MockFileStorageService) {
+				// Validation fails before any repository call
+			},
+			expectedError: entities.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			debtListRepo := &mocks.MockDebtListRepository{}
+			debtItemRepo := &mocks.MockDebtItemRepository{}
+			contactRepo := &mocks.MockContactRepository{}
+			paymentService := &mocks.MockPaymentScheduleService{}
+			fileStorageService := &mocks.MockFileStorageService{}
+			tt.setupMocks(debtListRepo, debtItemRepo, paymentService, fileStorageService)
+
+			debtService := services.NewDebtService(debtListRepo, debtItemRepo, contactRepo, paymentService, fileStorageService, tt.opts...)
+
+			// Execute
+			err := debtService.DeleteDebtItems(context.Background(), debtListID, userID, tt.ids)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				debtItemRepo.AssertNotCalled(t, "DeleteByIDs", mock.Anything, mock.Anything, mock.Anything)
+				debtItemRepo.AssertNotCalled(t, "PurgeByIDs", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			// Verify mock expectations
+			debtListRepo.AssertExpectations(t)
+			debtItemRepo.AssertExpectations(t)
+			paymentService.AssertExpectations(t)
+			fileStorageService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDebtService_CreateDebtList_DefaultInstallmentPlan(t *testing.T) {
+	userID := uuid.New()
+	contactID := uuid.New()
+	dueDate := time.Now().AddDate(0, 6, 0)
+
+	tests := []struct {
+		name            string
+		installmentPlan string
+		setupSettings   func(*mocks.MockUserSettingsRepository)
+		expectedPlan    string
+	}{
+		{
+			name:            "user default applied when plan omitted",
+			installmentPlan: "",
+			setupSettings: func(settingsRepo *mocks.MockUserSettingsRepository) {
+				settingsRepo.On("GetByUserID", mock.Anything, userID).Return(&entities.UserSettings{
+					UserID:                 userID,
+					DefaultInstallmentPlan: "monthly",
+				}, nil)
+			},
+			expectedPlan: "monthly",
+		},
+		{
+			name:            "onetime when user has no settings",
+			installmentPlan: "",
+			setupSettings: func(settingsRepo *mocks.MockUserSettingsRepository) {
+				settingsRepo.On("GetByUserID", mock.Anything, userID).Return(nil, entities.ErrUserSettingsNotFound)
+			},
+			expectedPlan: "onetime",
+		},
+		{
+			name:            "unknown stored default falls back to onetime",
+			installmentPlan: "",
+			setupSettings: func(settingsRepo *mocks.MockUserSettingsRepository) {
+				settingsRepo.On("GetByUserID", mock.Anything, userID).Return(&entities.UserSettings{
+					UserID:                 userID,
+					DefaultInstallmentPlan: "fortnightly",
+				}, nil)
+			},
+			expectedPlan: "onetime",
+		},
+		{
+			name:            "explicit plan wins over user default",
+			installmentPlan: "weekly",
+			setupSettings: func(settingsRepo *mocks.MockUserSettingsRepository) {
+				// Settings are not consulted when the plan is provided
+			},
+			expectedPlan: "weekly",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			debtListRepo := &mocks.MockDebtListRepository{}
+			debtItemRepo := &mocks.MockDebtItemRepository{}
+			contactRepo := &mocks.MockContactRepository{}
+			paymentService := &mocks.MockPaymentScheduleService{}
+			settingsRepo := &mocks.MockUserSettingsRepository{}
+			tt.setupSettings(settingsRepo)
+
+			contactRepo.On("GetUserContactRelation", mock.Anything, userID, contactID).Return(&entities.UserContact{
+				ID:        uuid.New(),
+				UserID:    userID,
+				ContactID: contactID,
+			}, nil)
+			contactRepo.On("GetByID", mock.Anything, contactID).Return(&entities.Contact{ID: contactID}, nil)
+			paymentService.On("CalculateInstallmentAmount", decimal.RequireFromString("600.00"), tt.expectedPlan, mock.AnythingOfType("time.Time"), dueDate, "last").Return(decimal.RequireFromString("100.00"))
+			paymentService.On("CalculateNextPaymentDate", mock.AnythingOfType("*entities.DebtList"), (*time.Time)(nil)).Return(time.Now().AddDate(0, 1, 0)).Maybe()
+			debtListRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.DebtList")).Return(nil)
+
+			// Create service
+			fileStorageService := &mocks.MockFileStorageService{}
+			debtService := services.NewDebtService(debtListRepo, debtItemRepo, contactRepo, paymentService, fileStorageService,
+				services.WithUserSettingsRepository(settingsRepo),
+			)
+
+			// Execute
+			result, err := debtService.CreateDebtList(context.Background(), userID, &entities.CreateDebtListRequest{
+				ContactID:       contactID,
+				DebtType:        "to_receive",
+				TotalAmount:     "600.00",
+				Currency:        "USD",
+				DueDate:         &dueDate,
+				InstallmentPlan: tt.installmentPlan,
+			})
+
+			// Assert
+			assert.NoError(t, err)
+			if assert.NotNil(t, result) {
+				assert.Equal(t, tt.expectedPlan, result.InstallmentPlan)
+			}
+
+			// Verify mock expectations
+			debtListRepo.AssertExpectations(t)
+			paymentService.AssertExpectations(t)
+			settingsRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDebtService_CreateDebtList_DefaultDueDate(t *testing.T) {
+	userID := uuid.New()
+	contactID := uuid.New()
+
+	tests := []struct {
+		name            string
+		installmentPlan string
+		opts            []services.DebtServiceOption
+		expectedOffset  time.Duration
+	}{
+		{
+			name:            "defaults to 30 days out",
+			installmentPlan: "onetime",
+			expectedOffset:  services.DefaultDueDateOffset,
+		},
+		{
+			name:            "configured offset",
+			installmentPlan: "onetime",
+			opts:            []services.DebtServiceOption{services.WithDefaultDueDateOffset(7 * 24 * time.Hour)},
+			expectedOffset:  7 * 24 * time.Hour,
+		},
+		{
+			name:           "plan omitted falls back to onetime",
+			expectedOffset: services.DefaultDueDateOffset,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			debtListRepo := &mocks.MockDebtListRepository{}
+			debtItemRepo := &mocks.MockDebtItemRepository{}
+			contactRepo := &mocks.MockContactRepository{}
+			contactRepo.On("GetUserContactRelation", mock.Anything, userID, contactID).Return(&entities.UserContact{
+				ID:        uuid.New(),
+				UserID:    userID,
+				ContactID: contactID,
+			}, nil)
+			contactRepo.On("GetByID", mock.Anything, contactID).Return(&entities.Contact{ID: contactID}, nil)
+			debtListRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.DebtList")).Return(nil)
+
+			// Create service
+			fileStorageService := &mocks.MockFileStorageService{}
+			debtService := services.NewDebtService(debtListRepo, debtItemRepo, contactRepo, services.NewPaymentScheduleService(), fileStorageService, tt.opts...)
+
+			// Execute: neither a due date nor a number of payments
+			before := time.Now()
+			result, err := debtService.CreateDebtList(context.Background(), userID, &entities.CreateDebtListRequest{
+				ContactID:       contactID,
+				DebtType:        "to_receive",
+				TotalAmount:     "250.00",
+				Currency:        "USD",
+				InstallmentPlan: tt.installmentPlan,
+			})
+			after := time.Now()
+
+			// Assert
+			require.NoError(t, err)
+			assert.True(t, result.DueDate.After(after))
+			assert.False(t, result.DueDate.Before(before.Add(tt.expectedOffset)))
+			assert.False(t, result.DueDate.After(after.Add(tt.expectedOffset)))
+			assert.Equal(t, "onetime", result.InstallmentPlan)
+			assert.Equal(t, result.DueDate, result.NextPaymentDate)
+			require.NotNil(t, result.NumberOfPayments)
+			assert.Equal(t, 1, *result.NumberOfPayments)
+			assert.Equal(t, "250", result.InstallmentAmount.String())
+
+			debtListRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDebtService_CreateDebtItem_PaymentDateWindow(t *testing.T) {
+	userID := uuid.New()
+	debtListID := uuid.New()
+
+	tests := []struct {
+		name          string
+		paymentDate   time.Time
+		opts          []services.DebtServiceOption
+		expectedError error
+	}{
+		{
+			name:          "reject payment post-dated beyond the default window",
+			paymentDate:   time.Now().AddDate(0, 0, 10),
+			expectedError: entities.ErrPaymentDateTooFarInFuture,
+		},
+		{
+			name:        "accept past-dated payment for backfilling",
+			paymentDate: time.Now().AddDate(0, -2, 0),
+		},
+		{
+			name:        "accept post-dated payment within a configured window",
+			paymentDate: time.Now().AddDate(0, 0, 10),
+			opts:        []services.DebtServiceOption{services.WithFuturePaymentWindow(30 * 24 * time.Hour)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			debtListRepo := &mocks.MockDebtListRepository{}
+			debtItemRepo := &mocks.MockDebtItemRepository{}
+			contactRepo := &mocks.MockContactRepository{}
+			paymentService := &mocks.MockPaymentScheduleService{}
+
+			if tt.expectedError == nil {
+				paymentDate := tt.paymentDate
+				debtListRepo.On("BelongsToUser", mock.Anything, debtListID, userID).Return(true, nil)
+				debtList := &entities.DebtList{
+					ID:              debtListID,
+					UserID:          userID,
+					Currency:        "USD",
+					TotalAmount:     decimal.RequireFromString("1000.00"),
+					NextPaymentDate: time.Now().AddDate(0, 1, 0),
+					CreatedAt:       time.Now().AddDate(0, -3, 0),
+				}
+				debtListRepo.On("GetByID", mock.Anything, debtListID).Return(debtList, nil)
+				debtItemRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.DebtItem")).Return(nil)
+				debtListRepo.On("RecalculatePaymentTotals", mock.Anything, debtListID, mock.Anything).
+					Run(recalculatesTotals(t, debtList, entities.PaymentAggregate{TotalPaid: decimal.RequireFromString("100.00"), PaymentCount: 1, LastPaymentDate: &paymentDate}, "100.00", "900.00", "active")).
+					Return(nil)
+				paymentService.On("CalculateNextPaymentDate", mock.AnythingOfType("*entities.DebtList"), &paymentDate).Return(time.Now().AddDate(0, 1, 0))
+			}
+
+			// Create service
+			fileStorageService := &mocks.MockFileStorageService{}
+			debtService := services.NewDebtService(debtListRepo, debtItemRepo, contactRepo, paymentService, fileStorageService, tt.opts...)
+
+			// Execute
+			result, err := debtService.CreateDebtItem(context.Background(), userID, &entities.CreateDebtItemRequest{
+				DebtListID:    debtListID,
+				Amount:        "100.00",
+				PaymentDate:   tt.paymentDate,
+				PaymentMethod: "cash",
+			})
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				debtItemRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+			}
+
+			// Verify mock expectations
+			debtListRepo.AssertExpectations(t)
+			debtItemRepo.AssertExpectations(t)
+			paymentService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDebtService_GetItemsByDirection(t *testing.T) {
+	userID := uuid.New()
+	lentID := uuid.New()     // owned, user is owed
+	borrowedID := uuid.New() // owned, user owes
+	theyLentID := uuid.New() // user is the contact of a to_receive debt, so user owes
+	theyOweID := uuid.New()  // user is the contact of a to_pay debt, so user is owed
+
+	newOwned := func() []entities.DebtList {
+		return []entities.DebtList{
+			{ID: lentID, UserID: userID, DebtType: "to_receive", NextPaymentDate: time.Now().AddDate(0, 0, -3)},
+			{ID: borrowedID, UserID: userID, DebtType: "to_pay", NextPaymentDate: time.Now().AddDate(0, 0, -1)},
+		}
+	}
+	newContact := func() []entities.DebtList {
+		return []entities.DebtList{
+			{ID: theyLentID, UserID: uuid.New(), DebtType: "to_receive", NextPaymentDate: time.Now().AddDate(0, 0, -4)},
+			{ID: theyOweID, UserID: uuid.New(), DebtType: "to_pay", NextPaymentDate: time.Now().AddDate(0, 0, -2)},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		direction     string
+		expectedError error
+		expectedIDs   []uuid.UUID
+		expectedTypes []string
+	}{
+		{
+			name:          "no direction returns both sides ordered by next payment date",
+			direction:     "",
+			expectedIDs:   []uuid.UUID{theyLentID, lentID, theyOweID, borrowedID},
+			expectedTypes: []string{"to_pay", "to_receive", "to_receive", "to_pay"},
+		},
+		{
+			name:          "owed to me",
+			direction:     entities.DebtDirectionOwedToMe,
+			expectedIDs:   []uuid.UUID{lentID, theyOweID},
+			expectedTypes: []string{"to_receive", "to_receive"},
+		},
+		{
+			name:          "i owe",
+			direction:     entities.DebtDirectionIOwe,
+			expectedIDs:   []uuid.UUID{theyLentID, borrowedID},
+			expectedTypes: []string{"to_pay", "to_pay"},
+		},
+		{
+			name:          "unknown direction",
+			direction:     "sideways",
+			expectedError: entities.ErrInvalidDebtDirection,
+		},
+	}
+
+	for _, tt := range tests {
+		for _, kind := range []string{"overdue", "due soon"} {
+			t.Run(tt.name+" ("+kind+")", func(t *testing.T) {
+				debtListRepo := &mocks.MockDebtListRepository{}
+				debtItemRepo := &mocks.MockDebtItemRepository{}
+				contactRepo := &mocks.MockContactRepository{}
+				paymentService := &mocks.MockPaymentScheduleService{}
+				fileStorageService := &mocks.MockFileStorageService{}
+
+				if tt.expectedError == nil {
+					if kind == "overdue" {
+						debtListRepo.On("GetOverdueForUser", mock.Anything, userID).Return(newOwned(), nil)
+						debtListRepo.On("GetOverdueWhereUserIsContact", mock.Anything, userID).Return(newContact(), nil)
+					} else {
+						debtListRepo.On("GetDueSoonForUser", mock.Anything, userID, mock.AnythingOfType("time.Time")).Return(newOwned(), nil)
+						debtListRepo.On("GetDueSoonWhereUserIsContact", mock.Anything, userID, mock.AnythingOfType("time.Time")).Return(newContact(), nil)
+					}
+				}
+
+				debtService := services.NewDebtService(debtListRepo, debtItemRepo, contactRepo, paymentService, fileStorageService)
+
+				var result []entities.DebtList
+				var err error
+				if kind == "overdue" {
+					result, err = debtService.GetOverdueItems(context.Background(), userID, tt.direction)
+				} else {
+					result, err = debtService.GetDueSoonItems(context.Background(), userID, 7, tt.direction)
+				}
+
+				if tt.expectedError != nil {
+					assert.ErrorIs(t, err, tt.expectedError)
+					assert.Nil(t, result)
+				} else {
+					assert.NoError(t, err)
+					ids := make([]uuid.UUID, len(result))
+					types := make([]string, len(result))
+					for i, debtList := range result {
+						ids[i] = debtList.ID
+						types[i] = debtList.DebtType
+					}
+					assert.Equal(t, tt.expectedIDs, ids)
+					assert.Equal(t, tt.expectedTypes, types)
+				}
+
+				debtListRepo.AssertExpectations(t)
+			})
+		}
+	}
+}
+
+func TestDebtService_GetBalanceHistory(t *testing.T) {
+	userID := uuid.New()
+	debtListID := uuid.New()
+	createdAt := time.Now().AddDate(0, -3, 0)
+
+	tests := []struct {
+		name             string
+		setupMocks       func(*mocks.MockDebtListRepository, *mocks.MockDebtItemRepository)
+		expectedError    error
+		expectedBalances []string
+	}{
+		{
+			name: "sequential payments decrease the balance",
+			setupMocks: func(debtListRepo *mocks.MockDebtListRepository, debtItemRepo *mocks.MockDebtItemRepository) {
+				debtListRepo.On("BelongsToUser", mock.Anything, debtListID, userID).Return(true, nil)
+				debtListRepo.On("GetByID", mock.Anything, debtListID).Return(&entities.DebtList{
+					ID:          debtListID,
+					UserID:      userID,
+					TotalAmount: decimal.RequireFromString("1000.00"),
+					CreatedAt:   createdAt,
+				}, nil)
+				// Returned out of order to ensure the history is sorted by payment date
+				debtItemRepo.On("GetCompletedPaymentsForDebtList", mock.Anything, debtListID).Return([]entities.DebtItem{
+					{ID: uuid.New(), Amount: decimal.RequireFromString("300.00"), PaymentDate: createdAt.AddDate(0, 2, 0)},
+					{ID: uuid.New(), Amount: decimal.RequireFromString("250.00"), PaymentDate: createdAt.AddDate(0, 1, 0)},
+					{ID: uuid.New(), Amount: decimal.RequireFromString("100.00"), PaymentDate: createdAt.AddDate(0, 2, 15)},
+				}, nil)
+			},
+			expectedBalances: []string{"1000.00", "750.00", "450.00", "350.00"},
+		},
+		{
+			name: "overpayment floors the balance at zero",
+			setupMocks: func(debtListRepo *mocks.MockDebtListRepository, debtItemRepo *mocks.MockDebtItemRepository) {
+				debtListRepo.On("BelongsToUser", mock.Anything, debtListID, userID).Return(true, nil)
+				debtListRepo.On("GetByID", mock.Anything, debtListID).Return(&entities.DebtList{
+					ID:          debtListID,
+					UserID:      userID,
+					TotalAmount: decimal.RequireFromString("100.00"),
+					CreatedAt:   createdAt,
+				}, nil)
+				debtItemRepo.On("GetCompletedPaymentsForDebtList", mock.Anything, debtListID).Return([]entities.DebtItem{
+					{ID: uuid.New(), Amount: decimal.RequireFromString("150.00"), PaymentDate: createdAt.AddDate(0, 1, 0)},
+				}, nil)
+			},
+			expectedBalances: []string{"100.00", "0.00"},
+		},
+		{
+			name: "no payments yields the starting balance only",
+			setupMocks: func(debtListRepo *mocks.MockDebtListRepository, debtItemRepo *mocks.MockDebtItemRepository) {
+				debtListRepo.On("BelongsToUser", mock.Anything, debtListID, userID).Return(false, nil)
+				debtListRepo.On("IsContactOfDebtList", mock.Anything, debtListID, userID).Return(true, nil)
+				debtListRepo.On("GetByID", mock.Anything, debtListID).Return(&entities.DebtList{
+					ID:          debtListID,
+					TotalAmount: decimal.RequireFromString("500.00"),
+					CreatedAt:   createdAt,
+				}, nil)
+				debtItemRepo.On("GetCompletedPaymentsForDebtList", mock.Anything, debtListID).Return([]entities.DebtItem{}, nil)
+			},
+			expectedBalances: []string{"500.00"},
+		},
+		{
+			name: "debt list not accessible",
+			setupMocks: func(debtListRepo *mocks.MockDebtListRepository, debtItemRepo *mocks.MockDebtItemRepository) {
+				debtListRepo.On("BelongsToUser", mock.Anything, debtListID, userID).Return(false, nil)
+				debtListRepo.On("IsContactOfDebtList", mock.Anything, debtListID, userID).Return(false, nil)
+			},
+			expectedError: entities.ErrDebtListNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			debtListRepo := &mocks.MockDebtListRepository{}
+			debtItemRepo := &mocks.MockDebtItemRepository{}
+			contactRepo := &mocks.MockContactRepository{}
+			paymentService := &mocks.MockPaymentScheduleService{}
+			fileStorageService := &mocks.MockFileStorageService{}
+			tt.setupMocks(debtListRepo, debtItemRepo)
+
+			debtService := services.NewDebtService(debtListRepo, debtItemRepo, contactRepo, paymentService, fileStorageService)
+
+			history, err := debtService.GetBalanceHistory(context.Background(), debtListID, userID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, history)
+			} else {
+				assert.NoError(t, err)
+				if assert.Len(t, history, len(tt.expectedBalances)) {
+					assert.True(t, history[0].Date.Equal(createdAt))
+					for i, expected := range tt.expectedBalances {
+						assert.Equal(t, expected, history[i].Balance.StringFixed(2), "point %d", i)
+						if i > 0 {
+							assert.True(t, history[i].Balance.LessThanOrEqual(history[i-1].Balance), "balance should not increase at point %d", i)
+							assert.False(t, history[i].Date.Before(history[i-1].Date), "dates should be in order at point %d", i)
+						}
+					}
+				}
+			}
+
+			debtListRepo.AssertExpectations(t)
+			debtItemRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDebtService_UpdateDebtList_Status(t *testing.T) {
+	userID := uuid.New()
+	debtListID := uuid.New()
+
+	newDebtList := func(paid string, nextPaymentDate time.Time) *entities.DebtList {
+		return &entities.DebtList{
+			ID:                 debtListID,
+			UserID:             userID,
+			ContactID:          uuid.New(),
+			DebtType:           "to_receive",
+			TotalAmount:        decimal.RequireFromString("500.00"),
+			InstallmentAmount:  decimal.RequireFromString("500.00"),
+			TotalPaymentsMade:  decimal.RequireFromString(paid),
+			TotalRemainingDebt: decimal.RequireFromString("500.00").Sub(decimal.RequireFromString(paid)),
+			Currency:           "USD",
+			Status:             "active",
+			DueDate:            nextPaymentDate,
+			NextPaymentDate:    nextPaymentDate,
+			InstallmentPlan:    "onetime",
+			NumberOfPayments:   intPtr(1),
+		}
+	}
+	future := time.Now().AddDate(0, 1, 0)
+	past := time.Now().AddDate(0, -1, 0)
+
+	tests := []struct {
+		name          string
+		status        string
+		setupMocks    func(*mocks.MockDebtListRepository, *mocks.MockDebtItemRepository, *mocks.MockPaymentScheduleService)
+		expectedError error
+	}{
+		{
+			name:          "unknown status is rejected",
+			status:        "banana",
+			setupMocks:    func(*mocks.MockDebtListRepository, *mocks.MockDebtItemRepository, *mocks.MockPaymentScheduleService) {},
+			expectedError: entities.ErrInvalidDebtStatus,
+		},
+		{
+			name:          "archived is not a settable status",
+			status:        "archived",
+			setupMocks:    func(*mocks.MockDebtListRepository, *mocks.MockDebtItemRepository, *mocks.MockPaymentScheduleService) {},
+			expectedError: entities.ErrInvalidDebtStatus,
+		},
+		{
+			name:   "settling a debt with a remaining balance conflicts",
+			status: "settled",
+			setupMocks: func(debtListRepo *mocks.MockDebtListRepository, debtItemRepo *mocks.MockDebtItemRepository, paymentService *mocks.MockPaymentScheduleService) {
+				debtListRepo.On("BelongsToUser", mock.Anything, debtListID, userID).Return(true, nil)
+				debtListRepo.On("GetByID", mock.Anything, debtListID).Return(newDebtList("200.00", future), nil)
+				debtItemRepo.On("GetByDebtListID", mock.Anything, debtListID).Return([]entities.DebtItem{}, nil)
+				paymentService.On("CalculateNextPaymentDate", mock.Anything, mock.Anything).Return(future)
+			},
+			expectedError: entities.ErrConflictingDebtStatus,
+		},
+		{
+			name:   "reopening a fully paid debt conflicts",
+			status: "active",
+			setupMocks: func(debtListRepo *mocks.MockDebtListRepository, debtItemRepo *mocks.MockDebtItemRepository, paymentService *mocks.MockPaymentScheduleService) {
+				debtListRepo.On("BelongsToUser", mock.Anything, debtListID, userID).Return(true, nil)
+				debtListRepo.On("GetByID", mock.Anything, debtListID).Return(newDebtList("500.00", future), nil)
+				debtItemRepo.On("GetByDebtListID", mock.Anything, debtListID).Return([]entities.DebtItem{}, nil)
+				paymentService.On("CalculateNextPaymentDate", mock.Anything, mock.Anything).Return(future)
+			},
+			expectedError: entities.ErrConflictingDebtStatus,
+		},
+		{
+			name:   "marking a debt overdue before its payment date conflicts",
+			status: "overdue",
+			setupMocks: func(debtListRepo *mocks.MockDebtListRepository, debtItemRepo *mocks.MockDebtItemRepository, paymentService *mocks.MockPaymentScheduleService) {
+				debtListRepo.On("BelongsToUser", mock.Anything, debtListID, userID).Return(true, nil)
+				debtListRepo.On("GetByID", mock.Anything, debtListID).Return(newDebtList("0.00", future), nil)
+				debtItemRepo.On("GetByDebtListID", mock.Anything, debtListID).Return([]entities.DebtItem{}, nil)
+				paymentService.On("CalculateNextPaymentDate", mock.Anything, mock.Anything).Return(future)
+			},
+			expectedError: entities.ErrConflictingDebtStatus,
+		},
+		{
+			name:   "status matching the computed state is accepted",
+			status: "overdue",
+			setupMocks: func(debtListRepo *mocks.MockDebtListRepository, debtItemRepo *mocks.MockDebtItemRepository, paymentService *mocks.MockPaymentScheduleService) {
+				debtListRepo.On("BelongsToUser", mock.Anything, debtListID, userID).Return(true, nil)
+				debtList := newDebtList("100.00", past)
+				debtListRepo.On("GetByID", mock.Anything, debtListID).Return(debtList, nil)
+				debtListRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.DebtList")).Return(nil)
+				debtListRepo.On("RecalculatePaymentTotals", mock.Anything, debtListID, mock.Anything).
+					Run(recalculatesTotals(t, debtList, entities.PaymentAggregate{TotalPaid: decimal.RequireFromString("100.00"), PaymentCount: 1, LastPaymentDate: &past}, "100.00", "400.00", "overdue")).
+					Return(nil)
+				debtListRepo.On("CreateHistoryEntries", mock.Anything, mock.Anything).Return(nil)
+				debtItemRepo.On("GetByDebtListID", mock.Anything, debtListID).Return([]entities.DebtItem{
+					{Amount: decimal.RequireFromString("100.00"), PaymentDate: past, Status: entities.PaymentStatusCompleted},
+				}, nil)
+				paymentService.On("CalculateNextPaymentDate", mock.Anything, mock.Anything).Return(past)
+			},
+		},
+		{
+			name:   "status is checked against the recalculated next payment date",
+			status: "active",
+			setupMocks: func(debtListRepo *mocks.MockDebtListRepository, debtItemRepo *mocks.MockDebtItemRepository, paymentService *mocks.MockPaymentScheduleService) {
+				debtListRepo.On("BelongsToUser", mock.Anything, debtListID, userID).Return(true, nil)
+				// The stored next payment date has passed, but the schedule now falls due later
+				debtList := newDebtList("100.00", past)
+				debtListRepo.On("GetByID", mock.Anything, debtListID).Return(debtList, nil)
+				debtListRepo.On("Update", mock.Anything, mock.MatchedBy(func(debtList *entities.DebtList) bool {
+					return debtList.NextPaymentDate.Equal(future)
+				})).Return(nil)
+				debtListRepo.On("RecalculatePaymentTotals", mock.Anything, debtListID, mock.Anything).Return(nil)
+				debtListRepo.On("CreateHistoryEntries", mock.Anything, mock.Anything).Return(nil)
+				debtItemRepo.On("GetByDebtListID", mock.Anything, debtListID).Return([]entities.DebtItem{}, nil)
+				paymentService.On("CalculateNextPaymentDate", mock.Anything, (*time.Time)(nil)).Return(future)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			debtListRepo := &mocks.MockDebtListRepository{}
+			debtItemRepo := &mocks.MockDebtItemRepository{}
+			contactRepo := &mocks.MockContactRepository{}
+			paymentService := &mocks.MockPaymentScheduleService{}
+			fileStorageService := &mocks.MockFileStorageService{}
+			tt.setupMocks(debtListRepo, debtItemRepo, paymentService)
+
+			debtService := services.NewDebtService(debtListRepo, debtItemRepo, contactRepo, paymentService, fileStorageService)
+
+			_, err := debtService.UpdateDebtList(context.Background(), debtListID, userID, &entities.UpdateDebtListRequest{
+				Status: stringPtr(tt.status),
+			})
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				debtListRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			debtListRepo.AssertExpectations(t)
+			debtItemRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// Helper functions
+func intPtr(i int) *int {
+	return &i
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestDebtService_MaxNumberOfPayments(t *testing.T) {
+	userID := uuid.New()
+	contactID := uuid.New()
+
+	newService := func(opts ...services.DebtServiceOption) (interfaces.DebtService, *mocks.MockDebtListRepository) {
+		debtListRepo := &mocks.MockDebtListRepository{}
+		contactRepo := &mocks.MockContactRepository{}
+		contactRepo.On("GetUserContactRelation", mock.Anything, userID, contactID).Return(&entities.UserContact{
+			ID:        uuid.New(),
+			UserID:    userID,
+			ContactID: contactID,
+		}, nil)
+		contactRepo.On("GetByID", mock.Anything, contactID).Return(&entities.Contact{ID: contactID}, nil)
+		debtListRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.DebtList")).Return(nil).Maybe()
+		debtService := services.NewDebtService(debtListRepo, &mocks.MockDebtItemRepository{}, contactRepo, services.NewPaymentScheduleService(), &mocks.MockFileStorageService{}, opts...)
+		return debtService, debtListRepo
+	}
+	createRequest := func(numberOfPayments int) *entities.CreateDebtListRequest {
+		return &entities.CreateDebtListRequest{
+			ContactID:        contactID,
+			DebtType:         "to_receive",
+			TotalAmount:      "1000.00",
+			Currency:         "USD",
+			InstallmentPlan:  "weekly",
+			NumberOfPayments: intPtr(numberOfPayments),
+		}
+	}
+
+	t.Run("excessive count is rejected on create", func(t *testing.T) {
+		debtService, debtListRepo := newService()
+
+		result, err := debtService.CreateDebtList(context.Background(), userID, createRequest(100000))
+
+		assert.ErrorIs(t, err, entities.ErrTooManyPayments)
+		assert.Nil(t, result)
+		debtListRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("excessive count is rejected on preview", func(t *testing.T) {
+		debtService, _ := newService()
+
+		schedule, err := debtService.PreviewPaymentSchedule(context.Background(), userID, createRequest(100000))
+
+		assert.ErrorIs(t, err, entities.ErrTooManyPayments)
+		assert.Nil(t, schedule)
+	})
+
+	t.Run("count at the default cap is accepted", func(t *testing.T) {
+		debtService, _ := newService()
+
+		result, err := debtService.CreateDebtList(context.Background(), userID, createRequest(services.DefaultMaxNumberOfPayments))
+
+		require.NoError(t, err)
+		assert.Equal(t, services.DefaultMaxNumberOfPayments, *result.NumberOfPayments)
+	})
+
+	t.Run("configured cap applies", func(t *testing.T) {
+		debtService, _ := newService(services.WithMaxNumberOfPayments(12))
+
+		_, err := debtService.CreateDebtList(context.Background(), userID, createRequest(13))
+		assert.ErrorIs(t, err, entities.ErrTooManyPayments)
+
+		_, err = debtService.CreateDebtList(context.Background(), userID, createRequest(12))
+		assert.NoError(t, err)
+	})
+
+	t.Run("excessive count is rejected on update", func(t *testing.T) {
+		debtService, debtListRepo := newService()
+		debtListID := uuid.New()
+		debtListRepo.On("BelongsToUser", mock.Anything, debtListID, userID).Return(true, nil)
+		debtListRepo.On("GetByID", mock.Anything, debtListID).Return(&entities.DebtList{
+			ID:              debtListID,
+			UserID:          userID,
+			ContactID:       contactID,
+			DebtType:        "to_receive",
+			TotalAmount:     decimal.RequireFromString("1000.00"),
+			Currency:        "USD",
+			Status:          "active",
+			InstallmentPlan: "weekly",
+			DueDate:         time.Now().AddDate(0, 3, 0),
+			CreatedAt:       time.Now(),
+		}, nil)
+
+		result, err := debtService.UpdateDebtList(context.Background(), debtListID, userID, &entities.UpdateDebtListRequest{
+			NumberOfPayments: intPtr(100000),
+		})
+
+		assert.ErrorIs(t, err, entities.ErrTooManyPayments)
+		assert.Nil(t, result)
+		debtListRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDebtService_MaxTotalAmount(t *testing.T) {
+	userID := uuid.New()
+	contactID := uuid.New()
+
+	newService := func(opts ...services.DebtServiceOption) (interfaces.DebtService, *mocks.MockDebtListRepository) {
+		debtListRepo := &mocks.MockDebtListRepository{}
+		contactRepo := &mocks.MockContactRepository{}
+		contactRepo.On("GetUserContactRelation", mock.Anything, userID, contactID).Return(&entities.UserContact{
+			ID:        uuid.New(),
+			UserID:    userID,
+			ContactID: contactID,
+		}, nil)
+		contactRepo.On("GetByID", mock.Anything, contactID).Return(&entities.Contact{ID: contactID}, nil)
+		debtListRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.DebtList")).Return(nil).Maybe()
+		debtService := services.NewDebtService(debtListRepo, &mocks.MockDebtItemRepository{}, contactRepo, services.NewPaymentScheduleService(), &mocks.MockFileStorageService{}, opts...)
+		return debtService, debtListRepo
+	}
+	createRequest := func(totalAmount string) *entities.CreateDebtListRequest {
+		return &entities.CreateDebtListRequest{
+			ContactID:       contactID,
+			DebtType:        "to_receive",
+			TotalAmount:     totalAmount,
+			Currency:        "USD",
+			InstallmentPlan: "onetime",
+			DueDate:         timePtr(time.Now().AddDate(0, 1, 0)),
+		}
+	}
+
+	t.Run("over-max amount is rejected on create", func(t *testing.T) {
+		debtService, debtListRepo := newService()
+
+		result, err := debtService.CreateDebtList(context.Background(), userID, createRequest("99999999999999999999.00"))
+
+		assert.ErrorIs(t, err, entities.ErrTotalAmountTooLarge)
+		assert.Nil(t, result)
+		debtListRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("over-max additional balance is rejected", func(t *testing.T) {
+		debtService, _ := newService(services.WithMaxTotalAmount(decimal.RequireFromString("500.00")))
+		req := createRequest("100.00")
+		req.AdditionalBalances = []entities.DebtBalanceRequest{{Currency: "EUR", TotalAmount: "500.01"}}
+
+		_, err := debtService.CreateDebtList(context.Background(), userID, req)
+
+		assert.ErrorIs(t, err, entities.ErrTotalAmountTooLarge)
+	})
+
+	t.Run("configured maximum applies", func(t *testing.T) {
+		debtService, _ := newService(services.WithMaxTotalAmount(decimal.RequireFromString("500.00")))
+
+		_, err := debtService.CreateDebtList(context.Background(), userID, createRequest("500.01"))
+		assert.ErrorIs(t, err, entities.ErrTotalAmountTooLarge)
+
+		_, err = debtService.CreateDebtList(context.Background(), userID, createRequest("500.00"))
+		assert.NoError(t, err)
+	})
+
+	t.Run("over-max amount is rejected on update", func(t *testing.T) {
+		debtService, debtListRepo := newService(services.WithMaxTotalAmount(decimal.RequireFromString("5000.00")))
+		debtListID := uuid.New()
+		debtListRepo.On("BelongsToUser", mock.Anything, debtListID, userID).Return(true, nil)
+		debtListRepo.On("GetByID", mock.Anything, debtListID).Return(&entities.DebtList{
+			ID:              debtListID,
+			UserID:          userID,
+			ContactID:       contactID,
+			DebtType:        "to_receive",
+			TotalAmount:     decimal.RequireFromString("1000.00"),
+			Currency:        "USD",
+			Status:          "active",
+			InstallmentPlan: "onetime",
+			DueDate:         time.Now().AddDate(0, 3, 0),
+			CreatedAt:       time.Now(),
+		}, nil)
+
+		result, err := debtService.UpdateDebtList(context.Background(), debtListID, userID, &entities.UpdateDebtListRequest{
+			TotalAmount: stringPtr("5000.01"),
+		})
+
+		assert.ErrorIs(t, err, entities.ErrTotalAmountTooLarge)
+		assert.Nil(t, result)
+		debtListRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDebtService_CreateDebtList_PaymentWeekday(t *testing.T) {
+	userID := uuid.New()
+	contactID := uuid.New()
+	saturday := int(time.Saturday)
+
+	newService := func() (interfaces.DebtService, *mocks.MockDebtListRepository) {
+		debtListRepo := &mocks.MockDebtListRepository{}
+		contactRepo := &mocks.MockContactRepository{}
+		contactRepo.On("GetUserContactRelation", mock.Anything, userID, contactID).Return(&entities.UserContact{
+			ID:        uuid.New(),
+			UserID:    userID,
+			ContactID: contactID,
+		}, nil)
+		contactRepo.On("GetByID", mock.Anything, contactID).Return(&entities.Contact{ID: contactID}, nil)
+		debtListRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.DebtList")).Return(nil).Maybe()
+		debtService := services.NewDebtService(debtListRepo, &mocks.MockDebtItemRepository{}, contactRepo, services.NewPaymentScheduleService(), &mocks.MockFileStorageService{})
+		return debtService, debtListRepo
+	}
+
+	t.Run("weekly due dates fall on the chosen weekday", func(t *testing.T) {
+		debtService, _ := newService()
+
+		result, err := debtService.CreateDebtList(context.Background(), userID, &entities.CreateDebtListRequest{
+			ContactID:        contactID,
+			DebtType:         "to_receive",
+			TotalAmount:      "600.00",
+			Currency:         "USD",
+			InstallmentPlan:  "weekly",
+			NumberOfPayments: intPtr(6),
+			PaymentWeekday:   &saturday,
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, result.PaymentWeekday)
+		assert.Equal(t, saturday, *result.PaymentWeekday)
+		assert.Equal(t, time.Saturday, result.NextPaymentDate.Weekday())
+		assert.Equal(t, time.Saturday, result.DueDate.Weekday())
+
+		schedule, err := debtService.PreviewPaymentSchedule(context.Background(), userID, &entities.CreateDebtListRequest{
+			ContactID:        contactID,
+			DebtType:         "to_receive",
+			TotalAmount:      "600.00",
+			Currency:         "USD",
+			InstallmentPlan:  "weekly",
+			NumberOfPayments: intPtr(6),
+			PaymentWeekday:   &saturday,
+		})
+		require.NoError(t, err)
+		require.Len(t, schedule, 6)
+		for _, item := range schedule {
+			assert.Equal(t, time.Saturday, item.DueDate.Weekday())
+		}
+	})
+
+	t.Run("weekday is rejected for other plans", func(t *testing.T) {
+		debtService, debtListRepo := newService()
+
+		result, err := debtService.CreateDebtList(context.Background(), userID, &entities.CreateDebtListRequest{
+			ContactID:        contactID,
+			DebtType:         "to_receive",
+			TotalAmount:      "600.00",
+			Currency:         "USD",
+			InstallmentPlan:  "monthly",
+			NumberOfPayments: intPtr(6),
+			PaymentWeekday:   &saturday,
+		})
+
+		assert.ErrorIs(t, err, entities.ErrInvalidPaymentWeekday)
+		assert.Nil(t, result)
+		debtListRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDebtService_GetTotalPaymentsForDebtList_PercentagePaid(t *testing.T) {
+	userID := uuid.New()
+	debtListID := uuid.New()
+
+	summaryFor := func(payments ...string) *entities.PaymentSummary {
+		debtListRepo := &mocks.MockDebtListRepository{}
+		debtItemRepo := &mocks.MockDebtItemRepository{}
+		debtListRepo.On("BelongsToUser", mock.Anything, debtListID, userID).Return(true, nil)
+		debtListRepo.On("GetByID", mock.Anything, debtListID).Return(&entities.DebtList{
+			ID:          debtListID,
+			UserID:      userID,
+			TotalAmount: decimal.RequireFromString("300.00"),
+		}, nil)
+		items := make([]entities.DebtItem, len(payments))
+		for i, amount := range payments {
+			items[i] = entities.DebtItem{ID: uuid.New(), DebtListID: debtListID, Amount: decimal.RequireFromString(amount), Status: "completed"}
+		}
+		debtItemRepo.On("GetCompletedPaymentsForDebtList", mock.Anything, debtListID).Return(items, nil)
+		debtListRepo.On("GetBalances", mock.Anything, debtListID).Return([]entities.DebtBalance{}, nil)
+
+		debtService := services.NewDebtService(debtListRepo, debtItemRepo, &mocks.MockContactRepository{}, services.NewPaymentScheduleService(), &mocks.MockFileStorageService{})
+		summary, err := debtService.GetTotalPaymentsForDebtList(context.Background(), debtListID, userID)
+		require.NoError(t, err)
+		return summary
+	}
+
+	assert.Equal(t, "33.33", summaryFor("100.00").PercentagePaid.String())
+	assert.Equal(t, "100", summaryFor("100.00", "100.00", "100.00").PercentagePaid.String())
+}
+
+func TestDebtService_TextLengthValidation(t *testing.T) {
+	userID := uuid.New()
+	contactID := uuid.New()
+	tooLong := strings.Repeat("a", entities.MaxTextLength+1)
+	atLimit := strings.Repeat("é", entities.MaxTextLength)
+
+	newService := func() (interfaces.DebtService, *mocks.MockDebtListRepository, *mocks.MockDebtItemRepository) {
+		debtListRepo := &mocks.MockDebtListRepository{}
+		debtItemRepo := &mocks.MockDebtItemRepository{}
+		contactRepo := &mocks.MockContactRepository{}
+		contactRepo.On("GetUserContactRelation", mock.Anything, userID, contactID).Return(&entities.UserContact{
+			ID:        uuid.New(),
+			UserID:    userID,
+			ContactID: contactID,
+		}, nil).Maybe()
+		contactRepo.On("GetByID", mock.Anything, contactID).Return(&entities.Contact{ID: contactID}, nil)
+		debtListRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.DebtList")).Return(nil).Maybe()
+		debtService := services.NewDebtService(debtListRepo, debtItemRepo, contactRepo, services.NewPaymentScheduleService(), &mocks.MockFileStorageService{})
+		return debtService, debtListRepo, debtItemRepo
+	}
+	createRequest := func(description, notes *string) *entities.CreateDebtListRequest {
+		return &entities.CreateDebtListRequest{
+			ContactID:   contactID,
+			DebtType:    "to_receive",
+			TotalAmount: "100.00",
+			Currency:    "USD",
+			Description: description,
+			Notes:       notes,
+		}
+	}
+
+	t.Run("over-length description is rejected on create", func(t *testing.T) {
+		debtService, debtListRepo, _ := newService()
+
+		result, err := debtService.CreateDebtList(context.Background(), userID, createRequest(&tooLong, nil))
+
+		assert.ErrorIs(t, err, entities.ErrTextTooLong)
+		assert.Nil(t, result)
+		debtListRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("over-length notes are rejected on create", func(t *testing.T) {
+		debtService, debtListRepo, _ := newService()
+
+		_, err := debtService.CreateDebtList(context.Background(), userID, createRequest(nil, &tooLong))
+
+		assert.ErrorIs(t, err, entities.ErrTextTooLong)
+		debtListRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("limit counts characters rather than bytes", func(t *testing.T) {
+		debtService, _, _ := newService()
+
+		result, err := debtService.CreateDebtList(context.Background(), userID, createRequest(&atLimit, &atLimit))
+
+		require.NoError(t, err)
+		assert.Equal(t, atLimit, *result.Description)
+	})
+
+	t.Run("over-length description is rejected on update", func(t *testing.T) {
+		debtService, debtListRepo, _ := newService()
+
+		result, err := debtService.UpdateDebtList(context.Background(), uuid.New(), userID, &entities.UpdateDebtListRequest{
+			Description: &tooLong,
+		})
+
+		assert.ErrorIs(t, err, entities.ErrTextTooLong)
+		assert.Nil(t, result)
+		debtListRepo.AssertNotCalled(t, "BelongsToUser", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("over-length payment description is rejected", func(t *testing.T) {
+		debtService, _, debtItemRepo := newService()
+
+		result, err := debtService.CreateDebtItem(context.Background(), userID, &entities.CreateDebtItemRequest{
+			DebtListID:    uuid.New(),
+			Amount:        "10.00",
+			Currency:      "USD",
+			PaymentDate:   time.Now(),
+			PaymentMethod: "cash",
+			Description:   &tooLong,
+		})
+
+		assert.ErrorIs(t, err, entities.ErrTextTooLong)
+		assert.Nil(t, result)
+		debtItemRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDebtService_CreateDebtList_ScheduleConflict(t *testing.T) {
+	userID := uuid.New()
+	contactID := uuid.New()
+
+	newService := func(opts ...services.DebtServiceOption) (interfaces.DebtService, *mocks.MockDebtListRepository) {
+		debtListRepo := &mocks.MockDebtListRepository{}
+		contactRepo := &mocks.MockContactRepository{}
+		contactRepo.On("GetUserContactRelation", mock.Anything, userID, contactID).Return(&entities.UserContact{
+			ID:        uuid.New(),
+			UserID:    userID,
+			ContactID: contactID,
+		}, nil)
+		contactRepo.On("GetByID", mock.Anything, contactID).Return(&entities.Contact{ID: contactID}, nil)
+		debtListRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.DebtList")).Return(nil).Maybe()
+		debtService := services.NewDebtService(debtListRepo, &mocks.MockDebtItemRepository{}, contactRepo, services.NewPaymentScheduleService(), &mocks.MockFileStorageService{}, opts...)
+		return debtService, debtListRepo
+	}
+	createRequest := func(dueDate time.Time) *entities.CreateDebtListRequest {
+		return &entities.CreateDebtListRequest{
+			ContactID:        contactID,
+			DebtType:         "to_receive",
+			TotalAmount:      "300.00",
+			Currency:         "USD",
+			InstallmentPlan:  "monthly",
+			NumberOfPayments: intPtr(3),
+			DueDate:          timePtr(dueDate),
+		}
+	}
+	impliedDueDate := services.NewPaymentScheduleService().CalculateDueDateFromNumberOfPayments(time.Now(), 3, "monthly")
+	conflictingDueDate := time.Now().AddDate(1, 0, 0)
+
+	t.Run("conflict creates the debt with a warning by default", func(t *testing.T) {
+		debtService, debtListRepo := newService()
+
+		result, err := debtService.CreateDebtList(context.Background(), userID, createRequest(conflictingDueDate))
+
+		require.NoError(t, err)
+		assert.WithinDuration(t, impliedDueDate, result.DueDate, time.Minute)
+		require.Len(t, result.Warnings, 1)
+		assert.Contains(t, result.Warnings[0], "due_date "+conflictingDueDate.Format("2006-01-02")+" was ignored")
+		debtListRepo.AssertCalled(t, "Create", mock.Anything, mock.AnythingOfType("*entities.DebtList"))
+	})
+
+	t.Run("conflict is rejected when strict", func(t *testing.T) {
+		debtService, debtListRepo := newService(services.WithStrictScheduleValidation(true))
+
+		result, err := debtService.CreateDebtList(context.Background(), userID, createRequest(conflictingDueDate))
+
+		assert.ErrorIs(t, err, entities.ErrConflictingSchedule)
+		assert.Nil(t, result)
+		debtListRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("conflict is rejected on preview when strict", func(t *testing.T) {
+		debtService, _ := newService(services.WithStrictScheduleValidation(true))
+
+		schedule, err := debtService.PreviewPaymentSchedule(context.Background(), userID, createRequest(conflictingDueDate))
+
+		assert.ErrorIs(t, err, entities.ErrConflictingSchedule)
+		assert.Nil(t, schedule)
+	})
+
+	t.Run("matching due date is accepted when strict", func(t *testing.T) {
+		debtService, _ := newService(services.WithStrictScheduleValidation(true))
+
+		result, err := debtService.CreateDebtList(context.Background(), userID, createRequest(impliedDueDate.Add(2*time.Hour)))
+
+		require.NoError(t, err)
+		assert.Empty(t, result.Warnings)
+	})
+}
+
+func TestDebtService_CreateDebtItem_SettledTolerance(t *testing.T) {
+	userID := uuid.New()
+	debtListID := uuid.New()
+	paymentDate := time.Now()
+
+	tests := []struct {
+		name           string
+		opts           []services.DebtServiceOption
+		totalPaid      string
+		remaining      string
+		expectedStatus string
+	}{
+		{
+			name:           "rounding remainder within default tolerance settles",
+			totalPaid:      "99.999",
+			remaining:      "0.001",
+			expectedStatus: "settled",
+		},
+		{
+			name:           "one minor unit left settles",
+			totalPaid:      "99.99",
+			remaining:      "0.01",
+			expectedStatus: "settled",
+		},
+		{
+			name:           "remainder above tolerance stays active",
+			totalPaid:      "99.98",
+			remaining:      "0.02",
+			expectedStatus: "active",
+		},
+		{
+			name:           "zero tolerance requires full payment",
+			opts:           []services.DebtServiceOption{services.WithSettledTolerance(decimal.Zero)},
+			totalPaid:      "99.999",
+			remaining:      "0.001",
+			expectedStatus: "active",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			debtListRepo := &mocks.MockDebtListRepository{}
+			debtItemRepo := &mocks.MockDebtItemRepository{}
+			paymentService := &mocks.MockPaymentScheduleService{}
+			nextPaymentDate := time.Now().AddDate(0, 1, 0)
+
+			debtListRepo.On("BelongsToUser", mock.Anything, debtListID, userID).Return(true, nil)
+			debtList := &entities.DebtList{
+				ID:              debtListID,
+				UserID:          userID,
+				DebtType:        "to_receive",
+				Currency:        "USD",
+				TotalAmount:     decimal.RequireFromString("100.00"),
+				NextPaymentDate: nextPaymentDate,
+				CreatedAt:       time.Now(),
+			}
+			debtListRepo.On("GetByID", mock.Anything, debtListID).Return(debtList, nil)
+			debtItemRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.DebtItem")).Return(nil)
+			debtListRepo.On("RecalculatePaymentTotals", mock.Anything, debtListID, mock.Anything).
+				Run(recalculatesTotals(t, debtList, entities.PaymentAggregate{TotalPaid: decimal.RequireFromString(tt.totalPaid), PaymentCount: 1, LastPaymentDate: &paymentDate}, tt.totalPaid, tt.remaining, tt.expectedStatus)).
+				Return(nil)
+			paymentService.On("CalculateNextPaymentDate", mock.AnythingOfType("*entities.DebtList"), &paymentDate).Return(nextPaymentDate)
+
+			debtService := services.NewDebtService(debtListRepo, debtItemRepo, &mocks.MockContactRepository{}, paymentService, &mocks.MockFileStorageService{}, tt.opts...)
+			_, err := debtService.CreateDebtItem(context.Background(), userID, &entities.CreateDebtItemRequest{
+				DebtListID:    debtListID,
+				Amount:        "33.33",
+				Currency:      "USD",
+				PaymentDate:   paymentDate,
+				PaymentMethod: "cash",
+			})
+			require.NoError(t, err)
+
+			debtListRepo.AssertExpectations(t)
+		})
+	}
 }
@@ -464,6 +464,37 @@ func TestDebtList_CalculateProgress(t *testing.T) {
 	}
 }
 
+func TestPercentagePaid_RoundsToTwoDecimals(t *testing.T) {
+	tests := []struct {
+		name     string
+		paid     string
+		total    string
+		expected string
+	}{
+		{name: "one third", paid: "1", total: "3", expected: "33.33"},
+		{name: "two thirds rounds half up", paid: "2", total: "3", expected: "66.67"},
+		{name: "half cent rounds up", paid: "1", total: "800", expected: "0.13"},
+		{name: "full payoff", paid: "300.00", total: "300.00", expected: "100"},
+		{name: "overpayment", paid: "350.00", total: "300.00", expected: "100"},
+		{name: "almost paid is never rounded to 100", paid: "99999.99", total: "100000.00", expected: "99.99"},
+		{name: "nothing paid", paid: "0", total: "300.00", expected: "0"},
+		{name: "zero total", paid: "10.00", total: "0", expected: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := entities.PercentagePaid(decimal.RequireFromString(tt.paid), decimal.RequireFromString(tt.total))
+			assert.Equal(t, tt.expected, result.String())
+
+			debtList := &entities.DebtList{
+				TotalAmount:       decimal.RequireFromString(tt.total),
+				TotalPaymentsMade: decimal.RequireFromString(tt.paid),
+			}
+			assert.Equal(t, tt.expected, debtList.CalculateProgress().String())
+		})
+	}
+}
+
 func TestDebtItem_IsValid(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -509,6 +540,42 @@ func TestDebtItem_IsValid(t *testing.T) {
 			expectedError: entities.ErrInvalidAmount,
 			expectValid:   false,
 		},
+		{
+			name: "negative adjustment",
+			debtItem: &entities.DebtItem{
+				DebtListID:    uuid.New(),
+				Amount:        decimal.RequireFromString("-50.00"),
+				Currency:      "USD",
+				PaymentMethod: "bank_transfer",
+				PaymentType:   entities.PaymentTypeAdjustment,
+			},
+			expectedError: nil,
+			expectValid:   true,
+		},
+		{
+			name: "zero adjustment",
+			debtItem: &entities.DebtItem{
+				DebtListID:    uuid.New(),
+				Amount:        decimal.Zero,
+				Currency:      "USD",
+				PaymentMethod: "bank_transfer",
+				PaymentType:   entities.PaymentTypeAdjustment,
+			},
+			expectedError: entities.ErrInvalidAmount,
+			expectValid:   false,
+		},
+		{
+			name: "invalid payment type",
+			debtItem: &entities.DebtItem{
+				DebtListID:    uuid.New(),
+				Amount:        decimal.RequireFromString("200.00"),
+				Currency:      "USD",
+				PaymentMethod: "bank_transfer",
+				PaymentType:   "gift",
+			},
+			expectedError: entities.ErrInvalidPaymentType,
+			expectValid:   false,
+		},
 		{
 			name: "empty currency",
 			debtItem: &entities.DebtItem{
@@ -582,3 +649,88 @@ func TestDebtItem_IsValid(t *testing.T) {
 
 
 
+
+func TestNormalizeDebtType(t *testing.T) {
+	tests := []struct {
+		name          string
+		debtType      string
+		expected      string
+		expectedError error
+	}{
+		{name: "to_receive", debtType: "to_receive", expected: entities.DebtTypeToReceive},
+		{name: "to_pay", debtType: "to_pay", expected: entities.DebtTypeToPay},
+		{name: "legacy owed_to_me", debtType: "owed_to_me", expected: entities.DebtTypeToReceive},
+		{name: "legacy i_owe", debtType: "i_owe", expected: entities.DebtTypeToPay},
+		{name: "mixed case and whitespace", debtType: "  To_Pay ", expected: entities.DebtTypeToPay},
+		{name: "empty", debtType: "", expectedError: entities.ErrInvalidDebtType},
+		{name: "unknown", debtType: "to_borrow", expectedError: entities.ErrInvalidDebtType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			debtType, err := entities.NormalizeDebtType(tt.debtType)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, debtType)
+			assert.True(t, entities.IsValidDebtType(debtType))
+		})
+	}
+}
+
+func TestParseDebtSort(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expected      entities.DebtSort
+		expectedError error
+	}{
+		{name: "field only", value: "due_date", expected: entities.DebtSort{Field: "due_date"}},
+		{name: "ascending", value: "next_payment_date asc", expected: entities.DebtSort{Field: "next_payment_date"}},
+		{name: "descending", value: "total_amount desc", expected: entities.DebtSort{Field: "total_amount", Descending: true}},
+		{name: "mixed case and whitespace", value: "  Created_At   DESC ", expected: entities.DebtSort{Field: "created_at", Descending: true}},
+		{name: "empty", value: "", expectedError: entities.ErrInvalidDebtSort},
+		{name: "field not whitelisted", value: "notes asc", expectedError: entities.ErrInvalidDebtSort},
+		{name: "column injection", value: "created_at; DROP TABLE debt_lists", expectedError: entities.ErrInvalidDebtSort},
+		{name: "unknown direction", value: "due_date sideways", expectedError: entities.ErrInvalidDebtSort},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			debtSort, err := entities.ParseDebtSort(tt.value)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, debtSort)
+		})
+	}
+}
+
+func TestParseExchangeRates(t *testing.T) {
+	t.Run("entries", func(t *testing.T) {
+		rates, err := entities.ParseExchangeRates(" usd:php=56.25 , EUR:USD=1.08,")
+		assert.NoError(t, err)
+		assert.Len(t, rates, 2)
+		assert.True(t, decimal.RequireFromString("56.25").Equal(rates[entities.CurrencyPair{From: "USD", To: "PHP"}]))
+		assert.True(t, decimal.RequireFromString("1.08").Equal(rates[entities.CurrencyPair{From: "EUR", To: "USD"}]))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		rates, err := entities.ParseExchangeRates("")
+		assert.NoError(t, err)
+		assert.Empty(t, rates)
+	})
+
+	for _, value := range []string{"USD:PHP", "USDPHP=56", "USD:PHP=abc", "USD:PHP=0", "USD:USD=1", "DOLLAR:PHP=56"} {
+		t.Run("invalid "+value, func(t *testing.T) {
+			_, err := entities.ParseExchangeRates(value)
+			assert.Error(t, err)
+		})
+	}
+}
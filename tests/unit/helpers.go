@@ -1,5 +1,27 @@
 package unit
 
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"pay-your-dues/internal/domain/entities"
+)
+
 func stringPtr(s string) *string {
 	return &s
 }
+
+// recalculatesTotals runs the service's totals computation the way the repository
+// would, against debtList and payments, and asserts the totals it derives
+func recalculatesTotals(t *testing.T, debtList *entities.DebtList, payments entities.PaymentAggregate, totalPaid, remaining, status string) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		compute := args.Get(2).(func(*entities.DebtList, entities.PaymentAggregate) entities.PaymentTotals)
+		totals := compute(debtList, payments)
+		assert.True(t, decimal.RequireFromString(totalPaid).Equal(totals.TotalPaid), totals.TotalPaid.String())
+		assert.True(t, decimal.RequireFromString(remaining).Equal(totals.RemainingDebt), totals.RemainingDebt.String())
+		assert.Equal(t, status, totals.Status)
+	}
+}
@@ -386,6 +386,17 @@ func TestCalculateNextPaymentDate(t *testing.T) {
 		expectedDay     int
 		expectedMonth   time.Month
 	}{
+		{
+			name: "daily from creation date",
+			debtList: &entities.DebtList{
+				InstallmentPlan: "daily",
+				CreatedAt:       baseTime,
+				DueDate:         baseTime.AddDate(0, 0, 10),
+			},
+			lastPaymentDate: nil,
+			expectedDay:     16,
+			expectedMonth:   time.January,
+		},
 		{
 			name: "weekly from creation date",
 			debtList: &entities.DebtList{
@@ -454,3 +465,184 @@ func TestCalculateNextPaymentDate(t *testing.T) {
 		})
 	}
 }
+
+func TestDailyInstallmentPlan(t *testing.T) {
+	service := services.NewPaymentScheduleService()
+	createdAt := time.Date(2024, 1, 30, 9, 0, 0, 0, time.UTC)
+
+	// Due date lands N days after creation, crossing the month boundary
+	dueDate := service.CalculateDueDateFromNumberOfPayments(createdAt, 5, "daily")
+	assert.Equal(t, time.Date(2024, 2, 4, 9, 0, 0, 0, time.UTC), dueDate)
+
+	// One installment per day between creation and due date
+	installment := service.CalculateInstallmentAmount(decimal.RequireFromString("500.00"), "daily", createdAt, dueDate, entities.RoundingModeLast)
+	assert.True(t, installment.Equal(decimal.RequireFromString("100.00")), "installment should be 100, got %s", installment)
+
+	debtList := &entities.DebtList{
+		ID:                uuid.New(),
+		TotalAmount:       decimal.RequireFromString("500.00"),
+		InstallmentAmount: installment,
+		InstallmentPlan:   "daily",
+		CreatedAt:         createdAt,
+		DueDate:           dueDate,
+	}
+	payments := []entities.DebtItem{
+		{ID: uuid.New(), Amount: decimal.RequireFromString("150.00"), Status: "completed", PaymentDate: createdAt},
+	}
+
+	schedule := service.CalculatePaymentSchedule(debtList, payments)
+	require.Len(t, schedule, 5)
+
+	previous := createdAt
+	for i, item := range schedule {
+		assert.Equal(t, i+1, item.PaymentNumber)
+		assert.Equal(t, 24*time.Hour, item.DueDate.Sub(previous), "payment %d should be one day after the previous one", item.PaymentNumber)
+		previous = item.DueDate
+	}
+	assert.Equal(t, dueDate, schedule[len(schedule)-1].DueDate)
+
+	assert.Equal(t, "paid", schedule[0].Status)
+	assert.Equal(t, "pending", schedule[1].Status)
+	assert.True(t, schedule[1].PaidAmount.Equal(decimal.RequireFromString("50.00")))
+}
+
+func TestPaymentWeekdayAlignment(t *testing.T) {
+	service := services.NewPaymentScheduleService()
+	// A Monday
+	createdAt := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	friday := int(time.Friday)
+
+	for _, tt := range []struct {
+		plan   string
+		period int
+	}{
+		{plan: "weekly", period: 7},
+		{plan: "biweekly", period: 14},
+	} {
+		t.Run(tt.plan, func(t *testing.T) {
+			debtList := &entities.DebtList{
+				ID:                uuid.New(),
+				TotalAmount:       decimal.RequireFromString("400.00"),
+				InstallmentAmount: decimal.RequireFromString("100.00"),
+				InstallmentPlan:   tt.plan,
+				PaymentWeekday:    &friday,
+				CreatedAt:         createdAt,
+			}
+
+			schedule := service.CalculatePaymentSchedule(debtList, nil)
+			require.Len(t, schedule, 4)
+
+			// The first installment is the first Friday at least a full period out
+			assert.Equal(t, createdAt.AddDate(0, 0, tt.period+4), schedule[0].DueDate)
+			for i, item := range schedule {
+				assert.Equal(t, time.Friday, item.DueDate.Weekday(), "payment %d should fall on a Friday", item.PaymentNumber)
+				if i > 0 {
+					assert.Equal(t, tt.period, int(item.DueDate.Sub(schedule[i-1].DueDate).Hours()/24))
+				}
+			}
+
+			// The next payment after any payment date also falls on a Friday
+			lastPayment := createdAt.AddDate(0, 0, 9)
+			next := service.CalculateNextPaymentDate(debtList, &lastPayment)
+			assert.Equal(t, time.Friday, next.Weekday())
+			assert.False(t, next.Before(lastPayment.AddDate(0, 0, tt.period)))
+		})
+	}
+
+	t.Run("without a weekday dates stay relative to creation", func(t *testing.T) {
+		debtList := &entities.DebtList{
+			ID:                uuid.New(),
+			TotalAmount:       decimal.RequireFromString("200.00"),
+			InstallmentAmount: decimal.RequireFromString("100.00"),
+			InstallmentPlan:   "weekly",
+			CreatedAt:         createdAt,
+		}
+
+		schedule := service.CalculatePaymentSchedule(debtList, nil)
+		require.Len(t, schedule, 2)
+		assert.Equal(t, createdAt.AddDate(0, 0, 7), schedule[0].DueDate)
+		assert.Equal(t, createdAt.AddDate(0, 0, 14), schedule[1].DueDate)
+	})
+}
+
+func TestCalculatePaymentSchedule_PaymentLinkage(t *testing.T) {
+	now := time.Now()
+	debtList := &entities.DebtList{
+		ID:                uuid.New(),
+		TotalAmount:       decimal.RequireFromString("1000.00"),
+		InstallmentAmount: decimal.RequireFromString("250.00"),
+		InstallmentPlan:   "monthly",
+		CreatedAt:         now,
+		DueDate:           now.AddDate(0, 4, 0),
+	}
+
+	first := entities.DebtItem{ID: uuid.New(), Amount: decimal.RequireFromString("100.00"), Status: "completed", PaymentDate: now}
+	second := entities.DebtItem{ID: uuid.New(), Amount: decimal.RequireFromString("300.00"), Status: "completed", PaymentDate: now.AddDate(0, 0, 1)}
+	reversal := entities.DebtItem{ID: uuid.New(), Amount: decimal.RequireFromString("-50.00"), Status: "completed", PaymentDate: now.AddDate(0, 0, 2), ReversalOf: &second.ID}
+	adjustment := entities.DebtItem{ID: uuid.New(), Amount: decimal.RequireFromString("20.00"), Status: "completed", PaymentDate: now.AddDate(0, 0, 3), PaymentType: entities.PaymentTypeAdjustment}
+	pending := entities.DebtItem{ID: uuid.New(), Amount: decimal.RequireFromString("500.00"), Status: "pending", PaymentDate: now}
+
+	// Payments are allocated in payment date order regardless of the order given
+	schedule := services.NewPaymentScheduleService().CalculatePaymentSchedule(debtList, []entities.DebtItem{adjustment, second, pending, reversal, first})
+	require.Len(t, schedule, 4)
+
+	// 100 from the first payment and 150 of the 250 left of the second after its reversal
+	assert.Equal(t, "paid", schedule[0].Status)
+	assert.Equal(t, []uuid.UUID{first.ID, second.ID}, schedule[0].PaymentIDs)
+
+	// The rest of the second payment; the adjustment counts towards the paid amount
+	// without being linked
+	assert.True(t, schedule[1].PaidAmount.Equal(decimal.RequireFromString("120.00")))
+	assert.Equal(t, []uuid.UUID{second.ID}, schedule[1].PaymentIDs)
+
+	for _, item := range schedule[2:] {
+		assert.NotNil(t, item.PaymentIDs)
+		assert.Empty(t, item.PaymentIDs)
+	}
+}
+
+func TestRoundingMode(t *testing.T) {
+	now := time.Now()
+	service := services.NewPaymentScheduleService()
+
+	tests := []struct {
+		name         string
+		total        string
+		roundingMode string
+		installment  string
+		expected     []string
+	}{
+		{name: "first absorbs the remainder", total: "100.00", roundingMode: entities.RoundingModeFirst, installment: "33.33", expected: []string{"33.34", "33.33", "33.33"}},
+		{name: "spread over one installment", total: "100.00", roundingMode: entities.RoundingModeSpread, installment: "33.33", expected: []string{"33.34", "33.33", "33.33"}},
+		{name: "last absorbs the remainder", total: "200.00", roundingMode: entities.RoundingModeLast, installment: "66.67", expected: []string{"66.67", "66.67", "66.66"}},
+		{name: "first with a larger remainder", total: "200.00", roundingMode: entities.RoundingModeFirst, installment: "66.66", expected: []string{"66.68", "66.66", "66.66"}},
+		{name: "spread over the earliest installments", total: "200.00", roundingMode: entities.RoundingModeSpread, installment: "66.66", expected: []string{"66.67", "66.67", "66.66"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			total := decimal.RequireFromString(tt.total)
+			installment := service.CalculateInstallmentAmountFromNumberOfPayments(total, 3, tt.roundingMode).Round(2)
+			assert.True(t, installment.Equal(decimal.RequireFromString(tt.installment)), "installment should be %s, got %s", tt.installment, installment)
+
+			debtList := &entities.DebtList{
+				ID:                uuid.New(),
+				TotalAmount:       total,
+				InstallmentAmount: installment,
+				InstallmentPlan:   "monthly",
+				RoundingMode:      tt.roundingMode,
+				CreatedAt:         now,
+				DueDate:           now.AddDate(0, 3, 0),
+			}
+			schedule := service.CalculatePaymentSchedule(debtList, nil)
+			require.Len(t, schedule, len(tt.expected))
+
+			sum := decimal.Zero
+			for i, item := range schedule {
+				assert.True(t, item.Amount.Equal(decimal.RequireFromString(tt.expected[i])), "installment %d should be %s, got %s", i+1, tt.expected[i], item.Amount)
+				sum = sum.Add(item.Amount)
+			}
+			assert.True(t, sum.Equal(total), "installments should add up to %s, got %s", total, sum)
+		})
+	}
+}
@@ -167,8 +167,8 @@ func TestS3Service_ExtractKeyFromURL(t *testing.T) {
 	}
 }
 
-func TestS3Service_IsValidImageType(t *testing.T) {
-	// Create a minimal S3Service for testing image type validation
+func TestS3Service_IsValidReceiptType(t *testing.T) {
+	// Create a minimal S3Service for testing receipt type validation
 	s3Service := &services.S3Service{}
 
 	tests := []struct {
@@ -188,7 +188,7 @@ func TestS3Service_IsValidImageType(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := s3Service.IsValidImageType(tt.contentType)
+			result := s3Service.IsValidReceiptType(tt.contentType)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
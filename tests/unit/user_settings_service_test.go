@@ -0,0 +1,152 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"pay-your-dues/internal/domain/entities"
+	"pay-your-dues/internal/mocks"
+	"pay-your-dues/internal/services"
+)
+
+func TestUserSettingsService_GetUserSettings_Defaults(t *testing.T) {
+	userID := uuid.New()
+
+	settingsRepo := &mocks.MockUserSettingsRepository{}
+	settingsRepo.On("GetByUserID", mock.Anything, userID).Return(nil, entities.ErrUserSettingsNotFound)
+
+	settingsService := services.NewUserSettingsService(settingsRepo)
+
+	settings, err := settingsService.GetUserSettings(context.Background(), userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, userID, settings.UserID)
+	assert.Equal(t, "PHP", settings.DefaultCurrency)
+	assert.Equal(t, "onetime", settings.DefaultInstallmentPlan)
+	settingsRepo.AssertExpectations(t)
+}
+
+func TestUserSettingsService_UpdateUserSettings(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name          string
+		request       *entities.UpdateUserSettingsRequest
+		setupMocks    func(*mocks.MockUserSettingsRepository)
+		expectedError error
+		expectedPlan  string
+	}{
+		{
+			name: "set default installment plan",
+			request: &entities.UpdateUserSettingsRequest{
+				DefaultInstallmentPlan: stringPtr("monthly"),
+			},
+			setupMocks: func(settingsRepo *mocks.MockUserSettingsRepository) {
+				settingsRepo.On("GetByUserID", mock.Anything, userID).Return(entities.NewDefaultUserSettings(userID), nil)
+				settingsRepo.On("Upsert", mock.Anything, mock.MatchedBy(func(settings *entities.UserSettings) bool {
+					return settings.UserID == userID && settings.DefaultInstallmentPlan == "monthly"
+				})).Return(nil)
+			},
+			expectedPlan: "monthly",
+		},
+		{
+			name: "reject unknown installment plan",
+			request: &entities.UpdateUserSettingsRequest{
+				DefaultInstallmentPlan: stringPtr("fortnightly"),
+			},
+			setupMocks: func(settingsRepo *mocks.MockUserSettingsRepository) {
+				settingsRepo.On("GetByUserID", mock.Anything, userID).Return(nil, entities.ErrUserSettingsNotFound)
+			},
+			expectedError: entities.ErrInvalidInstallmentPlan,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			settingsRepo := &mocks.MockUserSettingsRepository{}
+			tt.setupMocks(settingsRepo)
+
+			settingsService := services.NewUserSettingsService(settingsRepo)
+
+			// Execute
+			settings, err := settingsService.UpdateUserSettings(context.Background(), userID, tt.request)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, settings)
+				settingsRepo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedPlan, settings.DefaultInstallmentPlan)
+			}
+
+			// Verify mock expectations
+			settingsRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserSettingsService_UpdateNotificationPreferences(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("defaults enable every notification", func(t *testing.T) {
+		preferences := entities.NewDefaultUserSettings(userID).ToNotificationPreferences()
+
+		assert.Equal(t, entities.NotificationPreferences{
+			EmailReminders:     true,
+			ReminderLeadDays:   3,
+			OverdueAlerts:      true,
+			VerificationAlerts: true,
+		}, preferences)
+	})
+
+	t.Run("only provided preferences change", func(t *testing.T) {
+		settingsRepo := &mocks.MockUserSettingsRepository{}
+		saved := entities.NewDefaultUserSettings(userID)
+		saved.DefaultInstallmentPlan = "monthly"
+		settingsRepo.On("GetByUserID", mock.Anything, userID).Return(saved, nil)
+		settingsRepo.On("Upsert", mock.Anything, mock.MatchedBy(func(settings *entities.UserSettings) bool {
+			return settings.UserID == userID && !settings.EmailReminders && settings.ReminderLeadDays == 0
+		})).Return(nil)
+
+		settingsService := services.NewUserSettingsService(settingsRepo)
+
+		falseValue, zero := false, 0
+		settings, err := settingsService.UpdateNotificationPreferences(context.Background(), userID, &entities.UpdateNotificationPreferencesRequest{
+			EmailReminders:   &falseValue,
+			ReminderLeadDays: &zero,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.NotificationPreferences{
+			EmailReminders:     false,
+			ReminderLeadDays:   0,
+			OverdueAlerts:      true,
+			VerificationAlerts: true,
+		}, settings.ToNotificationPreferences())
+		assert.Equal(t, "monthly", settings.DefaultInstallmentPlan)
+		settingsRepo.AssertExpectations(t)
+	})
+
+	t.Run("reject lead days out of range", func(t *testing.T) {
+		settingsRepo := &mocks.MockUserSettingsRepository{}
+		settingsRepo.On("GetByUserID", mock.Anything, userID).Return(nil, entities.ErrUserSettingsNotFound)
+
+		settingsService := services.NewUserSettingsService(settingsRepo)
+
+		leadDays := entities.MaxReminderLeadDays + 1
+		settings, err := settingsService.UpdateNotificationPreferences(context.Background(), userID, &entities.UpdateNotificationPreferencesRequest{
+			ReminderLeadDays: &leadDays,
+		})
+
+		assert.ErrorIs(t, err, entities.ErrInvalidReminderLeadDays)
+		assert.Nil(t, settings)
+		settingsRepo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,116 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pay-your-dues/internal/workers"
+)
+
+func TestWorkerManager_StopDrainsWorkersWithinDeadline(t *testing.T) {
+	shutdownTimeout := 500 * time.Millisecond
+	manager := workers.NewManager(zerolog.Nop(), shutdownTimeout)
+
+	var started, stopped int32
+	for _, name := range []string{"reminders", "webhooks", "verification-expiry"} {
+		manager.Register(workers.NewFuncWorker(name, func(ctx context.Context) error {
+			atomic.AddInt32(&started, 1)
+			<-ctx.Done()
+			// Simulate finishing the current unit of work
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt32(&stopped, 1)
+			return ctx.Err()
+		}))
+	}
+	manager.Register(workers.NewFuncWorker("failing", func(ctx context.Context) error {
+		return errors.New("boom")
+	}))
+
+	manager.Start(context.Background())
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&started) == 3 }, time.Second, 5*time.Millisecond)
+
+	begin := time.Now()
+	err := manager.Stop()
+
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(begin), shutdownTimeout)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&stopped))
+}
+
+func TestWorkerManager_StopTimesOutOnStuckWorker(t *testing.T) {
+	shutdownTimeout := 50 * time.Millisecond
+	manager := workers.NewManager(zerolog.Nop(), shutdownTimeout)
+
+	release := make(chan struct{})
+	defer close(release)
+	manager.Register(workers.NewFuncWorker("stuck", func(ctx context.Context) error {
+		<-release
+		return nil
+	}))
+
+	manager.Start(context.Background())
+
+	begin := time.Now()
+	err := manager.Stop()
+
+	assert.ErrorIs(t, err, workers.ErrShutdownTimeout)
+	assert.GreaterOrEqual(t, time.Since(begin), shutdownTimeout)
+	assert.Less(t, time.Since(begin), 10*shutdownTimeout)
+}
+
+func TestWorkerManager_ParentContextCancelsWorkers(t *testing.T) {
+	manager := workers.NewManager(zerolog.Nop(), time.Second)
+
+	done := make(chan struct{})
+	manager.Register(workers.NewFuncWorker("listener", func(ctx context.Context) error {
+		<-ctx.Done()
+		close(done)
+		return nil
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	manager.Start(ctx)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker was not cancelled with its parent context")
+	}
+	assert.NoError(t, manager.Stop())
+}
+
+func TestWorkerManager_StopWithoutStart(t *testing.T) {
+	manager := workers.NewManager(zerolog.Nop(), time.Second)
+	assert.NoError(t, manager.Stop())
+}
+
+func TestPeriodicWorker_KeepsRunningAfterFailedTask(t *testing.T) {
+	var runs int32
+	worker := workers.NewPeriodicWorker("cleanup", 5*time.Millisecond, zerolog.Nop(), func(ctx context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		return errors.New("storage unavailable")
+	})
+	assert.Equal(t, "cleanup", worker.Name())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- worker.Run(ctx) }()
+
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&runs) >= 3 }, time.Second, 5*time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("periodic worker did not stop when cancelled")
+	}
+}